@@ -56,9 +56,17 @@ const helpTexts: Record<ExternalServiceKind, JSX.Element> = {
             with <code>write</code> permissions on the project and repository level.
         </>
     ),
+    [ExternalServiceKind.BITBUCKETCLOUD]: (
+        <>
+            <a href={HELP_TEXT_LINK_URL} rel="noreferrer noopener" target="_blank">
+                Create a new app password
+            </a>{' '}
+            with <code>account:read</code>, <code>repository:write</code>, <code>pullrequest:write</code>, and{' '}
+            <code>pipeline:read</code> permissions.
+        </>
+    ),
 
     // These are just for type completeness and serve as placeholders for a bright future.
-    [ExternalServiceKind.BITBUCKETCLOUD]: <span>Unsupported</span>,
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
     [ExternalServiceKind.JVMPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.PERFORCE]: <span>Unsupported</span>,
@@ -82,6 +90,7 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
     const labelId = 'addCredential'
     const [isLoading, setIsLoading] = useState<boolean | Error>(false)
     const [credential, setCredential] = useState<string>('')
+    const [username, setUsername] = useState<string>('')
     const [sshPublicKey, setSSHPublicKey] = useState<string>()
     const [step, setStep] = useState<Step>(initialStep)
 
@@ -89,6 +98,13 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
         setCredential(event.target.value)
     }, [])
 
+    // Bitbucket Cloud app passwords can only be used together with the
+    // username of the account they belong to.
+    const requiresUsername = externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD
+    const onChangeUsername = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setUsername(event.target.value)
+    }, [])
+
     const onSubmit = useCallback<React.FormEventHandler>(
         async event => {
             event.preventDefault()
@@ -96,6 +112,7 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
             try {
                 const createdCredential = await createBatchChangesCredential({
                     user: userID,
+                    username: requiresUsername ? username : null,
                     credential,
                     externalServiceKind,
                     externalServiceURL,
@@ -113,6 +130,8 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
         [
             afterCreate,
             userID,
+            requiresUsername,
+            username,
             credential,
             externalServiceKind,
             externalServiceURL,
@@ -164,8 +183,24 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
                     <>
                         {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
                         <Form onSubmit={onSubmit}>
+                            {requiresUsername && (
+                                <div className="form-group">
+                                    <label htmlFor="username">Username</label>
+                                    <input
+                                        id="username"
+                                        name="username"
+                                        type="text"
+                                        className="form-control test-add-credential-modal-username"
+                                        required={true}
+                                        spellCheck="false"
+                                        minLength={1}
+                                        value={username}
+                                        onChange={onChangeUsername}
+                                    />
+                                </div>
+                            )}
                             <div className="form-group">
-                                <label htmlFor="token">Personal access token</label>
+                                <label htmlFor="token">{requiresUsername ? 'App password' : 'Personal access token'}</label>
                                 <input
                                     id="token"
                                     name="token"
@@ -190,7 +225,11 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
                                 </button>
                                 <button
                                     type="submit"
-                                    disabled={isLoading === true || credential.length === 0}
+                                    disabled={
+                                        isLoading === true ||
+                                        credential.length === 0 ||
+                                        (requiresUsername && username.length === 0)
+                                    }
                                     className="btn btn-primary test-add-credential-modal-submit"
                                 >
                                     {isLoading === true && <LoadingSpinner className="icon-inline" />}
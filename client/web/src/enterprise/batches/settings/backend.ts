@@ -33,12 +33,14 @@ export function createBatchChangesCredential(
         gql`
             mutation CreateBatchChangesCredential(
                 $user: ID
+                $username: String
                 $credential: String!
                 $externalServiceKind: ExternalServiceKind!
                 $externalServiceURL: String!
             ) {
                 createBatchChangesCredential(
                     user: $user
+                    username: $username
                     credential: $credential
                     externalServiceKind: $externalServiceKind
                     externalServiceURL: $externalServiceURL
@@ -123,6 +123,7 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		"/.api/github-webhooks",
 		"/.api/gitlab-webhooks",
 		"/.api/bitbucket-server-webhooks",
+		"/.api/bitbucket-cloud-webhooks",
 	} {
 		if strings.HasPrefix(req.URL.Path, prefix) {
 			return true
@@ -134,6 +135,11 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		return true
 	}
 
+	// Permission is checked by the SCIM bearer token
+	if strings.HasPrefix(req.URL.Path, "/.api/scim/") {
+		return true
+	}
+
 	apiRouteName := matchedRouteName(req, router.Router())
 	if apiRouteName == router.UI {
 		// Test against UI router. (Some of its handlers inject private data into the title or meta tags.)
@@ -16,6 +16,8 @@ type AuthzResolver interface {
 	AuthorizedUserRepositories(ctx context.Context, args *AuthorizedRepoArgs) (RepositoryConnectionResolver, error)
 	UsersWithPendingPermissions(ctx context.Context) ([]string, error)
 	AuthorizedUsers(ctx context.Context, args *RepoAuthorizedUserArgs) (UserConnectionResolver, error)
+	RepositoryPermissionExplanation(ctx context.Context, args *RepositoryPermissionExplanationArgs) (RepositoryPermissionExplanationResolver, error)
+	PermissionsSyncJobs(ctx context.Context, args *PermissionsSyncJobsArgs) ([]PermissionsSyncJobResolver, error)
 
 	// Helpers
 	RepositoryPermissionsInfo(ctx context.Context, repoID graphql.ID) (PermissionsInfoResolver, error)
@@ -51,3 +53,45 @@ type PermissionsInfoResolver interface {
 	SyncedAt() *DateTime
 	UpdatedAt() DateTime
 }
+
+type RepositoryPermissionExplanationArgs struct {
+	User       graphql.ID
+	Repository graphql.ID
+}
+
+type PermissionsSyncJobsArgs struct {
+	User       *graphql.ID
+	Repository *graphql.ID
+	First      int32
+}
+
+type RepositoryPermissionExplanationResolver interface {
+	User() *UserResolver
+	Repository() *RepositoryResolver
+	HasAccess() bool
+	Reasons() []RepositoryPermissionReasonResolver
+	GrantedBy(ctx context.Context) (PermissionsSyncJobResolver, error)
+}
+
+type RepositoryPermissionReasonResolver interface {
+	Type() string
+	Description() string
+	Provider() *string
+	ExternalAccount() ExternalAccountResolver
+	ExternalServices() *[]graphql.ID
+	UpdatedAt() *DateTime
+}
+
+type PermissionsSyncJobResolver interface {
+	ID() graphql.ID
+	Type() string
+	User(ctx context.Context) (*UserResolver, error)
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	Providers() []string
+	StartedAt() DateTime
+	FinishedAt() DateTime
+	DurationMilliseconds() int32
+	Added() []graphql.ID
+	Removed() []graphql.ID
+	FailureMessage() *string
+}
@@ -136,6 +136,21 @@ type MoveBatchChangeArgs struct {
 	NewNamespace *graphql.ID
 }
 
+type SetBatchChangeAutoMergeStrategyArgs struct {
+	BatchChange graphql.ID
+	Strategy    *string
+}
+
+type SetBatchChangeContinuousExecutionArgs struct {
+	BatchChange   graphql.ID
+	IntervalHours *int32
+	ApplyPolicy   *string
+}
+
+type ListBatchChangeContinuousRunsArgs struct {
+	First int32
+}
+
 type DeleteBatchChangeArgs struct {
 	BatchChange graphql.ID
 }
@@ -188,6 +203,7 @@ type CreateBatchChangesCredentialArgs struct {
 	ExternalServiceKind string
 	ExternalServiceURL  string
 	User                *graphql.ID
+	Username            *string
 	Credential          string
 }
 
@@ -241,6 +257,46 @@ type CreateBatchSpecExecutionArgs struct {
 	Namespace *graphql.ID
 }
 
+type BatchSpecTemplateParameterInput struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	Default     *JSONValue
+}
+
+type CreateBatchSpecTemplateArgs struct {
+	Namespace   graphql.ID
+	Name        string
+	Description string
+	Spec        string
+	Parameters  []BatchSpecTemplateParameterInput
+}
+
+type UpdateBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+	Description       *string
+	Spec              string
+	Parameters        []BatchSpecTemplateParameterInput
+}
+
+type DeleteBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+}
+
+type ExecuteBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+	Version           *int32
+	Parameters        *JSONValue
+	Namespace         *graphql.ID
+}
+
+type ListBatchSpecTemplatesArgs struct {
+	Namespace *graphql.ID
+	First     int32
+	After     *string
+}
+
 type CloseChangesetsArgs struct {
 	BulkOperationBaseArgs
 }
@@ -269,6 +325,8 @@ type BatchChangesResolver interface {
 	ApplyBatchChange(ctx context.Context, args *ApplyBatchChangeArgs) (BatchChangeResolver, error)
 	CloseBatchChange(ctx context.Context, args *CloseBatchChangeArgs) (BatchChangeResolver, error)
 	MoveBatchChange(ctx context.Context, args *MoveBatchChangeArgs) (BatchChangeResolver, error)
+	SetBatchChangeAutoMergeStrategy(ctx context.Context, args *SetBatchChangeAutoMergeStrategyArgs) (BatchChangeResolver, error)
+	SetBatchChangeContinuousExecution(ctx context.Context, args *SetBatchChangeContinuousExecutionArgs) (BatchChangeResolver, error)
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
 	DeleteBatchChangesCredential(ctx context.Context, args *DeleteBatchChangesCredentialArgs) (*EmptyResponse, error)
@@ -283,6 +341,10 @@ type BatchChangesResolver interface {
 	CreateBatchSpecExecution(ctx context.Context, args *CreateBatchSpecExecutionArgs) (BatchSpecExecutionResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	CreateBatchSpecTemplate(ctx context.Context, args *CreateBatchSpecTemplateArgs) (BatchSpecTemplateResolver, error)
+	UpdateBatchSpecTemplate(ctx context.Context, args *UpdateBatchSpecTemplateArgs) (BatchSpecTemplateResolver, error)
+	DeleteBatchSpecTemplate(ctx context.Context, args *DeleteBatchSpecTemplateArgs) (*EmptyResponse, error)
+	ExecuteBatchSpecTemplate(ctx context.Context, args *ExecuteBatchSpecTemplateArgs) (BatchSpecExecutionResolver, error)
 
 	// Queries
 
@@ -297,6 +359,8 @@ type BatchChangesResolver interface {
 	BatchChangesCodeHosts(ctx context.Context, args *ListBatchChangesCodeHostsArgs) (BatchChangesCodeHostConnectionResolver, error)
 	RepoChangesetsStats(ctx context.Context, repo *graphql.ID) (RepoChangesetsStatsResolver, error)
 	RepoDiffStat(ctx context.Context, repo *graphql.ID) (*DiffStat, error)
+	BatchSpecTemplates(ctx context.Context, args *ListBatchSpecTemplatesArgs) (BatchSpecTemplateConnectionResolver, error)
+	BatchChangesReport(ctx context.Context, args *BatchChangesReportArgs) (BatchChangesReportResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -378,6 +442,24 @@ type VisibleChangesetApplyPreviewResolver interface {
 	Operations(ctx context.Context) ([]string, error)
 	Delta(ctx context.Context) (ChangesetSpecDeltaResolver, error)
 	Targets() VisibleApplyPreviewTargetsResolver
+	Dependencies(ctx context.Context) ([]ChangesetApplyPreviewDependencyResolver, error)
+	BlockedByDependencies(ctx context.Context) (bool, error)
+	Conflicts(ctx context.Context) ([]ChangesetApplyPreviewConflictResolver, error)
+	BlockedByConflicts(ctx context.Context) (bool, error)
+}
+
+type ChangesetApplyPreviewConflictResolver interface {
+	Changeset() ChangesetResolver
+	BatchChange(ctx context.Context) (BatchChangeResolver, error)
+	Paths() []string
+	HunksOverlap() bool
+}
+
+type ChangesetApplyPreviewDependencyResolver interface {
+	Repository() *string
+	Branch() *string
+	ChangesetSpecs() []VisibleChangesetSpecResolver
+	Merged() bool
 }
 
 type HiddenChangesetApplyPreviewResolver interface {
@@ -482,6 +564,7 @@ type ChangesetSpecDeltaResolver interface {
 	CommitMessageChanged() bool
 	AuthorNameChanged() bool
 	AuthorEmailChanged() bool
+	MetadataChanged() bool
 }
 
 type ChangesetDescription interface {
@@ -511,6 +594,12 @@ type GitBranchChangesetDescriptionResolver interface {
 	Commits() []GitCommitDescriptionResolver
 
 	Published() *batches.PublishedValue
+
+	Reviewers() []string
+	TeamReviewers() []string
+	Labels() []string
+	Assignees() []string
+	Milestone() *string
 }
 
 type GitCommitDescriptionResolver interface {
@@ -549,6 +638,21 @@ type ChangesetCountsArgs struct {
 	IncludeArchived bool
 }
 
+type BatchChangeReportArgs struct {
+	From            *DateTime
+	To              *DateTime
+	StaleAfterDays  int32
+	MergeSLADays    int32
+	IncludeArchived bool
+}
+
+type BatchChangesReportArgs struct {
+	From           *DateTime
+	To             *DateTime
+	StaleAfterDays int32
+	MergeSLADays   int32
+}
+
 type ListChangesetsArgs struct {
 	First int32
 	After *string
@@ -590,10 +694,15 @@ type BatchChangeResolver interface {
 	ChangesetsStats(ctx context.Context) (ChangesetsStatsResolver, error)
 	Changesets(ctx context.Context, args *ListChangesetsArgs) (ChangesetsConnectionResolver, error)
 	ChangesetCountsOverTime(ctx context.Context, args *ChangesetCountsArgs) ([]ChangesetCountsResolver, error)
+	Report(ctx context.Context, args *BatchChangeReportArgs) (BatchChangesReportResolver, error)
 	ClosedAt() *DateTime
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
+	AutoMergeStrategy() *string
+	ContinuousIntervalHours() *int32
+	ContinuousApplyPolicy() *string
+	ContinuousRuns(ctx context.Context, args *ListBatchChangeContinuousRunsArgs) ([]BatchChangeContinuousRunResolver, error)
 
 	// TODO(campaigns-deprecation): This should be removed once we remove batches.
 	// It's here so that in the NodeResolver we can have the same resolver,
@@ -601,6 +710,14 @@ type BatchChangeResolver interface {
 	ActAsCampaign() bool
 }
 
+type BatchChangeContinuousRunResolver interface {
+	State() string
+	Message() *string
+	BatchSpecExecution(ctx context.Context) (BatchSpecExecutionResolver, error)
+	CreatedAt() DateTime
+	FinishedAt() *DateTime
+}
+
 type BatchChangesConnectionResolver interface {
 	Nodes(ctx context.Context) ([]BatchChangeResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
@@ -700,6 +817,7 @@ type ExternalChangesetResolver interface {
 
 	Error() *string
 	SyncerError() *string
+	NeedsReexecution() bool
 	ScheduleEstimateAt(ctx context.Context) (*DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
@@ -715,6 +833,15 @@ type ChangesetEventResolver interface {
 	ID() graphql.ID
 	Changeset() ExternalChangesetResolver
 	CreatedAt() DateTime
+	AutoMergeDecision() ChangesetAutoMergeDecisionResolver
+}
+
+type ChangesetAutoMergeDecisionResolver interface {
+	Merged() bool
+	Strategy() string
+	SkipReason() *string
+	Message() *string
+	DecidedAt() DateTime
 }
 
 type ChangesetCountsResolver interface {
@@ -727,6 +854,51 @@ type ChangesetCountsResolver interface {
 	OpenApproved() int32
 	OpenChangesRequested() int32
 	OpenPending() int32
+	OpenChecksPending() int32
+	OpenChecksPassed() int32
+	OpenChecksFailed() int32
+}
+
+type BatchChangesReportResolver interface {
+	CountsOverTime(ctx context.Context) ([]ChangesetCountsResolver, error)
+	Totals(ctx context.Context) (ChangesetsBreakdownResolver, error)
+	ByCodeHost(ctx context.Context) ([]ChangesetsBreakdownResolver, error)
+	ByOrganization(ctx context.Context) ([]ChangesetsBreakdownResolver, error)
+	StaleChangesets(ctx context.Context) ([]ChangesetResolver, error)
+	ChangesetsCSV(ctx context.Context) (string, error)
+	CountsOverTimeCSV(ctx context.Context) (string, error)
+}
+
+type ChangesetsBreakdownResolver interface {
+	Key() string
+	Total() int32
+	Open() int32
+	Draft() int32
+	Merged() int32
+	Closed() int32
+	OpenApproved() int32
+	OpenChangesRequested() int32
+	OpenPending() int32
+	OpenChecksPending() int32
+	OpenChecksPassed() int32
+	OpenChecksFailed() int32
+	Stale() int32
+	SLABreaches() int32
+	TimeToMerge() TimeToMergeDistributionResolver
+}
+
+type TimeToMergeDistributionResolver interface {
+	Count() int32
+	MeanSeconds() *int32
+	MedianSeconds() *int32
+	P90Seconds() *int32
+	MaxSeconds() *int32
+	Buckets() []TimeToMergeBucketResolver
+}
+
+type TimeToMergeBucketResolver interface {
+	MaxSeconds() *int32
+	Count() int32
 }
 
 type BatchSpecExecutionResolver interface {
@@ -742,6 +914,57 @@ type BatchSpecExecutionResolver interface {
 	BatchSpec(ctx context.Context) (BatchSpecResolver, error)
 	Initiator(ctx context.Context) (*UserResolver, error)
 	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	Workspaces(ctx context.Context) ([]BatchSpecWorkspaceExecutionResolver, error)
+}
+
+type BatchSpecTemplateResolver interface {
+	ID() graphql.ID
+	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	Name() string
+	Description() string
+	Creator(ctx context.Context) (*UserResolver, error)
+	LatestVersion(ctx context.Context) (BatchSpecTemplateVersionResolver, error)
+	Versions(ctx context.Context) ([]BatchSpecTemplateVersionResolver, error)
+	Version(ctx context.Context, args *struct{ Version int32 }) (BatchSpecTemplateVersionResolver, error)
+	ViewerCanAdminister(ctx context.Context) (bool, error)
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
+
+type BatchSpecTemplateVersionResolver interface {
+	Version() int32
+	Spec() string
+	Parameters() []BatchSpecTemplateParameterResolver
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	RenderSpec(args *struct{ Parameters *JSONValue }) (string, error)
+}
+
+type BatchSpecTemplateParameterResolver interface {
+	Name() string
+	Type() string
+	Description() string
+	Required() bool
+	Default() *JSONValue
+}
+
+type BatchSpecTemplateConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]BatchSpecTemplateResolver, error)
+}
+
+type BatchSpecWorkspaceExecutionResolver interface {
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	Branch() string
+	Commit() string
+	Path() string
+	State() string
+	StartedAt() *DateTime
+	FinishedAt() *DateTime
+	Failure() *string
+	ExecutionLogs() []ExecutionLogEntryResolver
+	ChangesetSpecs(ctx context.Context) ([]ChangesetSpecResolver, error)
 }
 
 type BatchSpecExecutionStepsResolver interface {
@@ -24,6 +24,14 @@ type CodeIntelResolver interface {
 	CommitGraph(ctx context.Context, id graphql.ID) (CodeIntelligenceCommitGraphResolver, error)
 	QueueAutoIndexJobForRepo(ctx context.Context, args *struct{ Repository graphql.ID }) (*EmptyResponse, error)
 	GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error)
+	CodeIntelligenceRetentionPolicyByID(ctx context.Context, id graphql.ID) (CodeIntelligenceRetentionPolicyResolver, error)
+	CodeIntelligenceRetentionPolicies(ctx context.Context) ([]CodeIntelligenceRetentionPolicyResolver, error)
+	CreateCodeIntelligenceRetentionPolicy(ctx context.Context, args *CodeIntelligenceRetentionPolicyArgs) (CodeIntelligenceRetentionPolicyResolver, error)
+	UpdateCodeIntelligenceRetentionPolicy(ctx context.Context, args *UpdateCodeIntelligenceRetentionPolicyArgs) (CodeIntelligenceRetentionPolicyResolver, error)
+	DeleteCodeIntelligenceRetentionPolicy(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error)
+	CodeIntelligenceDiagnostics(ctx context.Context, args *CodeIntelligenceDiagnosticsArgs) (DiagnosticConnectionResolver, error)
+	CodeIntelligencePackageDependencies(ctx context.Context, args *CodeIntelligencePackageDependenciesArgs) (CodeIntelligencePackageDependencyConnectionResolver, error)
+	CodeIntelligencePackageDependents(ctx context.Context, args *CodeIntelligencePackageDependentsArgs) (CodeIntelligencePackageDependentConnectionResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -55,6 +63,20 @@ type LSIFUploadResolver interface {
 	PlaceInQueue() *int32
 	AssociatedIndex(ctx context.Context) (LSIFIndexResolver, error)
 	ProjectRoot(ctx context.Context) (*GitTreeEntryResolver, error)
+	Retention(ctx context.Context) (LSIFUploadRetentionResolver, error)
+}
+
+type LSIFUploadRetentionResolver interface {
+	Retained() bool
+	GovernedByPolicies() bool
+	ExpiresAt() *DateTime
+	Reasons() []LSIFUploadRetentionReasonResolver
+}
+
+type LSIFUploadRetentionReasonResolver interface {
+	Policy() CodeIntelligenceRetentionPolicyResolver
+	RefName() string
+	ExpiresAt() *DateTime
 }
 
 type LSIFUploadConnectionResolver interface {
@@ -127,6 +149,83 @@ type UpdateRepositoryIndexConfigurationArgs struct {
 	Configuration string
 }
 
+type CodeIntelligenceRetentionPolicyResolver interface {
+	ID() graphql.ID
+	Name() string
+	RepositoryPattern() *string
+	Type() string
+	Pattern() string
+	RetentionDurationHours() *int32
+	RetainCommitCount() *int32
+}
+
+type CodeIntelligenceRetentionPolicyArgs struct {
+	Name                   string
+	RepositoryPattern      *string
+	Type                   string
+	Pattern                string
+	RetentionDurationHours *int32
+	RetainCommitCount      *int32
+}
+
+type UpdateCodeIntelligenceRetentionPolicyArgs struct {
+	ID graphql.ID
+	CodeIntelligenceRetentionPolicyArgs
+}
+
+type CodeIntelligencePackageDependenciesArgs struct {
+	graphqlutil.ConnectionArgs
+	Repository        graphql.ID
+	Transitive        bool
+	MaxDepth          *int32
+	Scheme            *string
+	Name              *string
+	Version           *string
+	VersionConstraint *string
+	After             *string
+}
+
+type CodeIntelligencePackageDependentsArgs struct {
+	graphqlutil.ConnectionArgs
+	Scheme            string
+	Name              string
+	Version           *string
+	VersionConstraint *string
+	Transitive        bool
+	MaxDepth          *int32
+	After             *string
+}
+
+type CodeIntelligencePackageResolver interface {
+	Scheme() string
+	Name() string
+	Version() string
+}
+
+type CodeIntelligencePackageDependencyResolver interface {
+	Package() CodeIntelligencePackageResolver
+	Depth() int32
+	Providers(ctx context.Context) ([]*RepositoryResolver, error)
+}
+
+type CodeIntelligencePackageDependencyConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CodeIntelligencePackageDependencyResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type CodeIntelligencePackageDependentResolver interface {
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	Package() CodeIntelligencePackageResolver
+	Depth() int32
+}
+
+type CodeIntelligencePackageDependentConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CodeIntelligencePackageDependentResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
 type QueueAutoIndexJobArgs struct {
 	Repository graphql.ID
 }
@@ -152,6 +251,8 @@ type GitBlobLSIFDataResolver interface {
 	Ranges(ctx context.Context, args *LSIFRangesArgs) (CodeIntelligenceRangeConnectionResolver, error)
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	TypeDefinitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	Documentation(ctx context.Context, args *LSIFQueryPositionArgs) (DocumentationResolver, error)
 }
@@ -162,6 +263,10 @@ type GitBlobLSIFDataArgs struct {
 	Path      string
 	ExactPath bool
 	ToolName  string
+
+	// SearchBasedFallback, if set, falls back to search-based definitions
+	// and references when there is no precise data.
+	SearchBasedFallback bool
 }
 
 type LSIFRangesArgs struct {
@@ -194,6 +299,16 @@ type LSIFDiagnosticsArgs struct {
 	graphqlutil.ConnectionArgs
 }
 
+type CodeIntelligenceDiagnosticsArgs struct {
+	graphqlutil.ConnectionArgs
+	Repository *graphql.ID
+	Severity   *string
+	Source     *string
+	Code       *string
+	Query      *string
+	After      *string
+}
+
 type CodeIntelligenceRangeConnectionResolver interface {
 	Nodes(ctx context.Context) ([]CodeIntelligenceRangeResolver, error)
 }
@@ -209,6 +324,7 @@ type CodeIntelligenceRangeResolver interface {
 type LocationConnectionResolver interface {
 	Nodes(ctx context.Context) ([]LocationResolver, error)
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Precise() bool
 }
 
 type HoverResolver interface {
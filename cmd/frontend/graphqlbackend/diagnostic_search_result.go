@@ -0,0 +1,112 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// DiagnosticSearchResultResolver is a resolver for the GraphQL type `DiagnosticSearchResult`
+type DiagnosticSearchResultResolver struct {
+	result.DiagnosticMatch
+
+	RepoResolver *RepositoryResolver
+	db           dbutil.DB
+}
+
+func (r *DiagnosticSearchResultResolver) File() *GitTreeEntryResolver {
+	return &GitTreeEntryResolver{
+		db: r.db,
+		commit: &GitCommitResolver{
+			db:           r.db,
+			repoResolver: r.RepoResolver,
+			oid:          GitObjectID(r.CommitID),
+		},
+		stat: CreateFileInfo(r.Path, false),
+	}
+}
+
+func (r *DiagnosticSearchResultResolver) Repository() *RepositoryResolver {
+	return r.RepoResolver
+}
+
+func (r *DiagnosticSearchResultResolver) Diagnostics() []DiagnosticResolver {
+	file := r.File()
+
+	resolvers := make([]DiagnosticResolver, 0, len(r.DiagnosticMatch.Diagnostics))
+	for _, d := range r.DiagnosticMatch.Diagnostics {
+		resolvers = append(resolvers, &diagnosticMatchResolver{file: file, diagnostic: d})
+	}
+	return resolvers
+}
+
+func (r *DiagnosticSearchResultResolver) LimitHit() bool {
+	return r.DiagnosticMatch.LimitHit
+}
+
+func (r *DiagnosticSearchResultResolver) ToRepository() (*RepositoryResolver, bool) {
+	return nil, false
+}
+
+func (r *DiagnosticSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool) {
+	return nil, false
+}
+
+func (r *DiagnosticSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+
+func (r *DiagnosticSearchResultResolver) ToDiagnosticSearchResult() (*DiagnosticSearchResultResolver, bool) {
+	return r, true
+}
+
+func (r *DiagnosticSearchResultResolver) ResultCount() int32 {
+	return int32(r.DiagnosticMatch.ResultCount())
+}
+
+// diagnosticMatchResolver resolves a single diagnostic of a diagnostic search result.
+type diagnosticMatchResolver struct {
+	file       *GitTreeEntryResolver
+	diagnostic result.Diagnostic
+}
+
+var _ DiagnosticResolver = &diagnosticMatchResolver{}
+
+func (r *diagnosticMatchResolver) Severity() (*string, error) {
+	severity, ok := r.diagnostic.SeverityName()
+	if !ok {
+		return nil, errors.Errorf("unknown diagnostic severity %d", r.diagnostic.Severity)
+	}
+
+	return &severity, nil
+}
+
+func (r *diagnosticMatchResolver) Code() (*string, error) {
+	return nonEmptyStrPtr(r.diagnostic.Code), nil
+}
+
+func (r *diagnosticMatchResolver) Source() (*string, error) {
+	return nonEmptyStrPtr(r.diagnostic.Source), nil
+}
+
+func (r *diagnosticMatchResolver) Message() (*string, error) {
+	return nonEmptyStrPtr(r.diagnostic.Message), nil
+}
+
+func (r *diagnosticMatchResolver) Location(ctx context.Context) (LocationResolver, error) {
+	return NewLocationResolver(r.file, &lsp.Range{
+		Start: lsp.Position{Line: r.diagnostic.StartLine, Character: r.diagnostic.StartCharacter},
+		End:   lsp.Position{Line: r.diagnostic.EndLine, Character: r.diagnostic.EndCharacter},
+	}), nil
+}
+
+func nonEmptyStrPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -13,6 +13,23 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
+type ExternalAccountResolver interface {
+	ID() graphql.ID
+	User(ctx context.Context) (*UserResolver, error)
+	ServiceType() string
+	ServiceID() string
+	ClientID() string
+	AccountID() string
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+	RefreshURL() *string
+	AccountData(ctx context.Context) (*JSONValue, error)
+}
+
+func NewExternalAccountResolver(db dbutil.DB, account extsvc.Account) *externalAccountResolver {
+	return &externalAccountResolver{db: db, account: account}
+}
+
 type externalAccountResolver struct {
 	db      dbutil.DB
 	account extsvc.Account
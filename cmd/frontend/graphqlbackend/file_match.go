@@ -80,6 +80,9 @@ func (fm *FileMatchResolver) ToFileMatch() (*FileMatchResolver, bool)   { return
 func (fm *FileMatchResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (fm *FileMatchResolver) ToDiagnosticSearchResult() (*DiagnosticSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (fm *FileMatchResolver) ResultCount() int32 {
 	return int32(fm.FileMatch.ResultCount())
@@ -239,6 +239,11 @@ func (r *NodeResolver) ToLSIFIndex() (LSIFIndexResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToCodeIntelligenceRetentionPolicy() (CodeIntelligenceRetentionPolicyResolver, bool) {
+	n, ok := r.Node.(CodeIntelligenceRetentionPolicyResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToOutOfBandMigration() (*outOfBandMigrationResolver, bool) {
 	n, ok := r.Node.(*outOfBandMigrationResolver)
 	return n, ok
@@ -253,3 +258,8 @@ func (r *NodeResolver) ToBatchSpecExecution() (BatchSpecExecutionResolver, bool)
 	n, ok := r.Node.(BatchSpecExecutionResolver)
 	return n, ok
 }
+
+func (r *NodeResolver) ToBatchSpecTemplate() (BatchSpecTemplateResolver, bool) {
+	n, ok := r.Node.(BatchSpecTemplateResolver)
+	return n, ok
+}
@@ -306,6 +306,9 @@ func (r *RepositoryResolver) ToFileMatch() (*FileMatchResolver, bool)   { return
 func (r *RepositoryResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (r *RepositoryResolver) ToDiagnosticSearchResult() (*DiagnosticSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (r *RepositoryResolver) ResultCount() int32 {
 	return 1
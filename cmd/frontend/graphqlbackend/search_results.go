@@ -163,6 +163,12 @@ func matchesToResolvers(db dbutil.DB, matches []result.Match) []SearchResultReso
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.DiagnosticMatch:
+			resolvers = append(resolvers, &DiagnosticSearchResultResolver{
+				db:              db,
+				DiagnosticMatch: *v,
+				RepoResolver:    getRepoResolver(v.Repo, ""),
+			})
 		}
 	}
 	return resolvers
@@ -321,8 +327,8 @@ loop:
 	for _, r := range sr.Matches {
 		r := r // shadow so it doesn't change in the goroutine
 		switch m := r.(type) {
-		case *result.RepoMatch:
-			// We don't care about repo results here.
+		case *result.RepoMatch, *result.DiagnosticMatch:
+			// We don't care about repo or diagnostic results here.
 			continue
 		case *result.CommitMatch:
 			// Diff searches are cheap, because we implicitly have author date info.
@@ -1596,6 +1602,15 @@ func (r *searchResolver) doResults(ctx context.Context, args *search.TextParamet
 
 	}
 
+	if args.ResultTypes.Has(result.TypeDiagnostic) {
+		wg := waitGroup(args.ResultTypes.Without(result.TypeDiagnostic) == 0)
+		wg.Add(1)
+		goroutine.Go(func() {
+			defer wg.Done()
+			_ = agg.DoDiagnosticSearch(ctx, args, limit)
+		})
+	}
+
 	hasStartedAllBackends = true
 
 	// Wait for required searches.
@@ -1628,12 +1643,14 @@ func isContextError(ctx context.Context, err error) bool {
 //   - *RepositoryResolver         // repo name match
 //   - *fileMatchResolver          // text match
 //   - *commitSearchResultResolver // diff or commit match
+//   - *DiagnosticSearchResultResolver // precise code intelligence diagnostics of a file
 //
 // Note: Any new result types added here also need to be handled properly in search_results.go:301 (sparklines)
 type SearchResultResolver interface {
 	ToRepository() (*RepositoryResolver, bool)
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*CommitSearchResultResolver, bool)
+	ToDiagnosticSearchResult() (*DiagnosticSearchResultResolver, bool)
 
 	ResultCount() int32
 }
@@ -1678,6 +1695,8 @@ func compareSearchResults(left, right result.Match, exactFilePatterns map[string
 			return string(r.Name), "", nil
 		case *result.FileMatch:
 			return string(r.Repo.Name), r.Path, nil
+		case *result.DiagnosticMatch:
+			return string(r.Repo.Name), r.Path, nil
 		case *result.CommitMatch:
 			// Commits are relatively sorted by date, and after repo
 			// or path names. We use ~ as the key for repo and
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/diagnostic"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -67,6 +68,8 @@ func TestSearchResults(t *testing.T) {
 				resultDescriptions[i] = fmt.Sprintf("repo:%s", m.Name)
 			case *result.FileMatch:
 				resultDescriptions[i] = fmt.Sprintf("%s:%d", m.Path, m.LineMatches[0].LineNumber)
+			case *result.DiagnosticMatch:
+				resultDescriptions[i] = fmt.Sprintf("%s:%d", m.Path, m.Diagnostics[0].StartLine)
 			default:
 				t.Fatal("unexpected result type", match)
 			}
@@ -257,6 +260,55 @@ func TestSearchResults(t *testing.T) {
 			t.Error("calledSearchSymbols")
 		}
 	})
+
+	t.Run("type:diagnostic", func(t *testing.T) {
+		mockDecodedViewerFinalSettings = &schema.Settings{}
+		defer func() { mockDecodedViewerFinalSettings = nil }()
+
+		database.Mocks.Repos.ListRepoNames = func(_ context.Context, op database.ReposListOptions) ([]types.RepoName, error) {
+			return []types.RepoName{{ID: 1, Name: "repo"}}, nil
+		}
+		defer func() { database.Mocks = database.MockStores{} }()
+		database.Mocks.Repos.MockGetByName(t, "repo", 1)
+		database.Mocks.Repos.MockGet(t, 1)
+		database.Mocks.Repos.Count = mockCount
+
+		calledSearchFilesInRepos := atomic.NewBool(false)
+		unindexed.MockSearchFilesInRepos = func(args *search.TextParameters) ([]result.Match, *streaming.Stats, error) {
+			calledSearchFilesInRepos.Store(true)
+			return nil, &streaming.Stats{}, nil
+		}
+		defer func() { unindexed.MockSearchFilesInRepos = nil }()
+
+		var opts diagnostic.SearchOptions
+		diagnostic.SearchFunc = func(ctx context.Context, o diagnostic.SearchOptions) ([]diagnostic.FileDiagnostic, error) {
+			opts = o
+			return []diagnostic.FileDiagnostic{{
+				Repo:       types.RepoName{ID: 1, Name: "repo"},
+				Commit:     "deadbeef",
+				Path:       "dir/file",
+				Diagnostic: result.Diagnostic{Severity: 1, Message: "deprecated", StartLine: 42},
+			}}, nil
+		}
+		defer func() { diagnostic.SearchFunc = nil }()
+
+		testCallResults(t, `repo:r type:diagnostic severity:error source:staticcheck code:SA1019 deprecated`, "V2", []string{"dir/file:42"})
+		if calledSearchFilesInRepos.Load() {
+			t.Error("calledSearchFilesInRepos")
+		}
+
+		expectedOpts := diagnostic.SearchOptions{
+			RepositoryIDs:  []int{1},
+			Severity:       1,
+			Source:         "staticcheck",
+			Code:           "SA1019",
+			MessagePattern: "deprecated",
+			Limit:          opts.Limit,
+		}
+		if diff := cmp.Diff(expectedOpts, opts); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	})
 }
 
 func TestSearchResolver_DynamicFilters(t *testing.T) {
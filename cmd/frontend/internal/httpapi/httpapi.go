@@ -39,7 +39,7 @@ import (
 //
 // 🚨 SECURITY: The caller MUST wrap the returned handler in middleware that checks authentication
 // and sets the actor in the request context.
-func NewHandler(db dbutil.DB, m *mux.Router, schema *graphql.Schema, githubWebhook webhooks.Registerer, gitlabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, rateLimiter graphqlbackend.LimitWatcher) http.Handler {
+func NewHandler(db dbutil.DB, m *mux.Router, schema *graphql.Schema, githubWebhook webhooks.Registerer, gitlabWebhook, bitbucketServerWebhook, bitbucketCloudWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, rateLimiter graphqlbackend.LimitWatcher) http.Handler {
 	if m == nil {
 		m = apirouter.New(nil)
 	}
@@ -70,8 +70,16 @@ func NewHandler(db dbutil.DB, m *mux.Router, schema *graphql.Schema, githubWebho
 	m.Get(apirouter.GitHubWebhooks).Handler(trace.Route(&gh))
 	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(gitlabWebhook))
 	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(bitbucketServerWebhook))
+	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(bitbucketCloudWebhook))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(newCodeIntelUploadHandler(false)))
 
+	scim := &scimHandler{db: db}
+	m.Get(apirouter.SCIMServiceProviderConfig).Handler(scim.handler(scim.serveServiceProviderConfig))
+	m.Get(apirouter.SCIMUsers).Handler(scim.handler(scim.serveUsers))
+	m.Get(apirouter.SCIMUser).Handler(scim.handler(scim.serveUser))
+	m.Get(apirouter.SCIMGroups).Handler(scim.handler(scim.serveGroups))
+	m.Get(apirouter.SCIMGroup).Handler(scim.handler(scim.serveGroup))
+
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET", "POST").Name("updatecheck").Handler(trace.Route(http.HandlerFunc(updatecheck.Handler)))
 	}
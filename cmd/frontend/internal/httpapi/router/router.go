@@ -25,6 +25,13 @@ const (
 	GitHubWebhooks          = "github.webhooks"
 	GitLabWebhooks          = "gitlab.webhooks"
 	BitbucketServerWebhooks = "bitbucketServer.webhooks"
+	BitbucketCloudWebhooks  = "bitbucketCloud.webhooks"
+
+	SCIMServiceProviderConfig = "scim.service-provider-config"
+	SCIMUsers                 = "scim.users"
+	SCIMUser                  = "scim.user"
+	SCIMGroups                = "scim.groups"
+	SCIMGroup                 = "scim.group"
 
 	SavedQueriesListAll    = "internal.saved-queries.list-all"
 	SavedQueriesGetInfo    = "internal.saved-queries.get-info"
@@ -71,7 +78,13 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/github-webhooks").Methods("POST").Name(GitHubWebhooks)
 	base.Path("/gitlab-webhooks").Methods("POST").Name(GitLabWebhooks)
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
+	base.Path("/bitbucket-cloud-webhooks").Methods("POST").Name(BitbucketCloudWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
+	base.Path("/scim/v2/ServiceProviderConfig").Methods("GET").Name(SCIMServiceProviderConfig)
+	base.Path("/scim/v2/Users").Methods("GET", "POST").Name(SCIMUsers)
+	base.Path("/scim/v2/Users/{id}").Methods("GET", "PUT", "PATCH", "DELETE").Name(SCIMUser)
+	base.Path("/scim/v2/Groups").Methods("GET", "POST").Name(SCIMGroups)
+	base.Path("/scim/v2/Groups/{id}").Methods("GET", "PUT", "PATCH", "DELETE").Name(SCIMGroup)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
 	base.Path("/src-cli/version").Methods("GET").Name(SrcCliVersion)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCliDownload)
@@ -0,0 +1,307 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// The SCIM 2.0 endpoint lets identity providers provision users and groups, as
+// described in RFC 7643 and RFC 7644. Users are Sourcegraph users and groups are
+// organizations.
+
+const (
+	scimContentType = "application/scim+json"
+
+	scimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// scimDefaultCount is the number of resources returned by list requests
+	// without a count parameter, and the maximum count.
+	scimDefaultCount = 100
+)
+
+// scimHandler serves the SCIM endpoint.
+type scimHandler struct {
+	db dbutil.DB
+}
+
+// handler wraps a SCIM handler func with authentication and SCIM error responses.
+//
+// 🚨 SECURITY: The SCIM endpoint is served to anonymous requests, the bearer
+// token of the site configuration is the only authentication.
+func (h *scimHandler) handler(fn func(http.ResponseWriter, *http.Request) error) http.Handler {
+	return trace.Route(handlerutil.HandlerWithErrorReturn{
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			w.Header().Set("Content-Type", scimContentType)
+			if err := authenticateSCIM(r); err != nil {
+				return err
+			}
+			return fn(w, r)
+		},
+		Error: serveSCIMError,
+	})
+}
+
+func authenticateSCIM(r *http.Request) error {
+	c := conf.Get().AuthScim
+	if c == nil || c.AuthToken == "" {
+		return &scimError{status: http.StatusNotFound, detail: "SCIM is not enabled"}
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer"))
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(c.AuthToken)) != 1 {
+		return &scimError{status: http.StatusUnauthorized, detail: "invalid bearer token"}
+	}
+	return nil
+}
+
+// scimError is an error that is sent to the client as a SCIM error response.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func scimBadRequest(scimType, format string, args ...interface{}) error {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+func scimNotFound(resourceType, id string) error {
+	return &scimError{status: http.StatusNotFound, detail: fmt.Sprintf("%s %s not found", resourceType, id)}
+}
+
+func serveSCIMError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	trace.SetRequestErrorCause(r.Context(), err)
+
+	var e *scimError
+	switch {
+	case errors.As(err, &e):
+	case errcode.IsNotFound(err):
+		e = &scimError{status: http.StatusNotFound, detail: "resource not found"}
+	default:
+		log15.Error("SCIM handler error", "method", r.Method, "request_uri", r.URL.RequestURI(), "error", err)
+		e = &scimError{status: http.StatusInternalServerError, detail: "internal error"}
+	}
+
+	_ = writeSCIM(w, e.status, &struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		SCIMType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail"`
+	}{
+		Schemas:  []string{scimSchemaError},
+		Status:   strconv.Itoa(e.status),
+		SCIMType: e.scimType,
+		Detail:   e.detail,
+	})
+}
+
+func writeSCIM(w http.ResponseWriter, status int, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	return err
+}
+
+func readSCIM(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return scimBadRequest("invalidSyntax", "invalid request body: %v", err)
+	}
+	return nil
+}
+
+type scimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+func newSCIMMeta(resourceType, endpoint string, id int32, created, lastModified time.Time) *scimMeta {
+	return &scimMeta{
+		ResourceType: resourceType,
+		Created:      created,
+		LastModified: lastModified,
+		Location:     fmt.Sprintf("%s/.api/scim/v2/%s/%d", strings.TrimSuffix(conf.Get().ExternalURL, "/"), endpoint, id),
+	}
+}
+
+type scimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// scimFilter is an "attribute eq value" filter, the only kind of filter that
+// identity providers use to look up resources.
+type scimFilter struct {
+	// Attr is the lowercased attribute name, without the "value" sub-attribute
+	// of multi-valued attributes.
+	Attr  string
+	Value string
+}
+
+func parseSCIMFilter(filter string) (*scimFilter, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, nil
+	}
+
+	fields := strings.SplitN(filter, " ", 3)
+	if len(fields) != 3 || !strings.EqualFold(fields[1], "eq") {
+		return nil, scimBadRequest("invalidFilter", "unsupported filter %q: only \"attribute eq value\" filters are supported", filter)
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(fields[2])), &value); err != nil {
+		return nil, scimBadRequest("invalidFilter", "invalid filter value %s: must be a string", fields[2])
+	}
+	return &scimFilter{
+		Attr:  strings.TrimSuffix(strings.ToLower(fields[0]), ".value"),
+		Value: value,
+	}, nil
+}
+
+// parseSCIMPagination returns the 1-based start index and count of a list request.
+func parseSCIMPagination(r *http.Request) (startIndex, count int, err error) {
+	startIndex, count = 1, scimDefaultCount
+	q := r.URL.Query()
+	if s := q.Get("startIndex"); s != "" {
+		if startIndex, err = strconv.Atoi(s); err != nil {
+			return 0, 0, scimBadRequest("invalidValue", "invalid startIndex %q", s)
+		}
+		if startIndex < 1 {
+			startIndex = 1
+		}
+	}
+	if s := q.Get("count"); s != "" {
+		if count, err = strconv.Atoi(s); err != nil {
+			return 0, 0, scimBadRequest("invalidValue", "invalid count %q", s)
+		}
+		if count < 0 {
+			count = 0
+		} else if count > scimDefaultCount {
+			count = scimDefaultCount
+		}
+	}
+	return startIndex, count, nil
+}
+
+func parseSCIMID(resourceType, id string) (int32, error) {
+	n, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return 0, scimNotFound(resourceType, id)
+	}
+	return int32(n), nil
+}
+
+// scimPatchRequest is the body of a PATCH request.
+type scimPatchRequest struct {
+	Schemas    []string       `json:"schemas"`
+	Operations []*scimPatchOp `json:"Operations"`
+}
+
+type scimPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// attributes returns the attributes changed by the operation, keyed by their
+// path. Operations without a path have an object value whose keys are the paths.
+func (op *scimPatchOp) attributes() (map[string]json.RawMessage, error) {
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+	case "remove":
+		if op.Path == "" {
+			return nil, scimBadRequest("noTarget", "remove operations require a path")
+		}
+	default:
+		return nil, scimBadRequest("invalidSyntax", "unsupported patch operation %q", op.Op)
+	}
+
+	if op.Path != "" {
+		return map[string]json.RawMessage{op.Path: op.Value}, nil
+	}
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &attrs); err != nil {
+		return nil, scimBadRequest("invalidValue", "operations without a path must have an object value")
+	}
+	return attrs, nil
+}
+
+func (op *scimPatchOp) isRemove() bool {
+	return strings.EqualFold(op.Op, "remove")
+}
+
+// unmarshalSCIMString unmarshals a string attribute value.
+func unmarshalSCIMString(path string, raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", scimBadRequest("invalidValue", "%s must be a string", path)
+	}
+	return s, nil
+}
+
+// unmarshalSCIMBool unmarshals a boolean attribute value. Some identity
+// providers send booleans as strings, e.g. "False".
+func unmarshalSCIMBool(path string, raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+			return b, nil
+		}
+	}
+	return false, scimBadRequest("invalidValue", "%s must be a boolean", path)
+}
+
+func (h *scimHandler) serveServiceProviderConfig(w http.ResponseWriter, r *http.Request) error {
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	return writeSCIM(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{scimSchemaServiceProviderConfig},
+		"patch":          supported{true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": scimDefaultCount},
+		"changePassword": supported{false},
+		"sort":           supported{false},
+		"etag":           supported{false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the bearer token of the auth.scim site configuration",
+		}},
+	})
+}
@@ -0,0 +1,383 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// scimGroup is a SCIM group resource, which is an organization.
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+// scimMember is a member of a group, its value is the ID of the user.
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// applyPatch applies the operations of a PATCH request to the group.
+func (g *scimGroup) applyPatch(ops []*scimPatchOp) error {
+	for _, op := range ops {
+		attrs, err := op.attributes()
+		if err != nil {
+			return err
+		}
+		for path, value := range attrs {
+			if err := g.applyOp(strings.ToLower(op.Op), path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *scimGroup) applyOp(op, path string, value json.RawMessage) error {
+	lower := strings.ToLower(path)
+	switch {
+	case lower == "displayname":
+		if op == "remove" {
+			return scimBadRequest("mutability", "%s cannot be removed", path)
+		}
+		name, err := unmarshalSCIMString(path, value)
+		if err != nil {
+			return err
+		}
+		g.DisplayName = name
+
+	case lower == "members":
+		var members []scimMember
+		if value != nil {
+			if err := json.Unmarshal(value, &members); err != nil {
+				return scimBadRequest("invalidValue", "%s must be an array of members", path)
+			}
+		}
+		switch op {
+		case "add":
+			g.addMembers(members)
+		case "replace":
+			g.Members = nil
+			g.addMembers(members)
+		case "remove":
+			if value == nil || string(value) == "null" {
+				g.Members = nil
+			} else {
+				g.removeMembers(members)
+			}
+		}
+
+	case strings.HasPrefix(lower, "members[") && op == "remove":
+		// The only supported value path is members[value eq "<id>"].
+		filter, err := parseSCIMFilter(strings.TrimSuffix(path[len("members["):], "]"))
+		if err != nil {
+			return err
+		}
+		if filter == nil || filter.Attr != "value" {
+			return scimBadRequest("invalidPath", "unsupported path %q", path)
+		}
+		g.removeMembers([]scimMember{{Value: filter.Value}})
+
+	default:
+		return scimBadRequest("invalidPath", "unsupported path %q", path)
+	}
+	return nil
+}
+
+func (g *scimGroup) addMembers(members []scimMember) {
+	for _, m := range members {
+		if !g.hasMember(m.Value) {
+			g.Members = append(g.Members, scimMember{Value: m.Value})
+		}
+	}
+}
+
+func (g *scimGroup) removeMembers(members []scimMember) {
+	remove := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		remove[m.Value] = struct{}{}
+	}
+	kept := g.Members[:0]
+	for _, m := range g.Members {
+		if _, ok := remove[m.Value]; !ok {
+			kept = append(kept, m)
+		}
+	}
+	g.Members = kept
+}
+
+func (g *scimGroup) hasMember(value string) bool {
+	for _, m := range g.Members {
+		if m.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *scimHandler) serveGroups(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case http.MethodPost:
+		return h.createGroup(w, r)
+	default:
+		return h.listGroups(w, r)
+	}
+}
+
+func (h *scimHandler) serveGroup(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseSCIMID("Group", mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+	org, err := database.Orgs(h.db).GetByID(r.Context(), id)
+	if errcode.IsNotFound(err) {
+		return scimNotFound("Group", mux.Vars(r)["id"])
+	} else if err != nil {
+		return err
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := database.Orgs(h.db).Delete(r.Context(), org.ID); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	current, err := h.groupResource(r.Context(), org)
+	if err != nil {
+		return err
+	}
+
+	var desired scimGroup
+	switch r.Method {
+	case http.MethodPut:
+		if err := readSCIM(r, &desired); err != nil {
+			return err
+		}
+	case http.MethodPatch:
+		var req scimPatchRequest
+		if err := readSCIM(r, &req); err != nil {
+			return err
+		}
+		desired = *current
+		desired.Members = append([]scimMember(nil), current.Members...)
+		if err := desired.applyPatch(req.Operations); err != nil {
+			return err
+		}
+	default:
+		return writeSCIM(w, http.StatusOK, current)
+	}
+
+	if desired.DisplayName != current.DisplayName {
+		displayName := desired.DisplayName
+		if org, err = database.Orgs(h.db).Update(r.Context(), org.ID, &displayName); err != nil {
+			return err
+		}
+	}
+	if err := h.setMembers(r.Context(), org.ID, current.Members, desired.Members); err != nil {
+		return err
+	}
+
+	updated, err := h.groupResource(r.Context(), org)
+	if err != nil {
+		return err
+	}
+	return writeSCIM(w, http.StatusOK, updated)
+}
+
+func (h *scimHandler) listGroups(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+	startIndex, count, err := parseSCIMPagination(r)
+	if err != nil {
+		return err
+	}
+
+	var (
+		orgs  []*types.Org
+		total int
+	)
+	if filter != nil {
+		if filter.Attr != "displayname" {
+			return scimBadRequest("invalidFilter", "unsupported filter attribute %q", filter.Attr)
+		}
+		org, err := h.findOrg(r.Context(), filter.Value)
+		if err != nil {
+			return err
+		}
+		if org != nil {
+			total = 1
+			if startIndex == 1 && count > 0 {
+				orgs = append(orgs, org)
+			}
+		}
+	} else {
+		if total, err = database.Orgs(h.db).Count(r.Context(), database.OrgsListOptions{}); err != nil {
+			return err
+		}
+		if count > 0 {
+			orgs, err = database.Orgs(h.db).List(r.Context(), &database.OrgsListOptions{
+				LimitOffset: &database.LimitOffset{Limit: count, Offset: startIndex - 1},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	resources := make([]*scimGroup, 0, len(orgs))
+	for _, org := range orgs {
+		g, err := h.groupResource(r.Context(), org)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, g)
+	}
+	return writeSCIM(w, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// findOrg returns the organization of the group with the given display name, or
+// nil.
+func (h *scimHandler) findOrg(ctx context.Context, displayName string) (*types.Org, error) {
+	name, err := auth.NormalizeUsername(displayName)
+	if err != nil {
+		return nil, nil
+	}
+	org, err := database.Orgs(h.db).GetByName(ctx, name)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return org, err
+}
+
+func (h *scimHandler) createGroup(w http.ResponseWriter, r *http.Request) error {
+	var desired scimGroup
+	if err := readSCIM(r, &desired); err != nil {
+		return err
+	}
+
+	// Organization names have the same restrictions as usernames.
+	name, err := auth.NormalizeUsername(desired.DisplayName)
+	if err != nil {
+		return scimBadRequest("invalidValue", "invalid displayName: %v", err)
+	}
+	if existing, err := h.findOrg(r.Context(), desired.DisplayName); err != nil {
+		return err
+	} else if existing != nil {
+		return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "an organization with this displayName already exists"}
+	}
+
+	org, err := database.Orgs(h.db).Create(r.Context(), name, &desired.DisplayName)
+	if err != nil {
+		return err
+	}
+	if err := h.setMembers(r.Context(), org.ID, nil, desired.Members); err != nil {
+		return err
+	}
+
+	created, err := h.groupResource(r.Context(), org)
+	if err != nil {
+		return err
+	}
+	return writeSCIM(w, http.StatusCreated, created)
+}
+
+// setMembers adds and removes organization members to go from the current to
+// the desired members.
+func (h *scimHandler) setMembers(ctx context.Context, orgID int32, current, desired []scimMember) error {
+	currentIDs := make(map[string]struct{}, len(current))
+	for _, m := range current {
+		currentIDs[m.Value] = struct{}{}
+	}
+	desiredIDs := make(map[string]struct{}, len(desired))
+	for _, m := range desired {
+		desiredIDs[m.Value] = struct{}{}
+	}
+
+	members := database.OrgMembers(h.db)
+	for value := range desiredIDs {
+		if _, ok := currentIDs[value]; ok {
+			continue
+		}
+		userID, err := parseSCIMID("User", value)
+		if err != nil {
+			return scimBadRequest("invalidValue", "member %q is not a user", value)
+		}
+		if _, err := database.Users(h.db).GetByID(ctx, userID); errcode.IsNotFound(err) {
+			return scimBadRequest("invalidValue", "member %q is not a user", value)
+		} else if err != nil {
+			return err
+		}
+		if _, err := members.Create(ctx, orgID, userID); err != nil {
+			return err
+		}
+	}
+	for value := range currentIDs {
+		if _, ok := desiredIDs[value]; ok {
+			continue
+		}
+		userID, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		if err := members.Remove(ctx, orgID, int32(userID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *scimHandler) groupResource(ctx context.Context, org *types.Org) (*scimGroup, error) {
+	g := &scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          strconv.Itoa(int(org.ID)),
+		DisplayName: org.Name,
+		Members:     []scimMember{},
+		Meta:        newSCIMMeta("Group", "Groups", org.ID, org.CreatedAt, org.UpdatedAt),
+	}
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		g.DisplayName = *org.DisplayName
+	}
+
+	memberships, err := database.OrgMembers(h.db).GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return g, nil
+	}
+	userIDs := make([]int32, 0, len(memberships))
+	for _, m := range memberships {
+		userIDs = append(userIDs, m.UserID)
+	}
+	users, err := database.Users(h.db).List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		g.Members = append(g.Members, scimMember{Value: strconv.Itoa(int(u.ID)), Display: u.Username})
+	}
+	return g, nil
+}
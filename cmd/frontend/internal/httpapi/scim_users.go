@@ -0,0 +1,635 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// scimServiceType is the service type and service ID of the external accounts
+// that store the external IDs of the users provisioned through SCIM.
+const scimServiceType = "scim"
+
+// scimProvisionedTag is the tag of the users that are managed through SCIM:
+// the users created through SCIM, and the existing users that SCIM adopted
+// when it first created or changed them.
+const scimProvisionedTag = "SCIMProvisioned"
+
+func scimAccountSpec(externalID string) extsvc.AccountSpec {
+	return extsvc.AccountSpec{
+		ServiceType: scimServiceType,
+		ServiceID:   scimServiceType,
+		AccountID:   externalID,
+	}
+}
+
+// scimUser is a SCIM user resource.
+type scimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *scimName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []scimEmail `json:"emails,omitempty"`
+	// Active is nil in requests that do not set it, which means active.
+	Active *bool     `json:"active,omitempty"`
+	Meta   *scimMeta `json:"meta,omitempty"`
+}
+
+type scimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+func (u *scimUser) isActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// displayName returns the display name of the user, falling back to the name
+// attributes that identity providers set instead.
+func (u *scimUser) displayName() string {
+	if u.DisplayName != "" || u.Name == nil {
+		return u.DisplayName
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// primaryEmail returns the email marked as primary, or else the first email.
+func (u *scimUser) primaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// applyPatch applies the operations of a PATCH request to the user. Attributes
+// that Sourcegraph does not store are ignored, because identity providers send
+// all the attributes they know of.
+func (u *scimUser) applyPatch(ops []*scimPatchOp) error {
+	for _, op := range ops {
+		attrs, err := op.attributes()
+		if err != nil {
+			return err
+		}
+		for path, value := range attrs {
+			if err := u.setAttribute(path, value, op.isRemove()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u *scimUser) setAttribute(path string, value json.RawMessage, remove bool) (err error) {
+	lower := strings.ToLower(path)
+
+	if remove {
+		switch {
+		case lower == "externalid":
+			u.ExternalID = ""
+		case lower == "displayname":
+			u.DisplayName = ""
+		case lower == "name":
+			u.Name = nil
+		case strings.HasPrefix(lower, "name.") && u.Name != nil:
+			return u.setNameAttribute(lower, nil)
+		case lower == "username", lower == "active", strings.HasPrefix(lower, "emails"):
+			return scimBadRequest("mutability", "%s cannot be removed", path)
+		}
+		return nil
+	}
+
+	switch {
+	case lower == "username":
+		u.UserName, err = unmarshalSCIMString(path, value)
+	case lower == "displayname":
+		u.DisplayName, err = unmarshalSCIMString(path, value)
+	case lower == "externalid":
+		u.ExternalID, err = unmarshalSCIMString(path, value)
+	case lower == "active":
+		var active bool
+		active, err = unmarshalSCIMBool(path, value)
+		u.Active = &active
+	case lower == "name":
+		var name scimName
+		if json.Unmarshal(value, &name) != nil {
+			return scimBadRequest("invalidValue", "%s must be an object", path)
+		}
+		u.Name = &name
+	case strings.HasPrefix(lower, "name."):
+		return u.setNameAttribute(lower, value)
+	case lower == "emails":
+		var emails []scimEmail
+		if json.Unmarshal(value, &emails) != nil {
+			return scimBadRequest("invalidValue", "%s must be an array of emails", path)
+		}
+		u.Emails = emails
+	case strings.HasPrefix(lower, "emails"):
+		// A value path such as emails[type eq "work"].value sets the email of the
+		// user, Sourcegraph only keeps track of the primary one.
+		var email string
+		if email, err = unmarshalSCIMString(path, value); err == nil {
+			u.Emails = []scimEmail{{Value: email, Primary: true}}
+		}
+	}
+	return err
+}
+
+func (u *scimUser) setNameAttribute(lowerPath string, value json.RawMessage) (err error) {
+	var s string
+	if value != nil {
+		if s, err = unmarshalSCIMString(lowerPath, value); err != nil {
+			return err
+		}
+	}
+	if u.Name == nil {
+		u.Name = &scimName{}
+	}
+	switch lowerPath {
+	case "name.formatted":
+		u.Name.Formatted = s
+	case "name.givenname":
+		u.Name.GivenName = s
+	case "name.familyname":
+		u.Name.FamilyName = s
+	}
+	return nil
+}
+
+func (h *scimHandler) serveUsers(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case http.MethodPost:
+		return h.createUser(w, r)
+	default:
+		return h.listUsers(w, r)
+	}
+}
+
+func (h *scimHandler) serveUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseSCIMID("User", mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	// Deactivated users are soft-deleted, they can still be read, reactivated
+	// and deleted.
+	user, deleted, err := database.Users(h.db).GetByIDIncludingDeleted(r.Context(), id)
+	if errcode.IsNotFound(err) {
+		return scimNotFound("User", mux.Vars(r)["id"])
+	} else if err != nil {
+		return err
+	}
+	if r.Method != http.MethodGet {
+		if err := checkUserMutable(user); err != nil {
+			return err
+		}
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := database.Users(h.db).HardDelete(r.Context(), id); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	current, err := h.userResource(r.Context(), user, !deleted)
+	if err != nil {
+		return err
+	}
+
+	var desired scimUser
+	switch r.Method {
+	case http.MethodPut:
+		if err := readSCIM(r, &desired); err != nil {
+			return err
+		}
+	case http.MethodPatch:
+		var req scimPatchRequest
+		if err := readSCIM(r, &req); err != nil {
+			return err
+		}
+		desired = *current
+		if err := desired.applyPatch(req.Operations); err != nil {
+			return err
+		}
+	default:
+		return writeSCIM(w, http.StatusOK, current)
+	}
+
+	updated, err := h.updateUser(r.Context(), user, deleted, current, &desired)
+	if err != nil {
+		return err
+	}
+	return writeSCIM(w, http.StatusOK, updated)
+}
+
+// checkUserMutable returns an error unless the user can be changed through
+// SCIM. Users that signed in before SCIM was enabled can be changed, so that
+// they can be offboarded through the identity provider as well.
+//
+// 🚨 SECURITY: The SCIM token must not grant control over site admins, so that
+// a leaked token cannot be used to lock out the administrators of the instance.
+func checkUserMutable(user *types.User) error {
+	if user.SiteAdmin {
+		return &scimError{status: http.StatusForbidden, detail: "site admins cannot be changed through SCIM"}
+	}
+	return nil
+}
+
+func (h *scimHandler) listUsers(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+	startIndex, count, err := parseSCIMPagination(r)
+	if err != nil {
+		return err
+	}
+
+	var (
+		users []*types.User
+		total int
+	)
+	if filter != nil {
+		user, err := h.findUser(r.Context(), filter)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			total = 1
+			if startIndex == 1 && count > 0 {
+				users = append(users, user)
+			}
+		}
+	} else {
+		if total, err = database.Users(h.db).Count(r.Context(), &database.UsersListOptions{}); err != nil {
+			return err
+		}
+		if count > 0 {
+			users, err = database.Users(h.db).List(r.Context(), &database.UsersListOptions{
+				LimitOffset: &database.LimitOffset{Limit: count, Offset: startIndex - 1},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	resources := make([]*scimUser, 0, len(users))
+	for _, user := range users {
+		u, err := h.userResource(r.Context(), user, true)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, u)
+	}
+	return writeSCIM(w, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// findUser returns the user matching the filter, or nil.
+func (h *scimHandler) findUser(ctx context.Context, filter *scimFilter) (*types.User, error) {
+	var (
+		user *types.User
+		err  error
+	)
+	switch filter.Attr {
+	case "username":
+		username, normErr := auth.NormalizeUsername(filter.Value)
+		if normErr != nil {
+			return nil, nil
+		}
+		user, err = database.Users(h.db).GetByUsername(ctx, username)
+	case "emails":
+		user, err = database.Users(h.db).GetByVerifiedEmail(ctx, filter.Value)
+	case "externalid":
+		var userID int32
+		if userID, err = h.userIDByExternalID(ctx, filter.Value); err != nil || userID == 0 {
+			return nil, err
+		}
+		user, err = database.Users(h.db).GetByID(ctx, userID)
+	default:
+		return nil, scimBadRequest("invalidFilter", "unsupported filter attribute %q", filter.Attr)
+	}
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (h *scimHandler) userIDByExternalID(ctx context.Context, externalID string) (int32, error) {
+	accounts, err := database.ExternalAccounts(h.db).List(ctx, database.ExternalAccountsListOptions{
+		ServiceType: scimServiceType,
+		ServiceID:   scimServiceType,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, acct := range accounts {
+		if acct.AccountID == externalID {
+			return acct.UserID, nil
+		}
+	}
+	return 0, nil
+}
+
+func (h *scimHandler) createUser(w http.ResponseWriter, r *http.Request) (err error) {
+	var desired scimUser
+	if err := readSCIM(r, &desired); err != nil {
+		return err
+	}
+	if !desired.isActive() {
+		return scimBadRequest("invalidValue", "users cannot be created inactive")
+	}
+	username, err := auth.NormalizeUsername(desired.UserName)
+	if err != nil {
+		return scimBadRequest("invalidValue", "invalid userName: %v", err)
+	}
+
+	// Create the user, tag it and save its external ID atomically, so that a
+	// failure doesn't leave a user behind that the identity provider doesn't
+	// know about.
+	tx, err := database.Users(h.db).Transact(r.Context())
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	user, err := h.findExistingUser(r.Context(), tx, username, desired.primaryEmail(), desired.ExternalID)
+	if err != nil {
+		return err
+	}
+	if user != nil {
+		// Adopt the user that signed in before SCIM was enabled.
+		if err := checkUserMutable(user); err != nil {
+			return err
+		}
+		if email := desired.primaryEmail(); email != "" {
+			if err := setPrimaryEmail(r.Context(), tx, user.ID, email); err != nil {
+				return err
+			}
+		}
+		log15.Info("SCIM adopted existing user", "userID", user.ID)
+	} else {
+		// 🚨 SECURITY: The email is verified, because the identity provider is
+		// trusted to own the addresses of its users. This lets users sign in
+		// with SAML or OpenID Connect to the account that was provisioned for
+		// them.
+		user, err = tx.Create(r.Context(), database.NewUser{
+			Username:        username,
+			DisplayName:     desired.displayName(),
+			Email:           desired.primaryEmail(),
+			EmailIsVerified: true,
+		})
+		switch {
+		case database.IsUsernameExists(err):
+			return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "userName is already taken"}
+		case database.IsEmailExists(err):
+			return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "email is already taken"}
+		case err != nil:
+			return err
+		}
+	}
+	if err := tx.SetTag(r.Context(), user.ID, scimProvisionedTag, true); err != nil {
+		return errors.Wrap(err, "tag user")
+	}
+	if desired.ExternalID != "" {
+		if err := setExternalID(r.Context(), tx, user.ID, desired.ExternalID); err != nil {
+			return errors.Wrap(err, "save external ID")
+		}
+	}
+
+	created, err := h.userResourceWith(r.Context(), tx, user, true)
+	if err != nil {
+		return err
+	}
+	return writeSCIM(w, http.StatusCreated, created)
+}
+
+// findExistingUser returns the user that the identity provider wants to create,
+// if it exists already because the user signed in with SAML or OpenID Connect
+// before SCIM was enabled. The user is matched by the account of the identity
+// provider, by username, or by verified email, in this order.
+func (h *scimHandler) findExistingUser(ctx context.Context, users *database.UserStore, username, email, externalID string) (*types.User, error) {
+	if externalID != "" {
+		accounts, err := database.ExternalAccountsWith(users).List(ctx, database.ExternalAccountsListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, acct := range accounts {
+			if acct.ServiceType != scimServiceType && acct.AccountID == externalID {
+				return users.GetByID(ctx, acct.UserID)
+			}
+		}
+	}
+
+	user, err := users.GetByUsername(ctx, username)
+	if err == nil || !errcode.IsNotFound(err) {
+		return user, err
+	}
+
+	if email == "" {
+		return nil, nil
+	}
+	user, err = users.GetByVerifiedEmail(ctx, email)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// updateUser saves the differences between the current and desired states of
+// the user, and returns the resulting user resource. The user is adopted if
+// it wasn't managed through SCIM before.
+func (h *scimHandler) updateUser(ctx context.Context, user *types.User, deleted bool, current, desired *scimUser) (*scimUser, error) {
+	users := database.Users(h.db)
+
+	if deleted {
+		if !desired.isActive() {
+			// Deactivated users are not updated until they are reactivated.
+			return current, nil
+		}
+		if err := users.Recover(ctx, user.ID); err != nil {
+			if database.IsUsernameExists(err) {
+				return nil, &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "userName is already taken"}
+			}
+			return nil, errors.Wrap(err, "reactivate user")
+		}
+		log15.Info("SCIM reactivated user", "userID", user.ID)
+	}
+
+	if err := users.SetTag(ctx, user.ID, scimProvisionedTag, true); err != nil {
+		return nil, errors.Wrap(err, "tag user")
+	}
+
+	var update database.UserUpdate
+	if desired.UserName != current.UserName {
+		username, err := auth.NormalizeUsername(desired.UserName)
+		if err != nil {
+			return nil, scimBadRequest("invalidValue", "invalid userName: %v", err)
+		}
+		if username != user.Username {
+			update.Username = username
+		}
+	}
+	if name := desired.displayName(); name != user.DisplayName {
+		update.DisplayName = &name
+	}
+	if update != (database.UserUpdate{}) {
+		if err := users.Update(ctx, user.ID, update); err != nil {
+			if database.IsUsernameExists(err) {
+				return nil, &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "userName is already taken"}
+			}
+			return nil, err
+		}
+	}
+
+	if email := desired.primaryEmail(); email != "" && email != current.primaryEmail() {
+		if err := setPrimaryEmail(ctx, users, user.ID, email); err != nil {
+			return nil, err
+		}
+	}
+
+	if desired.ExternalID != current.ExternalID {
+		if err := setExternalID(ctx, users, user.ID, desired.ExternalID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !desired.isActive() {
+		if err := h.deactivateUser(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		// The user cannot be read anymore.
+		inactive := *desired
+		inactive.Active = &[]bool{false}[0]
+		return &inactive, nil
+	}
+
+	updated, err := users.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return h.userResource(ctx, updated, true)
+}
+
+func setPrimaryEmail(ctx context.Context, users *database.UserStore, userID int32, email string) error {
+	emails := database.UserEmailsWith(users)
+	if _, _, err := emails.Get(ctx, userID, email); errcode.IsNotFound(err) {
+		if err := emails.Add(ctx, userID, email, nil); err != nil {
+			return errors.Wrap(err, "add email")
+		}
+	} else if err != nil {
+		return err
+	}
+	if err := emails.SetVerified(ctx, userID, email, true); err != nil {
+		return errors.Wrap(err, "verify email")
+	}
+	return emails.SetPrimaryEmail(ctx, userID, email)
+}
+
+func setExternalID(ctx context.Context, users *database.UserStore, userID int32, externalID string) error {
+	accounts := database.ExternalAccountsWith(users)
+	existing, err := accounts.List(ctx, database.ExternalAccountsListOptions{
+		UserID:      userID,
+		ServiceType: scimServiceType,
+		ServiceID:   scimServiceType,
+	})
+	if err != nil {
+		return err
+	}
+	for _, acct := range existing {
+		if err := accounts.Delete(ctx, acct.ID); err != nil {
+			return err
+		}
+	}
+	if externalID == "" {
+		return nil
+	}
+	return accounts.AssociateUserAndSave(ctx, userID, scimAccountSpec(externalID), extsvc.AccountData{})
+}
+
+// deactivateUser revokes all access of the user immediately. Sourcegraph has no
+// inactive users, so the user is soft-deleted, which releases the username and
+// the emails. Reactivating the user recovers it, see updateUser.
+func (h *scimHandler) deactivateUser(ctx context.Context, userID int32) error {
+	users := database.Users(h.db)
+	if err := users.InvalidateSessionsByID(ctx, userID); err != nil {
+		return errors.Wrap(err, "invalidate sessions")
+	}
+	if err := users.Delete(ctx, userID); err != nil {
+		return err
+	}
+	log15.Info("SCIM deactivated user", "userID", userID)
+	return nil
+}
+
+func (h *scimHandler) userResource(ctx context.Context, user *types.User, active bool) (*scimUser, error) {
+	return h.userResourceWith(ctx, database.Users(h.db), user, active)
+}
+
+func (h *scimHandler) userResourceWith(ctx context.Context, users *database.UserStore, user *types.User, active bool) (*scimUser, error) {
+	u := &scimUser{
+		Schemas:     []string{scimSchemaUser},
+		ID:          strconv.Itoa(int(user.ID)),
+		UserName:    user.Username,
+		DisplayName: user.DisplayName,
+		Active:      &active,
+		Meta:        newSCIMMeta("User", "Users", user.ID, user.CreatedAt, user.UpdatedAt),
+	}
+
+	email, _, err := database.UserEmailsWith(users).GetPrimaryEmail(ctx, user.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, err
+	}
+	if email != "" {
+		u.Emails = []scimEmail{{Value: email, Primary: true}}
+	}
+
+	accounts, err := database.ExternalAccountsWith(users).List(ctx, database.ExternalAccountsListOptions{
+		UserID:      user.ID,
+		ServiceType: scimServiceType,
+		ServiceID:   scimServiceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) > 0 {
+		u.ExternalID = accounts[0].AccountID
+	}
+	return u, nil
+}
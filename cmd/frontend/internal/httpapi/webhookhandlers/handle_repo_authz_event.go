@@ -61,5 +61,6 @@ func scheduleRepoUpdate(ctx context.Context, repo *gh.Repository) error {
 	c := repoupdater.DefaultClient
 	return c.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
 		RepoIDs: []api.RepoID{r.ID},
+		Reason:  protocol.PermsSyncReasonWebhook,
 	})
 }
@@ -85,5 +85,6 @@ func scheduleUserUpdate(ctx context.Context, db dbutil.DB, extSvc *types.Externa
 	c := repoupdater.DefaultClient
 	return c.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
 		UserIDs: ids,
+		Reason:  protocol.PermsSyncReasonWebhook,
 	})
 }
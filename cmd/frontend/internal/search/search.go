@@ -420,6 +420,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.Repo) streamh
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.DiagnosticMatch:
+		return fromDiagnosticMatch(v, repoCache)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -434,6 +436,40 @@ func fromFileMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Repo) s
 	return fromPathMatch(fm, repoCache)
 }
 
+func fromDiagnosticMatch(dm *result.DiagnosticMatch, repoCache map[api.RepoID]*types.Repo) *streamhttp.EventDiagnosticMatch {
+	diagnostics := make([]streamhttp.Diagnostic, 0, len(dm.Diagnostics))
+	for _, d := range dm.Diagnostics {
+		severity, ok := d.SeverityName()
+		if !ok {
+			severity = "UNKNOWN"
+		}
+
+		diagnostics = append(diagnostics, streamhttp.Diagnostic{
+			URL:       dm.DiagnosticURL(d).String(),
+			Severity:  severity,
+			Code:      d.Code,
+			Source:    d.Source,
+			Message:   d.Message,
+			Line:      d.StartLine,
+			Character: d.StartCharacter,
+		})
+	}
+
+	var stars int
+	if r, ok := repoCache[dm.Repo.ID]; ok {
+		stars = r.Stars
+	}
+
+	return &streamhttp.EventDiagnosticMatch{
+		Type:        streamhttp.DiagnosticMatchType,
+		Path:        dm.Path,
+		Repository:  string(dm.Repo.Name),
+		RepoStars:   stars,
+		Version:     string(dm.CommitID),
+		Diagnostics: diagnostics,
+	}
+}
+
 func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Repo) *streamhttp.EventPathMatch {
 	var branches []string
 	if fm.InputRev != nil {
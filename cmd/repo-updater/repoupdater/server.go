@@ -63,9 +63,9 @@ type Server struct {
 	}
 	PermsSyncer interface {
 		// ScheduleUsers schedules new permissions syncing requests for given users.
-		ScheduleUsers(ctx context.Context, userIDs ...int32)
+		ScheduleUsers(ctx context.Context, reason protocol.PermsSyncReason, userIDs ...int32)
 		// ScheduleRepos schedules new permissions syncing requests for given repositories.
-		ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
+		ScheduleRepos(ctx context.Context, reason protocol.PermsSyncReason, repoIDs ...api.RepoID)
 	}
 }
 
@@ -518,8 +518,12 @@ func (s *Server) handleSchedulePermsSync(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	s.PermsSyncer.ScheduleUsers(r.Context(), req.UserIDs...)
-	s.PermsSyncer.ScheduleRepos(r.Context(), req.RepoIDs...)
+	if req.Reason == "" {
+		req.Reason = protocol.PermsSyncReasonManual
+	}
+
+	s.PermsSyncer.ScheduleUsers(r.Context(), req.Reason, req.UserIDs...)
+	s.PermsSyncer.ScheduleRepos(r.Context(), req.Reason, req.RepoIDs...)
 
 	respond(w, http.StatusOK, nil)
 }
@@ -752,10 +752,10 @@ func (s *fakeScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedule
 
 type fakePermsSyncer struct{}
 
-func (*fakePermsSyncer) ScheduleUsers(ctx context.Context, userIDs ...int32) {
+func (*fakePermsSyncer) ScheduleUsers(ctx context.Context, reason protocol.PermsSyncReason, userIDs ...int32) {
 }
 
-func (*fakePermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID) {
+func (*fakePermsSyncer) ScheduleRepos(ctx context.Context, reason protocol.PermsSyncReason, repoIDs ...api.RepoID) {
 }
 
 func TestServer_handleSchedulePermsSync(t *testing.T) {
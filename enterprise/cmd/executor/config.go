@@ -103,6 +103,7 @@ func (c *Config) ClientOptions(transport http.RoundTripper) apiclient.Options {
 		// Be unique but also descriptive.
 		ExecutorName:      hn + "-" + uuid.New().String(),
 		ExecutorHostname:  hn,
+		SrcCLIVersion:     c.srcCLIVersion(),
 		PathPrefix:        "/.executors/queue",
 		EndpointOptions:   c.EndpointOptions(),
 		BaseClientOptions: c.BaseClientOptions(transport),
@@ -30,6 +30,10 @@ type Options struct {
 	// ExecutorHostname is the hostname of the system it is running on.
 	ExecutorHostname string
 
+	// SrcCLIVersion is the version of src-cli the executor runs src-cli steps
+	// with, if known.
+	SrcCLIVersion string
+
 	// PathPrefix is the path prefix added to all requests.
 	PathPrefix string
 
@@ -68,6 +72,7 @@ func (c *Client) Dequeue(ctx context.Context, queueName string, job *executor.Jo
 	req, err := c.makeRequest("POST", fmt.Sprintf("%s/dequeue", queueName), executor.DequeueRequest{
 		ExecutorName:     c.options.ExecutorName,
 		ExecutorHostname: c.options.ExecutorHostname,
+		SrcCLIVersion:    c.options.SrcCLIVersion,
 	})
 	if err != nil {
 		return false, err
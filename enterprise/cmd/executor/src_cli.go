@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"time"
+
+	"github.com/inconshreveable/log15"
+)
+
+var srcCLIVersionPattern = regexp.MustCompile(`Current version: (\S+)`)
+
+// srcCLIVersion returns the version of src-cli that src-cli steps are run
+// with: the one in the virtual machine image if Firecracker is enabled, and the
+// one on the host otherwise. The frontend uses the version to decide which jobs
+// the executor can run. It returns an empty string if the version can't be
+// determined.
+func (c *Config) srcCLIVersion() string {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "src", "version", "-client-only")
+	if c.UseFirecracker {
+		cmd = exec.CommandContext(ctx, "docker", "run", "--rm", "--entrypoint", "src", c.FirecrackerImage, "version", "-client-only")
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		log15.Warn("Failed to determine src-cli version", "error", err)
+		return ""
+	}
+
+	match := srcCLIVersionPattern.FindSubmatch(out)
+	if match == nil {
+		log15.Warn("Failed to parse src-cli version", "output", string(out))
+		return ""
+	}
+	return string(match[1])
+}
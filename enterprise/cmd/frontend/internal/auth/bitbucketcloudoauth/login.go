@@ -0,0 +1,81 @@
+package bitbucketcloudoauth
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dghubble/gologin"
+	oauth2Login "github.com/dghubble/gologin/oauth2"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+func LoginHandler(config *oauth2.Config, failure http.Handler) http.Handler {
+	return oauth2Login.LoginHandler(config, failure)
+}
+
+func CallbackHandler(config *oauth2.Config, success, failure http.Handler) http.Handler {
+	success = bitbucketCloudHandler(config, success, failure)
+	return oauth2Login.CallbackHandler(config, success, failure)
+}
+
+func bitbucketCloudHandler(config *oauth2.Config, success, failure http.Handler) http.Handler {
+	if failure == nil {
+		failure = gologin.DefaultFailureHandler
+	}
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		token, err := oauth2Login.TokenFromContext(ctx)
+		if err != nil {
+			ctx = gologin.WithError(ctx, err)
+			failure.ServeHTTP(w, req.WithContext(ctx))
+			return
+		}
+
+		client, err := clientFromAuthURL(config.Endpoint.AuthURL, token.AccessToken)
+		if err != nil {
+			ctx = gologin.WithError(ctx, errors.Errorf("could not parse AuthURL %s", config.Endpoint.AuthURL))
+			failure.ServeHTTP(w, req.WithContext(ctx))
+			return
+		}
+		user, err := client.CurrentUser(ctx)
+		err = validateResponse(user, err)
+		if err != nil {
+			ctx = gologin.WithError(ctx, err)
+			failure.ServeHTTP(w, req.WithContext(ctx))
+			return
+		}
+		ctx = WithUser(ctx, user)
+		success.ServeHTTP(w, req.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
+
+// validateResponse returns an error if the given Bitbucket Cloud user or error are unexpected.
+// Returns nil if they are valid.
+func validateResponse(user *bitbucketcloud.User, err error) error {
+	if err != nil {
+		return errors.Wrap(err, "unable to get Bitbucket Cloud user")
+	}
+	if user == nil || user.UUID == "" {
+		return errors.Errorf("unable to get Bitbucket Cloud user: bad user info %#+v", user)
+	}
+	return nil
+}
+
+// clientFromAuthURL returns a Bitbucket Cloud API client authenticated with the
+// given OAuth token. The API is served from the "api." subdomain of the host
+// serving the OAuth endpoints, e.g. https://api.bitbucket.org for https://bitbucket.org.
+func clientFromAuthURL(authURL, oauthToken string) (*bitbucketcloud.Client, error) {
+	apiURL, err := url.Parse(authURL)
+	if err != nil {
+		return nil, err
+	}
+	apiURL.Host = "api." + apiURL.Host
+	apiURL.Path = ""
+	apiURL.RawQuery = ""
+	apiURL.Fragment = ""
+	return bitbucketcloud.NewClient(apiURL, nil).WithToken(oauthToken), nil
+}
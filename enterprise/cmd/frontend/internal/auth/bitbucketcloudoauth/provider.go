@@ -0,0 +1,78 @@
+package bitbucketcloudoauth
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dghubble/gologin"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const sessionKey = "bitbucketcloudoauth@0"
+
+func parseProvider(db dbutil.DB, callbackURL string, p *schema.BitbucketCloudAuthProvider, sourceCfg schema.AuthProviders) (provider *oauth.Provider, messages []string) {
+	rawURL := p.Url
+	if rawURL == "" {
+		rawURL = "https://bitbucket.org/"
+	}
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		messages = append(messages, fmt.Sprintf("Could not parse Bitbucket Cloud URL %q. You will not be able to login via Bitbucket Cloud.", rawURL))
+		return nil, messages
+	}
+	codeHost := extsvc.NewCodeHost(parsedURL, extsvc.TypeBitbucketCloud)
+
+	return oauth.NewProvider(oauth.ProviderOp{
+		AuthPrefix: authPrefix,
+		OAuth2Config: func(extraScopes ...string) oauth2.Config {
+			// Bitbucket Cloud OAuth consumers are granted their permissions when
+			// they are created, so there is no need to request any scopes.
+			return oauth2.Config{
+				RedirectURL:  callbackURL,
+				ClientID:     p.ClientKey,
+				ClientSecret: p.ClientSecret,
+				Endpoint: oauth2.Endpoint{
+					AuthURL:  codeHost.BaseURL.ResolveReference(&url.URL{Path: "/site/oauth2/authorize"}).String(),
+					TokenURL: codeHost.BaseURL.ResolveReference(&url.URL{Path: "/site/oauth2/access_token"}).String(),
+				},
+			}
+		},
+		SourceConfig: sourceCfg,
+		StateConfig:  getStateConfig(),
+		ServiceID:    codeHost.ServiceID,
+		ServiceType:  codeHost.ServiceType,
+		Login: func(oauth2Cfg oauth2.Config) http.Handler {
+			return LoginHandler(&oauth2Cfg, nil)
+		},
+		Callback: func(oauth2Cfg oauth2.Config) http.Handler {
+			return CallbackHandler(
+				&oauth2Cfg,
+				oauth.SessionIssuer(&sessionIssuerHelper{
+					db:          db,
+					CodeHost:    codeHost,
+					clientKey:   p.ClientKey,
+					allowSignup: p.AllowSignup,
+				}, sessionKey),
+				nil,
+			)
+		},
+	}), messages
+}
+
+func getStateConfig() gologin.CookieConfig {
+	cfg := gologin.CookieConfig{
+		Name:     "bitbucketcloud-state-cookie",
+		Path:     "/",
+		MaxAge:   120, // 120 seconds
+		HTTPOnly: true,
+		Secure:   conf.IsExternalURLSecure(),
+	}
+	return cfg
+}
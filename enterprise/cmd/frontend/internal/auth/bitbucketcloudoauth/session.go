@@ -0,0 +1,143 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot/hubspotutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+type sessionIssuerHelper struct {
+	*extsvc.CodeHost
+	db          dbutil.DB
+	clientKey   string
+	allowSignup bool
+}
+
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+	bbUser, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, "Could not read Bitbucket Cloud user from callback request.", errors.Wrap(err, "could not read user from context")
+	}
+
+	login, err := auth.NormalizeUsername(bbUser.Nickname)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+	}
+
+	client, err := clientFromAuthURL(s.BaseURL.String(), token.AccessToken)
+	if err != nil {
+		return nil, "Could not create Bitbucket Cloud API client.", err
+	}
+
+	// 🚨 SECURITY: Ensure that the user email is verified
+	verifiedEmails := getVerifiedEmails(ctx, client)
+	if len(verifiedEmails) == 0 {
+		return nil, "Could not get verified email for Bitbucket Cloud user. Check that your Bitbucket Cloud account has a confirmed email that matches one of your Sourcegraph verified emails.", errors.New("no verified email")
+	}
+
+	// Try every verified email in succession until the first that succeeds. The
+	// saved token includes the refresh token, which the authz provider needs to
+	// refresh the access token once it expires.
+	if token.RefreshToken == "" {
+		log15.Warn("Bitbucket Cloud OAuth token has no refresh token, repository permissions cannot be synced once it expires", "user", bbUser.UUID)
+	}
+	var data extsvc.AccountData
+	bitbucketcloud.SetExternalAccountData(&data, bbUser, token)
+	var (
+		firstSafeErrMsg string
+		firstErr        error
+	)
+	for i, verifiedEmail := range verifiedEmails {
+		userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, s.db, auth.GetAndSaveUserOp{
+			UserProps: database.NewUser{
+				Username:        login,
+				Email:           verifiedEmail,
+				EmailIsVerified: true,
+				DisplayName:     bbUser.DisplayName,
+				AvatarURL:       bbUser.Links.Avatar.Href,
+			},
+			ExternalAccount: extsvc.AccountSpec{
+				ServiceType: s.ServiceType,
+				ServiceID:   s.ServiceID,
+				ClientID:    s.clientKey,
+				AccountID:   bbUser.UUID,
+			},
+			ExternalAccountData: data,
+			CreateIfNotExist:    s.allowSignup,
+		})
+		if err == nil {
+			go hubspotutil.SyncUser(verifiedEmail, hubspotutil.SignupEventID, &hubspot.ContactProperties{
+				AnonymousUserID: anonymousUserID,
+				FirstSourceURL:  firstSourceURL,
+			})
+			return actor.FromUser(userID), "", nil // success
+		}
+		if i == 0 {
+			firstSafeErrMsg, firstErr = safeErrMsg, err
+		}
+	}
+	// On failure, return the first error
+	return nil, fmt.Sprintf("No user exists matching any of the verified emails: %s.\n\nFirst error was: %s", strings.Join(verifiedEmails, ", "), firstSafeErrMsg), firstErr
+}
+
+// CreateCodeHostConnection is not supported for Bitbucket Cloud, because Bitbucket Cloud
+// code host connections authenticate with an app password rather than an OAuth token.
+func (s *sessionIssuerHelper) CreateCodeHostConnection(ctx context.Context, token *oauth2.Token, providerID string) (safeErrMsg string, err error) {
+	return "Creating Bitbucket Cloud code host connections from the OAuth flow is not supported.", errors.New("code host connection creation not supported for Bitbucket Cloud")
+}
+
+func (s *sessionIssuerHelper) DeleteStateCookie(w http.ResponseWriter) {
+	stateConfig := getStateConfig()
+	stateConfig.MaxAge = -1
+	http.SetCookie(w, oauth.NewCookie(stateConfig, ""))
+}
+
+func (s *sessionIssuerHelper) SessionData(token *oauth2.Token) oauth.SessionData {
+	return oauth.SessionData{
+		ID: providers.ConfigID{
+			ID:   s.ServiceID,
+			Type: s.ServiceType,
+		},
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+	}
+}
+
+// getVerifiedEmails returns the list of user emails that are confirmed. If the primary email is
+// confirmed, it will be the first email in the returned list. It only checks the first page of
+// user emails.
+func getVerifiedEmails(ctx context.Context, client *bitbucketcloud.Client) (verifiedEmails []string) {
+	emails, _, err := client.CurrentUserEmails(ctx, nil)
+	if err != nil {
+		log15.Warn("Could not get Bitbucket Cloud authenticated user emails", "error", err)
+		return nil
+	}
+
+	for _, email := range emails {
+		if !email.IsConfirmed {
+			continue
+		}
+		if email.IsPrimary {
+			verifiedEmails = append([]string{email.Email}, verifiedEmails...)
+			continue
+		}
+		verifiedEmails = append(verifiedEmails, email.Email)
+	}
+	return verifiedEmails
+}
@@ -269,6 +269,12 @@ func authHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 				log15.Warn("Failed to set OpenID Connect session data. The session is still secure, but Sourcegraph will be unable to revoke the user's token or redirect the user to the end-session endpoint after the user signs out of Sourcegraph.", "error", err)
 			}
 
+			// Evaluate the group permissions of the user right away, rather than when
+			// the user is next synced in the background.
+			if p.config.GroupPermissions != nil {
+				schedulePermsSync(r.Context(), actr.UID)
+			}
+
 			// 🚨 SECURITY: Call auth.SafeRedirectURL to avoid an open-redirect vuln.
 			http.Redirect(w, r, auth.SafeRedirectURL(state.Redirect), http.StatusFound)
 
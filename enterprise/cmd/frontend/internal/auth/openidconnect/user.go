@@ -6,12 +6,16 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/coreos/go-oidc"
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz/idpgroups"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 )
 
 // getOrCreateUser gets or creates a user account based on the OpenID Connect token. It returns the
@@ -50,12 +54,20 @@ func getOrCreateUser(ctx context.Context, db dbutil.DB, p *provider, idToken *oi
 		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
 	}
 
+	// The groups are only saved when group permissions are configured for the
+	// authentication provider.
+	var groups []string
+	if p.config.GroupPermissions != nil {
+		groups = claimValues(userInfo, idpgroups.GroupsAttribute(p.config.GroupPermissions))
+	}
+
 	var data extsvc.AccountData
 	data.SetAccountData(struct {
 		IDToken    *oidc.IDToken  `json:"idToken"`
 		UserInfo   *oidc.UserInfo `json:"userInfo"`
 		UserClaims *userClaims    `json:"userClaims"`
-	}{IDToken: idToken, UserInfo: userInfo, UserClaims: claims})
+		idpgroups.AccountData
+	}{IDToken: idToken, UserInfo: userInfo, UserClaims: claims, AccountData: idpgroups.AccountData{Groups: groups}})
 
 	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
 		UserProps: database.NewUser{
@@ -79,3 +91,39 @@ func getOrCreateUser(ctx context.Context, db dbutil.DB, p *provider, idToken *oi
 	}
 	return actor.FromUser(userID), "", nil
 }
+
+// schedulePermsSync requests a permissions sync of the user who just signed in.
+// Errors are only logged, because the user is also synced in the background.
+func schedulePermsSync(ctx context.Context, userID int32) {
+	err := repoupdater.DefaultClient.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
+		UserIDs: []int32{userID},
+		Reason:  protocol.PermsSyncReasonManual,
+	})
+	if err != nil {
+		log15.Warn("Failed to schedule permissions sync after sign in.", "userID", userID, "error", err)
+	}
+}
+
+// claimValues returns the values of the claim of the user info with the given
+// name, which is either a list of strings or a single string.
+func claimValues(userInfo *oidc.UserInfo, key string) []string {
+	var claims map[string]interface{}
+	if err := userInfo.Claims(&claims); err != nil {
+		log15.Warn("OpenID Connect auth: could not parse userInfo claims.", "error", err)
+		return nil
+	}
+
+	switch v := claims[key].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -174,6 +174,12 @@ func samlSPHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
+			// Evaluate the group permissions of the user right away, rather than when
+			// the user is next synced in the background.
+			if p.config.GroupPermissions != nil {
+				schedulePermsSync(r.Context(), actor.UID)
+			}
+
 			// 🚨 SECURITY: Call auth.SafeRedirectURL to avoid an open-redirect vuln.
 			http.Redirect(w, r, auth.SafeRedirectURL(relayState.ReturnToURL), http.StatusFound)
 
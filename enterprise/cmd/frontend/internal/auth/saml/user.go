@@ -7,13 +7,17 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 	saml2 "github.com/russellhaering/gosaml2"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz/idpgroups"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 )
 
 type authnResponseInfo struct {
@@ -59,6 +63,10 @@ func readAuthnResponse(p *provider, encodedResp string) (*authnResponseInfo, err
 	if pn := attr.Get("eduPersonPrincipalName"); email == "" && mightBeEmail(pn) {
 		email = pn
 	}
+	var groups []string
+	if p.config.GroupPermissions != nil {
+		groups = attributeValues(assertions, idpgroups.GroupsAttribute(p.config.GroupPermissions))
+	}
 	info := authnResponseInfo{
 		spec: extsvc.AccountSpec{
 			ServiceType: providerType,
@@ -69,7 +77,10 @@ func readAuthnResponse(p *provider, encodedResp string) (*authnResponseInfo, err
 		email:                email,
 		unnormalizedUsername: firstNonempty(attr.Get("login"), attr.Get("uid"), attr.Get("username"), attr.Get("http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name"), email),
 		displayName:          firstNonempty(attr.Get("displayName"), attr.Get("givenName")+" "+attr.Get("surname"), attr.Get("http://schemas.xmlsoap.org/claims/CommonName"), attr.Get("http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname")),
-		accountData:          assertions,
+		accountData: accountData{
+			AssertionInfo: assertions,
+			AccountData:   idpgroups.AccountData{Groups: groups},
+		},
 	}
 	if assertions.NameID == "" {
 		return nil, errors.New("the SAML response did not contain a valid NameID")
@@ -117,6 +128,13 @@ func mightBeEmail(s string) bool {
 	return strings.Count(s, "@") == 1
 }
 
+// accountData is the data saved in SAML external accounts. The groups are only
+// saved when group permissions are configured for the authentication provider.
+type accountData struct {
+	*saml2.AssertionInfo
+	idpgroups.AccountData
+}
+
 type samlAssertionValues saml2.Values
 
 func (v samlAssertionValues) Get(key string) string {
@@ -127,3 +145,38 @@ func (v samlAssertionValues) Get(key string) string {
 	}
 	return ""
 }
+
+// attributeValues returns all the values of the attributes with the given name.
+// Unlike saml2.Values, it supports attributes that are repeated for every value,
+// which is how many identity providers list groups.
+func attributeValues(assertions *saml2.AssertionInfo, key string) []string {
+	var values []string
+	for _, assertion := range assertions.Assertions {
+		if assertion.AttributeStatement == nil {
+			continue
+		}
+		for _, a := range assertion.AttributeStatement.Attributes {
+			if a.Name != key && a.FriendlyName != key {
+				continue
+			}
+			for _, av := range a.Values {
+				if value := strings.TrimSpace(av.Value); value != "" {
+					values = append(values, value)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// schedulePermsSync requests a permissions sync of the user who just signed in.
+// Errors are only logged, because the user is also synced in the background.
+func schedulePermsSync(ctx context.Context, userID int32) {
+	err := repoupdater.DefaultClient.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
+		UserIDs: []int32{userID},
+		Reason:  protocol.PermsSyncReasonManual,
+	})
+	if err != nil {
+		log15.Warn("Failed to schedule permissions sync after sign in.", "userID", userID, "error", err)
+	}
+}
@@ -0,0 +1,290 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// The types of reasons that grant a user access to a repository, they must match
+// the values of the RepositoryPermissionReasonType GraphQL enum.
+const (
+	reasonSiteAdmin                      = "SITE_ADMIN"
+	reasonAllowByDefault                 = "ALLOW_BY_DEFAULT"
+	reasonPublic                         = "PUBLIC"
+	reasonUnrestrictedCodeHostConnection = "UNRESTRICTED_CODE_HOST_CONNECTION"
+	reasonUserCodeHostConnection         = "USER_CODE_HOST_CONNECTION"
+	reasonExplicitPermissions            = "EXPLICIT_PERMISSIONS"
+	reasonCodeHostPermissions            = "CODE_HOST_PERMISSIONS"
+)
+
+func (r *Resolver) RepositoryPermissionExplanation(ctx context.Context, args *graphqlbackend.RepositoryPermissionExplanationArgs) (graphqlbackend.RepositoryPermissionExplanationResolver, error) {
+	if err := r.checkLicense(); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins can explain the permissions of users.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.Handle().DB()); err != nil {
+		return nil, err
+	}
+
+	userID, err := graphqlbackend.UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	user, err := database.GlobalUsers.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Site admins may not be able to view the repository when authz is
+	// enforced for site admins, but we need to load it to explain the access of the
+	// given user.
+	repo, err := database.GlobalRepos.Get(actor.WithInternalActor(ctx), repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons, err := r.explainRepoAccess(ctx, user, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositoryPermissionExplanationResolver{
+		store:   r.store,
+		db:      r.store.Handle().DB(),
+		user:    user,
+		repo:    repo,
+		reasons: reasons,
+	}, nil
+}
+
+// explainRepoAccess returns all the reasons that grant the user access to the
+// repository. It mirrors the conditions of the authz query used to filter
+// repositories in internal/database/repos_perm.go, and returns no reasons when
+// the user cannot view the repository.
+func (r *Resolver) explainRepoAccess(ctx context.Context, user *types.User, repo *types.Repo) ([]*repositoryPermissionReasonResolver, error) {
+	db := r.store.Handle().DB()
+
+	allowByDefault, providers := authz.GetProviders()
+	usePermissionsUserMapping := globals.PermissionsUserMapping().Enabled
+	if usePermissionsUserMapping {
+		allowByDefault = false
+	}
+
+	var reasons []*repositoryPermissionReasonResolver
+
+	if user.SiteAdmin && !conf.Get().AuthzEnforceForSiteAdmins {
+		reasons = append(reasons, &repositoryPermissionReasonResolver{
+			typ:         reasonSiteAdmin,
+			description: "The user is a site admin and permissions are not enforced for site admins.",
+		})
+	}
+
+	if allowByDefault && len(providers) == 0 {
+		reasons = append(reasons, &repositoryPermissionReasonResolver{
+			typ:         reasonAllowByDefault,
+			description: "No authorization provider is configured and access to all repositories is allowed by default.",
+		})
+	}
+
+	if !usePermissionsUserMapping && !repo.Private {
+		reasons = append(reasons, &repositoryPermissionReasonResolver{
+			typ:         reasonPublic,
+			description: "The repository is not private.",
+		})
+	}
+
+	svcs, err := r.store.ListRepoAccessExternalServices(ctx, user.ID, int32(repo.ID))
+	if err != nil {
+		return nil, err
+	}
+	if !usePermissionsUserMapping && len(svcs.Unrestricted) > 0 {
+		reasons = append(reasons, &repositoryPermissionReasonResolver{
+			typ:              reasonUnrestrictedCodeHostConnection,
+			description:      "The repository is synced by a code host connection that does not enforce permissions.",
+			externalServices: svcs.Unrestricted,
+		})
+	}
+	if len(svcs.UserAdded) > 0 {
+		reasons = append(reasons, &repositoryPermissionReasonResolver{
+			typ:              reasonUserCodeHostConnection,
+			description:      "The repository is synced by a code host connection added by the user.",
+			externalServices: svcs.UserAdded,
+		})
+	}
+
+	p := &authz.UserPermissions{
+		UserID: user.ID,
+		Perm:   authz.Read, // Note: We currently only support read for repository permissions.
+		Type:   authz.PermRepos,
+	}
+	err = r.store.LoadUserPermissions(ctx, p)
+	if err != nil && err != authz.ErrPermsNotFound {
+		return nil, err
+	}
+	if err == authz.ErrPermsNotFound || !p.IDs.Contains(uint32(repo.ID)) {
+		return reasons, nil
+	}
+
+	if usePermissionsUserMapping {
+		reasons = append(reasons, &repositoryPermissionReasonResolver{
+			typ:         reasonExplicitPermissions,
+			description: "The user has been granted explicit permissions to the repository.",
+			updatedAt:   p.UpdatedAt,
+		})
+		return reasons, nil
+	}
+
+	reason := &repositoryPermissionReasonResolver{
+		db:          db,
+		typ:         reasonCodeHostPermissions,
+		description: "The code host reported that the user has access to the repository.",
+		updatedAt:   p.UpdatedAt,
+	}
+
+	// Find the authz provider responsible for the repository the same way the
+	// permissions syncer does, and the external account of the user on it.
+	for _, provider := range providers {
+		if _, ok := repo.Sources[provider.URN()]; !ok {
+			continue
+		}
+
+		serviceID := provider.ServiceID()
+		reason.provider = &serviceID
+		reason.description = fmt.Sprintf("The code host %s reported that the user has access to the repository.", serviceID)
+
+		accounts, err := r.store.ListExternalAccounts(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, acct := range accounts {
+			if acct.ServiceType == provider.ServiceType() && acct.ServiceID == provider.ServiceID() {
+				// 🚨 SECURITY: Never expose the credentials of the external account.
+				acct.AuthData = nil
+				reason.account = acct
+				break
+			}
+		}
+		break
+	}
+
+	return append(reasons, reason), nil
+}
+
+var _ graphqlbackend.RepositoryPermissionExplanationResolver = &repositoryPermissionExplanationResolver{}
+
+// repositoryPermissionExplanationResolver resolves the explanation of the access of
+// a user to a repository.
+//
+// 🚨 SECURITY: It is the caller's responsibility to ensure the current authenticated
+// user is a site admin.
+type repositoryPermissionExplanationResolver struct {
+	store   *edb.PermsStore
+	db      dbutil.DB
+	user    *types.User
+	repo    *types.Repo
+	reasons []*repositoryPermissionReasonResolver
+}
+
+func (r *repositoryPermissionExplanationResolver) User() *graphqlbackend.UserResolver {
+	return graphqlbackend.NewUserResolver(r.db, r.user)
+}
+
+func (r *repositoryPermissionExplanationResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return graphqlbackend.NewRepositoryResolver(r.db, r.repo)
+}
+
+func (r *repositoryPermissionExplanationResolver) HasAccess() bool {
+	return len(r.reasons) > 0
+}
+
+func (r *repositoryPermissionExplanationResolver) Reasons() []graphqlbackend.RepositoryPermissionReasonResolver {
+	reasons := make([]graphqlbackend.RepositoryPermissionReasonResolver, 0, len(r.reasons))
+	for _, reason := range r.reasons {
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+func (r *repositoryPermissionExplanationResolver) GrantedBy(ctx context.Context) (graphqlbackend.PermissionsSyncJobResolver, error) {
+	jobs, err := r.store.ListPermsSyncJobs(ctx, edb.ListPermsSyncJobsOpts{
+		GrantedUserID: r.user.ID,
+		GrantedRepoID: int32(r.repo.ID),
+		Limit:         1,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &permissionsSyncJobResolver{db: r.db, job: jobs[0]}, nil
+}
+
+var _ graphqlbackend.RepositoryPermissionReasonResolver = &repositoryPermissionReasonResolver{}
+
+type repositoryPermissionReasonResolver struct {
+	db               dbutil.DB
+	typ              string
+	description      string
+	provider         *string
+	account          *extsvc.Account
+	externalServices []int64
+	updatedAt        time.Time
+}
+
+func (r *repositoryPermissionReasonResolver) Type() string {
+	return r.typ
+}
+
+func (r *repositoryPermissionReasonResolver) Description() string {
+	return r.description
+}
+
+func (r *repositoryPermissionReasonResolver) Provider() *string {
+	return r.provider
+}
+
+func (r *repositoryPermissionReasonResolver) ExternalAccount() graphqlbackend.ExternalAccountResolver {
+	if r.account == nil {
+		return nil
+	}
+	return graphqlbackend.NewExternalAccountResolver(r.db, *r.account)
+}
+
+func (r *repositoryPermissionReasonResolver) ExternalServices() *[]graphql.ID {
+	if len(r.externalServices) == 0 {
+		return nil
+	}
+
+	ids := make([]graphql.ID, 0, len(r.externalServices))
+	for _, id := range r.externalServices {
+		ids = append(ids, graphqlbackend.MarshalExternalServiceID(id))
+	}
+	return &ids
+}
+
+func (r *repositoryPermissionReasonResolver) UpdatedAt() *graphqlbackend.DateTime {
+	if r.updatedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.updatedAt}
+}
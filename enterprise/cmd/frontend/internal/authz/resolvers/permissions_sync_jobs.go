@@ -0,0 +1,164 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// maxPermissionsSyncJobs is the maximum number of permissions sync jobs returned
+// by a single query.
+const maxPermissionsSyncJobs = 100
+
+func (r *Resolver) PermissionsSyncJobs(ctx context.Context, args *graphqlbackend.PermissionsSyncJobsArgs) ([]graphqlbackend.PermissionsSyncJobResolver, error) {
+	if err := r.checkLicense(); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins can query the permissions sync history.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.Handle().DB()); err != nil {
+		return nil, err
+	}
+
+	if (args.User == nil) == (args.Repository == nil) {
+		return nil, errors.New("exactly one of user or repository must be specified")
+	}
+
+	opts := edb.ListPermsSyncJobsOpts{Limit: int(args.First)}
+	if opts.Limit <= 0 || opts.Limit > maxPermissionsSyncJobs {
+		opts.Limit = maxPermissionsSyncJobs
+	}
+
+	if args.User != nil {
+		userID, err := graphqlbackend.UnmarshalUserID(*args.User)
+		if err != nil {
+			return nil, err
+		}
+		opts.UserID = userID
+	} else {
+		repoID, err := graphqlbackend.UnmarshalRepositoryID(*args.Repository)
+		if err != nil {
+			return nil, err
+		}
+		opts.RepoID = int32(repoID)
+	}
+
+	jobs, err := r.store.ListPermsSyncJobs(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.PermissionsSyncJobResolver, 0, len(jobs))
+	for _, job := range jobs {
+		resolvers = append(resolvers, &permissionsSyncJobResolver{db: r.store.Handle().DB(), job: job})
+	}
+	return resolvers, nil
+}
+
+var _ graphqlbackend.PermissionsSyncJobResolver = &permissionsSyncJobResolver{}
+
+// permissionsSyncJobResolver resolves a recorded permissions sync.
+//
+// 🚨 SECURITY: It is the caller's responsibility to ensure the current authenticated
+// user is a site admin.
+type permissionsSyncJobResolver struct {
+	db  dbutil.DB
+	job *edb.PermsSyncJob
+}
+
+func marshalPermissionsSyncJobID(id int32) graphql.ID {
+	return relay.MarshalID("PermissionsSyncJob", id)
+}
+
+func (r *permissionsSyncJobResolver) ID() graphql.ID {
+	return marshalPermissionsSyncJobID(r.job.ID)
+}
+
+func (r *permissionsSyncJobResolver) Type() string {
+	if r.job.RequestType == edb.PermsSyncJobTypeRepo {
+		return "REPOSITORY"
+	}
+	return "USER"
+}
+
+func (r *permissionsSyncJobResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.job.RequestType != edb.PermsSyncJobTypeUser {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.job.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *permissionsSyncJobResolver) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	if r.job.RequestType != edb.PermsSyncJobTypeRepo {
+		return nil, nil
+	}
+
+	// 🚨 SECURITY: Site admins may not be able to view the repository when authz is
+	// enforced for site admins, but they still need to know which repository the sync
+	// was about.
+	repo, err := database.GlobalRepos.Get(actor.WithInternalActor(ctx), api.RepoID(r.job.RepoID))
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(r.db, repo), nil
+}
+
+func (r *permissionsSyncJobResolver) Providers() []string {
+	return r.job.Providers
+}
+
+func (r *permissionsSyncJobResolver) StartedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.job.StartedAt}
+}
+
+func (r *permissionsSyncJobResolver) FinishedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.job.FinishedAt}
+}
+
+func (r *permissionsSyncJobResolver) DurationMilliseconds() int32 {
+	return int32(r.job.Duration().Milliseconds())
+}
+
+func (r *permissionsSyncJobResolver) Added() []graphql.ID {
+	return r.marshalIDs(r.job.AddedIDs)
+}
+
+func (r *permissionsSyncJobResolver) Removed() []graphql.ID {
+	return r.marshalIDs(r.job.RemovedIDs)
+}
+
+// marshalIDs marshals the IDs of the job as repository IDs for user-centric syncs
+// and as user IDs for repository-centric syncs.
+func (r *permissionsSyncJobResolver) marshalIDs(ids []int32) []graphql.ID {
+	gqlIDs := make([]graphql.ID, 0, len(ids))
+	for _, id := range ids {
+		if r.job.RequestType == edb.PermsSyncJobTypeRepo {
+			gqlIDs = append(gqlIDs, graphqlbackend.MarshalUserID(id))
+		} else {
+			gqlIDs = append(gqlIDs, graphqlbackend.MarshalRepositoryID(api.RepoID(id)))
+		}
+	}
+	return gqlIDs
+}
+
+func (r *permissionsSyncJobResolver) FailureMessage() *string {
+	return r.job.FailureMessage
+}
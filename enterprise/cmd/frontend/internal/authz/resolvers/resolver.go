@@ -176,6 +176,7 @@ func (r *Resolver) ScheduleRepositoryPermissionsSync(ctx context.Context, args *
 
 	err = r.repoupdaterClient.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
 		RepoIDs: []api.RepoID{repoID},
+		Reason:  protocol.PermsSyncReasonManual,
 	})
 	if err != nil {
 		return nil, err
@@ -200,6 +201,7 @@ func (r *Resolver) ScheduleUserPermissionsSync(ctx context.Context, args *graphq
 
 	err = r.repoupdaterClient.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
 		UserIDs: []int32{userID},
+		Reason:  protocol.PermsSyncReasonManual,
 	})
 	if err != nil {
 		return nil, err
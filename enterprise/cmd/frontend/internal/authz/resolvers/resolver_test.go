@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -813,3 +814,236 @@ func TestResolver_UserPermissionsInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestResolver_RepositoryPermissionExplanation(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		database.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+		t.Cleanup(func() {
+			database.Mocks.Users.GetByCurrentAuthUser = nil
+		})
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{store: edb.Perms(nil, timeutil.Now)}).RepositoryPermissionExplanation(ctx, &graphqlbackend.RepositoryPermissionExplanationArgs{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	p := &fakeProvider{
+		codeHost: extsvc.NewCodeHost(mustParseURL(t, "https://github.com/"), extsvc.TypeGitHub),
+	}
+	authz.SetProviders(false, []authz.Provider{p})
+	t.Cleanup(func() { authz.SetProviders(true, nil) })
+
+	database.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	database.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "alice"}, nil
+	}
+	database.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{
+			ID:      id,
+			Name:    "github.com/owner/repo",
+			Private: true,
+			Sources: map[string]*types.SourceInfo{p.URN(): {}},
+		}, nil
+	}
+	edb.Mocks.Perms.ListRepoAccessExternalServices = func(context.Context, int32, int32) (*edb.RepoAccessExternalServices, error) {
+		return &edb.RepoAccessExternalServices{UserAdded: []int64{3}}, nil
+	}
+	edb.Mocks.Perms.LoadUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
+		p.IDs = roaring.BitmapOf(1)
+		p.UpdatedAt = clock()
+		return nil
+	}
+	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
+		return []*extsvc.Account{{
+			ID:     5,
+			UserID: 1,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+				AccountID:   "alice",
+			},
+		}}, nil
+	}
+	edb.Mocks.Perms.ListPermsSyncJobs = func(_ context.Context, opts edb.ListPermsSyncJobsOpts) ([]*edb.PermsSyncJob, error) {
+		if opts.GrantedUserID != 1 || opts.GrantedRepoID != 1 {
+			return nil, errors.Errorf("unexpected options: %+v", opts)
+		}
+		return []*edb.PermsSyncJob{{
+			ID:          7,
+			RequestType: edb.PermsSyncJobTypeUser,
+			UserID:      1,
+			Providers:   []string{"https://github.com/"},
+			StartedAt:   clock(),
+			FinishedAt:  clock().Add(1500 * time.Millisecond),
+			AddedIDs:    []int32{1},
+		}}, nil
+	}
+	t.Cleanup(func() {
+		database.Mocks.Users = database.MockUsers{}
+		database.Mocks.Repos = database.MockRepos{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	})
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t, nil),
+			Query: `
+				{
+					repositoryPermissionExplanation(user: "VXNlcjox", repository: "UmVwb3NpdG9yeTox") {
+						hasAccess
+						reasons {
+							type
+							provider
+							externalAccount {
+								accountID
+							}
+							externalServices
+							updatedAt
+						}
+						grantedBy {
+							type
+							providers
+							durationMilliseconds
+							added
+						}
+					}
+				}
+			`,
+			ExpectedResult: fmt.Sprintf(`
+				{
+					"repositoryPermissionExplanation": {
+						"hasAccess": true,
+						"reasons": [
+							{
+								"type": "USER_CODE_HOST_CONNECTION",
+								"provider": null,
+								"externalAccount": null,
+								"externalServices": ["%[2]s"],
+								"updatedAt": null
+							},
+							{
+								"type": "CODE_HOST_PERMISSIONS",
+								"provider": "https://github.com/",
+								"externalAccount": {
+									"accountID": "alice"
+								},
+								"externalServices": null,
+								"updatedAt": "%[1]s"
+							}
+						],
+						"grantedBy": {
+							"type": "USER",
+							"providers": ["https://github.com/"],
+							"durationMilliseconds": 1500,
+							"added": ["UmVwb3NpdG9yeTox"]
+						}
+					}
+				}
+			`, clock().Format(time.RFC3339), graphqlbackend.MarshalExternalServiceID(3)),
+		},
+	})
+}
+
+func TestResolver_PermissionsSyncJobs(t *testing.T) {
+	database.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	t.Cleanup(func() {
+		database.Mocks.Users = database.MockUsers{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	})
+
+	r := &Resolver{store: edb.Perms(nil, timeutil.Now)}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("neither user nor repository", func(t *testing.T) {
+		_, err := r.PermissionsSyncJobs(ctx, &graphqlbackend.PermissionsSyncJobsArgs{First: 20})
+		if err == nil {
+			t.Fatal("want error but got nil")
+		}
+	})
+
+	t.Run("by repository", func(t *testing.T) {
+		failure := "rate limit exceeded"
+		edb.Mocks.Perms.ListPermsSyncJobs = func(_ context.Context, opts edb.ListPermsSyncJobsOpts) ([]*edb.PermsSyncJob, error) {
+			if diff := cmp.Diff(edb.ListPermsSyncJobsOpts{RepoID: 1, Limit: maxPermissionsSyncJobs}, opts); diff != "" {
+				return nil, errors.Errorf("opts mismatch (-want +got):\n%s", diff)
+			}
+			return []*edb.PermsSyncJob{{
+				ID:             1,
+				RequestType:    edb.PermsSyncJobTypeRepo,
+				RepoID:         1,
+				AddedIDs:       []int32{2},
+				RemovedIDs:     []int32{3},
+				FailureMessage: &failure,
+			}}, nil
+		}
+
+		repo := graphqlbackend.MarshalRepositoryID(1)
+		jobs, err := r.PermissionsSyncJobs(ctx, &graphqlbackend.PermissionsSyncJobsArgs{Repository: &repo, First: 1000})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 1 {
+			t.Fatalf("want 1 job but got %d", len(jobs))
+		}
+
+		job := jobs[0]
+		if diff := cmp.Diff("REPOSITORY", job.Type()); diff != "" {
+			t.Fatalf("Type mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]graphql.ID{graphqlbackend.MarshalUserID(2)}, job.Added()); diff != "" {
+			t.Fatalf("Added mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]graphql.ID{graphqlbackend.MarshalUserID(3)}, job.Removed()); diff != "" {
+			t.Fatalf("Removed mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(&failure, job.FailureMessage()); diff != "" {
+			t.Fatalf("FailureMessage mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+type fakeProvider struct {
+	codeHost *extsvc.CodeHost
+}
+
+func (p *fakeProvider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (*extsvc.Account, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) ServiceType() string { return p.codeHost.ServiceType }
+func (p *fakeProvider) ServiceID() string   { return p.codeHost.ServiceID }
+func (p *fakeProvider) URN() string         { return extsvc.URN(p.codeHost.ServiceType, 0) }
+func (p *fakeProvider) Validate() []string  { return nil }
+
+func (p *fakeProvider) FetchUserPerms(context.Context, *extsvc.Account) (*authz.ExternalUserPermissions, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) FetchUserPermsByToken(context.Context, string) (*authz.ExternalUserPermissions, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) FetchRepoPerms(context.Context, *extsvc.Repository) ([]extsvc.AccountID, error) {
+	return nil, nil
+}
+
+func mustParseURL(t *testing.T, rawURL string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
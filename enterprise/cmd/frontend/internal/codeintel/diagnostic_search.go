@@ -0,0 +1,54 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/diagnostic"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// searchDiagnostics resolves type:diagnostic searches from the diagnostics indexed by the
+// worker. The store applies repository permissions of the actor of the given context.
+func searchDiagnostics(dbStore *dbstore.Store) func(ctx context.Context, opts diagnostic.SearchOptions) ([]diagnostic.FileDiagnostic, error) {
+	return func(ctx context.Context, opts diagnostic.SearchOptions) ([]diagnostic.FileDiagnostic, error) {
+		diagnostics, _, err := dbStore.GetDiagnostics(ctx, dbstore.GetDiagnosticsOptions{
+			RepositoryIDs:                 opts.RepositoryIDs,
+			Severity:                      opts.Severity,
+			Source:                        opts.Source,
+			Code:                          opts.Code,
+			PathPatterns:                  opts.PathPatterns,
+			ExcludePathPattern:            opts.ExcludePathPattern,
+			PathPatternsAreCaseSensitive:  opts.PathPatternsAreCaseSensitive,
+			MessagePattern:                opts.MessagePattern,
+			MessagePatternIsCaseSensitive: opts.MessagePatternIsCaseSensitive,
+			Limit:                         opts.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		fileDiagnostics := make([]diagnostic.FileDiagnostic, 0, len(diagnostics))
+		for _, d := range diagnostics {
+			fileDiagnostics = append(fileDiagnostics, diagnostic.FileDiagnostic{
+				Repo:   types.RepoName{ID: api.RepoID(d.RepositoryID), Name: api.RepoName(d.RepositoryName)},
+				Commit: api.CommitID(d.Commit),
+				Path:   d.Path,
+				Diagnostic: result.Diagnostic{
+					Severity:       d.Severity,
+					Code:           d.Code,
+					Source:         d.Source,
+					Message:        d.Message,
+					StartLine:      d.StartLine,
+					StartCharacter: d.StartCharacter,
+					EndLine:        d.EndLine,
+					EndCharacter:   d.EndCharacter,
+				},
+			})
+		}
+
+		return fileDiagnostics, nil
+	}
+}
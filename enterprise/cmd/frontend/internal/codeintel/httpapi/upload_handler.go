@@ -89,6 +89,11 @@ func (h *UploadHandler) handleEnqueue(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err == upload.ErrInvalidMetadata {
+			http.Error(w, "Could not read indexer name from index metadata. Please supply it explicitly.", http.StatusBadRequest)
+			return
+		}
+
 		log15.Error("Failed to enqueue payload", "error", err)
 		http.Error(w, fmt.Sprintf("failed to enqueue payload: %s", err.Error()), http.StatusInternalServerError)
 		return
@@ -110,6 +115,8 @@ type UploadArgs struct {
 	RepositoryID      int
 	Indexer           string
 	AssociatedIndexID int
+	BaseUploadID      int
+	ChangedPaths      []string
 }
 
 type enqueuePayload struct {
@@ -129,6 +136,11 @@ type enqueuePayload struct {
 //   - POST `/upload?uploadId={id},index={i}`
 //   - POST `/upload?uploadId={id},done=true`
 //
+// Either sequence may create an incremental upload by supplying `baseUploadId={id}` along with a
+// `changedPath={path}` parameter for each document added, modified, or deleted since the commit of
+// the base upload. Only the changed documents are read from such an upload; the data for all other
+// documents is carried over from the base upload when the upload is processed.
+//
 // See the functions the following functions for details on how each request is handled:
 //
 //   - handleEnqueueSinglePayload
@@ -146,6 +158,12 @@ func (h *UploadHandler) handleEnqueueErr(w http.ResponseWriter, r *http.Request,
 		AssociatedIndexID: getQueryInt(r, "associatedIndexId"),
 	}
 
+	if !hasQuery(r, "uploadId") {
+		if err := h.setIncrementalUploadArgs(ctx, r, &uploadArgs); err != nil {
+			return nil, err
+		}
+	}
+
 	if !hasQuery(r, "multiPart") && !hasQuery(r, "uploadId") {
 		return h.handleEnqueueSinglePayload(r, uploadArgs)
 	}
@@ -185,6 +203,48 @@ func (h *UploadHandler) handleEnqueueErr(w http.ResponseWriter, r *http.Request,
 	return nil, clientError("no index supplied")
 }
 
+// setIncrementalUploadArgs populates the base upload and changed paths of the given upload arguments
+// from the request's query. The base upload must exist and index the same repository and root as the
+// new upload. Whether the base upload has completed processing is checked by the worker.
+func (h *UploadHandler) setIncrementalUploadArgs(ctx context.Context, r *http.Request, uploadArgs *UploadArgs) error {
+	changedPaths := getQueryValues(r, "changedPath")
+
+	if !hasQuery(r, "baseUploadId") {
+		if len(changedPaths) > 0 {
+			return clientError("changedPath supplied without baseUploadId")
+		}
+
+		return nil
+	}
+
+	baseUploadID := getQueryInt(r, "baseUploadId")
+	baseUpload, exists, err := h.dbStore.GetUploadByID(ctx, baseUploadID)
+	if err != nil {
+		return err
+	}
+	if !exists || baseUpload.RepositoryID != uploadArgs.RepositoryID || baseUpload.Root != uploadArgs.Root {
+		return clientError("base upload %d not found for this repository and root", baseUploadID)
+	}
+
+	uploadArgs.BaseUploadID = baseUploadID
+	uploadArgs.ChangedPaths = changedPaths
+	if uploadArgs.ChangedPaths == nil {
+		uploadArgs.ChangedPaths = []string{}
+	}
+
+	return nil
+}
+
+// baseUploadID returns a pointer to the base upload identifier, or nil if this is not an
+// incremental upload.
+func (a UploadArgs) baseUploadID() *int {
+	if a.BaseUploadID == 0 {
+		return nil
+	}
+
+	return &a.BaseUploadID
+}
+
 // handleEnqueueSinglePayload handles a non-multipart upload. This creates an upload record
 // with state 'queued', proxies the data to the bundle manager, and returns the generated ID.
 func (h *UploadHandler) handleEnqueueSinglePayload(r *http.Request, uploadArgs UploadArgs) (interface{}, error) {
@@ -212,6 +272,8 @@ func (h *UploadHandler) handleEnqueueSinglePayload(r *http.Request, uploadArgs U
 		RepositoryID:      uploadArgs.RepositoryID,
 		Indexer:           uploadArgs.Indexer,
 		AssociatedIndexID: &uploadArgs.AssociatedIndexID,
+		BaseUploadID:      uploadArgs.baseUploadID(),
+		ChangedPaths:      uploadArgs.ChangedPaths,
 		State:             "uploading",
 		NumParts:          1,
 		UploadedParts:     []int{0},
@@ -252,6 +314,8 @@ func (h *UploadHandler) handleEnqueueMultipartSetup(r *http.Request, uploadArgs
 		RepositoryID:      uploadArgs.RepositoryID,
 		Indexer:           uploadArgs.Indexer,
 		AssociatedIndexID: &uploadArgs.AssociatedIndexID,
+		BaseUploadID:      uploadArgs.baseUploadID(),
+		ChangedPaths:      uploadArgs.ChangedPaths,
 		State:             "uploading",
 		NumParts:          numParts,
 		UploadedParts:     nil,
@@ -346,8 +410,10 @@ func (h *UploadHandler) markUploadAsFailed(ctx context.Context, tx DBStore, uplo
 }
 
 // inferIndexer returns the tool name from the metadata vertex at the start of the the given
-// input stream. This method must destructively read the request body, but will re-assign the
-// Body field with a reader that holds the same information as the original request.
+// input stream. The payload may be either line-delimited LSIF JSON or a protobuf-encoded index,
+// which is detected from its leading bytes. This method must destructively read the request body,
+// but will re-assign the Body field with a reader that holds the same information as the original
+// request.
 //
 // Newer versions of src-cli will do this same check before uploading the file. However, older
 // versions of src-cli will not guarantee that the index name query parameter is sent. Requiring
@@ -15,12 +15,14 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/inconshreveable/log15"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	uploadstoremocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsiftyped"
 )
 
 func TestMain(m *testing.M) {
@@ -182,6 +184,169 @@ func TestHandleEnqueueSinglePayloadNoIndexerName(t *testing.T) {
 	}
 }
 
+func TestHandleEnqueueSinglePayloadNoIndexerNameTypedIndex(t *testing.T) {
+	setupRepoMocks(t)
+
+	mockDBStore := NewMockDBStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockDBStore.InsertUploadFunc.SetDefaultReturn(42, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"commit":     []string{testCommit},
+		"root":       []string{"proj/"},
+		"repository": []string{"github.com/test/test"},
+	}).Encode()
+
+	payload, err := proto.Marshal(&lsiftyped.Index{
+		Metadata:  &lsiftyped.Metadata{ToolInfo: &lsiftyped.ToolInfo{Name: "lsif-tsc"}},
+		Documents: []*lsiftyped.Document{{RelativePath: "index.ts"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	_, _ = io.Copy(gzipWriter, bytes.NewReader(payload))
+	gzipWriter.Close()
+	expectedContents := buf.Bytes()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader(expectedContents))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	h := &UploadHandler{
+		dbStore:     mockDBStore,
+		uploadStore: mockUploadStore,
+	}
+	h.handleEnqueue(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusAccepted, w.Code)
+	}
+
+	if len(mockDBStore.InsertUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", 1, len(mockDBStore.InsertUploadFunc.History()))
+	} else if indexer := mockDBStore.InsertUploadFunc.History()[0].Arg1.Indexer; indexer != "lsif-tsc" {
+		t.Errorf("unexpected indexer name. want=%s have=%s", "lsif-tsc", indexer)
+	}
+
+	if len(mockUploadStore.UploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of Upload calls. want=%d have=%d", 1, len(mockUploadStore.UploadFunc.History()))
+	} else {
+		contents, err := io.ReadAll(mockUploadStore.UploadFunc.History()[0].Arg2)
+		if err != nil {
+			t.Fatalf("unexpected error reading payload: %s", err)
+		}
+
+		if diff := cmp.Diff(expectedContents, contents); diff != "" {
+			t.Errorf("unexpected file contents (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestHandleEnqueueSinglePayloadIncremental(t *testing.T) {
+	setupRepoMocks(t)
+
+	mockDBStore := NewMockDBStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockDBStore.InsertUploadFunc.SetDefaultReturn(42, nil)
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(store.Upload{ID: 24, RepositoryID: 50, Root: "proj/", State: "completed"}, true, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"commit":       []string{testCommit},
+		"root":         []string{"proj/"},
+		"repository":   []string{"github.com/test/test"},
+		"indexerName":  []string{"lsif-go"},
+		"baseUploadId": []string{"24"},
+		"changedPath":  []string{"a.go", "b/c.go"},
+	}).Encode()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader([]byte("payload")))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	h := &UploadHandler{
+		dbStore:     mockDBStore,
+		uploadStore: mockUploadStore,
+	}
+	h.handleEnqueue(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusAccepted, w.Code)
+	}
+
+	if len(mockDBStore.InsertUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", 1, len(mockDBStore.InsertUploadFunc.History()))
+	} else {
+		call := mockDBStore.InsertUploadFunc.History()[0]
+		if call.Arg1.BaseUploadID == nil || *call.Arg1.BaseUploadID != 24 {
+			t.Errorf("unexpected base upload id. want=%d have=%v", 24, call.Arg1.BaseUploadID)
+		}
+		if diff := cmp.Diff([]string{"a.go", "b/c.go"}, call.Arg1.ChangedPaths); diff != "" {
+			t.Errorf("unexpected changed paths (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestHandleEnqueueSinglePayloadIncrementalUnknownBase(t *testing.T) {
+	setupRepoMocks(t)
+
+	mockDBStore := NewMockDBStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(store.Upload{ID: 24, RepositoryID: 50, Root: "other/"}, true, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"commit":       []string{testCommit},
+		"root":         []string{"proj/"},
+		"repository":   []string{"github.com/test/test"},
+		"indexerName":  []string{"lsif-go"},
+		"baseUploadId": []string{"24"},
+		"changedPath":  []string{"a.go"},
+	}).Encode()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader([]byte("payload")))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	h := &UploadHandler{
+		dbStore:     mockDBStore,
+		uploadStore: mockUploadStore,
+	}
+	h.handleEnqueue(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusBadRequest, w.Code)
+	}
+	if len(mockDBStore.InsertUploadFunc.History()) != 0 {
+		t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", 0, len(mockDBStore.InsertUploadFunc.History()))
+	}
+}
+
 func TestHandleEnqueueMultipartSetup(t *testing.T) {
 	setupRepoMocks(t)
 
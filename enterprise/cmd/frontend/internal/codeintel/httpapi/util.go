@@ -30,6 +30,16 @@ func getQuery(r *http.Request, name string) string {
 	return r.URL.Query().Get(name)
 }
 
+func getQueryValues(r *http.Request, name string) (values []string) {
+	for _, value := range r.URL.Query()[name] {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
 func getQueryInt(r *http.Request, name string) int {
 	value, _ := strconv.Atoi(r.URL.Query().Get(name))
 	return value
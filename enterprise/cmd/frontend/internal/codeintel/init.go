@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+	"github.com/sourcegraph/sourcegraph/internal/search/diagnostic"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
@@ -44,6 +45,8 @@ func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigrat
 		return err
 	}
 
+	diagnostic.SearchFunc = searchDiagnostics(services.dbStore)
+
 	enterpriseServices.CodeIntelResolver = resolver
 	enterpriseServices.NewCodeIntelUploadHandler = uploadHandler
 	return nil
@@ -59,6 +62,7 @@ func newResolver(ctx context.Context, db dbutil.DB, observationContext *observat
 		services.dbStore,
 		services.lsifStore,
 		services.gitserverClient,
+		&searchClient{db: db},
 		services.indexEnqueuer,
 		hunkCache,
 		observationContext,
@@ -0,0 +1,60 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+)
+
+// Diagnostics returns a page of the indexed diagnostics matching the given options, and the total
+// number of matching diagnostics. Each diagnostic is reported at the commit of the upload that
+// produced it, which is visible from the tip of the default branch of its repository.
+func (r *resolver) Diagnostics(ctx context.Context, opts store.GetDiagnosticsOptions) ([]AdjustedDiagnostic, int, error) {
+	diagnostics, totalCount, err := r.dbStore.GetDiagnostics(ctx, opts)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "dbStore.GetDiagnostics")
+	}
+
+	uploadIDs := make([]int, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		uploadIDs = append(uploadIDs, diagnostic.UploadID)
+	}
+
+	dumps, err := r.dbStore.GetDumpsByIDs(ctx, uploadIDs)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "dbStore.GetDumpsByIDs")
+	}
+
+	dumpsByID := make(map[int]store.Dump, len(dumps))
+	for _, dump := range dumps {
+		dumpsByID[dump.ID] = dump
+	}
+
+	adjustedDiagnostics := make([]AdjustedDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		dump, ok := dumpsByID[diagnostic.UploadID]
+		if !ok {
+			// The upload was deleted between the two queries
+			continue
+		}
+
+		adjustedDiagnostics = append(adjustedDiagnostics, AdjustedDiagnostic{
+			Diagnostic: lsifstore.Diagnostic{
+				DumpID:         diagnostic.UploadID,
+				Path:           diagnostic.Path,
+				DiagnosticData: diagnostic.DiagnosticData,
+			},
+			Dump:           dump,
+			AdjustedCommit: dump.Commit,
+			AdjustedRange: lsifstore.Range{
+				Start: lsifstore.Position{Line: diagnostic.StartLine, Character: diagnostic.StartCharacter},
+				End:   lsifstore.Position{Line: diagnostic.EndLine, Character: diagnostic.EndCharacter},
+			},
+		})
+	}
+
+	return adjustedDiagnostics, totalCount, nil
+}
@@ -0,0 +1,53 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestIndexedDiagnostics(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockDBStore.GetDiagnosticsFunc.SetDefaultReturn([]dbstore.Diagnostic{
+		{UploadID: 42, Path: "sub/a.go", DiagnosticData: semantic.DiagnosticData{Severity: 1, Message: "m1", StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4}},
+		{UploadID: 43, Path: "b.go", DiagnosticData: semantic.DiagnosticData{Severity: 2, Message: "m2"}},
+	}, 5, nil)
+	mockDBStore.GetDumpsByIDsFunc.SetDefaultReturn([]dbstore.Dump{
+		{ID: 42, Commit: "deadbeef", Root: "sub/"},
+	}, nil)
+
+	resolver := newResolver(mockDBStore, NewMockLSIFStore(), NewMockGitserverClient(), nil, nil, nil, &observation.TestContext)
+	diagnostics, totalCount, err := resolver.Diagnostics(context.Background(), dbstore.GetDiagnosticsOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 5 {
+		t.Errorf("unexpected total count. want=%d have=%d", 5, totalCount)
+	}
+
+	// The diagnostic of upload 43 is dropped as the upload no longer exists
+	expected := []AdjustedDiagnostic{
+		{
+			Diagnostic: lsifstore.Diagnostic{
+				DumpID:         42,
+				Path:           "sub/a.go",
+				DiagnosticData: semantic.DiagnosticData{Severity: 1, Message: "m1", StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4},
+			},
+			Dump:           dbstore.Dump{ID: 42, Commit: "deadbeef", Root: "sub/"},
+			AdjustedCommit: "deadbeef",
+			AdjustedRange: lsifstore.Range{
+				Start: lsifstore.Position{Line: 1, Character: 2},
+				End:   lsifstore.Position{Line: 3, Character: 4},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, diagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+}
@@ -54,3 +54,19 @@ func toSeverity(val int) (*string, error) {
 
 	return &severity, nil
 }
+
+// fromSeverity converts the given GraphQL severity into an LSP severity. The zero value is
+// returned if no severity is supplied.
+func fromSeverity(val *string) (int, error) {
+	if val == nil {
+		return 0, nil
+	}
+
+	for severity, name := range severities {
+		if name == *val {
+			return severity, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown diagnostic severity %q", *val)
+}
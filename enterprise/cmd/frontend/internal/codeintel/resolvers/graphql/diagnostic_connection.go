@@ -11,13 +11,22 @@ import (
 type DiagnosticConnectionResolver struct {
 	diagnostics      []resolvers.AdjustedDiagnostic
 	totalCount       int
+	offset           int
 	locationResolver *CachedLocationResolver
 }
 
 func NewDiagnosticConnectionResolver(diagnostics []resolvers.AdjustedDiagnostic, totalCount int, locationResolver *CachedLocationResolver) gql.DiagnosticConnectionResolver {
+	return NewPaginatedDiagnosticConnectionResolver(diagnostics, totalCount, 0, locationResolver)
+}
+
+// NewPaginatedDiagnosticConnectionResolver creates a diagnostic connection resolver for a page
+// of diagnostics starting at the given offset. The end cursor of the connection encodes the
+// offset of the next page.
+func NewPaginatedDiagnosticConnectionResolver(diagnostics []resolvers.AdjustedDiagnostic, totalCount, offset int, locationResolver *CachedLocationResolver) gql.DiagnosticConnectionResolver {
 	return &DiagnosticConnectionResolver{
 		diagnostics:      diagnostics,
 		totalCount:       totalCount,
+		offset:           offset,
 		locationResolver: locationResolver,
 	}
 }
@@ -35,5 +44,9 @@ func (r *DiagnosticConnectionResolver) TotalCount(ctx context.Context) (int32, e
 }
 
 func (r *DiagnosticConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
-	return graphqlutil.HasNextPage(len(r.diagnostics) < r.totalCount), nil
+	if nextOffset := r.offset + len(r.diagnostics); nextOffset < r.totalCount {
+		return encodeIntCursor(toInt32(&nextOffset)), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
 }
@@ -40,3 +40,15 @@ func unmarshalLSIFIndexGQLID(id graphql.ID) (indexID int64, err error) {
 	err = relay.UnmarshalSpec(id, &indexID)
 	return indexID, err
 }
+
+//
+//
+
+func marshalCodeIntelligenceRetentionPolicyGQLID(policyID int64) graphql.ID {
+	return relay.MarshalID("CodeIntelligenceRetentionPolicy", policyID)
+}
+
+func unmarshalCodeIntelligenceRetentionPolicyGQLID(id graphql.ID) (policyID int64, err error) {
+	err = relay.UnmarshalSpec(id, &policyID)
+	return policyID, err
+}
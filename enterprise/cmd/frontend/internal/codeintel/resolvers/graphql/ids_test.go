@@ -40,3 +40,14 @@ func TestIndexID(t *testing.T) {
 		t.Errorf("unexpected id. have=%d want=%d", expected, value)
 	}
 }
+
+func TestCodeIntelligenceRetentionPolicyID(t *testing.T) {
+	expected := int64(42)
+	value, err := unmarshalCodeIntelligenceRetentionPolicyGQLID(marshalCodeIntelligenceRetentionPolicyGQLID(expected))
+	if err != nil {
+		t.Fatalf("unexpected error marshalling id: %s", err)
+	}
+	if value != expected {
+		t.Errorf("unexpected id. have=%d want=%d", expected, value)
+	}
+}
@@ -11,13 +11,15 @@ import (
 type LocationConnectionResolver struct {
 	locations        []resolvers.AdjustedLocation
 	cursor           *string
+	precise          bool
 	locationResolver *CachedLocationResolver
 }
 
-func NewLocationConnectionResolver(locations []resolvers.AdjustedLocation, cursor *string, locationResolver *CachedLocationResolver) gql.LocationConnectionResolver {
+func NewLocationConnectionResolver(locations []resolvers.AdjustedLocation, cursor *string, precise bool, locationResolver *CachedLocationResolver) gql.LocationConnectionResolver {
 	return &LocationConnectionResolver{
 		locations:        locations,
 		cursor:           cursor,
+		precise:          precise,
 		locationResolver: locationResolver,
 	}
 }
@@ -29,3 +31,7 @@ func (r *LocationConnectionResolver) Nodes(ctx context.Context) ([]gql.LocationR
 func (r *LocationConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
 	return encodeCursor(r.cursor), nil
 }
+
+func (r *LocationConnectionResolver) Precise() bool {
+	return r.precise
+}
@@ -0,0 +1,153 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type PackageResolver struct {
+	scheme  string
+	name    string
+	version string
+}
+
+func (r *PackageResolver) Scheme() string  { return r.scheme }
+func (r *PackageResolver) Name() string    { return r.name }
+func (r *PackageResolver) Version() string { return r.version }
+
+type PackageDependencyResolver struct {
+	dependency       store.PackageDependency
+	locationResolver *CachedLocationResolver
+}
+
+func (r *PackageDependencyResolver) Package() gql.CodeIntelligencePackageResolver {
+	return &PackageResolver{
+		scheme:  r.dependency.Scheme,
+		name:    r.dependency.Name,
+		version: r.dependency.Version,
+	}
+}
+
+func (r *PackageDependencyResolver) Depth() int32 {
+	return int32(r.dependency.Depth)
+}
+
+func (r *PackageDependencyResolver) Providers(ctx context.Context) ([]*gql.RepositoryResolver, error) {
+	providers := make([]*gql.RepositoryResolver, 0, len(r.dependency.ProviderRepositoryIDs))
+	for _, id := range r.dependency.ProviderRepositoryIDs {
+		repository, err := r.locationResolver.Repository(ctx, api.RepoID(id))
+		if err != nil {
+			return nil, err
+		}
+		if repository != nil {
+			providers = append(providers, repository)
+		}
+	}
+
+	return providers, nil
+}
+
+type PackageDependencyConnectionResolver struct {
+	dependencies     []store.PackageDependency
+	totalCount       int
+	offset           int
+	locationResolver *CachedLocationResolver
+}
+
+// NewPackageDependencyConnectionResolver creates a connection resolver for a page of package dependencies
+// starting at the given offset.
+func NewPackageDependencyConnectionResolver(dependencies []store.PackageDependency, totalCount, offset int, locationResolver *CachedLocationResolver) gql.CodeIntelligencePackageDependencyConnectionResolver {
+	return &PackageDependencyConnectionResolver{
+		dependencies:     dependencies,
+		totalCount:       totalCount,
+		offset:           offset,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *PackageDependencyConnectionResolver) Nodes(ctx context.Context) ([]gql.CodeIntelligencePackageDependencyResolver, error) {
+	resolvers := make([]gql.CodeIntelligencePackageDependencyResolver, 0, len(r.dependencies))
+	for _, dependency := range r.dependencies {
+		resolvers = append(resolvers, &PackageDependencyResolver{dependency: dependency, locationResolver: r.locationResolver})
+	}
+
+	return resolvers, nil
+}
+
+func (r *PackageDependencyConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	return int32(r.totalCount), nil
+}
+
+func (r *PackageDependencyConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	return nextOffsetPageInfo(r.offset, len(r.dependencies), r.totalCount), nil
+}
+
+type PackageDependentResolver struct {
+	dependent        store.PackageDependent
+	locationResolver *CachedLocationResolver
+}
+
+func (r *PackageDependentResolver) Repository(ctx context.Context) (*gql.RepositoryResolver, error) {
+	return r.locationResolver.Repository(ctx, api.RepoID(r.dependent.RepositoryID))
+}
+
+func (r *PackageDependentResolver) Package() gql.CodeIntelligencePackageResolver {
+	return &PackageResolver{
+		scheme:  r.dependent.Scheme,
+		name:    r.dependent.Name,
+		version: r.dependent.Version,
+	}
+}
+
+func (r *PackageDependentResolver) Depth() int32 {
+	return int32(r.dependent.Depth)
+}
+
+type PackageDependentConnectionResolver struct {
+	dependents       []store.PackageDependent
+	totalCount       int
+	offset           int
+	locationResolver *CachedLocationResolver
+}
+
+// NewPackageDependentConnectionResolver creates a connection resolver for a page of package dependents
+// starting at the given offset.
+func NewPackageDependentConnectionResolver(dependents []store.PackageDependent, totalCount, offset int, locationResolver *CachedLocationResolver) gql.CodeIntelligencePackageDependentConnectionResolver {
+	return &PackageDependentConnectionResolver{
+		dependents:       dependents,
+		totalCount:       totalCount,
+		offset:           offset,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *PackageDependentConnectionResolver) Nodes(ctx context.Context) ([]gql.CodeIntelligencePackageDependentResolver, error) {
+	resolvers := make([]gql.CodeIntelligencePackageDependentResolver, 0, len(r.dependents))
+	for _, dependent := range r.dependents {
+		resolvers = append(resolvers, &PackageDependentResolver{dependent: dependent, locationResolver: r.locationResolver})
+	}
+
+	return resolvers, nil
+}
+
+func (r *PackageDependentConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	return int32(r.totalCount), nil
+}
+
+func (r *PackageDependentConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	return nextOffsetPageInfo(r.offset, len(r.dependents), r.totalCount), nil
+}
+
+// nextOffsetPageInfo returns the page info of a page of the given size starting at the given offset
+// into a result set of the given size. The end cursor encodes the offset of the next page.
+func nextOffsetPageInfo(offset, pageSize, totalCount int) *graphqlutil.PageInfo {
+	if nextOffset := offset + pageSize; nextOffset < totalCount {
+		return encodeIntCursor(toInt32(&nextOffset))
+	}
+
+	return graphqlutil.HasNextPage(false)
+}
@@ -27,6 +27,7 @@ var ErrIllegalBounds = errors.New("illegal bounds")
 // in the parent package.
 type QueryResolver struct {
 	resolver         resolvers.QueryResolver
+	precise          bool
 	locationResolver *CachedLocationResolver
 }
 
@@ -36,6 +37,17 @@ type QueryResolver struct {
 func NewQueryResolver(resolver resolvers.QueryResolver, locationResolver *CachedLocationResolver) gql.GitBlobLSIFDataResolver {
 	return &QueryResolver{
 		resolver:         resolver,
+		precise:          true,
+		locationResolver: locationResolver,
+	}
+}
+
+// NewSearchBasedQueryResolver creates a new QueryResolver with the given search-based resolver. The locations
+// returned by this resolver are marked as imprecise.
+func NewSearchBasedQueryResolver(resolver resolvers.QueryResolver, locationResolver *CachedLocationResolver) gql.GitBlobLSIFDataResolver {
+	return &QueryResolver{
+		resolver:         resolver,
+		precise:          false,
 		locationResolver: locationResolver,
 	}
 }
@@ -65,7 +77,7 @@ func (r *QueryResolver) Definitions(ctx context.Context, args *gql.LSIFQueryPosi
 		return nil, err
 	}
 
-	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
+	return NewLocationConnectionResolver(locations, nil, r.precise, r.locationResolver), nil
 }
 
 func (r *QueryResolver) References(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (gql.LocationConnectionResolver, error) {
@@ -83,7 +95,34 @@ func (r *QueryResolver) References(ctx context.Context, args *gql.LSIFPagedQuery
 		return nil, err
 	}
 
-	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
+	return NewLocationConnectionResolver(locations, strPtr(cursor), r.precise, r.locationResolver), nil
+}
+
+func (r *QueryResolver) Implementations(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (gql.LocationConnectionResolver, error) {
+	limit := derefInt32(args.First, DefaultReferencesPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+	cursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, cursor, err := r.resolver.Implementations(ctx, int(args.Line), int(args.Character), limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLocationConnectionResolver(locations, strPtr(cursor), r.precise, r.locationResolver), nil
+}
+
+func (r *QueryResolver) TypeDefinitions(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.LocationConnectionResolver, error) {
+	locations, err := r.resolver.TypeDefinitions(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLocationConnectionResolver(locations, nil, r.precise, r.locationResolver), nil
 }
 
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.HoverResolver, error) {
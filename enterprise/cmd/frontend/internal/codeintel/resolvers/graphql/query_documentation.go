@@ -112,7 +112,7 @@ func (r *QueryResolver) DocumentationDefinitions(ctx context.Context, args *gql.
 		return nil, err
 	}
 
-	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
+	return NewLocationConnectionResolver(locations, nil, true, r.locationResolver), nil
 }
 
 func (r *QueryResolver) DocumentationReferences(ctx context.Context, args *gql.LSIFPagedQueryDocumentationArgs) (gql.LocationConnectionResolver, error) {
@@ -130,5 +130,5 @@ func (r *QueryResolver) DocumentationReferences(ctx context.Context, args *gql.L
 		return nil, err
 	}
 
-	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
+	return NewLocationConnectionResolver(locations, strPtr(cursor), true, r.locationResolver), nil
 }
@@ -3,6 +3,7 @@ package graphql
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
@@ -17,12 +18,15 @@ import (
 )
 
 const (
-	DefaultUploadPageSize = 50
-	DefaultIndexPageSize  = 50
+	DefaultUploadPageSize            = 50
+	DefaultIndexPageSize             = 50
+	DefaultPackageDependencyPageSize = 100
 )
 
 var errAutoIndexingNotEnabled = errors.New("precise code intelligence auto indexing is not enabled")
 
+var errNegativeOffset = errors.New("invalid cursor: offset must not be negative")
+
 // Resolver is the main interface to code intel-related operations exposted to the GraphQL API. This
 // resolver concerns itself with GraphQL/API-specific behaviors (auth, validation, marshaling, etc.).
 // All code intel-specific behavior is delegated to the underlying resolver instance, which is defined
@@ -48,6 +52,9 @@ func (r *Resolver) NodeResolvers() map[string]gql.NodeByIDFunc {
 		"LSIFIndex": func(ctx context.Context, id graphql.ID) (gql.Node, error) {
 			return r.LSIFIndexByID(ctx, id)
 		},
+		"CodeIntelligenceRetentionPolicy": func(ctx context.Context, id graphql.ID) (gql.Node, error) {
+			return r.CodeIntelligenceRetentionPolicyByID(ctx, id)
+		},
 	}
 }
 
@@ -261,11 +268,187 @@ func (r *Resolver) QueueAutoIndexJobForRepo(ctx context.Context, args *struct{ R
 
 func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (gql.GitBlobLSIFDataResolver, error) {
 	resolver, err := r.resolver.QueryResolver(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if resolver != nil {
+		return NewQueryResolver(resolver, r.locationResolver), nil
+	}
+	if !args.SearchBasedFallback || args.ToolName != "" {
+		// Search-based results are opt-in and can't satisfy a request for data
+		// from a particular indexer
+		return nil, nil
+	}
+
+	resolver, err = r.resolver.SearchBasedQueryResolver(ctx, args)
 	if err != nil || resolver == nil {
 		return nil, err
 	}
 
-	return NewQueryResolver(resolver, r.locationResolver), nil
+	return NewSearchBasedQueryResolver(resolver, r.locationResolver), nil
+}
+
+func (r *Resolver) CodeIntelligenceRetentionPolicyByID(ctx context.Context, id graphql.ID) (gql.CodeIntelligenceRetentionPolicyResolver, error) {
+	// 🚨 SECURITY: Only site admins may see retention policies
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	policyID, err := unmarshalCodeIntelligenceRetentionPolicyGQLID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, exists, err := r.resolver.RetentionPolicyByID(ctx, int(policyID))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return NewRetentionPolicyResolver(policy), nil
+}
+
+func (r *Resolver) CodeIntelligenceRetentionPolicies(ctx context.Context) ([]gql.CodeIntelligenceRetentionPolicyResolver, error) {
+	// 🚨 SECURITY: Only site admins may see retention policies
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	retentionPolicies, err := r.resolver.RetentionPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]gql.CodeIntelligenceRetentionPolicyResolver, 0, len(retentionPolicies))
+	for _, policy := range retentionPolicies {
+		resolvers = append(resolvers, NewRetentionPolicyResolver(policy))
+	}
+
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateCodeIntelligenceRetentionPolicy(ctx context.Context, args *gql.CodeIntelligenceRetentionPolicyArgs) (gql.CodeIntelligenceRetentionPolicyResolver, error) {
+	// 🚨 SECURITY: Only site admins may configure retention policies
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	policy, err := r.resolver.CreateRetentionPolicy(ctx, makeRetentionPolicy(0, args))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRetentionPolicyResolver(policy), nil
+}
+
+func (r *Resolver) UpdateCodeIntelligenceRetentionPolicy(ctx context.Context, args *gql.UpdateCodeIntelligenceRetentionPolicyArgs) (gql.CodeIntelligenceRetentionPolicyResolver, error) {
+	// 🚨 SECURITY: Only site admins may configure retention policies
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	policyID, err := unmarshalCodeIntelligenceRetentionPolicyGQLID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, exists, err := r.resolver.UpdateRetentionPolicy(ctx, makeRetentionPolicy(int(policyID), &args.CodeIntelligenceRetentionPolicyArgs))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("retention policy %d not found", policyID)
+	}
+
+	return NewRetentionPolicyResolver(policy), nil
+}
+
+func (r *Resolver) DeleteCodeIntelligenceRetentionPolicy(ctx context.Context, args *struct{ ID graphql.ID }) (*gql.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may configure retention policies
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	policyID, err := unmarshalCodeIntelligenceRetentionPolicyGQLID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.resolver.DeleteRetentionPolicyByID(ctx, int(policyID)); err != nil {
+		return nil, err
+	}
+
+	return &gql.EmptyResponse{}, nil
+}
+
+func (r *Resolver) CodeIntelligenceDiagnostics(ctx context.Context, args *gql.CodeIntelligenceDiagnosticsArgs) (gql.DiagnosticConnectionResolver, error) {
+	// 🚨 SECURITY: Repository permissions are checked by the underlying store
+	opts, err := makeGetDiagnosticsOptions(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics, totalCount, err := r.resolver.Diagnostics(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPaginatedDiagnosticConnectionResolver(diagnostics, totalCount, opts.Offset, r.locationResolver), nil
+}
+
+func (r *Resolver) CodeIntelligencePackageDependencies(ctx context.Context, args *gql.CodeIntelligencePackageDependenciesArgs) (gql.CodeIntelligencePackageDependencyConnectionResolver, error) {
+	// 🚨 SECURITY: Repository permissions are checked by the underlying store
+	opts, err := makePackageDependenciesOptions(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, totalCount, err := r.resolver.PackageDependencies(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPackageDependencyConnectionResolver(dependencies, totalCount, opts.Offset, r.locationResolver), nil
+}
+
+func (r *Resolver) CodeIntelligencePackageDependents(ctx context.Context, args *gql.CodeIntelligencePackageDependentsArgs) (gql.CodeIntelligencePackageDependentConnectionResolver, error) {
+	// 🚨 SECURITY: Repository permissions are checked by the underlying store
+	opts, err := makePackageDependentsOptions(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dependents, totalCount, err := r.resolver.PackageDependents(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPackageDependentConnectionResolver(dependents, totalCount, opts.Offset, r.locationResolver), nil
+}
+
+// makeRetentionPolicy translates the given GraphQL arguments into a retention policy with the
+// given identifier.
+func makeRetentionPolicy(id int, args *gql.CodeIntelligenceRetentionPolicyArgs) store.RetentionPolicy {
+	var retentionDuration *time.Duration
+	if args.RetentionDurationHours != nil {
+		duration := time.Duration(*args.RetentionDurationHours) * time.Hour
+		retentionDuration = &duration
+	}
+
+	var retainCommitCount *int
+	if args.RetainCommitCount != nil {
+		count := int(*args.RetainCommitCount)
+		retainCommitCount = &count
+	}
+
+	return store.RetentionPolicy{
+		ID:                id,
+		Name:              args.Name,
+		RepositoryPattern: args.RepositoryPattern,
+		Type:              args.Type,
+		Pattern:           args.Pattern,
+		RetentionDuration: retentionDuration,
+		RetainCommitCount: retainCommitCount,
+	}
 }
 
 // makeGetUploadsOptions translates the given GraphQL arguments into options defined by the
@@ -313,6 +496,104 @@ func makeGetIndexesOptions(ctx context.Context, args *gql.LSIFRepositoryIndexesQ
 	}, nil
 }
 
+// makeGetDiagnosticsOptions translates the given GraphQL arguments into options defined by the
+// store.GetDiagnostics operation.
+func makeGetDiagnosticsOptions(ctx context.Context, args *gql.CodeIntelligenceDiagnosticsArgs) (store.GetDiagnosticsOptions, error) {
+	var repositoryIDs []int
+	if args.Repository != nil {
+		repositoryID, err := resolveRepositoryID(ctx, *args.Repository)
+		if err != nil {
+			return store.GetDiagnosticsOptions{}, err
+		}
+
+		repositoryIDs = append(repositoryIDs, repositoryID)
+	}
+
+	severity, err := fromSeverity(args.Severity)
+	if err != nil {
+		return store.GetDiagnosticsOptions{}, err
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return store.GetDiagnosticsOptions{}, err
+	}
+
+	return store.GetDiagnosticsOptions{
+		RepositoryIDs:  repositoryIDs,
+		Severity:       severity,
+		Source:         derefString(args.Source, ""),
+		Code:           derefString(args.Code, ""),
+		MessagePattern: derefString(args.Query, ""),
+		Limit:          derefInt32(args.First, DefaultDiagnosticsPageSize),
+		Offset:         offset,
+	}, nil
+}
+
+// makePackageDependenciesOptions translates the given GraphQL arguments into options defined by the
+// resolvers.PackageDependencies operation.
+func makePackageDependenciesOptions(ctx context.Context, args *gql.CodeIntelligencePackageDependenciesArgs) (resolvers.PackageDependenciesOptions, error) {
+	repositoryID, err := resolveRepositoryID(ctx, args.Repository)
+	if err != nil {
+		return resolvers.PackageDependenciesOptions{}, err
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return resolvers.PackageDependenciesOptions{}, err
+	}
+	if offset < 0 {
+		return resolvers.PackageDependenciesOptions{}, errNegativeOffset
+	}
+
+	return resolvers.PackageDependenciesOptions{
+		RepositoryID: repositoryID,
+		MaxDepth:     makeDependencyGraphDepth(args.Transitive, args.MaxDepth),
+		Scheme:       derefString(args.Scheme, ""),
+		Name:         derefString(args.Name, ""),
+		PackageVersionFilter: resolvers.PackageVersionFilter{
+			Version:           derefString(args.Version, ""),
+			VersionConstraint: derefString(args.VersionConstraint, ""),
+		},
+		Limit:  derefInt32(args.First, DefaultPackageDependencyPageSize),
+		Offset: offset,
+	}, nil
+}
+
+// makePackageDependentsOptions translates the given GraphQL arguments into options defined by the
+// resolvers.PackageDependents operation.
+func makePackageDependentsOptions(args *gql.CodeIntelligencePackageDependentsArgs) (resolvers.PackageDependentsOptions, error) {
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return resolvers.PackageDependentsOptions{}, err
+	}
+	if offset < 0 {
+		return resolvers.PackageDependentsOptions{}, errNegativeOffset
+	}
+
+	return resolvers.PackageDependentsOptions{
+		Scheme: args.Scheme,
+		Name:   args.Name,
+		PackageVersionFilter: resolvers.PackageVersionFilter{
+			Version:           derefString(args.Version, ""),
+			VersionConstraint: derefString(args.VersionConstraint, ""),
+		},
+		MaxDepth: makeDependencyGraphDepth(args.Transitive, args.MaxDepth),
+		Limit:    derefInt32(args.First, DefaultPackageDependencyPageSize),
+		Offset:   offset,
+	}, nil
+}
+
+// makeDependencyGraphDepth returns the maximum depth of a dependency graph traversal. Non-transitive
+// traversals only return direct dependencies or dependents.
+func makeDependencyGraphDepth(transitive bool, maxDepth *int32) int {
+	if !transitive {
+		return 1
+	}
+
+	return derefInt32(maxDepth, store.MaximumDependencyGraphDepth)
+}
+
 // resolveRepositoryByID gets a repository's internal identifier from a GraphQL identifier.
 func resolveRepositoryID(ctx context.Context, id graphql.ID) (int, error) {
 	if id == "" {
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -95,6 +96,47 @@ func TestDeleteLSIFIndexUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestGitBlobLSIFDataSearchBasedFallback(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.SearchBasedQueryResolverFunc.SetDefaultReturn(resolvermocks.NewMockQueryResolver(), nil)
+
+	args := &gql.GitBlobLSIFDataArgs{Repo: &types.Repo{ID: 50}, Commit: api.CommitID("deadbeef"), Path: "main.go"}
+	if resolver, err := NewResolver(db, mockResolver).GitBlobLSIFData(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if resolver != nil {
+		t.Errorf("expected nil-valued resolver when the fallback is not requested")
+	}
+
+	args.SearchBasedFallback = true
+	resolver, err := NewResolver(db, mockResolver).GitBlobLSIFData(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resolver == nil {
+		t.Fatalf("expected a search-based resolver")
+	}
+
+	connection, err := resolver.Definitions(context.Background(), &gql.LSIFQueryPositionArgs{Line: 10, Character: 15})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if connection.Precise() {
+		t.Errorf("expected imprecise locations")
+	}
+
+	args.ToolName = "lsif-go"
+	if resolver, err := NewResolver(db, mockResolver).GitBlobLSIFData(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if resolver != nil {
+		t.Errorf("expected nil-valued resolver when a tool name is given")
+	}
+	if len(mockResolver.SearchBasedQueryResolverFunc.History()) != 1 {
+		t.Errorf("unexpected call count. want=%d have=%d", 1, len(mockResolver.SearchBasedQueryResolverFunc.History()))
+	}
+}
+
 func TestMakeGetUploadsOptions(t *testing.T) {
 	t.Cleanup(func() {
 		database.Mocks.Repos.Get = nil
@@ -213,3 +255,76 @@ func TestMakeGetIndexesOptionsDefaults(t *testing.T) {
 		t.Errorf("unexpected opts (-want +got):\n%s", diff)
 	}
 }
+
+func TestMakePackageDependentsOptions(t *testing.T) {
+	testCases := []struct {
+		args     gql.CodeIntelligencePackageDependentsArgs
+		expected resolvers.PackageDependentsOptions
+	}{
+		{
+			args: gql.CodeIntelligencePackageDependentsArgs{Scheme: "npm", Name: "leftpad"},
+			expected: resolvers.PackageDependentsOptions{
+				Scheme:   "npm",
+				Name:     "leftpad",
+				MaxDepth: 1,
+				Limit:    DefaultPackageDependencyPageSize,
+			},
+		},
+		{
+			args: gql.CodeIntelligencePackageDependentsArgs{
+				ConnectionArgs:    graphqlutil.ConnectionArgs{First: intPtr(5)},
+				Scheme:            "npm",
+				Name:              "leftpad",
+				VersionConstraint: strPtr("< 2.0.0"),
+				Transitive:        true,
+				After:             encodeIntCursor(intPtr(25)).EndCursor(),
+			},
+			expected: resolvers.PackageDependentsOptions{
+				Scheme:               "npm",
+				Name:                 "leftpad",
+				PackageVersionFilter: resolvers.PackageVersionFilter{VersionConstraint: "< 2.0.0"},
+				MaxDepth:             store.MaximumDependencyGraphDepth,
+				Limit:                5,
+				Offset:               25,
+			},
+		},
+		{
+			args: gql.CodeIntelligencePackageDependentsArgs{
+				Scheme:     "npm",
+				Name:       "leftpad",
+				Version:    strPtr("1.0.0"),
+				Transitive: true,
+				MaxDepth:   intPtr(3),
+			},
+			expected: resolvers.PackageDependentsOptions{
+				Scheme:               "npm",
+				Name:                 "leftpad",
+				PackageVersionFilter: resolvers.PackageVersionFilter{Version: "1.0.0"},
+				MaxDepth:             3,
+				Limit:                DefaultPackageDependencyPageSize,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		opts, err := makePackageDependentsOptions(&testCase.args)
+		if err != nil {
+			t.Fatalf("unexpected error making options: %s", err)
+		}
+
+		if diff := cmp.Diff(testCase.expected, opts); diff != "" {
+			t.Errorf("unexpected opts (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestMakePackageDependencyOptionsNegativeOffset(t *testing.T) {
+	after := encodeIntCursor(intPtr(-1)).EndCursor()
+
+	if _, err := makePackageDependenciesOptions(context.Background(), &gql.CodeIntelligencePackageDependenciesArgs{After: after}); err != errNegativeOffset {
+		t.Errorf("unexpected error for dependencies. want=%q have=%v", errNegativeOffset, err)
+	}
+	if _, err := makePackageDependentsOptions(&gql.CodeIntelligencePackageDependentsArgs{Scheme: "npm", Name: "leftpad", After: after}); err != errNegativeOffset {
+		t.Errorf("unexpected error for dependents. want=%q have=%v", errNegativeOffset, err)
+	}
+}
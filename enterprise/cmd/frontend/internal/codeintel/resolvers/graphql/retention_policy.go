@@ -0,0 +1,76 @@
+package graphql
+
+import (
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+type RetentionPolicyResolver struct {
+	policy store.RetentionPolicy
+}
+
+func NewRetentionPolicyResolver(policy store.RetentionPolicy) gql.CodeIntelligenceRetentionPolicyResolver {
+	return &RetentionPolicyResolver{policy: policy}
+}
+
+func (r *RetentionPolicyResolver) ID() graphql.ID {
+	return marshalCodeIntelligenceRetentionPolicyGQLID(int64(r.policy.ID))
+}
+
+func (r *RetentionPolicyResolver) Name() string               { return r.policy.Name }
+func (r *RetentionPolicyResolver) RepositoryPattern() *string { return r.policy.RepositoryPattern }
+func (r *RetentionPolicyResolver) Type() string               { return r.policy.Type }
+func (r *RetentionPolicyResolver) Pattern() string            { return r.policy.Pattern }
+func (r *RetentionPolicyResolver) RetainCommitCount() *int32 {
+	return toInt32(r.policy.RetainCommitCount)
+}
+
+func (r *RetentionPolicyResolver) RetentionDurationHours() *int32 {
+	if r.policy.RetentionDuration == nil {
+		return nil
+	}
+
+	return intPtr(int32(*r.policy.RetentionDuration / time.Hour))
+}
+
+type UploadRetentionResolver struct {
+	retention policies.Retention
+	governed  bool
+}
+
+func NewUploadRetentionResolver(retention policies.Retention, governed bool) gql.LSIFUploadRetentionResolver {
+	return &UploadRetentionResolver{retention: retention, governed: governed}
+}
+
+func (r *UploadRetentionResolver) Retained() bool           { return r.retention.Retained }
+func (r *UploadRetentionResolver) GovernedByPolicies() bool { return r.governed }
+func (r *UploadRetentionResolver) ExpiresAt() *gql.DateTime {
+	return gql.DateTimeOrNil(r.retention.ExpiresAt())
+}
+
+func (r *UploadRetentionResolver) Reasons() []gql.LSIFUploadRetentionReasonResolver {
+	resolvers := make([]gql.LSIFUploadRetentionReasonResolver, 0, len(r.retention.Reasons))
+	for _, reason := range r.retention.Reasons {
+		resolvers = append(resolvers, &UploadRetentionReasonResolver{reason: reason})
+	}
+
+	return resolvers
+}
+
+type UploadRetentionReasonResolver struct {
+	reason policies.Reason
+}
+
+func (r *UploadRetentionReasonResolver) Policy() gql.CodeIntelligenceRetentionPolicyResolver {
+	return NewRetentionPolicyResolver(r.reason.Policy)
+}
+
+func (r *UploadRetentionReasonResolver) RefName() string { return r.reason.RefName }
+func (r *UploadRetentionReasonResolver) ExpiresAt() *gql.DateTime {
+	return gql.DateTimeOrNil(r.reason.ExpiresAt)
+}
@@ -70,3 +70,12 @@ func (r *UploadResolver) AssociatedIndex(ctx context.Context) (gql.LSIFIndexReso
 func (r *UploadResolver) ProjectRoot(ctx context.Context) (*gql.GitTreeEntryResolver, error) {
 	return r.locationResolver.Path(ctx, api.RepoID(r.upload.RepositoryID), r.upload.Commit, r.upload.Root)
 }
+
+func (r *UploadResolver) Retention(ctx context.Context) (gql.LSIFUploadRetentionResolver, error) {
+	retention, governed, err := r.prefetcher.resolver.UploadRetention(ctx, r.upload)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUploadRetentionResolver(retention, governed), nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
@@ -16,6 +17,14 @@ import (
 type GitserverClient interface {
 	CommitExists(ctx context.Context, repositoryID int, commit string) (bool, error)
 	CommitGraph(ctx context.Context, repositoryID int, options gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)
+	RawContents(ctx context.Context, repositoryID int, commit, file string) ([]byte, error)
+	Refs(ctx context.Context, repositoryID int) ([]gitserver.Ref, error)
+	CommitsOnBranch(ctx context.Context, repositoryID int, branchName string, maxCount int, since time.Time) ([]gitserver.BranchCommit, error)
+}
+
+// SearchClient runs search queries on behalf of the search-based code intelligence fallback.
+type SearchClient interface {
+	Search(ctx context.Context, query string) ([]result.Match, error)
 }
 
 type DBStore interface {
@@ -26,6 +35,10 @@ type DBStore interface {
 	GetUploads(ctx context.Context, opts dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
 	DeleteUploadByID(ctx context.Context, id int) (bool, error)
 	GetDumpsByIDs(ctx context.Context, ids []int) ([]dbstore.Dump, error)
+	GetDiagnostics(ctx context.Context, opts dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error)
+	GetPackageDependencies(ctx context.Context, opts dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error)
+	GetPackageDependents(ctx context.Context, opts dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error)
+	PackageReferenceVersions(ctx context.Context, scheme, name string) ([]string, error)
 	FindClosestDumps(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string) ([]dbstore.Dump, error)
 	FindClosestDumpsFromGraphFragment(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string, graph *gitserver.CommitGraph) ([]dbstore.Dump, error)
 	DefinitionDumps(ctx context.Context, monikers []semantic.QualifiedMonikerData) (_ []dbstore.Dump, err error)
@@ -40,6 +53,11 @@ type DBStore interface {
 	DeleteIndexByID(ctx context.Context, id int) (bool, error)
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (store.IndexConfiguration, bool, error)
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, data []byte) error
+	GetRetentionPolicies(ctx context.Context) ([]dbstore.RetentionPolicy, error)
+	GetRetentionPolicyByID(ctx context.Context, id int) (dbstore.RetentionPolicy, bool, error)
+	CreateRetentionPolicy(ctx context.Context, policy dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)
+	UpdateRetentionPolicy(ctx context.Context, policy dbstore.RetentionPolicy, now time.Time) (dbstore.RetentionPolicy, bool, error)
+	DeleteRetentionPolicyByID(ctx context.Context, id int) (bool, error)
 }
 
 type LSIFStore interface {
@@ -47,6 +65,8 @@ type LSIFStore interface {
 	Ranges(ctx context.Context, bundleID int, path string, startLine, endLine int) ([]lsifstore.CodeIntelligenceRange, error)
 	Definitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	References(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+	Implementations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+	TypeDefinitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	Hover(ctx context.Context, bundleID int, path string, line, character int) (string, lsifstore.Range, bool, error)
 	Diagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]lsifstore.Diagnostic, int, error)
 	MonikersByPosition(ctx context.Context, bundleID int, path string, line, character int) ([][]semantic.MonikerData, error)
@@ -15,6 +15,7 @@ import (
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	protocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	result "github.com/sourcegraph/sourcegraph/internal/search/result"
 	config "github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	semantic "github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
@@ -27,12 +28,19 @@ type MockDBStore struct {
 	// CommitGraphMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method CommitGraphMetadata.
 	CommitGraphMetadataFunc *DBStoreCommitGraphMetadataFunc
+	// CreateRetentionPolicyFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateRetentionPolicy.
+	CreateRetentionPolicyFunc *DBStoreCreateRetentionPolicyFunc
 	// DefinitionDumpsFunc is an instance of a mock function object
 	// controlling the behavior of the method DefinitionDumps.
 	DefinitionDumpsFunc *DBStoreDefinitionDumpsFunc
 	// DeleteIndexByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteIndexByID.
 	DeleteIndexByIDFunc *DBStoreDeleteIndexByIDFunc
+	// DeleteRetentionPolicyByIDFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteRetentionPolicyByID.
+	DeleteRetentionPolicyByIDFunc *DBStoreDeleteRetentionPolicyByIDFunc
 	// DeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploadByID.
 	DeleteUploadByIDFunc *DBStoreDeleteUploadByIDFunc
@@ -43,6 +51,9 @@ type MockDBStore struct {
 	// function object controlling the behavior of the method
 	// FindClosestDumpsFromGraphFragment.
 	FindClosestDumpsFromGraphFragmentFunc *DBStoreFindClosestDumpsFromGraphFragmentFunc
+	// GetDiagnosticsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDiagnostics.
+	GetDiagnosticsFunc *DBStoreGetDiagnosticsFunc
 	// GetDumpsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDumpsByIDs.
 	GetDumpsByIDsFunc *DBStoreGetDumpsByIDsFunc
@@ -59,6 +70,18 @@ type MockDBStore struct {
 	// GetIndexesByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetIndexesByIDs.
 	GetIndexesByIDsFunc *DBStoreGetIndexesByIDsFunc
+	// GetPackageDependenciesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPackageDependencies.
+	GetPackageDependenciesFunc *DBStoreGetPackageDependenciesFunc
+	// GetPackageDependentsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPackageDependents.
+	GetPackageDependentsFunc *DBStoreGetPackageDependentsFunc
+	// GetRetentionPoliciesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRetentionPolicies.
+	GetRetentionPoliciesFunc *DBStoreGetRetentionPoliciesFunc
+	// GetRetentionPolicyByIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRetentionPolicyByID.
+	GetRetentionPolicyByIDFunc *DBStoreGetRetentionPolicyByIDFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *DBStoreGetUploadByIDFunc
@@ -77,6 +100,9 @@ type MockDBStore struct {
 	// MarkRepositoryAsDirtyFunc is an instance of a mock function object
 	// controlling the behavior of the method MarkRepositoryAsDirty.
 	MarkRepositoryAsDirtyFunc *DBStoreMarkRepositoryAsDirtyFunc
+	// PackageReferenceVersionsFunc is an instance of a mock function object
+	// controlling the behavior of the method PackageReferenceVersions.
+	PackageReferenceVersionsFunc *DBStorePackageReferenceVersionsFunc
 	// ReferenceIDsAndFiltersFunc is an instance of a mock function object
 	// controlling the behavior of the method ReferenceIDsAndFilters.
 	ReferenceIDsAndFiltersFunc *DBStoreReferenceIDsAndFiltersFunc
@@ -87,6 +113,9 @@ type MockDBStore struct {
 	// function object controlling the behavior of the method
 	// UpdateIndexConfigurationByRepositoryID.
 	UpdateIndexConfigurationByRepositoryIDFunc *DBStoreUpdateIndexConfigurationByRepositoryIDFunc
+	// UpdateRetentionPolicyFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateRetentionPolicy.
+	UpdateRetentionPolicyFunc *DBStoreUpdateRetentionPolicyFunc
 }
 
 // NewMockDBStore creates a new mock of the DBStore interface. All methods
@@ -98,6 +127,11 @@ func NewMockDBStore() *MockDBStore {
 				return false, nil, nil
 			},
 		},
+		CreateRetentionPolicyFunc: &DBStoreCreateRetentionPolicyFunc{
+			defaultHook: func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+				return dbstore.RetentionPolicy{}, nil
+			},
+		},
 		DefinitionDumpsFunc: &DBStoreDefinitionDumpsFunc{
 			defaultHook: func(context.Context, []semantic.QualifiedMonikerData) ([]dbstore.Dump, error) {
 				return nil, nil
@@ -108,6 +142,11 @@ func NewMockDBStore() *MockDBStore {
 				return false, nil
 			},
 		},
+		DeleteRetentionPolicyByIDFunc: &DBStoreDeleteRetentionPolicyByIDFunc{
+			defaultHook: func(context.Context, int) (bool, error) {
+				return false, nil
+			},
+		},
 		DeleteUploadByIDFunc: &DBStoreDeleteUploadByIDFunc{
 			defaultHook: func(context.Context, int) (bool, error) {
 				return false, nil
@@ -123,6 +162,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil, nil
 			},
 		},
+		GetDiagnosticsFunc: &DBStoreGetDiagnosticsFunc{
+			defaultHook: func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error) {
+				return nil, 0, nil
+			},
+		},
 		GetDumpsByIDsFunc: &DBStoreGetDumpsByIDsFunc{
 			defaultHook: func(context.Context, []int) ([]dbstore.Dump, error) {
 				return nil, nil
@@ -148,6 +192,26 @@ func NewMockDBStore() *MockDBStore {
 				return nil, nil
 			},
 		},
+		GetPackageDependenciesFunc: &DBStoreGetPackageDependenciesFunc{
+			defaultHook: func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error) {
+				return nil, nil
+			},
+		},
+		GetPackageDependentsFunc: &DBStoreGetPackageDependentsFunc{
+			defaultHook: func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error) {
+				return nil, nil
+			},
+		},
+		GetRetentionPoliciesFunc: &DBStoreGetRetentionPoliciesFunc{
+			defaultHook: func(context.Context) ([]dbstore.RetentionPolicy, error) {
+				return nil, nil
+			},
+		},
+		GetRetentionPolicyByIDFunc: &DBStoreGetRetentionPolicyByIDFunc{
+			defaultHook: func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
+				return dbstore.RetentionPolicy{}, false, nil
+			},
+		},
 		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (dbstore.Upload, bool, error) {
 				return dbstore.Upload{}, false, nil
@@ -178,6 +242,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		PackageReferenceVersionsFunc: &DBStorePackageReferenceVersionsFunc{
+			defaultHook: func(context.Context, string, string) ([]string, error) {
+				return nil, nil
+			},
+		},
 		ReferenceIDsAndFiltersFunc: &DBStoreReferenceIDsAndFiltersFunc{
 			defaultHook: func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
 				return nil, 0, nil
@@ -193,6 +262,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		UpdateRetentionPolicyFunc: &DBStoreUpdateRetentionPolicyFunc{
+			defaultHook: func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error) {
+				return dbstore.RetentionPolicy{}, false, nil
+			},
+		},
 	}
 }
 
@@ -203,12 +277,18 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		CommitGraphMetadataFunc: &DBStoreCommitGraphMetadataFunc{
 			defaultHook: i.CommitGraphMetadata,
 		},
+		CreateRetentionPolicyFunc: &DBStoreCreateRetentionPolicyFunc{
+			defaultHook: i.CreateRetentionPolicy,
+		},
 		DefinitionDumpsFunc: &DBStoreDefinitionDumpsFunc{
 			defaultHook: i.DefinitionDumps,
 		},
 		DeleteIndexByIDFunc: &DBStoreDeleteIndexByIDFunc{
 			defaultHook: i.DeleteIndexByID,
 		},
+		DeleteRetentionPolicyByIDFunc: &DBStoreDeleteRetentionPolicyByIDFunc{
+			defaultHook: i.DeleteRetentionPolicyByID,
+		},
 		DeleteUploadByIDFunc: &DBStoreDeleteUploadByIDFunc{
 			defaultHook: i.DeleteUploadByID,
 		},
@@ -218,6 +298,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		FindClosestDumpsFromGraphFragmentFunc: &DBStoreFindClosestDumpsFromGraphFragmentFunc{
 			defaultHook: i.FindClosestDumpsFromGraphFragment,
 		},
+		GetDiagnosticsFunc: &DBStoreGetDiagnosticsFunc{
+			defaultHook: i.GetDiagnostics,
+		},
 		GetDumpsByIDsFunc: &DBStoreGetDumpsByIDsFunc{
 			defaultHook: i.GetDumpsByIDs,
 		},
@@ -233,6 +316,18 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		GetIndexesByIDsFunc: &DBStoreGetIndexesByIDsFunc{
 			defaultHook: i.GetIndexesByIDs,
 		},
+		GetPackageDependenciesFunc: &DBStoreGetPackageDependenciesFunc{
+			defaultHook: i.GetPackageDependencies,
+		},
+		GetPackageDependentsFunc: &DBStoreGetPackageDependentsFunc{
+			defaultHook: i.GetPackageDependents,
+		},
+		GetRetentionPoliciesFunc: &DBStoreGetRetentionPoliciesFunc{
+			defaultHook: i.GetRetentionPolicies,
+		},
+		GetRetentionPolicyByIDFunc: &DBStoreGetRetentionPolicyByIDFunc{
+			defaultHook: i.GetRetentionPolicyByID,
+		},
 		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
@@ -251,6 +346,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		MarkRepositoryAsDirtyFunc: &DBStoreMarkRepositoryAsDirtyFunc{
 			defaultHook: i.MarkRepositoryAsDirty,
 		},
+		PackageReferenceVersionsFunc: &DBStorePackageReferenceVersionsFunc{
+			defaultHook: i.PackageReferenceVersions,
+		},
 		ReferenceIDsAndFiltersFunc: &DBStoreReferenceIDsAndFiltersFunc{
 			defaultHook: i.ReferenceIDsAndFilters,
 		},
@@ -260,6 +358,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		UpdateIndexConfigurationByRepositoryIDFunc: &DBStoreUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: i.UpdateIndexConfigurationByRepositoryID,
 		},
+		UpdateRetentionPolicyFunc: &DBStoreUpdateRetentionPolicyFunc{
+			defaultHook: i.UpdateRetentionPolicy,
+		},
 	}
 }
 
@@ -375,6 +476,116 @@ func (c DBStoreCommitGraphMetadataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// DBStoreCreateRetentionPolicyFunc describes the behavior when the
+// CreateRetentionPolicy method of the parent MockDBStore instance is
+// invoked.
+type DBStoreCreateRetentionPolicyFunc struct {
+	defaultHook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)
+	hooks       []func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)
+	history     []DBStoreCreateRetentionPolicyFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateRetentionPolicy delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) CreateRetentionPolicy(v0 context.Context, v1 dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+	r0, r1 := m.CreateRetentionPolicyFunc.nextHook()(v0, v1)
+	m.CreateRetentionPolicyFunc.appendCall(DBStoreCreateRetentionPolicyFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateRetentionPolicy method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreCreateRetentionPolicyFunc) SetDefaultHook(hook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateRetentionPolicy method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreCreateRetentionPolicyFunc) PushHook(hook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreCreateRetentionPolicyFunc) SetDefaultReturn(r0 dbstore.RetentionPolicy, r1 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreCreateRetentionPolicyFunc) PushReturn(r0 dbstore.RetentionPolicy, r1 error) {
+	f.PushHook(func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreCreateRetentionPolicyFunc) nextHook() func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreCreateRetentionPolicyFunc) appendCall(r0 DBStoreCreateRetentionPolicyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreCreateRetentionPolicyFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreCreateRetentionPolicyFunc) History() []DBStoreCreateRetentionPolicyFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreCreateRetentionPolicyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreCreateRetentionPolicyFuncCall is an object that describes an
+// invocation of method CreateRetentionPolicy on an instance of MockDBStore.
+type DBStoreCreateRetentionPolicyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.RetentionPolicy
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.RetentionPolicy
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreCreateRetentionPolicyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreCreateRetentionPolicyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreDefinitionDumpsFunc describes the behavior when the
 // DefinitionDumps method of the parent MockDBStore instance is invoked.
 type DBStoreDefinitionDumpsFunc struct {
@@ -593,6 +804,118 @@ func (c DBStoreDeleteIndexByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DBStoreDeleteRetentionPolicyByIDFunc describes the behavior when the
+// DeleteRetentionPolicyByID method of the parent MockDBStore instance is
+// invoked.
+type DBStoreDeleteRetentionPolicyByIDFunc struct {
+	defaultHook func(context.Context, int) (bool, error)
+	hooks       []func(context.Context, int) (bool, error)
+	history     []DBStoreDeleteRetentionPolicyByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteRetentionPolicyByID delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) DeleteRetentionPolicyByID(v0 context.Context, v1 int) (bool, error) {
+	r0, r1 := m.DeleteRetentionPolicyByIDFunc.nextHook()(v0, v1)
+	m.DeleteRetentionPolicyByIDFunc.appendCall(DBStoreDeleteRetentionPolicyByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteRetentionPolicyByID method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreDeleteRetentionPolicyByIDFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteRetentionPolicyByID method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreDeleteRetentionPolicyByIDFunc) PushHook(hook func(context.Context, int) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreDeleteRetentionPolicyByIDFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreDeleteRetentionPolicyByIDFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreDeleteRetentionPolicyByIDFunc) nextHook() func(context.Context, int) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreDeleteRetentionPolicyByIDFunc) appendCall(r0 DBStoreDeleteRetentionPolicyByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreDeleteRetentionPolicyByIDFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreDeleteRetentionPolicyByIDFunc) History() []DBStoreDeleteRetentionPolicyByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreDeleteRetentionPolicyByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreDeleteRetentionPolicyByIDFuncCall is an object that describes an
+// invocation of method DeleteRetentionPolicyByID on an instance of
+// MockDBStore.
+type DBStoreDeleteRetentionPolicyByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreDeleteRetentionPolicyByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreDeleteRetentionPolicyByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreDeleteUploadByIDFunc describes the behavior when the
 // DeleteUploadByID method of the parent MockDBStore instance is invoked.
 type DBStoreDeleteUploadByIDFunc struct {
@@ -951,35 +1274,35 @@ func (c DBStoreFindClosestDumpsFromGraphFragmentFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreGetDumpsByIDsFunc describes the behavior when the GetDumpsByIDs
+// DBStoreGetDiagnosticsFunc describes the behavior when the GetDiagnostics
 // method of the parent MockDBStore instance is invoked.
-type DBStoreGetDumpsByIDsFunc struct {
-	defaultHook func(context.Context, []int) ([]dbstore.Dump, error)
-	hooks       []func(context.Context, []int) ([]dbstore.Dump, error)
-	history     []DBStoreGetDumpsByIDsFuncCall
+type DBStoreGetDiagnosticsFunc struct {
+	defaultHook func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error)
+	hooks       []func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error)
+	history     []DBStoreGetDiagnosticsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetDumpsByIDs delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockDBStore) GetDumpsByIDs(v0 context.Context, v1 []int) ([]dbstore.Dump, error) {
-	r0, r1 := m.GetDumpsByIDsFunc.nextHook()(v0, v1)
-	m.GetDumpsByIDsFunc.appendCall(DBStoreGetDumpsByIDsFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// GetDiagnostics delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetDiagnostics(v0 context.Context, v1 dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error) {
+	r0, r1, r2 := m.GetDiagnosticsFunc.nextHook()(v0, v1)
+	m.GetDiagnosticsFunc.appendCall(DBStoreGetDiagnosticsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetDumpsByIDs method
-// of the parent MockDBStore instance is invoked and the hook queue is
-// empty.
-func (f *DBStoreGetDumpsByIDsFunc) SetDefaultHook(hook func(context.Context, []int) ([]dbstore.Dump, error)) {
+// SetDefaultHook sets function that is called when the GetDiagnostics
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreGetDiagnosticsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetDumpsByIDs method of the parent MockDBStore instance invokes the hook
+// GetDiagnostics method of the parent MockDBStore instance invokes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *DBStoreGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([]dbstore.Dump, error)) {
+func (f *DBStoreGetDiagnosticsFunc) PushHook(hook func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -987,21 +1310,21 @@ func (f *DBStoreGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreGetDumpsByIDsFunc) SetDefaultReturn(r0 []dbstore.Dump, r1 error) {
-	f.SetDefaultHook(func(context.Context, []int) ([]dbstore.Dump, error) {
-		return r0, r1
+func (f *DBStoreGetDiagnosticsFunc) SetDefaultReturn(r0 []dbstore.Diagnostic, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreGetDumpsByIDsFunc) PushReturn(r0 []dbstore.Dump, r1 error) {
-	f.PushHook(func(context.Context, []int) ([]dbstore.Dump, error) {
-		return r0, r1
+func (f *DBStoreGetDiagnosticsFunc) PushReturn(r0 []dbstore.Diagnostic, r1 int, r2 error) {
+	f.PushHook(func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *DBStoreGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]dbstore.Dump, error) {
+func (f *DBStoreGetDiagnosticsFunc) nextHook() func(context.Context, dbstore.GetDiagnosticsOptions) ([]dbstore.Diagnostic, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1014,57 +1337,169 @@ func (f *DBStoreGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]db
 	return hook
 }
 
-func (f *DBStoreGetDumpsByIDsFunc) appendCall(r0 DBStoreGetDumpsByIDsFuncCall) {
+func (f *DBStoreGetDiagnosticsFunc) appendCall(r0 DBStoreGetDiagnosticsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreGetDumpsByIDsFuncCall objects
+// History returns a sequence of DBStoreGetDiagnosticsFuncCall objects
 // describing the invocations of this function.
-func (f *DBStoreGetDumpsByIDsFunc) History() []DBStoreGetDumpsByIDsFuncCall {
+func (f *DBStoreGetDiagnosticsFunc) History() []DBStoreGetDiagnosticsFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreGetDumpsByIDsFuncCall, len(f.history))
+	history := make([]DBStoreGetDiagnosticsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreGetDumpsByIDsFuncCall is an object that describes an invocation of
-// method GetDumpsByIDs on an instance of MockDBStore.
-type DBStoreGetDumpsByIDsFuncCall struct {
+// DBStoreGetDiagnosticsFuncCall is an object that describes an invocation
+// of method GetDiagnostics on an instance of MockDBStore.
+type DBStoreGetDiagnosticsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 []int
+	Arg1 dbstore.GetDiagnosticsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []dbstore.Dump
+	Result0 []dbstore.Diagnostic
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreGetDumpsByIDsFuncCall) Args() []interface{} {
+func (c DBStoreGetDiagnosticsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreGetDumpsByIDsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c DBStoreGetDiagnosticsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// DBStoreGetIndexByIDFunc describes the behavior when the GetIndexByID
+// DBStoreGetDumpsByIDsFunc describes the behavior when the GetDumpsByIDs
 // method of the parent MockDBStore instance is invoked.
-type DBStoreGetIndexByIDFunc struct {
-	defaultHook func(context.Context, int) (dbstore.Index, bool, error)
-	hooks       []func(context.Context, int) (dbstore.Index, bool, error)
+type DBStoreGetDumpsByIDsFunc struct {
+	defaultHook func(context.Context, []int) ([]dbstore.Dump, error)
+	hooks       []func(context.Context, []int) ([]dbstore.Dump, error)
+	history     []DBStoreGetDumpsByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDumpsByIDs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) GetDumpsByIDs(v0 context.Context, v1 []int) ([]dbstore.Dump, error) {
+	r0, r1 := m.GetDumpsByIDsFunc.nextHook()(v0, v1)
+	m.GetDumpsByIDsFunc.appendCall(DBStoreGetDumpsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDumpsByIDs method
+// of the parent MockDBStore instance is invoked and the hook queue is
+// empty.
+func (f *DBStoreGetDumpsByIDsFunc) SetDefaultHook(hook func(context.Context, []int) ([]dbstore.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDumpsByIDs method of the parent MockDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([]dbstore.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreGetDumpsByIDsFunc) SetDefaultReturn(r0 []dbstore.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) ([]dbstore.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreGetDumpsByIDsFunc) PushReturn(r0 []dbstore.Dump, r1 error) {
+	f.PushHook(func(context.Context, []int) ([]dbstore.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]dbstore.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetDumpsByIDsFunc) appendCall(r0 DBStoreGetDumpsByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetDumpsByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetDumpsByIDsFunc) History() []DBStoreGetDumpsByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetDumpsByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetDumpsByIDsFuncCall is an object that describes an invocation of
+// method GetDumpsByIDs on an instance of MockDBStore.
+type DBStoreGetDumpsByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetDumpsByIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetDumpsByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DBStoreGetIndexByIDFunc describes the behavior when the GetIndexByID
+// method of the parent MockDBStore instance is invoked.
+type DBStoreGetIndexByIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.Index, bool, error)
+	hooks       []func(context.Context, int) (dbstore.Index, bool, error)
 	history     []DBStoreGetIndexByIDFuncCall
 	mutex       sync.Mutex
 }
@@ -1516,35 +1951,36 @@ func (c DBStoreGetIndexesByIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreGetUploadByIDFunc describes the behavior when the GetUploadByID
-// method of the parent MockDBStore instance is invoked.
-type DBStoreGetUploadByIDFunc struct {
-	defaultHook func(context.Context, int) (dbstore.Upload, bool, error)
-	hooks       []func(context.Context, int) (dbstore.Upload, bool, error)
-	history     []DBStoreGetUploadByIDFuncCall
+// DBStoreGetPackageDependenciesFunc describes the behavior when the
+// GetPackageDependencies method of the parent MockDBStore instance is
+// invoked.
+type DBStoreGetPackageDependenciesFunc struct {
+	defaultHook func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error)
+	hooks       []func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error)
+	history     []DBStoreGetPackageDependenciesFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploadByID delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockDBStore) GetUploadByID(v0 context.Context, v1 int) (dbstore.Upload, bool, error) {
-	r0, r1, r2 := m.GetUploadByIDFunc.nextHook()(v0, v1)
-	m.GetUploadByIDFunc.appendCall(DBStoreGetUploadByIDFuncCall{v0, v1, r0, r1, r2})
-	return r0, r1, r2
+// GetPackageDependencies delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetPackageDependencies(v0 context.Context, v1 dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error) {
+	r0, r1 := m.GetPackageDependenciesFunc.nextHook()(v0, v1)
+	m.GetPackageDependenciesFunc.appendCall(DBStoreGetPackageDependenciesFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetUploadByID method
-// of the parent MockDBStore instance is invoked and the hook queue is
-// empty.
-func (f *DBStoreGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
+// SetDefaultHook sets function that is called when the
+// GetPackageDependencies method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreGetPackageDependenciesFunc) SetDefaultHook(hook func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUploadByID method of the parent MockDBStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *DBStoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
+// GetPackageDependencies method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreGetPackageDependenciesFunc) PushHook(hook func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1552,21 +1988,21 @@ func (f *DBStoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (dbs
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreGetUploadByIDFunc) SetDefaultReturn(r0 dbstore.Upload, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) (dbstore.Upload, bool, error) {
-		return r0, r1, r2
+func (f *DBStoreGetPackageDependenciesFunc) SetDefaultReturn(r0 []dbstore.PackageDependency, r1 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreGetUploadByIDFunc) PushReturn(r0 dbstore.Upload, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int) (dbstore.Upload, bool, error) {
-		return r0, r1, r2
+func (f *DBStoreGetPackageDependenciesFunc) PushReturn(r0 []dbstore.PackageDependency, r1 error) {
+	f.PushHook(func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error) {
+		return r0, r1
 	})
 }
 
-func (f *DBStoreGetUploadByIDFunc) nextHook() func(context.Context, int) (dbstore.Upload, bool, error) {
+func (f *DBStoreGetPackageDependenciesFunc) nextHook() func(context.Context, dbstore.GetPackageDependenciesOptions) ([]dbstore.PackageDependency, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1579,83 +2015,83 @@ func (f *DBStoreGetUploadByIDFunc) nextHook() func(context.Context, int) (dbstor
 	return hook
 }
 
-func (f *DBStoreGetUploadByIDFunc) appendCall(r0 DBStoreGetUploadByIDFuncCall) {
+func (f *DBStoreGetPackageDependenciesFunc) appendCall(r0 DBStoreGetPackageDependenciesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreGetUploadByIDFuncCall objects
-// describing the invocations of this function.
-func (f *DBStoreGetUploadByIDFunc) History() []DBStoreGetUploadByIDFuncCall {
+// History returns a sequence of DBStoreGetPackageDependenciesFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreGetPackageDependenciesFunc) History() []DBStoreGetPackageDependenciesFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreGetUploadByIDFuncCall, len(f.history))
+	history := make([]DBStoreGetPackageDependenciesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreGetUploadByIDFuncCall is an object that describes an invocation of
-// method GetUploadByID on an instance of MockDBStore.
-type DBStoreGetUploadByIDFuncCall struct {
+// DBStoreGetPackageDependenciesFuncCall is an object that describes an
+// invocation of method GetPackageDependencies on an instance of
+// MockDBStore.
+type DBStoreGetPackageDependenciesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 dbstore.GetPackageDependenciesOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 dbstore.Upload
+	Result0 []dbstore.PackageDependency
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreGetUploadByIDFuncCall) Args() []interface{} {
+func (c DBStoreGetPackageDependenciesFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreGetUploadByIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c DBStoreGetPackageDependenciesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreGetUploadsFunc describes the behavior when the GetUploads method
-// of the parent MockDBStore instance is invoked.
-type DBStoreGetUploadsFunc struct {
-	defaultHook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
-	hooks       []func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
-	history     []DBStoreGetUploadsFuncCall
+// DBStoreGetPackageDependentsFunc describes the behavior when the
+// GetPackageDependents method of the parent MockDBStore instance is
+// invoked.
+type DBStoreGetPackageDependentsFunc struct {
+	defaultHook func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error)
+	hooks       []func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error)
+	history     []DBStoreGetPackageDependentsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploads delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockDBStore) GetUploads(v0 context.Context, v1 dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
-	r0, r1, r2 := m.GetUploadsFunc.nextHook()(v0, v1)
-	m.GetUploadsFunc.appendCall(DBStoreGetUploadsFuncCall{v0, v1, r0, r1, r2})
-	return r0, r1, r2
+// GetPackageDependents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetPackageDependents(v0 context.Context, v1 dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error) {
+	r0, r1 := m.GetPackageDependentsFunc.nextHook()(v0, v1)
+	m.GetPackageDependentsFunc.appendCall(DBStoreGetPackageDependentsFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetUploads method of
-// the parent MockDBStore instance is invoked and the hook queue is empty.
-func (f *DBStoreGetUploadsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
+// SetDefaultHook sets function that is called when the GetPackageDependents
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreGetPackageDependentsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUploads method of the parent MockDBStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *DBStoreGetUploadsFunc) PushHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
+// GetPackageDependents method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreGetPackageDependentsFunc) PushHook(hook func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1663,21 +2099,21 @@ func (f *DBStoreGetUploadsFunc) PushHook(hook func(context.Context, dbstore.GetU
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreGetUploadsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
-		return r0, r1, r2
+func (f *DBStoreGetPackageDependentsFunc) SetDefaultReturn(r0 []dbstore.PackageDependent, r1 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreGetUploadsFunc) PushReturn(r0 []dbstore.Upload, r1 int, r2 error) {
-	f.PushHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
-		return r0, r1, r2
+func (f *DBStoreGetPackageDependentsFunc) PushReturn(r0 []dbstore.PackageDependent, r1 error) {
+	f.PushHook(func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error) {
+		return r0, r1
 	})
 }
 
-func (f *DBStoreGetUploadsFunc) nextHook() func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+func (f *DBStoreGetPackageDependentsFunc) nextHook() func(context.Context, dbstore.GetPackageDependentsOptions) ([]dbstore.PackageDependent, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1690,84 +2126,82 @@ func (f *DBStoreGetUploadsFunc) nextHook() func(context.Context, dbstore.GetUplo
 	return hook
 }
 
-func (f *DBStoreGetUploadsFunc) appendCall(r0 DBStoreGetUploadsFuncCall) {
+func (f *DBStoreGetPackageDependentsFunc) appendCall(r0 DBStoreGetPackageDependentsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreGetUploadsFuncCall objects
+// History returns a sequence of DBStoreGetPackageDependentsFuncCall objects
 // describing the invocations of this function.
-func (f *DBStoreGetUploadsFunc) History() []DBStoreGetUploadsFuncCall {
+func (f *DBStoreGetPackageDependentsFunc) History() []DBStoreGetPackageDependentsFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreGetUploadsFuncCall, len(f.history))
+	history := make([]DBStoreGetPackageDependentsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreGetUploadsFuncCall is an object that describes an invocation of
-// method GetUploads on an instance of MockDBStore.
-type DBStoreGetUploadsFuncCall struct {
+// DBStoreGetPackageDependentsFuncCall is an object that describes an
+// invocation of method GetPackageDependents on an instance of MockDBStore.
+type DBStoreGetPackageDependentsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 dbstore.GetUploadsOptions
+	Arg1 dbstore.GetPackageDependentsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []dbstore.Upload
+	Result0 []dbstore.PackageDependent
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 int
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreGetUploadsFuncCall) Args() []interface{} {
+func (c DBStoreGetPackageDependentsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreGetUploadsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c DBStoreGetPackageDependentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreGetUploadsByIDsFunc describes the behavior when the
-// GetUploadsByIDs method of the parent MockDBStore instance is invoked.
-type DBStoreGetUploadsByIDsFunc struct {
-	defaultHook func(context.Context, ...int) ([]dbstore.Upload, error)
-	hooks       []func(context.Context, ...int) ([]dbstore.Upload, error)
-	history     []DBStoreGetUploadsByIDsFuncCall
+// DBStoreGetRetentionPoliciesFunc describes the behavior when the
+// GetRetentionPolicies method of the parent MockDBStore instance is
+// invoked.
+type DBStoreGetRetentionPoliciesFunc struct {
+	defaultHook func(context.Context) ([]dbstore.RetentionPolicy, error)
+	hooks       []func(context.Context) ([]dbstore.RetentionPolicy, error)
+	history     []DBStoreGetRetentionPoliciesFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploadsByIDs delegates to the next hook function in the queue and
+// GetRetentionPolicies delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockDBStore) GetUploadsByIDs(v0 context.Context, v1 ...int) ([]dbstore.Upload, error) {
-	r0, r1 := m.GetUploadsByIDsFunc.nextHook()(v0, v1...)
-	m.GetUploadsByIDsFunc.appendCall(DBStoreGetUploadsByIDsFuncCall{v0, v1, r0, r1})
+func (m *MockDBStore) GetRetentionPolicies(v0 context.Context) ([]dbstore.RetentionPolicy, error) {
+	r0, r1 := m.GetRetentionPoliciesFunc.nextHook()(v0)
+	m.GetRetentionPoliciesFunc.appendCall(DBStoreGetRetentionPoliciesFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetUploadsByIDs
+// SetDefaultHook sets function that is called when the GetRetentionPolicies
 // method of the parent MockDBStore instance is invoked and the hook queue
 // is empty.
-func (f *DBStoreGetUploadsByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+func (f *DBStoreGetRetentionPoliciesFunc) SetDefaultHook(hook func(context.Context) ([]dbstore.RetentionPolicy, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUploadsByIDs method of the parent MockDBStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *DBStoreGetUploadsByIDsFunc) PushHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+// GetRetentionPolicies method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreGetRetentionPoliciesFunc) PushHook(hook func(context.Context) ([]dbstore.RetentionPolicy, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1775,21 +2209,21 @@ func (f *DBStoreGetUploadsByIDsFunc) PushHook(hook func(context.Context, ...int)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreGetUploadsByIDsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 error) {
-	f.SetDefaultHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+func (f *DBStoreGetRetentionPoliciesFunc) SetDefaultReturn(r0 []dbstore.RetentionPolicy, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]dbstore.RetentionPolicy, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreGetUploadsByIDsFunc) PushReturn(r0 []dbstore.Upload, r1 error) {
-	f.PushHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+func (f *DBStoreGetRetentionPoliciesFunc) PushReturn(r0 []dbstore.RetentionPolicy, r1 error) {
+	f.PushHook(func(context.Context) ([]dbstore.RetentionPolicy, error) {
 		return r0, r1
 	})
 }
 
-func (f *DBStoreGetUploadsByIDsFunc) nextHook() func(context.Context, ...int) ([]dbstore.Upload, error) {
+func (f *DBStoreGetRetentionPoliciesFunc) nextHook() func(context.Context) ([]dbstore.RetentionPolicy, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1802,87 +2236,79 @@ func (f *DBStoreGetUploadsByIDsFunc) nextHook() func(context.Context, ...int) ([
 	return hook
 }
 
-func (f *DBStoreGetUploadsByIDsFunc) appendCall(r0 DBStoreGetUploadsByIDsFuncCall) {
+func (f *DBStoreGetRetentionPoliciesFunc) appendCall(r0 DBStoreGetRetentionPoliciesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreGetUploadsByIDsFuncCall objects
+// History returns a sequence of DBStoreGetRetentionPoliciesFuncCall objects
 // describing the invocations of this function.
-func (f *DBStoreGetUploadsByIDsFunc) History() []DBStoreGetUploadsByIDsFuncCall {
+func (f *DBStoreGetRetentionPoliciesFunc) History() []DBStoreGetRetentionPoliciesFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreGetUploadsByIDsFuncCall, len(f.history))
+	history := make([]DBStoreGetRetentionPoliciesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreGetUploadsByIDsFuncCall is an object that describes an invocation
-// of method GetUploadsByIDs on an instance of MockDBStore.
-type DBStoreGetUploadsByIDsFuncCall struct {
+// DBStoreGetRetentionPoliciesFuncCall is an object that describes an
+// invocation of method GetRetentionPolicies on an instance of MockDBStore.
+type DBStoreGetRetentionPoliciesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []dbstore.Upload
+	Result0 []dbstore.RetentionPolicy
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c DBStoreGetUploadsByIDsFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c DBStoreGetRetentionPoliciesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreGetUploadsByIDsFuncCall) Results() []interface{} {
+func (c DBStoreGetRetentionPoliciesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreHasCommitFunc describes the behavior when the HasCommit method of
-// the parent MockDBStore instance is invoked.
-type DBStoreHasCommitFunc struct {
-	defaultHook func(context.Context, int, string) (bool, error)
-	hooks       []func(context.Context, int, string) (bool, error)
-	history     []DBStoreHasCommitFuncCall
+// DBStoreGetRetentionPolicyByIDFunc describes the behavior when the
+// GetRetentionPolicyByID method of the parent MockDBStore instance is
+// invoked.
+type DBStoreGetRetentionPolicyByIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.RetentionPolicy, bool, error)
+	hooks       []func(context.Context, int) (dbstore.RetentionPolicy, bool, error)
+	history     []DBStoreGetRetentionPolicyByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// HasCommit delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockDBStore) HasCommit(v0 context.Context, v1 int, v2 string) (bool, error) {
-	r0, r1 := m.HasCommitFunc.nextHook()(v0, v1, v2)
-	m.HasCommitFunc.appendCall(DBStoreHasCommitFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+// GetRetentionPolicyByID delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetRetentionPolicyByID(v0 context.Context, v1 int) (dbstore.RetentionPolicy, bool, error) {
+	r0, r1, r2 := m.GetRetentionPolicyByIDFunc.nextHook()(v0, v1)
+	m.GetRetentionPolicyByIDFunc.appendCall(DBStoreGetRetentionPolicyByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the HasCommit method of
-// the parent MockDBStore instance is invoked and the hook queue is empty.
-func (f *DBStoreHasCommitFunc) SetDefaultHook(hook func(context.Context, int, string) (bool, error)) {
+// SetDefaultHook sets function that is called when the
+// GetRetentionPolicyByID method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreGetRetentionPolicyByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.RetentionPolicy, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// HasCommit method of the parent MockDBStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *DBStoreHasCommitFunc) PushHook(hook func(context.Context, int, string) (bool, error)) {
+// GetRetentionPolicyByID method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreGetRetentionPolicyByIDFunc) PushHook(hook func(context.Context, int) (dbstore.RetentionPolicy, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1890,21 +2316,21 @@ func (f *DBStoreHasCommitFunc) PushHook(hook func(context.Context, int, string)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreHasCommitFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string) (bool, error) {
-		return r0, r1
+func (f *DBStoreGetRetentionPolicyByIDFunc) SetDefaultReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreHasCommitFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, int, string) (bool, error) {
-		return r0, r1
+func (f *DBStoreGetRetentionPolicyByIDFunc) PushReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *DBStoreHasCommitFunc) nextHook() func(context.Context, int, string) (bool, error) {
+func (f *DBStoreGetRetentionPolicyByIDFunc) nextHook() func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1917,84 +2343,85 @@ func (f *DBStoreHasCommitFunc) nextHook() func(context.Context, int, string) (bo
 	return hook
 }
 
-func (f *DBStoreHasCommitFunc) appendCall(r0 DBStoreHasCommitFuncCall) {
+func (f *DBStoreGetRetentionPolicyByIDFunc) appendCall(r0 DBStoreGetRetentionPolicyByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreHasCommitFuncCall objects describing
-// the invocations of this function.
-func (f *DBStoreHasCommitFunc) History() []DBStoreHasCommitFuncCall {
+// History returns a sequence of DBStoreGetRetentionPolicyByIDFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreGetRetentionPolicyByIDFunc) History() []DBStoreGetRetentionPolicyByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreHasCommitFuncCall, len(f.history))
+	history := make([]DBStoreGetRetentionPolicyByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreHasCommitFuncCall is an object that describes an invocation of
-// method HasCommit on an instance of MockDBStore.
-type DBStoreHasCommitFuncCall struct {
+// DBStoreGetRetentionPolicyByIDFuncCall is an object that describes an
+// invocation of method GetRetentionPolicyByID on an instance of
+// MockDBStore.
+type DBStoreGetRetentionPolicyByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 dbstore.RetentionPolicy
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreHasCommitFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c DBStoreGetRetentionPolicyByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreHasCommitFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c DBStoreGetRetentionPolicyByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// DBStoreHasRepositoryFunc describes the behavior when the HasRepository
+// DBStoreGetUploadByIDFunc describes the behavior when the GetUploadByID
 // method of the parent MockDBStore instance is invoked.
-type DBStoreHasRepositoryFunc struct {
-	defaultHook func(context.Context, int) (bool, error)
-	hooks       []func(context.Context, int) (bool, error)
-	history     []DBStoreHasRepositoryFuncCall
+type DBStoreGetUploadByIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.Upload, bool, error)
+	hooks       []func(context.Context, int) (dbstore.Upload, bool, error)
+	history     []DBStoreGetUploadByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// HasRepository delegates to the next hook function in the queue and stores
+// GetUploadByID delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockDBStore) HasRepository(v0 context.Context, v1 int) (bool, error) {
-	r0, r1 := m.HasRepositoryFunc.nextHook()(v0, v1)
-	m.HasRepositoryFunc.appendCall(DBStoreHasRepositoryFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockDBStore) GetUploadByID(v0 context.Context, v1 int) (dbstore.Upload, bool, error) {
+	r0, r1, r2 := m.GetUploadByIDFunc.nextHook()(v0, v1)
+	m.GetUploadByIDFunc.appendCall(DBStoreGetUploadByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the HasRepository method
+// SetDefaultHook sets function that is called when the GetUploadByID method
 // of the parent MockDBStore instance is invoked and the hook queue is
 // empty.
-func (f *DBStoreHasRepositoryFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
+func (f *DBStoreGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// HasRepository method of the parent MockDBStore instance invokes the hook
+// GetUploadByID method of the parent MockDBStore instance invokes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *DBStoreHasRepositoryFunc) PushHook(hook func(context.Context, int) (bool, error)) {
+func (f *DBStoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2002,21 +2429,21 @@ func (f *DBStoreHasRepositoryFunc) PushHook(hook func(context.Context, int) (boo
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreHasRepositoryFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, int) (bool, error) {
-		return r0, r1
+func (f *DBStoreGetUploadByIDFunc) SetDefaultReturn(r0 dbstore.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.Upload, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreHasRepositoryFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, int) (bool, error) {
-		return r0, r1
+func (f *DBStoreGetUploadByIDFunc) PushReturn(r0 dbstore.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.Upload, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *DBStoreHasRepositoryFunc) nextHook() func(context.Context, int) (bool, error) {
+func (f *DBStoreGetUploadByIDFunc) nextHook() func(context.Context, int) (dbstore.Upload, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2029,26 +2456,26 @@ func (f *DBStoreHasRepositoryFunc) nextHook() func(context.Context, int) (bool,
 	return hook
 }
 
-func (f *DBStoreHasRepositoryFunc) appendCall(r0 DBStoreHasRepositoryFuncCall) {
+func (f *DBStoreGetUploadByIDFunc) appendCall(r0 DBStoreGetUploadByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreHasRepositoryFuncCall objects
+// History returns a sequence of DBStoreGetUploadByIDFuncCall objects
 // describing the invocations of this function.
-func (f *DBStoreHasRepositoryFunc) History() []DBStoreHasRepositoryFuncCall {
+func (f *DBStoreGetUploadByIDFunc) History() []DBStoreGetUploadByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreHasRepositoryFuncCall, len(f.history))
+	history := make([]DBStoreGetUploadByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreHasRepositoryFuncCall is an object that describes an invocation of
-// method HasRepository on an instance of MockDBStore.
-type DBStoreHasRepositoryFuncCall struct {
+// DBStoreGetUploadByIDFuncCall is an object that describes an invocation of
+// method GetUploadByID on an instance of MockDBStore.
+type DBStoreGetUploadByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2057,54 +2484,55 @@ type DBStoreHasRepositoryFuncCall struct {
 	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 dbstore.Upload
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreHasRepositoryFuncCall) Args() []interface{} {
+func (c DBStoreGetUploadByIDFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreHasRepositoryFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c DBStoreGetUploadByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// DBStoreMarkRepositoryAsDirtyFunc describes the behavior when the
-// MarkRepositoryAsDirty method of the parent MockDBStore instance is
-// invoked.
-type DBStoreMarkRepositoryAsDirtyFunc struct {
-	defaultHook func(context.Context, int) error
-	hooks       []func(context.Context, int) error
-	history     []DBStoreMarkRepositoryAsDirtyFuncCall
+// DBStoreGetUploadsFunc describes the behavior when the GetUploads method
+// of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadsFunc struct {
+	defaultHook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
+	hooks       []func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
+	history     []DBStoreGetUploadsFuncCall
 	mutex       sync.Mutex
 }
 
-// MarkRepositoryAsDirty delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockDBStore) MarkRepositoryAsDirty(v0 context.Context, v1 int) error {
-	r0 := m.MarkRepositoryAsDirtyFunc.nextHook()(v0, v1)
-	m.MarkRepositoryAsDirtyFunc.appendCall(DBStoreMarkRepositoryAsDirtyFuncCall{v0, v1, r0})
-	return r0
+// GetUploads delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploads(v0 context.Context, v1 dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+	r0, r1, r2 := m.GetUploadsFunc.nextHook()(v0, v1)
+	m.GetUploadsFunc.appendCall(DBStoreGetUploadsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the
-// MarkRepositoryAsDirty method of the parent MockDBStore instance is
-// invoked and the hook queue is empty.
-func (f *DBStoreMarkRepositoryAsDirtyFunc) SetDefaultHook(hook func(context.Context, int) error) {
+// SetDefaultHook sets function that is called when the GetUploads method of
+// the parent MockDBStore instance is invoked and the hook queue is empty.
+func (f *DBStoreGetUploadsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// MarkRepositoryAsDirty method of the parent MockDBStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *DBStoreMarkRepositoryAsDirtyFunc) PushHook(hook func(context.Context, int) error) {
+// GetUploads method of the parent MockDBStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreGetUploadsFunc) PushHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2112,21 +2540,21 @@ func (f *DBStoreMarkRepositoryAsDirtyFunc) PushHook(hook func(context.Context, i
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreMarkRepositoryAsDirtyFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int) error {
-		return r0
+func (f *DBStoreGetUploadsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreMarkRepositoryAsDirtyFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int) error {
-		return r0
+func (f *DBStoreGetUploadsFunc) PushReturn(r0 []dbstore.Upload, r1 int, r2 error) {
+	f.PushHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *DBStoreMarkRepositoryAsDirtyFunc) nextHook() func(context.Context, int) error {
+func (f *DBStoreGetUploadsFunc) nextHook() func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2139,79 +2567,84 @@ func (f *DBStoreMarkRepositoryAsDirtyFunc) nextHook() func(context.Context, int)
 	return hook
 }
 
-func (f *DBStoreMarkRepositoryAsDirtyFunc) appendCall(r0 DBStoreMarkRepositoryAsDirtyFuncCall) {
+func (f *DBStoreGetUploadsFunc) appendCall(r0 DBStoreGetUploadsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreMarkRepositoryAsDirtyFuncCall
-// objects describing the invocations of this function.
-func (f *DBStoreMarkRepositoryAsDirtyFunc) History() []DBStoreMarkRepositoryAsDirtyFuncCall {
+// History returns a sequence of DBStoreGetUploadsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetUploadsFunc) History() []DBStoreGetUploadsFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreMarkRepositoryAsDirtyFuncCall, len(f.history))
+	history := make([]DBStoreGetUploadsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreMarkRepositoryAsDirtyFuncCall is an object that describes an
-// invocation of method MarkRepositoryAsDirty on an instance of MockDBStore.
-type DBStoreMarkRepositoryAsDirtyFuncCall struct {
+// DBStoreGetUploadsFuncCall is an object that describes an invocation of
+// method GetUploads on an instance of MockDBStore.
+type DBStoreGetUploadsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 dbstore.GetUploadsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []dbstore.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreMarkRepositoryAsDirtyFuncCall) Args() []interface{} {
+func (c DBStoreGetUploadsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreMarkRepositoryAsDirtyFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c DBStoreGetUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// DBStoreReferenceIDsAndFiltersFunc describes the behavior when the
-// ReferenceIDsAndFilters method of the parent MockDBStore instance is
-// invoked.
-type DBStoreReferenceIDsAndFiltersFunc struct {
-	defaultHook func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)
-	hooks       []func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)
-	history     []DBStoreReferenceIDsAndFiltersFuncCall
+// DBStoreGetUploadsByIDsFunc describes the behavior when the
+// GetUploadsByIDs method of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadsByIDsFunc struct {
+	defaultHook func(context.Context, ...int) ([]dbstore.Upload, error)
+	hooks       []func(context.Context, ...int) ([]dbstore.Upload, error)
+	history     []DBStoreGetUploadsByIDsFuncCall
 	mutex       sync.Mutex
 }
 
-// ReferenceIDsAndFilters delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockDBStore) ReferenceIDsAndFilters(v0 context.Context, v1 int, v2 string, v3 []semantic.QualifiedMonikerData, v4 int, v5 int) (dbstore.PackageReferenceScanner, int, error) {
-	r0, r1, r2 := m.ReferenceIDsAndFiltersFunc.nextHook()(v0, v1, v2, v3, v4, v5)
-	m.ReferenceIDsAndFiltersFunc.appendCall(DBStoreReferenceIDsAndFiltersFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
-	return r0, r1, r2
+// GetUploadsByIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploadsByIDs(v0 context.Context, v1 ...int) ([]dbstore.Upload, error) {
+	r0, r1 := m.GetUploadsByIDsFunc.nextHook()(v0, v1...)
+	m.GetUploadsByIDsFunc.appendCall(DBStoreGetUploadsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// ReferenceIDsAndFilters method of the parent MockDBStore instance is
-// invoked and the hook queue is empty.
-func (f *DBStoreReferenceIDsAndFiltersFunc) SetDefaultHook(hook func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)) {
+// SetDefaultHook sets function that is called when the GetUploadsByIDs
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreGetUploadsByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ReferenceIDsAndFilters method of the parent MockDBStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *DBStoreReferenceIDsAndFiltersFunc) PushHook(hook func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)) {
+// GetUploadsByIDs method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreGetUploadsByIDsFunc) PushHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2219,21 +2652,21 @@ func (f *DBStoreReferenceIDsAndFiltersFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreReferenceIDsAndFiltersFunc) SetDefaultReturn(r0 dbstore.PackageReferenceScanner, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
-		return r0, r1, r2
+func (f *DBStoreGetUploadsByIDsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreReferenceIDsAndFiltersFunc) PushReturn(r0 dbstore.PackageReferenceScanner, r1 int, r2 error) {
-	f.PushHook(func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
-		return r0, r1, r2
+func (f *DBStoreGetUploadsByIDsFunc) PushReturn(r0 []dbstore.Upload, r1 error) {
+	f.PushHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+		return r0, r1
 	})
 }
 
-func (f *DBStoreReferenceIDsAndFiltersFunc) nextHook() func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
+func (f *DBStoreGetUploadsByIDsFunc) nextHook() func(context.Context, ...int) ([]dbstore.Upload, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2246,96 +2679,87 @@ func (f *DBStoreReferenceIDsAndFiltersFunc) nextHook() func(context.Context, int
 	return hook
 }
 
-func (f *DBStoreReferenceIDsAndFiltersFunc) appendCall(r0 DBStoreReferenceIDsAndFiltersFuncCall) {
+func (f *DBStoreGetUploadsByIDsFunc) appendCall(r0 DBStoreGetUploadsByIDsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreReferenceIDsAndFiltersFuncCall
-// objects describing the invocations of this function.
-func (f *DBStoreReferenceIDsAndFiltersFunc) History() []DBStoreReferenceIDsAndFiltersFuncCall {
+// History returns a sequence of DBStoreGetUploadsByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetUploadsByIDsFunc) History() []DBStoreGetUploadsByIDsFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreReferenceIDsAndFiltersFuncCall, len(f.history))
+	history := make([]DBStoreGetUploadsByIDsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreReferenceIDsAndFiltersFuncCall is an object that describes an
-// invocation of method ReferenceIDsAndFilters on an instance of
-// MockDBStore.
-type DBStoreReferenceIDsAndFiltersFuncCall struct {
+// DBStoreGetUploadsByIDsFuncCall is an object that describes an invocation
+// of method GetUploadsByIDs on an instance of MockDBStore.
+type DBStoreGetUploadsByIDsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 []semantic.QualifiedMonikerData
-	// Arg4 is the value of the 5th argument passed to this method
-	// invocation.
-	Arg4 int
-	// Arg5 is the value of the 6th argument passed to this method
-	// invocation.
-	Arg5 int
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 dbstore.PackageReferenceScanner
+	Result0 []dbstore.Upload
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 int
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation.
-func (c DBStoreReferenceIDsAndFiltersFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c DBStoreGetUploadsByIDsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreReferenceIDsAndFiltersFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c DBStoreGetUploadsByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreRepoNameFunc describes the behavior when the RepoName method of
+// DBStoreHasCommitFunc describes the behavior when the HasCommit method of
 // the parent MockDBStore instance is invoked.
-type DBStoreRepoNameFunc struct {
-	defaultHook func(context.Context, int) (string, error)
-	hooks       []func(context.Context, int) (string, error)
-	history     []DBStoreRepoNameFuncCall
+type DBStoreHasCommitFunc struct {
+	defaultHook func(context.Context, int, string) (bool, error)
+	hooks       []func(context.Context, int, string) (bool, error)
+	history     []DBStoreHasCommitFuncCall
 	mutex       sync.Mutex
 }
 
-// RepoName delegates to the next hook function in the queue and stores the
+// HasCommit delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockDBStore) RepoName(v0 context.Context, v1 int) (string, error) {
-	r0, r1 := m.RepoNameFunc.nextHook()(v0, v1)
-	m.RepoNameFunc.appendCall(DBStoreRepoNameFuncCall{v0, v1, r0, r1})
+func (m *MockDBStore) HasCommit(v0 context.Context, v1 int, v2 string) (bool, error) {
+	r0, r1 := m.HasCommitFunc.nextHook()(v0, v1, v2)
+	m.HasCommitFunc.appendCall(DBStoreHasCommitFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the RepoName method of
+// SetDefaultHook sets function that is called when the HasCommit method of
 // the parent MockDBStore instance is invoked and the hook queue is empty.
-func (f *DBStoreRepoNameFunc) SetDefaultHook(hook func(context.Context, int) (string, error)) {
+func (f *DBStoreHasCommitFunc) SetDefaultHook(hook func(context.Context, int, string) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// RepoName method of the parent MockDBStore instance invokes the hook at
+// HasCommit method of the parent MockDBStore instance invokes the hook at
 // the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *DBStoreRepoNameFunc) PushHook(hook func(context.Context, int) (string, error)) {
+func (f *DBStoreHasCommitFunc) PushHook(hook func(context.Context, int, string) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2343,21 +2767,21 @@ func (f *DBStoreRepoNameFunc) PushHook(hook func(context.Context, int) (string,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreRepoNameFunc) SetDefaultReturn(r0 string, r1 error) {
-	f.SetDefaultHook(func(context.Context, int) (string, error) {
+func (f *DBStoreHasCommitFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (bool, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreRepoNameFunc) PushReturn(r0 string, r1 error) {
-	f.PushHook(func(context.Context, int) (string, error) {
+func (f *DBStoreHasCommitFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int, string) (bool, error) {
 		return r0, r1
 	})
 }
 
-func (f *DBStoreRepoNameFunc) nextHook() func(context.Context, int) (string, error) {
+func (f *DBStoreHasCommitFunc) nextHook() func(context.Context, int, string) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2370,35 +2794,38 @@ func (f *DBStoreRepoNameFunc) nextHook() func(context.Context, int) (string, err
 	return hook
 }
 
-func (f *DBStoreRepoNameFunc) appendCall(r0 DBStoreRepoNameFuncCall) {
+func (f *DBStoreHasCommitFunc) appendCall(r0 DBStoreHasCommitFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreRepoNameFuncCall objects describing
+// History returns a sequence of DBStoreHasCommitFuncCall objects describing
 // the invocations of this function.
-func (f *DBStoreRepoNameFunc) History() []DBStoreRepoNameFuncCall {
+func (f *DBStoreHasCommitFunc) History() []DBStoreHasCommitFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreRepoNameFuncCall, len(f.history))
+	history := make([]DBStoreHasCommitFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreRepoNameFuncCall is an object that describes an invocation of
-// method RepoName on an instance of MockDBStore.
-type DBStoreRepoNameFuncCall struct {
+// DBStoreHasCommitFuncCall is an object that describes an invocation of
+// method HasCommit on an instance of MockDBStore.
+type DBStoreHasCommitFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2406,48 +2833,45 @@ type DBStoreRepoNameFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreRepoNameFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c DBStoreHasCommitFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreRepoNameFuncCall) Results() []interface{} {
+func (c DBStoreHasCommitFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreUpdateIndexConfigurationByRepositoryIDFunc describes the behavior
-// when the UpdateIndexConfigurationByRepositoryID method of the parent
-// MockDBStore instance is invoked.
-type DBStoreUpdateIndexConfigurationByRepositoryIDFunc struct {
-	defaultHook func(context.Context, int, []byte) error
-	hooks       []func(context.Context, int, []byte) error
-	history     []DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall
+// DBStoreHasRepositoryFunc describes the behavior when the HasRepository
+// method of the parent MockDBStore instance is invoked.
+type DBStoreHasRepositoryFunc struct {
+	defaultHook func(context.Context, int) (bool, error)
+	hooks       []func(context.Context, int) (bool, error)
+	history     []DBStoreHasRepositoryFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateIndexConfigurationByRepositoryID delegates to the next hook
-// function in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockDBStore) UpdateIndexConfigurationByRepositoryID(v0 context.Context, v1 int, v2 []byte) error {
-	r0 := m.UpdateIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1, v2)
-	m.UpdateIndexConfigurationByRepositoryIDFunc.appendCall(DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall{v0, v1, v2, r0})
-	return r0
+// HasRepository delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) HasRepository(v0 context.Context, v1 int) (bool, error) {
+	r0, r1 := m.HasRepositoryFunc.nextHook()(v0, v1)
+	m.HasRepositoryFunc.appendCall(DBStoreHasRepositoryFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// UpdateIndexConfigurationByRepositoryID method of the parent MockDBStore
-// instance is invoked and the hook queue is empty.
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, []byte) error) {
+// SetDefaultHook sets function that is called when the HasRepository method
+// of the parent MockDBStore instance is invoked and the hook queue is
+// empty.
+func (f *DBStoreHasRepositoryFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateIndexConfigurationByRepositoryID method of the parent MockDBStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) PushHook(hook func(context.Context, int, []byte) error) {
+// HasRepository method of the parent MockDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreHasRepositoryFunc) PushHook(hook func(context.Context, int) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2455,21 +2879,21 @@ func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) PushHook(hook func(c
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int, []byte) error {
-		return r0
+func (f *DBStoreHasRepositoryFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (bool, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int, []byte) error {
-		return r0
+func (f *DBStoreHasRepositoryFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int) (bool, error) {
+		return r0, r1
 	})
 }
 
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) nextHook() func(context.Context, int, []byte) error {
+func (f *DBStoreHasRepositoryFunc) nextHook() func(context.Context, int) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2482,71 +2906,755 @@ func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) nextHook() func(cont
 	return hook
 }
 
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) appendCall(r0 DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall) {
+func (f *DBStoreHasRepositoryFunc) appendCall(r0 DBStoreHasRepositoryFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall objects describing
-// the invocations of this function.
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) History() []DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall {
+// History returns a sequence of DBStoreHasRepositoryFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreHasRepositoryFunc) History() []DBStoreHasRepositoryFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall, len(f.history))
+	history := make([]DBStoreHasRepositoryFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall is an object that
-// describes an invocation of method UpdateIndexConfigurationByRepositoryID
-// on an instance of MockDBStore.
-type DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall struct {
+// DBStoreHasRepositoryFuncCall is an object that describes an invocation of
+// method HasRepository on an instance of MockDBStore.
+type DBStoreHasRepositoryFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 []byte
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c DBStoreHasRepositoryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c DBStoreHasRepositoryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockEnqueuerDBStore is a mock implementation of the EnqueuerDBStore
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
-// used for unit testing.
-type MockEnqueuerDBStore struct {
-	// DirtyRepositoriesFunc is an instance of a mock function object
-	// controlling the behavior of the method DirtyRepositories.
-	DirtyRepositoriesFunc *EnqueuerDBStoreDirtyRepositoriesFunc
-	// DoneFunc is an instance of a mock function object controlling the
-	// behavior of the method Done.
-	DoneFunc *EnqueuerDBStoreDoneFunc
-	// GetIndexConfigurationByRepositoryIDFunc is an instance of a mock
-	// function object controlling the behavior of the method
-	// GetIndexConfigurationByRepositoryID.
-	GetIndexConfigurationByRepositoryIDFunc *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc
-	// GetRepositoriesWithIndexConfigurationFunc is an instance of a mock
-	// function object controlling the behavior of the method
+// DBStoreMarkRepositoryAsDirtyFunc describes the behavior when the
+// MarkRepositoryAsDirty method of the parent MockDBStore instance is
+// invoked.
+type DBStoreMarkRepositoryAsDirtyFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []DBStoreMarkRepositoryAsDirtyFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkRepositoryAsDirty delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) MarkRepositoryAsDirty(v0 context.Context, v1 int) error {
+	r0 := m.MarkRepositoryAsDirtyFunc.nextHook()(v0, v1)
+	m.MarkRepositoryAsDirtyFunc.appendCall(DBStoreMarkRepositoryAsDirtyFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// MarkRepositoryAsDirty method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreMarkRepositoryAsDirtyFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkRepositoryAsDirty method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreMarkRepositoryAsDirtyFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreMarkRepositoryAsDirtyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreMarkRepositoryAsDirtyFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *DBStoreMarkRepositoryAsDirtyFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreMarkRepositoryAsDirtyFunc) appendCall(r0 DBStoreMarkRepositoryAsDirtyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreMarkRepositoryAsDirtyFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreMarkRepositoryAsDirtyFunc) History() []DBStoreMarkRepositoryAsDirtyFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreMarkRepositoryAsDirtyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreMarkRepositoryAsDirtyFuncCall is an object that describes an
+// invocation of method MarkRepositoryAsDirty on an instance of MockDBStore.
+type DBStoreMarkRepositoryAsDirtyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreMarkRepositoryAsDirtyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreMarkRepositoryAsDirtyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStorePackageReferenceVersionsFunc describes the behavior when the
+// PackageReferenceVersions method of the parent MockDBStore instance is
+// invoked.
+type DBStorePackageReferenceVersionsFunc struct {
+	defaultHook func(context.Context, string, string) ([]string, error)
+	hooks       []func(context.Context, string, string) ([]string, error)
+	history     []DBStorePackageReferenceVersionsFuncCall
+	mutex       sync.Mutex
+}
+
+// PackageReferenceVersions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) PackageReferenceVersions(v0 context.Context, v1 string, v2 string) ([]string, error) {
+	r0, r1 := m.PackageReferenceVersionsFunc.nextHook()(v0, v1, v2)
+	m.PackageReferenceVersionsFunc.appendCall(DBStorePackageReferenceVersionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// PackageReferenceVersions method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStorePackageReferenceVersionsFunc) SetDefaultHook(hook func(context.Context, string, string) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PackageReferenceVersions method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStorePackageReferenceVersionsFunc) PushHook(hook func(context.Context, string, string) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStorePackageReferenceVersionsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStorePackageReferenceVersionsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, string, string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStorePackageReferenceVersionsFunc) nextHook() func(context.Context, string, string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStorePackageReferenceVersionsFunc) appendCall(r0 DBStorePackageReferenceVersionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStorePackageReferenceVersionsFuncCall
+// objects describing the invocations of this function.
+func (f *DBStorePackageReferenceVersionsFunc) History() []DBStorePackageReferenceVersionsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStorePackageReferenceVersionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStorePackageReferenceVersionsFuncCall is an object that describes an
+// invocation of method PackageReferenceVersions on an instance of
+// MockDBStore.
+type DBStorePackageReferenceVersionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStorePackageReferenceVersionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStorePackageReferenceVersionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DBStoreReferenceIDsAndFiltersFunc describes the behavior when the
+// ReferenceIDsAndFilters method of the parent MockDBStore instance is
+// invoked.
+type DBStoreReferenceIDsAndFiltersFunc struct {
+	defaultHook func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)
+	hooks       []func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)
+	history     []DBStoreReferenceIDsAndFiltersFuncCall
+	mutex       sync.Mutex
+}
+
+// ReferenceIDsAndFilters delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) ReferenceIDsAndFilters(v0 context.Context, v1 int, v2 string, v3 []semantic.QualifiedMonikerData, v4 int, v5 int) (dbstore.PackageReferenceScanner, int, error) {
+	r0, r1, r2 := m.ReferenceIDsAndFiltersFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.ReferenceIDsAndFiltersFunc.appendCall(DBStoreReferenceIDsAndFiltersFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// ReferenceIDsAndFilters method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreReferenceIDsAndFiltersFunc) SetDefaultHook(hook func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReferenceIDsAndFilters method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreReferenceIDsAndFiltersFunc) PushHook(hook func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreReferenceIDsAndFiltersFunc) SetDefaultReturn(r0 dbstore.PackageReferenceScanner, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreReferenceIDsAndFiltersFunc) PushReturn(r0 dbstore.PackageReferenceScanner, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreReferenceIDsAndFiltersFunc) nextHook() func(context.Context, int, string, []semantic.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreReferenceIDsAndFiltersFunc) appendCall(r0 DBStoreReferenceIDsAndFiltersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreReferenceIDsAndFiltersFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreReferenceIDsAndFiltersFunc) History() []DBStoreReferenceIDsAndFiltersFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreReferenceIDsAndFiltersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreReferenceIDsAndFiltersFuncCall is an object that describes an
+// invocation of method ReferenceIDsAndFilters on an instance of
+// MockDBStore.
+type DBStoreReferenceIDsAndFiltersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []semantic.QualifiedMonikerData
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.PackageReferenceScanner
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreReferenceIDsAndFiltersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreReferenceIDsAndFiltersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// DBStoreRepoNameFunc describes the behavior when the RepoName method of
+// the parent MockDBStore instance is invoked.
+type DBStoreRepoNameFunc struct {
+	defaultHook func(context.Context, int) (string, error)
+	hooks       []func(context.Context, int) (string, error)
+	history     []DBStoreRepoNameFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoName delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockDBStore) RepoName(v0 context.Context, v1 int) (string, error) {
+	r0, r1 := m.RepoNameFunc.nextHook()(v0, v1)
+	m.RepoNameFunc.appendCall(DBStoreRepoNameFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RepoName method of
+// the parent MockDBStore instance is invoked and the hook queue is empty.
+func (f *DBStoreRepoNameFunc) SetDefaultHook(hook func(context.Context, int) (string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoName method of the parent MockDBStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreRepoNameFunc) PushHook(hook func(context.Context, int) (string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreRepoNameFunc) SetDefaultReturn(r0 string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreRepoNameFunc) PushReturn(r0 string, r1 error) {
+	f.PushHook(func(context.Context, int) (string, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreRepoNameFunc) nextHook() func(context.Context, int) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreRepoNameFunc) appendCall(r0 DBStoreRepoNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreRepoNameFuncCall objects describing
+// the invocations of this function.
+func (f *DBStoreRepoNameFunc) History() []DBStoreRepoNameFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreRepoNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreRepoNameFuncCall is an object that describes an invocation of
+// method RepoName on an instance of MockDBStore.
+type DBStoreRepoNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreRepoNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreRepoNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DBStoreUpdateIndexConfigurationByRepositoryIDFunc describes the behavior
+// when the UpdateIndexConfigurationByRepositoryID method of the parent
+// MockDBStore instance is invoked.
+type DBStoreUpdateIndexConfigurationByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int, []byte) error
+	hooks       []func(context.Context, int, []byte) error
+	history     []DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexConfigurationByRepositoryID delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockDBStore) UpdateIndexConfigurationByRepositoryID(v0 context.Context, v1 int, v2 []byte) error {
+	r0 := m.UpdateIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1, v2)
+	m.UpdateIndexConfigurationByRepositoryIDFunc.appendCall(DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexConfigurationByRepositoryID method of the parent MockDBStore
+// instance is invoked and the hook queue is empty.
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, []byte) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIndexConfigurationByRepositoryID method of the parent MockDBStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) PushHook(hook func(context.Context, int, []byte) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []byte) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []byte) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) nextHook() func(context.Context, int, []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) appendCall(r0 DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall objects describing
+// the invocations of this function.
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) History() []DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall is an object that
+// describes an invocation of method UpdateIndexConfigurationByRepositoryID
+// on an instance of MockDBStore.
+type DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []byte
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStoreUpdateRetentionPolicyFunc describes the behavior when the
+// UpdateRetentionPolicy method of the parent MockDBStore instance is
+// invoked.
+type DBStoreUpdateRetentionPolicyFunc struct {
+	defaultHook func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error)
+	hooks       []func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error)
+	history     []DBStoreUpdateRetentionPolicyFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateRetentionPolicy delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) UpdateRetentionPolicy(v0 context.Context, v1 dbstore.RetentionPolicy, v2 time.Time) (dbstore.RetentionPolicy, bool, error) {
+	r0, r1, r2 := m.UpdateRetentionPolicyFunc.nextHook()(v0, v1, v2)
+	m.UpdateRetentionPolicyFunc.appendCall(DBStoreUpdateRetentionPolicyFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateRetentionPolicy method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreUpdateRetentionPolicyFunc) SetDefaultHook(hook func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateRetentionPolicy method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreUpdateRetentionPolicyFunc) PushHook(hook func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateRetentionPolicyFunc) SetDefaultReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateRetentionPolicyFunc) PushReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreUpdateRetentionPolicyFunc) nextHook() func(context.Context, dbstore.RetentionPolicy, time.Time) (dbstore.RetentionPolicy, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateRetentionPolicyFunc) appendCall(r0 DBStoreUpdateRetentionPolicyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreUpdateRetentionPolicyFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreUpdateRetentionPolicyFunc) History() []DBStoreUpdateRetentionPolicyFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateRetentionPolicyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateRetentionPolicyFuncCall is an object that describes an
+// invocation of method UpdateRetentionPolicy on an instance of MockDBStore.
+type DBStoreUpdateRetentionPolicyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.RetentionPolicy
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.RetentionPolicy
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateRetentionPolicyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateRetentionPolicyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// MockEnqueuerDBStore is a mock implementation of the EnqueuerDBStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockEnqueuerDBStore struct {
+	// DirtyRepositoriesFunc is an instance of a mock function object
+	// controlling the behavior of the method DirtyRepositories.
+	DirtyRepositoriesFunc *EnqueuerDBStoreDirtyRepositoriesFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *EnqueuerDBStoreDoneFunc
+	// GetIndexConfigurationByRepositoryIDFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetIndexConfigurationByRepositoryID.
+	GetIndexConfigurationByRepositoryIDFunc *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc
+	// GetRepositoriesWithIndexConfigurationFunc is an instance of a mock
+	// function object controlling the behavior of the method
 	// GetRepositoriesWithIndexConfiguration.
 	GetRepositoriesWithIndexConfigurationFunc *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc
 	// HandleFunc is an instance of a mock function object controlling the
@@ -2563,117 +3671,448 @@ type MockEnqueuerDBStore struct {
 	TransactFunc *EnqueuerDBStoreTransactFunc
 }
 
-// NewMockEnqueuerDBStore creates a new mock of the EnqueuerDBStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockEnqueuerDBStore() *MockEnqueuerDBStore {
-	return &MockEnqueuerDBStore{
-		DirtyRepositoriesFunc: &EnqueuerDBStoreDirtyRepositoriesFunc{
-			defaultHook: func(context.Context) (map[int]int, error) {
-				return nil, nil
-			},
-		},
-		DoneFunc: &EnqueuerDBStoreDoneFunc{
-			defaultHook: func(error) error {
-				return nil
-			},
-		},
-		GetIndexConfigurationByRepositoryIDFunc: &EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc{
-			defaultHook: func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
-				return dbstore.IndexConfiguration{}, false, nil
-			},
-		},
-		GetRepositoriesWithIndexConfigurationFunc: &EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc{
-			defaultHook: func(context.Context) ([]int, error) {
-				return nil, nil
-			},
-		},
-		HandleFunc: &EnqueuerDBStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				return nil
-			},
-		},
-		InsertIndexFunc: &EnqueuerDBStoreInsertIndexFunc{
-			defaultHook: func(context.Context, dbstore.Index) (int, error) {
-				return 0, nil
-			},
-		},
-		IsQueuedFunc: &EnqueuerDBStoreIsQueuedFunc{
-			defaultHook: func(context.Context, int, string) (bool, error) {
-				return false, nil
-			},
-		},
-		TransactFunc: &EnqueuerDBStoreTransactFunc{
-			defaultHook: func(context.Context) (enqueuer.DBStore, error) {
-				return nil, nil
-			},
-		},
+// NewMockEnqueuerDBStore creates a new mock of the EnqueuerDBStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockEnqueuerDBStore() *MockEnqueuerDBStore {
+	return &MockEnqueuerDBStore{
+		DirtyRepositoriesFunc: &EnqueuerDBStoreDirtyRepositoriesFunc{
+			defaultHook: func(context.Context) (map[int]int, error) {
+				return nil, nil
+			},
+		},
+		DoneFunc: &EnqueuerDBStoreDoneFunc{
+			defaultHook: func(error) error {
+				return nil
+			},
+		},
+		GetIndexConfigurationByRepositoryIDFunc: &EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc{
+			defaultHook: func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
+				return dbstore.IndexConfiguration{}, false, nil
+			},
+		},
+		GetRepositoriesWithIndexConfigurationFunc: &EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc{
+			defaultHook: func(context.Context) ([]int, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &EnqueuerDBStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		InsertIndexFunc: &EnqueuerDBStoreInsertIndexFunc{
+			defaultHook: func(context.Context, dbstore.Index) (int, error) {
+				return 0, nil
+			},
+		},
+		IsQueuedFunc: &EnqueuerDBStoreIsQueuedFunc{
+			defaultHook: func(context.Context, int, string) (bool, error) {
+				return false, nil
+			},
+		},
+		TransactFunc: &EnqueuerDBStoreTransactFunc{
+			defaultHook: func(context.Context) (enqueuer.DBStore, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewMockEnqueuerDBStoreFrom creates a new mock of the MockEnqueuerDBStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockEnqueuerDBStoreFrom(i EnqueuerDBStore) *MockEnqueuerDBStore {
+	return &MockEnqueuerDBStore{
+		DirtyRepositoriesFunc: &EnqueuerDBStoreDirtyRepositoriesFunc{
+			defaultHook: i.DirtyRepositories,
+		},
+		DoneFunc: &EnqueuerDBStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		GetIndexConfigurationByRepositoryIDFunc: &EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc{
+			defaultHook: i.GetIndexConfigurationByRepositoryID,
+		},
+		GetRepositoriesWithIndexConfigurationFunc: &EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc{
+			defaultHook: i.GetRepositoriesWithIndexConfiguration,
+		},
+		HandleFunc: &EnqueuerDBStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		InsertIndexFunc: &EnqueuerDBStoreInsertIndexFunc{
+			defaultHook: i.InsertIndex,
+		},
+		IsQueuedFunc: &EnqueuerDBStoreIsQueuedFunc{
+			defaultHook: i.IsQueued,
+		},
+		TransactFunc: &EnqueuerDBStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+	}
+}
+
+// EnqueuerDBStoreDirtyRepositoriesFunc describes the behavior when the
+// DirtyRepositories method of the parent MockEnqueuerDBStore instance is
+// invoked.
+type EnqueuerDBStoreDirtyRepositoriesFunc struct {
+	defaultHook func(context.Context) (map[int]int, error)
+	hooks       []func(context.Context) (map[int]int, error)
+	history     []EnqueuerDBStoreDirtyRepositoriesFuncCall
+	mutex       sync.Mutex
+}
+
+// DirtyRepositories delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnqueuerDBStore) DirtyRepositories(v0 context.Context) (map[int]int, error) {
+	r0, r1 := m.DirtyRepositoriesFunc.nextHook()(v0)
+	m.DirtyRepositoriesFunc.appendCall(EnqueuerDBStoreDirtyRepositoriesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DirtyRepositories
+// method of the parent MockEnqueuerDBStore instance is invoked and the hook
+// queue is empty.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DirtyRepositories method of the parent MockEnqueuerDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushHook(hook func(context.Context) (map[int]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushReturn(r0 map[int]int, r1 error) {
+	f.PushHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) nextHook() func(context.Context) (map[int]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) appendCall(r0 EnqueuerDBStoreDirtyRepositoriesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnqueuerDBStoreDirtyRepositoriesFuncCall
+// objects describing the invocations of this function.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) History() []EnqueuerDBStoreDirtyRepositoriesFuncCall {
+	f.mutex.Lock()
+	history := make([]EnqueuerDBStoreDirtyRepositoriesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnqueuerDBStoreDirtyRepositoriesFuncCall is an object that describes an
+// invocation of method DirtyRepositories on an instance of
+// MockEnqueuerDBStore.
+type EnqueuerDBStoreDirtyRepositoriesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// EnqueuerDBStoreDoneFunc describes the behavior when the Done method of
+// the parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []EnqueuerDBStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnqueuerDBStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(EnqueuerDBStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockEnqueuerDBStore instance is invoked and the hook queue is
+// empty.
+func (f *EnqueuerDBStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockEnqueuerDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnqueuerDBStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnqueuerDBStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnqueuerDBStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *EnqueuerDBStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnqueuerDBStoreDoneFunc) appendCall(r0 EnqueuerDBStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnqueuerDBStoreDoneFuncCall objects
+// describing the invocations of this function.
+func (f *EnqueuerDBStoreDoneFunc) History() []EnqueuerDBStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]EnqueuerDBStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnqueuerDBStoreDoneFuncCall is an object that describes an invocation of
+// method Done on an instance of MockEnqueuerDBStore.
+type EnqueuerDBStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnqueuerDBStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnqueuerDBStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc describes the
+// behavior when the GetIndexConfigurationByRepositoryID method of the
+// parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.IndexConfiguration, bool, error)
+	hooks       []func(context.Context, int) (dbstore.IndexConfiguration, bool, error)
+	history     []EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIndexConfigurationByRepositoryID delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockEnqueuerDBStore) GetIndexConfigurationByRepositoryID(v0 context.Context, v1 int) (dbstore.IndexConfiguration, bool, error) {
+	r0, r1, r2 := m.GetIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1)
+	m.GetIndexConfigurationByRepositoryIDFunc.appendCall(EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetIndexConfigurationByRepositoryID method of the parent
+// MockEnqueuerDBStore instance is invoked and the hook queue is empty.
+func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.IndexConfiguration, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIndexConfigurationByRepositoryID method of the parent
+// MockEnqueuerDBStore instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) PushHook(hook func(context.Context, int) (dbstore.IndexConfiguration, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) SetDefaultReturn(r0 dbstore.IndexConfiguration, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) PushReturn(r0 dbstore.IndexConfiguration, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) nextHook() func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) appendCall(r0 EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall objects
+// describing the invocations of this function.
+func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) History() []EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall {
+	f.mutex.Lock()
+	history := make([]EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall is an object
+// that describes an invocation of method
+// GetIndexConfigurationByRepositoryID on an instance of
+// MockEnqueuerDBStore.
+type EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.IndexConfiguration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
-// NewMockEnqueuerDBStoreFrom creates a new mock of the MockEnqueuerDBStore
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockEnqueuerDBStoreFrom(i EnqueuerDBStore) *MockEnqueuerDBStore {
-	return &MockEnqueuerDBStore{
-		DirtyRepositoriesFunc: &EnqueuerDBStoreDirtyRepositoriesFunc{
-			defaultHook: i.DirtyRepositories,
-		},
-		DoneFunc: &EnqueuerDBStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		GetIndexConfigurationByRepositoryIDFunc: &EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc{
-			defaultHook: i.GetIndexConfigurationByRepositoryID,
-		},
-		GetRepositoriesWithIndexConfigurationFunc: &EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc{
-			defaultHook: i.GetRepositoriesWithIndexConfiguration,
-		},
-		HandleFunc: &EnqueuerDBStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		InsertIndexFunc: &EnqueuerDBStoreInsertIndexFunc{
-			defaultHook: i.InsertIndex,
-		},
-		IsQueuedFunc: &EnqueuerDBStoreIsQueuedFunc{
-			defaultHook: i.IsQueued,
-		},
-		TransactFunc: &EnqueuerDBStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-	}
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
-// EnqueuerDBStoreDirtyRepositoriesFunc describes the behavior when the
-// DirtyRepositories method of the parent MockEnqueuerDBStore instance is
-// invoked.
-type EnqueuerDBStoreDirtyRepositoriesFunc struct {
-	defaultHook func(context.Context) (map[int]int, error)
-	hooks       []func(context.Context) (map[int]int, error)
-	history     []EnqueuerDBStoreDirtyRepositoriesFuncCall
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc describes the
+// behavior when the GetRepositoriesWithIndexConfiguration method of the
+// parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall
 	mutex       sync.Mutex
 }
 
-// DirtyRepositories delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) DirtyRepositories(v0 context.Context) (map[int]int, error) {
-	r0, r1 := m.DirtyRepositoriesFunc.nextHook()(v0)
-	m.DirtyRepositoriesFunc.appendCall(EnqueuerDBStoreDirtyRepositoriesFuncCall{v0, r0, r1})
+// GetRepositoriesWithIndexConfiguration delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockEnqueuerDBStore) GetRepositoriesWithIndexConfiguration(v0 context.Context) ([]int, error) {
+	r0, r1 := m.GetRepositoriesWithIndexConfigurationFunc.nextHook()(v0)
+	m.GetRepositoriesWithIndexConfigurationFunc.appendCall(EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the DirtyRepositories
-// method of the parent MockEnqueuerDBStore instance is invoked and the hook
-// queue is empty.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+// SetDefaultHook sets function that is called when the
+// GetRepositoriesWithIndexConfiguration method of the parent
+// MockEnqueuerDBStore instance is invoked and the hook queue is empty.
+func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultHook(hook func(context.Context) ([]int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DirtyRepositories method of the parent MockEnqueuerDBStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushHook(hook func(context.Context) (map[int]int, error)) {
+// GetRepositoriesWithIndexConfiguration method of the parent
+// MockEnqueuerDBStore instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) PushHook(hook func(context.Context) ([]int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2681,21 +4120,21 @@ func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
-	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushReturn(r0 map[int]int, r1 error) {
-	f.PushHook(func(context.Context) (map[int]int, error) {
+func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) nextHook() func(context.Context) (map[int]int, error) {
+func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) nextHook() func(context.Context) ([]int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2708,33 +4147,35 @@ func (f *EnqueuerDBStoreDirtyRepositoriesFunc) nextHook() func(context.Context)
 	return hook
 }
 
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) appendCall(r0 EnqueuerDBStoreDirtyRepositoriesFuncCall) {
+func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) appendCall(r0 EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerDBStoreDirtyRepositoriesFuncCall
-// objects describing the invocations of this function.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) History() []EnqueuerDBStoreDirtyRepositoriesFuncCall {
+// History returns a sequence of
+// EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall objects
+// describing the invocations of this function.
+func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) History() []EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreDirtyRepositoriesFuncCall, len(f.history))
+	history := make([]EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreDirtyRepositoriesFuncCall is an object that describes an
-// invocation of method DirtyRepositories on an instance of
+// EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall is an object
+// that describes an invocation of method
+// GetRepositoriesWithIndexConfiguration on an instance of
 // MockEnqueuerDBStore.
-type EnqueuerDBStoreDirtyRepositoriesFuncCall struct {
+type EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[int]int
+	Result0 []int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2742,45 +4183,45 @@ type EnqueuerDBStoreDirtyRepositoriesFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Args() []interface{} {
+func (c EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Results() []interface{} {
+func (c EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// EnqueuerDBStoreDoneFunc describes the behavior when the Done method of
-// the parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []EnqueuerDBStoreDoneFuncCall
+// EnqueuerDBStoreHandleFunc describes the behavior when the Handle method
+// of the parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []EnqueuerDBStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(EnqueuerDBStoreDoneFuncCall{v0, r0})
+func (m *MockEnqueuerDBStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(EnqueuerDBStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
+// SetDefaultHook sets function that is called when the Handle method of the
 // parent MockEnqueuerDBStore instance is invoked and the hook queue is
 // empty.
-func (f *EnqueuerDBStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+func (f *EnqueuerDBStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockEnqueuerDBStore instance invokes the hook
+// Handle method of the parent MockEnqueuerDBStore instance invokes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *EnqueuerDBStoreDoneFunc) PushHook(hook func(error) error) {
+func (f *EnqueuerDBStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2788,21 +4229,21 @@ func (f *EnqueuerDBStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
+func (f *EnqueuerDBStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
+func (f *EnqueuerDBStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *EnqueuerDBStoreDoneFunc) nextHook() func(error) error {
+func (f *EnqueuerDBStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2815,78 +4256,72 @@ func (f *EnqueuerDBStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *EnqueuerDBStoreDoneFunc) appendCall(r0 EnqueuerDBStoreDoneFuncCall) {
+func (f *EnqueuerDBStoreHandleFunc) appendCall(r0 EnqueuerDBStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerDBStoreDoneFuncCall objects
+// History returns a sequence of EnqueuerDBStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *EnqueuerDBStoreDoneFunc) History() []EnqueuerDBStoreDoneFuncCall {
+func (f *EnqueuerDBStoreHandleFunc) History() []EnqueuerDBStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreDoneFuncCall, len(f.history))
+	history := make([]EnqueuerDBStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreDoneFuncCall is an object that describes an invocation of
-// method Done on an instance of MockEnqueuerDBStore.
-type EnqueuerDBStoreDoneFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 error
+// EnqueuerDBStoreHandleFuncCall is an object that describes an invocation
+// of method Handle on an instance of MockEnqueuerDBStore.
+type EnqueuerDBStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c EnqueuerDBStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreDoneFuncCall) Results() []interface{} {
+func (c EnqueuerDBStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc describes the
-// behavior when the GetIndexConfigurationByRepositoryID method of the
-// parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc struct {
-	defaultHook func(context.Context, int) (dbstore.IndexConfiguration, bool, error)
-	hooks       []func(context.Context, int) (dbstore.IndexConfiguration, bool, error)
-	history     []EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall
+// EnqueuerDBStoreInsertIndexFunc describes the behavior when the
+// InsertIndex method of the parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreInsertIndexFunc struct {
+	defaultHook func(context.Context, dbstore.Index) (int, error)
+	hooks       []func(context.Context, dbstore.Index) (int, error)
+	history     []EnqueuerDBStoreInsertIndexFuncCall
 	mutex       sync.Mutex
 }
 
-// GetIndexConfigurationByRepositoryID delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockEnqueuerDBStore) GetIndexConfigurationByRepositoryID(v0 context.Context, v1 int) (dbstore.IndexConfiguration, bool, error) {
-	r0, r1, r2 := m.GetIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1)
-	m.GetIndexConfigurationByRepositoryIDFunc.appendCall(EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall{v0, v1, r0, r1, r2})
-	return r0, r1, r2
+// InsertIndex delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockEnqueuerDBStore) InsertIndex(v0 context.Context, v1 dbstore.Index) (int, error) {
+	r0, r1 := m.InsertIndexFunc.nextHook()(v0, v1)
+	m.InsertIndexFunc.appendCall(EnqueuerDBStoreInsertIndexFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetIndexConfigurationByRepositoryID method of the parent
-// MockEnqueuerDBStore instance is invoked and the hook queue is empty.
-func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.IndexConfiguration, bool, error)) {
+// SetDefaultHook sets function that is called when the InsertIndex method
+// of the parent MockEnqueuerDBStore instance is invoked and the hook queue
+// is empty.
+func (f *EnqueuerDBStoreInsertIndexFunc) SetDefaultHook(hook func(context.Context, dbstore.Index) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetIndexConfigurationByRepositoryID method of the parent
-// MockEnqueuerDBStore instance invokes the hook at the front of the queue
-// and discards it. After the queue is empty, the default hook function is
-// invoked for any future action.
-func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) PushHook(hook func(context.Context, int) (dbstore.IndexConfiguration, bool, error)) {
+// InsertIndex method of the parent MockEnqueuerDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnqueuerDBStoreInsertIndexFunc) PushHook(hook func(context.Context, dbstore.Index) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2894,21 +4329,21 @@ func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) PushHook(hook f
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) SetDefaultReturn(r0 dbstore.IndexConfiguration, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
-		return r0, r1, r2
+func (f *EnqueuerDBStoreInsertIndexFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.Index) (int, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) PushReturn(r0 dbstore.IndexConfiguration, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
-		return r0, r1, r2
+func (f *EnqueuerDBStoreInsertIndexFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, dbstore.Index) (int, error) {
+		return r0, r1
 	})
 }
 
-func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) nextHook() func(context.Context, int) (dbstore.IndexConfiguration, bool, error) {
+func (f *EnqueuerDBStoreInsertIndexFunc) nextHook() func(context.Context, dbstore.Index) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2921,90 +4356,81 @@ func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) nextHook() func
 	return hook
 }
 
-func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) appendCall(r0 EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall) {
+func (f *EnqueuerDBStoreInsertIndexFunc) appendCall(r0 EnqueuerDBStoreInsertIndexFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall objects
+// History returns a sequence of EnqueuerDBStoreInsertIndexFuncCall objects
 // describing the invocations of this function.
-func (f *EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFunc) History() []EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall {
+func (f *EnqueuerDBStoreInsertIndexFunc) History() []EnqueuerDBStoreInsertIndexFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall, len(f.history))
+	history := make([]EnqueuerDBStoreInsertIndexFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall is an object
-// that describes an invocation of method
-// GetIndexConfigurationByRepositoryID on an instance of
-// MockEnqueuerDBStore.
-type EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall struct {
+// EnqueuerDBStoreInsertIndexFuncCall is an object that describes an
+// invocation of method InsertIndex on an instance of MockEnqueuerDBStore.
+type EnqueuerDBStoreInsertIndexFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 dbstore.Index
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 dbstore.IndexConfiguration
+	Result0 int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall) Args() []interface{} {
+func (c EnqueuerDBStoreInsertIndexFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreGetIndexConfigurationByRepositoryIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c EnqueuerDBStoreInsertIndexFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc describes the
-// behavior when the GetRepositoriesWithIndexConfiguration method of the
-// parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc struct {
-	defaultHook func(context.Context) ([]int, error)
-	hooks       []func(context.Context) ([]int, error)
-	history     []EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall
+// EnqueuerDBStoreIsQueuedFunc describes the behavior when the IsQueued
+// method of the parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreIsQueuedFunc struct {
+	defaultHook func(context.Context, int, string) (bool, error)
+	hooks       []func(context.Context, int, string) (bool, error)
+	history     []EnqueuerDBStoreIsQueuedFuncCall
 	mutex       sync.Mutex
 }
 
-// GetRepositoriesWithIndexConfiguration delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockEnqueuerDBStore) GetRepositoriesWithIndexConfiguration(v0 context.Context) ([]int, error) {
-	r0, r1 := m.GetRepositoriesWithIndexConfigurationFunc.nextHook()(v0)
-	m.GetRepositoriesWithIndexConfigurationFunc.appendCall(EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall{v0, r0, r1})
+// IsQueued delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnqueuerDBStore) IsQueued(v0 context.Context, v1 int, v2 string) (bool, error) {
+	r0, r1 := m.IsQueuedFunc.nextHook()(v0, v1, v2)
+	m.IsQueuedFunc.appendCall(EnqueuerDBStoreIsQueuedFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetRepositoriesWithIndexConfiguration method of the parent
-// MockEnqueuerDBStore instance is invoked and the hook queue is empty.
-func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+// SetDefaultHook sets function that is called when the IsQueued method of
+// the parent MockEnqueuerDBStore instance is invoked and the hook queue is
+// empty.
+func (f *EnqueuerDBStoreIsQueuedFunc) SetDefaultHook(hook func(context.Context, int, string) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetRepositoriesWithIndexConfiguration method of the parent
-// MockEnqueuerDBStore instance invokes the hook at the front of the queue
-// and discards it. After the queue is empty, the default hook function is
-// invoked for any future action.
-func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) PushHook(hook func(context.Context) ([]int, error)) {
+// IsQueued method of the parent MockEnqueuerDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnqueuerDBStoreIsQueuedFunc) PushHook(hook func(context.Context, int, string) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3012,21 +4438,21 @@ func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) PushHook(hook
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultReturn(r0 []int, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]int, error) {
+func (f *EnqueuerDBStoreIsQueuedFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (bool, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) PushReturn(r0 []int, r1 error) {
-	f.PushHook(func(context.Context) ([]int, error) {
+func (f *EnqueuerDBStoreIsQueuedFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int, string) (bool, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) nextHook() func(context.Context) ([]int, error) {
+func (f *EnqueuerDBStoreIsQueuedFunc) nextHook() func(context.Context, int, string) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3039,35 +4465,38 @@ func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) nextHook() fu
 	return hook
 }
 
-func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) appendCall(r0 EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall) {
+func (f *EnqueuerDBStoreIsQueuedFunc) appendCall(r0 EnqueuerDBStoreIsQueuedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall objects
+// History returns a sequence of EnqueuerDBStoreIsQueuedFuncCall objects
 // describing the invocations of this function.
-func (f *EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFunc) History() []EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall {
+func (f *EnqueuerDBStoreIsQueuedFunc) History() []EnqueuerDBStoreIsQueuedFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall, len(f.history))
+	history := make([]EnqueuerDBStoreIsQueuedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall is an object
-// that describes an invocation of method
-// GetRepositoriesWithIndexConfiguration on an instance of
-// MockEnqueuerDBStore.
-type EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall struct {
+// EnqueuerDBStoreIsQueuedFuncCall is an object that describes an invocation
+// of method IsQueued on an instance of MockEnqueuerDBStore.
+type EnqueuerDBStoreIsQueuedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []int
+	Result0 bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3075,45 +4504,45 @@ type EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c EnqueuerDBStoreIsQueuedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreGetRepositoriesWithIndexConfigurationFuncCall) Results() []interface{} {
+func (c EnqueuerDBStoreIsQueuedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// EnqueuerDBStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreHandleFunc struct {
-	defaultHook func() *basestore.TransactableHandle
-	hooks       []func() *basestore.TransactableHandle
-	history     []EnqueuerDBStoreHandleFuncCall
+// EnqueuerDBStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreTransactFunc struct {
+	defaultHook func(context.Context) (enqueuer.DBStore, error)
+	hooks       []func(context.Context) (enqueuer.DBStore, error)
+	history     []EnqueuerDBStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
+// Transact delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) Handle() *basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(EnqueuerDBStoreHandleFuncCall{r0})
-	return r0
+func (m *MockEnqueuerDBStore) Transact(v0 context.Context) (enqueuer.DBStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(EnqueuerDBStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockEnqueuerDBStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockEnqueuerDBStore instance is invoked and the hook queue is
 // empty.
-func (f *EnqueuerDBStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+func (f *EnqueuerDBStoreTransactFunc) SetDefaultHook(hook func(context.Context) (enqueuer.DBStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockEnqueuerDBStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *EnqueuerDBStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+// Transact method of the parent MockEnqueuerDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnqueuerDBStoreTransactFunc) PushHook(hook func(context.Context) (enqueuer.DBStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3121,21 +4550,21 @@ func (f *EnqueuerDBStoreHandleFunc) PushHook(hook func() *basestore.Transactable
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
-	f.SetDefaultHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *EnqueuerDBStoreTransactFunc) SetDefaultReturn(r0 enqueuer.DBStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (enqueuer.DBStore, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
-	f.PushHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *EnqueuerDBStoreTransactFunc) PushReturn(r0 enqueuer.DBStore, r1 error) {
+	f.PushHook(func(context.Context) (enqueuer.DBStore, error) {
+		return r0, r1
 	})
 }
 
-func (f *EnqueuerDBStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *EnqueuerDBStoreTransactFunc) nextHook() func(context.Context) (enqueuer.DBStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3148,72 +4577,158 @@ func (f *EnqueuerDBStoreHandleFunc) nextHook() func() *basestore.TransactableHan
 	return hook
 }
 
-func (f *EnqueuerDBStoreHandleFunc) appendCall(r0 EnqueuerDBStoreHandleFuncCall) {
+func (f *EnqueuerDBStoreTransactFunc) appendCall(r0 EnqueuerDBStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerDBStoreHandleFuncCall objects
+// History returns a sequence of EnqueuerDBStoreTransactFuncCall objects
 // describing the invocations of this function.
-func (f *EnqueuerDBStoreHandleFunc) History() []EnqueuerDBStoreHandleFuncCall {
+func (f *EnqueuerDBStoreTransactFunc) History() []EnqueuerDBStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreHandleFuncCall, len(f.history))
+	history := make([]EnqueuerDBStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreHandleFuncCall is an object that describes an invocation
-// of method Handle on an instance of MockEnqueuerDBStore.
-type EnqueuerDBStoreHandleFuncCall struct {
+// EnqueuerDBStoreTransactFuncCall is an object that describes an invocation
+// of method Transact on an instance of MockEnqueuerDBStore.
+type EnqueuerDBStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *basestore.TransactableHandle
+	Result0 enqueuer.DBStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c EnqueuerDBStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c EnqueuerDBStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockEnqueuerGitserverClient is a mock implementation of the
+// EnqueuerGitserverClient interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockEnqueuerGitserverClient struct {
+	// FileExistsFunc is an instance of a mock function object controlling
+	// the behavior of the method FileExists.
+	FileExistsFunc *EnqueuerGitserverClientFileExistsFunc
+	// HeadFunc is an instance of a mock function object controlling the
+	// behavior of the method Head.
+	HeadFunc *EnqueuerGitserverClientHeadFunc
+	// ListFilesFunc is an instance of a mock function object controlling
+	// the behavior of the method ListFiles.
+	ListFilesFunc *EnqueuerGitserverClientListFilesFunc
+	// RawContentsFunc is an instance of a mock function object controlling
+	// the behavior of the method RawContents.
+	RawContentsFunc *EnqueuerGitserverClientRawContentsFunc
+	// ResolveRevisionFunc is an instance of a mock function object
+	// controlling the behavior of the method ResolveRevision.
+	ResolveRevisionFunc *EnqueuerGitserverClientResolveRevisionFunc
+}
+
+// NewMockEnqueuerGitserverClient creates a new mock of the
+// EnqueuerGitserverClient interface. All methods return zero values for all
+// results, unless overwritten.
+func NewMockEnqueuerGitserverClient() *MockEnqueuerGitserverClient {
+	return &MockEnqueuerGitserverClient{
+		FileExistsFunc: &EnqueuerGitserverClientFileExistsFunc{
+			defaultHook: func(context.Context, int, string, string) (bool, error) {
+				return false, nil
+			},
+		},
+		HeadFunc: &EnqueuerGitserverClientHeadFunc{
+			defaultHook: func(context.Context, int) (string, bool, error) {
+				return "", false, nil
+			},
+		},
+		ListFilesFunc: &EnqueuerGitserverClientListFilesFunc{
+			defaultHook: func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
+				return nil, nil
+			},
+		},
+		RawContentsFunc: &EnqueuerGitserverClientRawContentsFunc{
+			defaultHook: func(context.Context, int, string, string) ([]byte, error) {
+				return nil, nil
+			},
+		},
+		ResolveRevisionFunc: &EnqueuerGitserverClientResolveRevisionFunc{
+			defaultHook: func(context.Context, int, string) (api.CommitID, error) {
+				return "", nil
+			},
+		},
+	}
+}
+
+// NewMockEnqueuerGitserverClientFrom creates a new mock of the
+// MockEnqueuerGitserverClient interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockEnqueuerGitserverClientFrom(i EnqueuerGitserverClient) *MockEnqueuerGitserverClient {
+	return &MockEnqueuerGitserverClient{
+		FileExistsFunc: &EnqueuerGitserverClientFileExistsFunc{
+			defaultHook: i.FileExists,
+		},
+		HeadFunc: &EnqueuerGitserverClientHeadFunc{
+			defaultHook: i.Head,
+		},
+		ListFilesFunc: &EnqueuerGitserverClientListFilesFunc{
+			defaultHook: i.ListFiles,
+		},
+		RawContentsFunc: &EnqueuerGitserverClientRawContentsFunc{
+			defaultHook: i.RawContents,
+		},
+		ResolveRevisionFunc: &EnqueuerGitserverClientResolveRevisionFunc{
+			defaultHook: i.ResolveRevision,
+		},
+	}
 }
 
-// EnqueuerDBStoreInsertIndexFunc describes the behavior when the
-// InsertIndex method of the parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreInsertIndexFunc struct {
-	defaultHook func(context.Context, dbstore.Index) (int, error)
-	hooks       []func(context.Context, dbstore.Index) (int, error)
-	history     []EnqueuerDBStoreInsertIndexFuncCall
+// EnqueuerGitserverClientFileExistsFunc describes the behavior when the
+// FileExists method of the parent MockEnqueuerGitserverClient instance is
+// invoked.
+type EnqueuerGitserverClientFileExistsFunc struct {
+	defaultHook func(context.Context, int, string, string) (bool, error)
+	hooks       []func(context.Context, int, string, string) (bool, error)
+	history     []EnqueuerGitserverClientFileExistsFuncCall
 	mutex       sync.Mutex
 }
 
-// InsertIndex delegates to the next hook function in the queue and stores
+// FileExists delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) InsertIndex(v0 context.Context, v1 dbstore.Index) (int, error) {
-	r0, r1 := m.InsertIndexFunc.nextHook()(v0, v1)
-	m.InsertIndexFunc.appendCall(EnqueuerDBStoreInsertIndexFuncCall{v0, v1, r0, r1})
+func (m *MockEnqueuerGitserverClient) FileExists(v0 context.Context, v1 int, v2 string, v3 string) (bool, error) {
+	r0, r1 := m.FileExistsFunc.nextHook()(v0, v1, v2, v3)
+	m.FileExistsFunc.appendCall(EnqueuerGitserverClientFileExistsFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the InsertIndex method
-// of the parent MockEnqueuerDBStore instance is invoked and the hook queue
-// is empty.
-func (f *EnqueuerDBStoreInsertIndexFunc) SetDefaultHook(hook func(context.Context, dbstore.Index) (int, error)) {
+// SetDefaultHook sets function that is called when the FileExists method of
+// the parent MockEnqueuerGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *EnqueuerGitserverClientFileExistsFunc) SetDefaultHook(hook func(context.Context, int, string, string) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// InsertIndex method of the parent MockEnqueuerDBStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *EnqueuerDBStoreInsertIndexFunc) PushHook(hook func(context.Context, dbstore.Index) (int, error)) {
+// FileExists method of the parent MockEnqueuerGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnqueuerGitserverClientFileExistsFunc) PushHook(hook func(context.Context, int, string, string) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3221,21 +4736,21 @@ func (f *EnqueuerDBStoreInsertIndexFunc) PushHook(hook func(context.Context, dbs
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreInsertIndexFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, dbstore.Index) (int, error) {
+func (f *EnqueuerGitserverClientFileExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) (bool, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreInsertIndexFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, dbstore.Index) (int, error) {
+func (f *EnqueuerGitserverClientFileExistsFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) (bool, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerDBStoreInsertIndexFunc) nextHook() func(context.Context, dbstore.Index) (int, error) {
+func (f *EnqueuerGitserverClientFileExistsFunc) nextHook() func(context.Context, int, string, string) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3248,35 +4763,42 @@ func (f *EnqueuerDBStoreInsertIndexFunc) nextHook() func(context.Context, dbstor
 	return hook
 }
 
-func (f *EnqueuerDBStoreInsertIndexFunc) appendCall(r0 EnqueuerDBStoreInsertIndexFuncCall) {
+func (f *EnqueuerGitserverClientFileExistsFunc) appendCall(r0 EnqueuerGitserverClientFileExistsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerDBStoreInsertIndexFuncCall objects
-// describing the invocations of this function.
-func (f *EnqueuerDBStoreInsertIndexFunc) History() []EnqueuerDBStoreInsertIndexFuncCall {
+// History returns a sequence of EnqueuerGitserverClientFileExistsFuncCall
+// objects describing the invocations of this function.
+func (f *EnqueuerGitserverClientFileExistsFunc) History() []EnqueuerGitserverClientFileExistsFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreInsertIndexFuncCall, len(f.history))
+	history := make([]EnqueuerGitserverClientFileExistsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreInsertIndexFuncCall is an object that describes an
-// invocation of method InsertIndex on an instance of MockEnqueuerDBStore.
-type EnqueuerDBStoreInsertIndexFuncCall struct {
+// EnqueuerGitserverClientFileExistsFuncCall is an object that describes an
+// invocation of method FileExists on an instance of
+// MockEnqueuerGitserverClient.
+type EnqueuerGitserverClientFileExistsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 dbstore.Index
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3284,45 +4806,45 @@ type EnqueuerDBStoreInsertIndexFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreInsertIndexFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c EnqueuerGitserverClientFileExistsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreInsertIndexFuncCall) Results() []interface{} {
+func (c EnqueuerGitserverClientFileExistsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// EnqueuerDBStoreIsQueuedFunc describes the behavior when the IsQueued
-// method of the parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreIsQueuedFunc struct {
-	defaultHook func(context.Context, int, string) (bool, error)
-	hooks       []func(context.Context, int, string) (bool, error)
-	history     []EnqueuerDBStoreIsQueuedFuncCall
+// EnqueuerGitserverClientHeadFunc describes the behavior when the Head
+// method of the parent MockEnqueuerGitserverClient instance is invoked.
+type EnqueuerGitserverClientHeadFunc struct {
+	defaultHook func(context.Context, int) (string, bool, error)
+	hooks       []func(context.Context, int) (string, bool, error)
+	history     []EnqueuerGitserverClientHeadFuncCall
 	mutex       sync.Mutex
 }
 
-// IsQueued delegates to the next hook function in the queue and stores the
+// Head delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) IsQueued(v0 context.Context, v1 int, v2 string) (bool, error) {
-	r0, r1 := m.IsQueuedFunc.nextHook()(v0, v1, v2)
-	m.IsQueuedFunc.appendCall(EnqueuerDBStoreIsQueuedFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+func (m *MockEnqueuerGitserverClient) Head(v0 context.Context, v1 int) (string, bool, error) {
+	r0, r1, r2 := m.HeadFunc.nextHook()(v0, v1)
+	m.HeadFunc.appendCall(EnqueuerGitserverClientHeadFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the IsQueued method of
-// the parent MockEnqueuerDBStore instance is invoked and the hook queue is
-// empty.
-func (f *EnqueuerDBStoreIsQueuedFunc) SetDefaultHook(hook func(context.Context, int, string) (bool, error)) {
+// SetDefaultHook sets function that is called when the Head method of the
+// parent MockEnqueuerGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *EnqueuerGitserverClientHeadFunc) SetDefaultHook(hook func(context.Context, int) (string, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// IsQueued method of the parent MockEnqueuerDBStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *EnqueuerDBStoreIsQueuedFunc) PushHook(hook func(context.Context, int, string) (bool, error)) {
+// Head method of the parent MockEnqueuerGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnqueuerGitserverClientHeadFunc) PushHook(hook func(context.Context, int) (string, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3330,21 +4852,21 @@ func (f *EnqueuerDBStoreIsQueuedFunc) PushHook(hook func(context.Context, int, s
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreIsQueuedFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string) (bool, error) {
-		return r0, r1
+func (f *EnqueuerGitserverClientHeadFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreIsQueuedFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, int, string) (bool, error) {
-		return r0, r1
+func (f *EnqueuerGitserverClientHeadFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *EnqueuerDBStoreIsQueuedFunc) nextHook() func(context.Context, int, string) (bool, error) {
+func (f *EnqueuerGitserverClientHeadFunc) nextHook() func(context.Context, int) (string, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3357,84 +4879,86 @@ func (f *EnqueuerDBStoreIsQueuedFunc) nextHook() func(context.Context, int, stri
 	return hook
 }
 
-func (f *EnqueuerDBStoreIsQueuedFunc) appendCall(r0 EnqueuerDBStoreIsQueuedFuncCall) {
+func (f *EnqueuerGitserverClientHeadFunc) appendCall(r0 EnqueuerGitserverClientHeadFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerDBStoreIsQueuedFuncCall objects
+// History returns a sequence of EnqueuerGitserverClientHeadFuncCall objects
 // describing the invocations of this function.
-func (f *EnqueuerDBStoreIsQueuedFunc) History() []EnqueuerDBStoreIsQueuedFuncCall {
+func (f *EnqueuerGitserverClientHeadFunc) History() []EnqueuerGitserverClientHeadFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreIsQueuedFuncCall, len(f.history))
+	history := make([]EnqueuerGitserverClientHeadFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreIsQueuedFuncCall is an object that describes an invocation
-// of method IsQueued on an instance of MockEnqueuerDBStore.
-type EnqueuerDBStoreIsQueuedFuncCall struct {
+// EnqueuerGitserverClientHeadFuncCall is an object that describes an
+// invocation of method Head on an instance of MockEnqueuerGitserverClient.
+type EnqueuerGitserverClientHeadFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreIsQueuedFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c EnqueuerGitserverClientHeadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreIsQueuedFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c EnqueuerGitserverClientHeadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// EnqueuerDBStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreTransactFunc struct {
-	defaultHook func(context.Context) (enqueuer.DBStore, error)
-	hooks       []func(context.Context) (enqueuer.DBStore, error)
-	history     []EnqueuerDBStoreTransactFuncCall
+// EnqueuerGitserverClientListFilesFunc describes the behavior when the
+// ListFiles method of the parent MockEnqueuerGitserverClient instance is
+// invoked.
+type EnqueuerGitserverClientListFilesFunc struct {
+	defaultHook func(context.Context, int, string, *regexp.Regexp) ([]string, error)
+	hooks       []func(context.Context, int, string, *regexp.Regexp) ([]string, error)
+	history     []EnqueuerGitserverClientListFilesFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
+// ListFiles delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) Transact(v0 context.Context) (enqueuer.DBStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(EnqueuerDBStoreTransactFuncCall{v0, r0, r1})
+func (m *MockEnqueuerGitserverClient) ListFiles(v0 context.Context, v1 int, v2 string, v3 *regexp.Regexp) ([]string, error) {
+	r0, r1 := m.ListFilesFunc.nextHook()(v0, v1, v2, v3)
+	m.ListFilesFunc.appendCall(EnqueuerGitserverClientListFilesFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockEnqueuerDBStore instance is invoked and the hook queue is
-// empty.
-func (f *EnqueuerDBStoreTransactFunc) SetDefaultHook(hook func(context.Context) (enqueuer.DBStore, error)) {
+// SetDefaultHook sets function that is called when the ListFiles method of
+// the parent MockEnqueuerGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *EnqueuerGitserverClientListFilesFunc) SetDefaultHook(hook func(context.Context, int, string, *regexp.Regexp) ([]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockEnqueuerDBStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *EnqueuerDBStoreTransactFunc) PushHook(hook func(context.Context) (enqueuer.DBStore, error)) {
+// ListFiles method of the parent MockEnqueuerGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnqueuerGitserverClientListFilesFunc) PushHook(hook func(context.Context, int, string, *regexp.Regexp) ([]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3442,21 +4966,21 @@ func (f *EnqueuerDBStoreTransactFunc) PushHook(hook func(context.Context) (enque
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreTransactFunc) SetDefaultReturn(r0 enqueuer.DBStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (enqueuer.DBStore, error) {
+func (f *EnqueuerGitserverClientListFilesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreTransactFunc) PushReturn(r0 enqueuer.DBStore, r1 error) {
-	f.PushHook(func(context.Context) (enqueuer.DBStore, error) {
+func (f *EnqueuerGitserverClientListFilesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerDBStoreTransactFunc) nextHook() func(context.Context) (enqueuer.DBStore, error) {
+func (f *EnqueuerGitserverClientListFilesFunc) nextHook() func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3469,32 +4993,42 @@ func (f *EnqueuerDBStoreTransactFunc) nextHook() func(context.Context) (enqueuer
 	return hook
 }
 
-func (f *EnqueuerDBStoreTransactFunc) appendCall(r0 EnqueuerDBStoreTransactFuncCall) {
+func (f *EnqueuerGitserverClientListFilesFunc) appendCall(r0 EnqueuerGitserverClientListFilesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerDBStoreTransactFuncCall objects
-// describing the invocations of this function.
-func (f *EnqueuerDBStoreTransactFunc) History() []EnqueuerDBStoreTransactFuncCall {
+// History returns a sequence of EnqueuerGitserverClientListFilesFuncCall
+// objects describing the invocations of this function.
+func (f *EnqueuerGitserverClientListFilesFunc) History() []EnqueuerGitserverClientListFilesFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreTransactFuncCall, len(f.history))
+	history := make([]EnqueuerGitserverClientListFilesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreTransactFuncCall is an object that describes an invocation
-// of method Transact on an instance of MockEnqueuerDBStore.
-type EnqueuerDBStoreTransactFuncCall struct {
+// EnqueuerGitserverClientListFilesFuncCall is an object that describes an
+// invocation of method ListFiles on an instance of
+// MockEnqueuerGitserverClient.
+type EnqueuerGitserverClientListFilesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *regexp.Regexp
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 enqueuer.DBStore
+	Result0 []string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3502,125 +5036,47 @@ type EnqueuerDBStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c EnqueuerGitserverClientListFilesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreTransactFuncCall) Results() []interface{} {
+func (c EnqueuerGitserverClientListFilesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockEnqueuerGitserverClient is a mock implementation of the
-// EnqueuerGitserverClient interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
-// used for unit testing.
-type MockEnqueuerGitserverClient struct {
-	// FileExistsFunc is an instance of a mock function object controlling
-	// the behavior of the method FileExists.
-	FileExistsFunc *EnqueuerGitserverClientFileExistsFunc
-	// HeadFunc is an instance of a mock function object controlling the
-	// behavior of the method Head.
-	HeadFunc *EnqueuerGitserverClientHeadFunc
-	// ListFilesFunc is an instance of a mock function object controlling
-	// the behavior of the method ListFiles.
-	ListFilesFunc *EnqueuerGitserverClientListFilesFunc
-	// RawContentsFunc is an instance of a mock function object controlling
-	// the behavior of the method RawContents.
-	RawContentsFunc *EnqueuerGitserverClientRawContentsFunc
-	// ResolveRevisionFunc is an instance of a mock function object
-	// controlling the behavior of the method ResolveRevision.
-	ResolveRevisionFunc *EnqueuerGitserverClientResolveRevisionFunc
-}
-
-// NewMockEnqueuerGitserverClient creates a new mock of the
-// EnqueuerGitserverClient interface. All methods return zero values for all
-// results, unless overwritten.
-func NewMockEnqueuerGitserverClient() *MockEnqueuerGitserverClient {
-	return &MockEnqueuerGitserverClient{
-		FileExistsFunc: &EnqueuerGitserverClientFileExistsFunc{
-			defaultHook: func(context.Context, int, string, string) (bool, error) {
-				return false, nil
-			},
-		},
-		HeadFunc: &EnqueuerGitserverClientHeadFunc{
-			defaultHook: func(context.Context, int) (string, bool, error) {
-				return "", false, nil
-			},
-		},
-		ListFilesFunc: &EnqueuerGitserverClientListFilesFunc{
-			defaultHook: func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
-				return nil, nil
-			},
-		},
-		RawContentsFunc: &EnqueuerGitserverClientRawContentsFunc{
-			defaultHook: func(context.Context, int, string, string) ([]byte, error) {
-				return nil, nil
-			},
-		},
-		ResolveRevisionFunc: &EnqueuerGitserverClientResolveRevisionFunc{
-			defaultHook: func(context.Context, int, string) (api.CommitID, error) {
-				return "", nil
-			},
-		},
-	}
-}
-
-// NewMockEnqueuerGitserverClientFrom creates a new mock of the
-// MockEnqueuerGitserverClient interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockEnqueuerGitserverClientFrom(i EnqueuerGitserverClient) *MockEnqueuerGitserverClient {
-	return &MockEnqueuerGitserverClient{
-		FileExistsFunc: &EnqueuerGitserverClientFileExistsFunc{
-			defaultHook: i.FileExists,
-		},
-		HeadFunc: &EnqueuerGitserverClientHeadFunc{
-			defaultHook: i.Head,
-		},
-		ListFilesFunc: &EnqueuerGitserverClientListFilesFunc{
-			defaultHook: i.ListFiles,
-		},
-		RawContentsFunc: &EnqueuerGitserverClientRawContentsFunc{
-			defaultHook: i.RawContents,
-		},
-		ResolveRevisionFunc: &EnqueuerGitserverClientResolveRevisionFunc{
-			defaultHook: i.ResolveRevision,
-		},
-	}
-}
-
-// EnqueuerGitserverClientFileExistsFunc describes the behavior when the
-// FileExists method of the parent MockEnqueuerGitserverClient instance is
+// EnqueuerGitserverClientRawContentsFunc describes the behavior when the
+// RawContents method of the parent MockEnqueuerGitserverClient instance is
 // invoked.
-type EnqueuerGitserverClientFileExistsFunc struct {
-	defaultHook func(context.Context, int, string, string) (bool, error)
-	hooks       []func(context.Context, int, string, string) (bool, error)
-	history     []EnqueuerGitserverClientFileExistsFuncCall
+type EnqueuerGitserverClientRawContentsFunc struct {
+	defaultHook func(context.Context, int, string, string) ([]byte, error)
+	hooks       []func(context.Context, int, string, string) ([]byte, error)
+	history     []EnqueuerGitserverClientRawContentsFuncCall
 	mutex       sync.Mutex
 }
 
-// FileExists delegates to the next hook function in the queue and stores
+// RawContents delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockEnqueuerGitserverClient) FileExists(v0 context.Context, v1 int, v2 string, v3 string) (bool, error) {
-	r0, r1 := m.FileExistsFunc.nextHook()(v0, v1, v2, v3)
-	m.FileExistsFunc.appendCall(EnqueuerGitserverClientFileExistsFuncCall{v0, v1, v2, v3, r0, r1})
+func (m *MockEnqueuerGitserverClient) RawContents(v0 context.Context, v1 int, v2 string, v3 string) ([]byte, error) {
+	r0, r1 := m.RawContentsFunc.nextHook()(v0, v1, v2, v3)
+	m.RawContentsFunc.appendCall(EnqueuerGitserverClientRawContentsFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the FileExists method of
-// the parent MockEnqueuerGitserverClient instance is invoked and the hook
-// queue is empty.
-func (f *EnqueuerGitserverClientFileExistsFunc) SetDefaultHook(hook func(context.Context, int, string, string) (bool, error)) {
+// SetDefaultHook sets function that is called when the RawContents method
+// of the parent MockEnqueuerGitserverClient instance is invoked and the
+// hook queue is empty.
+func (f *EnqueuerGitserverClientRawContentsFunc) SetDefaultHook(hook func(context.Context, int, string, string) ([]byte, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// FileExists method of the parent MockEnqueuerGitserverClient instance
+// RawContents method of the parent MockEnqueuerGitserverClient instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *EnqueuerGitserverClientFileExistsFunc) PushHook(hook func(context.Context, int, string, string) (bool, error)) {
+func (f *EnqueuerGitserverClientRawContentsFunc) PushHook(hook func(context.Context, int, string, string) ([]byte, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3628,21 +5084,21 @@ func (f *EnqueuerGitserverClientFileExistsFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerGitserverClientFileExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string, string) (bool, error) {
+func (f *EnqueuerGitserverClientRawContentsFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) ([]byte, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerGitserverClientFileExistsFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, int, string, string) (bool, error) {
+func (f *EnqueuerGitserverClientRawContentsFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) ([]byte, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerGitserverClientFileExistsFunc) nextHook() func(context.Context, int, string, string) (bool, error) {
+func (f *EnqueuerGitserverClientRawContentsFunc) nextHook() func(context.Context, int, string, string) ([]byte, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3655,27 +5111,27 @@ func (f *EnqueuerGitserverClientFileExistsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *EnqueuerGitserverClientFileExistsFunc) appendCall(r0 EnqueuerGitserverClientFileExistsFuncCall) {
+func (f *EnqueuerGitserverClientRawContentsFunc) appendCall(r0 EnqueuerGitserverClientRawContentsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerGitserverClientFileExistsFuncCall
+// History returns a sequence of EnqueuerGitserverClientRawContentsFuncCall
 // objects describing the invocations of this function.
-func (f *EnqueuerGitserverClientFileExistsFunc) History() []EnqueuerGitserverClientFileExistsFuncCall {
+func (f *EnqueuerGitserverClientRawContentsFunc) History() []EnqueuerGitserverClientRawContentsFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerGitserverClientFileExistsFuncCall, len(f.history))
+	history := make([]EnqueuerGitserverClientRawContentsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerGitserverClientFileExistsFuncCall is an object that describes an
-// invocation of method FileExists on an instance of
+// EnqueuerGitserverClientRawContentsFuncCall is an object that describes an
+// invocation of method RawContents on an instance of
 // MockEnqueuerGitserverClient.
-type EnqueuerGitserverClientFileExistsFuncCall struct {
+type EnqueuerGitserverClientRawContentsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -3690,7 +5146,7 @@ type EnqueuerGitserverClientFileExistsFuncCall struct {
 	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 []byte
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3698,45 +5154,47 @@ type EnqueuerGitserverClientFileExistsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerGitserverClientFileExistsFuncCall) Args() []interface{} {
+func (c EnqueuerGitserverClientRawContentsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerGitserverClientFileExistsFuncCall) Results() []interface{} {
+func (c EnqueuerGitserverClientRawContentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// EnqueuerGitserverClientHeadFunc describes the behavior when the Head
-// method of the parent MockEnqueuerGitserverClient instance is invoked.
-type EnqueuerGitserverClientHeadFunc struct {
-	defaultHook func(context.Context, int) (string, bool, error)
-	hooks       []func(context.Context, int) (string, bool, error)
-	history     []EnqueuerGitserverClientHeadFuncCall
+// EnqueuerGitserverClientResolveRevisionFunc describes the behavior when
+// the ResolveRevision method of the parent MockEnqueuerGitserverClient
+// instance is invoked.
+type EnqueuerGitserverClientResolveRevisionFunc struct {
+	defaultHook func(context.Context, int, string) (api.CommitID, error)
+	hooks       []func(context.Context, int, string) (api.CommitID, error)
+	history     []EnqueuerGitserverClientResolveRevisionFuncCall
 	mutex       sync.Mutex
 }
 
-// Head delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockEnqueuerGitserverClient) Head(v0 context.Context, v1 int) (string, bool, error) {
-	r0, r1, r2 := m.HeadFunc.nextHook()(v0, v1)
-	m.HeadFunc.appendCall(EnqueuerGitserverClientHeadFuncCall{v0, v1, r0, r1, r2})
-	return r0, r1, r2
+// ResolveRevision delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnqueuerGitserverClient) ResolveRevision(v0 context.Context, v1 int, v2 string) (api.CommitID, error) {
+	r0, r1 := m.ResolveRevisionFunc.nextHook()(v0, v1, v2)
+	m.ResolveRevisionFunc.appendCall(EnqueuerGitserverClientResolveRevisionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Head method of the
-// parent MockEnqueuerGitserverClient instance is invoked and the hook queue
-// is empty.
-func (f *EnqueuerGitserverClientHeadFunc) SetDefaultHook(hook func(context.Context, int) (string, bool, error)) {
+// SetDefaultHook sets function that is called when the ResolveRevision
+// method of the parent MockEnqueuerGitserverClient instance is invoked and
+// the hook queue is empty.
+func (f *EnqueuerGitserverClientResolveRevisionFunc) SetDefaultHook(hook func(context.Context, int, string) (api.CommitID, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Head method of the parent MockEnqueuerGitserverClient instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *EnqueuerGitserverClientHeadFunc) PushHook(hook func(context.Context, int) (string, bool, error)) {
+// ResolveRevision method of the parent MockEnqueuerGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnqueuerGitserverClientResolveRevisionFunc) PushHook(hook func(context.Context, int, string) (api.CommitID, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3744,21 +5202,21 @@ func (f *EnqueuerGitserverClientHeadFunc) PushHook(hook func(context.Context, in
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerGitserverClientHeadFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) (string, bool, error) {
-		return r0, r1, r2
+func (f *EnqueuerGitserverClientResolveRevisionFunc) SetDefaultReturn(r0 api.CommitID, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (api.CommitID, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerGitserverClientHeadFunc) PushReturn(r0 string, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int) (string, bool, error) {
-		return r0, r1, r2
+func (f *EnqueuerGitserverClientResolveRevisionFunc) PushReturn(r0 api.CommitID, r1 error) {
+	f.PushHook(func(context.Context, int, string) (api.CommitID, error) {
+		return r0, r1
 	})
 }
 
-func (f *EnqueuerGitserverClientHeadFunc) nextHook() func(context.Context, int) (string, bool, error) {
+func (f *EnqueuerGitserverClientResolveRevisionFunc) nextHook() func(context.Context, int, string) (api.CommitID, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3771,86 +5229,165 @@ func (f *EnqueuerGitserverClientHeadFunc) nextHook() func(context.Context, int)
 	return hook
 }
 
-func (f *EnqueuerGitserverClientHeadFunc) appendCall(r0 EnqueuerGitserverClientHeadFuncCall) {
+func (f *EnqueuerGitserverClientResolveRevisionFunc) appendCall(r0 EnqueuerGitserverClientResolveRevisionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerGitserverClientHeadFuncCall objects
-// describing the invocations of this function.
-func (f *EnqueuerGitserverClientHeadFunc) History() []EnqueuerGitserverClientHeadFuncCall {
+// History returns a sequence of
+// EnqueuerGitserverClientResolveRevisionFuncCall objects describing the
+// invocations of this function.
+func (f *EnqueuerGitserverClientResolveRevisionFunc) History() []EnqueuerGitserverClientResolveRevisionFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerGitserverClientHeadFuncCall, len(f.history))
+	history := make([]EnqueuerGitserverClientResolveRevisionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerGitserverClientHeadFuncCall is an object that describes an
-// invocation of method Head on an instance of MockEnqueuerGitserverClient.
-type EnqueuerGitserverClientHeadFuncCall struct {
+// EnqueuerGitserverClientResolveRevisionFuncCall is an object that
+// describes an invocation of method ResolveRevision on an instance of
+// MockEnqueuerGitserverClient.
+type EnqueuerGitserverClientResolveRevisionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 api.CommitID
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnqueuerGitserverClientResolveRevisionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnqueuerGitserverClientResolveRevisionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockGitserverClient is a mock implementation of the GitserverClient
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockGitserverClient struct {
+	// CommitExistsFunc is an instance of a mock function object controlling
+	// the behavior of the method CommitExists.
+	CommitExistsFunc *GitserverClientCommitExistsFunc
+	// CommitGraphFunc is an instance of a mock function object controlling
+	// the behavior of the method CommitGraph.
+	CommitGraphFunc *GitserverClientCommitGraphFunc
+	// CommitsOnBranchFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitsOnBranch.
+	CommitsOnBranchFunc *GitserverClientCommitsOnBranchFunc
+	// RawContentsFunc is an instance of a mock function object controlling
+	// the behavior of the method RawContents.
+	RawContentsFunc *GitserverClientRawContentsFunc
+	// RefsFunc is an instance of a mock function object controlling the
+	// behavior of the method Refs.
+	RefsFunc *GitserverClientRefsFunc
 }
 
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c EnqueuerGitserverClientHeadFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+// NewMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitExistsFunc: &GitserverClientCommitExistsFunc{
+			defaultHook: func(context.Context, int, string) (bool, error) {
+				return false, nil
+			},
+		},
+		CommitGraphFunc: &GitserverClientCommitGraphFunc{
+			defaultHook: func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+				return nil, nil
+			},
+		},
+		CommitsOnBranchFunc: &GitserverClientCommitsOnBranchFunc{
+			defaultHook: func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error) {
+				return nil, nil
+			},
+		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: func(context.Context, int, string, string) ([]byte, error) {
+				return nil, nil
+			},
+		},
+		RefsFunc: &GitserverClientRefsFunc{
+			defaultHook: func(context.Context, int) ([]gitserver.Ref, error) {
+				return nil, nil
+			},
+		},
+	}
 }
 
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c EnqueuerGitserverClientHeadFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitExistsFunc: &GitserverClientCommitExistsFunc{
+			defaultHook: i.CommitExists,
+		},
+		CommitGraphFunc: &GitserverClientCommitGraphFunc{
+			defaultHook: i.CommitGraph,
+		},
+		CommitsOnBranchFunc: &GitserverClientCommitsOnBranchFunc{
+			defaultHook: i.CommitsOnBranch,
+		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: i.RawContents,
+		},
+		RefsFunc: &GitserverClientRefsFunc{
+			defaultHook: i.Refs,
+		},
+	}
 }
 
-// EnqueuerGitserverClientListFilesFunc describes the behavior when the
-// ListFiles method of the parent MockEnqueuerGitserverClient instance is
+// GitserverClientCommitExistsFunc describes the behavior when the
+// CommitExists method of the parent MockGitserverClient instance is
 // invoked.
-type EnqueuerGitserverClientListFilesFunc struct {
-	defaultHook func(context.Context, int, string, *regexp.Regexp) ([]string, error)
-	hooks       []func(context.Context, int, string, *regexp.Regexp) ([]string, error)
-	history     []EnqueuerGitserverClientListFilesFuncCall
+type GitserverClientCommitExistsFunc struct {
+	defaultHook func(context.Context, int, string) (bool, error)
+	hooks       []func(context.Context, int, string) (bool, error)
+	history     []GitserverClientCommitExistsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListFiles delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockEnqueuerGitserverClient) ListFiles(v0 context.Context, v1 int, v2 string, v3 *regexp.Regexp) ([]string, error) {
-	r0, r1 := m.ListFilesFunc.nextHook()(v0, v1, v2, v3)
-	m.ListFilesFunc.appendCall(EnqueuerGitserverClientListFilesFuncCall{v0, v1, v2, v3, r0, r1})
+// CommitExists delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) CommitExists(v0 context.Context, v1 int, v2 string) (bool, error) {
+	r0, r1 := m.CommitExistsFunc.nextHook()(v0, v1, v2)
+	m.CommitExistsFunc.appendCall(GitserverClientCommitExistsFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListFiles method of
-// the parent MockEnqueuerGitserverClient instance is invoked and the hook
-// queue is empty.
-func (f *EnqueuerGitserverClientListFilesFunc) SetDefaultHook(hook func(context.Context, int, string, *regexp.Regexp) ([]string, error)) {
+// SetDefaultHook sets function that is called when the CommitExists method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientCommitExistsFunc) SetDefaultHook(hook func(context.Context, int, string) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListFiles method of the parent MockEnqueuerGitserverClient instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *EnqueuerGitserverClientListFilesFunc) PushHook(hook func(context.Context, int, string, *regexp.Regexp) ([]string, error)) {
+// CommitExists method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientCommitExistsFunc) PushHook(hook func(context.Context, int, string) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3858,21 +5395,21 @@ func (f *EnqueuerGitserverClientListFilesFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerGitserverClientListFilesFunc) SetDefaultReturn(r0 []string, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
+func (f *GitserverClientCommitExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (bool, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerGitserverClientListFilesFunc) PushReturn(r0 []string, r1 error) {
-	f.PushHook(func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
+func (f *GitserverClientCommitExistsFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int, string) (bool, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerGitserverClientListFilesFunc) nextHook() func(context.Context, int, string, *regexp.Regexp) ([]string, error) {
+func (f *GitserverClientCommitExistsFunc) nextHook() func(context.Context, int, string) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3885,27 +5422,26 @@ func (f *EnqueuerGitserverClientListFilesFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *EnqueuerGitserverClientListFilesFunc) appendCall(r0 EnqueuerGitserverClientListFilesFuncCall) {
+func (f *GitserverClientCommitExistsFunc) appendCall(r0 GitserverClientCommitExistsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerGitserverClientListFilesFuncCall
-// objects describing the invocations of this function.
-func (f *EnqueuerGitserverClientListFilesFunc) History() []EnqueuerGitserverClientListFilesFuncCall {
+// History returns a sequence of GitserverClientCommitExistsFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientCommitExistsFunc) History() []GitserverClientCommitExistsFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerGitserverClientListFilesFuncCall, len(f.history))
+	history := make([]GitserverClientCommitExistsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerGitserverClientListFilesFuncCall is an object that describes an
-// invocation of method ListFiles on an instance of
-// MockEnqueuerGitserverClient.
-type EnqueuerGitserverClientListFilesFuncCall struct {
+// GitserverClientCommitExistsFuncCall is an object that describes an
+// invocation of method CommitExists on an instance of MockGitserverClient.
+type GitserverClientCommitExistsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -3915,12 +5451,9 @@ type EnqueuerGitserverClientListFilesFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 *regexp.Regexp
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []string
+	Result0 bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3928,47 +5461,45 @@ type EnqueuerGitserverClientListFilesFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerGitserverClientListFilesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c GitserverClientCommitExistsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerGitserverClientListFilesFuncCall) Results() []interface{} {
+func (c GitserverClientCommitExistsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// EnqueuerGitserverClientRawContentsFunc describes the behavior when the
-// RawContents method of the parent MockEnqueuerGitserverClient instance is
-// invoked.
-type EnqueuerGitserverClientRawContentsFunc struct {
-	defaultHook func(context.Context, int, string, string) ([]byte, error)
-	hooks       []func(context.Context, int, string, string) ([]byte, error)
-	history     []EnqueuerGitserverClientRawContentsFuncCall
+// GitserverClientCommitGraphFunc describes the behavior when the
+// CommitGraph method of the parent MockGitserverClient instance is invoked.
+type GitserverClientCommitGraphFunc struct {
+	defaultHook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)
+	hooks       []func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)
+	history     []GitserverClientCommitGraphFuncCall
 	mutex       sync.Mutex
 }
 
-// RawContents delegates to the next hook function in the queue and stores
+// CommitGraph delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockEnqueuerGitserverClient) RawContents(v0 context.Context, v1 int, v2 string, v3 string) ([]byte, error) {
-	r0, r1 := m.RawContentsFunc.nextHook()(v0, v1, v2, v3)
-	m.RawContentsFunc.appendCall(EnqueuerGitserverClientRawContentsFuncCall{v0, v1, v2, v3, r0, r1})
+func (m *MockGitserverClient) CommitGraph(v0 context.Context, v1 int, v2 gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+	r0, r1 := m.CommitGraphFunc.nextHook()(v0, v1, v2)
+	m.CommitGraphFunc.appendCall(GitserverClientCommitGraphFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the RawContents method
-// of the parent MockEnqueuerGitserverClient instance is invoked and the
-// hook queue is empty.
-func (f *EnqueuerGitserverClientRawContentsFunc) SetDefaultHook(hook func(context.Context, int, string, string) ([]byte, error)) {
+// SetDefaultHook sets function that is called when the CommitGraph method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientCommitGraphFunc) SetDefaultHook(hook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// RawContents method of the parent MockEnqueuerGitserverClient instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *EnqueuerGitserverClientRawContentsFunc) PushHook(hook func(context.Context, int, string, string) ([]byte, error)) {
+// CommitGraph method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientCommitGraphFunc) PushHook(hook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3976,21 +5507,21 @@ func (f *EnqueuerGitserverClientRawContentsFunc) PushHook(hook func(context.Cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerGitserverClientRawContentsFunc) SetDefaultReturn(r0 []byte, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string, string) ([]byte, error) {
+func (f *GitserverClientCommitGraphFunc) SetDefaultReturn(r0 *gitserver.CommitGraph, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerGitserverClientRawContentsFunc) PushReturn(r0 []byte, r1 error) {
-	f.PushHook(func(context.Context, int, string, string) ([]byte, error) {
+func (f *GitserverClientCommitGraphFunc) PushReturn(r0 *gitserver.CommitGraph, r1 error) {
+	f.PushHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerGitserverClientRawContentsFunc) nextHook() func(context.Context, int, string, string) ([]byte, error) {
+func (f *GitserverClientCommitGraphFunc) nextHook() func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4003,27 +5534,26 @@ func (f *EnqueuerGitserverClientRawContentsFunc) nextHook() func(context.Context
 	return hook
 }
 
-func (f *EnqueuerGitserverClientRawContentsFunc) appendCall(r0 EnqueuerGitserverClientRawContentsFuncCall) {
+func (f *GitserverClientCommitGraphFunc) appendCall(r0 GitserverClientCommitGraphFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerGitserverClientRawContentsFuncCall
-// objects describing the invocations of this function.
-func (f *EnqueuerGitserverClientRawContentsFunc) History() []EnqueuerGitserverClientRawContentsFuncCall {
+// History returns a sequence of GitserverClientCommitGraphFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientCommitGraphFunc) History() []GitserverClientCommitGraphFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerGitserverClientRawContentsFuncCall, len(f.history))
+	history := make([]GitserverClientCommitGraphFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerGitserverClientRawContentsFuncCall is an object that describes an
-// invocation of method RawContents on an instance of
-// MockEnqueuerGitserverClient.
-type EnqueuerGitserverClientRawContentsFuncCall struct {
+// GitserverClientCommitGraphFuncCall is an object that describes an
+// invocation of method CommitGraph on an instance of MockGitserverClient.
+type GitserverClientCommitGraphFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -4032,13 +5562,10 @@ type EnqueuerGitserverClientRawContentsFuncCall struct {
 	Arg1 int
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
+	Arg2 gitserver.CommitGraphOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []byte
+	Result0 *gitserver.CommitGraph
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4046,47 +5573,46 @@ type EnqueuerGitserverClientRawContentsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerGitserverClientRawContentsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c GitserverClientCommitGraphFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerGitserverClientRawContentsFuncCall) Results() []interface{} {
+func (c GitserverClientCommitGraphFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// EnqueuerGitserverClientResolveRevisionFunc describes the behavior when
-// the ResolveRevision method of the parent MockEnqueuerGitserverClient
-// instance is invoked.
-type EnqueuerGitserverClientResolveRevisionFunc struct {
-	defaultHook func(context.Context, int, string) (api.CommitID, error)
-	hooks       []func(context.Context, int, string) (api.CommitID, error)
-	history     []EnqueuerGitserverClientResolveRevisionFuncCall
+// GitserverClientCommitsOnBranchFunc describes the behavior when the
+// CommitsOnBranch method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientCommitsOnBranchFunc struct {
+	defaultHook func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error)
+	hooks       []func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error)
+	history     []GitserverClientCommitsOnBranchFuncCall
 	mutex       sync.Mutex
 }
 
-// ResolveRevision delegates to the next hook function in the queue and
+// CommitsOnBranch delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockEnqueuerGitserverClient) ResolveRevision(v0 context.Context, v1 int, v2 string) (api.CommitID, error) {
-	r0, r1 := m.ResolveRevisionFunc.nextHook()(v0, v1, v2)
-	m.ResolveRevisionFunc.appendCall(EnqueuerGitserverClientResolveRevisionFuncCall{v0, v1, v2, r0, r1})
+func (m *MockGitserverClient) CommitsOnBranch(v0 context.Context, v1 int, v2 string, v3 int, v4 time.Time) ([]gitserver.BranchCommit, error) {
+	r0, r1 := m.CommitsOnBranchFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CommitsOnBranchFunc.appendCall(GitserverClientCommitsOnBranchFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ResolveRevision
-// method of the parent MockEnqueuerGitserverClient instance is invoked and
-// the hook queue is empty.
-func (f *EnqueuerGitserverClientResolveRevisionFunc) SetDefaultHook(hook func(context.Context, int, string) (api.CommitID, error)) {
+// SetDefaultHook sets function that is called when the CommitsOnBranch
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientCommitsOnBranchFunc) SetDefaultHook(hook func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ResolveRevision method of the parent MockEnqueuerGitserverClient instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *EnqueuerGitserverClientResolveRevisionFunc) PushHook(hook func(context.Context, int, string) (api.CommitID, error)) {
+// CommitsOnBranch method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientCommitsOnBranchFunc) PushHook(hook func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4094,21 +5620,21 @@ func (f *EnqueuerGitserverClientResolveRevisionFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerGitserverClientResolveRevisionFunc) SetDefaultReturn(r0 api.CommitID, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string) (api.CommitID, error) {
+func (f *GitserverClientCommitsOnBranchFunc) SetDefaultReturn(r0 []gitserver.BranchCommit, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerGitserverClientResolveRevisionFunc) PushReturn(r0 api.CommitID, r1 error) {
-	f.PushHook(func(context.Context, int, string) (api.CommitID, error) {
+func (f *GitserverClientCommitsOnBranchFunc) PushReturn(r0 []gitserver.BranchCommit, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error) {
 		return r0, r1
 	})
 }
 
-func (f *EnqueuerGitserverClientResolveRevisionFunc) nextHook() func(context.Context, int, string) (api.CommitID, error) {
+func (f *GitserverClientCommitsOnBranchFunc) nextHook() func(context.Context, int, string, int, time.Time) ([]gitserver.BranchCommit, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4121,28 +5647,27 @@ func (f *EnqueuerGitserverClientResolveRevisionFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *EnqueuerGitserverClientResolveRevisionFunc) appendCall(r0 EnqueuerGitserverClientResolveRevisionFuncCall) {
+func (f *GitserverClientCommitsOnBranchFunc) appendCall(r0 GitserverClientCommitsOnBranchFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// EnqueuerGitserverClientResolveRevisionFuncCall objects describing the
-// invocations of this function.
-func (f *EnqueuerGitserverClientResolveRevisionFunc) History() []EnqueuerGitserverClientResolveRevisionFuncCall {
+// History returns a sequence of GitserverClientCommitsOnBranchFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientCommitsOnBranchFunc) History() []GitserverClientCommitsOnBranchFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerGitserverClientResolveRevisionFuncCall, len(f.history))
+	history := make([]GitserverClientCommitsOnBranchFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerGitserverClientResolveRevisionFuncCall is an object that
-// describes an invocation of method ResolveRevision on an instance of
-// MockEnqueuerGitserverClient.
-type EnqueuerGitserverClientResolveRevisionFuncCall struct {
+// GitserverClientCommitsOnBranchFuncCall is an object that describes an
+// invocation of method CommitsOnBranch on an instance of
+// MockGitserverClient.
+type GitserverClientCommitsOnBranchFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -4152,9 +5677,15 @@ type EnqueuerGitserverClientResolveRevisionFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 api.CommitID
+	Result0 []gitserver.BranchCommit
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4162,91 +5693,45 @@ type EnqueuerGitserverClientResolveRevisionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerGitserverClientResolveRevisionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c GitserverClientCommitsOnBranchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerGitserverClientResolveRevisionFuncCall) Results() []interface{} {
+func (c GitserverClientCommitsOnBranchFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockGitserverClient is a mock implementation of the GitserverClient
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
-// used for unit testing.
-type MockGitserverClient struct {
-	// CommitExistsFunc is an instance of a mock function object controlling
-	// the behavior of the method CommitExists.
-	CommitExistsFunc *GitserverClientCommitExistsFunc
-	// CommitGraphFunc is an instance of a mock function object controlling
-	// the behavior of the method CommitGraph.
-	CommitGraphFunc *GitserverClientCommitGraphFunc
-}
-
-// NewMockGitserverClient creates a new mock of the GitserverClient
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockGitserverClient() *MockGitserverClient {
-	return &MockGitserverClient{
-		CommitExistsFunc: &GitserverClientCommitExistsFunc{
-			defaultHook: func(context.Context, int, string) (bool, error) {
-				return false, nil
-			},
-		},
-		CommitGraphFunc: &GitserverClientCommitGraphFunc{
-			defaultHook: func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
-				return nil, nil
-			},
-		},
-	}
-}
-
-// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
-	return &MockGitserverClient{
-		CommitExistsFunc: &GitserverClientCommitExistsFunc{
-			defaultHook: i.CommitExists,
-		},
-		CommitGraphFunc: &GitserverClientCommitGraphFunc{
-			defaultHook: i.CommitGraph,
-		},
-	}
-}
-
-// GitserverClientCommitExistsFunc describes the behavior when the
-// CommitExists method of the parent MockGitserverClient instance is
-// invoked.
-type GitserverClientCommitExistsFunc struct {
-	defaultHook func(context.Context, int, string) (bool, error)
-	hooks       []func(context.Context, int, string) (bool, error)
-	history     []GitserverClientCommitExistsFuncCall
+// GitserverClientRawContentsFunc describes the behavior when the
+// RawContents method of the parent MockGitserverClient instance is invoked.
+type GitserverClientRawContentsFunc struct {
+	defaultHook func(context.Context, int, string, string) ([]byte, error)
+	hooks       []func(context.Context, int, string, string) ([]byte, error)
+	history     []GitserverClientRawContentsFuncCall
 	mutex       sync.Mutex
 }
 
-// CommitExists delegates to the next hook function in the queue and stores
+// RawContents delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockGitserverClient) CommitExists(v0 context.Context, v1 int, v2 string) (bool, error) {
-	r0, r1 := m.CommitExistsFunc.nextHook()(v0, v1, v2)
-	m.CommitExistsFunc.appendCall(GitserverClientCommitExistsFuncCall{v0, v1, v2, r0, r1})
+func (m *MockGitserverClient) RawContents(v0 context.Context, v1 int, v2 string, v3 string) ([]byte, error) {
+	r0, r1 := m.RawContentsFunc.nextHook()(v0, v1, v2, v3)
+	m.RawContentsFunc.appendCall(GitserverClientRawContentsFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CommitExists method
+// SetDefaultHook sets function that is called when the RawContents method
 // of the parent MockGitserverClient instance is invoked and the hook queue
 // is empty.
-func (f *GitserverClientCommitExistsFunc) SetDefaultHook(hook func(context.Context, int, string) (bool, error)) {
+func (f *GitserverClientRawContentsFunc) SetDefaultHook(hook func(context.Context, int, string, string) ([]byte, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CommitExists method of the parent MockGitserverClient instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *GitserverClientCommitExistsFunc) PushHook(hook func(context.Context, int, string) (bool, error)) {
+// RawContents method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientRawContentsFunc) PushHook(hook func(context.Context, int, string, string) ([]byte, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4254,21 +5739,21 @@ func (f *GitserverClientCommitExistsFunc) PushHook(hook func(context.Context, in
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *GitserverClientCommitExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string) (bool, error) {
+func (f *GitserverClientRawContentsFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) ([]byte, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *GitserverClientCommitExistsFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, int, string) (bool, error) {
+func (f *GitserverClientRawContentsFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) ([]byte, error) {
 		return r0, r1
 	})
 }
 
-func (f *GitserverClientCommitExistsFunc) nextHook() func(context.Context, int, string) (bool, error) {
+func (f *GitserverClientRawContentsFunc) nextHook() func(context.Context, int, string, string) ([]byte, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4281,26 +5766,26 @@ func (f *GitserverClientCommitExistsFunc) nextHook() func(context.Context, int,
 	return hook
 }
 
-func (f *GitserverClientCommitExistsFunc) appendCall(r0 GitserverClientCommitExistsFuncCall) {
+func (f *GitserverClientRawContentsFunc) appendCall(r0 GitserverClientRawContentsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of GitserverClientCommitExistsFuncCall objects
+// History returns a sequence of GitserverClientRawContentsFuncCall objects
 // describing the invocations of this function.
-func (f *GitserverClientCommitExistsFunc) History() []GitserverClientCommitExistsFuncCall {
+func (f *GitserverClientRawContentsFunc) History() []GitserverClientRawContentsFuncCall {
 	f.mutex.Lock()
-	history := make([]GitserverClientCommitExistsFuncCall, len(f.history))
+	history := make([]GitserverClientRawContentsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitserverClientCommitExistsFuncCall is an object that describes an
-// invocation of method CommitExists on an instance of MockGitserverClient.
-type GitserverClientCommitExistsFuncCall struct {
+// GitserverClientRawContentsFuncCall is an object that describes an
+// invocation of method RawContents on an instance of MockGitserverClient.
+type GitserverClientRawContentsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -4310,9 +5795,12 @@ type GitserverClientCommitExistsFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 []byte
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4320,45 +5808,45 @@ type GitserverClientCommitExistsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitserverClientCommitExistsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c GitserverClientRawContentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitserverClientCommitExistsFuncCall) Results() []interface{} {
+func (c GitserverClientRawContentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// GitserverClientCommitGraphFunc describes the behavior when the
-// CommitGraph method of the parent MockGitserverClient instance is invoked.
-type GitserverClientCommitGraphFunc struct {
-	defaultHook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)
-	hooks       []func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)
-	history     []GitserverClientCommitGraphFuncCall
+// GitserverClientRefsFunc describes the behavior when the Refs method of
+// the parent MockGitserverClient instance is invoked.
+type GitserverClientRefsFunc struct {
+	defaultHook func(context.Context, int) ([]gitserver.Ref, error)
+	hooks       []func(context.Context, int) ([]gitserver.Ref, error)
+	history     []GitserverClientRefsFuncCall
 	mutex       sync.Mutex
 }
 
-// CommitGraph delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockGitserverClient) CommitGraph(v0 context.Context, v1 int, v2 gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
-	r0, r1 := m.CommitGraphFunc.nextHook()(v0, v1, v2)
-	m.CommitGraphFunc.appendCall(GitserverClientCommitGraphFuncCall{v0, v1, v2, r0, r1})
+// Refs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverClient) Refs(v0 context.Context, v1 int) ([]gitserver.Ref, error) {
+	r0, r1 := m.RefsFunc.nextHook()(v0, v1)
+	m.RefsFunc.appendCall(GitserverClientRefsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CommitGraph method
-// of the parent MockGitserverClient instance is invoked and the hook queue
-// is empty.
-func (f *GitserverClientCommitGraphFunc) SetDefaultHook(hook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)) {
+// SetDefaultHook sets function that is called when the Refs method of the
+// parent MockGitserverClient instance is invoked and the hook queue is
+// empty.
+func (f *GitserverClientRefsFunc) SetDefaultHook(hook func(context.Context, int) ([]gitserver.Ref, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CommitGraph method of the parent MockGitserverClient instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *GitserverClientCommitGraphFunc) PushHook(hook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)) {
+// Refs method of the parent MockGitserverClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *GitserverClientRefsFunc) PushHook(hook func(context.Context, int) ([]gitserver.Ref, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4366,21 +5854,21 @@ func (f *GitserverClientCommitGraphFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *GitserverClientCommitGraphFunc) SetDefaultReturn(r0 *gitserver.CommitGraph, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+func (f *GitserverClientRefsFunc) SetDefaultReturn(r0 []gitserver.Ref, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]gitserver.Ref, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *GitserverClientCommitGraphFunc) PushReturn(r0 *gitserver.CommitGraph, r1 error) {
-	f.PushHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+func (f *GitserverClientRefsFunc) PushReturn(r0 []gitserver.Ref, r1 error) {
+	f.PushHook(func(context.Context, int) ([]gitserver.Ref, error) {
 		return r0, r1
 	})
 }
 
-func (f *GitserverClientCommitGraphFunc) nextHook() func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+func (f *GitserverClientRefsFunc) nextHook() func(context.Context, int) ([]gitserver.Ref, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4393,38 +5881,35 @@ func (f *GitserverClientCommitGraphFunc) nextHook() func(context.Context, int, g
 	return hook
 }
 
-func (f *GitserverClientCommitGraphFunc) appendCall(r0 GitserverClientCommitGraphFuncCall) {
+func (f *GitserverClientRefsFunc) appendCall(r0 GitserverClientRefsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of GitserverClientCommitGraphFuncCall objects
+// History returns a sequence of GitserverClientRefsFuncCall objects
 // describing the invocations of this function.
-func (f *GitserverClientCommitGraphFunc) History() []GitserverClientCommitGraphFuncCall {
+func (f *GitserverClientRefsFunc) History() []GitserverClientRefsFuncCall {
 	f.mutex.Lock()
-	history := make([]GitserverClientCommitGraphFuncCall, len(f.history))
+	history := make([]GitserverClientRefsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitserverClientCommitGraphFuncCall is an object that describes an
-// invocation of method CommitGraph on an instance of MockGitserverClient.
-type GitserverClientCommitGraphFuncCall struct {
+// GitserverClientRefsFuncCall is an object that describes an invocation of
+// method Refs on an instance of MockGitserverClient.
+type GitserverClientRefsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 gitserver.CommitGraphOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *gitserver.CommitGraph
+	Result0 []gitserver.Ref
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4432,13 +5917,13 @@ type GitserverClientCommitGraphFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitserverClientCommitGraphFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c GitserverClientRefsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitserverClientCommitGraphFuncCall) Results() []interface{} {
+func (c GitserverClientRefsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -4745,6 +6230,9 @@ type MockLSIFStore struct {
 	// HoverFunc is an instance of a mock function object controlling the
 	// behavior of the method Hover.
 	HoverFunc *LSIFStoreHoverFunc
+	// ImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method Implementations.
+	ImplementationsFunc *LSIFStoreImplementationsFunc
 	// MonikersByPositionFunc is an instance of a mock function object
 	// controlling the behavior of the method MonikersByPosition.
 	MonikersByPositionFunc *LSIFStoreMonikersByPositionFunc
@@ -4757,6 +6245,9 @@ type MockLSIFStore struct {
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *LSIFStoreReferencesFunc
+	// TypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method TypeDefinitions.
+	TypeDefinitionsFunc *LSIFStoreTypeDefinitionsFunc
 }
 
 // NewMockLSIFStore creates a new mock of the LSIFStore interface. All
@@ -4813,6 +6304,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return "", lsifstore.Range{}, false, nil
 			},
 		},
+		ImplementationsFunc: &LSIFStoreImplementationsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+				return nil, 0, nil
+			},
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([][]semantic.MonikerData, error) {
 				return nil, nil
@@ -4833,6 +6329,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil, 0, nil
 			},
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+				return nil, 0, nil
+			},
+		},
 	}
 }
 
@@ -4870,6 +6371,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		HoverFunc: &LSIFStoreHoverFunc{
 			defaultHook: i.Hover,
 		},
+		ImplementationsFunc: &LSIFStoreImplementationsFunc{
+			defaultHook: i.Implementations,
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: i.MonikersByPosition,
 		},
@@ -4882,6 +6386,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		ReferencesFunc: &LSIFStoreReferencesFunc{
 			defaultHook: i.References,
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: i.TypeDefinitions,
+		},
 	}
 }
 
@@ -5981,17 +7488,141 @@ func (m *MockLSIFStore) Hover(v0 context.Context, v1 int, v2 string, v3 int, v4
 	return r0, r1, r2, r3
 }
 
-// SetDefaultHook sets function that is called when the Hover method of the
-// parent MockLSIFStore instance is invoked and the hook queue is empty.
-func (f *LSIFStoreHoverFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error)) {
+// SetDefaultHook sets function that is called when the Hover method of the
+// parent MockLSIFStore instance is invoked and the hook queue is empty.
+func (f *LSIFStoreHoverFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Hover method of the parent MockLSIFStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *LSIFStoreHoverFunc) PushHook(hook func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreHoverFunc) SetDefaultReturn(r0 string, r1 lsifstore.Range, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreHoverFunc) PushReturn(r0 string, r1 lsifstore.Range, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *LSIFStoreHoverFunc) nextHook() func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreHoverFunc) appendCall(r0 LSIFStoreHoverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreHoverFuncCall objects describing
+// the invocations of this function.
+func (f *LSIFStoreHoverFunc) History() []LSIFStoreHoverFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreHoverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreHoverFuncCall is an object that describes an invocation of
+// method Hover on an instance of MockLSIFStore.
+type LSIFStoreHoverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 lsifstore.Range
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreHoverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreHoverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// LSIFStoreImplementationsFunc describes the behavior when the
+// Implementations method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreImplementationsFunc struct {
+	defaultHook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	hooks       []func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	history     []LSIFStoreImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// Implementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) Implementations(v0 context.Context, v1 int, v2 string, v3 int, v4 int, v5 int, v6 int) ([]lsifstore.Location, int, error) {
+	r0, r1, r2 := m.ImplementationsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.ImplementationsFunc.appendCall(LSIFStoreImplementationsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Implementations
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreImplementationsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Hover method of the parent MockLSIFStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *LSIFStoreHoverFunc) PushHook(hook func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error)) {
+// Implementations method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreImplementationsFunc) PushHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5999,21 +7630,21 @@ func (f *LSIFStoreHoverFunc) PushHook(hook func(context.Context, int, string, in
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *LSIFStoreHoverFunc) SetDefaultReturn(r0 string, r1 lsifstore.Range, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *LSIFStoreImplementationsFunc) SetDefaultReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *LSIFStoreHoverFunc) PushReturn(r0 string, r1 lsifstore.Range, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *LSIFStoreImplementationsFunc) PushReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *LSIFStoreHoverFunc) nextHook() func(context.Context, int, string, int, int) (string, lsifstore.Range, bool, error) {
+func (f *LSIFStoreImplementationsFunc) nextHook() func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6026,26 +7657,26 @@ func (f *LSIFStoreHoverFunc) nextHook() func(context.Context, int, string, int,
 	return hook
 }
 
-func (f *LSIFStoreHoverFunc) appendCall(r0 LSIFStoreHoverFuncCall) {
+func (f *LSIFStoreImplementationsFunc) appendCall(r0 LSIFStoreImplementationsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of LSIFStoreHoverFuncCall objects describing
-// the invocations of this function.
-func (f *LSIFStoreHoverFunc) History() []LSIFStoreHoverFuncCall {
+// History returns a sequence of LSIFStoreImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreImplementationsFunc) History() []LSIFStoreImplementationsFuncCall {
 	f.mutex.Lock()
-	history := make([]LSIFStoreHoverFuncCall, len(f.history))
+	history := make([]LSIFStoreImplementationsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LSIFStoreHoverFuncCall is an object that describes an invocation of
-// method Hover on an instance of MockLSIFStore.
-type LSIFStoreHoverFuncCall struct {
+// LSIFStoreImplementationsFuncCall is an object that describes an
+// invocation of method Implementations on an instance of MockLSIFStore.
+type LSIFStoreImplementationsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -6061,30 +7692,33 @@ type LSIFStoreHoverFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 []lsifstore.Location
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 lsifstore.Range
+	Result1 int
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
-	Result2 bool
-	// Result3 is the value of the 4th result returned from this method
-	// invocation.
-	Result3 error
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c LSIFStoreHoverFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+func (c LSIFStoreImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c LSIFStoreHoverFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+func (c LSIFStoreImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
 // LSIFStoreMonikersByPositionFunc describes the behavior when the
@@ -6568,6 +8202,133 @@ func (c LSIFStoreReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreTypeDefinitionsFunc describes the behavior when the
+// TypeDefinitions method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	hooks       []func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	history     []LSIFStoreTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// TypeDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) TypeDefinitions(v0 context.Context, v1 int, v2 string, v3 int, v4 int, v5 int, v6 int) ([]lsifstore.Location, int, error) {
+	r0, r1, r2 := m.TypeDefinitionsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.TypeDefinitionsFunc.appendCall(LSIFStoreTypeDefinitionsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the TypeDefinitions
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TypeDefinitions method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreTypeDefinitionsFunc) PushHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreTypeDefinitionsFunc) PushReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) nextHook() func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) appendCall(r0 LSIFStoreTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreTypeDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreTypeDefinitionsFunc) History() []LSIFStoreTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreTypeDefinitionsFuncCall is an object that describes an
+// invocation of method TypeDefinitions on an instance of MockLSIFStore.
+type LSIFStoreTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // MockRepoUpdaterClient is a mock implementation of the RepoUpdaterClient
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
@@ -6713,3 +8474,144 @@ func (c RepoUpdaterClientEnqueueRepoUpdateFuncCall) Args() []interface{} {
 func (c RepoUpdaterClientEnqueueRepoUpdateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// MockSearchClient is a mock implementation of the SearchClient interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockSearchClient struct {
+	// SearchFunc is an instance of a mock function object controlling the
+	// behavior of the method Search.
+	SearchFunc *SearchClientSearchFunc
+}
+
+// NewMockSearchClient creates a new mock of the SearchClient interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockSearchClient() *MockSearchClient {
+	return &MockSearchClient{
+		SearchFunc: &SearchClientSearchFunc{
+			defaultHook: func(context.Context, string) ([]result.Match, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewMockSearchClientFrom creates a new mock of the MockSearchClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSearchClientFrom(i SearchClient) *MockSearchClient {
+	return &MockSearchClient{
+		SearchFunc: &SearchClientSearchFunc{
+			defaultHook: i.Search,
+		},
+	}
+}
+
+// SearchClientSearchFunc describes the behavior when the Search method of
+// the parent MockSearchClient instance is invoked.
+type SearchClientSearchFunc struct {
+	defaultHook func(context.Context, string) ([]result.Match, error)
+	hooks       []func(context.Context, string) ([]result.Match, error)
+	history     []SearchClientSearchFuncCall
+	mutex       sync.Mutex
+}
+
+// Search delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchClient) Search(v0 context.Context, v1 string) ([]result.Match, error) {
+	r0, r1 := m.SearchFunc.nextHook()(v0, v1)
+	m.SearchFunc.appendCall(SearchClientSearchFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Search method of the
+// parent MockSearchClient instance is invoked and the hook queue is empty.
+func (f *SearchClientSearchFunc) SetDefaultHook(hook func(context.Context, string) ([]result.Match, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Search method of the parent MockSearchClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SearchClientSearchFunc) PushHook(hook func(context.Context, string) ([]result.Match, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SearchClientSearchFunc) SetDefaultReturn(r0 []result.Match, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]result.Match, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SearchClientSearchFunc) PushReturn(r0 []result.Match, r1 error) {
+	f.PushHook(func(context.Context, string) ([]result.Match, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchClientSearchFunc) nextHook() func(context.Context, string) ([]result.Match, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchClientSearchFunc) appendCall(r0 SearchClientSearchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SearchClientSearchFuncCall objects
+// describing the invocations of this function.
+func (f *SearchClientSearchFunc) History() []SearchClientSearchFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchClientSearchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchClientSearchFuncCall is an object that describes an invocation of
+// method Search on an instance of MockSearchClient.
+type SearchClientSearchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []result.Match
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchClientSearchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchClientSearchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -8,6 +8,7 @@ import (
 
 	graphqlbackend "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	resolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	policies "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
 	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 )
 
@@ -19,12 +20,22 @@ type MockResolver struct {
 	// CommitGraphFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitGraph.
 	CommitGraphFunc *ResolverCommitGraphFunc
+	// CreateRetentionPolicyFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateRetentionPolicy.
+	CreateRetentionPolicyFunc *ResolverCreateRetentionPolicyFunc
 	// DeleteIndexByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteIndexByID.
 	DeleteIndexByIDFunc *ResolverDeleteIndexByIDFunc
+	// DeleteRetentionPolicyByIDFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteRetentionPolicyByID.
+	DeleteRetentionPolicyByIDFunc *ResolverDeleteRetentionPolicyByIDFunc
 	// DeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploadByID.
 	DeleteUploadByIDFunc *ResolverDeleteUploadByIDFunc
+	// DiagnosticsFunc is an instance of a mock function object controlling
+	// the behavior of the method Diagnostics.
+	DiagnosticsFunc *ResolverDiagnosticsFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *ResolverGetIndexByIDFunc
@@ -43,19 +54,40 @@ type MockResolver struct {
 	// IndexConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method IndexConnectionResolver.
 	IndexConnectionResolverFunc *ResolverIndexConnectionResolverFunc
+	// PackageDependenciesFunc is an instance of a mock function object
+	// controlling the behavior of the method PackageDependencies.
+	PackageDependenciesFunc *ResolverPackageDependenciesFunc
+	// PackageDependentsFunc is an instance of a mock function object
+	// controlling the behavior of the method PackageDependents.
+	PackageDependentsFunc *ResolverPackageDependentsFunc
 	// QueryResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryResolver.
 	QueryResolverFunc *ResolverQueryResolverFunc
 	// QueueAutoIndexJobForRepoFunc is an instance of a mock function object
 	// controlling the behavior of the method QueueAutoIndexJobForRepo.
 	QueueAutoIndexJobForRepoFunc *ResolverQueueAutoIndexJobForRepoFunc
+	// RetentionPoliciesFunc is an instance of a mock function object
+	// controlling the behavior of the method RetentionPolicies.
+	RetentionPoliciesFunc *ResolverRetentionPoliciesFunc
+	// RetentionPolicyByIDFunc is an instance of a mock function object
+	// controlling the behavior of the method RetentionPolicyByID.
+	RetentionPolicyByIDFunc *ResolverRetentionPolicyByIDFunc
+	// SearchBasedQueryResolverFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchBasedQueryResolver.
+	SearchBasedQueryResolverFunc *ResolverSearchBasedQueryResolverFunc
 	// UpdateIndexConfigurationByRepositoryIDFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// UpdateIndexConfigurationByRepositoryID.
 	UpdateIndexConfigurationByRepositoryIDFunc *ResolverUpdateIndexConfigurationByRepositoryIDFunc
+	// UpdateRetentionPolicyFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateRetentionPolicy.
+	UpdateRetentionPolicyFunc *ResolverUpdateRetentionPolicyFunc
 	// UploadConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadConnectionResolver.
 	UploadConnectionResolverFunc *ResolverUploadConnectionResolverFunc
+	// UploadRetentionFunc is an instance of a mock function object
+	// controlling the behavior of the method UploadRetention.
+	UploadRetentionFunc *ResolverUploadRetentionFunc
 }
 
 // NewMockResolver creates a new mock of the Resolver interface. All methods
@@ -67,16 +99,31 @@ func NewMockResolver() *MockResolver {
 				return nil, nil
 			},
 		},
+		CreateRetentionPolicyFunc: &ResolverCreateRetentionPolicyFunc{
+			defaultHook: func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+				return dbstore.RetentionPolicy{}, nil
+			},
+		},
 		DeleteIndexByIDFunc: &ResolverDeleteIndexByIDFunc{
 			defaultHook: func(context.Context, int) error {
 				return nil
 			},
 		},
+		DeleteRetentionPolicyByIDFunc: &ResolverDeleteRetentionPolicyByIDFunc{
+			defaultHook: func(context.Context, int) (bool, error) {
+				return false, nil
+			},
+		},
 		DeleteUploadByIDFunc: &ResolverDeleteUploadByIDFunc{
 			defaultHook: func(context.Context, int) error {
 				return nil
 			},
 		},
+		DiagnosticsFunc: &ResolverDiagnosticsFunc{
+			defaultHook: func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error) {
+				return nil, 0, nil
+			},
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (dbstore.Index, bool, error) {
 				return dbstore.Index{}, false, nil
@@ -107,6 +154,16 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		PackageDependenciesFunc: &ResolverPackageDependenciesFunc{
+			defaultHook: func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error) {
+				return nil, 0, nil
+			},
+		},
+		PackageDependentsFunc: &ResolverPackageDependentsFunc{
+			defaultHook: func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error) {
+				return nil, 0, nil
+			},
+		},
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
 				return nil, nil
@@ -117,16 +174,41 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		RetentionPoliciesFunc: &ResolverRetentionPoliciesFunc{
+			defaultHook: func(context.Context) ([]dbstore.RetentionPolicy, error) {
+				return nil, nil
+			},
+		},
+		RetentionPolicyByIDFunc: &ResolverRetentionPolicyByIDFunc{
+			defaultHook: func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
+				return dbstore.RetentionPolicy{}, false, nil
+			},
+		},
+		SearchBasedQueryResolverFunc: &ResolverSearchBasedQueryResolverFunc{
+			defaultHook: func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+				return nil, nil
+			},
+		},
 		UpdateIndexConfigurationByRepositoryIDFunc: &ResolverUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: func(context.Context, int, string) error {
 				return nil
 			},
 		},
+		UpdateRetentionPolicyFunc: &ResolverUpdateRetentionPolicyFunc{
+			defaultHook: func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error) {
+				return dbstore.RetentionPolicy{}, false, nil
+			},
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: func(dbstore.GetUploadsOptions) *resolvers.UploadsResolver {
 				return nil
 			},
 		},
+		UploadRetentionFunc: &ResolverUploadRetentionFunc{
+			defaultHook: func(context.Context, dbstore.Upload) (policies.Retention, bool, error) {
+				return policies.Retention{}, false, nil
+			},
+		},
 	}
 }
 
@@ -137,12 +219,21 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		CommitGraphFunc: &ResolverCommitGraphFunc{
 			defaultHook: i.CommitGraph,
 		},
+		CreateRetentionPolicyFunc: &ResolverCreateRetentionPolicyFunc{
+			defaultHook: i.CreateRetentionPolicy,
+		},
 		DeleteIndexByIDFunc: &ResolverDeleteIndexByIDFunc{
 			defaultHook: i.DeleteIndexByID,
 		},
+		DeleteRetentionPolicyByIDFunc: &ResolverDeleteRetentionPolicyByIDFunc{
+			defaultHook: i.DeleteRetentionPolicyByID,
+		},
 		DeleteUploadByIDFunc: &ResolverDeleteUploadByIDFunc{
 			defaultHook: i.DeleteUploadByID,
 		},
+		DiagnosticsFunc: &ResolverDiagnosticsFunc{
+			defaultHook: i.Diagnostics,
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
@@ -161,18 +252,39 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		IndexConnectionResolverFunc: &ResolverIndexConnectionResolverFunc{
 			defaultHook: i.IndexConnectionResolver,
 		},
+		PackageDependenciesFunc: &ResolverPackageDependenciesFunc{
+			defaultHook: i.PackageDependencies,
+		},
+		PackageDependentsFunc: &ResolverPackageDependentsFunc{
+			defaultHook: i.PackageDependents,
+		},
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: i.QueryResolver,
 		},
 		QueueAutoIndexJobForRepoFunc: &ResolverQueueAutoIndexJobForRepoFunc{
 			defaultHook: i.QueueAutoIndexJobForRepo,
 		},
+		RetentionPoliciesFunc: &ResolverRetentionPoliciesFunc{
+			defaultHook: i.RetentionPolicies,
+		},
+		RetentionPolicyByIDFunc: &ResolverRetentionPolicyByIDFunc{
+			defaultHook: i.RetentionPolicyByID,
+		},
+		SearchBasedQueryResolverFunc: &ResolverSearchBasedQueryResolverFunc{
+			defaultHook: i.SearchBasedQueryResolver,
+		},
 		UpdateIndexConfigurationByRepositoryIDFunc: &ResolverUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: i.UpdateIndexConfigurationByRepositoryID,
 		},
+		UpdateRetentionPolicyFunc: &ResolverUpdateRetentionPolicyFunc{
+			defaultHook: i.UpdateRetentionPolicy,
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: i.UploadConnectionResolver,
 		},
+		UploadRetentionFunc: &ResolverUploadRetentionFunc{
+			defaultHook: i.UploadRetention,
+		},
 	}
 }
 
@@ -285,6 +397,117 @@ func (c ResolverCommitGraphFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ResolverCreateRetentionPolicyFunc describes the behavior when the
+// CreateRetentionPolicy method of the parent MockResolver instance is
+// invoked.
+type ResolverCreateRetentionPolicyFunc struct {
+	defaultHook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)
+	hooks       []func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)
+	history     []ResolverCreateRetentionPolicyFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateRetentionPolicy delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) CreateRetentionPolicy(v0 context.Context, v1 dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+	r0, r1 := m.CreateRetentionPolicyFunc.nextHook()(v0, v1)
+	m.CreateRetentionPolicyFunc.appendCall(ResolverCreateRetentionPolicyFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateRetentionPolicy method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverCreateRetentionPolicyFunc) SetDefaultHook(hook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateRetentionPolicy method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverCreateRetentionPolicyFunc) PushHook(hook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverCreateRetentionPolicyFunc) SetDefaultReturn(r0 dbstore.RetentionPolicy, r1 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverCreateRetentionPolicyFunc) PushReturn(r0 dbstore.RetentionPolicy, r1 error) {
+	f.PushHook(func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverCreateRetentionPolicyFunc) nextHook() func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverCreateRetentionPolicyFunc) appendCall(r0 ResolverCreateRetentionPolicyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverCreateRetentionPolicyFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverCreateRetentionPolicyFunc) History() []ResolverCreateRetentionPolicyFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverCreateRetentionPolicyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverCreateRetentionPolicyFuncCall is an object that describes an
+// invocation of method CreateRetentionPolicy on an instance of
+// MockResolver.
+type ResolverCreateRetentionPolicyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.RetentionPolicy
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.RetentionPolicy
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverCreateRetentionPolicyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverCreateRetentionPolicyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverDeleteIndexByIDFunc describes the behavior when the
 // DeleteIndexByID method of the parent MockResolver instance is invoked.
 type ResolverDeleteIndexByIDFunc struct {
@@ -391,6 +614,118 @@ func (c ResolverDeleteIndexByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverDeleteRetentionPolicyByIDFunc describes the behavior when the
+// DeleteRetentionPolicyByID method of the parent MockResolver instance is
+// invoked.
+type ResolverDeleteRetentionPolicyByIDFunc struct {
+	defaultHook func(context.Context, int) (bool, error)
+	hooks       []func(context.Context, int) (bool, error)
+	history     []ResolverDeleteRetentionPolicyByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteRetentionPolicyByID delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockResolver) DeleteRetentionPolicyByID(v0 context.Context, v1 int) (bool, error) {
+	r0, r1 := m.DeleteRetentionPolicyByIDFunc.nextHook()(v0, v1)
+	m.DeleteRetentionPolicyByIDFunc.appendCall(ResolverDeleteRetentionPolicyByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteRetentionPolicyByID method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverDeleteRetentionPolicyByIDFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteRetentionPolicyByID method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverDeleteRetentionPolicyByIDFunc) PushHook(hook func(context.Context, int) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverDeleteRetentionPolicyByIDFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverDeleteRetentionPolicyByIDFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverDeleteRetentionPolicyByIDFunc) nextHook() func(context.Context, int) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverDeleteRetentionPolicyByIDFunc) appendCall(r0 ResolverDeleteRetentionPolicyByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverDeleteRetentionPolicyByIDFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverDeleteRetentionPolicyByIDFunc) History() []ResolverDeleteRetentionPolicyByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverDeleteRetentionPolicyByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverDeleteRetentionPolicyByIDFuncCall is an object that describes an
+// invocation of method DeleteRetentionPolicyByID on an instance of
+// MockResolver.
+type ResolverDeleteRetentionPolicyByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverDeleteRetentionPolicyByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverDeleteRetentionPolicyByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverDeleteUploadByIDFunc describes the behavior when the
 // DeleteUploadByID method of the parent MockResolver instance is invoked.
 type ResolverDeleteUploadByIDFunc struct {
@@ -497,35 +832,35 @@ func (c ResolverDeleteUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ResolverGetIndexByIDFunc describes the behavior when the GetIndexByID
+// ResolverDiagnosticsFunc describes the behavior when the Diagnostics
 // method of the parent MockResolver instance is invoked.
-type ResolverGetIndexByIDFunc struct {
-	defaultHook func(context.Context, int) (dbstore.Index, bool, error)
-	hooks       []func(context.Context, int) (dbstore.Index, bool, error)
-	history     []ResolverGetIndexByIDFuncCall
+type ResolverDiagnosticsFunc struct {
+	defaultHook func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error)
+	hooks       []func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error)
+	history     []ResolverDiagnosticsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetIndexByID delegates to the next hook function in the queue and stores
+// Diagnostics delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockResolver) GetIndexByID(v0 context.Context, v1 int) (dbstore.Index, bool, error) {
-	r0, r1, r2 := m.GetIndexByIDFunc.nextHook()(v0, v1)
-	m.GetIndexByIDFunc.appendCall(ResolverGetIndexByIDFuncCall{v0, v1, r0, r1, r2})
+func (m *MockResolver) Diagnostics(v0 context.Context, v1 dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error) {
+	r0, r1, r2 := m.DiagnosticsFunc.nextHook()(v0, v1)
+	m.DiagnosticsFunc.appendCall(ResolverDiagnosticsFuncCall{v0, v1, r0, r1, r2})
 	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetIndexByID method
+// SetDefaultHook sets function that is called when the Diagnostics method
 // of the parent MockResolver instance is invoked and the hook queue is
 // empty.
-func (f *ResolverGetIndexByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.Index, bool, error)) {
+func (f *ResolverDiagnosticsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetIndexByID method of the parent MockResolver instance invokes the hook
+// Diagnostics method of the parent MockResolver instance invokes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *ResolverGetIndexByIDFunc) PushHook(hook func(context.Context, int) (dbstore.Index, bool, error)) {
+func (f *ResolverDiagnosticsFunc) PushHook(hook func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -533,21 +868,21 @@ func (f *ResolverGetIndexByIDFunc) PushHook(hook func(context.Context, int) (dbs
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *ResolverGetIndexByIDFunc) SetDefaultReturn(r0 dbstore.Index, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) (dbstore.Index, bool, error) {
+func (f *ResolverDiagnosticsFunc) SetDefaultReturn(r0 []resolvers.AdjustedDiagnostic, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error) {
 		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *ResolverGetIndexByIDFunc) PushReturn(r0 dbstore.Index, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int) (dbstore.Index, bool, error) {
+func (f *ResolverDiagnosticsFunc) PushReturn(r0 []resolvers.AdjustedDiagnostic, r1 int, r2 error) {
+	f.PushHook(func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *ResolverGetIndexByIDFunc) nextHook() func(context.Context, int) (dbstore.Index, bool, error) {
+func (f *ResolverDiagnosticsFunc) nextHook() func(context.Context, dbstore.GetDiagnosticsOptions) ([]resolvers.AdjustedDiagnostic, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -560,38 +895,38 @@ func (f *ResolverGetIndexByIDFunc) nextHook() func(context.Context, int) (dbstor
 	return hook
 }
 
-func (f *ResolverGetIndexByIDFunc) appendCall(r0 ResolverGetIndexByIDFuncCall) {
+func (f *ResolverDiagnosticsFunc) appendCall(r0 ResolverDiagnosticsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ResolverGetIndexByIDFuncCall objects
+// History returns a sequence of ResolverDiagnosticsFuncCall objects
 // describing the invocations of this function.
-func (f *ResolverGetIndexByIDFunc) History() []ResolverGetIndexByIDFuncCall {
+func (f *ResolverDiagnosticsFunc) History() []ResolverDiagnosticsFuncCall {
 	f.mutex.Lock()
-	history := make([]ResolverGetIndexByIDFuncCall, len(f.history))
+	history := make([]ResolverDiagnosticsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ResolverGetIndexByIDFuncCall is an object that describes an invocation of
-// method GetIndexByID on an instance of MockResolver.
-type ResolverGetIndexByIDFuncCall struct {
+// ResolverDiagnosticsFuncCall is an object that describes an invocation of
+// method Diagnostics on an instance of MockResolver.
+type ResolverDiagnosticsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 dbstore.GetDiagnosticsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 dbstore.Index
+	Result0 []resolvers.AdjustedDiagnostic
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
+	Result1 int
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
 	Result2 error
@@ -599,37 +934,149 @@ type ResolverGetIndexByIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ResolverGetIndexByIDFuncCall) Args() []interface{} {
+func (c ResolverDiagnosticsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ResolverGetIndexByIDFuncCall) Results() []interface{} {
+func (c ResolverDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// ResolverGetIndexesByIDsFunc describes the behavior when the
-// GetIndexesByIDs method of the parent MockResolver instance is invoked.
-type ResolverGetIndexesByIDsFunc struct {
-	defaultHook func(context.Context, ...int) ([]dbstore.Index, error)
-	hooks       []func(context.Context, ...int) ([]dbstore.Index, error)
-	history     []ResolverGetIndexesByIDsFuncCall
+// ResolverGetIndexByIDFunc describes the behavior when the GetIndexByID
+// method of the parent MockResolver instance is invoked.
+type ResolverGetIndexByIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.Index, bool, error)
+	hooks       []func(context.Context, int) (dbstore.Index, bool, error)
+	history     []ResolverGetIndexByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// GetIndexesByIDs delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockResolver) GetIndexesByIDs(v0 context.Context, v1 ...int) ([]dbstore.Index, error) {
-	r0, r1 := m.GetIndexesByIDsFunc.nextHook()(v0, v1...)
-	m.GetIndexesByIDsFunc.appendCall(ResolverGetIndexesByIDsFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// GetIndexByID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockResolver) GetIndexByID(v0 context.Context, v1 int) (dbstore.Index, bool, error) {
+	r0, r1, r2 := m.GetIndexByIDFunc.nextHook()(v0, v1)
+	m.GetIndexByIDFunc.appendCall(ResolverGetIndexByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetIndexesByIDs
-// method of the parent MockResolver instance is invoked and the hook queue
-// is empty.
-func (f *ResolverGetIndexesByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) ([]dbstore.Index, error)) {
+// SetDefaultHook sets function that is called when the GetIndexByID method
+// of the parent MockResolver instance is invoked and the hook queue is
+// empty.
+func (f *ResolverGetIndexByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.Index, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIndexByID method of the parent MockResolver instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ResolverGetIndexByIDFunc) PushHook(hook func(context.Context, int) (dbstore.Index, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverGetIndexByIDFunc) SetDefaultReturn(r0 dbstore.Index, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.Index, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverGetIndexByIDFunc) PushReturn(r0 dbstore.Index, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.Index, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverGetIndexByIDFunc) nextHook() func(context.Context, int) (dbstore.Index, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverGetIndexByIDFunc) appendCall(r0 ResolverGetIndexByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverGetIndexByIDFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverGetIndexByIDFunc) History() []ResolverGetIndexByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverGetIndexByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverGetIndexByIDFuncCall is an object that describes an invocation of
+// method GetIndexByID on an instance of MockResolver.
+type ResolverGetIndexByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.Index
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverGetIndexByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverGetIndexByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ResolverGetIndexesByIDsFunc describes the behavior when the
+// GetIndexesByIDs method of the parent MockResolver instance is invoked.
+type ResolverGetIndexesByIDsFunc struct {
+	defaultHook func(context.Context, ...int) ([]dbstore.Index, error)
+	hooks       []func(context.Context, ...int) ([]dbstore.Index, error)
+	history     []ResolverGetIndexesByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIndexesByIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) GetIndexesByIDs(v0 context.Context, v1 ...int) ([]dbstore.Index, error) {
+	r0, r1 := m.GetIndexesByIDsFunc.nextHook()(v0, v1...)
+	m.GetIndexesByIDsFunc.appendCall(ResolverGetIndexesByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetIndexesByIDs
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverGetIndexesByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) ([]dbstore.Index, error)) {
 	f.defaultHook = hook
 }
 
@@ -1168,35 +1615,36 @@ func (c ResolverIndexConnectionResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ResolverQueryResolverFunc describes the behavior when the QueryResolver
-// method of the parent MockResolver instance is invoked.
-type ResolverQueryResolverFunc struct {
-	defaultHook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)
-	hooks       []func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)
-	history     []ResolverQueryResolverFuncCall
+// ResolverPackageDependenciesFunc describes the behavior when the
+// PackageDependencies method of the parent MockResolver instance is
+// invoked.
+type ResolverPackageDependenciesFunc struct {
+	defaultHook func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error)
+	hooks       []func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error)
+	history     []ResolverPackageDependenciesFuncCall
 	mutex       sync.Mutex
 }
 
-// QueryResolver delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockResolver) QueryResolver(v0 context.Context, v1 *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
-	r0, r1 := m.QueryResolverFunc.nextHook()(v0, v1)
-	m.QueryResolverFunc.appendCall(ResolverQueryResolverFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// PackageDependencies delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) PackageDependencies(v0 context.Context, v1 resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error) {
+	r0, r1, r2 := m.PackageDependenciesFunc.nextHook()(v0, v1)
+	m.PackageDependenciesFunc.appendCall(ResolverPackageDependenciesFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the QueryResolver method
-// of the parent MockResolver instance is invoked and the hook queue is
-// empty.
-func (f *ResolverQueryResolverFunc) SetDefaultHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)) {
+// SetDefaultHook sets function that is called when the PackageDependencies
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverPackageDependenciesFunc) SetDefaultHook(hook func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// QueryResolver method of the parent MockResolver instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ResolverQueryResolverFunc) PushHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)) {
+// PackageDependencies method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverPackageDependenciesFunc) PushHook(hook func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1204,21 +1652,21 @@ func (f *ResolverQueryResolverFunc) PushHook(hook func(context.Context, *graphql
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *ResolverQueryResolverFunc) SetDefaultReturn(r0 resolvers.QueryResolver, r1 error) {
-	f.SetDefaultHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
-		return r0, r1
+func (f *ResolverPackageDependenciesFunc) SetDefaultReturn(r0 []dbstore.PackageDependency, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *ResolverQueryResolverFunc) PushReturn(r0 resolvers.QueryResolver, r1 error) {
-	f.PushHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
-		return r0, r1
+func (f *ResolverPackageDependenciesFunc) PushReturn(r0 []dbstore.PackageDependency, r1 int, r2 error) {
+	f.PushHook(func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *ResolverQueryResolverFunc) nextHook() func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+func (f *ResolverPackageDependenciesFunc) nextHook() func(context.Context, resolvers.PackageDependenciesOptions) ([]dbstore.PackageDependency, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1231,83 +1679,84 @@ func (f *ResolverQueryResolverFunc) nextHook() func(context.Context, *graphqlbac
 	return hook
 }
 
-func (f *ResolverQueryResolverFunc) appendCall(r0 ResolverQueryResolverFuncCall) {
+func (f *ResolverPackageDependenciesFunc) appendCall(r0 ResolverPackageDependenciesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ResolverQueryResolverFuncCall objects
+// History returns a sequence of ResolverPackageDependenciesFuncCall objects
 // describing the invocations of this function.
-func (f *ResolverQueryResolverFunc) History() []ResolverQueryResolverFuncCall {
+func (f *ResolverPackageDependenciesFunc) History() []ResolverPackageDependenciesFuncCall {
 	f.mutex.Lock()
-	history := make([]ResolverQueryResolverFuncCall, len(f.history))
+	history := make([]ResolverPackageDependenciesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ResolverQueryResolverFuncCall is an object that describes an invocation
-// of method QueryResolver on an instance of MockResolver.
-type ResolverQueryResolverFuncCall struct {
+// ResolverPackageDependenciesFuncCall is an object that describes an
+// invocation of method PackageDependencies on an instance of MockResolver.
+type ResolverPackageDependenciesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *graphqlbackend.GitBlobLSIFDataArgs
+	Arg1 resolvers.PackageDependenciesOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 resolvers.QueryResolver
+	Result0 []dbstore.PackageDependency
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ResolverQueryResolverFuncCall) Args() []interface{} {
+func (c ResolverPackageDependenciesFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ResolverQueryResolverFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ResolverPackageDependenciesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// ResolverQueueAutoIndexJobForRepoFunc describes the behavior when the
-// QueueAutoIndexJobForRepo method of the parent MockResolver instance is
-// invoked.
-type ResolverQueueAutoIndexJobForRepoFunc struct {
-	defaultHook func(context.Context, int) error
-	hooks       []func(context.Context, int) error
-	history     []ResolverQueueAutoIndexJobForRepoFuncCall
+// ResolverPackageDependentsFunc describes the behavior when the
+// PackageDependents method of the parent MockResolver instance is invoked.
+type ResolverPackageDependentsFunc struct {
+	defaultHook func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error)
+	hooks       []func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error)
+	history     []ResolverPackageDependentsFuncCall
 	mutex       sync.Mutex
 }
 
-// QueueAutoIndexJobForRepo delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockResolver) QueueAutoIndexJobForRepo(v0 context.Context, v1 int) error {
-	r0 := m.QueueAutoIndexJobForRepoFunc.nextHook()(v0, v1)
-	m.QueueAutoIndexJobForRepoFunc.appendCall(ResolverQueueAutoIndexJobForRepoFuncCall{v0, v1, r0})
-	return r0
+// PackageDependents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) PackageDependents(v0 context.Context, v1 resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error) {
+	r0, r1, r2 := m.PackageDependentsFunc.nextHook()(v0, v1)
+	m.PackageDependentsFunc.appendCall(ResolverPackageDependentsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the
-// QueueAutoIndexJobForRepo method of the parent MockResolver instance is
-// invoked and the hook queue is empty.
-func (f *ResolverQueueAutoIndexJobForRepoFunc) SetDefaultHook(hook func(context.Context, int) error) {
+// SetDefaultHook sets function that is called when the PackageDependents
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverPackageDependentsFunc) SetDefaultHook(hook func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// QueueAutoIndexJobForRepo method of the parent MockResolver instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *ResolverQueueAutoIndexJobForRepoFunc) PushHook(hook func(context.Context, int) error) {
+// PackageDependents method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverPackageDependentsFunc) PushHook(hook func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1315,21 +1764,21 @@ func (f *ResolverQueueAutoIndexJobForRepoFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *ResolverQueueAutoIndexJobForRepoFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int) error {
-		return r0
+func (f *ResolverPackageDependentsFunc) SetDefaultReturn(r0 []dbstore.PackageDependent, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *ResolverQueueAutoIndexJobForRepoFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int) error {
-		return r0
+func (f *ResolverPackageDependentsFunc) PushReturn(r0 []dbstore.PackageDependent, r1 int, r2 error) {
+	f.PushHook(func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *ResolverQueueAutoIndexJobForRepoFunc) nextHook() func(context.Context, int) error {
+func (f *ResolverPackageDependentsFunc) nextHook() func(context.Context, resolvers.PackageDependentsOptions) ([]dbstore.PackageDependent, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1342,78 +1791,632 @@ func (f *ResolverQueueAutoIndexJobForRepoFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *ResolverQueueAutoIndexJobForRepoFunc) appendCall(r0 ResolverQueueAutoIndexJobForRepoFuncCall) {
+func (f *ResolverPackageDependentsFunc) appendCall(r0 ResolverPackageDependentsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ResolverQueueAutoIndexJobForRepoFuncCall
-// objects describing the invocations of this function.
-func (f *ResolverQueueAutoIndexJobForRepoFunc) History() []ResolverQueueAutoIndexJobForRepoFuncCall {
+// History returns a sequence of ResolverPackageDependentsFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverPackageDependentsFunc) History() []ResolverPackageDependentsFuncCall {
 	f.mutex.Lock()
-	history := make([]ResolverQueueAutoIndexJobForRepoFuncCall, len(f.history))
+	history := make([]ResolverPackageDependentsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ResolverQueueAutoIndexJobForRepoFuncCall is an object that describes an
-// invocation of method QueueAutoIndexJobForRepo on an instance of
-// MockResolver.
-type ResolverQueueAutoIndexJobForRepoFuncCall struct {
+// ResolverPackageDependentsFuncCall is an object that describes an
+// invocation of method PackageDependents on an instance of MockResolver.
+type ResolverPackageDependentsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 resolvers.PackageDependentsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []dbstore.PackageDependent
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ResolverQueueAutoIndexJobForRepoFuncCall) Args() []interface{} {
+func (c ResolverPackageDependentsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ResolverQueueAutoIndexJobForRepoFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ResolverPackageDependentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// ResolverUpdateIndexConfigurationByRepositoryIDFunc describes the behavior
-// when the UpdateIndexConfigurationByRepositoryID method of the parent
-// MockResolver instance is invoked.
-type ResolverUpdateIndexConfigurationByRepositoryIDFunc struct {
-	defaultHook func(context.Context, int, string) error
-	hooks       []func(context.Context, int, string) error
-	history     []ResolverUpdateIndexConfigurationByRepositoryIDFuncCall
+// ResolverQueryResolverFunc describes the behavior when the QueryResolver
+// method of the parent MockResolver instance is invoked.
+type ResolverQueryResolverFunc struct {
+	defaultHook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)
+	hooks       []func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)
+	history     []ResolverQueryResolverFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateIndexConfigurationByRepositoryID delegates to the next hook
-// function in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockResolver) UpdateIndexConfigurationByRepositoryID(v0 context.Context, v1 int, v2 string) error {
-	r0 := m.UpdateIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1, v2)
-	m.UpdateIndexConfigurationByRepositoryIDFunc.appendCall(ResolverUpdateIndexConfigurationByRepositoryIDFuncCall{v0, v1, v2, r0})
-	return r0
+// QueryResolver delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockResolver) QueryResolver(v0 context.Context, v1 *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+	r0, r1 := m.QueryResolverFunc.nextHook()(v0, v1)
+	m.QueryResolverFunc.appendCall(ResolverQueryResolverFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// UpdateIndexConfigurationByRepositoryID method of the parent MockResolver
-// instance is invoked and the hook queue is empty.
-func (f *ResolverUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+// SetDefaultHook sets function that is called when the QueryResolver method
+// of the parent MockResolver instance is invoked and the hook queue is
+// empty.
+func (f *ResolverQueryResolverFunc) SetDefaultHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateIndexConfigurationByRepositoryID method of the parent MockResolver
+// QueryResolver method of the parent MockResolver instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ResolverQueryResolverFunc) PushHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverQueryResolverFunc) SetDefaultReturn(r0 resolvers.QueryResolver, r1 error) {
+	f.SetDefaultHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverQueryResolverFunc) PushReturn(r0 resolvers.QueryResolver, r1 error) {
+	f.PushHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverQueryResolverFunc) nextHook() func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverQueryResolverFunc) appendCall(r0 ResolverQueryResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverQueryResolverFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverQueryResolverFunc) History() []ResolverQueryResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverQueryResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverQueryResolverFuncCall is an object that describes an invocation
+// of method QueryResolver on an instance of MockResolver.
+type ResolverQueryResolverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *graphqlbackend.GitBlobLSIFDataArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 resolvers.QueryResolver
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverQueryResolverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverQueryResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ResolverQueueAutoIndexJobForRepoFunc describes the behavior when the
+// QueueAutoIndexJobForRepo method of the parent MockResolver instance is
+// invoked.
+type ResolverQueueAutoIndexJobForRepoFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []ResolverQueueAutoIndexJobForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// QueueAutoIndexJobForRepo delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) QueueAutoIndexJobForRepo(v0 context.Context, v1 int) error {
+	r0 := m.QueueAutoIndexJobForRepoFunc.nextHook()(v0, v1)
+	m.QueueAutoIndexJobForRepoFunc.appendCall(ResolverQueueAutoIndexJobForRepoFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// QueueAutoIndexJobForRepo method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueueAutoIndexJobForRepo method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *ResolverQueueAutoIndexJobForRepoFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverQueueAutoIndexJobForRepoFunc) appendCall(r0 ResolverQueueAutoIndexJobForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverQueueAutoIndexJobForRepoFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) History() []ResolverQueueAutoIndexJobForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverQueueAutoIndexJobForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverQueueAutoIndexJobForRepoFuncCall is an object that describes an
+// invocation of method QueueAutoIndexJobForRepo on an instance of
+// MockResolver.
+type ResolverQueueAutoIndexJobForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverQueueAutoIndexJobForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverQueueAutoIndexJobForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ResolverRetentionPoliciesFunc describes the behavior when the
+// RetentionPolicies method of the parent MockResolver instance is invoked.
+type ResolverRetentionPoliciesFunc struct {
+	defaultHook func(context.Context) ([]dbstore.RetentionPolicy, error)
+	hooks       []func(context.Context) ([]dbstore.RetentionPolicy, error)
+	history     []ResolverRetentionPoliciesFuncCall
+	mutex       sync.Mutex
+}
+
+// RetentionPolicies delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) RetentionPolicies(v0 context.Context) ([]dbstore.RetentionPolicy, error) {
+	r0, r1 := m.RetentionPoliciesFunc.nextHook()(v0)
+	m.RetentionPoliciesFunc.appendCall(ResolverRetentionPoliciesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RetentionPolicies
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverRetentionPoliciesFunc) SetDefaultHook(hook func(context.Context) ([]dbstore.RetentionPolicy, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RetentionPolicies method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverRetentionPoliciesFunc) PushHook(hook func(context.Context) ([]dbstore.RetentionPolicy, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverRetentionPoliciesFunc) SetDefaultReturn(r0 []dbstore.RetentionPolicy, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverRetentionPoliciesFunc) PushReturn(r0 []dbstore.RetentionPolicy, r1 error) {
+	f.PushHook(func(context.Context) ([]dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverRetentionPoliciesFunc) nextHook() func(context.Context) ([]dbstore.RetentionPolicy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverRetentionPoliciesFunc) appendCall(r0 ResolverRetentionPoliciesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverRetentionPoliciesFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverRetentionPoliciesFunc) History() []ResolverRetentionPoliciesFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverRetentionPoliciesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverRetentionPoliciesFuncCall is an object that describes an
+// invocation of method RetentionPolicies on an instance of MockResolver.
+type ResolverRetentionPoliciesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.RetentionPolicy
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverRetentionPoliciesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverRetentionPoliciesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ResolverRetentionPolicyByIDFunc describes the behavior when the
+// RetentionPolicyByID method of the parent MockResolver instance is
+// invoked.
+type ResolverRetentionPolicyByIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.RetentionPolicy, bool, error)
+	hooks       []func(context.Context, int) (dbstore.RetentionPolicy, bool, error)
+	history     []ResolverRetentionPolicyByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// RetentionPolicyByID delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) RetentionPolicyByID(v0 context.Context, v1 int) (dbstore.RetentionPolicy, bool, error) {
+	r0, r1, r2 := m.RetentionPolicyByIDFunc.nextHook()(v0, v1)
+	m.RetentionPolicyByIDFunc.appendCall(ResolverRetentionPolicyByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the RetentionPolicyByID
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverRetentionPolicyByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.RetentionPolicy, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RetentionPolicyByID method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverRetentionPolicyByIDFunc) PushHook(hook func(context.Context, int) (dbstore.RetentionPolicy, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverRetentionPolicyByIDFunc) SetDefaultReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverRetentionPolicyByIDFunc) PushReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverRetentionPolicyByIDFunc) nextHook() func(context.Context, int) (dbstore.RetentionPolicy, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverRetentionPolicyByIDFunc) appendCall(r0 ResolverRetentionPolicyByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverRetentionPolicyByIDFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverRetentionPolicyByIDFunc) History() []ResolverRetentionPolicyByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverRetentionPolicyByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverRetentionPolicyByIDFuncCall is an object that describes an
+// invocation of method RetentionPolicyByID on an instance of MockResolver.
+type ResolverRetentionPolicyByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.RetentionPolicy
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverRetentionPolicyByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverRetentionPolicyByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ResolverSearchBasedQueryResolverFunc describes the behavior when the
+// SearchBasedQueryResolver method of the parent MockResolver instance is
+// invoked.
+type ResolverSearchBasedQueryResolverFunc struct {
+	defaultHook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)
+	hooks       []func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)
+	history     []ResolverSearchBasedQueryResolverFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchBasedQueryResolver delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) SearchBasedQueryResolver(v0 context.Context, v1 *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+	r0, r1 := m.SearchBasedQueryResolverFunc.nextHook()(v0, v1)
+	m.SearchBasedQueryResolverFunc.appendCall(ResolverSearchBasedQueryResolverFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// SearchBasedQueryResolver method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverSearchBasedQueryResolverFunc) SetDefaultHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchBasedQueryResolver method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverSearchBasedQueryResolverFunc) PushHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverSearchBasedQueryResolverFunc) SetDefaultReturn(r0 resolvers.QueryResolver, r1 error) {
+	f.SetDefaultHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverSearchBasedQueryResolverFunc) PushReturn(r0 resolvers.QueryResolver, r1 error) {
+	f.PushHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverSearchBasedQueryResolverFunc) nextHook() func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverSearchBasedQueryResolverFunc) appendCall(r0 ResolverSearchBasedQueryResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverSearchBasedQueryResolverFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverSearchBasedQueryResolverFunc) History() []ResolverSearchBasedQueryResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverSearchBasedQueryResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverSearchBasedQueryResolverFuncCall is an object that describes an
+// invocation of method SearchBasedQueryResolver on an instance of
+// MockResolver.
+type ResolverSearchBasedQueryResolverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *graphqlbackend.GitBlobLSIFDataArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 resolvers.QueryResolver
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverSearchBasedQueryResolverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverSearchBasedQueryResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ResolverUpdateIndexConfigurationByRepositoryIDFunc describes the behavior
+// when the UpdateIndexConfigurationByRepositoryID method of the parent
+// MockResolver instance is invoked.
+type ResolverUpdateIndexConfigurationByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int, string) error
+	hooks       []func(context.Context, int, string) error
+	history     []ResolverUpdateIndexConfigurationByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexConfigurationByRepositoryID delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockResolver) UpdateIndexConfigurationByRepositoryID(v0 context.Context, v1 int, v2 string) error {
+	r0 := m.UpdateIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1, v2)
+	m.UpdateIndexConfigurationByRepositoryIDFunc.appendCall(ResolverUpdateIndexConfigurationByRepositoryIDFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexConfigurationByRepositoryID method of the parent MockResolver
+// instance is invoked and the hook queue is empty.
+func (f *ResolverUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIndexConfigurationByRepositoryID method of the parent MockResolver
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
@@ -1500,6 +2503,120 @@ func (c ResolverUpdateIndexConfigurationByRepositoryIDFuncCall) Results() []inte
 	return []interface{}{c.Result0}
 }
 
+// ResolverUpdateRetentionPolicyFunc describes the behavior when the
+// UpdateRetentionPolicy method of the parent MockResolver instance is
+// invoked.
+type ResolverUpdateRetentionPolicyFunc struct {
+	defaultHook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error)
+	hooks       []func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error)
+	history     []ResolverUpdateRetentionPolicyFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateRetentionPolicy delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) UpdateRetentionPolicy(v0 context.Context, v1 dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error) {
+	r0, r1, r2 := m.UpdateRetentionPolicyFunc.nextHook()(v0, v1)
+	m.UpdateRetentionPolicyFunc.appendCall(ResolverUpdateRetentionPolicyFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateRetentionPolicy method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverUpdateRetentionPolicyFunc) SetDefaultHook(hook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateRetentionPolicy method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverUpdateRetentionPolicyFunc) PushHook(hook func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverUpdateRetentionPolicyFunc) SetDefaultReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverUpdateRetentionPolicyFunc) PushReturn(r0 dbstore.RetentionPolicy, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverUpdateRetentionPolicyFunc) nextHook() func(context.Context, dbstore.RetentionPolicy) (dbstore.RetentionPolicy, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUpdateRetentionPolicyFunc) appendCall(r0 ResolverUpdateRetentionPolicyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverUpdateRetentionPolicyFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverUpdateRetentionPolicyFunc) History() []ResolverUpdateRetentionPolicyFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUpdateRetentionPolicyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUpdateRetentionPolicyFuncCall is an object that describes an
+// invocation of method UpdateRetentionPolicy on an instance of
+// MockResolver.
+type ResolverUpdateRetentionPolicyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.RetentionPolicy
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.RetentionPolicy
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUpdateRetentionPolicyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUpdateRetentionPolicyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ResolverUploadConnectionResolverFunc describes the behavior when the
 // UploadConnectionResolver method of the parent MockResolver instance is
 // invoked.
@@ -1605,3 +2722,115 @@ func (c ResolverUploadConnectionResolverFuncCall) Args() []interface{} {
 func (c ResolverUploadConnectionResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// ResolverUploadRetentionFunc describes the behavior when the
+// UploadRetention method of the parent MockResolver instance is invoked.
+type ResolverUploadRetentionFunc struct {
+	defaultHook func(context.Context, dbstore.Upload) (policies.Retention, bool, error)
+	hooks       []func(context.Context, dbstore.Upload) (policies.Retention, bool, error)
+	history     []ResolverUploadRetentionFuncCall
+	mutex       sync.Mutex
+}
+
+// UploadRetention delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) UploadRetention(v0 context.Context, v1 dbstore.Upload) (policies.Retention, bool, error) {
+	r0, r1, r2 := m.UploadRetentionFunc.nextHook()(v0, v1)
+	m.UploadRetentionFunc.appendCall(ResolverUploadRetentionFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the UploadRetention
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverUploadRetentionFunc) SetDefaultHook(hook func(context.Context, dbstore.Upload) (policies.Retention, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UploadRetention method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverUploadRetentionFunc) PushHook(hook func(context.Context, dbstore.Upload) (policies.Retention, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverUploadRetentionFunc) SetDefaultReturn(r0 policies.Retention, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.Upload) (policies.Retention, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverUploadRetentionFunc) PushReturn(r0 policies.Retention, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, dbstore.Upload) (policies.Retention, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverUploadRetentionFunc) nextHook() func(context.Context, dbstore.Upload) (policies.Retention, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUploadRetentionFunc) appendCall(r0 ResolverUploadRetentionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverUploadRetentionFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverUploadRetentionFunc) History() []ResolverUploadRetentionFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUploadRetentionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUploadRetentionFuncCall is an object that describes an invocation
+// of method UploadRetention on an instance of MockResolver.
+type ResolverUploadRetentionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.Upload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 policies.Retention
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUploadRetentionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUploadRetentionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
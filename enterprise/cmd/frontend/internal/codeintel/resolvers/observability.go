@@ -19,13 +19,17 @@ type operations struct {
 	definitions               *observation.Operation
 	diagnostics               *observation.Operation
 	hover                     *observation.Operation
+	implementations           *observation.Operation
 	ranges                    *observation.Operation
 	references                *observation.Operation
+	typeDefinitions           *observation.Operation
 	documentationPage         *observation.Operation
 	documentationPathInfo     *observation.Operation
 	documentationIDsToPathIDs *observation.Operation
 	documentationReferences   *observation.Operation
 	documentation             *observation.Operation
+	searchBasedDefinitions    *observation.Operation
+	searchBasedReferences     *observation.Operation
 
 	findClosestDumps *observation.Operation
 }
@@ -60,13 +64,17 @@ func newOperations(observationContext *observation.Context) *operations {
 		definitions:               op("Definitions"),
 		diagnostics:               op("Diagnostics"),
 		hover:                     op("Hover"),
+		implementations:           op("Implementations"),
 		ranges:                    op("Ranges"),
 		references:                op("References"),
+		typeDefinitions:           op("TypeDefinitions"),
 		documentationPage:         op("DocumentationPage"),
 		documentationPathInfo:     op("DocumentationPathInfo"),
 		documentationIDsToPathIDs: op("DocumentationIDsToPathIDs"),
 		documentationReferences:   op("DocumentationReferences"),
 		documentation:             op("Documentation"),
+		searchBasedDefinitions:    op("SearchBasedDefinitions"),
+		searchBasedReferences:     op("SearchBasedReferences"),
 
 		findClosestDumps: subOp("findClosestDumps"),
 	}
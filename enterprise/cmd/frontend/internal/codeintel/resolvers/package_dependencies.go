@@ -0,0 +1,154 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/Masterminds/semver"
+	"github.com/cockroachdb/errors"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+// PackageVersionFilter restricts a set of packages by version.
+type PackageVersionFilter struct {
+	// Version restricts packages to the given version, if non-empty.
+	Version string
+	// VersionConstraint restricts packages to versions satisfying the given semantic version
+	// constraint (e.g. "< 2.17.0" or "^1.2"), if non-empty. Packages whose version is not a
+	// semantic version never satisfy a constraint.
+	VersionConstraint string
+}
+
+// matcher returns a function that returns true for versions passing the filter.
+func (f PackageVersionFilter) matcher() (func(version string) bool, error) {
+	var constraints *semver.Constraints
+	if f.VersionConstraint != "" {
+		var err error
+		if constraints, err = semver.NewConstraint(f.VersionConstraint); err != nil {
+			return nil, errors.Wrapf(err, "invalid version constraint %q", f.VersionConstraint)
+		}
+	}
+
+	return func(version string) bool {
+		if f.Version != "" && version != f.Version {
+			return false
+		}
+
+		if constraints != nil {
+			v, err := semver.NewVersion(version)
+			if err != nil || !constraints.Check(v) {
+				return false
+			}
+		}
+
+		return true
+	}, nil
+}
+
+type PackageDependenciesOptions struct {
+	RepositoryID int
+	// MaxDepth is the maximum length of a chain of package references followed from the repository.
+	MaxDepth int
+	Scheme   string
+	Name     string
+	PackageVersionFilter
+	Limit  int
+	Offset int
+}
+
+// PackageDependencies returns a page of the packages the given repository depends on, ordered by
+// depth, and the total number of such packages.
+func (r *resolver) PackageDependencies(ctx context.Context, opts PackageDependenciesOptions) ([]store.PackageDependency, int, error) {
+	matches, err := opts.PackageVersionFilter.matcher()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dependencies, err := r.dbStore.GetPackageDependencies(ctx, store.GetPackageDependenciesOptions{
+		RepositoryID: opts.RepositoryID,
+		MaxDepth:     opts.MaxDepth,
+		Scheme:       opts.Scheme,
+		Name:         opts.Name,
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "dbstore.GetPackageDependencies")
+	}
+
+	filtered := dependencies[:0]
+	for _, dependency := range dependencies {
+		if matches(dependency.Version) {
+			filtered = append(filtered, dependency)
+		}
+	}
+
+	lo, hi := pageBounds(len(filtered), opts.Limit, opts.Offset)
+	return filtered[lo:hi], len(filtered), nil
+}
+
+type PackageDependentsOptions struct {
+	Scheme string
+	Name   string
+	// PackageVersionFilter restricts the versions of the target package.
+	PackageVersionFilter
+	// MaxDepth is the maximum length of a chain of package references followed to the package.
+	MaxDepth int
+	Limit    int
+	Offset   int
+}
+
+// PackageDependents returns a page of the repositories that depend on the given package, ordered
+// by depth, and the total number of such repositories.
+func (r *resolver) PackageDependents(ctx context.Context, opts PackageDependentsOptions) ([]store.PackageDependent, int, error) {
+	matches, err := opts.PackageVersionFilter.matcher()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var versions []string
+	if opts.Version != "" || opts.VersionConstraint != "" {
+		allVersions, err := r.dbStore.PackageReferenceVersions(ctx, opts.Scheme, opts.Name)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "dbstore.PackageReferenceVersions")
+		}
+
+		for _, version := range allVersions {
+			if matches(version) {
+				versions = append(versions, version)
+			}
+		}
+
+		if len(versions) == 0 {
+			// No referenced version passes the filter
+			return nil, 0, nil
+		}
+	}
+
+	dependents, err := r.dbStore.GetPackageDependents(ctx, store.GetPackageDependentsOptions{
+		Scheme:   opts.Scheme,
+		Name:     opts.Name,
+		Versions: versions,
+		MaxDepth: opts.MaxDepth,
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "dbstore.GetPackageDependents")
+	}
+
+	lo, hi := pageBounds(len(dependents), opts.Limit, opts.Offset)
+	return dependents[lo:hi], len(dependents), nil
+}
+
+// pageBounds returns the bounds of the page of the given size starting at the given offset within
+// a slice of the given length.
+func pageBounds(length, limit, offset int) (lo, hi int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > length {
+		offset = length
+	}
+	if limit < 0 || offset+limit > length {
+		return offset, length
+	}
+
+	return offset, offset + limit
+}
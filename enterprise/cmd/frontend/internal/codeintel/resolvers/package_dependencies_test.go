@@ -0,0 +1,111 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestPackageDependenciesVersionConstraint(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockDBStore.GetPackageDependenciesFunc.SetDefaultReturn([]dbstore.PackageDependency{
+		{Scheme: "npm", Name: "a", Version: "1.0.0", Depth: 1},
+		{Scheme: "npm", Name: "b", Version: "2.3.0", Depth: 1},
+		{Scheme: "npm", Name: "c", Version: "master", Depth: 2},
+		{Scheme: "npm", Name: "d", Version: "1.5.0", Depth: 2},
+	}, nil)
+
+	resolver := newResolver(mockDBStore, NewMockLSIFStore(), NewMockGitserverClient(), nil, nil, nil, &observation.TestContext)
+	dependencies, totalCount, err := resolver.PackageDependencies(context.Background(), PackageDependenciesOptions{
+		RepositoryID:         50,
+		MaxDepth:             2,
+		PackageVersionFilter: PackageVersionFilter{VersionConstraint: "< 2.0.0"},
+		Limit:                1,
+		Offset:               1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 2 {
+		t.Errorf("unexpected total count. want=%d have=%d", 2, totalCount)
+	}
+
+	expected := []dbstore.PackageDependency{
+		{Scheme: "npm", Name: "d", Version: "1.5.0", Depth: 2},
+	}
+	if diff := cmp.Diff(expected, dependencies); diff != "" {
+		t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+	}
+
+	if _, _, err := resolver.PackageDependencies(context.Background(), PackageDependenciesOptions{
+		PackageVersionFilter: PackageVersionFilter{VersionConstraint: "not a constraint"},
+	}); err == nil {
+		t.Errorf("expected error for invalid version constraint")
+	}
+}
+
+func TestPackageDependentsVersionConstraint(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockDBStore.PackageReferenceVersionsFunc.SetDefaultReturn([]string{"1.0.0", "1.9.0", "2.1.0", "latest"}, nil)
+	mockDBStore.GetPackageDependentsFunc.SetDefaultReturn([]dbstore.PackageDependent{
+		{RepositoryID: 50, RepositoryName: "r50", Scheme: "npm", Name: "leftpad", Version: "1.0.0", Depth: 1},
+	}, nil)
+
+	resolver := newResolver(mockDBStore, NewMockLSIFStore(), NewMockGitserverClient(), nil, nil, nil, &observation.TestContext)
+	dependents, totalCount, err := resolver.PackageDependents(context.Background(), PackageDependentsOptions{
+		Scheme:               "npm",
+		Name:                 "leftpad",
+		PackageVersionFilter: PackageVersionFilter{VersionConstraint: "^1.0"},
+		MaxDepth:             1,
+		Limit:                10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 1 || len(dependents) != 1 {
+		t.Errorf("unexpected dependents. want=%d have=%d (total=%d)", 1, len(dependents), totalCount)
+	}
+
+	if history := mockDBStore.GetPackageDependentsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of GetPackageDependents calls. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff([]string{"1.0.0", "1.9.0"}, history[0].Arg1.Versions); diff != "" {
+		t.Errorf("unexpected versions (-want +got):\n%s", diff)
+	}
+
+	// No referenced version satisfies the constraint
+	dependents, totalCount, err = resolver.PackageDependents(context.Background(), PackageDependentsOptions{
+		Scheme:               "npm",
+		Name:                 "leftpad",
+		PackageVersionFilter: PackageVersionFilter{VersionConstraint: ">= 3.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 0 || len(dependents) != 0 {
+		t.Errorf("expected no dependents")
+	}
+	if history := mockDBStore.GetPackageDependentsFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected number of GetPackageDependents calls. want=%d have=%d", 1, len(history))
+	}
+}
+
+func TestPageBounds(t *testing.T) {
+	for _, testCase := range []struct {
+		length, limit, offset int
+		lo, hi                int
+	}{
+		{length: 10, limit: 3, offset: 0, lo: 0, hi: 3},
+		{length: 10, limit: 3, offset: 8, lo: 8, hi: 10},
+		{length: 10, limit: 3, offset: 12, lo: 10, hi: 10},
+		{length: 10, limit: -1, offset: 2, lo: 2, hi: 10},
+		{length: 10, limit: 3, offset: -1, lo: 0, hi: 3},
+	} {
+		if lo, hi := pageBounds(testCase.length, testCase.limit, testCase.offset); lo != testCase.lo || hi != testCase.hi {
+			t.Errorf("unexpected bounds for %+v. want=[%d:%d] have=[%d:%d]", testCase, testCase.lo, testCase.hi, lo, hi)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
 
 const slowDefinitionsRequestThreshold = time.Second
@@ -78,9 +79,14 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 		log.String("monikers", monikersToString(orderedMonikers)),
 	)
 
+	return r.remoteDefinitions(ctx, traceLog, orderedMonikers)
+}
+
+// remoteDefinitions returns the list of source locations that define any of the given monikers within
+// the indexes that provide them.
+func (r *queryResolver) remoteDefinitions(ctx context.Context, traceLog observation.TraceLogger, orderedMonikers []semantic.QualifiedMonikerData) ([]AdjustedLocation, error) {
 	// Determine the set of uploads over which we need to perform a moniker search. This will
-	// include all all indexes which define one of the ordered monikers. This should not include
-	// any of the indexes we have already performed an LSIF graph traversal in above.
+	// include all all indexes which define one of the ordered monikers.
 	uploads, err := r.definitionUploads(ctx, orderedMonikers)
 	if err != nil {
 		return nil, err
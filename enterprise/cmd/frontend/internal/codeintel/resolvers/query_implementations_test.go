@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestImplementations(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockDBStore.ReferenceIDsAndFiltersFunc.PushReturn(dbstore.PackageReferenceScannerFromSlice(), 0, nil)
+
+	locations := []lsifstore.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+		{DumpID: 51, Path: "c.go", Range: testRange3},
+	}
+	mockLSIFStore.ImplementationsFunc.PushReturn(locations[:1], 1, nil)
+	mockLSIFStore.ImplementationsFunc.PushReturn(locations[1:], 2, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedLocations, _, err := resolver.Implementations(context.Background(), 10, 20, 50, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying implementations: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+		{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.ReferencesFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for lsifstore.References. want=%d have=%d", 0, len(history))
+	}
+}
@@ -3,7 +3,6 @@ package resolvers
 import (
 	"context"
 	"fmt"
-	"sort"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -35,6 +34,33 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	})
 	defer endObservation()
 
+	source := locationSource{
+		localLocations: r.lsifStore.References,
+		localName:      "lsifstore.References",
+		tableName:      "references",
+	}
+
+	return r.pagedLocations(ctx, traceLog, source, line, character, limit, rawCursor)
+}
+
+// locationSource describes how a paginated location request resolves "local" locations via an LSIF
+// graph traversal and "remote" locations via a moniker search.
+type locationSource struct {
+	// localLocations returns a page of the locations attached to the ranges enclosing the given
+	// position within a single bundle, along with the total number of such locations.
+	localLocations func(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+
+	// localName is the name of the localLocations method used in error messages.
+	localName string
+
+	// tableName is the name of the moniker table over which remote locations are searched.
+	tableName string
+}
+
+// pagedLocations returns the page of the result set denoted by the given cursor as well as the cursor
+// for the next page, or an empty string if there are no more pages. The References and Implementations
+// methods differ only by the source of their locations.
+func (r *queryResolver) pagedLocations(ctx context.Context, traceLog observation.TraceLogger, source locationSource, line, character, limit int, rawCursor string) (_ []AdjustedLocation, _ string, err error) {
 	// Maintain a map from identifers to hydrated upload records from the database. We use
 	// this map as a quick lookup when constructing the resulting location set. Any additional
 	// upload records pulled back from the database while processing this page will be added
@@ -96,7 +122,7 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	}
 
 	// Query a single page of location results
-	locations, hasMore, err := r.pageReferences(ctx, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, &cursor, limit)
+	locations, hasMore, err := r.pageReferences(ctx, source, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, &cursor, limit)
 	if err != nil {
 		return nil, "", err
 	}
@@ -237,7 +263,7 @@ func (r *queryResolver) definitionUploadIDsFromCursor(ctx context.Context, adjus
 // pageReferences returns a slice of the result set denoted by the given cursor. The given cursor will be
 // adjusted to reflect the offsets required to resolve the next page of results. If there are no more pages
 // left in the result set, a false-valued flag is returned.
-func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []adjustedUpload, orderedMonikers []semantic.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageReferences(ctx context.Context, source locationSource, adjustedUploads []adjustedUpload, orderedMonikers []semantic.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	var locations []lsifstore.Location
 
 	// Phase 1: Gather all "local" locations via LSIF graph traversal. We'll continue to request additional
@@ -246,7 +272,7 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 
 	if !cursor.RemotePhase {
 		for len(locations) < limit {
-			localLocations, hasMore, err := r.pageLocalReferences(ctx, adjustedUploads, cursor, limit-len(locations))
+			localLocations, hasMore, err := r.pageLocalReferences(ctx, source, adjustedUploads, cursor, limit-len(locations))
 			if err != nil {
 				return nil, false, err
 			}
@@ -266,7 +292,7 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 
 	if cursor.RemotePhase {
 		for len(locations) < limit {
-			remoteLocations, hasMore, err := r.pageRemoteReferences(ctx, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, cursor, limit-len(locations))
+			remoteLocations, hasMore, err := r.pageRemoteReferences(ctx, source, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, cursor, limit-len(locations))
 			if err != nil {
 				return nil, false, err
 			}
@@ -285,7 +311,7 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 // traversing the LSIF graph. The given cursor will be adjusted to reflect the offsets required to resolve
 // the next page of results. If there are no more pages left in the result set, a false-valued flag is
 // returned.
-func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads []adjustedUpload, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageLocalReferences(ctx context.Context, source locationSource, adjustedUploads []adjustedUpload, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	var allLocations []lsifstore.Location
 	for i := range adjustedUploads {
 		if len(allLocations) >= limit {
@@ -297,7 +323,7 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 			continue
 		}
 
-		locations, totalCount, err := r.lsifStore.References(
+		locations, totalCount, err := source.localLocations(
 			ctx,
 			adjustedUploads[i].Upload.ID,
 			adjustedUploads[i].AdjustedPathInBundle,
@@ -307,7 +333,7 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 			cursor.LocalOffset,
 		)
 		if err != nil {
-			return nil, false, errors.Wrap(err, "lsifstore.References")
+			return nil, false, errors.Wrap(err, source.localName)
 		}
 
 		cursor.LocalOffset += len(locations)
@@ -332,7 +358,7 @@ const maximumIndexesPerMonikerSearch = 50
 // performing a moniker search over a group of indexes. The given cursor will be adjusted to reflect the
 // offsets required to resolve the next page of results. If there are no more pages left in the result set,
 // a false-valued flag is returned.
-func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUploads []adjustedUpload, orderedMonikers []semantic.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageRemoteReferences(ctx context.Context, source locationSource, adjustedUploads []adjustedUpload, orderedMonikers []semantic.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	for len(cursor.BatchIDs) == 0 {
 		if cursor.RemoteBatchOffset < 0 {
 			// No more batches
@@ -364,7 +390,7 @@ func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUpload
 	}
 
 	// Perform the moniker search
-	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, "references", limit, cursor.RemoteOffset)
+	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, source.tableName, limit, cursor.RemoteOffset)
 	if err != nil {
 		return nil, false, err
 	}
@@ -447,11 +473,15 @@ func (r *queryResolver) uploadIDsWithReferences(ctx context.Context, orderedMoni
 	}()
 
 	ignoreIDsMap := map[int]struct{}{}
-	for id := range ignoreIDs {
+	for _, id := range ignoreIDs {
 		ignoreIDsMap[id] = struct{}{}
 	}
 
+	// The scanner yields uploads ranked by relevance (see dbstore.ReferenceIDsAndFilters). We keep
+	// the identifiers in the order in which they were first scanned so that the moniker search over
+	// this batch returns the locations of the most relevant uploads first.
 	filtered := map[int]struct{}{}
+	ids = make([]int, 0, limit)
 
 	for len(filtered) < limit {
 		packageReference, exists, err := scanner.Next()
@@ -484,16 +514,11 @@ func (r *queryResolver) uploadIDsWithReferences(ctx context.Context, orderedMoni
 		if ok {
 			// Imports at least one target identifier
 			filtered[packageReference.DumpID] = struct{}{}
+			ids = append(ids, packageReference.DumpID)
 		}
 	}
 
-	flattened := make([]int, 0, len(filtered))
-	for k := range filtered {
-		flattened = append(flattened, k)
-	}
-	sort.Ints(flattened)
-
-	return flattened, recordsScanned, totalCount, nil
+	return ids, recordsScanned, totalCount, nil
 }
 
 // testFilter returns true if the given  encoded bloom filter includes any of the given monikers.
@@ -512,17 +537,13 @@ func testFilter(filter []byte, orderedMonikers []semantic.QualifiedMonikerData)
 	return false, nil
 }
 
-// uploadsByIDs returns a slice of uploads with the given identifiers. This method will not return a
-// new upload record for a commit which is unknown to gitserver. The given upload map is used as a
-// caching mechanism - uploads present in the map are not fetched again from the database.
+// uploadsByIDs returns a slice of uploads with the given identifiers, in the same order. This method
+// will not return a new upload record for a commit which is unknown to gitserver. The given upload map
+// is used as a caching mechanism - uploads present in the map are not fetched again from the database.
 func (r *queryResolver) uploadsByIDs(ctx context.Context, ids []int, uploadsByIDs map[int]dbstore.Dump) ([]dbstore.Dump, error) {
 	missingIDs := make([]int, 0, len(ids))
-	existingUploads := make([]store.Dump, 0, len(ids))
-
 	for _, id := range ids {
-		if upload, ok := uploadsByIDs[id]; ok {
-			existingUploads = append(existingUploads, upload)
-		} else {
+		if _, ok := uploadsByIDs[id]; !ok {
 			missingIDs = append(missingIDs, id)
 		}
 	}
@@ -537,5 +558,19 @@ func (r *queryResolver) uploadsByIDs(ctx context.Context, ids []int, uploadsByID
 		return nil, nil
 	}
 
-	return append(existingUploads, newUploads...), nil
+	newUploadsByID := make(map[int]store.Dump, len(newUploads))
+	for _, upload := range newUploads {
+		newUploadsByID[upload.ID] = upload
+	}
+
+	allUploads := make([]store.Dump, 0, len(ids))
+	for _, id := range ids {
+		if upload, ok := uploadsByIDs[id]; ok {
+			allUploads = append(allUploads, upload)
+		} else if upload, ok := newUploadsByID[id]; ok {
+			allUploads = append(allUploads, upload)
+		}
+	}
+
+	return allUploads, nil
 }
@@ -236,3 +236,58 @@ func TestReferencesRemote(t *testing.T) {
 		}
 	}
 }
+
+func TestUploadIDsWithReferencesRankOrder(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+
+	filter, err := bloomfilter.CreateFilter([]string{"padLeft"})
+	if err != nil {
+		t.Fatalf("unexpected error encoding bloom filter: %s", err)
+	}
+	otherFilter, err := bloomfilter.CreateFilter([]string{"padRight"})
+	if err != nil {
+		t.Fatalf("unexpected error encoding bloom filter: %s", err)
+	}
+
+	// Ranked by dependency graph distance rather than by identifier
+	mockDBStore.ReferenceIDsAndFiltersFunc.PushReturn(dbstore.PackageReferenceScannerFromSlice(
+		lsifstore.PackageReference{Package: lsifstore.Package{DumpID: 253}, Filter: filter},
+		lsifstore.PackageReference{Package: lsifstore.Package{DumpID: 250}, Filter: filter},
+		lsifstore.PackageReference{Package: lsifstore.Package{DumpID: 253}, Filter: filter},
+		lsifstore.PackageReference{Package: lsifstore.Package{DumpID: 252}, Filter: filter},
+		lsifstore.PackageReference{Package: lsifstore.Package{DumpID: 254}, Filter: otherFilter},
+		lsifstore.PackageReference{Package: lsifstore.Package{DumpID: 251}, Filter: filter},
+	), 6, nil)
+
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		noopPositionAdjuster(),
+		42,
+		"deadbeef",
+		"s1/main.go",
+		nil,
+		newOperations(&observation.TestContext),
+	)
+
+	monikers := []semantic.QualifiedMonikerData{
+		{MonikerData: semantic.MonikerData{Scheme: "tsc", Identifier: "padLeft"}},
+	}
+
+	ids, recordsScanned, totalCount, err := resolver.uploadIDsWithReferences(context.Background(), monikers, []int{252}, 50, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]int{253, 250, 251}, ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+	if recordsScanned != 6 {
+		t.Errorf("unexpected records scanned. want=%d have=%d", 6, recordsScanned)
+	}
+	if totalCount != 6 {
+		t.Errorf("unexpected total count. want=%d have=%d", 6, totalCount)
+	}
+}
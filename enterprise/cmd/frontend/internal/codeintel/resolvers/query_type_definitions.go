@@ -0,0 +1,107 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const slowTypeDefinitionsRequestThreshold = time.Second
+
+// TypeDefinitions returns the list of source locations that define the type of the symbol at the given position.
+func (r *queryResolver) TypeDefinitions(ctx context.Context, line, character int) (_ []AdjustedLocation, err error) {
+	ctx, traceLog, endObservation := observeResolver(ctx, &err, "TypeDefinitions", r.operations.typeDefinitions, slowTypeDefinitionsRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	// Adjust the path and position for each visible upload based on its git difference to
+	// the target commit.
+
+	adjustedUploads, err := r.adjustUploads(ctx, line, character)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range adjustedUploads {
+		traceLog(log.Int("uploadID", adjustedUploads[i].Upload.ID))
+
+		locations, _, err := r.lsifStore.TypeDefinitions(
+			ctx,
+			adjustedUploads[i].Upload.ID,
+			adjustedUploads[i].AdjustedPathInBundle,
+			adjustedUploads[i].AdjustedPosition.Line,
+			adjustedUploads[i].AdjustedPosition.Character,
+			DefinitionsLimit,
+			0,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.TypeDefinitions")
+		}
+		if len(locations) == 0 {
+			continue
+		}
+
+		// The type may be declared in a dependency, in which case the type definition result
+		// contains ranges referring to the imported type. Gather the import monikers attached
+		// to these ranges and search for their definitions in the providing indexes.
+
+		orderedMonikers, err := r.orderedMonikers(ctx, locationsAsAdjustedUploads(adjustedUploads[i].Upload, locations), "import")
+		if err != nil {
+			return nil, err
+		}
+		traceLog(
+			log.Int("numMonikers", len(orderedMonikers)),
+			log.String("monikers", monikersToString(orderedMonikers)),
+		)
+
+		if len(orderedMonikers) > 0 {
+			adjustedLocations, err := r.remoteDefinitions(ctx, traceLog, orderedMonikers)
+			if err != nil {
+				return nil, err
+			}
+			if len(adjustedLocations) > 0 {
+				return adjustedLocations, nil
+			}
+		}
+
+		uploadsByID := map[int]dbstore.Dump{
+			adjustedUploads[i].Upload.ID: adjustedUploads[i].Upload,
+		}
+
+		// If we have a local type definition, we won't find a better one and can exit early
+		return r.adjustLocations(ctx, uploadsByID, locations)
+	}
+
+	return nil, nil
+}
+
+// locationsAsAdjustedUploads returns an adjusted upload for the start of each of the given locations
+// within the given upload. This allows querying the monikers attached to these locations.
+func locationsAsAdjustedUploads(upload dbstore.Dump, locations []lsifstore.Location) []adjustedUpload {
+	adjustedUploads := make([]adjustedUpload, 0, len(locations))
+	for _, location := range locations {
+		adjustedUploads = append(adjustedUploads, adjustedUpload{
+			Upload:               upload,
+			AdjustedPath:         upload.Root + location.Path,
+			AdjustedPosition:     location.Range.Start,
+			AdjustedPathInBundle: location.Path,
+		})
+	}
+
+	return adjustedUploads
+}
@@ -0,0 +1,116 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestTypeDefinitions(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	locations := []lsifstore.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+	}
+	mockLSIFStore.TypeDefinitionsFunc.PushReturn(nil, 0, nil)
+	mockLSIFStore.TypeDefinitionsFunc.PushReturn(locations, len(locations), nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedLocations, err := resolver.TypeDefinitions(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestTypeDefinitionsRemote(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	localLocations := []lsifstore.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+	}
+	mockLSIFStore.TypeDefinitionsFunc.PushReturn(localLocations, len(localLocations), nil)
+
+	remoteUploads := []dbstore.Dump{
+		{ID: 151, Commit: "deadbeef2", Root: "sub2/"},
+	}
+	mockDBStore.DefinitionDumpsFunc.PushReturn(remoteUploads, nil)
+	mockGitserverClient.CommitExistsFunc.SetDefaultReturn(true, nil)
+
+	moniker := semantic.MonikerData{Kind: "import", Scheme: "gomod", Identifier: "pkg.Type", PackageInformationID: "51"}
+	mockLSIFStore.MonikersByPositionFunc.PushReturn([][]semantic.MonikerData{{moniker}}, nil)
+
+	packageInformation := semantic.PackageInformationData{Name: "pkg", Version: "0.1.0"}
+	mockLSIFStore.PackageInformationFunc.PushReturn(packageInformation, true, nil)
+
+	remoteLocations := []lsifstore.Location{
+		{DumpID: 151, Path: "types.go", Range: testRange3},
+	}
+	mockLSIFStore.BulkMonikerResultsFunc.PushReturn(remoteLocations, len(remoteLocations), nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 51, Commit: "deadbeef", Root: "sub1/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedLocations, err := resolver.TypeDefinitions(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: remoteUploads[0], Path: "sub2/types.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange3},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.MonikersByPositionFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.MonikersByPosition. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg2 != "a.go" || history[0].Arg3 != testRange1.Start.Line {
+		t.Errorf("unexpected moniker position. want=%s:%d have=%s:%d", "a.go", testRange1.Start.Line, history[0].Arg2, history[0].Arg3)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
@@ -33,12 +34,23 @@ type Resolver interface {
 	CommitGraph(ctx context.Context, repositoryID int) (gql.CodeIntelligenceCommitGraphResolver, error)
 	QueueAutoIndexJobForRepo(ctx context.Context, repositoryID int) error
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
+	SearchBasedQueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
+	RetentionPolicies(ctx context.Context) ([]store.RetentionPolicy, error)
+	RetentionPolicyByID(ctx context.Context, id int) (store.RetentionPolicy, bool, error)
+	CreateRetentionPolicy(ctx context.Context, policy store.RetentionPolicy) (store.RetentionPolicy, error)
+	UpdateRetentionPolicy(ctx context.Context, policy store.RetentionPolicy) (store.RetentionPolicy, bool, error)
+	DeleteRetentionPolicyByID(ctx context.Context, id int) (bool, error)
+	UploadRetention(ctx context.Context, upload store.Upload) (policies.Retention, bool, error)
+	Diagnostics(ctx context.Context, opts store.GetDiagnosticsOptions) ([]AdjustedDiagnostic, int, error)
+	PackageDependencies(ctx context.Context, opts PackageDependenciesOptions) ([]store.PackageDependency, int, error)
+	PackageDependents(ctx context.Context, opts PackageDependentsOptions) ([]store.PackageDependent, int, error)
 }
 
 type resolver struct {
 	dbStore         DBStore
 	lsifStore       LSIFStore
 	gitserverClient GitserverClient
+	searchClient    SearchClient
 	indexEnqueuer   IndexEnqueuer
 	hunkCache       HunkCache
 	operations      *operations
@@ -49,17 +61,19 @@ func NewResolver(
 	dbStore DBStore,
 	lsifStore LSIFStore,
 	gitserverClient GitserverClient,
+	searchClient SearchClient,
 	indexEnqueuer IndexEnqueuer,
 	hunkCache HunkCache,
 	observationContext *observation.Context,
 ) Resolver {
-	return newResolver(dbStore, lsifStore, gitserverClient, indexEnqueuer, hunkCache, observationContext)
+	return newResolver(dbStore, lsifStore, gitserverClient, searchClient, indexEnqueuer, hunkCache, observationContext)
 }
 
 func newResolver(
 	dbStore DBStore,
 	lsifStore LSIFStore,
 	gitserverClient GitserverClient,
+	searchClient SearchClient,
 	indexEnqueuer IndexEnqueuer,
 	hunkCache HunkCache,
 	observationContext *observation.Context,
@@ -68,6 +82,7 @@ func newResolver(
 		dbStore:         dbStore,
 		lsifStore:       lsifStore,
 		gitserverClient: gitserverClient,
+		searchClient:    searchClient,
 		indexEnqueuer:   indexEnqueuer,
 		hunkCache:       hunkCache,
 		operations:      newOperations(observationContext),
@@ -200,3 +215,25 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 		r.operations,
 	), nil
 }
+
+// SearchBasedQueryResolver constructs a query resolver that answers definition and reference
+// queries for the given repository, commit, and path with search-based heuristics. This is
+// used when no upload can answer precise queries for the path. This method returns a nil
+// resolver if the language of the path is not recognized.
+func (r *resolver) SearchBasedQueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error) {
+	language, ok := languageSpecForPath(args.Path)
+	if !ok || r.searchClient == nil {
+		return nil, nil
+	}
+
+	return newSearchBasedQueryResolver(
+		r.gitserverClient,
+		r.searchClient,
+		int(args.Repo.ID),
+		string(args.Repo.Name),
+		string(args.Commit),
+		args.Path,
+		language,
+		r.operations,
+	), nil
+}
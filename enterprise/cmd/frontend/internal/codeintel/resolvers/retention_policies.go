@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+func (r *resolver) RetentionPolicies(ctx context.Context) ([]store.RetentionPolicy, error) {
+	return r.dbStore.GetRetentionPolicies(ctx)
+}
+
+func (r *resolver) RetentionPolicyByID(ctx context.Context, id int) (store.RetentionPolicy, bool, error) {
+	return r.dbStore.GetRetentionPolicyByID(ctx, id)
+}
+
+func (r *resolver) CreateRetentionPolicy(ctx context.Context, policy store.RetentionPolicy) (store.RetentionPolicy, error) {
+	if err := policies.ValidatePolicy(policy); err != nil {
+		return store.RetentionPolicy{}, err
+	}
+
+	return r.dbStore.CreateRetentionPolicy(ctx, policy)
+}
+
+func (r *resolver) UpdateRetentionPolicy(ctx context.Context, policy store.RetentionPolicy) (store.RetentionPolicy, bool, error) {
+	if err := policies.ValidatePolicy(policy); err != nil {
+		return store.RetentionPolicy{}, false, err
+	}
+
+	return r.dbStore.UpdateRetentionPolicy(ctx, policy, time.Now())
+}
+
+func (r *resolver) DeleteRetentionPolicyByID(ctx context.Context, id int) (bool, error) {
+	return r.dbStore.DeleteRetentionPolicyByID(ctx, id)
+}
+
+// UploadRetention evaluates the current retention policies against the given upload. This is
+// the same evaluation performed periodically by the janitor, which uses the result to decide
+// whether or not the upload can be expired. A false-valued flag is returned if no retention
+// policy applies to the upload's repository.
+func (r *resolver) UploadRetention(ctx context.Context, upload store.Upload) (policies.Retention, bool, error) {
+	retentionPolicies, err := r.dbStore.GetRetentionPolicies(ctx)
+	if err != nil {
+		return policies.Retention{}, false, err
+	}
+
+	retention, governed, err := policies.Evaluate(ctx, r.gitserverClient, retentionPolicies, upload.RepositoryID, upload.RepositoryName, []store.Upload{upload}, time.Now())
+	if err != nil || !governed {
+		return policies.Retention{}, false, err
+	}
+
+	return retention[upload.ID], true, nil
+}
@@ -0,0 +1,294 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/opentracing/opentracing-go/log"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// SearchBasedReferencesLimit is the maximum number of reference locations that are gathered
+// by a single search-based references request. Pages of references are taken from this set.
+const SearchBasedReferencesLimit = 500
+
+const slowSearchBasedRequestThreshold = 2 * time.Second
+
+// ErrIllegalSearchBasedCursor occurs when a search-based references cursor cannot be decoded.
+var ErrIllegalSearchBasedCursor = errors.New("illegal search-based references cursor")
+
+// searchBasedQueryResolver answers definition and reference queries for a path without any
+// visible LSIF upload. Locations are found by searching for the identifier under the cursor:
+// definitions through the symbols service and references through a text search, both
+// restricted to files of the same language. The results are imprecise; all other queries
+// return empty results.
+//
+// Only the repository and commit of the path are searched. Unlike precise results, the
+// fallback doesn't find definitions in dependencies or references from other repositories.
+type searchBasedQueryResolver struct {
+	gitserverClient GitserverClient
+	searchClient    SearchClient
+	repositoryID    int
+	repositoryName  string
+	commit          string
+	path            string
+	language        languageSpec
+	operations      *operations
+}
+
+func newSearchBasedQueryResolver(
+	gitserverClient GitserverClient,
+	searchClient SearchClient,
+	repositoryID int,
+	repositoryName string,
+	commit string,
+	path string,
+	language languageSpec,
+	operations *operations,
+) *searchBasedQueryResolver {
+	return &searchBasedQueryResolver{
+		gitserverClient: gitserverClient,
+		searchClient:    searchClient,
+		repositoryID:    repositoryID,
+		repositoryName:  repositoryName,
+		commit:          commit,
+		path:            path,
+		language:        language,
+		operations:      operations,
+	}
+}
+
+// Definitions returns the locations of symbols in the same repository and language whose name
+// matches the identifier at the given position. Definitions within the same file are preferred.
+func (r *searchBasedQueryResolver) Definitions(ctx context.Context, line, character int) (_ []AdjustedLocation, err error) {
+	ctx, traceLog, endObservation := observeResolver(ctx, &err, "SearchBasedDefinitions", r.operations.searchBasedDefinitions, slowSearchBasedRequestThreshold, r.observationArgs(line, character))
+	defer endObservation()
+
+	identifier, ok, err := r.identifierAtPosition(ctx, line, character)
+	if err != nil || !ok {
+		return nil, err
+	}
+	traceLog(log.String("identifier", identifier))
+
+	matches, err := r.searchClient.Search(ctx, r.makeQuery("type:symbol", "^"+regexp.QuoteMeta(identifier)+"$", DefinitionsLimit))
+	if err != nil {
+		return nil, errors.Wrap(err, "searchClient.Search")
+	}
+
+	var locations, localLocations []AdjustedLocation
+	for _, match := range matches {
+		fileMatch, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		for _, symbolMatch := range fileMatch.Symbols {
+			if symbolMatch.Symbol.Name != identifier {
+				continue
+			}
+
+			rng := symbolMatch.Symbol.Range()
+			location := r.makeLocation(fileMatch.Path, rng.Start.Line, rng.Start.Character, rng.End.Character)
+
+			locations = append(locations, location)
+			if fileMatch.Path == r.path {
+				localLocations = append(localLocations, location)
+			}
+		}
+	}
+	traceLog(log.Int("numLocations", len(locations)), log.Int("numLocalLocations", len(localLocations)))
+
+	if len(localLocations) > 0 {
+		locations = localLocations
+	}
+	sortAdjustedLocations(locations)
+
+	if len(locations) > DefinitionsLimit {
+		locations = locations[:DefinitionsLimit]
+	}
+
+	return locations, nil
+}
+
+// References returns the occurrences of the identifier at the given position as a whole word
+// within files of the same repository and language. Pages of results are indexed by an offset
+// encoded in the cursor.
+func (r *searchBasedQueryResolver) References(ctx context.Context, line, character, limit int, rawCursor string) (_ []AdjustedLocation, _ string, err error) {
+	ctx, traceLog, endObservation := observeResolver(ctx, &err, "SearchBasedReferences", r.operations.searchBasedReferences, slowSearchBasedRequestThreshold, r.observationArgs(line, character))
+	defer endObservation()
+
+	offset := 0
+	if rawCursor != "" {
+		if offset, err = strconv.Atoi(rawCursor); err != nil || offset < 0 {
+			return nil, "", ErrIllegalSearchBasedCursor
+		}
+	}
+
+	identifier, ok, err := r.identifierAtPosition(ctx, line, character)
+	if err != nil || !ok {
+		return nil, "", err
+	}
+	traceLog(log.String("identifier", identifier))
+
+	matches, err := r.searchClient.Search(ctx, r.makeQuery("type:file", `\b`+regexp.QuoteMeta(identifier)+`\b`, SearchBasedReferencesLimit))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "searchClient.Search")
+	}
+
+	var locations []AdjustedLocation
+	for _, match := range matches {
+		fileMatch, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		for _, lineMatch := range fileMatch.LineMatches {
+			for _, offsetAndLength := range lineMatch.OffsetAndLengths {
+				start := int(offsetAndLength[0])
+				locations = append(locations, r.makeLocation(fileMatch.Path, int(lineMatch.LineNumber), start, start+int(offsetAndLength[1])))
+			}
+		}
+	}
+	traceLog(log.Int("numLocations", len(locations)))
+
+	sortAdjustedLocations(locations)
+	if len(locations) > SearchBasedReferencesLimit {
+		locations = locations[:SearchBasedReferencesLimit]
+	}
+
+	if offset >= len(locations) {
+		return nil, "", nil
+	}
+	locations = locations[offset:]
+
+	nextCursor := ""
+	if len(locations) > limit {
+		locations = locations[:limit]
+		nextCursor = strconv.Itoa(offset + limit)
+	}
+
+	return locations, nextCursor, nil
+}
+
+func (r *searchBasedQueryResolver) Ranges(ctx context.Context, startLine, endLine int) ([]AdjustedCodeIntelligenceRange, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return nil, "", nil
+}
+
+func (r *searchBasedQueryResolver) TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) Hover(ctx context.Context, line, character int) (string, lsifstore.Range, bool, error) {
+	return "", lsifstore.Range{}, false, nil
+}
+
+func (r *searchBasedQueryResolver) Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error) {
+	return nil, 0, nil
+}
+
+func (r *searchBasedQueryResolver) DocumentationPage(ctx context.Context, pathID string) (*semantic.DocumentationPageData, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) DocumentationPathInfo(ctx context.Context, pathID string) (*semantic.DocumentationPathInfoData, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) Documentation(ctx context.Context, line int, character int) ([]*Documentation, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) DocumentationDefinitions(ctx context.Context, pathID string) ([]AdjustedLocation, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) DocumentationReferences(ctx context.Context, pathID string, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return nil, "", nil
+}
+
+// identifierAtPosition reads the target file and returns the identifier at the given position.
+func (r *searchBasedQueryResolver) identifierAtPosition(ctx context.Context, line, character int) (string, bool, error) {
+	contents, err := r.gitserverClient.RawContents(ctx, r.repositoryID, r.commit, r.path)
+	if err != nil {
+		return "", false, errors.Wrap(err, "gitserverClient.RawContents")
+	}
+
+	identifier, ok := identifierAtPosition(r.language, contents, line, character)
+	return identifier, ok, nil
+}
+
+// makeQuery creates a search query of the given type for the given regular expression pattern,
+// restricted to files of the target language in the target repository and commit.
+func (r *searchBasedQueryResolver) makeQuery(typ, pattern string, count int) string {
+	terms := []string{
+		fmt.Sprintf("repo:^%s$@%s", regexp.QuoteMeta(r.repositoryName), r.commit),
+		typ,
+		"patternType:regexp",
+		"case:yes",
+		fmt.Sprintf("count:%d", count),
+	}
+	if filter := r.language.fileFilter(); filter != "" {
+		terms = append(terms, filter)
+	}
+
+	return strings.Join(append(terms, pattern), " ")
+}
+
+// makeLocation creates a location within the target repository and commit. The location's
+// dump carries only the fields used to resolve the repository and commit.
+func (r *searchBasedQueryResolver) makeLocation(path string, line, startCharacter, endCharacter int) AdjustedLocation {
+	return AdjustedLocation{
+		Dump:           store.Dump{RepositoryID: r.repositoryID, RepositoryName: r.repositoryName, Commit: r.commit},
+		Path:           path,
+		AdjustedCommit: r.commit,
+		AdjustedRange: lsifstore.Range{
+			Start: lsifstore.Position{Line: line, Character: startCharacter},
+			End:   lsifstore.Position{Line: line, Character: endCharacter},
+		},
+	}
+}
+
+func (r *searchBasedQueryResolver) observationArgs(line, character int) observation.Args {
+	return observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.String("language", r.language.name),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	}
+}
+
+// sortAdjustedLocations sorts the given locations by path and position so that pages of
+// search-based results are stable between requests.
+func sortAdjustedLocations(locations []AdjustedLocation) {
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Path != locations[j].Path {
+			return locations[i].Path < locations[j].Path
+		}
+
+		ri, rj := locations[i].AdjustedRange.Start, locations[j].AdjustedRange.Start
+		if ri.Line != rj.Line {
+			return ri.Line < rj.Line
+		}
+
+		return ri.Character < rj.Character
+	})
+}
@@ -0,0 +1,98 @@
+package resolvers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// languageSpec describes how identifiers are recognized for a family of source files
+// that share a symbol namespace. Files with any of the given extensions are searched
+// together when looking for definitions and references.
+type languageSpec struct {
+	name       string
+	extensions []string
+
+	// identCharPattern matches a single character that may occur within an identifier.
+	identCharPattern *regexp.Regexp
+}
+
+var defaultIdentCharPattern = regexp.MustCompile(`[\p{L}\p{N}_]`)
+
+var languageSpecs = []languageSpec{
+	{name: "c", extensions: []string{"c", "h", "cc", "cpp", "cxx", "hh", "hpp", "hxx", "m", "mm"}},
+	{name: "csharp", extensions: []string{"cs"}},
+	{name: "go", extensions: []string{"go"}},
+	{name: "java", extensions: []string{"java"}},
+	{name: "kotlin", extensions: []string{"kt", "kts"}},
+	{name: "php", extensions: []string{"php"}, identCharPattern: regexp.MustCompile(`[\p{L}\p{N}_$]`)},
+	{name: "python", extensions: []string{"py", "pyi"}},
+	{name: "ruby", extensions: []string{"rb"}, identCharPattern: regexp.MustCompile(`[\p{L}\p{N}_!?]`)},
+	{name: "rust", extensions: []string{"rs"}},
+	{name: "scala", extensions: []string{"scala", "sc", "sbt"}},
+	{name: "swift", extensions: []string{"swift"}},
+	{name: "typescript", extensions: []string{"ts", "tsx", "js", "jsx", "mjs", "cjs"}, identCharPattern: regexp.MustCompile(`[\p{L}\p{N}_$]`)},
+}
+
+// languageSpecForPath returns the language spec matching the extension of the given path.
+func languageSpecForPath(path string) (languageSpec, bool) {
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	for _, spec := range languageSpecs {
+		for _, candidate := range spec.extensions {
+			if candidate == extension {
+				return spec, true
+			}
+		}
+	}
+
+	return languageSpec{}, false
+}
+
+// fileFilter returns a search query file filter matching the extensions of this language.
+// An unknown language (the zero value) does not restrict the set of searched files.
+func (s languageSpec) fileFilter() string {
+	if len(s.extensions) == 0 {
+		return ""
+	}
+
+	return `file:\.(` + strings.Join(s.extensions, "|") + `)$`
+}
+
+func (s languageSpec) isIdentChar(r rune) bool {
+	pattern := s.identCharPattern
+	if pattern == nil {
+		pattern = defaultIdentCharPattern
+	}
+
+	return pattern.MatchString(string(r))
+}
+
+// identifierAtPosition returns the identifier enclosing the given zero-based line and
+// character (not byte) offset of the given file contents. Identifiers beginning with a
+// digit are ignored.
+func identifierAtPosition(spec languageSpec, contents []byte, line, character int) (string, bool) {
+	lines := strings.Split(string(contents), "\n")
+	if line < 0 || line >= len(lines) {
+		return "", false
+	}
+
+	runes := []rune(strings.TrimSuffix(lines[line], "\r"))
+	if character < 0 || character > len(runes) {
+		return "", false
+	}
+
+	start := character
+	for start > 0 && spec.isIdentChar(runes[start-1]) {
+		start--
+	}
+	end := character
+	for end < len(runes) && spec.isIdentChar(runes[end]) {
+		end++
+	}
+
+	if start == end || (runes[start] >= '0' && runes[start] <= '9') {
+		return "", false
+	}
+
+	return string(runes[start:end]), true
+}
@@ -0,0 +1,256 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const searchBasedTestSource = `package foo
+
+func (s *Server) Handle(req Request) {
+	s.handler.Handle(req)
+}
+`
+
+func TestSearchBasedQueryResolver(t *testing.T) {
+	mockSearchClient := NewMockSearchClient()
+	resolver := NewResolver(NewMockDBStore(), NewMockLSIFStore(), NewMockGitserverClient(), mockSearchClient, nil, nil, &observation.TestContext)
+
+	queryResolver, err := resolver.SearchBasedQueryResolver(context.Background(), &gql.GitBlobLSIFDataArgs{
+		Repo:   &types.Repo{ID: 50, Name: "github.com/test/repo"},
+		Commit: api.CommitID("deadbeef"),
+		Path:   "foo/server.go",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if queryResolver == nil {
+		t.Fatalf("expected a resolver")
+	}
+
+	queryResolver, err = resolver.SearchBasedQueryResolver(context.Background(), &gql.GitBlobLSIFDataArgs{
+		Repo:   &types.Repo{ID: 50, Name: "github.com/test/repo"},
+		Commit: api.CommitID("deadbeef"),
+		Path:   "README.md",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if queryResolver != nil {
+		t.Errorf("expected nil-valued resolver for unsupported language")
+	}
+}
+
+func TestSearchBasedDefinitions(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(searchBasedTestSource), nil)
+
+	mockSearchClient := NewMockSearchClient()
+	mockSearchClient.SearchFunc.SetDefaultHook(func(ctx context.Context, query string) ([]result.Match, error) {
+		return []result.Match{
+			symbolFileMatch("bar/client.go", result.Symbol{Name: "Handle", Line: 10, Pattern: `/^func (c *Client) Handle() {$/`}),
+			symbolFileMatch("foo/server.go", result.Symbol{Name: "Handle", Line: 3, Pattern: `/^func (s *Server) Handle(req Request) {$/`}),
+		}, nil
+	})
+
+	resolver := newSearchBasedQueryResolver(mockGitserverClient, mockSearchClient, 50, "github.com/test/repo", "deadbeef", "foo/server.go", goLanguageSpec(), newOperations(&observation.TestContext))
+	locations, err := resolver.Definitions(context.Background(), 2, 19)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		searchBasedLocation("foo/server.go", 2, 17, 23),
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockGitserverClient.RawContentsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of RawContents calls. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1 != 50 || history[0].Arg2 != "deadbeef" || history[0].Arg3 != "foo/server.go" {
+		t.Errorf("unexpected RawContents args %v", history[0].Args())
+	}
+
+	expectedQuery := `repo:^github\.com/test/repo$@deadbeef type:symbol patternType:regexp case:yes count:100 file:\.(go)$ ^Handle$`
+	if history := mockSearchClient.SearchFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of Search calls. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1 != expectedQuery {
+		t.Errorf("unexpected query. want=%q have=%q", expectedQuery, history[0].Arg1)
+	}
+}
+
+func TestSearchBasedDefinitionsNoIdentifier(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(searchBasedTestSource), nil)
+	mockSearchClient := NewMockSearchClient()
+
+	resolver := newSearchBasedQueryResolver(mockGitserverClient, mockSearchClient, 50, "github.com/test/repo", "deadbeef", "foo/server.go", goLanguageSpec(), newOperations(&observation.TestContext))
+	locations, err := resolver.Definitions(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(locations) != 0 {
+		t.Errorf("unexpected locations %v", locations)
+	}
+	if history := mockSearchClient.SearchFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected number of Search calls. want=%d have=%d", 0, len(history))
+	}
+}
+
+func TestSearchBasedReferences(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(searchBasedTestSource), nil)
+
+	mockSearchClient := NewMockSearchClient()
+	mockSearchClient.SearchFunc.SetDefaultHook(func(ctx context.Context, query string) ([]result.Match, error) {
+		return []result.Match{
+			&result.FileMatch{
+				File: result.File{Path: "foo/server.go"},
+				LineMatches: []*result.LineMatch{
+					{LineNumber: 3, OffsetAndLengths: [][2]int32{{11, 6}}},
+					{LineNumber: 2, OffsetAndLengths: [][2]int32{{17, 6}}},
+				},
+			},
+			&result.FileMatch{
+				File: result.File{Path: "bar/client.go"},
+				LineMatches: []*result.LineMatch{
+					{LineNumber: 7, OffsetAndLengths: [][2]int32{{1, 6}, {20, 6}}},
+				},
+			},
+		}, nil
+	})
+
+	resolver := newSearchBasedQueryResolver(mockGitserverClient, mockSearchClient, 50, "github.com/test/repo", "deadbeef", "foo/server.go", goLanguageSpec(), newOperations(&observation.TestContext))
+
+	locations, cursor, err := resolver.References(context.Background(), 3, 12, 3, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedLocations := []AdjustedLocation{
+		searchBasedLocation("bar/client.go", 7, 1, 7),
+		searchBasedLocation("bar/client.go", 7, 20, 26),
+		searchBasedLocation("foo/server.go", 2, 17, 23),
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor != "3" {
+		t.Errorf("unexpected cursor. want=%q have=%q", "3", cursor)
+	}
+
+	locations, cursor, err = resolver.References(context.Background(), 3, 12, 3, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedLocations = []AdjustedLocation{
+		searchBasedLocation("foo/server.go", 3, 11, 17),
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor != "" {
+		t.Errorf("unexpected cursor. want=%q have=%q", "", cursor)
+	}
+
+	expectedQuery := `repo:^github\.com/test/repo$@deadbeef type:file patternType:regexp case:yes count:500 file:\.(go)$ \bHandle\b`
+	if history := mockSearchClient.SearchFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected number of Search calls. want=%d have=%d", 2, len(history))
+	} else if history[0].Arg1 != expectedQuery {
+		t.Errorf("unexpected query. want=%q have=%q", expectedQuery, history[0].Arg1)
+	}
+
+	if _, _, err := resolver.References(context.Background(), 3, 12, 3, "-1"); err != ErrIllegalSearchBasedCursor {
+		t.Errorf("unexpected error. want=%q have=%q", ErrIllegalSearchBasedCursor, err)
+	}
+}
+
+func TestIdentifierAtPosition(t *testing.T) {
+	goSpec, _ := languageSpecForPath("main.go")
+	tsSpec, _ := languageSpecForPath("index.tsx")
+
+	testCases := []struct {
+		spec       languageSpec
+		contents   string
+		line       int
+		character  int
+		identifier string
+	}{
+		{goSpec, "foo.Bar(baz)", 0, 0, "foo"},
+		{goSpec, "foo.Bar(baz)", 0, 3, "foo"},
+		{goSpec, "foo.Bar(baz)", 0, 5, "Bar"},
+		{goSpec, "foo.Bar(baz)", 0, 11, "baz"},
+		{goSpec, "x := 1234", 0, 6, ""},
+		{goSpec, "a\r\nbc_d\r\n", 1, 2, "bc_d"},
+		{goSpec, "héllo wörld", 0, 8, "wörld"},
+		{goSpec, "foo", 3, 0, ""},
+		{tsSpec, "const $el = $('a')", 0, 7, "$el"},
+		{goSpec, "const $el = $('a')", 0, 7, "el"},
+	}
+
+	for _, testCase := range testCases {
+		name := fmt.Sprintf("%s@%d:%d", testCase.contents, testCase.line, testCase.character)
+
+		t.Run(name, func(t *testing.T) {
+			identifier, ok := identifierAtPosition(testCase.spec, []byte(testCase.contents), testCase.line, testCase.character)
+			if identifier != testCase.identifier || ok != (testCase.identifier != "") {
+				t.Errorf("unexpected identifier. want=%q have=%q (%v)", testCase.identifier, identifier, ok)
+			}
+		})
+	}
+}
+
+func TestLanguageSpecForPath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		language string
+	}{
+		{"main.go", "go"},
+		{"src/App.TSX", "typescript"},
+		{"lib/util.js", "typescript"},
+		{"include/foo.hpp", "c"},
+		{"README.md", ""},
+		{"Makefile", ""},
+	}
+
+	for _, testCase := range testCases {
+		spec, _ := languageSpecForPath(testCase.path)
+		if spec.name != testCase.language {
+			t.Errorf("unexpected language for %s. want=%q have=%q", testCase.path, testCase.language, spec.name)
+		}
+	}
+}
+
+func goLanguageSpec() languageSpec {
+	spec, _ := languageSpecForPath("main.go")
+	return spec
+}
+
+func symbolFileMatch(path string, symbol result.Symbol) *result.FileMatch {
+	fileMatch := &result.FileMatch{File: result.File{Path: path}}
+	fileMatch.Symbols = []*result.SymbolMatch{{Symbol: symbol, File: &fileMatch.File}}
+	return fileMatch
+}
+
+func searchBasedLocation(path string, line, startCharacter, endCharacter int) AdjustedLocation {
+	return AdjustedLocation{
+		Dump:           dbstore.Dump{RepositoryID: 50, RepositoryName: "github.com/test/repo", Commit: "deadbeef"},
+		Path:           path,
+		AdjustedCommit: "deadbeef",
+		AdjustedRange: lsifstore.Range{
+			Start: lsifstore.Position{Line: line, Character: startCharacter},
+			End:   lsifstore.Position{Line: line, Character: endCharacter},
+		},
+	}
+}
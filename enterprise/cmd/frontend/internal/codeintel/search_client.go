@@ -0,0 +1,29 @@
+package codeintel
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// searchClient runs search queries on behalf of the search-based code intelligence fallback.
+// Queries run as the actor of the given context, so results respect repository permissions.
+type searchClient struct {
+	db dbutil.DB
+}
+
+func (c *searchClient) Search(ctx context.Context, query string) ([]result.Match, error) {
+	search, err := gql.NewSearchImplementer(ctx, c.db, &gql.SearchArgs{Version: "V2", Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := search.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return results.Matches, nil
+}
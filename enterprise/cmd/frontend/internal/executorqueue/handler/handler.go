@@ -21,8 +21,9 @@ type QueueOptions struct {
 	Store store.Store
 
 	// RecordTransformer is a required hook for each registered queue that transforms a generic
-	// record from that queue into the job to be given to an executor.
-	RecordTransformer func(ctx context.Context, record workerutil.Record) (apiclient.Job, error)
+	// record from that queue into the job to be given to an executor. The version of src-cli
+	// reported by the executor is empty if the executor didn't report one.
+	RecordTransformer func(ctx context.Context, record workerutil.Record, srcCLIVersion string) (apiclient.Job, error)
 }
 
 func newHandler(queueOptions QueueOptions) *handler {
@@ -36,7 +37,7 @@ var ErrUnknownJob = errors.New("unknown job")
 // dequeue selects a job record from the database and stashes metadata including
 // the job record and the locking transaction. If no job is available for processing,
 // a false-valued flag is returned.
-func (h *handler) dequeue(ctx context.Context, executorName, executorHostname string) (_ apiclient.Job, dequeued bool, _ error) {
+func (h *handler) dequeue(ctx context.Context, executorName, executorHostname, srcCLIVersion string) (_ apiclient.Job, dequeued bool, _ error) {
 	// We explicitly DON'T want to use executorHostname here, it is NOT guaranteed to be unique.
 	record, dequeued, err := h.Store.Dequeue(ctx, executorName, nil)
 	if err != nil {
@@ -46,7 +47,7 @@ func (h *handler) dequeue(ctx context.Context, executorName, executorHostname st
 		return apiclient.Job{}, false, nil
 	}
 
-	job, err := h.RecordTransformer(ctx, record)
+	job, err := h.RecordTransformer(ctx, record, srcCLIVersion)
 	if err != nil {
 		if _, err := h.Store.MarkFailed(ctx, record.RecordID(), fmt.Sprintf("failed to transform record: %s", err), store.MarkFinalOptions{}); err != nil {
 			log15.Error("Failed to mark record as failed", "recordID", record.RecordID(), "error", err)
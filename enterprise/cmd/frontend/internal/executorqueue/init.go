@@ -59,8 +59,9 @@ func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigrat
 
 	// Register queues.
 	queueOptions := map[string]handler.QueueOptions{
-		"codeintel": codeintelqueue.QueueOptions(db, codeintelConfig, observationContext),
-		"batches":   batches.QueueOptions(db, batchesConfig, observationContext),
+		"codeintel":             codeintelqueue.QueueOptions(db, codeintelConfig, observationContext),
+		"batches":               batches.QueueOptions(db, batchesConfig, observationContext),
+		"batch-spec-workspaces": batches.WorkspaceQueueOptions(db, batchesConfig, observationContext),
 	}
 
 	handler, err := codeintel.NewCodeIntelUploadHandler(ctx, db, true)
@@ -9,6 +9,14 @@ type Config struct {
 	env.BaseConfig
 
 	Shared *config.SharedConfig
+
+	// SingleJob makes executors run `src batch preview` for the whole batch spec
+	// in a single job, instead of resolving its workspaces and executing each of
+	// them in a separate job. Executors that don't report a src-cli version that
+	// supports workspace jobs always run a single job.
+	SingleJob bool
 }
 
-func (c *Config) Load() {}
+func (c *Config) Load() {
+	c.SingleJob = c.GetBool("EXECUTOR_QUEUE_BATCHES_SINGLE_JOB", "false", "Execute batch specs in a single `src batch preview` job rather than one job per workspace, even on executors with src-cli 3.31.0 or later.")
+}
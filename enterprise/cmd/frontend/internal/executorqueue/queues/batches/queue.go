@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/handler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -14,9 +15,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
+// QueueOptions returns the options of the queue from which executors dequeue
+// batch spec executions to resolve their workspaces.
 func QueueOptions(db dbutil.DB, config *Config, observationContext *observation.Context) handler.QueueOptions {
-	recordTransformer := func(ctx context.Context, record workerutil.Record) (apiclient.Job, error) {
-		return transformRecord(ctx, db, record.(*btypes.BatchSpecExecution), config)
+	recordTransformer := func(ctx context.Context, record workerutil.Record, srcCLIVersion string) (apiclient.Job, error) {
+		return transformRecord(ctx, db, record.(*btypes.BatchSpecExecution), srcCLIVersion, config)
 	}
 
 	return handler.QueueOptions{
@@ -24,3 +27,18 @@ func QueueOptions(db dbutil.DB, config *Config, observationContext *observation.
 		RecordTransformer: recordTransformer,
 	}
 }
+
+// WorkspaceQueueOptions returns the options of the queue from which executors
+// dequeue the batch spec workspace execution jobs.
+func WorkspaceQueueOptions(db dbutil.DB, config *Config, observationContext *observation.Context) handler.QueueOptions {
+	batchesStore := store.New(db, nil)
+
+	recordTransformer := func(ctx context.Context, record workerutil.Record, srcCLIVersion string) (apiclient.Job, error) {
+		return transformWorkspaceRecord(ctx, batchesStore, record.(*btypes.BatchSpecWorkspaceExecutionJob), srcCLIVersion, config)
+	}
+
+	return handler.QueueOptions{
+		Store:             background.NewWorkspaceExecutorStore(basestore.NewWithDB(db, sql.TxOptions{}), observationContext),
+		RecordTransformer: recordTransformer,
+	}
+}
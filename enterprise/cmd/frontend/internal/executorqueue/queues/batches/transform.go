@@ -2,60 +2,61 @@ package batches
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
 
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
-// transformRecord transforms a *btypes.BatchSpecExecution into an apiclient.Job.
-func transformRecord(ctx context.Context, db dbutil.DB, exec *btypes.BatchSpecExecution, config *Config) (apiclient.Job, error) {
-	// TODO: createAccessToken is a bit of technical debt until we figure out a
-	// better solution. The problem is that src-cli needs to make requests to
-	// the Sourcegraph instance *on behalf of the user*.
-	//
-	// Ideally we'd have something like one-time tokens that
-	// * we could hand to src-cli
-	// * are not visible to the user in the Sourcegraph web UI
-	// * valid only for the duration of the batch spec execution
-	// * and cleaned up after batch spec is executed
-	//
-	// Until then we create a fresh access token every time.
-	//
-	// GetOrCreate doesn't work because once an access token has been created
-	// in the database Sourcegraph can't access the plain-text token anymore.
-	// Only a hash for verification is kept in the database.
-	token, err := createAccessToken(ctx, db, exec.UserID)
+// transformRecord transforms a *btypes.BatchSpecExecution into an apiclient.Job
+// that resolves the workspaces of the batch spec. If the executor reported a
+// src-cli version that doesn't support workspace jobs, or if config.SingleJob
+// is set, the job executes the whole batch spec instead.
+func transformRecord(ctx context.Context, db dbutil.DB, exec *btypes.BatchSpecExecution, srcCLIVersion string, config *Config) (apiclient.Job, error) {
+	cliEnv, redactedValues, err := makeSrcCLIEnv(ctx, db, exec.UserID, config)
 	if err != nil {
 		return apiclient.Job{}, err
 	}
 
-	frontendURL := conf.Get().ExternalURL
-
-	srcEndpoint, err := makeURL(frontendURL, config.Shared.FrontendUsername, config.Shared.FrontendPassword)
-	if err != nil {
-		return apiclient.Job{}, err
-	}
-
-	redactedSrcEndpoint, err := makeURL(frontendURL, "USERNAME_REMOVED", "PASSWORD_REMOVED")
-	if err != nil {
-		return apiclient.Job{}, err
+	if config.SingleJob || !supportsWorkspaceJobs(srcCLIVersion) {
+		return transformSingleJobRecord(ctx, db, exec, cliEnv, redactedValues)
 	}
 
-	cliEnv := []string{
-		fmt.Sprintf("SRC_ENDPOINT=%s", srcEndpoint),
-		fmt.Sprintf("SRC_ACCESS_TOKEN=%s", token),
-
-		// TODO: This is wrong here, it should be set on the executor machine
-		fmt.Sprintf("HOME=%s", os.Getenv("HOME")),
-		fmt.Sprintf("PATH=%s", os.Getenv("PATH")),
-	}
+	return apiclient.Job{
+		ID:                  int(exec.ID),
+		VirtualMachineFiles: map[string]string{"spec.yml": exec.BatchSpec},
+		CliSteps: []apiclient.CliStep{
+			{
+				Commands: []string{
+					"batch",
+					"workspaces",
+					"-f", "spec.yml",
+					"-text-only",
+				},
+				Dir: ".",
+				Env: cliEnv,
+			},
+		},
+		RedactedValues: redactedValues,
+	}, nil
+}
 
+// transformSingleJobRecord transforms a *btypes.BatchSpecExecution into an
+// apiclient.Job that executes the whole batch spec and creates the batch spec
+// in the namespace of the execution.
+func transformSingleJobRecord(ctx context.Context, db dbutil.DB, exec *btypes.BatchSpecExecution, cliEnv []string, redactedValues map[string]string) (apiclient.Job, error) {
 	var namespaceName string
 	if exec.NamespaceUserID != 0 {
 		user, err := database.Users(db).GetByID(ctx, exec.NamespaceUserID)
@@ -88,26 +89,168 @@ func transformRecord(ctx context.Context, db dbutil.DB, exec *btypes.BatchSpecEx
 				Env: cliEnv,
 			},
 		},
-		RedactedValues: map[string]string{
-			// 🚨 SECURITY: Catch leak of upload endpoint. This is necessary in addition
-			// to the below in case the username or password contains illegal URL characters,
-			// which are then urlencoded and are not replaceable via byte comparison.
-			srcEndpoint: redactedSrcEndpoint,
-
-			// 🚨 SECURITY: Catch uses of fragments pulled from URL to construct another target
-			// (in src-cli). We only pass the constructed URL to src-cli, which we trust not to
-			// ship the values to a third party, but not to trust to ensure the values are absent
-			// from the command's stdout or stderr streams.
-			config.Shared.FrontendUsername: "USERNAME_REMOVED",
-			config.Shared.FrontendPassword: "PASSWORD_REMOVED",
-
-			// 🚨 SECURITY: Redact the access token used for src-cli to talk to
-			// Sourcegraph instance.
-			token: "SRC_ACCESS_TOKEN_REMOVED",
+		RedactedValues: redactedValues,
+	}, nil
+}
+
+// workspace is the input of `src batch exec`. It describes the workspace in
+// which the steps of the batch spec are executed.
+type workspace struct {
+	Repository struct {
+		ID   graphql.ID `json:"id"`
+		Name string     `json:"name"`
+	} `json:"repository"`
+	Branch             string   `json:"branch"`
+	Commit             string   `json:"commit"`
+	Path               string   `json:"path"`
+	OnlyFetchWorkspace bool     `json:"onlyFetchWorkspace"`
+	FileMatches        []string `json:"fileMatches"`
+}
+
+// transformWorkspaceRecord transforms a *btypes.BatchSpecWorkspaceExecutionJob
+// into an apiclient.Job that executes the steps of the batch spec in the
+// workspace and uploads the resulting changeset specs. If the user has a
+// cached result for the workspace, it is passed to src-cli so that the steps
+// don't need to be executed again.
+func transformWorkspaceRecord(ctx context.Context, s *store.Store, job *btypes.BatchSpecWorkspaceExecutionJob, srcCLIVersion string, config *Config) (apiclient.Job, error) {
+	if !supportsWorkspaceJobs(srcCLIVersion) {
+		return apiclient.Job{}, errors.Errorf("executor src-cli version %q does not support workspace jobs, %s or later is required", srcCLIVersion, workspaceJobsMinimumSrcCLIVersion)
+	}
+
+	exec, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: job.BatchSpecExecutionID})
+	if err != nil {
+		return apiclient.Job{}, err
+	}
+
+	repo, err := s.Repos().Get(actor.WithInternalActor(ctx), job.RepoID)
+	if err != nil {
+		return apiclient.Job{}, err
+	}
+
+	w := workspace{
+		Branch:             job.Branch,
+		Commit:             job.Commit,
+		Path:               job.Path,
+		OnlyFetchWorkspace: job.OnlyFetchWorkspace,
+		FileMatches:        job.FileMatches,
+	}
+	w.Repository.ID = graphqlbackend.MarshalRepositoryID(repo.ID)
+	w.Repository.Name = string(repo.Name)
+
+	marshaledWorkspace, err := json.Marshal(&w)
+	if err != nil {
+		return apiclient.Job{}, err
+	}
+
+	files := map[string]string{
+		"spec.yml":       exec.BatchSpec,
+		"workspace.json": string(marshaledWorkspace),
+	}
+	commands := []string{
+		"batch",
+		"exec",
+		"-f", "spec.yml",
+		"-workspace", "workspace.json",
+		"-text-only",
+	}
+
+	entry, err := s.GetBatchSpecExecutionCacheEntry(ctx, store.GetBatchSpecExecutionCacheEntryOpts{
+		UserID: exec.UserID,
+		Key:    job.CacheKey,
+	})
+	if err != nil && err != store.ErrNoResults {
+		return apiclient.Job{}, err
+	}
+	if entry != nil {
+		files["cache.json"] = entry.Value
+		commands = append(commands, "-cache", "cache.json")
+	}
+
+	cliEnv, redactedValues, err := makeSrcCLIEnv(ctx, s.DB(), exec.UserID, config)
+	if err != nil {
+		return apiclient.Job{}, err
+	}
+
+	return apiclient.Job{
+		ID:                  int(job.ID),
+		VirtualMachineFiles: files,
+		CliSteps: []apiclient.CliStep{
+			{
+				Commands: commands,
+				Dir:      ".",
+				Env:      cliEnv,
+			},
 		},
+		RedactedValues: redactedValues,
 	}, nil
 }
 
+// makeSrcCLIEnv returns the environment in which src-cli talks to the
+// Sourcegraph instance on behalf of the given user, and the values that need
+// to be redacted from the output of the job.
+func makeSrcCLIEnv(ctx context.Context, db dbutil.DB, userID int32, config *Config) ([]string, map[string]string, error) {
+	// TODO: createAccessToken is a bit of technical debt until we figure out a
+	// better solution. The problem is that src-cli needs to make requests to
+	// the Sourcegraph instance *on behalf of the user*.
+	//
+	// Ideally we'd have something like one-time tokens that
+	// * we could hand to src-cli
+	// * are not visible to the user in the Sourcegraph web UI
+	// * valid only for the duration of the batch spec execution
+	// * and cleaned up after batch spec is executed
+	//
+	// Until then we create a fresh access token every time.
+	//
+	// GetOrCreate doesn't work because once an access token has been created
+	// in the database Sourcegraph can't access the plain-text token anymore.
+	// Only a hash for verification is kept in the database.
+	token, err := createAccessToken(ctx, db, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	frontendURL := conf.Get().ExternalURL
+
+	srcEndpoint, err := makeURL(frontendURL, config.Shared.FrontendUsername, config.Shared.FrontendPassword)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redactedSrcEndpoint, err := makeURL(frontendURL, "USERNAME_REMOVED", "PASSWORD_REMOVED")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cliEnv := []string{
+		fmt.Sprintf("SRC_ENDPOINT=%s", srcEndpoint),
+		fmt.Sprintf("SRC_ACCESS_TOKEN=%s", token),
+
+		// TODO: This is wrong here, it should be set on the executor machine
+		fmt.Sprintf("HOME=%s", os.Getenv("HOME")),
+		fmt.Sprintf("PATH=%s", os.Getenv("PATH")),
+	}
+
+	redactedValues := map[string]string{
+		// 🚨 SECURITY: Catch leak of upload endpoint. This is necessary in addition
+		// to the below in case the username or password contains illegal URL characters,
+		// which are then urlencoded and are not replaceable via byte comparison.
+		srcEndpoint: redactedSrcEndpoint,
+
+		// 🚨 SECURITY: Catch uses of fragments pulled from URL to construct another target
+		// (in src-cli). We only pass the constructed URL to src-cli, which we trust not to
+		// ship the values to a third party, but not to trust to ensure the values are absent
+		// from the command's stdout or stderr streams.
+		config.Shared.FrontendUsername: "USERNAME_REMOVED",
+		config.Shared.FrontendPassword: "PASSWORD_REMOVED",
+
+		// 🚨 SECURITY: Redact the access token used for src-cli to talk to
+		// Sourcegraph instance.
+		token: "SRC_ACCESS_TOKEN_REMOVED",
+	}
+
+	return cliEnv, redactedValues, nil
+}
+
 const (
 	accessTokenNote  = "batch-spec-execution"
 	accessTokenScope = "user:all"
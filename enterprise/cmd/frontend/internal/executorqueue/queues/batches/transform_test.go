@@ -50,16 +50,7 @@ func TestTransformRecord(t *testing.T) {
 		},
 	}
 
-	database.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
-		return &types.User{
-			Username: "john_namespace",
-		}, nil
-	}
-	t.Cleanup(func() {
-		database.Mocks.Users.GetByID = nil
-	})
-
-	job, err := transformRecord(context.Background(), &dbtesting.MockDB{}, index, config)
+	job, err := transformRecord(context.Background(), &dbtesting.MockDB{}, index, "3.31.0", config)
 	if err != nil {
 		t.Fatalf("unexpected error transforming record: %s", err)
 	}
@@ -70,11 +61,9 @@ func TestTransformRecord(t *testing.T) {
 		CliSteps: []apiclient.CliStep{
 			{
 				Commands: []string{
-					"batch", "preview",
+					"batch", "workspaces",
 					"-f", "spec.yml",
 					"-text-only",
-					"-skip-errors",
-					"-n", "john_namespace",
 				},
 				Dir: ".",
 				Env: []string{
@@ -95,4 +84,69 @@ func TestTransformRecord(t *testing.T) {
 	if diff := cmp.Diff(expected, job); diff != "" {
 		t.Errorf("unexpected job (-want +got):\n%s", diff)
 	}
+
+	t.Run("single job", func(t *testing.T) {
+		database.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+			return &types.User{
+				Username: "john_namespace",
+			}, nil
+		}
+		t.Cleanup(func() {
+			database.Mocks.Users.GetByID = nil
+		})
+
+		singleJobConfig := *config
+		singleJobConfig.SingleJob = true
+
+		expected.CliSteps[0].Commands = []string{
+			"batch", "preview",
+			"-f", "spec.yml",
+			"-text-only",
+			"-skip-errors",
+			"-n", "john_namespace",
+		}
+
+		for _, tc := range []struct {
+			name          string
+			srcCLIVersion string
+			config        *Config
+		}{
+			{name: "configured", srcCLIVersion: "3.31.0", config: &singleJobConfig},
+			{name: "old src-cli", srcCLIVersion: "3.30.4", config: config},
+			{name: "unknown src-cli", srcCLIVersion: "", config: config},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				job, err := transformRecord(context.Background(), &dbtesting.MockDB{}, index, tc.srcCLIVersion, tc.config)
+				if err != nil {
+					t.Fatalf("unexpected error transforming record: %s", err)
+				}
+				if diff := cmp.Diff(expected, job); diff != "" {
+					t.Errorf("unexpected job (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+}
+
+func TestSupportsWorkspaceJobs(t *testing.T) {
+	for version, want := range map[string]bool{
+		"":              false,
+		"dev":           false,
+		"3.30.4":        false,
+		"3.31.0":        true,
+		"3.31.1-rc.1":   true,
+		"4.0.0":         true,
+		"0.0.0+dev.foo": false,
+	} {
+		if have := supportsWorkspaceJobs(version); have != want {
+			t.Errorf("supportsWorkspaceJobs(%q): want=%t, have=%t", version, want, have)
+		}
+	}
+}
+
+func TestTransformWorkspaceRecordOldSrcCLI(t *testing.T) {
+	job := &btypes.BatchSpecWorkspaceExecutionJob{ID: 42, BatchSpecExecutionID: 1}
+	if _, err := transformWorkspaceRecord(context.Background(), nil, job, "3.30.4", &Config{}); err == nil {
+		t.Fatal("expected error but got none")
+	}
 }
@@ -0,0 +1,21 @@
+package batches
+
+import "github.com/Masterminds/semver"
+
+// workspaceJobsMinimumSrcCLIVersion is the first version of src-cli that
+// supports `src batch workspaces` and `src batch exec`. It is independent of
+// srccli.MinimumVersion, which is the version suggested to users of the
+// instance.
+var workspaceJobsMinimumSrcCLIVersion = semver.MustParse("3.31.0")
+
+// supportsWorkspaceJobs returns whether an executor that reported the given
+// src-cli version can run the jobs that resolve and execute the workspaces of
+// a batch spec separately. Executors that didn't report a version, or that
+// reported one that can't be parsed, are assumed to be too old.
+func supportsWorkspaceJobs(srcCLIVersion string) bool {
+	v, err := semver.NewVersion(srcCLIVersion)
+	if err != nil {
+		return false
+	}
+	return !v.LessThan(workspaceJobsMinimumSrcCLIVersion)
+}
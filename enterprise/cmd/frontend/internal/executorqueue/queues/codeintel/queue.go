@@ -14,7 +14,7 @@ import (
 )
 
 func QueueOptions(db dbutil.DB, config *Config, observationContext *observation.Context) handler.QueueOptions {
-	recordTransformer := func(ctx context.Context, record workerutil.Record) (apiclient.Job, error) {
+	recordTransformer := func(ctx context.Context, record workerutil.Record, _ string) (apiclient.Job, error) {
 		return transformRecord(record.(store.Index), config)
 	}
 
@@ -97,15 +97,27 @@ func (h *handler) handle(ctx context.Context, upload store.Upload) (requeued boo
 		return directoryChildren, nil
 	}
 
+	if upload.BaseUploadID != nil {
+		if err := validateBaseUpload(ctx, h.dbStore, upload); err != nil {
+			return false, err
+		}
+	}
+
 	return false, withUploadData(ctx, h.uploadStore, upload.ID, func(r io.Reader) (err error) {
-		groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
-		if err != nil {
-			return errors.Wrap(err, "conversion.Correlate")
+		var groupedBundleData *semantic.GroupedBundleDataChans
+		if upload.BaseUploadID == nil {
+			if groupedBundleData, err = conversion.Correlate(ctx, r, upload.Root, getChildren); err != nil {
+				return errors.Wrap(err, "conversion.Correlate")
+			}
+		} else {
+			if groupedBundleData, err = conversion.CorrelateIncremental(ctx, r, upload.Root, getChildren, upload.ChangedPaths); err != nil {
+				return errors.Wrap(err, "conversion.CorrelateIncremental")
+			}
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
 		// different transaction context (managed by the writeData function).
-		if err := writeData(ctx, h.lsifStore, upload.ID, groupedBundleData); err != nil {
+		if err := writeData(ctx, h.lsifStore, upload, groupedBundleData); err != nil {
 			if isUniqueConstraintViolation(err) {
 				// If this is a unique constraint violation, then we've previously processed this same
 				// upload record up to this point, but failed to perform the transaction below. We can
@@ -141,6 +153,13 @@ func (h *handler) handle(ctx context.Context, upload store.Upload) (requeued boo
 				return errors.Wrap(err, "store.UpdatePackageReferences")
 			}
 
+			// Carry over the package data of the documents the incremental upload shares with its base.
+			if upload.BaseUploadID != nil {
+				if err := tx.CopyPackageData(ctx, *upload.BaseUploadID, upload.ID); err != nil {
+					return errors.Wrap(err, "store.CopyPackageData")
+				}
+			}
+
 			// Before we mark the upload as complete, we need to delete any existing completed uploads
 			// that have the same repository_id, commit, root, and indexer values. Otherwise the transaction
 			// will fail as these values form a unique constraint.
@@ -238,14 +257,38 @@ func withUploadData(ctx context.Context, uploadStore uploadstore.Store, id int,
 	return nil
 }
 
+// validateBaseUpload ensures that the base upload of the given incremental upload has been processed
+// successfully and indexes the same repository, root, and indexer as the incremental upload.
+func validateBaseUpload(ctx context.Context, dbStore DBStore, upload store.Upload) error {
+	dumps, err := dbStore.GetDumpsByIDs(ctx, []int{*upload.BaseUploadID})
+	if err != nil {
+		return errors.Wrap(err, "store.GetDumpsByIDs")
+	}
+
+	if len(dumps) == 0 || dumps[0].RepositoryID != upload.RepositoryID || dumps[0].Root != upload.Root || dumps[0].Indexer != upload.Indexer {
+		return errors.Errorf("base upload %d is not a completed upload of the same repository, root, and indexer; re-upload the full index", *upload.BaseUploadID)
+	}
+
+	return nil
+}
+
 // writeData transactionally writes the given grouped bundle data into the given LSIF store.
-func writeData(ctx context.Context, lsifStore LSIFStore, id int, groupedBundleData *semantic.GroupedBundleDataChans) (err error) {
+func writeData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, groupedBundleData *semantic.GroupedBundleDataChans) (err error) {
 	tx, err := lsifStore.Transact(ctx)
 	if err != nil {
 		return err
 	}
 	defer func() { err = tx.Done(err) }()
 
+	id := upload.ID
+	if upload.BaseUploadID != nil {
+		if err := tx.WriteIncrementalBundle(ctx, id, *upload.BaseUploadID, upload.ChangedPaths, groupedBundleData); err != nil {
+			return errors.Wrap(err, "store.WriteIncrementalBundle")
+		}
+
+		return nil
+	}
+
 	if err := tx.WriteMeta(ctx, id, groupedBundleData.Meta); err != nil {
 		return errors.Wrap(err, "store.WriteMeta")
 	}
@@ -261,6 +304,9 @@ func writeData(ctx context.Context, lsifStore LSIFStore, id int, groupedBundleDa
 	if err := tx.WriteReferences(ctx, id, groupedBundleData.References); err != nil {
 		return errors.Wrap(err, "store.WriteReferences")
 	}
+	if err := tx.WriteImplementations(ctx, id, groupedBundleData.Implementations); err != nil {
+		return errors.Wrap(err, "store.WriteImplementations")
+	}
 	if err := tx.WriteDocumentationPages(ctx, id, groupedBundleData.DocumentationPages); err != nil {
 		return errors.Wrap(err, "store.WriteDocumentationPages")
 	}
@@ -205,6 +205,121 @@ func TestHandleError(t *testing.T) {
 	}
 }
 
+func TestHandleIncremental(t *testing.T) {
+	setupRepoMocks(t)
+
+	baseUploadID := 41
+	upload := dbstore.Upload{
+		ID:           42,
+		Root:         "root/",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		BaseUploadID: &baseUploadID,
+		ChangedPaths: []string{"foo.go"},
+	}
+
+	mockWorkerStore := NewMockWorkerStore()
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+
+	// Set default transaction behavior
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockLSIFStore.TransactFunc.SetDefaultReturn(mockLSIFStore, nil)
+	mockLSIFStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Give correlation package a valid input dump
+	mockUploadStore.GetFunc.SetDefaultHook(copyTestDump)
+
+	// Allowlist all files in dump
+	gitserverClient.DirectoryChildrenFunc.SetDefaultReturn(map[string][]string{
+		"": {"foo.go", "bar.go"},
+	}, nil)
+
+	mockDBStore.GetDumpsByIDsFunc.SetDefaultReturn([]dbstore.Dump{
+		{ID: 41, Root: "root/", RepositoryID: 50, Indexer: "lsif-go"},
+	}, nil)
+
+	handler := &handler{
+		dbStore:         mockDBStore,
+		workerStore:     mockWorkerStore,
+		lsifStore:       mockLSIFStore,
+		uploadStore:     mockUploadStore,
+		gitserverClient: gitserverClient,
+	}
+
+	requeued, err := handler.handle(context.Background(), upload)
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if calls := mockLSIFStore.WriteIncrementalBundleFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of WriteIncrementalBundle calls. want=%d have=%d", 1, len(calls))
+	} else {
+		if calls[0].Arg1 != 42 || calls[0].Arg2 != 41 {
+			t.Errorf("unexpected bundle ids. want=%d,%d have=%d,%d", 42, 41, calls[0].Arg1, calls[0].Arg2)
+		}
+		if diff := cmp.Diff([]string{"foo.go"}, calls[0].Arg3); diff != "" {
+			t.Errorf("unexpected changed paths (-want +got):\n%s", diff)
+		}
+	}
+	if calls := mockLSIFStore.WriteDocumentsFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of WriteDocuments calls. want=%d have=%d", 0, len(calls))
+	}
+
+	if calls := mockDBStore.CopyPackageDataFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of CopyPackageData calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 41 || calls[0].Arg2 != 42 {
+		t.Errorf("unexpected dump ids. want=%d,%d have=%d,%d", 41, 42, calls[0].Arg1, calls[0].Arg2)
+	}
+}
+
+func TestHandleIncrementalMismatchedBase(t *testing.T) {
+	setupRepoMocks(t)
+
+	baseUploadID := 41
+	upload := dbstore.Upload{
+		ID:           42,
+		Root:         "root/",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		BaseUploadID: &baseUploadID,
+		ChangedPaths: []string{"foo.go"},
+	}
+
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+
+	mockDBStore.GetDumpsByIDsFunc.SetDefaultReturn([]dbstore.Dump{
+		{ID: 41, Root: "other/", RepositoryID: 50, Indexer: "lsif-go"},
+	}, nil)
+
+	handler := &handler{
+		dbStore:         mockDBStore,
+		workerStore:     NewMockWorkerStore(),
+		lsifStore:       mockLSIFStore,
+		uploadStore:     mockUploadStore,
+		gitserverClient: NewMockGitserverClient(),
+	}
+
+	if _, err := handler.handle(context.Background(), upload); err == nil {
+		t.Fatalf("expected error handling upload")
+	} else if !strings.Contains(err.Error(), "base upload 41") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockUploadStore.GetFunc.History()) != 0 {
+		t.Errorf("unexpected number of Get calls. want=%d have=%d", 0, len(mockUploadStore.GetFunc.History()))
+	}
+}
+
 func TestHandleCloneInProgress(t *testing.T) {
 	t.Cleanup(func() {
 		backend.Mocks.Repos.Get = nil
@@ -26,6 +26,8 @@ type DBStore interface {
 	DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) error
 	InsertDependencyIndexingJob(ctx context.Context, uploadID int) (int, error)
 	UpdateCommitedAt(ctx context.Context, dumpID int, committedAt time.Time) error
+	GetDumpsByIDs(ctx context.Context, ids []int) ([]dbstore.Dump, error)
+	CopyPackageData(ctx context.Context, sourceDumpID, targetDumpID int) error
 }
 
 type DBStoreShim struct {
@@ -54,9 +56,11 @@ type LSIFStore interface {
 	WriteResultChunks(ctx context.Context, bundleID int, resultChunks chan semantic.IndexedResultChunkData) error
 	WriteDefinitions(ctx context.Context, bundleID int, monikerLocations chan semantic.MonikerLocations) error
 	WriteReferences(ctx context.Context, bundleID int, monikerLocations chan semantic.MonikerLocations) error
+	WriteImplementations(ctx context.Context, bundleID int, monikerLocations chan semantic.MonikerLocations) error
 	WriteDocumentationPages(ctx context.Context, bundleID int, documentation chan *semantic.DocumentationPageData) error
 	WriteDocumentationPathInfo(ctx context.Context, bundleID int, documentation chan *semantic.DocumentationPathInfoData) error
 	WriteDocumentationMappings(ctx context.Context, bundleID int, mappings chan semantic.DocumentationMapping) error
+	WriteIncrementalBundle(ctx context.Context, bundleID, baseBundleID int, changedPaths []string, groupedBundleData *semantic.GroupedBundleDataChans) error
 }
 
 type LSIFStoreShim struct {
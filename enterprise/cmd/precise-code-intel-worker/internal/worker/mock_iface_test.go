@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	semantic "github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
@@ -17,12 +18,18 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker)
 // used for unit testing.
 type MockDBStore struct {
+	// CopyPackageDataFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyPackageData.
+	CopyPackageDataFunc *DBStoreCopyPackageDataFunc
 	// DeleteOverlappingDumpsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteOverlappingDumps.
 	DeleteOverlappingDumpsFunc *DBStoreDeleteOverlappingDumpsFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *DBStoreDoneFunc
+	// GetDumpsByIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDumpsByIDs.
+	GetDumpsByIDsFunc *DBStoreGetDumpsByIDsFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *DBStoreHandleFunc
@@ -57,6 +64,11 @@ type MockDBStore struct {
 // return zero values for all results, unless overwritten.
 func NewMockDBStore() *MockDBStore {
 	return &MockDBStore{
+		CopyPackageDataFunc: &DBStoreCopyPackageDataFunc{
+			defaultHook: func(context.Context, int, int) error {
+				return nil
+			},
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: func(context.Context, int, string, string, string) error {
 				return nil
@@ -67,6 +79,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		GetDumpsByIDsFunc: &DBStoreGetDumpsByIDsFunc{
+			defaultHook: func(context.Context, []int) ([]dbstore.Dump, error) {
+				return nil, nil
+			},
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				return nil
@@ -119,12 +136,18 @@ func NewMockDBStore() *MockDBStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	return &MockDBStore{
+		CopyPackageDataFunc: &DBStoreCopyPackageDataFunc{
+			defaultHook: i.CopyPackageData,
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: i.DeleteOverlappingDumps,
 		},
 		DoneFunc: &DBStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetDumpsByIDsFunc: &DBStoreGetDumpsByIDsFunc{
+			defaultHook: i.GetDumpsByIDs,
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
@@ -155,6 +178,115 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	}
 }
 
+// DBStoreCopyPackageDataFunc describes the behavior when the
+// CopyPackageData method of the parent MockDBStore instance is invoked.
+type DBStoreCopyPackageDataFunc struct {
+	defaultHook func(context.Context, int, int) error
+	hooks       []func(context.Context, int, int) error
+	history     []DBStoreCopyPackageDataFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyPackageData delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) CopyPackageData(v0 context.Context, v1 int, v2 int) error {
+	r0 := m.CopyPackageDataFunc.nextHook()(v0, v1, v2)
+	m.CopyPackageDataFunc.appendCall(DBStoreCopyPackageDataFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CopyPackageData
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreCopyPackageDataFunc) SetDefaultHook(hook func(context.Context, int, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyPackageData method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreCopyPackageDataFunc) PushHook(hook func(context.Context, int, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreCopyPackageDataFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreCopyPackageDataFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int) error {
+		return r0
+	})
+}
+
+func (f *DBStoreCopyPackageDataFunc) nextHook() func(context.Context, int, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreCopyPackageDataFunc) appendCall(r0 DBStoreCopyPackageDataFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreCopyPackageDataFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreCopyPackageDataFunc) History() []DBStoreCopyPackageDataFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreCopyPackageDataFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreCopyPackageDataFuncCall is an object that describes an invocation
+// of method CopyPackageData on an instance of MockDBStore.
+type DBStoreCopyPackageDataFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreCopyPackageDataFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreCopyPackageDataFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBStoreDeleteOverlappingDumpsFunc describes the behavior when the
 // DeleteOverlappingDumps method of the parent MockDBStore instance is
 // invoked.
@@ -374,6 +506,115 @@ func (c DBStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreGetDumpsByIDsFunc describes the behavior when the GetDumpsByIDs
+// method of the parent MockDBStore instance is invoked.
+type DBStoreGetDumpsByIDsFunc struct {
+	defaultHook func(context.Context, []int) ([]dbstore.Dump, error)
+	hooks       []func(context.Context, []int) ([]dbstore.Dump, error)
+	history     []DBStoreGetDumpsByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDumpsByIDs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) GetDumpsByIDs(v0 context.Context, v1 []int) ([]dbstore.Dump, error) {
+	r0, r1 := m.GetDumpsByIDsFunc.nextHook()(v0, v1)
+	m.GetDumpsByIDsFunc.appendCall(DBStoreGetDumpsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDumpsByIDs method
+// of the parent MockDBStore instance is invoked and the hook queue is
+// empty.
+func (f *DBStoreGetDumpsByIDsFunc) SetDefaultHook(hook func(context.Context, []int) ([]dbstore.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDumpsByIDs method of the parent MockDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([]dbstore.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreGetDumpsByIDsFunc) SetDefaultReturn(r0 []dbstore.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) ([]dbstore.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreGetDumpsByIDsFunc) PushReturn(r0 []dbstore.Dump, r1 error) {
+	f.PushHook(func(context.Context, []int) ([]dbstore.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]dbstore.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetDumpsByIDsFunc) appendCall(r0 DBStoreGetDumpsByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetDumpsByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetDumpsByIDsFunc) History() []DBStoreGetDumpsByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetDumpsByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetDumpsByIDsFuncCall is an object that describes an invocation of
+// method GetDumpsByIDs on an instance of MockDBStore.
+type DBStoreGetDumpsByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetDumpsByIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetDumpsByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreHandleFunc describes the behavior when the Handle method of the
 // parent MockDBStore instance is invoked.
 type DBStoreHandleFunc struct {
@@ -1764,6 +2005,12 @@ type MockLSIFStore struct {
 	// WriteDocumentsFunc is an instance of a mock function object
 	// controlling the behavior of the method WriteDocuments.
 	WriteDocumentsFunc *LSIFStoreWriteDocumentsFunc
+	// WriteImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method WriteImplementations.
+	WriteImplementationsFunc *LSIFStoreWriteImplementationsFunc
+	// WriteIncrementalBundleFunc is an instance of a mock function object
+	// controlling the behavior of the method WriteIncrementalBundle.
+	WriteIncrementalBundleFunc *LSIFStoreWriteIncrementalBundleFunc
 	// WriteMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method WriteMeta.
 	WriteMetaFunc *LSIFStoreWriteMetaFunc
@@ -1814,6 +2061,16 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil
 			},
 		},
+		WriteImplementationsFunc: &LSIFStoreWriteImplementationsFunc{
+			defaultHook: func(context.Context, int, chan semantic.MonikerLocations) error {
+				return nil
+			},
+		},
+		WriteIncrementalBundleFunc: &LSIFStoreWriteIncrementalBundleFunc{
+			defaultHook: func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error {
+				return nil
+			},
+		},
 		WriteMetaFunc: &LSIFStoreWriteMetaFunc{
 			defaultHook: func(context.Context, int, semantic.MetaData) error {
 				return nil
@@ -1857,6 +2114,12 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		WriteDocumentsFunc: &LSIFStoreWriteDocumentsFunc{
 			defaultHook: i.WriteDocuments,
 		},
+		WriteImplementationsFunc: &LSIFStoreWriteImplementationsFunc{
+			defaultHook: i.WriteImplementations,
+		},
+		WriteIncrementalBundleFunc: &LSIFStoreWriteIncrementalBundleFunc{
+			defaultHook: i.WriteIncrementalBundle,
+		},
 		WriteMetaFunc: &LSIFStoreWriteMetaFunc{
 			defaultHook: i.WriteMeta,
 		},
@@ -2630,6 +2893,235 @@ func (c LSIFStoreWriteDocumentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// LSIFStoreWriteImplementationsFunc describes the behavior when the
+// WriteImplementations method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreWriteImplementationsFunc struct {
+	defaultHook func(context.Context, int, chan semantic.MonikerLocations) error
+	hooks       []func(context.Context, int, chan semantic.MonikerLocations) error
+	history     []LSIFStoreWriteImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// WriteImplementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) WriteImplementations(v0 context.Context, v1 int, v2 chan semantic.MonikerLocations) error {
+	r0 := m.WriteImplementationsFunc.nextHook()(v0, v1, v2)
+	m.WriteImplementationsFunc.appendCall(LSIFStoreWriteImplementationsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WriteImplementations
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreWriteImplementationsFunc) SetDefaultHook(hook func(context.Context, int, chan semantic.MonikerLocations) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WriteImplementations method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreWriteImplementationsFunc) PushHook(hook func(context.Context, int, chan semantic.MonikerLocations) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreWriteImplementationsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, chan semantic.MonikerLocations) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreWriteImplementationsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, chan semantic.MonikerLocations) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreWriteImplementationsFunc) nextHook() func(context.Context, int, chan semantic.MonikerLocations) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreWriteImplementationsFunc) appendCall(r0 LSIFStoreWriteImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreWriteImplementationsFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreWriteImplementationsFunc) History() []LSIFStoreWriteImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreWriteImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreWriteImplementationsFuncCall is an object that describes an
+// invocation of method WriteImplementations on an instance of
+// MockLSIFStore.
+type LSIFStoreWriteImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 chan semantic.MonikerLocations
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreWriteImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreWriteImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreWriteIncrementalBundleFunc describes the behavior when the
+// WriteIncrementalBundle method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreWriteIncrementalBundleFunc struct {
+	defaultHook func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error
+	hooks       []func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error
+	history     []LSIFStoreWriteIncrementalBundleFuncCall
+	mutex       sync.Mutex
+}
+
+// WriteIncrementalBundle delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) WriteIncrementalBundle(v0 context.Context, v1 int, v2 int, v3 []string, v4 *semantic.GroupedBundleDataChans) error {
+	r0 := m.WriteIncrementalBundleFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.WriteIncrementalBundleFunc.appendCall(LSIFStoreWriteIncrementalBundleFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// WriteIncrementalBundle method of the parent MockLSIFStore instance is
+// invoked and the hook queue is empty.
+func (f *LSIFStoreWriteIncrementalBundleFunc) SetDefaultHook(hook func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WriteIncrementalBundle method of the parent MockLSIFStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LSIFStoreWriteIncrementalBundleFunc) PushHook(hook func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreWriteIncrementalBundleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreWriteIncrementalBundleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreWriteIncrementalBundleFunc) nextHook() func(context.Context, int, int, []string, *semantic.GroupedBundleDataChans) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreWriteIncrementalBundleFunc) appendCall(r0 LSIFStoreWriteIncrementalBundleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreWriteIncrementalBundleFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreWriteIncrementalBundleFunc) History() []LSIFStoreWriteIncrementalBundleFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreWriteIncrementalBundleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreWriteIncrementalBundleFuncCall is an object that describes an
+// invocation of method WriteIncrementalBundle on an instance of
+// MockLSIFStore.
+type LSIFStoreWriteIncrementalBundleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 *semantic.GroupedBundleDataChans
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreWriteIncrementalBundleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreWriteIncrementalBundleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // LSIFStoreWriteMetaFunc describes the behavior when the WriteMeta method
 // of the parent MockLSIFStore instance is invoked.
 type LSIFStoreWriteMetaFunc struct {
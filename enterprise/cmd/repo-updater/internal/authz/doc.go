@@ -12,7 +12,7 @@ package authz
 //	││         │      └─────┘    │            ││
 //	││         ▼                 ▼            ││
 //	││  ┌─────────────┐     ┌─────────┐       ││
-//	││  │ runSchedule │     │ runSync │       ││
+//	││  │ runSchedule │     │ worker  │       ││
 //	││  └─────────────┘     └─────────┘       ││
 //	││         │                 ▲            ││
 //	││          enqueue           dequeue     ││
@@ -33,3 +33,9 @@ package authz
 //	│              RPC handlers                │
 //	│            └ ─ ─ ─ ─ ─ ─ ─ ┘             │
 //	└──────────────────────────────────────────┘
+//
+// The queue is the "perms_sync_requests" database table, so that pending requests
+// survive restarts and can be processed by any repo-updater instance. Requests are
+// dequeued by a workerutil.Worker in order of priority (manual, webhook, then
+// background schedules), and requests of workers that stopped heartbeating are put
+// back into the queue by a dbworker.Resetter.
@@ -32,4 +32,16 @@ var (
 		Name: "src_repoupdater_perms_syncer_queue_size",
 		Help: "The size of the sync request queue",
 	})
+	metricsResets = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_perms_syncer_queue_resets_total",
+		Help: "Total number of sync requests put back into queued state",
+	})
+	metricsResetFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_perms_syncer_queue_max_resets_total",
+		Help: "Total number of sync requests that exceed the max number of resets",
+	})
+	metricsResetErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_perms_syncer_queue_reset_errors_total",
+		Help: "Total number of errors when running the sync request resetter",
+	})
 )
@@ -0,0 +1,90 @@
+package authz
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// newWorker returns the worker processing the permissions sync requests queued in
+// the database, and the resetter putting the requests of workers that stopped
+// heartbeating (e.g. a repo-updater instance that was restarted) back in the queue.
+func (s *PermsSyncer) newWorker(ctx context.Context) (*workerutil.Worker, *dbworker.Resetter) {
+	handle := basestore.NewHandleWithDB(s.permsStore.Handle().DB(), sql.TxOptions{
+		// Change the isolation level for every transaction created by the worker
+		// so that multiple workers can modify the same rows without conflicts.
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	store := dbworkerstore.New(handle, dbworkerstore.Options{
+		Name:              "perms_sync_worker_store",
+		TableName:         "perms_sync_requests",
+		ColumnExpressions: edb.PermsSyncRequestColumns,
+		Scan:              edb.ScanPermsSyncRequest,
+		// User-centric requests are usually triggered by user actions and are
+		// processed before repository-centric requests of the same priority.
+		OrderByExpression: sqlf.Sprintf("perms_sync_requests.priority DESC, perms_sync_requests.request_type = 'user' DESC, perms_sync_requests.queued_at, perms_sync_requests.id"),
+		StalledMaxAge:     time.Minute,
+		MaxNumResets:      5,
+		// Failed requests are not retried, the users and repositories are
+		// scheduled again by the background schedule.
+		MaxNumRetries: 0,
+	})
+
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	worker := dbworker.NewWorker(ctx, store, &permsSyncHandler{syncer: s}, workerutil.WorkerOptions{
+		Name:              "perms_sync_worker",
+		NumHandlers:       s.numHandlers,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationContext, "repo_updater_perms_syncer", nil),
+	})
+
+	resetter := dbworker.NewResetter(store, dbworker.ResetterOptions{
+		Name:     "perms_sync_worker_resetter",
+		Interval: time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			RecordResets:        metricsResets,
+			RecordResetFailures: metricsResetFailures,
+			Errors:              metricsResetErrors,
+		},
+	})
+
+	return worker, resetter
+}
+
+// permsSyncHandler processes the permissions sync requests dequeued by the worker.
+type permsSyncHandler struct {
+	syncer *PermsSyncer
+}
+
+var _ workerutil.Handler = &permsSyncHandler{}
+var _ workerutil.WithPreDequeue = &permsSyncHandler{}
+
+func (h *permsSyncHandler) Handle(ctx context.Context, record workerutil.Record) error {
+	return h.syncer.syncPerms(ctx, record.(*edb.PermsSyncRequest))
+}
+
+// PreDequeue leaves the requests in the queue while background permissions
+// syncing is disabled.
+func (h *permsSyncHandler) PreDequeue(ctx context.Context) (bool, interface{}, error) {
+	return !h.syncer.isDisabled(), nil, nil
+}
@@ -1,11 +1,11 @@
 package authz
 
 import (
-	"container/heap"
 	"context"
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/RoaringBitmap/roaring"
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/authz/idpgroups"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -25,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -34,8 +36,6 @@ import (
 //
 // It is meant to be running in the background.
 type PermsSyncer struct {
-	// The priority queue to maintain the permissions syncing requests.
-	queue *requestQueue
 	// The database interface for any repos and external services operations.
 	reposStore *repos.Store
 	// The database interface for any permissions operations.
@@ -46,6 +46,18 @@ type PermsSyncer struct {
 	rateLimiterRegistry *ratelimit.Registry
 	// The time duration of how often to re-compute schedule for users and repositories.
 	scheduleInterval time.Duration
+	// The maximum number of permissions syncing requests processed concurrently.
+	numHandlers int
+	// The maximum number of concurrent requests made to a single authz provider
+	// by this process.
+	providerConcurrency int
+
+	// The semaphores limiting the concurrent requests made to authz providers,
+	// keyed by ServiceID. They are local to this process: when several
+	// repo-updater instances process the queue, each of them may make up to
+	// providerConcurrency concurrent requests to the same provider.
+	providerSemaphoresMu sync.Mutex
+	providerSemaphores   map[string]chan struct{}
 }
 
 // NewPermsSyncer returns a new permissions syncing manager.
@@ -56,20 +68,23 @@ func NewPermsSyncer(
 	rateLimiterRegistry *ratelimit.Registry,
 ) *PermsSyncer {
 	return &PermsSyncer{
-		queue:               newRequestQueue(),
 		reposStore:          reposStore,
 		permsStore:          permsStore,
 		clock:               clock,
 		rateLimiterRegistry: rateLimiterRegistry,
 		scheduleInterval:    time.Minute,
+		numHandlers:         5,
+		providerConcurrency: 2,
+		providerSemaphores:  make(map[string]chan struct{}),
 	}
 }
 
 // ScheduleUsers schedules new permissions syncing requests for given users.
-// By design, all schedules triggered by user actions are in high priority.
+// The priority of the requests is determined by the reason, requests triggered
+// by user actions have the highest priority.
 //
 // This method implements the repoupdater.Server.PermsSyncer in the OSS namespace.
-func (s *PermsSyncer) ScheduleUsers(ctx context.Context, userIDs ...int32) {
+func (s *PermsSyncer) ScheduleUsers(ctx context.Context, reason protocol.PermsSyncReason, userIDs ...int32) {
 	if len(userIDs) == 0 {
 		return
 	} else if s.isDisabled() {
@@ -80,10 +95,8 @@ func (s *PermsSyncer) ScheduleUsers(ctx context.Context, userIDs ...int32) {
 	users := make([]scheduledUser, len(userIDs))
 	for i := range userIDs {
 		users[i] = scheduledUser{
-			priority: priorityHigh,
-			userID:   userIDs[i],
-			// NOTE: Have nextSyncAt with zero value (i.e. not set) gives it higher priority,
-			// as the request is most likely triggered by a user action from OSS namespace.
+			reason: edb.PermsSyncReason(reason),
+			userID: userIDs[i],
 		}
 	}
 
@@ -91,30 +104,19 @@ func (s *PermsSyncer) ScheduleUsers(ctx context.Context, userIDs ...int32) {
 }
 
 func (s *PermsSyncer) scheduleUsers(ctx context.Context, users ...scheduledUser) {
+	batches := newScheduleBatches()
 	for _, u := range users {
-		select {
-		case <-ctx.Done():
-			log15.Debug("PermsSyncer.scheduleUsers.canceled")
-			return
-		default:
-		}
-
-		updated := s.queue.enqueue(&requestMeta{
-			Priority:   u.priority,
-			Type:       requestTypeUser,
-			ID:         u.userID,
-			NextSyncAt: u.nextSyncAt,
-			NoPerms:    u.noPerms,
-		})
-		log15.Debug("PermsSyncer.queue.enqueued", "userID", u.userID, "updated", updated)
+		batches.add(u.reason, u.noPerms, u.userID)
 	}
+	s.enqueue(ctx, edb.PermsSyncJobTypeUser, batches)
 }
 
 // ScheduleRepos schedules new permissions syncing requests for given repositories.
-// By design, all schedules triggered by user actions are in high priority.
+// The priority of the requests is determined by the reason, requests triggered
+// by user actions have the highest priority.
 //
 // This method implements the repoupdater.Server.PermsSyncer in the OSS namespace.
-func (s *PermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID) {
+func (s *PermsSyncer) ScheduleRepos(ctx context.Context, reason protocol.PermsSyncReason, repoIDs ...api.RepoID) {
 	if len(repoIDs) == 0 {
 		return
 	} else if s.isDisabled() {
@@ -125,10 +127,8 @@ func (s *PermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
 	repos := make([]scheduledRepo, len(repoIDs))
 	for i := range repoIDs {
 		repos[i] = scheduledRepo{
-			priority: priorityHigh,
-			repoID:   repoIDs[i],
-			// NOTE: Have nextSyncAt with zero value (i.e. not set) gives it higher priority,
-			// as the request is most likely triggered by a user action from OSS namespace.
+			reason: edb.PermsSyncReason(reason),
+			repoID: repoIDs[i],
 		}
 	}
 
@@ -136,22 +136,55 @@ func (s *PermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
 }
 
 func (s *PermsSyncer) scheduleRepos(ctx context.Context, repos ...scheduledRepo) {
+	batches := newScheduleBatches()
 	for _, r := range repos {
-		select {
-		case <-ctx.Done():
-			log15.Debug("PermsSyncer.scheduleRepos.canceled")
-			return
-		default:
-		}
+		batches.add(r.reason, r.noPerms, int32(r.repoID))
+	}
+	s.enqueue(ctx, edb.PermsSyncJobTypeRepo, batches)
+}
+
+// scheduleBatchKey identifies a batch of requests that are enqueued together.
+type scheduleBatchKey struct {
+	reason  edb.PermsSyncReason
+	noPerms bool
+}
 
-		updated := s.queue.enqueue(&requestMeta{
-			Priority:   r.priority,
-			Type:       requestTypeRepo,
-			ID:         int32(r.repoID),
-			NextSyncAt: r.nextSyncAt,
-			NoPerms:    r.noPerms,
+// scheduleBatches groups the IDs of scheduled users or repositories by reason and
+// whether they have no permissions, preserving the order in which they were added.
+type scheduleBatches struct {
+	keys []scheduleBatchKey
+	ids  map[scheduleBatchKey][]int32
+}
+
+func newScheduleBatches() *scheduleBatches {
+	return &scheduleBatches{ids: make(map[scheduleBatchKey][]int32)}
+}
+
+func (b *scheduleBatches) add(reason edb.PermsSyncReason, noPerms bool, id int32) {
+	key := scheduleBatchKey{reason: reason, noPerms: noPerms}
+	if _, ok := b.ids[key]; !ok {
+		b.keys = append(b.keys, key)
+	}
+	b.ids[key] = append(b.ids[key], id)
+}
+
+// enqueue inserts the batches of requests of the given type into the database
+// queue, which is processed by the worker started in Run.
+func (s *PermsSyncer) enqueue(ctx context.Context, typ edb.PermsSyncJobType, batches *scheduleBatches) {
+	for _, key := range batches.keys {
+		ids := batches.ids[key]
+		err := s.permsStore.EnqueuePermsSyncRequests(ctx, edb.EnqueuePermsSyncRequestsOpts{
+			RequestType: typ,
+			IDs:         ids,
+			Priority:    key.reason.Priority(),
+			Reason:      key.reason,
+			NoPerms:     key.noPerms,
 		})
-		log15.Debug("PermsSyncer.queue.enqueued", "repoID", r.repoID, "updated", updated)
+		if err != nil {
+			log15.Error("Failed to enqueue permissions sync requests", "type", typ, "reason", key.reason, "count", len(ids), "error", err)
+			continue
+		}
+		log15.Debug("PermsSyncer.enqueued", "type", typ, "reason", key.reason, "ids", ids)
 	}
 }
 
@@ -166,6 +199,19 @@ func (s *PermsSyncer) providersByServiceID() map[string]authz.Provider {
 	return providers
 }
 
+// idpGroupsProviders returns the authz providers of identity provider groups,
+// which grant access to repositories of any code host.
+func (s *PermsSyncer) idpGroupsProviders() []*idpgroups.Provider {
+	_, ps := authz.GetProviders()
+	var providers []*idpgroups.Provider
+	for _, p := range ps {
+		if gp, ok := p.(*idpgroups.Provider); ok {
+			providers = append(providers, gp)
+		}
+	}
+	return providers
+}
+
 // providersByURNs returns a list of authz.Provider configured in the external services.
 // Keys are URN, e.g. "extsvc:github:1".
 func (s *PermsSyncer) providersByURNs() map[string]authz.Provider {
@@ -181,7 +227,14 @@ func (s *PermsSyncer) providersByURNs() map[string]authz.Provider {
 // the method will use partial results to update permissions tables even when error occurs.
 func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms bool) (err error) {
 	ctx, save := s.observe(ctx, "PermsSyncer.syncUserPerms", "")
-	defer save(requestTypeUser, userID, &err)
+	defer save(edb.PermsSyncJobTypeUser, userID, &err)
+
+	job := &edb.PermsSyncJob{
+		RequestType: edb.PermsSyncJobTypeUser,
+		UserID:      userID,
+		StartedAt:   s.clock(),
+	}
+	defer func() { s.recordSyncJob(ctx, job, err) }()
 
 	accounts := database.ExternalAccountsWith(s.reposStore)
 
@@ -273,6 +326,7 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 	}
 
 	var repoSpecs, includePrefixSpecs, excludePrefixSpecs []api.ExternalRepoSpec
+	var repoIDs []api.RepoID
 
 	for _, accountOrService := range accountsOrServices {
 		var extIDs *authz.ExternalUserPermissions
@@ -285,11 +339,16 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 				// We have no authz provider configured for this external account or service
 				continue
 			}
+			job.Providers = appendProvider(job.Providers, provider.ServiceID())
 
 			if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
 				return errors.Wrap(err, "wait for rate limiter")
 			}
+			if err := s.acquireProvider(ctx, provider.ServiceID()); err != nil {
+				return errors.Wrap(err, "wait for provider concurrency limit")
+			}
 			extIDs, err = provider.FetchUserPerms(ctx, v)
+			s.releaseProvider(provider.ServiceID())
 
 			if err != nil {
 				// The "401 Unauthorized" is returned by code hosts when the token is no longer valid
@@ -331,6 +390,7 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 				// We have no authz provider configured for this external service or service
 				continue
 			}
+			job.Providers = appendProvider(job.Providers, provider.ServiceID())
 			token, err := extsvc.ExtractToken(v.Config, v.Kind)
 			if err != nil {
 				log15.Warn("Extracting token from external service config", "error", err, "id", v.ID)
@@ -344,8 +404,12 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 			if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
 				return errors.Wrap(err, "wait for rate limiter")
 			}
+			if err := s.acquireProvider(ctx, provider.ServiceID()); err != nil {
+				return errors.Wrap(err, "wait for provider concurrency limit")
+			}
 
 			extIDs, err = provider.FetchUserPermsByToken(ctx, token)
+			s.releaseProvider(provider.ServiceID())
 			if err != nil {
 				log15.Warn("Fetching user permissions by token", "error", err)
 				continue
@@ -393,6 +457,7 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 				)
 			}
 		}
+		repoIDs = append(repoIDs, extIDs.RepoIDs...)
 	}
 
 	// Get corresponding internal database IDs
@@ -435,11 +500,26 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 	for i := range repoNames {
 		p.IDs.Add(uint32(repoNames[i].ID))
 	}
+	for _, id := range repoIDs {
+		p.IDs.Add(uint32(id))
+	}
+
+	// Load currently stored permissions to record the changes in the audit trail.
+	oldPerms := &authz.UserPermissions{
+		UserID: user.ID,
+		Perm:   p.Perm,
+		Type:   p.Type,
+	}
+	err = s.permsStore.LoadUserPermissions(ctx, oldPerms)
+	if err != nil && err != authz.ErrPermsNotFound {
+		return errors.Wrap(err, "load user permissions")
+	}
 
 	err = s.permsStore.SetUserPermissions(ctx, p)
 	if err != nil {
 		return errors.Wrap(err, "set user permissions")
 	}
+	job.AddedIDs, job.RemovedIDs = diffIDs(oldPerms.IDs, p.IDs)
 
 	log15.Debug("PermsSyncer.syncUserPerms.synced", "userID", user.ID)
 	return nil
@@ -450,7 +530,14 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 // tables even when error occurs.
 func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPerms bool) (err error) {
 	ctx, save := s.observe(ctx, "PermsSyncer.syncRepoPerms", "")
-	defer save(requestTypeRepo, int32(repoID), &err)
+	defer save(edb.PermsSyncJobTypeRepo, int32(repoID), &err)
+
+	job := &edb.PermsSyncJob{
+		RequestType: edb.PermsSyncJobTypeRepo,
+		RepoID:      int32(repoID),
+		StartedAt:   s.clock(),
+	}
+	defer func() { s.recordSyncJob(ctx, job, err) }()
 
 	rs, err := s.reposStore.RepoStore.List(ctx, database.ReposListOptions{
 		IDs: []api.RepoID{repoID},
@@ -463,6 +550,7 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 	repo := rs[0]
 
 	var provider authz.Provider
+	var groupsProviders []*idpgroups.Provider
 
 	// Only check authz provider for private repositories because we only need to
 	// fetch permissions for private repositories.
@@ -476,11 +564,12 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 				break
 			}
 		}
+		groupsProviders = s.idpGroupsProviders()
 	}
 
 	// For non-private repositories, we rely on the fact that the `provider` is
 	// always nil here because we don't restrict access to non-private repositories.
-	if provider == nil {
+	if provider == nil && len(groupsProviders) == 0 {
 		log15.Debug("PermsSyncer.syncRepoPerms.noProvider",
 			"repoID", repo.ID,
 			"private", repo.Private,
@@ -492,31 +581,40 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
 	}
 
-	if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
-		return errors.Wrap(err, "wait for rate limiter")
-	}
+	var extAccountIDs []extsvc.AccountID
+	if provider != nil {
+		job.Providers = []string{provider.ServiceID()}
 
-	extAccountIDs, err := provider.FetchRepoPerms(ctx, &extsvc.Repository{
-		URI:              repo.URI,
-		ExternalRepoSpec: repo.ExternalRepo,
-	})
+		if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
+			return errors.Wrap(err, "wait for rate limiter")
+		}
+		if err := s.acquireProvider(ctx, provider.ServiceID()); err != nil {
+			return errors.Wrap(err, "wait for provider concurrency limit")
+		}
 
-	// Detect 404 error (i.e. not authorized to call given APIs) that often happens with GitHub.com
-	// when the owner of the token only has READ access. However, we don't want to fail
-	// so the scheduler won't keep trying to fetch permissions of this same repository, so we
-	// return a nil error and log a warning message.
-	var e *github.APIError
-	if errors.As(err, &e) && e.Code == http.StatusNotFound {
-		log15.Warn("PermsSyncer.syncRepoPerms.ignoreUnauthorizedAPIError", "repoID", repo.ID, "err", err, "suggestion", "GitHub access token user may only have read access to the repository, but needs write for permissions")
-		return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
-	}
+		extAccountIDs, err = provider.FetchRepoPerms(ctx, &extsvc.Repository{
+			URI:              repo.URI,
+			ExternalRepoSpec: repo.ExternalRepo,
+		})
+		s.releaseProvider(provider.ServiceID())
+
+		// Detect 404 error (i.e. not authorized to call given APIs) that often happens with GitHub.com
+		// when the owner of the token only has READ access. However, we don't want to fail
+		// so the scheduler won't keep trying to fetch permissions of this same repository, so we
+		// return a nil error and log a warning message.
+		var e *github.APIError
+		if errors.As(err, &e) && e.Code == http.StatusNotFound {
+			log15.Warn("PermsSyncer.syncRepoPerms.ignoreUnauthorizedAPIError", "repoID", repo.ID, "err", err, "suggestion", "GitHub access token user may only have read access to the repository, but needs write for permissions")
+			return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
+		}
 
-	if err != nil {
-		// Process partial results if this is an initial fetch.
-		if !noPerms {
-			return errors.Wrap(err, "fetch repository permissions")
+		if err != nil {
+			// Process partial results if this is an initial fetch.
+			if !noPerms {
+				return errors.Wrap(err, "fetch repository permissions")
+			}
+			log15.Warn("PermsSyncer.syncRepoPerms.proceedWithPartialResults", "repoID", repo.ID, "err", err)
 		}
-		log15.Warn("PermsSyncer.syncRepoPerms.proceedWithPartialResults", "repoID", repo.ID, "err", err)
 	}
 
 	pendingAccountIDsSet := make(map[string]struct{})
@@ -559,6 +657,25 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		delete(pendingAccountIDsSet, aid)
 	}
 
+	// Users who are granted access by the groups of identity providers are added
+	// to the users reported by the code host, otherwise they would lose access to
+	// the repository until their next user-centric sync.
+	for _, gp := range groupsProviders {
+		job.Providers = appendProvider(job.Providers, gp.ServiceID())
+
+		groupsUserIDs, err := s.fetchGroupsUserIDs(ctx, gp, repo)
+		if err != nil {
+			// Process partial results if this is an initial fetch.
+			if !noPerms {
+				return errors.Wrap(err, "fetch identity provider groups permissions")
+			}
+			log15.Warn("PermsSyncer.syncRepoPerms.proceedWithPartialResults", "repoID", repo.ID, "provider", gp.ServiceID(), "err", err)
+		}
+		for _, uid := range groupsUserIDs {
+			p.UserIDs.Add(uint32(uid))
+		}
+	}
+
 	pendingAccountIDs := make([]string, 0, len(pendingAccountIDsSet))
 	for aid := range pendingAccountIDsSet {
 		pendingAccountIDs = append(pendingAccountIDs, aid)
@@ -570,95 +687,213 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 	}
 	defer func() { err = txs.Done(err) }()
 
-	accounts := &extsvc.Accounts{
-		ServiceType: provider.ServiceType(),
-		ServiceID:   provider.ServiceID(),
-		AccountIDs:  pendingAccountIDs,
+	// Load currently stored permissions to record the changes in the audit trail.
+	oldPerms := &authz.RepoPermissions{
+		RepoID: p.RepoID,
+		Perm:   p.Perm,
+	}
+	if err = txs.LoadRepoPermissions(ctx, oldPerms); err != nil && err != authz.ErrPermsNotFound {
+		return errors.Wrap(err, "load repository permissions")
 	}
 
 	if err = txs.SetRepoPermissions(ctx, p); err != nil {
 		return errors.Wrap(err, "set repository permissions")
-	} else if err = txs.SetRepoPendingPermissions(ctx, accounts, p); err != nil {
-		return errors.Wrap(err, "set repository pending permissions")
 	}
 
+	// Pending permissions are only set for accounts of the code host, the accounts
+	// of identity providers are created when users sign in.
+	if provider != nil {
+		accounts := &extsvc.Accounts{
+			ServiceType: provider.ServiceType(),
+			ServiceID:   provider.ServiceID(),
+			AccountIDs:  pendingAccountIDs,
+		}
+		if err = txs.SetRepoPendingPermissions(ctx, accounts, p); err != nil {
+			return errors.Wrap(err, "set repository pending permissions")
+		}
+	}
+	job.AddedIDs, job.RemovedIDs = diffIDs(oldPerms.UserIDs, p.UserIDs)
+
 	log15.Debug("PermsSyncer.syncRepoPerms.synced", "repoID", repo.ID, "name", repo.Name, "count", len(extAccountIDs))
 	return nil
 }
 
-// waitForRateLimit blocks until rate limit permits n events to happen. It returns
-// an error if n exceeds the limiter's burst size, the context is canceled, or the
-// expected wait time exceeds the context's deadline. The burst limit is ignored if
-// the rate limit is Inf.
-func (s *PermsSyncer) waitForRateLimit(ctx context.Context, serviceID string, n int) error {
-	if s.rateLimiterRegistry == nil {
-		return nil
+// fetchGroupsUserIDs returns the IDs of the users who are granted access to the
+// repository by the groups of the identity provider.
+func (s *PermsSyncer) fetchGroupsUserIDs(ctx context.Context, provider *idpgroups.Provider, repo *types.Repo) ([]int32, error) {
+	extAccountIDs, err := provider.FetchRepoPerms(ctx, &extsvc.Repository{
+		URI:              repo.URI,
+		ExternalRepoSpec: repo.ExternalRepo,
+	})
+	if len(extAccountIDs) == 0 {
+		return nil, err
 	}
 
-	rl := s.rateLimiterRegistry.Get(serviceID)
-	if err := rl.WaitN(ctx, n); err != nil {
-		return err
+	accountIDs := make([]string, len(extAccountIDs))
+	for i := range extAccountIDs {
+		accountIDs[i] = string(extAccountIDs[i])
 	}
-	return nil
+	userIDs, getErr := s.permsStore.GetUserIDsByExternalAccounts(ctx, &extsvc.Accounts{
+		ServiceType: provider.ServiceType(),
+		ServiceID:   provider.ServiceID(),
+		AccountIDs:  accountIDs,
+	})
+	if getErr != nil {
+		return nil, errors.Wrap(getErr, "get user IDs by external accounts")
+	}
+
+	ids := make([]int32, 0, len(userIDs))
+	for _, uid := range userIDs {
+		ids = append(ids, uid)
+	}
+	return ids, err
 }
 
-// syncPerms processes the permissions syncing request and remove the request from
-// the queue once it is done (independent of success or failure).
-func (s *PermsSyncer) syncPerms(ctx context.Context, request *syncRequest) error {
-	defer s.queue.remove(request.Type, request.ID, true)
+// recordSyncJob saves the audit record of a finished permissions sync. Syncs that
+// neither consulted any authz provider, changed any permissions nor failed are not
+// recorded, so that the audit trail is not flooded by syncs of public repositories.
+func (s *PermsSyncer) recordSyncJob(ctx context.Context, job *edb.PermsSyncJob, err error) {
+	if err == nil && len(job.Providers) == 0 && len(job.AddedIDs) == 0 && len(job.RemovedIDs) == 0 {
+		return
+	}
 
-	var err error
-	switch request.Type {
-	case requestTypeUser:
-		err = s.syncUserPerms(ctx, request.ID, request.NoPerms)
-	case requestTypeRepo:
-		err = s.syncRepoPerms(ctx, api.RepoID(request.ID), request.NoPerms)
-	default:
-		err = errors.Errorf("unexpected request type: %v", request.Type)
+	job.FinishedAt = s.clock()
+	if err != nil {
+		msg := err.Error()
+		job.FailureMessage = &msg
 	}
 
-	return err
+	if err := s.permsStore.InsertPermsSyncJob(ctx, job); err != nil {
+		log15.Error("Failed to record permissions sync job",
+			"type", job.RequestType,
+			"userID", job.UserID,
+			"repoID", job.RepoID,
+			"error", err)
+	}
 }
 
-func (s *PermsSyncer) runSync(ctx context.Context) {
-	log15.Debug("PermsSyncer.runSync.started")
-	defer log15.Info("PermsSyncer.runSync.stopped")
+// appendProvider appends the service ID to the list of providers unless it is
+// already present.
+func appendProvider(providers []string, serviceID string) []string {
+	for _, p := range providers {
+		if p == serviceID {
+			return providers
+		}
+	}
+	return append(providers, serviceID)
+}
+
+// diffIDs returns the IDs that are present in newIDs but not in oldIDs, and the
+// IDs that are present in oldIDs but not in newIDs. Nil bitmaps are treated as
+// empty.
+func diffIDs(oldIDs, newIDs *roaring.Bitmap) (added, removed []int32) {
+	if oldIDs == nil {
+		oldIDs = roaring.NewBitmap()
+	}
+	if newIDs == nil {
+		newIDs = roaring.NewBitmap()
+	}
+
+	for _, id := range roaring.AndNot(newIDs, oldIDs).ToArray() {
+		added = append(added, int32(id))
+	}
+	for _, id := range roaring.AndNot(oldIDs, newIDs).ToArray() {
+		removed = append(removed, int32(id))
+	}
+	return added, removed
+}
+
+// syncJobsRetention is how long the audit records of permissions syncs are kept.
+const syncJobsRetention = 30 * 24 * time.Hour
+
+// syncRequestsRetention is how long processed permissions sync requests are kept
+// in the queue table. The outcome of a sync is kept in its audit record instead.
+const syncRequestsRetention = 24 * time.Hour
+
+// cleanupSyncJobs periodically deletes audit records of permissions syncs and
+// processed permissions sync requests that are older than their retention period.
+func (s *PermsSyncer) cleanupSyncJobs(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
 
-	// To unblock the "select" on the next loop iteration if no enqueue happened in between.
-	notifyDequeued := make(chan struct{}, 1)
 	for {
 		select {
-		case <-notifyDequeued:
-		case <-s.queue.notifyEnqueue:
+		case <-ticker.C:
 		case <-ctx.Done():
 			return
 		}
 
-		request := s.queue.acquireNext()
-		if request == nil {
-			// No waiting request is in the queue
-			continue
+		err := s.permsStore.DeletePermsSyncJobsBefore(ctx, s.clock().Add(-syncJobsRetention))
+		if err != nil {
+			log15.Error("Failed to delete old permissions sync jobs", "err", err)
 		}
 
-		// Check if it's the time to sync the request
-		if wait := request.NextSyncAt.Sub(s.clock()); wait > 0 {
-			s.queue.release(request.Type, request.ID)
-			time.AfterFunc(wait, func() {
-				notify(s.queue.notifyEnqueue)
-			})
-
-			log15.Debug("PermsSyncer.Run.waitForNextSync", "duration", wait)
-			continue
+		err = s.permsStore.DeletePermsSyncRequestsBefore(ctx, s.clock().Add(-syncRequestsRetention))
+		if err != nil {
+			log15.Error("Failed to delete old permissions sync requests", "err", err)
 		}
+	}
+}
 
-		notify(notifyDequeued)
+// waitForRateLimit blocks until rate limit permits n events to happen. It returns
+// an error if n exceeds the limiter's burst size, the context is canceled, or the
+// expected wait time exceeds the context's deadline. The burst limit is ignored if
+// the rate limit is Inf.
+func (s *PermsSyncer) waitForRateLimit(ctx context.Context, serviceID string, n int) error {
+	if s.rateLimiterRegistry == nil {
+		return nil
+	}
 
-		err := s.syncPerms(ctx, request)
-		if err != nil {
-			log15.Error("Failed to sync permissions", "type", request.Type, "id", request.ID, "err", err)
-			continue
-		}
+	rl := s.rateLimiterRegistry.Get(serviceID)
+	if err := rl.WaitN(ctx, n); err != nil {
+		return err
+	}
+	return nil
+}
+
+// acquireProvider blocks until fewer than providerConcurrency requests are being
+// made concurrently to the authz provider with the given service ID, or until
+// the context is canceled. A successful call must be followed by a call to
+// releaseProvider once the request to the provider is done.
+func (s *PermsSyncer) acquireProvider(ctx context.Context, serviceID string) error {
+	s.providerSemaphoresMu.Lock()
+	sem, ok := s.providerSemaphores[serviceID]
+	if !ok {
+		sem = make(chan struct{}, s.providerConcurrency)
+		s.providerSemaphores[serviceID] = sem
+	}
+	s.providerSemaphoresMu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseProvider releases a slot acquired by acquireProvider.
+func (s *PermsSyncer) releaseProvider(serviceID string) {
+	s.providerSemaphoresMu.Lock()
+	sem := s.providerSemaphores[serviceID]
+	s.providerSemaphoresMu.Unlock()
+
+	<-sem
+}
+
+// syncPerms processes the permissions syncing request dequeued by the worker.
+func (s *PermsSyncer) syncPerms(ctx context.Context, request *edb.PermsSyncRequest) error {
+	var err error
+	switch request.RequestType {
+	case edb.PermsSyncJobTypeUser:
+		err = s.syncUserPerms(ctx, request.UserID, request.NoPerms)
+	case edb.PermsSyncJobTypeRepo:
+		err = s.syncRepoPerms(ctx, api.RepoID(request.RepoID), request.NoPerms)
+	default:
+		err = errors.Errorf("unexpected request type: %q", request.RequestType)
 	}
+
+	return err
 }
 
 // scheduleUsersWithNoPerms returns computed schedules for users who have no permissions
@@ -673,9 +908,8 @@ func (s *PermsSyncer) scheduleUsersWithNoPerms(ctx context.Context) ([]scheduled
 	users := make([]scheduledUser, len(ids))
 	for i, id := range ids {
 		users[i] = scheduledUser{
-			priority: priorityLow,
-			userID:   id,
-			// NOTE: Have nextSyncAt with zero value (i.e. not set) gives it higher priority.
+			reason:  edb.PermsSyncReasonNoPerms,
+			userID:  id,
 			noPerms: true,
 		}
 	}
@@ -694,9 +928,8 @@ func (s *PermsSyncer) scheduleReposWithNoPerms(ctx context.Context) ([]scheduled
 	repos := make([]scheduledRepo, len(ids))
 	for i, id := range ids {
 		repos[i] = scheduledRepo{
-			priority: priorityLow,
-			repoID:   id,
-			// NOTE: Have nextSyncAt with zero value (i.e. not set) gives it higher priority.
+			reason:  edb.PermsSyncReasonNoPerms,
+			repoID:  id,
 			noPerms: true,
 		}
 	}
@@ -712,11 +945,10 @@ func (s *PermsSyncer) scheduleUsersWithOldestPerms(ctx context.Context, limit in
 	}
 
 	users := make([]scheduledUser, 0, len(results))
-	for id, t := range results {
+	for id := range results {
 		users = append(users, scheduledUser{
-			priority:   priorityLow,
-			userID:     id,
-			nextSyncAt: t,
+			reason: edb.PermsSyncReasonOldestPerms,
+			userID: id,
 		})
 	}
 	return users, nil
@@ -731,11 +963,10 @@ func (s *PermsSyncer) scheduleReposWithOldestPerms(ctx context.Context, limit in
 	}
 
 	repos := make([]scheduledRepo, 0, len(results))
-	for id, t := range results {
+	for id := range results {
 		repos = append(repos, scheduledRepo{
-			priority:   priorityLow,
-			repoID:     id,
-			nextSyncAt: t,
+			reason: edb.PermsSyncReasonOldestPerms,
+			repoID: id,
 		})
 	}
 	return repos, nil
@@ -749,9 +980,8 @@ type schedule struct {
 
 // scheduledUser contains information for scheduling a user.
 type scheduledUser struct {
-	priority   priority
-	userID     int32
-	nextSyncAt time.Time
+	reason edb.PermsSyncReason
+	userID int32
 
 	// Whether the user has no permissions when scheduled. Currently used to
 	// accept partial results from authz provider in case of error.
@@ -760,9 +990,8 @@ type scheduledUser struct {
 
 // scheduledRepo contains for scheduling a repository.
 type scheduledRepo struct {
-	priority   priority
-	repoID     api.RepoID
-	nextSyncAt time.Time
+	reason edb.PermsSyncReason
+	repoID api.RepoID
 
 	// Whether the repository has no permissions when scheduled. Currently used
 	// to accept partial results from authz provider in case of error.
@@ -800,9 +1029,6 @@ func (s *PermsSyncer) schedule(ctx context.Context) (*schedule, error) {
 	// Hard coded both to 10 for now.
 	const limit = 10
 
-	// TODO(jchen): Use better heuristics for setting NextSyncAt, the initial version
-	// just uses the value of LastUpdatedAt get from the perms tables.
-
 	users, err = s.scheduleUsersWithOldestPerms(ctx, limit)
 	if err != nil {
 		return nil, errors.Wrap(err, "load users with oldest permissions")
@@ -862,65 +1088,44 @@ func (s *PermsSyncer) runSchedule(ctx context.Context) {
 
 // DebugDump returns the state of the permissions syncer for debugging.
 func (s *PermsSyncer) DebugDump() interface{} {
-	type requestInfo struct {
-		Meta     *requestMeta
-		Acquired bool
-	}
 	data := struct {
 		Name  string
 		Size  int
-		Queue []*requestInfo
+		Queue []*edb.PermsSyncRequest
+		Error string `json:",omitempty"`
 	}{
 		Name: "permissions",
 	}
 
-	queue := requestQueue{
-		heap: make([]*syncRequest, len(s.queue.heap)),
-	}
-
-	s.queue.mu.RLock()
-	defer s.queue.mu.RUnlock()
-
-	for i, request := range s.queue.heap {
-		// Copy the syncRequest as a value so that poping off the heap here won't
-		// update the index value of the real heap, and we don't do a racy read on
-		// the repo pointer which may change concurrently in the real heap.
-		requestCopy := *request
-		queue.heap[i] = &requestCopy
+	// Only dump the head of the queue, it may contain a large number of requests.
+	const limit = 100
+	queue, err := s.permsStore.ListQueuedPermsSyncRequests(context.Background(), limit)
+	if err != nil {
+		data.Error = err.Error()
+		return &data
 	}
+	data.Queue = queue
 
-	for len(queue.heap) > 0 {
-		// Copy values of the syncRequest so that the requestMeta pointer
-		// won't change concurrently after we release the lock.
-		request := heap.Pop(&queue).(*syncRequest)
-		data.Queue = append(data.Queue, &requestInfo{
-			Meta: &requestMeta{
-				Priority:   request.Priority,
-				Type:       request.Type,
-				ID:         request.ID,
-				NextSyncAt: request.NextSyncAt,
-			},
-			Acquired: request.acquired,
-		})
+	data.Size, err = s.permsStore.CountQueuedPermsSyncRequests(context.Background())
+	if err != nil {
+		data.Error = err.Error()
 	}
-	data.Size = len(data.Queue)
-
 	return &data
 }
 
-func (s *PermsSyncer) observe(ctx context.Context, family, title string) (context.Context, func(requestType, int32, *error)) {
+func (s *PermsSyncer) observe(ctx context.Context, family, title string) (context.Context, func(edb.PermsSyncJobType, int32, *error)) {
 	began := s.clock()
 	tr, ctx := trace.New(ctx, family, title)
 
-	return ctx, func(typ requestType, id int32, err *error) {
+	return ctx, func(typ edb.PermsSyncJobType, id int32, err *error) {
 		defer tr.Finish()
 		tr.LogFields(otlog.Int32("id", id))
 
 		var typLabel string
 		switch typ {
-		case requestTypeRepo:
+		case edb.PermsSyncJobTypeRepo:
 			typLabel = "repo"
-		case requestTypeUser:
+		case edb.PermsSyncJobTypeUser:
 			typLabel = "user"
 		default:
 			tr.SetError(errors.Errorf("unexpected request type: %v", typ))
@@ -960,18 +1165,27 @@ func (s *PermsSyncer) collectMetrics(ctx context.Context) {
 		metricsStalePerms.WithLabelValues("repo").Set(float64(m.ReposWithStalePerms))
 		metricsPermsGap.WithLabelValues("repo").Set(m.ReposPermsGapSeconds)
 
-		s.queue.mu.RLock()
-		metricsQueueSize.Set(float64(s.queue.Len()))
-		s.queue.mu.RUnlock()
+		size, err := s.permsStore.CountQueuedPermsSyncRequests(ctx)
+		if err != nil {
+			log15.Error("Failed to count queued permissions sync requests", "err", err)
+			continue
+		}
+		metricsQueueSize.Set(float64(size))
 	}
 }
 
 // Run kicks off the permissions syncing process, this method is blocking and
 // should be called as a goroutine.
 func (s *PermsSyncer) Run(ctx context.Context) {
-	go s.runSync(ctx)
+	worker, resetter := s.newWorker(ctx)
+	go worker.Start()
+	go resetter.Start()
+	defer worker.Stop()
+	defer resetter.Stop()
+
 	go s.runSchedule(ctx)
 	go s.collectMetrics(ctx)
+	go s.cleanupSyncJobs(ctx)
 
 	<-ctx.Done()
 }
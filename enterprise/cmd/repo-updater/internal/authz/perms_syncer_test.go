@@ -3,16 +3,19 @@ package authz
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/cockroachdb/errors"
 	"github.com/google/go-cmp/cmp"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/authz/idpgroups"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -20,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -28,18 +32,26 @@ func TestPermsSyncer_ScheduleUsers(t *testing.T) {
 	authz.SetProviders(true, []authz.Provider{&mockProvider{}})
 	defer authz.SetProviders(true, nil)
 
-	s := NewPermsSyncer(nil, nil, nil, nil)
-	s.ScheduleUsers(context.Background(), 1)
+	var got []edb.EnqueuePermsSyncRequestsOpts
+	edb.Mocks.Perms.EnqueuePermsSyncRequests = func(_ context.Context, opts edb.EnqueuePermsSyncRequestsOpts) error {
+		got = append(got, opts)
+		return nil
+	}
+	defer func() { edb.Mocks.Perms = edb.MockPerms{} }()
 
-	expHeap := []*syncRequest{
-		{requestMeta: &requestMeta{
-			Priority: priorityHigh,
-			Type:     requestTypeUser,
-			ID:       1,
-		}, acquired: false, index: 0},
+	s := NewPermsSyncer(nil, edb.Perms(nil, nil), nil, nil)
+	s.ScheduleUsers(context.Background(), protocol.PermsSyncReasonManual, 1)
+
+	want := []edb.EnqueuePermsSyncRequestsOpts{
+		{
+			RequestType: edb.PermsSyncJobTypeUser,
+			IDs:         []int32{1},
+			Priority:    edb.PermsSyncPriorityHigh,
+			Reason:      edb.PermsSyncReasonManual,
+		},
 	}
-	if diff := cmp.Diff(expHeap, s.queue.heap, cmpOpts); diff != "" {
-		t.Fatalf("heap: %v", diff)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("enqueued: %v", diff)
 	}
 }
 
@@ -47,18 +59,62 @@ func TestPermsSyncer_ScheduleRepos(t *testing.T) {
 	authz.SetProviders(true, []authz.Provider{&mockProvider{}})
 	defer authz.SetProviders(true, nil)
 
-	s := NewPermsSyncer(nil, nil, nil, nil)
-	s.ScheduleRepos(context.Background(), 1)
+	var got []edb.EnqueuePermsSyncRequestsOpts
+	edb.Mocks.Perms.EnqueuePermsSyncRequests = func(_ context.Context, opts edb.EnqueuePermsSyncRequestsOpts) error {
+		got = append(got, opts)
+		return nil
+	}
+	defer func() { edb.Mocks.Perms = edb.MockPerms{} }()
+
+	s := NewPermsSyncer(nil, edb.Perms(nil, nil), nil, nil)
+	s.ScheduleRepos(context.Background(), protocol.PermsSyncReasonWebhook, 1)
+
+	want := []edb.EnqueuePermsSyncRequestsOpts{
+		{
+			RequestType: edb.PermsSyncJobTypeRepo,
+			IDs:         []int32{1},
+			Priority:    edb.PermsSyncPriorityMedium,
+			Reason:      edb.PermsSyncReasonWebhook,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("enqueued: %v", diff)
+	}
+}
+
+func TestPermsSyncer_scheduleUsers(t *testing.T) {
+	var got []edb.EnqueuePermsSyncRequestsOpts
+	edb.Mocks.Perms.EnqueuePermsSyncRequests = func(_ context.Context, opts edb.EnqueuePermsSyncRequestsOpts) error {
+		got = append(got, opts)
+		return nil
+	}
+	defer func() { edb.Mocks.Perms = edb.MockPerms{} }()
+
+	// Users are enqueued in batches grouped by reason.
+	s := NewPermsSyncer(nil, edb.Perms(nil, nil), nil, nil)
+	s.scheduleUsers(context.Background(),
+		scheduledUser{reason: edb.PermsSyncReasonNoPerms, userID: 1, noPerms: true},
+		scheduledUser{reason: edb.PermsSyncReasonOldestPerms, userID: 2},
+		scheduledUser{reason: edb.PermsSyncReasonNoPerms, userID: 3, noPerms: true},
+	)
 
-	expHeap := []*syncRequest{
-		{requestMeta: &requestMeta{
-			Priority: priorityHigh,
-			Type:     requestTypeRepo,
-			ID:       1,
-		}, acquired: false, index: 0},
+	want := []edb.EnqueuePermsSyncRequestsOpts{
+		{
+			RequestType: edb.PermsSyncJobTypeUser,
+			IDs:         []int32{1, 3},
+			Priority:    edb.PermsSyncPriorityLow,
+			Reason:      edb.PermsSyncReasonNoPerms,
+			NoPerms:     true,
+		},
+		{
+			RequestType: edb.PermsSyncJobTypeUser,
+			IDs:         []int32{2},
+			Priority:    edb.PermsSyncPriorityLow,
+			Reason:      edb.PermsSyncReasonOldestPerms,
+		},
 	}
-	if diff := cmp.Diff(expHeap, s.queue.heap, cmpOpts); diff != "" {
-		t.Fatalf("heap: %v", diff)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("enqueued: %v", diff)
 	}
 }
 
@@ -125,6 +181,12 @@ func TestPermsSyncer_syncUserPerms(t *testing.T) {
 	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
 		return []*extsvc.Account{&extAccount}, nil
 	}
+	edb.Mocks.Perms.LoadUserPermissions = func(context.Context, *authz.UserPermissions) error {
+		return authz.ErrPermsNotFound
+	}
+	edb.Mocks.Perms.InsertPermsSyncJob = func(context.Context, *edb.PermsSyncJob) error {
+		return nil
+	}
 	edb.Mocks.Perms.SetUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
 		if p.UserID != 1 {
 			return errors.Errorf("UserID: want 1 but got %d", p.UserID)
@@ -213,6 +275,12 @@ func TestPermsSyncer_syncUserPerms_tokenExpire(t *testing.T) {
 	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
 		return []*extsvc.Account{&extAccount}, nil
 	}
+	edb.Mocks.Perms.LoadUserPermissions = func(context.Context, *authz.UserPermissions) error {
+		return authz.ErrPermsNotFound
+	}
+	edb.Mocks.Perms.InsertPermsSyncJob = func(context.Context, *edb.PermsSyncJob) error {
+		return nil
+	}
 	edb.Mocks.Perms.SetUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
 		return nil
 	}
@@ -328,6 +396,12 @@ func TestPermsSyncer_syncUserPerms_prefixSpecs(t *testing.T) {
 	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
 		return []*extsvc.Account{&extAccount}, nil
 	}
+	edb.Mocks.Perms.LoadUserPermissions = func(context.Context, *authz.UserPermissions) error {
+		return authz.ErrPermsNotFound
+	}
+	edb.Mocks.Perms.InsertPermsSyncJob = func(context.Context, *edb.PermsSyncJob) error {
+		return nil
+	}
 	edb.Mocks.Perms.SetUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
 		return nil
 	}
@@ -368,6 +442,107 @@ func TestPermsSyncer_syncUserPerms_prefixSpecs(t *testing.T) {
 	}
 }
 
+func TestPermsSyncer_syncUserPerms_recordSyncJob(t *testing.T) {
+	p := &mockProvider{
+		id:          1,
+		serviceType: extsvc.TypeGitLab,
+		serviceID:   "https://gitlab.com/",
+	}
+	authz.SetProviders(false, []authz.Provider{p})
+	defer authz.SetProviders(true, nil)
+
+	extAccount := extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.ServiceType(),
+			ServiceID:   p.ServiceID(),
+		},
+	}
+
+	var recorded []*edb.PermsSyncJob
+	database.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	database.Mocks.ExternalAccounts.TouchLastValid = func(ctx context.Context, id int32) error {
+		return nil
+	}
+	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
+		return []*extsvc.Account{&extAccount}, nil
+	}
+	edb.Mocks.Perms.LoadUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
+		p.IDs = roaring.BitmapOf(2, 3)
+		return nil
+	}
+	edb.Mocks.Perms.SetUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
+		return nil
+	}
+	edb.Mocks.Perms.InsertPermsSyncJob = func(_ context.Context, job *edb.PermsSyncJob) error {
+		recorded = append(recorded, job)
+		return nil
+	}
+	database.Mocks.Repos.ListRepoNames = func(v0 context.Context, args database.ReposListOptions) ([]types.RepoName, error) {
+		return []types.RepoName{{ID: 1}, {ID: 2}}, nil
+	}
+	database.Mocks.UserEmails.ListByUser = func(ctx context.Context, opt database.UserEmailsListOptions) ([]*database.UserEmail, error) {
+		return nil, nil
+	}
+	database.Mocks.ExternalServices.List = func(opt database.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return []*types.ExternalService{}, nil
+	}
+	defer func() {
+		database.Mocks = database.MockStores{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	clock := func() time.Time { return time.Unix(1000, 0) }
+	s := NewPermsSyncer(repos.NewStore(&dbtesting.MockDB{}, sql.TxOptions{}), edb.Perms(nil, clock), clock, nil)
+
+	t.Run("success", func(t *testing.T) {
+		recorded = nil
+		p.fetchUserPerms = func(context.Context, *extsvc.Account) (*authz.ExternalUserPermissions, error) {
+			return &authz.ExternalUserPermissions{
+				Exacts: []extsvc.RepoID{"1", "2"},
+			}, nil
+		}
+
+		err := s.syncUserPerms(context.Background(), 1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*edb.PermsSyncJob{{
+			RequestType: edb.PermsSyncJobTypeUser,
+			UserID:      1,
+			Providers:   []string{"https://gitlab.com/"},
+			StartedAt:   clock(),
+			FinishedAt:  clock(),
+			AddedIDs:    []int32{1},
+			RemovedIDs:  []int32{3},
+		}}
+		if diff := cmp.Diff(want, recorded); diff != "" {
+			t.Fatalf("recorded jobs mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		recorded = nil
+		p.fetchUserPerms = func(context.Context, *extsvc.Account) (*authz.ExternalUserPermissions, error) {
+			return nil, errors.New("boom")
+		}
+
+		err := s.syncUserPerms(context.Background(), 1, false)
+		if err == nil {
+			t.Fatal("want error but got nil")
+		}
+
+		if len(recorded) != 1 {
+			t.Fatalf("want 1 recorded job but got %d", len(recorded))
+		}
+		if recorded[0].FailureMessage == nil || *recorded[0].FailureMessage != err.Error() {
+			t.Fatalf("FailureMessage: want %q but got %v", err.Error(), recorded[0].FailureMessage)
+		}
+	})
+}
+
 func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 	newPermsSyncer := func(store *repos.Store) *PermsSyncer {
 		return NewPermsSyncer(store, edb.Perms(nil, timeutil.Now), timeutil.Now, nil)
@@ -439,6 +614,12 @@ func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 		edb.Mocks.Perms.GetUserIDsByExternalAccounts = func(context.Context, *extsvc.Accounts) (map[string]int32, error) {
 			return map[string]int32{"user": 1}, nil
 		}
+		edb.Mocks.Perms.LoadRepoPermissions = func(context.Context, *authz.RepoPermissions) error {
+			return authz.ErrPermsNotFound
+		}
+		edb.Mocks.Perms.InsertPermsSyncJob = func(context.Context, *edb.PermsSyncJob) error {
+			return nil
+		}
 		edb.Mocks.Perms.SetRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
 			if p.RepoID != 1 {
 				return errors.Errorf("RepoID: want 1 but got %d", p.RepoID)
@@ -480,6 +661,95 @@ func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 		}
 	})
 
+	t.Run("add users granted by identity provider groups", func(t *testing.T) {
+		p1 := &mockProvider{
+			serviceType: extsvc.TypeGitLab,
+			serviceID:   "https://gitlab.com/",
+			fetchRepoPerms: func(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+				return []extsvc.AccountID{"user"}, nil
+			},
+		}
+		p2 := idpgroups.NewProvider("idpgroups:saml:https://idp.example.com", "saml", "https://idp.example.com", []*idpgroups.Group{
+			{Name: "engineering", Repos: []string{"^gitlab.com/org/"}},
+		})
+		authz.SetProviders(false, []authz.Provider{p1, p2})
+		defer authz.SetProviders(true, nil)
+
+		groups, err := json.Marshal(idpgroups.AccountData{Groups: []string{"engineering"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := json.RawMessage(groups)
+		database.Mocks.ExternalAccounts.List = func(database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+			return []*extsvc.Account{{
+				AccountSpec: extsvc.AccountSpec{ServiceType: "saml", ServiceID: "https://idp.example.com", AccountID: "alice"},
+				AccountData: extsvc.AccountData{Data: &data},
+			}}, nil
+		}
+		database.Mocks.Repos.ListRepoNames = func(context.Context, database.ReposListOptions) ([]types.RepoName, error) {
+			return []types.RepoName{{ID: 1, Name: "gitlab.com/org/repo"}}, nil
+		}
+
+		edb.Mocks.Perms.Transact = func(context.Context) (*edb.PermsStore, error) {
+			return &edb.PermsStore{}, nil
+		}
+		edb.Mocks.Perms.GetUserIDsByExternalAccounts = func(_ context.Context, accounts *extsvc.Accounts) (map[string]int32, error) {
+			if accounts.ServiceType == "saml" {
+				return map[string]int32{"alice": 2}, nil
+			}
+			return map[string]int32{"user": 1}, nil
+		}
+		edb.Mocks.Perms.LoadRepoPermissions = func(context.Context, *authz.RepoPermissions) error {
+			return authz.ErrPermsNotFound
+		}
+		var gotProviders []string
+		edb.Mocks.Perms.InsertPermsSyncJob = func(_ context.Context, job *edb.PermsSyncJob) error {
+			gotProviders = job.Providers
+			return nil
+		}
+		edb.Mocks.Perms.SetRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
+			wantUserIDs := []uint32{1, 2}
+			if diff := cmp.Diff(wantUserIDs, p.UserIDs.ToArray()); diff != "" {
+				return errors.Errorf("UserIDs mismatch (-want +got):\n%s", diff)
+			}
+			return nil
+		}
+		edb.Mocks.Perms.SetRepoPendingPermissions = func(ctx context.Context, accounts *extsvc.Accounts, p *authz.RepoPermissions) error {
+			return nil
+		}
+		database.Mocks.Repos.List = func(context.Context, database.ReposListOptions) ([]*types.Repo, error) {
+			return []*types.Repo{
+				{
+					ID:      1,
+					Private: true,
+					ExternalRepo: api.ExternalRepoSpec{
+						ServiceID: p1.ServiceID(),
+					},
+					Sources: map[string]*types.SourceInfo{
+						p1.URN(): {},
+					},
+				},
+			}, nil
+		}
+		defer func() {
+			edb.Mocks.Perms = edb.MockPerms{}
+			database.Mocks.Repos = database.MockRepos{}
+			database.Mocks.ExternalAccounts = database.MockExternalAccounts{}
+		}()
+
+		s := newPermsSyncer(repos.NewStore(&dbtesting.MockDB{}, sql.TxOptions{}))
+
+		err = s.syncRepoPerms(context.Background(), 1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantProviders := []string{"https://gitlab.com/", "https://idp.example.com"}
+		if diff := cmp.Diff(wantProviders, gotProviders); diff != "" {
+			t.Fatalf("Providers mismatch (-want +got):\n%s", diff)
+		}
+	})
+
 	p := &mockProvider{
 		serviceType: extsvc.TypeGitLab,
 		serviceID:   "https://gitlab.com/",
@@ -493,6 +763,12 @@ func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 	edb.Mocks.Perms.GetUserIDsByExternalAccounts = func(context.Context, *extsvc.Accounts) (map[string]int32, error) {
 		return map[string]int32{"user": 1}, nil
 	}
+	edb.Mocks.Perms.LoadRepoPermissions = func(context.Context, *authz.RepoPermissions) error {
+		return authz.ErrPermsNotFound
+	}
+	edb.Mocks.Perms.InsertPermsSyncJob = func(context.Context, *edb.PermsSyncJob) error {
+		return nil
+	}
 	edb.Mocks.Perms.SetRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
 		if p.RepoID != 1 {
 			return errors.Errorf("RepoID: want 1 but got %d", p.RepoID)
@@ -606,25 +882,41 @@ func TestPermsSyncer_waitForRateLimit(t *testing.T) {
 }
 
 func TestPermsSyncer_syncPerms(t *testing.T) {
-	request := &syncRequest{
-		requestMeta: &requestMeta{
-			Type: 3,
-			ID:   1,
-		},
-		acquired: true,
-	}
-
-	// Request should be removed from the queue even if error occurred.
 	s := NewPermsSyncer(nil, nil, nil, nil)
-	s.queue.Push(request)
 
-	expErr := "unexpected request type: 3"
-	err := s.syncPerms(context.Background(), request)
+	expErr := `unexpected request type: "foo"`
+	err := s.syncPerms(context.Background(), &edb.PermsSyncRequest{
+		RequestType: "foo",
+		UserID:      1,
+	})
 	if err == nil || err.Error() != expErr {
 		t.Fatalf("err: want %q but got %v", expErr, err)
 	}
+}
 
-	if s.queue.Len() != 0 {
-		t.Fatalf("queue length: want 0 but got %d", s.queue.Len())
+func TestPermsSyncer_acquireProvider(t *testing.T) {
+	s := NewPermsSyncer(nil, nil, nil, nil)
+	s.providerConcurrency = 1
+
+	ctx := context.Background()
+	if err := s.acquireProvider(ctx, "https://github.com/"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other providers are not limited by requests made to GitHub.
+	if err := s.acquireProvider(ctx, "https://gitlab.com/"); err != nil {
+		t.Fatal(err)
+	}
+	s.releaseProvider("https://gitlab.com/")
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := s.acquireProvider(timeoutCtx, "https://github.com/"); err != context.DeadlineExceeded {
+		t.Fatalf("err: want %v but got %v", context.DeadlineExceeded, err)
+	}
+
+	s.releaseProvider("https://github.com/")
+	if err := s.acquireProvider(ctx, "https://github.com/"); err != nil {
+		t.Fatal(err)
 	}
 }
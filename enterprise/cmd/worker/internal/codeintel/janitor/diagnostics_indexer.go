@@ -0,0 +1,140 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/derision-test/glock"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type diagnosticsIndexer struct {
+	dbStore      DBStore
+	lsifStore    LSIFStore
+	batchSize    int
+	maxPerUpload int
+	metrics      *metrics
+	clock        glock.Clock
+}
+
+var _ goroutine.Handler = &diagnosticsIndexer{}
+
+// NewDiagnosticsIndexer returns a background routine that periodically copies the diagnostics
+// of newly completed uploads from the codeintel database into the lsif_diagnostics table of the
+// frontend database, where they can be aggregated and searched across repositories.
+func NewDiagnosticsIndexer(
+	dbStore DBStore,
+	lsifStore LSIFStore,
+	batchSize int,
+	maxPerUpload int,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, newDiagnosticsIndexer(
+		dbStore,
+		lsifStore,
+		batchSize,
+		maxPerUpload,
+		metrics,
+		glock.NewRealClock(),
+	))
+}
+
+func newDiagnosticsIndexer(
+	dbStore DBStore,
+	lsifStore LSIFStore,
+	batchSize int,
+	maxPerUpload int,
+	metrics *metrics,
+	clock glock.Clock,
+) *diagnosticsIndexer {
+	return &diagnosticsIndexer{
+		dbStore:      dbStore,
+		lsifStore:    lsifStore,
+		batchSize:    batchSize,
+		maxPerUpload: maxPerUpload,
+		metrics:      metrics,
+		clock:        clock,
+	}
+}
+
+func (i *diagnosticsIndexer) Handle(ctx context.Context) error {
+	uploadIDs, err := i.dbStore.UploadIDsWithUnindexedDiagnostics(ctx, i.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.UploadIDsWithUnindexedDiagnostics")
+	}
+	if len(uploadIDs) == 0 {
+		return nil
+	}
+
+	uploads, err := i.dbStore.GetUploadsByIDs(ctx, uploadIDs...)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.GetUploadsByIDs")
+	}
+
+	uploadsByID := make(map[int]dbstore.Upload, len(uploads))
+	for _, upload := range uploads {
+		uploadsByID[upload.ID] = upload
+	}
+
+	for _, uploadID := range uploadIDs {
+		upload, ok := uploadsByID[uploadID]
+		if !ok {
+			// The upload was deleted since we listed it; mark it as indexed so that we
+			// do not keep selecting it.
+			if err := i.dbStore.UpdateDiagnostics(ctx, uploadID, nil, i.clock.Now()); err != nil {
+				return errors.Wrap(err, "dbstore.UpdateDiagnostics")
+			}
+
+			continue
+		}
+
+		if err := i.handleUpload(ctx, upload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *diagnosticsIndexer) HandleError(err error) {
+	i.metrics.numErrors.Inc()
+	log15.Error("Failed to index codeintel diagnostics", "error", err)
+}
+
+func (i *diagnosticsIndexer) handleUpload(ctx context.Context, upload dbstore.Upload) error {
+	lsifDiagnostics, totalCount, err := i.lsifStore.Diagnostics(ctx, upload.ID, "", i.maxPerUpload, 0)
+	if err != nil {
+		return errors.Wrap(err, "lsifstore.Diagnostics")
+	}
+	if totalCount > len(lsifDiagnostics) {
+		log15.Warn(
+			"Upload has more diagnostics than can be indexed",
+			"upload_id", upload.ID,
+			"num_diagnostics", totalCount,
+			"max_diagnostics", i.maxPerUpload,
+		)
+	}
+
+	diagnostics := make([]dbstore.Diagnostic, 0, len(lsifDiagnostics))
+	for _, diagnostic := range lsifDiagnostics {
+		diagnostics = append(diagnostics, dbstore.Diagnostic{
+			UploadID:       upload.ID,
+			Path:           upload.Root + diagnostic.Path,
+			DiagnosticData: diagnostic.DiagnosticData,
+		})
+	}
+
+	if err := i.dbStore.UpdateDiagnostics(ctx, upload.ID, diagnostics, i.clock.Now()); err != nil {
+		return errors.Wrap(err, "dbstore.UpdateDiagnostics")
+	}
+
+	log15.Debug("Indexed diagnostics", "upload_id", upload.ID, "num_diagnostics", len(diagnostics))
+	i.metrics.numDiagnosticsIndexed.Add(float64(len(diagnostics)))
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package janitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestDiagnosticsIndexer(t *testing.T) {
+	dbStore := NewMockDBStore()
+	dbStore.UploadIDsWithUnindexedDiagnosticsFunc.SetDefaultReturn([]int{1, 2, 3}, nil)
+	dbStore.GetUploadsByIDsFunc.SetDefaultReturn([]dbstore.Upload{
+		{ID: 1, Root: ""},
+		{ID: 2, Root: "sub/"},
+	}, nil)
+
+	lsifStore := NewMockLSIFStore()
+	lsifStore.DiagnosticsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]lsifstore.Diagnostic, int, error) {
+		return []lsifstore.Diagnostic{
+			{DumpID: bundleID, Path: "a.go", DiagnosticData: semantic.DiagnosticData{Severity: 1, Message: "m1"}},
+			{DumpID: bundleID, Path: "b.go", DiagnosticData: semantic.DiagnosticData{Severity: 2, Message: "m2"}},
+		}, 2, nil
+	})
+
+	clock := glock.NewMockClockAt(time.Unix(1587396557, 0).UTC())
+	indexer := newDiagnosticsIndexer(dbStore, lsifStore, 10, 100, newMetrics(&observation.TestContext), clock)
+	if err := indexer.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error indexing diagnostics: %s", err)
+	}
+
+	if len(lsifStore.DiagnosticsFunc.History()) != 2 {
+		t.Fatalf("unexpected number of calls to Diagnostics. want=%d have=%d", 2, len(lsifStore.DiagnosticsFunc.History()))
+	}
+	if limit := lsifStore.DiagnosticsFunc.History()[0].Arg3; limit != 100 {
+		t.Errorf("unexpected limit. want=%d have=%d", 100, limit)
+	}
+
+	paths := map[int][]string{}
+	for _, call := range dbStore.UpdateDiagnosticsFunc.History() {
+		if !call.Arg3.Equal(clock.Now()) {
+			t.Errorf("unexpected now. want=%s have=%s", clock.Now(), call.Arg3)
+		}
+
+		paths[call.Arg1] = []string{}
+		for _, diagnostic := range call.Arg2 {
+			if diagnostic.UploadID != call.Arg1 {
+				t.Errorf("unexpected upload id. want=%d have=%d", call.Arg1, diagnostic.UploadID)
+			}
+
+			paths[call.Arg1] = append(paths[call.Arg1], diagnostic.Path)
+		}
+	}
+
+	expectedPaths := map[int][]string{
+		1: {"a.go", "b.go"},
+		2: {"sub/a.go", "sub/b.go"},
+		3: {},
+	}
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Errorf("unexpected diagnostic paths (-want +got):\n%s", diff)
+	}
+}
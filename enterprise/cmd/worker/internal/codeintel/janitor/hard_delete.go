@@ -29,6 +29,9 @@ var _ goroutine.Handler = &hardDeleter{}
 // did not have an associated upload record. Doing a soft-delete and a transactional
 // cleanup routine instead ensures we delete unreachable data as soon as it's no longer
 // referenceable.
+//
+// Uploads that are the base of an incremental upload are kept until the incremental
+// upload has been hard-deleted, as the incremental upload shares their data.
 func NewHardDeleter(dbStore DBStore, lsifStore LSIFStore, interval time.Duration, metrics *metrics) goroutine.BackgroundRoutine {
 	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &hardDeleter{
 		dbStore:   dbStore,
@@ -41,8 +44,9 @@ const uploadsBatchSize = 100
 
 func (d *hardDeleter) Handle(ctx context.Context) error {
 	options := store.GetUploadsOptions{
-		State: "deleted",
-		Limit: uploadsBatchSize,
+		State:           "deleted",
+		Limit:           uploadsBatchSize,
+		OmitBaseUploads: true,
 	}
 
 	for {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 )
 
@@ -16,6 +17,7 @@ type DBStore interface {
 	Done(err error) error
 
 	GetUploads(ctx context.Context, opts dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
+	GetUploadsByIDs(ctx context.Context, ids ...int) ([]dbstore.Upload, error)
 	DeleteUploadsWithoutRepository(ctx context.Context, now time.Time) (map[int]int, error)
 	HardDeleteUploadByID(ctx context.Context, ids ...int) error
 	SoftDeleteOldUploads(ctx context.Context, maxAge time.Duration, now time.Time) (int, error)
@@ -25,6 +27,11 @@ type DBStore interface {
 	DeleteUploadsStuckUploading(ctx context.Context, uploadedBefore time.Time) (int, error)
 	StaleSourcedCommits(ctx context.Context, threshold time.Duration, limit int, now time.Time) ([]dbstore.SourcedCommits, error)
 	RefreshCommitResolvability(ctx context.Context, repositoryID int, commit string, delete bool, now time.Time) (int, int, error)
+	GetRetentionPolicies(ctx context.Context) ([]dbstore.RetentionPolicy, error)
+	RepositoryIDsForRetentionEvaluation(ctx context.Context, minimumTimeSinceLastEvaluation time.Duration, limit int, now time.Time) ([]int, error)
+	UpdateUploadRetention(ctx context.Context, uploadIDs, retainedIDs, expiredIDs []int, now time.Time) error
+	UploadIDsWithUnindexedDiagnostics(ctx context.Context, limit int) ([]int, error)
+	UpdateDiagnostics(ctx context.Context, uploadID int, diagnostics []dbstore.Diagnostic, now time.Time) error
 }
 
 type DBStoreShim struct {
@@ -42,4 +49,5 @@ func (s *DBStoreShim) Transact(ctx context.Context) (DBStore, error) {
 
 type LSIFStore interface {
 	Clear(ctx context.Context, bundleIDs ...int) error
+	Diagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]lsifstore.Diagnostic, int, error)
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	lsifstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 )
 
@@ -37,9 +38,15 @@ type MockDBStore struct {
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *DBStoreDoneFunc
+	// GetRetentionPoliciesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRetentionPolicies.
+	GetRetentionPoliciesFunc *DBStoreGetRetentionPoliciesFunc
 	// GetUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUploads.
 	GetUploadsFunc *DBStoreGetUploadsFunc
+	// GetUploadsByIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadsByIDs.
+	GetUploadsByIDsFunc *DBStoreGetUploadsByIDsFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *DBStoreHandleFunc
@@ -50,6 +57,10 @@ type MockDBStore struct {
 	// object controlling the behavior of the method
 	// RefreshCommitResolvability.
 	RefreshCommitResolvabilityFunc *DBStoreRefreshCommitResolvabilityFunc
+	// RepositoryIDsForRetentionEvaluationFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// RepositoryIDsForRetentionEvaluation.
+	RepositoryIDsForRetentionEvaluationFunc *DBStoreRepositoryIDsForRetentionEvaluationFunc
 	// SoftDeleteOldUploadsFunc is an instance of a mock function object
 	// controlling the behavior of the method SoftDeleteOldUploads.
 	SoftDeleteOldUploadsFunc *DBStoreSoftDeleteOldUploadsFunc
@@ -59,6 +70,16 @@ type MockDBStore struct {
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *DBStoreTransactFunc
+	// UpdateDiagnosticsFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateDiagnostics.
+	UpdateDiagnosticsFunc *DBStoreUpdateDiagnosticsFunc
+	// UpdateUploadRetentionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateUploadRetention.
+	UpdateUploadRetentionFunc *DBStoreUpdateUploadRetentionFunc
+	// UploadIDsWithUnindexedDiagnosticsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// UploadIDsWithUnindexedDiagnostics.
+	UploadIDsWithUnindexedDiagnosticsFunc *DBStoreUploadIDsWithUnindexedDiagnosticsFunc
 }
 
 // NewMockDBStore creates a new mock of the DBStore interface. All methods
@@ -95,11 +116,21 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		GetRetentionPoliciesFunc: &DBStoreGetRetentionPoliciesFunc{
+			defaultHook: func(context.Context) ([]dbstore.RetentionPolicy, error) {
+				return nil, nil
+			},
+		},
 		GetUploadsFunc: &DBStoreGetUploadsFunc{
 			defaultHook: func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
 				return nil, 0, nil
 			},
 		},
+		GetUploadsByIDsFunc: &DBStoreGetUploadsByIDsFunc{
+			defaultHook: func(context.Context, ...int) ([]dbstore.Upload, error) {
+				return nil, nil
+			},
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				return nil
@@ -115,6 +146,11 @@ func NewMockDBStore() *MockDBStore {
 				return 0, 0, nil
 			},
 		},
+		RepositoryIDsForRetentionEvaluationFunc: &DBStoreRepositoryIDsForRetentionEvaluationFunc{
+			defaultHook: func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+				return nil, nil
+			},
+		},
 		SoftDeleteOldUploadsFunc: &DBStoreSoftDeleteOldUploadsFunc{
 			defaultHook: func(context.Context, time.Duration, time.Time) (int, error) {
 				return 0, nil
@@ -130,6 +166,21 @@ func NewMockDBStore() *MockDBStore {
 				return nil, nil
 			},
 		},
+		UpdateDiagnosticsFunc: &DBStoreUpdateDiagnosticsFunc{
+			defaultHook: func(context.Context, int, []dbstore.Diagnostic, time.Time) error {
+				return nil
+			},
+		},
+		UpdateUploadRetentionFunc: &DBStoreUpdateUploadRetentionFunc{
+			defaultHook: func(context.Context, []int, []int, []int, time.Time) error {
+				return nil
+			},
+		},
+		UploadIDsWithUnindexedDiagnosticsFunc: &DBStoreUploadIDsWithUnindexedDiagnosticsFunc{
+			defaultHook: func(context.Context, int) ([]int, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -155,9 +206,15 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		DoneFunc: &DBStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetRetentionPoliciesFunc: &DBStoreGetRetentionPoliciesFunc{
+			defaultHook: i.GetRetentionPolicies,
+		},
 		GetUploadsFunc: &DBStoreGetUploadsFunc{
 			defaultHook: i.GetUploads,
 		},
+		GetUploadsByIDsFunc: &DBStoreGetUploadsByIDsFunc{
+			defaultHook: i.GetUploadsByIDs,
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
@@ -167,6 +224,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		RefreshCommitResolvabilityFunc: &DBStoreRefreshCommitResolvabilityFunc{
 			defaultHook: i.RefreshCommitResolvability,
 		},
+		RepositoryIDsForRetentionEvaluationFunc: &DBStoreRepositoryIDsForRetentionEvaluationFunc{
+			defaultHook: i.RepositoryIDsForRetentionEvaluation,
+		},
 		SoftDeleteOldUploadsFunc: &DBStoreSoftDeleteOldUploadsFunc{
 			defaultHook: i.SoftDeleteOldUploads,
 		},
@@ -176,6 +236,15 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		TransactFunc: &DBStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		UpdateDiagnosticsFunc: &DBStoreUpdateDiagnosticsFunc{
+			defaultHook: i.UpdateDiagnostics,
+		},
+		UpdateUploadRetentionFunc: &DBStoreUpdateUploadRetentionFunc{
+			defaultHook: i.UpdateUploadRetention,
+		},
+		UploadIDsWithUnindexedDiagnosticsFunc: &DBStoreUploadIDsWithUnindexedDiagnosticsFunc{
+			defaultHook: i.UploadIDsWithUnindexedDiagnostics,
+		},
 	}
 }
 
@@ -837,6 +906,113 @@ func (c DBStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreGetRetentionPoliciesFunc describes the behavior when the
+// GetRetentionPolicies method of the parent MockDBStore instance is
+// invoked.
+type DBStoreGetRetentionPoliciesFunc struct {
+	defaultHook func(context.Context) ([]dbstore.RetentionPolicy, error)
+	hooks       []func(context.Context) ([]dbstore.RetentionPolicy, error)
+	history     []DBStoreGetRetentionPoliciesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRetentionPolicies delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetRetentionPolicies(v0 context.Context) ([]dbstore.RetentionPolicy, error) {
+	r0, r1 := m.GetRetentionPoliciesFunc.nextHook()(v0)
+	m.GetRetentionPoliciesFunc.appendCall(DBStoreGetRetentionPoliciesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetRetentionPolicies
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreGetRetentionPoliciesFunc) SetDefaultHook(hook func(context.Context) ([]dbstore.RetentionPolicy, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRetentionPolicies method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreGetRetentionPoliciesFunc) PushHook(hook func(context.Context) ([]dbstore.RetentionPolicy, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreGetRetentionPoliciesFunc) SetDefaultReturn(r0 []dbstore.RetentionPolicy, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreGetRetentionPoliciesFunc) PushReturn(r0 []dbstore.RetentionPolicy, r1 error) {
+	f.PushHook(func(context.Context) ([]dbstore.RetentionPolicy, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreGetRetentionPoliciesFunc) nextHook() func(context.Context) ([]dbstore.RetentionPolicy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetRetentionPoliciesFunc) appendCall(r0 DBStoreGetRetentionPoliciesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetRetentionPoliciesFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetRetentionPoliciesFunc) History() []DBStoreGetRetentionPoliciesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetRetentionPoliciesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetRetentionPoliciesFuncCall is an object that describes an
+// invocation of method GetRetentionPolicies on an instance of MockDBStore.
+type DBStoreGetRetentionPoliciesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.RetentionPolicy
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetRetentionPoliciesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetRetentionPoliciesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreGetUploadsFunc describes the behavior when the GetUploads method
 // of the parent MockDBStore instance is invoked.
 type DBStoreGetUploadsFunc struct {
@@ -948,6 +1124,122 @@ func (c DBStoreGetUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// DBStoreGetUploadsByIDsFunc describes the behavior when the
+// GetUploadsByIDs method of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadsByIDsFunc struct {
+	defaultHook func(context.Context, ...int) ([]dbstore.Upload, error)
+	hooks       []func(context.Context, ...int) ([]dbstore.Upload, error)
+	history     []DBStoreGetUploadsByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsByIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploadsByIDs(v0 context.Context, v1 ...int) ([]dbstore.Upload, error) {
+	r0, r1 := m.GetUploadsByIDsFunc.nextHook()(v0, v1...)
+	m.GetUploadsByIDsFunc.appendCall(DBStoreGetUploadsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadsByIDs
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreGetUploadsByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsByIDs method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreGetUploadsByIDsFunc) PushHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreGetUploadsByIDsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreGetUploadsByIDsFunc) PushReturn(r0 []dbstore.Upload, r1 error) {
+	f.PushHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreGetUploadsByIDsFunc) nextHook() func(context.Context, ...int) ([]dbstore.Upload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetUploadsByIDsFunc) appendCall(r0 DBStoreGetUploadsByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetUploadsByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetUploadsByIDsFunc) History() []DBStoreGetUploadsByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetUploadsByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetUploadsByIDsFuncCall is an object that describes an invocation
+// of method GetUploadsByIDs on an instance of MockDBStore.
+type DBStoreGetUploadsByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c DBStoreGetUploadsByIDsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetUploadsByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreHandleFunc describes the behavior when the Handle method of the
 // parent MockDBStore instance is invoked.
 type DBStoreHandleFunc struct {
@@ -1285,36 +1577,38 @@ func (c DBStoreRefreshCommitResolvabilityFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// DBStoreSoftDeleteOldUploadsFunc describes the behavior when the
-// SoftDeleteOldUploads method of the parent MockDBStore instance is
-// invoked.
-type DBStoreSoftDeleteOldUploadsFunc struct {
-	defaultHook func(context.Context, time.Duration, time.Time) (int, error)
-	hooks       []func(context.Context, time.Duration, time.Time) (int, error)
-	history     []DBStoreSoftDeleteOldUploadsFuncCall
+// DBStoreRepositoryIDsForRetentionEvaluationFunc describes the behavior
+// when the RepositoryIDsForRetentionEvaluation method of the parent
+// MockDBStore instance is invoked.
+type DBStoreRepositoryIDsForRetentionEvaluationFunc struct {
+	defaultHook func(context.Context, time.Duration, int, time.Time) ([]int, error)
+	hooks       []func(context.Context, time.Duration, int, time.Time) ([]int, error)
+	history     []DBStoreRepositoryIDsForRetentionEvaluationFuncCall
 	mutex       sync.Mutex
 }
 
-// SoftDeleteOldUploads delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockDBStore) SoftDeleteOldUploads(v0 context.Context, v1 time.Duration, v2 time.Time) (int, error) {
-	r0, r1 := m.SoftDeleteOldUploadsFunc.nextHook()(v0, v1, v2)
-	m.SoftDeleteOldUploadsFunc.appendCall(DBStoreSoftDeleteOldUploadsFuncCall{v0, v1, v2, r0, r1})
+// RepositoryIDsForRetentionEvaluation delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockDBStore) RepositoryIDsForRetentionEvaluation(v0 context.Context, v1 time.Duration, v2 int, v3 time.Time) ([]int, error) {
+	r0, r1 := m.RepositoryIDsForRetentionEvaluationFunc.nextHook()(v0, v1, v2, v3)
+	m.RepositoryIDsForRetentionEvaluationFunc.appendCall(DBStoreRepositoryIDsForRetentionEvaluationFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the SoftDeleteOldUploads
-// method of the parent MockDBStore instance is invoked and the hook queue
-// is empty.
-func (f *DBStoreSoftDeleteOldUploadsFunc) SetDefaultHook(hook func(context.Context, time.Duration, time.Time) (int, error)) {
+// SetDefaultHook sets function that is called when the
+// RepositoryIDsForRetentionEvaluation method of the parent MockDBStore
+// instance is invoked and the hook queue is empty.
+func (f *DBStoreRepositoryIDsForRetentionEvaluationFunc) SetDefaultHook(hook func(context.Context, time.Duration, int, time.Time) ([]int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SoftDeleteOldUploads method of the parent MockDBStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *DBStoreSoftDeleteOldUploadsFunc) PushHook(hook func(context.Context, time.Duration, time.Time) (int, error)) {
+// RepositoryIDsForRetentionEvaluation method of the parent MockDBStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *DBStoreRepositoryIDsForRetentionEvaluationFunc) PushHook(hook func(context.Context, time.Duration, int, time.Time) ([]int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1322,21 +1616,21 @@ func (f *DBStoreSoftDeleteOldUploadsFunc) PushHook(hook func(context.Context, ti
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreSoftDeleteOldUploadsFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, time.Duration, time.Time) (int, error) {
+func (f *DBStoreRepositoryIDsForRetentionEvaluationFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, int, time.Time) ([]int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreSoftDeleteOldUploadsFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, time.Duration, time.Time) (int, error) {
+func (f *DBStoreRepositoryIDsForRetentionEvaluationFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, time.Duration, int, time.Time) ([]int, error) {
 		return r0, r1
 	})
 }
 
-func (f *DBStoreSoftDeleteOldUploadsFunc) nextHook() func(context.Context, time.Duration, time.Time) (int, error) {
+func (f *DBStoreRepositoryIDsForRetentionEvaluationFunc) nextHook() func(context.Context, time.Duration, int, time.Time) ([]int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1349,26 +1643,28 @@ func (f *DBStoreSoftDeleteOldUploadsFunc) nextHook() func(context.Context, time.
 	return hook
 }
 
-func (f *DBStoreSoftDeleteOldUploadsFunc) appendCall(r0 DBStoreSoftDeleteOldUploadsFuncCall) {
+func (f *DBStoreRepositoryIDsForRetentionEvaluationFunc) appendCall(r0 DBStoreRepositoryIDsForRetentionEvaluationFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreSoftDeleteOldUploadsFuncCall objects
-// describing the invocations of this function.
-func (f *DBStoreSoftDeleteOldUploadsFunc) History() []DBStoreSoftDeleteOldUploadsFuncCall {
+// History returns a sequence of
+// DBStoreRepositoryIDsForRetentionEvaluationFuncCall objects describing the
+// invocations of this function.
+func (f *DBStoreRepositoryIDsForRetentionEvaluationFunc) History() []DBStoreRepositoryIDsForRetentionEvaluationFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreSoftDeleteOldUploadsFuncCall, len(f.history))
+	history := make([]DBStoreRepositoryIDsForRetentionEvaluationFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreSoftDeleteOldUploadsFuncCall is an object that describes an
-// invocation of method SoftDeleteOldUploads on an instance of MockDBStore.
-type DBStoreSoftDeleteOldUploadsFuncCall struct {
+// DBStoreRepositoryIDsForRetentionEvaluationFuncCall is an object that
+// describes an invocation of method RepositoryIDsForRetentionEvaluation on
+// an instance of MockDBStore.
+type DBStoreRepositoryIDsForRetentionEvaluationFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1377,10 +1673,13 @@ type DBStoreSoftDeleteOldUploadsFuncCall struct {
 	Arg1 time.Duration
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 time.Time
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 []int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1388,30 +1687,143 @@ type DBStoreSoftDeleteOldUploadsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreSoftDeleteOldUploadsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c DBStoreRepositoryIDsForRetentionEvaluationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreSoftDeleteOldUploadsFuncCall) Results() []interface{} {
+func (c DBStoreRepositoryIDsForRetentionEvaluationFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreStaleSourcedCommitsFunc describes the behavior when the
-// StaleSourcedCommits method of the parent MockDBStore instance is invoked.
-type DBStoreStaleSourcedCommitsFunc struct {
-	defaultHook func(context.Context, time.Duration, int, time.Time) ([]dbstore.SourcedCommits, error)
-	hooks       []func(context.Context, time.Duration, int, time.Time) ([]dbstore.SourcedCommits, error)
-	history     []DBStoreStaleSourcedCommitsFuncCall
+// DBStoreSoftDeleteOldUploadsFunc describes the behavior when the
+// SoftDeleteOldUploads method of the parent MockDBStore instance is
+// invoked.
+type DBStoreSoftDeleteOldUploadsFunc struct {
+	defaultHook func(context.Context, time.Duration, time.Time) (int, error)
+	hooks       []func(context.Context, time.Duration, time.Time) (int, error)
+	history     []DBStoreSoftDeleteOldUploadsFuncCall
 	mutex       sync.Mutex
 }
 
-// StaleSourcedCommits delegates to the next hook function in the queue and
+// SoftDeleteOldUploads delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockDBStore) StaleSourcedCommits(v0 context.Context, v1 time.Duration, v2 int, v3 time.Time) ([]dbstore.SourcedCommits, error) {
-	r0, r1 := m.StaleSourcedCommitsFunc.nextHook()(v0, v1, v2, v3)
-	m.StaleSourcedCommitsFunc.appendCall(DBStoreStaleSourcedCommitsFuncCall{v0, v1, v2, v3, r0, r1})
+func (m *MockDBStore) SoftDeleteOldUploads(v0 context.Context, v1 time.Duration, v2 time.Time) (int, error) {
+	r0, r1 := m.SoftDeleteOldUploadsFunc.nextHook()(v0, v1, v2)
+	m.SoftDeleteOldUploadsFunc.appendCall(DBStoreSoftDeleteOldUploadsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SoftDeleteOldUploads
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreSoftDeleteOldUploadsFunc) SetDefaultHook(hook func(context.Context, time.Duration, time.Time) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SoftDeleteOldUploads method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreSoftDeleteOldUploadsFunc) PushHook(hook func(context.Context, time.Duration, time.Time) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreSoftDeleteOldUploadsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreSoftDeleteOldUploadsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, time.Duration, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreSoftDeleteOldUploadsFunc) nextHook() func(context.Context, time.Duration, time.Time) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreSoftDeleteOldUploadsFunc) appendCall(r0 DBStoreSoftDeleteOldUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreSoftDeleteOldUploadsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreSoftDeleteOldUploadsFunc) History() []DBStoreSoftDeleteOldUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreSoftDeleteOldUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreSoftDeleteOldUploadsFuncCall is an object that describes an
+// invocation of method SoftDeleteOldUploads on an instance of MockDBStore.
+type DBStoreSoftDeleteOldUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreSoftDeleteOldUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreSoftDeleteOldUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DBStoreStaleSourcedCommitsFunc describes the behavior when the
+// StaleSourcedCommits method of the parent MockDBStore instance is invoked.
+type DBStoreStaleSourcedCommitsFunc struct {
+	defaultHook func(context.Context, time.Duration, int, time.Time) ([]dbstore.SourcedCommits, error)
+	hooks       []func(context.Context, time.Duration, int, time.Time) ([]dbstore.SourcedCommits, error)
+	history     []DBStoreStaleSourcedCommitsFuncCall
+	mutex       sync.Mutex
+}
+
+// StaleSourcedCommits delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) StaleSourcedCommits(v0 context.Context, v1 time.Duration, v2 int, v3 time.Time) ([]dbstore.SourcedCommits, error) {
+	r0, r1 := m.StaleSourcedCommitsFunc.nextHook()(v0, v1, v2, v3)
+	m.StaleSourcedCommitsFunc.appendCall(DBStoreStaleSourcedCommitsFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
@@ -1626,6 +2038,9 @@ type MockLSIFStore struct {
 	// ClearFunc is an instance of a mock function object controlling the
 	// behavior of the method Clear.
 	ClearFunc *LSIFStoreClearFunc
+	// DiagnosticsFunc is an instance of a mock function object controlling
+	// the behavior of the method Diagnostics.
+	DiagnosticsFunc *LSIFStoreDiagnosticsFunc
 }
 
 // NewMockLSIFStore creates a new mock of the LSIFStore interface. All
@@ -1637,6 +2052,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil
 			},
 		},
+		DiagnosticsFunc: &LSIFStoreDiagnosticsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+				return nil, 0, nil
+			},
+		},
 	}
 }
 
@@ -1647,6 +2067,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		ClearFunc: &LSIFStoreClearFunc{
 			defaultHook: i.Clear,
 		},
+		DiagnosticsFunc: &LSIFStoreDiagnosticsFunc{
+			defaultHook: i.Diagnostics,
+		},
 	}
 }
 
@@ -1761,3 +2184,465 @@ func (c LSIFStoreClearFuncCall) Args() []interface{} {
 func (c LSIFStoreClearFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// LSIFStoreDiagnosticsFunc describes the behavior when the Diagnostics
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreDiagnosticsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)
+	hooks       []func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)
+	history     []LSIFStoreDiagnosticsFuncCall
+	mutex       sync.Mutex
+}
+
+// Diagnostics delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) Diagnostics(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]lsifstore.Diagnostic, int, error) {
+	r0, r1, r2 := m.DiagnosticsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.DiagnosticsFunc.appendCall(LSIFStoreDiagnosticsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Diagnostics method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreDiagnosticsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Diagnostics method of the parent MockLSIFStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LSIFStoreDiagnosticsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreDiagnosticsFunc) SetDefaultReturn(r0 []lsifstore.Diagnostic, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreDiagnosticsFunc) PushReturn(r0 []lsifstore.Diagnostic, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreDiagnosticsFunc) nextHook() func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreDiagnosticsFunc) appendCall(r0 LSIFStoreDiagnosticsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreDiagnosticsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreDiagnosticsFunc) History() []LSIFStoreDiagnosticsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreDiagnosticsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreDiagnosticsFuncCall is an object that describes an invocation of
+// method Diagnostics on an instance of MockLSIFStore.
+type LSIFStoreDiagnosticsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.Diagnostic
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreDiagnosticsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreDiagnosticsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// DBStoreUpdateDiagnosticsFunc describes the behavior when the
+// UpdateDiagnostics method of the parent MockDBStore instance is invoked.
+type DBStoreUpdateDiagnosticsFunc struct {
+	defaultHook func(context.Context, int, []dbstore.Diagnostic, time.Time) error
+	hooks       []func(context.Context, int, []dbstore.Diagnostic, time.Time) error
+	history     []DBStoreUpdateDiagnosticsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateDiagnostics delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) UpdateDiagnostics(v0 context.Context, v1 int, v2 []dbstore.Diagnostic, v3 time.Time) error {
+	r0 := m.UpdateDiagnosticsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateDiagnosticsFunc.appendCall(DBStoreUpdateDiagnosticsFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateDiagnostics
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreUpdateDiagnosticsFunc) SetDefaultHook(hook func(context.Context, int, []dbstore.Diagnostic, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateDiagnostics method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreUpdateDiagnosticsFunc) PushHook(hook func(context.Context, int, []dbstore.Diagnostic, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateDiagnosticsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []dbstore.Diagnostic, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateDiagnosticsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []dbstore.Diagnostic, time.Time) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUpdateDiagnosticsFunc) nextHook() func(context.Context, int, []dbstore.Diagnostic, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateDiagnosticsFunc) appendCall(r0 DBStoreUpdateDiagnosticsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreUpdateDiagnosticsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreUpdateDiagnosticsFunc) History() []DBStoreUpdateDiagnosticsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateDiagnosticsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateDiagnosticsFuncCall is an object that describes an
+// invocation of method UpdateDiagnostics on an instance of MockDBStore.
+type DBStoreUpdateDiagnosticsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []dbstore.Diagnostic
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateDiagnosticsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateDiagnosticsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStoreUpdateUploadRetentionFunc describes the behavior when the
+// UpdateUploadRetention method of the parent MockDBStore instance is
+// invoked.
+type DBStoreUpdateUploadRetentionFunc struct {
+	defaultHook func(context.Context, []int, []int, []int, time.Time) error
+	hooks       []func(context.Context, []int, []int, []int, time.Time) error
+	history     []DBStoreUpdateUploadRetentionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateUploadRetention delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) UpdateUploadRetention(v0 context.Context, v1 []int, v2 []int, v3 []int, v4 time.Time) error {
+	r0 := m.UpdateUploadRetentionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateUploadRetentionFunc.appendCall(DBStoreUpdateUploadRetentionFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateUploadRetention method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreUpdateUploadRetentionFunc) SetDefaultHook(hook func(context.Context, []int, []int, []int, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateUploadRetention method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreUpdateUploadRetentionFunc) PushHook(hook func(context.Context, []int, []int, []int, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateUploadRetentionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int, []int, []int, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateUploadRetentionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int, []int, []int, time.Time) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUpdateUploadRetentionFunc) nextHook() func(context.Context, []int, []int, []int, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateUploadRetentionFunc) appendCall(r0 DBStoreUpdateUploadRetentionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreUpdateUploadRetentionFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreUpdateUploadRetentionFunc) History() []DBStoreUpdateUploadRetentionFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateUploadRetentionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateUploadRetentionFuncCall is an object that describes an
+// invocation of method UpdateUploadRetention on an instance of MockDBStore.
+type DBStoreUpdateUploadRetentionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateUploadRetentionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateUploadRetentionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStoreUploadIDsWithUnindexedDiagnosticsFunc describes the behavior when
+// the UploadIDsWithUnindexedDiagnostics method of the parent MockDBStore
+// instance is invoked.
+type DBStoreUploadIDsWithUnindexedDiagnosticsFunc struct {
+	defaultHook func(context.Context, int) ([]int, error)
+	hooks       []func(context.Context, int) ([]int, error)
+	history     []DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall
+	mutex       sync.Mutex
+}
+
+// UploadIDsWithUnindexedDiagnostics delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) UploadIDsWithUnindexedDiagnostics(v0 context.Context, v1 int) ([]int, error) {
+	r0, r1 := m.UploadIDsWithUnindexedDiagnosticsFunc.nextHook()(v0, v1)
+	m.UploadIDsWithUnindexedDiagnosticsFunc.appendCall(DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UploadIDsWithUnindexedDiagnostics method of the parent MockDBStore
+// instance is invoked and the hook queue is empty.
+func (f *DBStoreUploadIDsWithUnindexedDiagnosticsFunc) SetDefaultHook(hook func(context.Context, int) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UploadIDsWithUnindexedDiagnostics method of the parent MockDBStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *DBStoreUploadIDsWithUnindexedDiagnosticsFunc) PushHook(hook func(context.Context, int) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUploadIDsWithUnindexedDiagnosticsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUploadIDsWithUnindexedDiagnosticsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreUploadIDsWithUnindexedDiagnosticsFunc) nextHook() func(context.Context, int) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUploadIDsWithUnindexedDiagnosticsFunc) appendCall(r0 DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall objects describing the
+// invocations of this function.
+func (f *DBStoreUploadIDsWithUnindexedDiagnosticsFunc) History() []DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall is an object that
+// describes an invocation of method UploadIDsWithUnindexedDiagnostics on an
+// instance of MockDBStore.
+type DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUploadIDsWithUnindexedDiagnosticsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -14,6 +14,12 @@ type metrics struct {
 	numUploadResets         prometheus.Counter
 	numErrors               prometheus.Counter
 
+	// Retention metrics
+	numUploadRetentionsEvaluated prometheus.Counter
+
+	// Diagnostics metrics
+	numDiagnosticsIndexed prometheus.Counter
+
 	// Resetter metrics
 	numUploadResetFailures          prometheus.Counter
 	numUploadResetErrors            prometheus.Counter
@@ -55,6 +61,16 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		"The number of errors that occur during a codeintel expiration job.",
 	)
 
+	numUploadRetentionsEvaluated := counter(
+		"src_codeintel_background_upload_retentions_evaluated_total",
+		"The number of codeintel upload records against which retention policies were evaluated.",
+	)
+
+	numDiagnosticsIndexed := counter(
+		"src_codeintel_background_diagnostics_indexed_total",
+		"The number of codeintel diagnostics copied into the frontend database.",
+	)
+
 	numUploadResets := counter(
 		"src_codeintel_background_upload_record_resets_total",
 		"The number of upload record resets.",
@@ -99,6 +115,8 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		numIndexRecordsRemoved:          numIndexRecordsRemoved,
 		numUploadsPurged:                numUploadsPurged,
 		numErrors:                       numErrors,
+		numUploadRetentionsEvaluated:    numUploadRetentionsEvaluated,
+		numDiagnosticsIndexed:           numDiagnosticsIndexed,
 		numUploadResets:                 numUploadResets,
 		numUploadResetFailures:          numUploadResetFailures,
 		numUploadResetErrors:            numUploadResetErrors,
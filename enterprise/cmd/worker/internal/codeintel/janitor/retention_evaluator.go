@@ -0,0 +1,152 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/derision-test/glock"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type retentionPolicyEvaluator struct {
+	dbStore                        DBStore
+	gitserverClient                policies.GitserverClient
+	minimumTimeSinceLastEvaluation time.Duration
+	batchSize                      int
+	metrics                        *metrics
+	clock                          glock.Clock
+}
+
+var _ goroutine.Handler = &retentionPolicyEvaluator{}
+
+// NewRetentionPolicyEvaluator returns a background routine that periodically evaluates the
+// configured retention policies against the completed uploads of each repository. The result
+// is recorded on each upload record and is consulted by the record expirer: uploads retained
+// by a policy are never expired, and uploads of a repository governed by at least one policy
+// are expired as soon as no policy retains them (unless they are visible at the tip of the
+// default branch).
+func NewRetentionPolicyEvaluator(
+	dbStore DBStore,
+	gitserverClient policies.GitserverClient,
+	minimumTimeSinceLastEvaluation time.Duration,
+	batchSize int,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, newRetentionPolicyEvaluator(
+		dbStore,
+		gitserverClient,
+		minimumTimeSinceLastEvaluation,
+		batchSize,
+		metrics,
+		glock.NewRealClock(),
+	))
+}
+
+func newRetentionPolicyEvaluator(
+	dbStore DBStore,
+	gitserverClient policies.GitserverClient,
+	minimumTimeSinceLastEvaluation time.Duration,
+	batchSize int,
+	metrics *metrics,
+	clock glock.Clock,
+) *retentionPolicyEvaluator {
+	return &retentionPolicyEvaluator{
+		dbStore:                        dbStore,
+		gitserverClient:                gitserverClient,
+		minimumTimeSinceLastEvaluation: minimumTimeSinceLastEvaluation,
+		batchSize:                      batchSize,
+		metrics:                        metrics,
+		clock:                          clock,
+	}
+}
+
+func (e *retentionPolicyEvaluator) Handle(ctx context.Context) error {
+	now := e.clock.Now()
+
+	repositoryIDs, err := e.dbStore.RepositoryIDsForRetentionEvaluation(ctx, e.minimumTimeSinceLastEvaluation, e.batchSize, now)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.RepositoryIDsForRetentionEvaluation")
+	}
+	if len(repositoryIDs) == 0 {
+		return nil
+	}
+
+	retentionPolicies, err := e.dbStore.GetRetentionPolicies(ctx)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.GetRetentionPolicies")
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		if err := e.handleRepository(ctx, retentionPolicies, repositoryID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *retentionPolicyEvaluator) HandleError(err error) {
+	e.metrics.numErrors.Inc()
+	log15.Error("Failed to evaluate codeintel retention policies", "error", err)
+}
+
+func (e *retentionPolicyEvaluator) handleRepository(ctx context.Context, retentionPolicies []dbstore.RetentionPolicy, repositoryID int, now time.Time) error {
+	var uploads []dbstore.Upload
+	for {
+		page, totalCount, err := e.dbStore.GetUploads(ctx, dbstore.GetUploadsOptions{
+			RepositoryID: repositoryID,
+			State:        "completed",
+			Limit:        uploadsBatchSize,
+			Offset:       len(uploads),
+		})
+		if err != nil {
+			return errors.Wrap(err, "dbstore.GetUploads")
+		}
+
+		uploads = append(uploads, page...)
+
+		if len(page) == 0 || len(uploads) >= totalCount {
+			break
+		}
+	}
+	if len(uploads) == 0 {
+		return nil
+	}
+
+	retention, governed, err := policies.Evaluate(ctx, e.gitserverClient, retentionPolicies, repositoryID, uploads[0].RepositoryName, uploads, now)
+	if err != nil {
+		return errors.Wrap(err, "policies.Evaluate")
+	}
+
+	var retainedIDs, expiredIDs []int
+	if governed {
+		for _, upload := range uploads {
+			if retention[upload.ID].Retained {
+				retainedIDs = append(retainedIDs, upload.ID)
+			} else {
+				expiredIDs = append(expiredIDs, upload.ID)
+			}
+		}
+	}
+
+	if err := e.dbStore.UpdateUploadRetention(ctx, uploadIDs(uploads), retainedIDs, expiredIDs, now); err != nil {
+		return errors.Wrap(err, "dbstore.UpdateUploadRetention")
+	}
+
+	log15.Debug(
+		"Evaluated retention policies",
+		"repository_id", repositoryID,
+		"governed", governed,
+		"num_retained", len(retainedIDs),
+		"num_expired", len(expiredIDs),
+	)
+	e.metrics.numUploadRetentionsEvaluated.Add(float64(len(uploads)))
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package janitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type testGitserverClient struct {
+	refs map[int][]gitserver.Ref
+}
+
+func (c *testGitserverClient) Refs(ctx context.Context, repositoryID int) ([]gitserver.Ref, error) {
+	return c.refs[repositoryID], nil
+}
+
+func (c *testGitserverClient) CommitsOnBranch(ctx context.Context, repositoryID int, branchName string, maxCount int, since time.Time) ([]gitserver.BranchCommit, error) {
+	return nil, nil
+}
+
+func TestRetentionPolicyEvaluator(t *testing.T) {
+	repositoryPattern := "github.com/sourcegraph/*"
+
+	dbStore := NewMockDBStore()
+	dbStore.RepositoryIDsForRetentionEvaluationFunc.SetDefaultReturn([]int{50, 51}, nil)
+	dbStore.GetRetentionPoliciesFunc.SetDefaultReturn([]dbstore.RetentionPolicy{
+		{ID: 1, Name: "releases", RepositoryPattern: &repositoryPattern, Type: dbstore.RetentionPolicyTypeTag, Pattern: "v*"},
+	}, nil)
+	dbStore.GetUploadsFunc.SetDefaultHook(func(ctx context.Context, opts dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+		if opts.Offset > 0 {
+			return nil, 2, nil
+		}
+
+		switch opts.RepositoryID {
+		case 50:
+			return []dbstore.Upload{
+				{ID: 1, RepositoryID: 50, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "c1"},
+				{ID: 2, RepositoryID: 50, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "c2"},
+			}, 2, nil
+		case 51:
+			return []dbstore.Upload{
+				{ID: 3, RepositoryID: 51, RepositoryName: "github.com/golang/go", Commit: "c3"},
+				{ID: 4, RepositoryID: 51, RepositoryName: "github.com/golang/go", Commit: "c4"},
+			}, 2, nil
+		}
+
+		return nil, 0, nil
+	})
+
+	gitserverClient := &testGitserverClient{
+		refs: map[int][]gitserver.Ref{
+			50: {{Commit: "c1", RefDescription: gitserver.RefDescription{Name: "v1.0.0", Type: gitserver.RefTypeTag}}},
+			51: {{Commit: "c3", RefDescription: gitserver.RefDescription{Name: "v1.0.0", Type: gitserver.RefTypeTag}}},
+		},
+	}
+
+	clock := glock.NewMockClock()
+	evaluator := newRetentionPolicyEvaluator(dbStore, gitserverClient, time.Hour, 10, newMetrics(&observation.TestContext), clock)
+
+	if err := evaluator.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error evaluating retention policies: %s", err)
+	}
+
+	type updateUploadRetentionInvocation struct {
+		UploadIDs   []int
+		RetainedIDs []int
+		ExpiredIDs  []int
+	}
+
+	var calls []updateUploadRetentionInvocation
+	for _, call := range dbStore.UpdateUploadRetentionFunc.History() {
+		calls = append(calls, updateUploadRetentionInvocation{call.Arg1, call.Arg2, call.Arg3})
+	}
+
+	expectedCalls := []updateUploadRetentionInvocation{
+		{UploadIDs: []int{1, 2}, RetainedIDs: []int{1}, ExpiredIDs: []int{2}},
+		{UploadIDs: []int{3, 4}}, // not governed by any policy
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls to UpdateUploadRetention (-want +got):\n%s", diff)
+	}
+}
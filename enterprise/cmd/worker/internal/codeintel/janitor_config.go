@@ -9,12 +9,18 @@ import (
 type janitorConfig struct {
 	env.BaseConfig
 
-	DataTTL                                 time.Duration
-	UploadTimeout                           time.Duration
-	CleanupTaskInterval                     time.Duration
-	CommitResolverTaskInterval              time.Duration
-	CommitResolverMinimumTimeSinceLastCheck time.Duration
-	CommitResolverBatchSize                 int
+	DataTTL                                       time.Duration
+	UploadTimeout                                 time.Duration
+	CleanupTaskInterval                           time.Duration
+	CommitResolverTaskInterval                    time.Duration
+	CommitResolverMinimumTimeSinceLastCheck       time.Duration
+	CommitResolverBatchSize                       int
+	RetentionPolicyTaskInterval                   time.Duration
+	RetentionPolicyMinimumTimeSinceLastEvaluation time.Duration
+	RetentionPolicyRepositoryBatchSize            int
+	DiagnosticsIndexerTaskInterval                time.Duration
+	DiagnosticsIndexerBatchSize                   int
+	DiagnosticsMaxPerUpload                       int
 }
 
 var janitorConfigInst = &janitorConfig{}
@@ -26,4 +32,10 @@ func (c *janitorConfig) Load() {
 	c.CommitResolverTaskInterval = c.GetInterval("PRECISE_CODE_INTEL_COMMIT_RESOLVER_TASK_INTERVAL", "10s", "The frequency with which to run the periodic commit resolver task.")
 	c.CommitResolverMinimumTimeSinceLastCheck = c.GetInterval("PRECISE_CODE_INTEL_COMMIT_RESOLVER_MINIMUM_TIME_SINCE_LAST_CHECK", "24h", "The minimum time the commit resolver will re-check an upload or index record.")
 	c.CommitResolverBatchSize = c.GetInt("PRECISE_CODE_INTEL_COMMIT_RESOLVER_BATCH_SIZE", "100", "The maximum number of unique commits to resolve at a time.")
+	c.RetentionPolicyTaskInterval = c.GetInterval("PRECISE_CODE_INTEL_RETENTION_POLICY_TASK_INTERVAL", "1m", "The frequency with which to run the periodic retention policy evaluation task.")
+	c.RetentionPolicyMinimumTimeSinceLastEvaluation = c.GetInterval("PRECISE_CODE_INTEL_RETENTION_POLICY_MINIMUM_TIME_SINCE_LAST_EVALUATION", "1h", "The minimum time between retention policy evaluations of the uploads of a repository.")
+	c.RetentionPolicyRepositoryBatchSize = c.GetInt("PRECISE_CODE_INTEL_RETENTION_POLICY_REPOSITORY_BATCH_SIZE", "50", "The maximum number of repositories whose uploads are evaluated against retention policies at a time.")
+	c.DiagnosticsIndexerTaskInterval = c.GetInterval("PRECISE_CODE_INTEL_DIAGNOSTICS_INDEXER_TASK_INTERVAL", "10s", "The frequency with which to run the periodic diagnostics indexer task.")
+	c.DiagnosticsIndexerBatchSize = c.GetInt("PRECISE_CODE_INTEL_DIAGNOSTICS_INDEXER_BATCH_SIZE", "100", "The maximum number of uploads whose diagnostics are indexed at a time.")
+	c.DiagnosticsMaxPerUpload = c.GetInt("PRECISE_CODE_INTEL_DIAGNOSTICS_MAX_PER_UPLOAD", "10000", "The maximum number of diagnostics indexed for a single upload.")
 }
@@ -48,6 +48,11 @@ func (j *janitorJob) Routines(ctx context.Context) ([]goroutine.BackgroundRoutin
 		return nil, err
 	}
 
+	gitserverClient, err := InitGitserverClient()
+	if err != nil {
+		return nil, err
+	}
+
 	dbStoreShim := &janitor.DBStoreShim{Store: dbStore}
 	uploadWorkerStore := dbstore.WorkerutilUploadStore(dbStoreShim, observationContext)
 	indexWorkerStore := dbstore.WorkerutilIndexStore(dbStoreShim, observationContext)
@@ -62,6 +67,8 @@ func (j *janitorJob) Routines(ctx context.Context) ([]goroutine.BackgroundRoutin
 		janitor.NewIndexResetter(indexWorkerStore, janitorConfigInst.CleanupTaskInterval, metrics, observationContext),
 		janitor.NewDependencyIndexResetter(dependencyIndexStore, janitorConfigInst.CleanupTaskInterval, metrics, observationContext),
 		janitor.NewUnknownCommitJanitor(dbStoreShim, janitorConfigInst.CommitResolverMinimumTimeSinceLastCheck, janitorConfigInst.CommitResolverBatchSize, janitorConfigInst.CommitResolverTaskInterval, metrics),
+		janitor.NewRetentionPolicyEvaluator(dbStoreShim, gitserverClient, janitorConfigInst.RetentionPolicyMinimumTimeSinceLastEvaluation, janitorConfigInst.RetentionPolicyRepositoryBatchSize, janitorConfigInst.RetentionPolicyTaskInterval, metrics),
+		janitor.NewDiagnosticsIndexer(dbStoreShim, lsifStore, janitorConfigInst.DiagnosticsIndexerBatchSize, janitorConfigInst.DiagnosticsMaxPerUpload, janitorConfigInst.DiagnosticsIndexerTaskInterval, metrics),
 	}
 
 	return routines, nil
@@ -10,9 +10,11 @@ import (
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/internal/authz/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/authz/idpgroups"
 	"github.com/sourcegraph/sourcegraph/internal/authz/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -52,6 +54,7 @@ func ProvidersFromConfig(
 			extsvc.KindGitHub,
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
+			extsvc.KindBitbucketCloud,
 			extsvc.KindPerforce,
 		},
 		LimitOffset: &database.LimitOffset{
@@ -63,6 +66,7 @@ func ProvidersFromConfig(
 		gitHubConns          []*types.GitHubConnection
 		gitLabConns          []*types.GitLabConnection
 		bitbucketServerConns []*types.BitbucketServerConnection
+		bitbucketCloudConns  []*types.BitbucketCloudConnection
 		perforceConns        []*types.PerforceConnection
 	)
 	for {
@@ -99,6 +103,11 @@ func ProvidersFromConfig(
 					URN:                       svc.URN(),
 					BitbucketServerConnection: c,
 				})
+			case *schema.BitbucketCloudConnection:
+				bitbucketCloudConns = append(bitbucketCloudConns, &types.BitbucketCloudConnection{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				})
 			case *schema.PerforceConnection:
 				perforceConns = append(perforceConns, &types.PerforceConnection{
 					URN:                svc.URN(),
@@ -137,6 +146,13 @@ func ProvidersFromConfig(
 		warnings = append(warnings, bbsWarnings...)
 	}
 
+	if len(bitbucketCloudConns) > 0 {
+		bbcProviders, bbcProblems, bbcWarnings := bitbucketcloud.NewAuthzProviders(bitbucketCloudConns, cfg.AuthProviders)
+		providers = append(providers, bbcProviders...)
+		seriousProblems = append(seriousProblems, bbcProblems...)
+		warnings = append(warnings, bbcWarnings...)
+	}
+
 	if len(perforceConns) > 0 {
 		pfProviders, pfProblems, pfWarnings := perforce.NewAuthzProviders(perforceConns)
 		providers = append(providers, pfProviders...)
@@ -144,6 +160,11 @@ func ProvidersFromConfig(
 		warnings = append(warnings, pfWarnings...)
 	}
 
+	idpProviders, idpProblems, idpWarnings := idpgroups.NewAuthzProviders(ctx, cfg.AuthProviders)
+	providers = append(providers, idpProviders...)
+	seriousProblems = append(seriousProblems, idpProblems...)
+	warnings = append(warnings, idpWarnings...)
+
 	// 🚨 SECURITY: Warn the admin when both code host authz provider and the permissions user mapping are configured.
 	if cfg.SiteConfiguration.PermissionsUserMapping != nil &&
 		cfg.SiteConfiguration.PermissionsUserMapping.Enabled {
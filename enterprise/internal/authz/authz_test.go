@@ -83,6 +83,7 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		cfg                          conf.Unified
 		gitlabConnections            []*schema.GitLabConnection
 		bitbucketServerConnections   []*schema.BitbucketServerConnection
+		bitbucketCloudConnections    []*schema.BitbucketCloudConnection
 		expAuthzAllowAccessByDefault bool
 		expAuthzProviders            func(*testing.T, []authz.Provider)
 		expSeriousProblems           []string
@@ -394,6 +395,54 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			description: "1 Bitbucket Cloud connection with authz enabled, 1 Bitbucket Cloud matching auth provider",
+			cfg: conf.Unified{
+				SiteConfiguration: schema.SiteConfiguration{
+					AuthProviders: []schema.AuthProviders{{
+						Bitbucketcloud: &schema.BitbucketCloudAuthProvider{
+							ClientKey:    "clientKey",
+							ClientSecret: "clientSecret",
+							Type:         "bitbucketcloud",
+						},
+					}},
+				},
+			},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{},
+					Url:           "https://bitbucket.org",
+					Username:      "admin",
+					AppPassword:   "secret-password",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) == 0 {
+					t.Fatalf("no providers")
+				}
+
+				if have[0].ServiceType() != extsvc.TypeBitbucketCloud {
+					t.Fatalf("no Bitbucket Cloud authz provider returned")
+				}
+			},
+		},
+		{
+			description: "1 Bitbucket Cloud connection with authz enabled, no Bitbucket Cloud auth provider",
+			cfg: conf.Unified{
+				SiteConfiguration: schema.SiteConfiguration{},
+			},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{},
+					Url:           "https://bitbucket.org",
+					Username:      "admin",
+					AppPassword:   "secret-password",
+				},
+			},
+			expAuthzAllowAccessByDefault: false,
+			expSeriousProblems:           []string{"Did not find authentication provider matching \"https://bitbucket.org\". Check the [**site configuration**](/site-admin/configuration) to verify an entry in [`auth.providers`](https://docs.sourcegraph.com/admin/auth) exists for https://bitbucket.org."},
+		},
 
 		// For Sourcegraph authz provider
 		{
@@ -466,6 +515,7 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		store := fakeStore{
 			gitlabs:          test.gitlabConnections,
 			bitbucketServers: test.bitbucketServerConnections,
+			bitbucketClouds:  test.bitbucketCloudConnections,
 		}
 
 		allowAccessByDefault, authzProviders, seriousProblems, _ := ProvidersFromConfig(
@@ -505,6 +555,7 @@ type fakeStore struct {
 	gitlabs          []*schema.GitLabConnection
 	githubs          []*schema.GitHubConnection
 	bitbucketServers []*schema.BitbucketServerConnection
+	bitbucketClouds  []*schema.BitbucketCloudConnection
 	perforces        []*schema.PerforceConnection
 }
 
@@ -541,6 +592,13 @@ func (s fakeStore) List(ctx context.Context, opt database.ExternalServicesListOp
 					Config: mustMarshalJSONString(bbs),
 				})
 			}
+		case extsvc.KindBitbucketCloud:
+			for _, bbc := range s.bitbucketClouds {
+				svcs = append(svcs, &types.ExternalService{
+					Kind:   kind,
+					Config: mustMarshalJSONString(bbc),
+				})
+			}
 		case extsvc.KindPerforce:
 			for _, p := range s.perforces {
 				svcs = append(svcs, &types.ExternalService{
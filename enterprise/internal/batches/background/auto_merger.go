@@ -0,0 +1,148 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/config"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// newAutoMergeWorker returns a background routine that periodically merges
+// the changesets of batch changes with auto-merge enabled once their checks
+// passed and they have been approved.
+//
+// The worker is driven by the state the changeset syncer stores on the
+// changesets: a changeset is only considered again after it has been updated
+// since the last decision made for it. Every decision is recorded as a
+// changeset event of kind ChangesetEventKindAutoMerge.
+func newAutoMergeWorker(ctx context.Context, s *store.Store, sourcer sources.Sourcer) goroutine.BackgroundRoutine {
+	m := &autoMerger{store: s, sourcer: sourcer}
+	handler := goroutine.NewHandlerWithErrorMessage("auto-merge batch changes changesets", m.run)
+	return goroutine.NewPeriodicGoroutine(ctx, 1*time.Minute, handler)
+}
+
+type autoMerger struct {
+	store   *store.Store
+	sourcer sources.Sourcer
+}
+
+func (m *autoMerger) run(ctx context.Context) error {
+	batchChanges, _, err := m.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+		State:                btypes.BatchChangeStateOpen,
+		OnlyAutoMergeEnabled: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing batch changes")
+	}
+
+	windowOpen := config.ActiveWindow().IsOpen(m.store.Clock()())
+
+	var errs *multierror.Error
+	for _, batchChange := range batchChanges {
+		if err := m.processBatchChange(ctx, batchChange, windowOpen); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "batch change %d", batchChange.ID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (m *autoMerger) processBatchChange(ctx context.Context, batchChange *btypes.BatchChange, windowOpen bool) error {
+	published := btypes.ChangesetPublicationStatePublished
+	changesets, _, err := m.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		OwnedByBatchChangeID:         batchChange.ID,
+		PublicationState:             &published,
+		ReconcilerStates:             []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+		ExternalStates:               []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+		OnlyWithoutAutoMergeDecision: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	var errs *multierror.Error
+	for _, ch := range changesets {
+		decision := decideAutoMerge(batchChange, ch, windowOpen, m.store.Clock()())
+		if decision.Merged {
+			if err := m.merge(ctx, batchChange, ch); err != nil {
+				log15.Warn("auto-merging changeset failed", "changeset", ch.ID, "err", err)
+				decision.Merged = false
+				decision.SkipReason = btypes.ChangesetAutoMergeSkipReasonMergeFailed
+				decision.Message = err.Error()
+			}
+		}
+
+		if err := m.store.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+			ChangesetID: ch.ID,
+			Kind:        btypes.ChangesetEventKindAutoMerge,
+			Key:         decision.Key(),
+			Metadata:    decision,
+		}); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "recording auto-merge decision for changeset %d", ch.ID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// decideAutoMerge decides whether the given changeset should be merged now.
+func decideAutoMerge(batchChange *btypes.BatchChange, ch *btypes.Changeset, windowOpen bool, now time.Time) *btypes.ChangesetAutoMergeDecision {
+	decision := &btypes.ChangesetAutoMergeDecision{
+		Strategy:  batchChange.AutoMergeStrategy,
+		DecidedAt: now,
+	}
+
+	switch {
+	case ch.ExternalCheckState != btypes.ChangesetCheckStatePassed:
+		decision.SkipReason = btypes.ChangesetAutoMergeSkipReasonChecksNotPassed
+	case ch.ExternalReviewState != btypes.ChangesetReviewStateApproved:
+		decision.SkipReason = btypes.ChangesetAutoMergeSkipReasonReviewNotApproved
+	case !windowOpen:
+		decision.SkipReason = btypes.ChangesetAutoMergeSkipReasonOutsideRolloutWindow
+	default:
+		decision.Merged = true
+	}
+
+	return decision
+}
+
+func (m *autoMerger) merge(ctx context.Context, batchChange *btypes.BatchChange, ch *btypes.Changeset) (err error) {
+	// Merge on behalf of the user that last applied the batch change to
+	// enforce repository permissions.
+	ctx = actor.WithActor(ctx, actor.FromUser(batchChange.LastApplierID))
+
+	repo, err := m.store.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "loading repo")
+	}
+
+	css, err := m.sourcer.ForRepo(ctx, m.store, repo)
+	if err != nil {
+		return errors.Wrap(err, "loading ChangesetSource")
+	}
+	css, err = sources.WithAuthenticatorForUser(ctx, m.store, css, batchChange.LastApplierID, repo)
+	if err != nil {
+		return errors.Wrap(err, "authenticating ChangesetSource")
+	}
+
+	cs := &sources.Changeset{Changeset: ch, Repo: repo}
+	squash := batchChange.AutoMergeStrategy == btypes.BatchChangeAutoMergeStrategySquash
+	if err := css.MergeChangeset(ctx, cs, squash); err != nil {
+		return err
+	}
+
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	return updateChangesetCodeHostState(ctx, tx, cs)
+}
@@ -0,0 +1,196 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestAutoMergerProcessBatchChange(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	now := timeutil.Now()
+	clock := func() time.Time { return now }
+	bstore := store.NewWithClock(db, nil, clock)
+
+	user := ct.CreateTestUser(t, db, true)
+	repos, _ := ct.CreateTestRepos(t, ctx, db, 1)
+	repo := repos[0]
+	ct.CreateTestSiteCredential(t, bstore, repo)
+
+	batchSpec := ct.CreateBatchSpec(t, ctx, bstore, "test-auto-merge", user.ID)
+	batchChange := ct.BuildBatchChange(bstore, "test-auto-merge", user.ID, batchSpec.ID)
+	batchChange.AutoMergeStrategy = btypes.BatchChangeAutoMergeStrategySquash
+	if err := bstore.CreateBatchChange(ctx, batchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	createChangeset := func(t *testing.T, checkState btypes.ChangesetCheckState) *btypes.Changeset {
+		t.Helper()
+		return ct.CreateChangeset(t, ctx, bstore, ct.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChange:         batchChange.ID,
+			OwnedByBatchChange:  batchChange.ID,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  checkState,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+			ExternalID:          "123",
+			Metadata:            &github.PullRequest{},
+		})
+	}
+
+	loadDecision := func(t *testing.T, ch *btypes.Changeset) *btypes.ChangesetAutoMergeDecision {
+		t.Helper()
+		events, _, err := bstore.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+			ChangesetIDs: []int64{ch.ID},
+			Kinds:        []btypes.ChangesetEventKind{btypes.ChangesetEventKindAutoMerge},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("wrong number of auto-merge events. want=1, have=%d", len(events))
+		}
+		return events[0].Metadata.(*btypes.ChangesetAutoMergeDecision)
+	}
+
+	t.Run("merges ready changesets", func(t *testing.T) {
+		ready := createChangeset(t, btypes.ChangesetCheckStatePassed)
+		pending := createChangeset(t, btypes.ChangesetCheckStatePending)
+		t.Cleanup(func() {
+			for _, ch := range []*btypes.Changeset{ready, pending} {
+				if err := bstore.DeleteChangeset(ctx, ch.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+
+		fake := &sources.FakeChangesetSource{}
+		m := &autoMerger{store: bstore, sourcer: sources.NewFakeSourcer(nil, fake)}
+		if err := m.processBatchChange(ctx, batchChange, true); err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+
+		want := &btypes.ChangesetAutoMergeDecision{Merged: true, Strategy: btypes.BatchChangeAutoMergeStrategySquash, DecidedAt: now}
+		if diff := cmp.Diff(want, loadDecision(t, ready)); diff != "" {
+			t.Fatalf("wrong decision (-want +have):\n%s", diff)
+		}
+		want = &btypes.ChangesetAutoMergeDecision{SkipReason: btypes.ChangesetAutoMergeSkipReasonChecksNotPassed, Strategy: btypes.BatchChangeAutoMergeStrategySquash, DecidedAt: now}
+		if diff := cmp.Diff(want, loadDecision(t, pending)); diff != "" {
+			t.Fatalf("wrong decision (-want +have):\n%s", diff)
+		}
+
+		// Changesets with a decision are not considered again until they
+		// have been updated.
+		fake.MergeChangesetCalled = false
+		if err := m.processBatchChange(ctx, batchChange, true); err != nil {
+			t.Fatal(err)
+		}
+		if fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset not to be called again")
+		}
+	})
+
+	t.Run("records failed merges", func(t *testing.T) {
+		ch := createChangeset(t, btypes.ChangesetCheckStatePassed)
+		t.Cleanup(func() {
+			if err := bstore.DeleteChangeset(ctx, ch.ID); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		fake := &sources.FakeChangesetSource{Err: errors.New("merge conflict")}
+		m := &autoMerger{store: bstore, sourcer: sources.NewFakeSourcer(nil, fake)}
+		if err := m.processBatchChange(ctx, batchChange, true); err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+
+		want := &btypes.ChangesetAutoMergeDecision{
+			SkipReason: btypes.ChangesetAutoMergeSkipReasonMergeFailed,
+			Strategy:   btypes.BatchChangeAutoMergeStrategySquash,
+			Message:    "merge conflict",
+			DecidedAt:  now,
+		}
+		if diff := cmp.Diff(want, loadDecision(t, ch)); diff != "" {
+			t.Fatalf("wrong decision (-want +have):\n%s", diff)
+		}
+	})
+}
+
+func TestDecideAutoMerge(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	batchChange := &btypes.BatchChange{AutoMergeStrategy: btypes.BatchChangeAutoMergeStrategySquash}
+
+	for name, tc := range map[string]struct {
+		checkState  btypes.ChangesetCheckState
+		reviewState btypes.ChangesetReviewState
+		windowOpen  bool
+		want        *btypes.ChangesetAutoMergeDecision
+	}{
+		"ready": {
+			checkState:  btypes.ChangesetCheckStatePassed,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			want:        &btypes.ChangesetAutoMergeDecision{Merged: true, Strategy: btypes.BatchChangeAutoMergeStrategySquash, DecidedAt: now},
+		},
+		"checks pending": {
+			checkState:  btypes.ChangesetCheckStatePending,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			want:        &btypes.ChangesetAutoMergeDecision{SkipReason: btypes.ChangesetAutoMergeSkipReasonChecksNotPassed, Strategy: btypes.BatchChangeAutoMergeStrategySquash, DecidedAt: now},
+		},
+		"checks unknown": {
+			checkState:  btypes.ChangesetCheckStateUnknown,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			want:        &btypes.ChangesetAutoMergeDecision{SkipReason: btypes.ChangesetAutoMergeSkipReasonChecksNotPassed, Strategy: btypes.BatchChangeAutoMergeStrategySquash, DecidedAt: now},
+		},
+		"changes requested": {
+			checkState:  btypes.ChangesetCheckStatePassed,
+			reviewState: btypes.ChangesetReviewStateChangesRequested,
+			windowOpen:  true,
+			want:        &btypes.ChangesetAutoMergeDecision{SkipReason: btypes.ChangesetAutoMergeSkipReasonReviewNotApproved, Strategy: btypes.BatchChangeAutoMergeStrategySquash, DecidedAt: now},
+		},
+		"window closed": {
+			checkState:  btypes.ChangesetCheckStatePassed,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  false,
+			want:        &btypes.ChangesetAutoMergeDecision{SkipReason: btypes.ChangesetAutoMergeSkipReasonOutsideRolloutWindow, Strategy: btypes.BatchChangeAutoMergeStrategySquash, DecidedAt: now},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ch := &btypes.Changeset{
+				ExternalCheckState:  tc.checkState,
+				ExternalReviewState: tc.reviewState,
+			}
+			have := decideAutoMerge(batchChange, ch, tc.windowOpen, now)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong decision (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
@@ -37,7 +37,13 @@ func Routines(ctx context.Context, batchesStore *store.Store, cf *httpcli.Factor
 		newBulkOperationWorker(ctx, batchesStore, sourcer, metrics),
 		newBulkOperationWorkerResetter(batchesStore, metrics),
 
+		newAutoMergeWorker(ctx, batchesStore, sourcer),
+		newDependencyEnqueuer(ctx, batchesStore),
+		newContinuousExecutor(ctx, batchesStore),
+
 		newBatchSpecExecutionResetter(batchesStore, observationContext, metrics),
+		newBatchSpecWorkspaceExecutionResetter(batchesStore, observationContext, metrics),
+		newBatchSpecAssemblerWorker(ctx, batchesStore, metrics),
 	}
 	return routines
 }
@@ -0,0 +1,126 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// newBatchSpecAssemblerWorker creates a dbworker.Worker that assembles the
+// batch spec of a batch spec execution once all of its workspace execution
+// jobs have finished.
+func newBatchSpecAssemblerWorker(ctx context.Context, s *store.Store, metrics batchChangesMetrics) *workerutil.Worker {
+	a := &batchSpecAssembler{store: s}
+
+	options := workerutil.WorkerOptions{
+		Name:              "batch_spec_assembler",
+		NumHandlers:       5,
+		HeartbeatInterval: 15 * time.Second,
+		Interval:          5 * time.Second,
+		Metrics:           metrics.batchSpecAssemblerWorkerMetrics,
+	}
+
+	workerStore := createBatchSpecAssemblerDBWorkerStore(s)
+
+	worker := dbworker.NewWorker(ctx, workerStore, a.HandlerFunc(), options)
+	return worker
+}
+
+func createBatchSpecAssemblerDBWorkerStore(s *store.Store) dbworkerstore.Store {
+	return dbworkerstore.New(s.Handle(), dbworkerstore.Options{
+		Name:      "batch_spec_assembler_worker_store",
+		TableName: "batch_spec_executions",
+		// Only executions whose workspace execution jobs have all finished
+		// are ready to be assembled.
+		ViewName:          "batch_spec_executions_pending_assembly batch_spec_executions",
+		ColumnExpressions: store.BatchSpecExecutionColumns,
+		Scan:              scanFirstExecutionRecord,
+		OrderByExpression: sqlf.Sprintf("batch_spec_executions.created_at, batch_spec_executions.id"),
+		StalledMaxAge:     executorStalledJobMaximumAge,
+		MaxNumResets:      executorMaximumNumResets,
+		// Explicitly disable retries.
+		MaxNumRetries: 0,
+	})
+}
+
+// batchSpecAssembler creates the batch spec of a batch spec execution out of
+// the changeset specs that its workspace execution jobs created.
+type batchSpecAssembler struct {
+	store *store.Store
+}
+
+func (a *batchSpecAssembler) HandlerFunc() workerutil.HandlerFunc {
+	return func(ctx context.Context, record workerutil.Record) (err error) {
+		tx, err := a.store.Transact(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if doneErr := tx.Done(err); doneErr != nil {
+				err = multierror.Append(err, doneErr)
+			}
+		}()
+
+		return assembleBatchSpec(ctx, tx, record.(*btypes.BatchSpecExecution))
+	}
+}
+
+var errAllWorkspacesFailed = errors.New("the execution failed in all workspaces")
+
+// assembleBatchSpec creates the batch spec for the given execution and
+// attaches the changeset specs created by its completed workspace execution
+// jobs. Like `src batch preview -skip-errors`, workspaces that failed are
+// skipped, unless all of them failed.
+func assembleBatchSpec(ctx context.Context, tx *store.Store, exec *btypes.BatchSpecExecution) error {
+	jobs, err := tx.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+		BatchSpecExecutionID: exec.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var randIDs []string
+	completed := 0
+	for _, j := range jobs {
+		if j.State != btypes.BatchSpecWorkspaceExecutionJobStateCompleted {
+			continue
+		}
+		completed++
+		randIDs = append(randIDs, j.ChangesetSpecRandIDs...)
+	}
+	if len(jobs) > 0 && completed == 0 {
+		return errAllWorkspacesFailed
+	}
+
+	// 🚨 SECURITY: The batch spec is created on behalf of the user that
+	// started the execution, so that their permissions are enforced.
+	ctx = actor.WithActor(ctx, actor.FromUser(exec.UserID))
+
+	spec, err := service.New(tx).CreateBatchSpec(ctx, service.CreateBatchSpecOpts{
+		RawSpec:              exec.BatchSpec,
+		NamespaceUserID:      exec.NamespaceUserID,
+		NamespaceOrgID:       exec.NamespaceOrgID,
+		ChangesetSpecRandIDs: randIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(setBatchSpecExecutionBatchSpecQueryFmtstr, spec.ID, exec.ID))
+}
+
+const setBatchSpecExecutionBatchSpecQueryFmtstr = `
+-- source: enterprise/internal/batches/background/batch_spec_assembler.go:assembleBatchSpec
+UPDATE batch_spec_executions SET batch_spec_id = %s WHERE id = %s
+`
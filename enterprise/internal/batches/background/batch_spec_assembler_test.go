@@ -0,0 +1,95 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestAssembleBatchSpec(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+	user := ct.CreateTestUser(t, db, true)
+	repos, _ := ct.CreateTestRepos(t, ctx, db, 2)
+
+	s := store.New(db, nil)
+
+	createExecution := func(t *testing.T, states ...btypes.BatchSpecWorkspaceExecutionJobState) (*btypes.BatchSpecExecution, []*btypes.ChangesetSpec) {
+		t.Helper()
+
+		exec := &btypes.BatchSpecExecution{
+			BatchSpec:       "name: testing",
+			UserID:          user.ID,
+			NamespaceUserID: user.ID,
+		}
+		if err := s.CreateBatchSpecExecution(ctx, exec); err != nil {
+			t.Fatal(err)
+		}
+
+		var specs []*btypes.ChangesetSpec
+		for i, state := range states {
+			spec := ct.CreateChangesetSpec(t, ctx, s, ct.TestSpecOpts{User: user.ID, Repo: repos[i].ID, HeadRef: "refs/heads/testing"})
+			specs = append(specs, spec)
+
+			job := &btypes.BatchSpecWorkspaceExecutionJob{
+				BatchSpecExecutionID: exec.ID,
+				RepoID:               repos[i].ID,
+				Branch:               "refs/heads/main",
+				Commit:               "d34db33f",
+				CacheKey:             "key",
+			}
+			if err := s.CreateBatchSpecWorkspaceExecutionJobs(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+			q := sqlf.Sprintf(
+				"UPDATE batch_spec_workspace_execution_jobs SET state = %s, changeset_spec_rand_ids = ARRAY[%s] WHERE id = %s",
+				state, spec.RandID, job.ID,
+			)
+			if err := s.Exec(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return exec, specs
+	}
+
+	t.Run("skips failed workspaces", func(t *testing.T) {
+		exec, specs := createExecution(t, btypes.BatchSpecWorkspaceExecutionJobStateCompleted, btypes.BatchSpecWorkspaceExecutionJobStateFailed)
+
+		if err := assembleBatchSpec(ctx, s, exec); err != nil {
+			t.Fatal(err)
+		}
+
+		reloaded, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: exec.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reloaded.BatchSpecID == 0 {
+			t.Fatal("batch spec not set on execution")
+		}
+
+		for i, want := range []int64{reloaded.BatchSpecID, 0} {
+			spec, err := s.GetChangesetSpecByID(ctx, specs[i].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spec.BatchSpecID != want {
+				t.Errorf("changeset spec %d attached to wrong batch spec. want=%d, have=%d", i, want, spec.BatchSpecID)
+			}
+		}
+	})
+
+	t.Run("all workspaces failed", func(t *testing.T) {
+		exec, _ := createExecution(t, btypes.BatchSpecWorkspaceExecutionJobStateFailed)
+
+		if err := assembleBatchSpec(ctx, s, exec); err != errAllWorkspacesFailed {
+			t.Fatalf("wrong error. want=%s, have=%v", errAllWorkspacesFailed, err)
+		}
+	})
+}
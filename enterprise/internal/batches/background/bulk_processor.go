@@ -150,24 +150,7 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return err
 	}
 
-	events, err := cs.Changeset.Events()
-	if err != nil {
-		log15.Error("Events", "err", err)
-		return errcode.MakeNonRetryable(err)
-	}
-	state.SetDerivedState(ctx, b.tx.Repos(), cs.Changeset, events)
-
-	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
-		log15.Error("UpsertChangesetEvents", "err", err)
-		return errcode.MakeNonRetryable(err)
-	}
-
-	if err := b.tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
-		log15.Error("UpdateChangeset", "err", err)
-		return errcode.MakeNonRetryable(err)
-	}
-
-	return nil
+	return updateChangesetCodeHostState(ctx, b.tx, cs)
 }
 
 func (b *bulkProcessor) closeChangeset(ctx context.Context, job *btypes.ChangesetJob) (err error) {
@@ -179,19 +162,25 @@ func (b *bulkProcessor) closeChangeset(ctx context.Context, job *btypes.Changese
 		return err
 	}
 
+	return updateChangesetCodeHostState(ctx, b.tx, cs)
+}
+
+// updateChangesetCodeHostState persists the code host state of the changeset
+// after it has been modified on the code host through a ChangesetSource.
+func updateChangesetCodeHostState(ctx context.Context, tx *store.Store, cs *sources.Changeset) error {
 	events, err := cs.Changeset.Events()
 	if err != nil {
 		log15.Error("Events", "err", err)
 		return errcode.MakeNonRetryable(err)
 	}
-	state.SetDerivedState(ctx, b.tx.Repos(), cs.Changeset, events)
+	state.SetDerivedState(ctx, tx.Repos(), cs.Changeset, events)
 
-	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
 		log15.Error("UpsertChangesetEvents", "err", err)
 		return errcode.MakeNonRetryable(err)
 	}
 
-	if err := b.tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+	if err := tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
 		log15.Error("UpdateChangeset", "err", err)
 		return errcode.MakeNonRetryable(err)
 	}
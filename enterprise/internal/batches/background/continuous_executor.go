@@ -0,0 +1,285 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/rewirer"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// newContinuousExecutor returns a background routine that periodically
+// executes the current batch spec of batch changes with continuous execution
+// enabled again, and applies the resulting batch spec according to the apply
+// policy of the batch change.
+//
+// Every execution resolves the `on` section of the batch spec again, so that
+// repositories that started matching since the last execution are picked up.
+// Workspaces whose repository didn't change hit the execution cache, so only
+// new and changed workspaces are actually executed.
+func newContinuousExecutor(ctx context.Context, s *store.Store) goroutine.BackgroundRoutine {
+	e := &continuousExecutor{store: s}
+	handler := goroutine.NewHandlerWithErrorMessage("continuously execute batch changes", e.run)
+	return goroutine.NewPeriodicGoroutine(ctx, 1*time.Minute, handler)
+}
+
+type continuousExecutor struct {
+	store *store.Store
+}
+
+func (e *continuousExecutor) run(ctx context.Context) error {
+	batchChanges, _, err := e.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+		State:                 btypes.BatchChangeStateOpen,
+		OnlyContinuousEnabled: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing batch changes")
+	}
+
+	var errs *multierror.Error
+	for _, batchChange := range batchChanges {
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "batch change %d", batchChange.ID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (e *continuousExecutor) processBatchChange(ctx context.Context, batchChange *btypes.BatchChange) error {
+	run, err := e.store.GetLatestBatchChangeContinuousRun(ctx, batchChange.ID)
+	if err != nil && err != store.ErrNoResults {
+		return errors.Wrap(err, "loading latest continuous run")
+	}
+
+	if run != nil && !run.State.Finished() {
+		return e.finishRun(ctx, batchChange, run)
+	}
+
+	// The next run is due one interval after the last run was started or the
+	// batch change was last applied, whichever happened later.
+	last := batchChange.LastAppliedAt
+	if run != nil && run.CreatedAt.After(last) {
+		last = run.CreatedAt
+	}
+	if e.store.Clock()().Before(last.Add(batchChange.ContinuousInterval())) {
+		return nil
+	}
+
+	return e.startRun(ctx, batchChange)
+}
+
+// startRun enqueues a new execution of the current batch spec of the batch
+// change and records it as a continuous run.
+func (e *continuousExecutor) startRun(ctx context.Context, batchChange *btypes.BatchChange) (err error) {
+	if batchChange.LastApplierID == 0 {
+		return errors.New("batch change has no last applier to execute the batch spec as")
+	}
+
+	batchSpec, err := e.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+
+	tx, err := e.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	exec := &btypes.BatchSpecExecution{
+		BatchSpec:       batchSpec.RawSpec,
+		UserID:          batchChange.LastApplierID,
+		NamespaceUserID: batchChange.NamespaceUserID,
+		NamespaceOrgID:  batchChange.NamespaceOrgID,
+	}
+	if err := tx.CreateBatchSpecExecution(ctx, exec); err != nil {
+		return errors.Wrap(err, "creating batch spec execution")
+	}
+
+	return tx.CreateBatchChangeContinuousRun(ctx, &btypes.BatchChangeContinuousRun{
+		BatchChangeID:        batchChange.ID,
+		BatchSpecExecutionID: exec.ID,
+	})
+}
+
+// finishRun checks whether the execution of the given run has finished and,
+// if so, applies its batch spec or records why it wasn't applied.
+func (e *continuousExecutor) finishRun(ctx context.Context, batchChange *btypes.BatchChange, run *btypes.BatchChangeContinuousRun) error {
+	exec, err := e.store.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: run.BatchSpecExecutionID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec execution")
+	}
+
+	switch exec.State {
+	case btypes.BatchSpecExecutionStateQueued, btypes.BatchSpecExecutionStateProcessing:
+		return nil
+
+	case btypes.BatchSpecExecutionStateFailed, btypes.BatchSpecExecutionStateErrored:
+		message := "the execution failed"
+		if exec.FailureMessage != nil {
+			message = *exec.FailureMessage
+		}
+		return e.updateRun(ctx, run, btypes.BatchChangeContinuousRunStateFailed, message)
+	}
+
+	if batchChange.LastAppliedAt.After(run.CreatedAt) {
+		return e.updateRun(ctx, run, btypes.BatchChangeContinuousRunStateSkipped, "the batch change was applied while the run was executing")
+	}
+
+	// Preview and apply on behalf of the user that last applied the batch
+	// change to enforce repository permissions.
+	ctx = actor.WithActor(ctx, actor.FromUser(batchChange.LastApplierID))
+
+	batchSpec, err := e.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: exec.BatchSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return e.updateRun(ctx, run, btypes.BatchChangeContinuousRunStateFailed, "the batch spec of the execution no longer exists")
+		}
+		return errors.Wrap(err, "loading batch spec")
+	}
+
+	mappings, err := e.store.GetRewirerMappings(ctx, store.GetRewirerMappingsOpts{
+		BatchSpecID:   batchSpec.ID,
+		BatchChangeID: batchChange.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "loading rewirer mappings")
+	}
+
+	changes, err := summarizeContinuousRun(ctx, e.store, batchChange.ID, mappings)
+	if err != nil {
+		return e.updateRun(ctx, run, btypes.BatchChangeContinuousRunStateFailed, err.Error())
+	}
+
+	if apply, reason := decideContinuousApply(batchChange.ContinuousApplyPolicy, changes); !apply {
+		return e.updateRun(ctx, run, btypes.BatchChangeContinuousRunStateSkipped, reason)
+	}
+
+	if _, err := service.New(e.store).ApplyBatchChange(ctx, service.ApplyBatchChangeOpts{
+		BatchSpecRandID:     batchSpec.RandID,
+		EnsureBatchChangeID: batchChange.ID,
+	}); err != nil {
+		return e.updateRun(ctx, run, btypes.BatchChangeContinuousRunStateFailed, err.Error())
+	}
+
+	return e.updateRun(ctx, run, btypes.BatchChangeContinuousRunStateApplied, "")
+}
+
+func (e *continuousExecutor) updateRun(ctx context.Context, run *btypes.BatchChangeContinuousRun, state btypes.BatchChangeContinuousRunState, message string) error {
+	run.State = state
+	run.Message = message
+	run.FinishedAt = e.store.Clock()()
+	return e.store.UpdateBatchChangeContinuousRun(ctx, run)
+}
+
+// continuousRunChanges summarizes what applying the batch spec of a
+// continuous run would do to the changesets of the batch change.
+type continuousRunChanges struct {
+	// Created is the number of changesets that would be created or
+	// published.
+	Created int
+	// Updated is the number of published changesets that would be updated.
+	Updated int
+	// Closed is the number of open changesets that would be closed, because
+	// their repository no longer matches.
+	Closed int
+}
+
+// summarizeContinuousRun dry-runs the rewirer and the reconciler planner on
+// the given mappings, the same way the apply preview does.
+func summarizeContinuousRun(ctx context.Context, s *store.Store, batchChangeID int64, mappings btypes.RewirerMappings) (changes continuousRunChanges, err error) {
+	for _, m := range mappings {
+		// Clone all entities, since the rewirer modifies them.
+		mapping := &btypes.RewirerMapping{
+			ChangesetSpecID: m.ChangesetSpecID,
+			ChangesetID:     m.ChangesetID,
+			RepoID:          m.RepoID,
+		}
+		if m.Changeset != nil {
+			mapping.Changeset = m.Changeset.Clone()
+		}
+		if m.ChangesetSpec != nil {
+			mapping.ChangesetSpec = m.ChangesetSpec.Clone()
+		}
+		if m.Repo != nil {
+			mapping.Repo = m.Repo.Clone()
+		}
+
+		changesets, err := rewirer.New(btypes.RewirerMappings{mapping}, batchChangeID).Rewire()
+		if err != nil {
+			return changes, err
+		}
+		if len(changesets) == 0 {
+			continue
+		}
+		changeset := changesets[0]
+
+		var previousSpec, currentSpec *btypes.ChangesetSpec
+		if changeset.PreviousSpecID != 0 {
+			if previousSpec, err = s.GetChangesetSpecByID(ctx, changeset.PreviousSpecID); err != nil {
+				return changes, err
+			}
+		}
+		if changeset.CurrentSpecID != 0 {
+			if mapping.ChangesetSpec != nil {
+				currentSpec = mapping.ChangesetSpec
+			} else if currentSpec, err = s.GetChangesetSpecByID(ctx, changeset.CurrentSpecID); err != nil {
+				return changes, err
+			}
+		}
+
+		plan, err := reconciler.DeterminePlan(previousSpec, currentSpec, changeset)
+		if err != nil {
+			return changes, err
+		}
+
+		switch {
+		case plan.Ops.Contains(btypes.ReconcilerOperationClose), plan.Ops.Contains(btypes.ReconcilerOperationArchive):
+			if changeset.ExternalState == btypes.ChangesetExternalStateOpen || changeset.ExternalState == btypes.ChangesetExternalStateDraft {
+				changes.Closed++
+			}
+		case m.Changeset == nil,
+			plan.Ops.Contains(btypes.ReconcilerOperationPublish),
+			plan.Ops.Contains(btypes.ReconcilerOperationPublishDraft):
+			changes.Created++
+		case plan.Ops.Contains(btypes.ReconcilerOperationPush),
+			plan.Ops.Contains(btypes.ReconcilerOperationUpdate),
+			plan.Ops.Contains(btypes.ReconcilerOperationUpdateMetadata),
+			plan.Ops.Contains(btypes.ReconcilerOperationUndraft),
+			plan.Ops.Contains(btypes.ReconcilerOperationReopen):
+			changes.Updated++
+		}
+	}
+	return changes, nil
+}
+
+// decideContinuousApply decides whether the batch spec of a continuous run
+// is applied automatically. If not, it returns the reason.
+//
+// Changesets are never closed automatically: a repository that stops matching
+// the `on` section could as well be one that is temporarily unavailable.
+func decideContinuousApply(policy btypes.BatchChangeContinuousApplyPolicy, changes continuousRunChanges) (bool, string) {
+	switch {
+	case changes.Closed > 0:
+		return false, fmt.Sprintf("%d changesets would be closed, preview and apply the batch spec manually", changes.Closed)
+	case changes.Created == 0 && changes.Updated == 0:
+		return false, "no changesets would be created or updated"
+	case policy == btypes.BatchChangeContinuousApplyPolicyCreateAndUpdate:
+		return true, ""
+	case policy == btypes.BatchChangeContinuousApplyPolicyCreate && changes.Updated == 0:
+		return true, ""
+	case policy == btypes.BatchChangeContinuousApplyPolicyCreate:
+		return false, fmt.Sprintf("%d published changesets would be updated, preview and apply the batch spec manually", changes.Updated)
+	default:
+		return false, "changesets are not applied automatically, preview and apply the batch spec manually"
+	}
+}
@@ -0,0 +1,313 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestContinuousExecutor(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	clock := &ct.TestClock{Time: timeutil.Now()}
+	bstore := store.NewWithClock(db, nil, clock.Now)
+	e := &continuousExecutor{store: bstore}
+
+	user := ct.CreateTestUser(t, db, true)
+	repos, _ := ct.CreateTestRepos(t, ctx, db, 1)
+
+	createBatchChange := func(t *testing.T, name string, policy btypes.BatchChangeContinuousApplyPolicy) *btypes.BatchChange {
+		t.Helper()
+		batchSpec := ct.CreateBatchSpec(t, ctx, bstore, name, user.ID)
+		batchChange := ct.BuildBatchChange(bstore, name, user.ID, batchSpec.ID)
+		batchChange.ContinuousIntervalHours = 1
+		batchChange.ContinuousApplyPolicy = policy
+		if err := bstore.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		return batchChange
+	}
+
+	loadRun := func(t *testing.T, batchChange *btypes.BatchChange) *btypes.BatchChangeContinuousRun {
+		t.Helper()
+		run, err := bstore.GetLatestBatchChangeContinuousRun(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return run
+	}
+
+	// startRun advances the clock past the interval of the batch change and
+	// starts a new run.
+	startRun := func(t *testing.T, batchChange *btypes.BatchChange) *btypes.BatchChangeContinuousRun {
+		t.Helper()
+		clock.Add(batchChange.ContinuousInterval())
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		run := loadRun(t, batchChange)
+		if have, want := run.State, btypes.BatchChangeContinuousRunStateExecuting; have != want {
+			t.Fatalf("wrong run state. want=%s, have=%s", want, have)
+		}
+		return run
+	}
+
+	finishExecution := func(t *testing.T, run *btypes.BatchChangeContinuousRun, state btypes.BatchSpecExecutionState, failureMessage string, batchSpecID int64) {
+		t.Helper()
+		q := sqlf.Sprintf(
+			"UPDATE batch_spec_executions SET state = %s, failure_message = NULLIF(%s, ''), batch_spec_id = NULLIF(%s, 0) WHERE id = %s",
+			state, failureMessage, batchSpecID, run.BatchSpecExecutionID,
+		)
+		if err := bstore.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertRun := func(t *testing.T, batchChange *btypes.BatchChange, state btypes.BatchChangeContinuousRunState, message string) {
+		t.Helper()
+		run := loadRun(t, batchChange)
+		if have, want := run.State, state; have != want {
+			t.Fatalf("wrong run state. want=%s, have=%s", want, have)
+		}
+		if have, want := run.Message, message; have != want {
+			t.Fatalf("wrong run message. want=%q, have=%q", want, have)
+		}
+		if have, want := run.FinishedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("wrong finished at. want=%s, have=%s", want, have)
+		}
+	}
+
+	t.Run("starts runs when due", func(t *testing.T) {
+		batchChange := createBatchChange(t, "continuous-start", btypes.BatchChangeContinuousApplyPolicyNone)
+
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := bstore.GetLatestBatchChangeContinuousRun(ctx, batchChange.ID); err != store.ErrNoResults {
+			t.Fatalf("unexpected run before the interval passed. err=%v", err)
+		}
+
+		run := startRun(t, batchChange)
+		exec, err := bstore.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: run.BatchSpecExecutionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := exec.UserID, batchChange.LastApplierID; have != want {
+			t.Fatalf("wrong execution user. want=%d, have=%d", want, have)
+		}
+		if have, want := exec.NamespaceUserID, batchChange.NamespaceUserID; have != want {
+			t.Fatalf("wrong execution namespace. want=%d, have=%d", want, have)
+		}
+
+		// The run keeps executing as long as its execution does.
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := loadRun(t, batchChange).ID, run.ID; have != want {
+			t.Fatalf("wrong latest run. want=%d, have=%d", want, have)
+		}
+		if have, want := loadRun(t, batchChange).State, btypes.BatchChangeContinuousRunStateExecuting; have != want {
+			t.Fatalf("wrong run state. want=%s, have=%s", want, have)
+		}
+	})
+
+	t.Run("propagates failed executions", func(t *testing.T) {
+		batchChange := createBatchChange(t, "continuous-failed", btypes.BatchChangeContinuousApplyPolicyCreate)
+		run := startRun(t, batchChange)
+		finishExecution(t, run, btypes.BatchSpecExecutionStateFailed, "executor went away", 0)
+
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		assertRun(t, batchChange, btypes.BatchChangeContinuousRunStateFailed, "executor went away")
+	})
+
+	t.Run("skips runs when the batch change was applied in the meantime", func(t *testing.T) {
+		batchChange := createBatchChange(t, "continuous-reapplied", btypes.BatchChangeContinuousApplyPolicyCreate)
+		run := startRun(t, batchChange)
+		batchSpec := ct.CreateBatchSpec(t, ctx, bstore, batchChange.Name, user.ID)
+		finishExecution(t, run, btypes.BatchSpecExecutionStateCompleted, "", batchSpec.ID)
+
+		clock.Add(time.Minute)
+		batchChange.LastAppliedAt = clock.Now()
+		if err := bstore.UpdateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		assertRun(t, batchChange, btypes.BatchChangeContinuousRunStateSkipped, "the batch change was applied while the run was executing")
+	})
+
+	t.Run("skips runs without changes", func(t *testing.T) {
+		batchChange := createBatchChange(t, "continuous-unchanged", btypes.BatchChangeContinuousApplyPolicyCreate)
+		run := startRun(t, batchChange)
+		batchSpec := ct.CreateBatchSpec(t, ctx, bstore, batchChange.Name, user.ID)
+		finishExecution(t, run, btypes.BatchSpecExecutionStateCompleted, "", batchSpec.ID)
+
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		assertRun(t, batchChange, btypes.BatchChangeContinuousRunStateSkipped, "no changesets would be created or updated")
+	})
+
+	t.Run("applies new changesets", func(t *testing.T) {
+		batchChange := createBatchChange(t, "continuous-apply", btypes.BatchChangeContinuousApplyPolicyCreate)
+		run := startRun(t, batchChange)
+		batchSpec := ct.CreateBatchSpec(t, ctx, bstore, batchChange.Name, user.ID)
+		ct.CreateChangesetSpec(t, ctx, bstore, ct.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repos[0].ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   "refs/heads/continuous-apply",
+			Published: true,
+		})
+		finishExecution(t, run, btypes.BatchSpecExecutionStateCompleted, "", batchSpec.ID)
+
+		if err := e.processBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		assertRun(t, batchChange, btypes.BatchChangeContinuousRunStateApplied, "")
+
+		reloaded, err := bstore.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.BatchSpecID, batchSpec.ID; have != want {
+			t.Fatalf("batch spec not applied. want=%d, have=%d", want, have)
+		}
+	})
+}
+
+func TestSummarizeContinuousRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+	bstore := store.New(db, nil)
+
+	user := ct.CreateTestUser(t, db, true)
+	ctx = actor.WithActor(ctx, actor.FromUser(user.ID))
+	repos, _ := ct.CreateTestRepos(t, ctx, db, 3)
+
+	oldBatchSpec := ct.CreateBatchSpec(t, ctx, bstore, "summarize", user.ID)
+	batchChange := ct.CreateBatchChange(t, ctx, bstore, "summarize", user.ID, oldBatchSpec.ID)
+
+	// The batch change has a published changeset in the first two repos.
+	for _, repo := range repos[:2] {
+		spec := ct.CreateChangesetSpec(t, ctx, bstore, ct.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repo.ID,
+			BatchSpec: oldBatchSpec.ID,
+			HeadRef:   "refs/heads/summarize",
+			Title:     "old title",
+			Published: true,
+		})
+		ct.CreateChangeset(t, ctx, bstore, ct.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			CurrentSpec:        spec.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			ExternalID:         "123",
+			ExternalBranch:     "refs/heads/summarize",
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			Metadata:           &github.PullRequest{},
+		})
+	}
+
+	// The new batch spec updates the changeset in the first repo, no longer
+	// matches the second repo and adds a changeset in the third repo.
+	newBatchSpec := ct.CreateBatchSpec(t, ctx, bstore, "summarize", user.ID)
+	for _, repo := range []int{0, 2} {
+		ct.CreateChangesetSpec(t, ctx, bstore, ct.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repos[repo].ID,
+			BatchSpec: newBatchSpec.ID,
+			HeadRef:   "refs/heads/summarize",
+			Title:     "new title",
+			Published: true,
+		})
+	}
+
+	mappings, err := bstore.GetRewirerMappings(ctx, store.GetRewirerMappingsOpts{
+		BatchSpecID:   newBatchSpec.ID,
+		BatchChangeID: batchChange.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := summarizeContinuousRun(ctx, bstore, batchChange.ID, mappings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := continuousRunChanges{Created: 1, Updated: 1, Closed: 1}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong changes (-want +have):\n%s", diff)
+	}
+}
+
+func TestDecideContinuousApply(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy    btypes.BatchChangeContinuousApplyPolicy
+		changes   continuousRunChanges
+		wantApply bool
+	}{
+		"none": {
+			policy:  btypes.BatchChangeContinuousApplyPolicyNone,
+			changes: continuousRunChanges{Created: 2},
+		},
+		"create with new changesets": {
+			policy:    btypes.BatchChangeContinuousApplyPolicyCreate,
+			changes:   continuousRunChanges{Created: 2},
+			wantApply: true,
+		},
+		"create with updated changesets": {
+			policy:  btypes.BatchChangeContinuousApplyPolicyCreate,
+			changes: continuousRunChanges{Created: 2, Updated: 1},
+		},
+		"create and update with updated changesets": {
+			policy:    btypes.BatchChangeContinuousApplyPolicyCreateAndUpdate,
+			changes:   continuousRunChanges{Created: 2, Updated: 1},
+			wantApply: true,
+		},
+		"create and update with closed changesets": {
+			policy:  btypes.BatchChangeContinuousApplyPolicyCreateAndUpdate,
+			changes: continuousRunChanges{Created: 2, Closed: 1},
+		},
+		"no changes": {
+			policy: btypes.BatchChangeContinuousApplyPolicyCreateAndUpdate,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			apply, reason := decideContinuousApply(tc.policy, tc.changes)
+			if apply != tc.wantApply {
+				t.Fatalf("wrong decision. want=%t, have=%t", tc.wantApply, apply)
+			}
+			if !apply && reason == "" {
+				t.Fatal("no reason given for skipping")
+			}
+		})
+	}
+}
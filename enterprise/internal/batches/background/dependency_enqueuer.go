@@ -0,0 +1,130 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// newDependencyEnqueuer returns a background routine that periodically
+// enqueues the changesets the reconciler held back because of their
+// dependencies, or because of conflicting changesets of other batch changes,
+// once all changesets they depend on have been merged and the conflicting
+// changesets have been merged or closed.
+func newDependencyEnqueuer(ctx context.Context, s *store.Store) goroutine.BackgroundRoutine {
+	e := &dependencyEnqueuer{store: s}
+	handler := goroutine.NewHandlerWithErrorMessage("enqueue batch changes changesets with merged dependencies or conflicts", e.run)
+	return goroutine.NewPeriodicGoroutine(ctx, 1*time.Minute, handler)
+}
+
+type dependencyEnqueuer struct {
+	store *store.Store
+}
+
+func (e *dependencyEnqueuer) run(ctx context.Context) error {
+	changesets, _, err := e.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		ReconcilerStates:     []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+		OnlyWithDependencies: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	waiting, _, err := e.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		ReconcilerStates:        []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+		OnlyWaitingForConflicts: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets waiting for conflicts")
+	}
+
+	seen := make(map[int64]struct{}, len(changesets)+len(waiting))
+	byBatchChange := make(map[int64][]*btypes.Changeset)
+	for _, ch := range append(changesets, waiting...) {
+		if _, ok := seen[ch.ID]; ok {
+			continue
+		}
+		seen[ch.ID] = struct{}{}
+
+		// Only unpublished and draft changesets can be held back.
+		if ch.OwnedByBatchChangeID == 0 || (ch.Published() && ch.ExternalState != btypes.ChangesetExternalStateDraft) {
+			continue
+		}
+		byBatchChange[ch.OwnedByBatchChangeID] = append(byBatchChange[ch.OwnedByBatchChangeID], ch)
+	}
+
+	var errs *multierror.Error
+	for batchChangeID, changesets := range byBatchChange {
+		if err := e.processBatchChange(ctx, batchChangeID, changesets); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "batch change %d", batchChangeID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (e *dependencyEnqueuer) processBatchChange(ctx context.Context, batchChangeID int64, changesets []*btypes.Changeset) error {
+	g, err := reconciler.LoadChangesetDependencyGraph(ctx, e.store, batchChangeID)
+	if err != nil {
+		return errors.Wrap(err, "loading dependency graph")
+	}
+
+	var errs *multierror.Error
+	for _, ch := range changesets {
+		n := g.Node(ch.CurrentSpecID)
+		if n == nil || g.Blocked(n) {
+			continue
+		}
+
+		released, err := e.released(ctx, ch, n.ChangesetSpec)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "changeset %d", ch.ID))
+			continue
+		}
+		if !released {
+			continue
+		}
+
+		blocked, err := reconciler.BlockedByConflicts(ctx, e.store, ch, n.ChangesetSpec)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "changeset %d", ch.ID))
+			continue
+		}
+		if blocked {
+			continue
+		}
+
+		log15.Info("Enqueuing changeset that is no longer held back", "changeset", ch.ID)
+		if err := e.store.EnqueueChangeset(ctx, ch, btypes.ReconcilerStateQueued, btypes.ReconcilerStateCompleted); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "enqueuing changeset %d", ch.ID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// released returns whether the reconciler would now publish or undraft the
+// changeset, which it previously didn't because of its dependencies or
+// conflicts.
+func (e *dependencyEnqueuer) released(ctx context.Context, ch *btypes.Changeset, curr *btypes.ChangesetSpec) (bool, error) {
+	var prev *btypes.ChangesetSpec
+	if ch.PreviousSpecID != 0 {
+		var err error
+		if prev, err = e.store.GetChangesetSpecByID(ctx, ch.PreviousSpecID); err != nil {
+			return false, errors.Wrap(err, "loading previous changeset spec")
+		}
+	}
+
+	plan, err := reconciler.DeterminePlan(prev, curr, ch)
+	if err != nil {
+		return false, err
+	}
+	return plan.Ops.Contains(btypes.ReconcilerOperationPublish) ||
+		plan.Ops.Contains(btypes.ReconcilerOperationUndraft), nil
+}
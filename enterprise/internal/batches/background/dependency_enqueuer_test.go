@@ -0,0 +1,103 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestDependencyEnqueuer(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	now := timeutil.Now()
+	clock := func() time.Time { return now }
+	bstore := store.NewWithClock(db, nil, clock)
+
+	user := ct.CreateTestUser(t, db, true)
+	repos, _ := ct.CreateTestRepos(t, ctx, db, 1)
+	repo := repos[0]
+
+	batchSpec := ct.CreateBatchSpec(t, ctx, bstore, "test-dependencies", user.ID)
+	batchChange := ct.CreateBatchChange(t, ctx, bstore, "test-dependencies", user.ID, batchSpec.ID)
+
+	prerequisiteSpec := ct.CreateChangesetSpec(t, ctx, bstore, ct.TestSpecOpts{
+		User:      user.ID,
+		Repo:      repo.ID,
+		BatchSpec: batchSpec.ID,
+		HeadRef:   "refs/heads/base",
+		Published: true,
+	})
+	dependentSpec := ct.BuildChangesetSpec(t, ct.TestSpecOpts{
+		User:      user.ID,
+		Repo:      repo.ID,
+		BatchSpec: batchSpec.ID,
+		HeadRef:   "refs/heads/dependent",
+		Published: true,
+	})
+	dependentSpec.Spec.DependsOn = []btypes.ChangesetSpecDependency{{Branch: "base"}}
+	if err := bstore.CreateChangesetSpec(ctx, dependentSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	prerequisite := ct.CreateChangeset(t, ctx, bstore, ct.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        prerequisiteSpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+		ExternalID:         "123",
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+	dependent := ct.CreateChangeset(t, ctx, bstore, ct.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        dependentSpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	assertReconcilerState := func(t *testing.T, want btypes.ReconcilerState) {
+		t.Helper()
+		reloaded, err := bstore.GetChangesetByID(ctx, dependent.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := reloaded.ReconcilerState; have != want {
+			t.Fatalf("wrong reconciler state. want=%s, have=%s", want, have)
+		}
+	}
+
+	e := &dependencyEnqueuer{store: bstore}
+
+	// The prerequisite hasn't been merged yet, so the dependent changeset is
+	// still held back.
+	if err := e.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assertReconcilerState(t, btypes.ReconcilerStateCompleted)
+
+	prerequisite.ExternalState = btypes.ChangesetExternalStateMerged
+	if err := bstore.UpdateChangeset(ctx, prerequisite); err != nil {
+		t.Fatal(err)
+	}
+
+	// Now that the prerequisite has been merged, the dependent changeset is
+	// enqueued to be published.
+	if err := e.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assertReconcilerState(t, btypes.ReconcilerStateQueued)
+}
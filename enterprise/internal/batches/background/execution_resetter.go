@@ -9,7 +9,8 @@ import (
 )
 
 // newBatchSpecExecutionResetter creates a dbworker.Resetter that re-enqueues
-// lost batch_spec_execution jobs for processing.
+// lost batch_spec_execution jobs for processing. This covers both the
+// resolution of the workspaces by executors and the batch spec assembler.
 func newBatchSpecExecutionResetter(s *store.Store, observationContext *observation.Context, metrics batchChangesMetrics) *dbworker.Resetter {
 	workerStore := NewExecutorStore(s, observationContext)
 
@@ -22,3 +23,18 @@ func newBatchSpecExecutionResetter(s *store.Store, observationContext *observati
 	resetter := dbworker.NewResetter(workerStore, options)
 	return resetter
 }
+
+// newBatchSpecWorkspaceExecutionResetter creates a dbworker.Resetter that
+// re-enqueues lost batch_spec_workspace_execution_jobs for processing.
+func newBatchSpecWorkspaceExecutionResetter(s *store.Store, observationContext *observation.Context, metrics batchChangesMetrics) *dbworker.Resetter {
+	workerStore := NewWorkspaceExecutorStore(s, observationContext)
+
+	options := dbworker.ResetterOptions{
+		Name:     "batch_spec_workspace_executor_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  metrics.workspaceExecutionResetterMetrics,
+	}
+
+	resetter := dbworker.NewResetter(workerStore, options)
+	return resetter
+}
@@ -9,11 +9,14 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/ghodss/yaml"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -32,8 +35,11 @@ const executorStalledJobMaximumAge = time.Second * 25
 const executorMaximumNumResets = 3
 
 var executorWorkerStoreOptions = dbworkerstore.Options{
-	Name:              "batch_spec_executor_worker_store",
-	TableName:         "batch_spec_executions",
+	Name:      "batch_spec_executor_worker_store",
+	TableName: "batch_spec_executions",
+	// Executors only resolve the workspaces of a batch spec execution. Once
+	// they're resolved, the record is processed by the batch spec assembler.
+	ViewName:          "batch_spec_executions_pending_resolution batch_spec_executions",
 	ColumnExpressions: store.BatchSpecExecutionColumns,
 	Scan:              scanFirstExecutionRecord,
 	OrderByExpression: sqlf.Sprintf("batch_spec_executions.created_at, batch_spec_executions.id"),
@@ -52,51 +58,159 @@ func NewExecutorStore(s basestore.ShareableStore, observationContext *observatio
 var _ dbworkerstore.Store = &executorStore{}
 
 // executorStore is a thin wrapper around dbworkerstore.Store that allows us to
-// extract information out of the ExecutionLogEntry field and persisting it to
-// separate columns when marking a job as complete.
+// extract the resolved workspaces out of the ExecutionLogEntry field and enqueue
+// a workspace execution job for each of them when marking a job as complete.
 type executorStore struct {
 	dbworkerstore.Store
 }
 
-// markCompleteQuery is taken from internal/workerutil/dbworker/store/store.go
-//
-// If that one changes we need to update this one here too.
-const markCompleteQuery = `
+// markWorkspacesResolvedQuery puts a batch spec execution whose workspaces
+// have been resolved back into the queue, this time as the job that assembles
+// the batch spec once all workspace execution jobs have finished. See the
+// batch_spec_executions_pending_assembly view.
+const markWorkspacesResolvedQuery = `
+-- source: enterprise/internal/batches/background/executor_store.go:MarkComplete
+UPDATE batch_spec_executions
+SET state = 'queued', workspaces_resolved_at = clock_timestamp(), worker_hostname = ''
+WHERE id = %s AND state = 'processing' AND worker_hostname = %s
+RETURNING id
+`
+
+// markSingleJobCompleteQuery completes a batch spec execution that was
+// executed by `src batch preview` in a single job, and links it to the batch
+// spec that src-cli created.
+const markSingleJobCompleteQuery = `
+-- source: enterprise/internal/batches/background/executor_store.go:MarkComplete
 UPDATE batch_spec_executions
 SET state = 'completed', finished_at = clock_timestamp(), batch_spec_id = (SELECT id FROM batch_specs WHERE rand_id = %s)
 WHERE id = %s AND state = 'processing' AND worker_hostname = %s
 RETURNING id
 `
 
+// MarkComplete extracts the workspaces resolved by src-cli from the execution
+// logs and creates a batch spec workspace execution job for each of them. If
+// the execution was run by `src batch preview` in a single job instead, it
+// extracts the ID of the created batch spec and completes the execution.
 func (s *executorStore) MarkComplete(ctx context.Context, id int, options dbworkerstore.MarkFinalOptions) (_ bool, err error) {
 	batchesStore := store.New(s.Store.Handle().DB(), nil)
 
-	batchSpecRandID, err := loadAndExtractBatchSpecRandID(ctx, batchesStore, int64(id))
+	exec, err := batchesStore.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: int64(id)})
+	if err != nil {
+		return false, err
+	}
+
+	if isSingleJobExecution(exec.ExecutionLogs) {
+		batchSpecRandID, err := loadAndExtractBatchSpecRandID(ctx, batchesStore, int64(id))
+		if err != nil {
+			// If we couldn't extract the batch spec rand id, we mark the job as failed
+			return s.Store.MarkFailed(ctx, id, fmt.Sprintf("failed to extract batch spec ID: %s", err), options)
+		}
+
+		_, ok, err := basestore.ScanFirstInt(batchesStore.Query(ctx, sqlf.Sprintf(markSingleJobCompleteQuery, batchSpecRandID, id, options.WorkerHostname)))
+		return ok, err
+	}
+
+	jobs, err := buildWorkspaceExecutionJobs(exec)
+	if err != nil {
+		// If we couldn't extract the workspaces, we mark the job as failed
+		return s.Store.MarkFailed(ctx, id, fmt.Sprintf("failed to resolve workspaces: %s", err), options)
+	}
+
+	tx, err := batchesStore.Transact(ctx)
 	if err != nil {
-		// If we couldn't extract the batch spec rand id, we mark the job as failed
-		return s.Store.MarkFailed(ctx, id, fmt.Sprintf("failed to extract batch spec ID: %s", err), options)
+		return false, err
 	}
+	defer func() { err = tx.Done(err) }()
 
-	_, ok, err := basestore.ScanFirstInt(batchesStore.Query(ctx, sqlf.Sprintf(markCompleteQuery, batchSpecRandID, id, options.WorkerHostname)))
-	return ok, err
+	_, ok, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(markWorkspacesResolvedQuery, id, options.WorkerHostname)))
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	if len(jobs) == 0 {
+		return true, nil
+	}
+
+	return true, tx.CreateBatchSpecWorkspaceExecutionJobs(ctx, jobs...)
 }
 
-func loadAndExtractBatchSpecRandID(ctx context.Context, s *store.Store, id int64) (string, error) {
-	exec, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: id})
+// resolvedWorkspace is a workspace as reported by src-cli after resolving
+// the workspaces of a batch spec.
+type resolvedWorkspace struct {
+	Repository struct {
+		ID   graphql.ID `json:"id"`
+		Name string     `json:"name"`
+	} `json:"repository"`
+	Branch             string   `json:"branch"`
+	Commit             string   `json:"commit"`
+	Path               string   `json:"path"`
+	OnlyFetchWorkspace bool     `json:"onlyFetchWorkspace"`
+	FileMatches        []string `json:"fileMatches"`
+}
+
+// buildWorkspaceExecutionJobs builds a batch spec workspace execution job for
+// each workspace that src-cli resolved for the given execution.
+func buildWorkspaceExecutionJobs(exec *btypes.BatchSpecExecution) ([]*btypes.BatchSpecWorkspaceExecutionJob, error) {
+	msg, err := findSrcCLIOperationResult(exec.ExecutionLogs, operationResolvingWorkspaces)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if len(exec.ExecutionLogs) < 1 {
-		return "", errors.New("no execution logs")
+	var workspaces []resolvedWorkspace
+	if err := json.Unmarshal([]byte(msg), &workspaces); err != nil {
+		return nil, errors.Wrap(err, "parsing resolved workspaces")
 	}
 
-	return extractBatchSpecRandID(exec.ExecutionLogs)
+	steps, err := rawBatchSpecSteps(exec.BatchSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*btypes.BatchSpecWorkspaceExecutionJob, 0, len(workspaces))
+	for _, w := range workspaces {
+		var repoID api.RepoID
+		if err := relay.UnmarshalSpec(w.Repository.ID, &repoID); err != nil {
+			return nil, errors.Wrapf(err, "invalid repository ID %q", w.Repository.ID)
+		}
+
+		cacheKey, err := btypes.BatchSpecExecutionCacheKey(repoID, w.Commit, w.Path, w.OnlyFetchWorkspace, steps)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, &btypes.BatchSpecWorkspaceExecutionJob{
+			BatchSpecExecutionID: exec.ID,
+			RepoID:               repoID,
+			Branch:               w.Branch,
+			Commit:               w.Commit,
+			Path:                 w.Path,
+			OnlyFetchWorkspace:   w.OnlyFetchWorkspace,
+			FileMatches:          w.FileMatches,
+			CacheKey:             cacheKey,
+		})
+	}
+
+	return jobs, nil
 }
 
-var ErrNoBatchSpecRandID = errors.New("no batch spec rand id found in execution logs")
+// rawBatchSpecSteps returns the steps of the given raw batch spec as JSON, so
+// that they can be hashed into a cache key. Only the steps are considered, so
+// that changing the changeset template doesn't invalidate the cache.
+func rawBatchSpecSteps(rawSpec string) (json.RawMessage, error) {
+	var spec struct {
+		Steps interface{} `json:"steps"`
+	}
+	if err := yaml.Unmarshal([]byte(rawSpec), &spec); err != nil {
+		return nil, errors.Wrap(err, "parsing batch spec")
+	}
 
-func extractBatchSpecRandID(logs []workerutil.ExecutionLogEntry) (string, error) {
+	// Marshaling the decoded steps again normalizes formatting and key order.
+	return json.Marshal(spec.Steps)
+}
+
+// findSrcCLIOperationResult returns the message of the log line with which
+// src-cli reported the successful completion of the given operation.
+func findSrcCLIOperationResult(logs []workerutil.ExecutionLogEntry, operation string) (string, error) {
 	var (
 		entry workerutil.ExecutionLogEntry
 		found bool
@@ -110,10 +224,9 @@ func extractBatchSpecRandID(logs []workerutil.ExecutionLogEntry) (string, error)
 		}
 	}
 	if !found {
-		return "", ErrNoBatchSpecRandID
+		return "", ErrNoSrcCLIOutput
 	}
 
-	var batchSpecRandID string
 	for _, l := range strings.Split(entry.Out, "\n") {
 		const outputLinePrefix = "stdout: "
 
@@ -129,23 +242,61 @@ func extractBatchSpecRandID(logs []workerutil.ExecutionLogEntry) (string, error)
 			continue
 		}
 
-		if e.Operation == operationCreatingBatchSpec && e.Status == "SUCCESS" {
-			parts := strings.Split(e.Message, "/")
-			if len(parts) == 0 {
-				return "", ErrNoBatchSpecRandID
-			}
+		if e.Operation == operation && e.Status == "SUCCESS" {
+			return e.Message, nil
+		}
+	}
+
+	return "", errors.Errorf("src-cli did not report a successful %s operation", operation)
+}
 
-			batchSpecGraphQLID := graphql.ID(parts[len(parts)-1])
-			if err := relay.UnmarshalSpec(batchSpecGraphQLID, &batchSpecRandID); err != nil {
-				// If we can't extract the ID we simply return our main error
-				return "", ErrNoBatchSpecRandID
-			}
+var ErrNoSrcCLIOutput = errors.New("no src-cli output found in execution logs")
 
-			return batchSpecRandID, nil
+// isSingleJobExecution returns whether the execution was run by `src batch
+// preview` in a single job, rather than resolving its workspaces.
+func isSingleJobExecution(logs []workerutil.ExecutionLogEntry) bool {
+	for _, e := range logs {
+		if e.Key == "step.src.0" {
+			return len(e.Command) > 2 && e.Command[1] == "batch" && e.Command[2] == "preview"
 		}
 	}
+	return false
+}
+
+func loadAndExtractBatchSpecRandID(ctx context.Context, s *store.Store, id int64) (string, error) {
+	exec, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: id})
+	if err != nil {
+		return "", err
+	}
 
-	return batchSpecRandID, ErrNoBatchSpecRandID
+	if len(exec.ExecutionLogs) < 1 {
+		return "", errors.New("no execution logs")
+	}
+
+	return extractBatchSpecRandID(exec.ExecutionLogs)
+}
+
+var ErrNoBatchSpecRandID = errors.New("no batch spec rand id found in execution logs")
+
+func extractBatchSpecRandID(logs []workerutil.ExecutionLogEntry) (string, error) {
+	msg, err := findSrcCLIOperationResult(logs, operationCreatingBatchSpec)
+	if err != nil {
+		return "", ErrNoBatchSpecRandID
+	}
+
+	parts := strings.Split(msg, "/")
+	if len(parts) == 0 {
+		return "", ErrNoBatchSpecRandID
+	}
+
+	var batchSpecRandID string
+	batchSpecGraphQLID := graphql.ID(parts[len(parts)-1])
+	if err := relay.UnmarshalSpec(batchSpecGraphQLID, &batchSpecRandID); err != nil {
+		// If we can't extract the ID we simply return our main error
+		return "", ErrNoBatchSpecRandID
+	}
+
+	return batchSpecRandID, nil
 }
 
 // srcCLILogLine matches the definition of log entries that are printed by
@@ -159,7 +310,23 @@ type srcCLILogLine struct {
 	Message string `json:"message,omitempty"` // "70% done"
 }
 
-const operationCreatingBatchSpec = "CREATING_BATCH_SPEC"
+// The operations below are the protocol between the executor queue and src-cli,
+// which src-cli reports with the `-text-only` flag. The workspace operations
+// require src-cli 3.31.0 or later, see the executor queue of batches.
+const (
+	// operationCreatingBatchSpec is reported by `src batch preview`. Its message
+	// is the URL of the preview of the created batch spec.
+	operationCreatingBatchSpec = "CREATING_BATCH_SPEC"
+	// operationResolvingWorkspaces is reported by `src batch workspaces`. Its
+	// message is the JSON-encoded list of resolved workspaces.
+	operationResolvingWorkspaces = "RESOLVING_WORKSPACES"
+	// operationCachingExecutionResult is reported by `src batch exec`. Its
+	// message is the opaque execution result to cache.
+	operationCachingExecutionResult = "CACHING_EXECUTION_RESULT"
+	// operationUploadingChangesetSpecs is reported by `src batch exec`. Its
+	// message is the JSON-encoded list of IDs of the uploaded changeset specs.
+	operationUploadingChangesetSpecs = "UPLOADING_CHANGESET_SPECS"
+)
 
 // scanFirstExecutionRecord scans a slice of batch change executions and returns the first.
 func scanFirstExecutionRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
@@ -13,18 +13,22 @@ import (
 type batchChangesMetrics struct {
 	reconcilerWorkerMetrics            workerutil.WorkerMetrics
 	bulkProcessorWorkerMetrics         workerutil.WorkerMetrics
+	batchSpecAssemblerWorkerMetrics    workerutil.WorkerMetrics
 	reconcilerWorkerResetterMetrics    dbworker.ResetterMetrics
 	bulkProcessorWorkerResetterMetrics dbworker.ResetterMetrics
 	executionResetterMetrics           dbworker.ResetterMetrics
+	workspaceExecutionResetterMetrics  dbworker.ResetterMetrics
 }
 
 func newMetrics(observationContext *observation.Context) batchChangesMetrics {
 	return batchChangesMetrics{
 		reconcilerWorkerMetrics:            workerutil.NewMetrics(observationContext, "batch_changes_reconciler", nil),
 		bulkProcessorWorkerMetrics:         workerutil.NewMetrics(observationContext, "batch_changes_bulk_processor", nil),
+		batchSpecAssemblerWorkerMetrics:    workerutil.NewMetrics(observationContext, "batch_spec_assembler", nil),
 		reconcilerWorkerResetterMetrics:    makeResetterMetrics(observationContext, "batch_changes_reconciler"),
 		bulkProcessorWorkerResetterMetrics: makeResetterMetrics(observationContext, "batch_changes_bulk_processor"),
 		executionResetterMetrics:           makeResetterMetrics(observationContext, "batch_spec_executor"),
+		workspaceExecutionResetterMetrics:  makeResetterMetrics(observationContext, "batch_spec_workspace_executor"),
 	}
 }
 
@@ -0,0 +1,148 @@
+package background
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// batchSpecWorkspaceExecutionMaxNumRetries is the maximum number of times a
+// batch spec workspace execution job is retried after it errored.
+//
+// 🚨 This must be kept in sync with the batch_spec_executions_pending_assembly
+// view, which waits for errored jobs that will still be retried.
+const batchSpecWorkspaceExecutionMaxNumRetries = 3
+
+var workspaceExecutorWorkerStoreOptions = dbworkerstore.Options{
+	Name:              "batch_spec_workspace_executor_worker_store",
+	TableName:         "batch_spec_workspace_execution_jobs",
+	ColumnExpressions: store.BatchSpecWorkspaceExecutionJobColumns.ToSqlf(),
+	Scan:              scanFirstWorkspaceExecutionJobRecord,
+	OrderByExpression: sqlf.Sprintf("batch_spec_workspace_execution_jobs.created_at, batch_spec_workspace_execution_jobs.id"),
+	StalledMaxAge:     executorStalledJobMaximumAge,
+	MaxNumResets:      executorMaximumNumResets,
+	RetryAfter:        10 * time.Second,
+	MaxNumRetries:     batchSpecWorkspaceExecutionMaxNumRetries,
+}
+
+// NewWorkspaceExecutorStore creates a dbworker store that wraps the
+// batch_spec_workspace_execution_jobs table.
+func NewWorkspaceExecutorStore(s basestore.ShareableStore, observationContext *observation.Context) dbworkerstore.Store {
+	return &workspaceExecutorStore{Store: dbworkerstore.NewWithMetrics(s.Handle(), workspaceExecutorWorkerStoreOptions, observationContext)}
+}
+
+var _ dbworkerstore.Store = &workspaceExecutorStore{}
+
+// workspaceExecutorStore is a thin wrapper around dbworkerstore.Store that
+// extracts the created changeset specs and the execution result to cache out
+// of the ExecutionLogEntry field when marking a job as complete.
+type workspaceExecutorStore struct {
+	dbworkerstore.Store
+}
+
+// markWorkspaceCompleteQuery is taken from internal/workerutil/dbworker/store/store.go
+//
+// If that one changes we need to update this one here too.
+const markWorkspaceCompleteQuery = `
+-- source: enterprise/internal/batches/background/workspace_executor_store.go:MarkComplete
+UPDATE batch_spec_workspace_execution_jobs
+SET state = 'completed', finished_at = clock_timestamp(), changeset_spec_rand_ids = %s
+WHERE id = %s AND state = 'processing' AND worker_hostname = %s
+RETURNING id
+`
+
+func (s *workspaceExecutorStore) MarkComplete(ctx context.Context, id int, options dbworkerstore.MarkFinalOptions) (_ bool, err error) {
+	batchesStore := store.New(s.Store.Handle().DB(), nil)
+
+	job, err := batchesStore.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: int64(id)})
+	if err != nil {
+		return false, err
+	}
+
+	randIDs, err := extractChangesetSpecRandIDs(job.ExecutionLogs)
+	if err != nil {
+		// If we couldn't extract the changeset specs, we mark the job as failed
+		return s.Store.MarkFailed(ctx, id, fmt.Sprintf("failed to extract changeset spec IDs: %s", err), options)
+	}
+
+	exec, err := batchesStore.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: job.BatchSpecExecutionID})
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := batchesStore.Transact(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	_, ok, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(markWorkspaceCompleteQuery, pq.Array(randIDs), id, options.WorkerHostname)))
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	// Caching is best effort: src-cli doesn't report a result if the job
+	// was itself served from the cache.
+	value, err := findSrcCLIOperationResult(job.ExecutionLogs, operationCachingExecutionResult)
+	if err != nil {
+		return true, nil
+	}
+
+	return true, tx.UpsertBatchSpecExecutionCacheEntry(ctx, &btypes.BatchSpecExecutionCacheEntry{
+		UserID: exec.UserID,
+		Key:    job.CacheKey,
+		Value:  value,
+	})
+}
+
+const changesetSpecIDKind = "ChangesetSpec"
+
+// extractChangesetSpecRandIDs returns the random IDs of the changeset specs
+// that src-cli uploaded, as reported in the given execution logs.
+func extractChangesetSpecRandIDs(logs []workerutil.ExecutionLogEntry) ([]string, error) {
+	msg, err := findSrcCLIOperationResult(logs, operationUploadingChangesetSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []graphql.ID
+	if err := json.Unmarshal([]byte(msg), &ids); err != nil {
+		return nil, errors.Wrap(err, "parsing changeset spec IDs")
+	}
+
+	randIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if kind := relay.UnmarshalKind(id); kind != changesetSpecIDKind {
+			return nil, errors.Errorf("expected changeset spec ID, got %q", kind)
+		}
+
+		var randID string
+		if err := relay.UnmarshalSpec(id, &randID); err != nil {
+			return nil, errors.Wrapf(err, "invalid changeset spec ID %q", id)
+		}
+		randIDs = append(randIDs, randID)
+	}
+
+	return randIDs, nil
+}
+
+// scanFirstWorkspaceExecutionJobRecord scans a slice of batch spec workspace
+// execution jobs and returns the first.
+func scanFirstWorkspaceExecutionJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return store.ScanFirstBatchSpecWorkspaceExecutionJob(rows, err)
+}
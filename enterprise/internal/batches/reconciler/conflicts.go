@@ -0,0 +1,106 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// FindChangesetConflicts returns, keyed by changeset spec ID, the open
+// changesets of other batch changes that modify the same files in the same
+// repository and base branch as the given changeset specs. Changesets owned
+// by the batch change with the given ID are ignored; the ID is 0 if the batch
+// change doesn't exist yet.
+func FindChangesetConflicts(ctx context.Context, s *store.Store, batchChangeID int64, specs []*btypes.ChangesetSpec) (map[int64][]*btypes.ChangesetConflict, error) {
+	repoIDs := make([]api.RepoID, 0, len(specs))
+	for _, spec := range specs {
+		if !spec.Spec.IsImportingExisting() {
+			repoIDs = append(repoIDs, spec.RepoID)
+		}
+	}
+	if len(repoIDs) == 0 {
+		return map[int64][]*btypes.ChangesetConflict{}, nil
+	}
+
+	cs, _, err := s.ListChangesets(ctx, store.ListChangesetsOpts{
+		RepoIDs: repoIDs,
+		ExternalStates: []btypes.ChangesetExternalState{
+			btypes.ChangesetExternalStateOpen,
+			btypes.ChangesetExternalStateDraft,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	candidates := make(map[int64]*btypes.Changeset, len(cs))
+	specIDs := make([]int64, 0, len(cs))
+	for _, c := range cs {
+		// Only changesets created by other batch changes have a changeset
+		// spec with a diff to compare to.
+		if c.OwnedByBatchChangeID == 0 || c.OwnedByBatchChangeID == batchChangeID || c.CurrentSpecID == 0 {
+			continue
+		}
+		candidates[c.CurrentSpecID] = c
+		specIDs = append(specIDs, c.CurrentSpecID)
+	}
+	if len(specIDs) == 0 {
+		return map[int64][]*btypes.ChangesetConflict{}, nil
+	}
+
+	others, _, err := s.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: specIDs})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changeset specs")
+	}
+
+	conflicts := make(map[int64][]*btypes.ChangesetConflict)
+	for _, spec := range specs {
+		for _, other := range others {
+			if other.RepoID != spec.RepoID {
+				continue
+			}
+			if git.EnsureRefPrefix(other.Spec.BaseRef) != git.EnsureRefPrefix(spec.Spec.BaseRef) {
+				continue
+			}
+
+			conflict, err := btypes.ChangesetSpecsConflict(spec, other)
+			if err != nil {
+				return nil, errors.Wrapf(err, "comparing changeset specs %d and %d", spec.ID, other.ID)
+			}
+			if conflict == nil {
+				continue
+			}
+			conflict.Changeset = candidates[other.ID]
+			conflicts[spec.ID] = append(conflicts[spec.ID], conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// BlockedByConflicts returns whether the changeset has to be held back
+// because its batch spec waits for conflicting changesets and open changesets
+// of other batch changes modify the same files.
+func BlockedByConflicts(ctx context.Context, s *store.Store, ch *btypes.Changeset, spec *btypes.ChangesetSpec) (bool, error) {
+	if spec == nil || spec.Spec.IsImportingExisting() || ch.OwnedByBatchChangeID == 0 {
+		return false, nil
+	}
+
+	batchSpec, err := s.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: spec.BatchSpecID})
+	if err != nil {
+		return false, errors.Wrap(err, "loading batch spec")
+	}
+	if !batchSpec.Spec.ChangesetTemplate.WaitForConflictingChangesets {
+		return false, nil
+	}
+
+	conflicts, err := FindChangesetConflicts(ctx, s, ch.OwnedByBatchChangeID, []*btypes.ChangesetSpec{spec})
+	if err != nil {
+		return false, err
+	}
+	return len(conflicts[spec.ID]) > 0, nil
+}
@@ -0,0 +1,81 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+// LoadChangesetDependencyGraph loads the dependency graph of the changesets
+// owned by the given batch change, based on their current changeset specs.
+func LoadChangesetDependencyGraph(ctx context.Context, s *store.Store, batchChangeID int64) (*btypes.ChangesetDependencyGraph, error) {
+	cs, _, err := s.ListChangesets(ctx, store.ListChangesetsOpts{OwnedByBatchChangeID: batchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	specIDs := make([]int64, 0, len(cs))
+	for _, c := range cs {
+		if c.CurrentSpecID != 0 {
+			specIDs = append(specIDs, c.CurrentSpecID)
+		}
+	}
+	if len(specIDs) == 0 {
+		return &btypes.ChangesetDependencyGraph{}, nil
+	}
+
+	specs, _, err := s.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: specIDs})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changeset specs")
+	}
+	specsByID := make(map[int64]*btypes.ChangesetSpec, len(specs))
+	for _, spec := range specs {
+		specsByID[spec.ID] = spec
+	}
+
+	reposByID, err := s.Repos().GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading repos")
+	}
+
+	g := &btypes.ChangesetDependencyGraph{}
+	for _, c := range cs {
+		spec, ok := specsByID[c.CurrentSpecID]
+		if !ok {
+			continue
+		}
+		repo, ok := reposByID[c.RepoID]
+		if !ok {
+			continue
+		}
+		g.Nodes = append(g.Nodes, &btypes.ChangesetDependencyNode{
+			ChangesetSpec: spec,
+			Changeset:     c,
+			RepoName:      repo.Name,
+		})
+	}
+	return g, nil
+}
+
+// blockedByDependencies returns whether the changeset has to be held back
+// because changesets of its batch change it depends on haven't been merged
+// yet.
+func blockedByDependencies(ctx context.Context, tx *store.Store, ch *btypes.Changeset, spec *btypes.ChangesetSpec) (bool, error) {
+	if spec == nil || len(spec.Spec.DependsOn) == 0 || ch.OwnedByBatchChangeID == 0 {
+		return false, nil
+	}
+
+	g, err := LoadChangesetDependencyGraph(ctx, tx, ch.OwnedByBatchChangeID)
+	if err != nil {
+		return false, err
+	}
+
+	n := g.Node(spec.ID)
+	if n == nil {
+		return false, nil
+	}
+	return g.Blocked(n), nil
+}
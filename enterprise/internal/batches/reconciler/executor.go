@@ -17,12 +17,23 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 // executePlan executes the given reconciler plan.
 func executePlan(ctx context.Context, gitserverClient GitserverClient, sourcer sources.Sourcer, noSleepBeforeSync bool, tx *store.Store, plan *Plan) (err error) {
+	// The metadata of the previous spec was never applied to a changeset that
+	// is only being published now.
+	previousSpec := plan.PreviousChangesetSpec
+	for _, op := range plan.Ops {
+		if op == btypes.ReconcilerOperationPublish || op == btypes.ReconcilerOperationPublishDraft {
+			previousSpec = nil
+		}
+	}
+
 	e := &executor{
 		gitserverClient:   gitserverClient,
 		sourcer:           sourcer,
@@ -30,6 +41,7 @@ func executePlan(ctx context.Context, gitserverClient GitserverClient, sourcer s
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		previousSpec:      previousSpec,
 	}
 
 	return e.Run(ctx, plan)
@@ -42,6 +54,7 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	previousSpec      *btypes.ChangesetSpec
 
 	css  sources.ChangesetSource
 	repo *types.Repo
@@ -75,6 +88,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationPush:
 			err = e.pushChangesetPatch(ctx)
 
+		case btypes.ReconcilerOperationRebase:
+			err = e.rebaseChangeset(ctx)
+
 		case btypes.ReconcilerOperationPublish:
 			err = e.publishChangeset(ctx, false)
 
@@ -90,6 +106,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationUndraft:
 			err = e.undraftChangeset(ctx)
 
+		case btypes.ReconcilerOperationUpdateMetadata:
+			err = e.updateChangesetMetadata(ctx)
+
 		case btypes.ReconcilerOperationClose:
 			err = e.closeChangeset(ctx)
 
@@ -154,7 +173,53 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	return e.pushCommit(ctx, opts)
+	if err := e.pushCommit(ctx, opts); err != nil {
+		return err
+	}
+
+	// The new commit is based on the base revision of the spec, so a
+	// re-execution isn't needed anymore.
+	e.ch.NeedsReexecution = false
+	return nil
+}
+
+// rebaseChangeset applies the diff of the current changeset spec on top of
+// the current head of the base branch and force-pushes the resulting commit.
+// If the diff doesn't apply anymore, the changeset is flagged as needing
+// re-execution instead.
+func (e *executor) rebaseChangeset(ctx context.Context) (err error) {
+	rev := e.spec.Spec.BaseRef
+	// GitHub tells us which commit the base branch points to. Resolving it
+	// makes sure gitserver fetches it, in case it hasn't seen it yet.
+	if pr, ok := e.ch.Metadata.(*github.PullRequest); ok && pr.BaseRefOid != "" {
+		rev = pr.BaseRefOid
+	}
+	baseCommit, err := git.ResolveRevision(ctx, e.repo.Name, rev, git.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base revision")
+	}
+
+	pushConf, err := e.css.GitserverPushConfig(ctx, e.tx.ExternalServices(), e.repo)
+	if err != nil {
+		return err
+	}
+	opts, err := buildCommitOpts(e.repo, e.spec, pushConf)
+	if err != nil {
+		return err
+	}
+	opts.BaseCommit = baseCommit
+
+	if _, err := e.gitserverClient.CreateCommitFromPatch(ctx, opts); err != nil {
+		var patchErr *protocol.CreateCommitFromPatchError
+		if errors.As(err, &patchErr) && isPatchApplyError(patchErr) {
+			log15.Info("Diff does not apply to new base, changeset needs re-execution", "changeset", e.ch.ID, "base", baseCommit)
+			e.ch.NeedsReexecution = true
+			return nil
+		}
+		return formatCreateCommitFromPatchError(err)
+	}
+
+	return nil
 }
 
 // publishChangeset creates the given changeset on its code host.
@@ -336,6 +401,28 @@ func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+// updateChangesetMetadata brings the reviewers, labels, assignees and
+// milestone of the given changeset on its code host in line with its
+// ChangesetSpec. Code hosts that support none of them are skipped.
+func (e *executor) updateChangesetMetadata(ctx context.Context) (err error) {
+	metadataCss, ok := sources.ToMetadataChangesetSource(e.css)
+	if !ok {
+		log15.Warn("Changeset source does not support metadata updates", "changeset", e.ch.ID, "externalServiceType", e.ch.ExternalServiceType)
+		return nil
+	}
+
+	update := sources.NewChangesetMetadataUpdate(e.previousSpec, e.spec)
+	if update.IsEmpty() {
+		return nil
+	}
+
+	cs := &sources.Changeset{Repo: e.repo, Changeset: e.ch}
+	if err := metadataCss.UpdateChangesetMetadata(ctx, cs, update); err != nil {
+		return errors.Wrap(err, "updating changeset metadata")
+	}
+	return nil
+}
+
 // sleep sleeps for 3 seconds.
 func (e *executor) sleep() {
 	if !e.noSleepBeforeSync {
@@ -391,22 +478,34 @@ func loadChangesetSource(ctx context.Context, s *store.Store, sourcer sources.So
 func (e *executor) pushCommit(ctx context.Context, opts protocol.CreateCommitFromPatchRequest) error {
 	_, err := e.gitserverClient.CreateCommitFromPatch(ctx, opts)
 	if err != nil {
-		var e *protocol.CreateCommitFromPatchError
-		if errors.As(err, &e) {
-			return errors.Errorf(
-				"creating commit from patch for repository %q: %s\n"+
-					"```\n"+
-					"$ %s\n"+
-					"%s\n"+
-					"```",
-				e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput))
-		}
-		return err
+		return formatCreateCommitFromPatchError(err)
 	}
 
 	return nil
 }
 
+// formatCreateCommitFromPatchError includes the failed command and its output
+// in the error returned by gitserver, so they show up in the UI.
+func formatCreateCommitFromPatchError(err error) error {
+	var e *protocol.CreateCommitFromPatchError
+	if errors.As(err, &e) {
+		return errors.Errorf(
+			"creating commit from patch for repository %q: %s\n"+
+				"```\n"+
+				"$ %s\n"+
+				"%s\n"+
+				"```",
+			e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput))
+	}
+	return err
+}
+
+// isPatchApplyError returns true if gitserver failed to create the commit
+// because the patch doesn't apply to the base commit.
+func isPatchApplyError(e *protocol.CreateCommitFromPatchError) bool {
+	return strings.HasPrefix(e.Command, "git apply")
+}
+
 func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) (opts protocol.CreateCommitFromPatchRequest, err error) {
 	desc := spec.Spec
 
@@ -22,6 +22,7 @@ import (
 	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -634,6 +635,98 @@ func TestExecutor_ExecutePlan_PublishedChangesetDuplicateBranch(t *testing.T) {
 	}
 }
 
+func TestExecutor_ExecutePlan_Rebase(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	cstore := store.New(db, et.TestKey{})
+
+	rs, extSvc := ct.CreateTestRepos(t, ctx, db, 1)
+	repo := rs[0]
+	ct.CreateTestSiteCredential(t, cstore, repo)
+
+	state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: repo.Name,
+		VCS:  protocol.VCSInfo{URL: repo.URI},
+	})
+	defer state.Unmock()
+
+	git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+		if spec != "new-base" {
+			t.Fatalf("wrong revision resolved. want=%q, have=%q", "new-base", spec)
+		}
+		return "new-base-commit", nil
+	}
+	defer func() { git.Mocks.ResolveRevision = nil }()
+
+	pr := buildGithubPR(time.Now(), btypes.ChangesetExternalStateOpen)
+	pr.Mergeable = github.PullRequestMergeableStateConflicting
+	pr.BaseRefOid = "new-base"
+
+	tests := map[string]struct {
+		gitserverErr         error
+		wantNeedsReexecution bool
+	}{
+		"diff applies": {},
+		"diff does not apply": {
+			gitserverErr: &gitprotocol.CreateCommitFromPatchError{
+				RepositoryName: string(repo.Name),
+				InternalError:  "gitserver: applying patch: exit status 1",
+				Command:        "git apply --cached -p0",
+				CombinedOutput: "error: patch failed: README.md:1",
+			},
+			wantNeedsReexecution: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			changeset := ct.CreateChangeset(t, ctx, cstore, ct.TestChangesetOpts{
+				Repo:             repo.ID,
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ExternalID:       pr.ID + name,
+				Metadata:         pr,
+			})
+
+			plan := &Plan{Changeset: changeset}
+			plan.AddOp(btypes.ReconcilerOperationRebase)
+			plan.ChangesetSpec = ct.BuildChangesetSpec(t, ct.TestSpecOpts{
+				Repo:      repo.ID,
+				HeadRef:   "refs/heads/rebase-me",
+				BaseRev:   "old-base-commit",
+				Published: true,
+			})
+
+			gitClient := &ct.FakeGitserverClient{ResponseErr: tc.gitserverErr}
+			sourcer := sources.NewFakeSourcer(nil, &sources.FakeChangesetSource{Svc: extSvc, FakeMetadata: pr})
+
+			if err := executePlan(ctx, gitClient, sourcer, true, cstore, plan); err != nil {
+				t.Fatalf("ExecutePlan failed: %s", err)
+			}
+
+			if !gitClient.CreateCommitFromPatchCalled {
+				t.Fatal("CreateCommitFromPatch not called")
+			}
+			if have, want := gitClient.CreateCommitFromPatchReq.BaseCommit, api.CommitID("new-base-commit"); have != want {
+				t.Fatalf("wrong base commit. want=%q, have=%q", want, have)
+			}
+
+			reloaded, err := cstore.GetChangesetByID(ctx, changeset.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := reloaded.NeedsReexecution, tc.wantNeedsReexecution; have != want {
+				t.Fatalf("wrong NeedsReexecution. want=%t, have=%t", want, have)
+			}
+		})
+	}
+}
+
 func TestLoadChangesetSource(t *testing.T) {
 	ctx := backend.WithAuthzBypass(context.Background())
 	db := dbtest.NewDB(t, "")
@@ -11,18 +11,20 @@ import (
 )
 
 var operationPrecedence = map[btypes.ReconcilerOperation]int{
-	btypes.ReconcilerOperationPush:         0,
-	btypes.ReconcilerOperationDetach:       0,
-	btypes.ReconcilerOperationArchive:      0,
-	btypes.ReconcilerOperationImport:       1,
-	btypes.ReconcilerOperationPublish:      1,
-	btypes.ReconcilerOperationPublishDraft: 1,
-	btypes.ReconcilerOperationClose:        1,
-	btypes.ReconcilerOperationReopen:       2,
-	btypes.ReconcilerOperationUndraft:      3,
-	btypes.ReconcilerOperationUpdate:       4,
-	btypes.ReconcilerOperationSleep:        5,
-	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationPush:           0,
+	btypes.ReconcilerOperationRebase:         0,
+	btypes.ReconcilerOperationDetach:         0,
+	btypes.ReconcilerOperationArchive:        0,
+	btypes.ReconcilerOperationImport:         1,
+	btypes.ReconcilerOperationPublish:        1,
+	btypes.ReconcilerOperationPublishDraft:   1,
+	btypes.ReconcilerOperationClose:          1,
+	btypes.ReconcilerOperationReopen:         2,
+	btypes.ReconcilerOperationUndraft:        3,
+	btypes.ReconcilerOperationUpdate:         4,
+	btypes.ReconcilerOperationUpdateMetadata: 5,
+	btypes.ReconcilerOperationSleep:          6,
+	btypes.ReconcilerOperationSync:           7,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -31,6 +33,16 @@ func (ops Operations) IsNone() bool {
 	return len(ops) == 0
 }
 
+// Contains returns whether op is one of the operations.
+func (ops Operations) Contains(op btypes.ReconcilerOperation) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
 func (ops Operations) Equal(b Operations) bool {
 	if len(ops) != len(b) {
 		return false
@@ -91,17 +103,68 @@ type Plan struct {
 	// The changeset spec that is used in this plan.
 	ChangesetSpec *btypes.ChangesetSpec
 
+	// The changeset spec that was previously applied to the changeset, if
+	// any.
+	PreviousChangesetSpec *btypes.ChangesetSpec
+
 	// The operations that need to be done to reconcile the changeset.
 	Ops Operations
 
 	// The Delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	Delta *ChangesetSpecDelta
+
+	// Whether the changeset is held back because changesets it depends on
+	// haven't been merged yet.
+	BlockedByDependencies bool
+
+	// Whether the changeset is held back because open changesets of other
+	// batch changes modify the same files.
+	BlockedByConflicts bool
 }
 
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
 func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 
+// HoldForDependencies changes the plan so that the changeset isn't published
+// or taken out of draft, because changesets it depends on haven't been merged
+// yet. If the code host supports drafts, the changeset is published as a
+// draft instead, so that reviewers can already take a look.
+func (p *Plan) HoldForDependencies() {
+	p.BlockedByDependencies = true
+	p.holdBack()
+}
+
+// HoldForConflicts changes the plan in the same way as HoldForDependencies,
+// because open changesets of other batch changes modify the same files and
+// the batch spec asks to wait for them.
+func (p *Plan) HoldForConflicts() {
+	p.BlockedByConflicts = true
+	p.holdBack()
+}
+
+// holdBack removes the publish and undraft operations from the plan,
+// publishing the changeset as a draft instead if possible.
+func (p *Plan) holdBack() {
+	if p.Ops.Contains(btypes.ReconcilerOperationPublish) && !p.Changeset.SupportsDraft() {
+		// Everything else in the plan requires the changeset to be published.
+		p.Ops = Operations{}
+		return
+	}
+
+	ops := make(Operations, 0, len(p.Ops))
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish:
+			ops = append(ops, btypes.ReconcilerOperationPublishDraft)
+		case btypes.ReconcilerOperationUndraft:
+		default:
+			ops = append(ops, op)
+		}
+	}
+	p.Ops = ops
+}
+
 // DeterminePlan looks at the given changeset to determine what action the
 // reconciler should take.
 // It consumes the current and the previous changeset spec, if they exist. If
@@ -109,8 +172,9 @@ func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 // error.
 func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.Changeset) (*Plan, error) {
 	pl := &Plan{
-		Changeset:     ch,
-		ChangesetSpec: currentSpec,
+		Changeset:             ch,
+		ChangesetSpec:         currentSpec,
+		PreviousChangesetSpec: previousSpec,
 	}
 
 	wantDetach := false
@@ -182,6 +246,11 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 			pl.SetOp(btypes.ReconcilerOperationPublishDraft)
 			pl.AddOp(btypes.ReconcilerOperationPush)
 		}
+		// Once published, apply the reviewers, labels, etc. of the spec.
+		publishing := pl.Ops.Contains(btypes.ReconcilerOperationPublish) || pl.Ops.Contains(btypes.ReconcilerOperationPublishDraft)
+		if publishing && currentSpec.Spec.HasMetadata() {
+			pl.AddOp(btypes.ReconcilerOperationUpdateMetadata)
+		}
 		// TODO: test for Published.Nil() and then plan based on the UI
 		// publication state. For now, we'll let it fall through and treat it
 		// the same as being unpublished.
@@ -235,6 +304,29 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 			}
 		}
 
+		if delta.MetadataChanged {
+			pl.AddOp(btypes.ReconcilerOperationUpdateMetadata)
+		}
+
+		if ch.HasDiff() && !pl.Ops.Contains(btypes.ReconcilerOperationPush) {
+			if ch.NeedsReexecution && previousSpec != nil && previousSpec.Spec.BaseRev != currentSpec.Spec.BaseRev {
+				// The batch spec has been re-executed against a newer base
+				// revision. Even if that produced the same diff, we need to
+				// push it, since the old commit conflicts with the base.
+				pl.AddOp(btypes.ReconcilerOperationPush)
+				pl.AddOp(btypes.ReconcilerOperationSleep)
+				pl.AddOp(btypes.ReconcilerOperationSync)
+			} else if ch.Conflicting() && !ch.NeedsReexecution {
+				// The base branch moved and the changeset now conflicts with
+				// it, so we try to apply the diff on top of the new base. If
+				// that doesn't work, the changeset is flagged as needing
+				// re-execution and we don't try again.
+				pl.AddOp(btypes.ReconcilerOperationRebase)
+				pl.AddOp(btypes.ReconcilerOperationSleep)
+				pl.AddOp(btypes.ReconcilerOperationSync)
+			}
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", ch.PublicationState)
 	}
@@ -279,6 +371,13 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	if previous.Spec.BaseRef != current.Spec.BaseRef {
 		delta.BaseRefChanged = true
 	}
+	if !stringSlicesEqual(previous.Spec.Reviewers, current.Spec.Reviewers) ||
+		!stringSlicesEqual(previous.Spec.TeamReviewers, current.Spec.TeamReviewers) ||
+		!stringSlicesEqual(previous.Spec.Labels, current.Spec.Labels) ||
+		!stringSlicesEqual(previous.Spec.Assignees, current.Spec.Assignees) ||
+		previous.Spec.Milestone != current.Spec.Milestone {
+		delta.MetadataChanged = true
+	}
 
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
@@ -352,6 +451,7 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	MetadataChanged      bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
@@ -367,3 +467,23 @@ func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
 	return d.NeedCommitUpdate() || d.NeedCodeHostUpdate()
 }
+
+// stringSlicesEqual returns whether a and b contain the same strings, in any
+// order.
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		if counts[v] == 0 {
+			return false
+		}
+		counts[v]--
+	}
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
 
 func TestDetermineReconcilerPlan(t *testing.T) {
@@ -206,6 +207,63 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			},
 			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
 		},
+		{
+			name:        "publish true with metadata",
+			currentSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"bug"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublish,
+				btypes.ReconcilerOperationUpdateMetadata,
+			},
+		},
+		{
+			name:        "publish false with metadata",
+			currentSpec: &ct.TestSpecOpts{Published: false, Labels: []string{"bug"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:        "detaching unpublished changeset with metadata from another batch change",
+			currentSpec: &ct.TestSpecOpts{Published: false, Labels: []string{"bug"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+				OwnedByBatchChange: 1234,
+				BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: 1234}, {BatchChangeID: 2345, Detach: true}},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationDetach},
+		},
+		{
+			name:         "labels changed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"bug"}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Labels: []string{"bug", "enhancement"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdateMetadata},
+		},
+		{
+			name:         "metadata reordered on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}, Milestone: "v1"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Reviewers: []string{"bob", "alice"}, Milestone: "v1"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "milestone removed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Milestone: "v1"},
+			currentSpec:  &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdateMetadata},
+		},
 		{
 			name:         "commit diff changed on published changeset",
 			previousSpec: &ct.TestSpecOpts{Published: true, CommitDiff: "testDiff"},
@@ -394,6 +452,75 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationImport,
 			},
 		},
+		{
+			name:         "conflicting changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true},
+			currentSpec:  &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata:         &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationRebase,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "conflicting changeset with new diff",
+			previousSpec: &ct.TestSpecOpts{Published: true, CommitDiff: "old diff"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, CommitDiff: "new diff"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata:         &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "conflicting closed changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true},
+			currentSpec:  &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateClosed,
+				Metadata:         &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "conflicting changeset needing re-execution",
+			previousSpec: &ct.TestSpecOpts{Published: true},
+			currentSpec:  &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				NeedsReexecution: true,
+				Metadata:         &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "re-executed against new base revision",
+			previousSpec: &ct.TestSpecOpts{Published: true, BaseRev: "old-base"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, BaseRev: "new-base"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				NeedsReexecution: true,
+				Metadata:         &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -420,6 +547,81 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 	}
 }
 
+func TestPlanHoldForDependencies(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name           string
+		previousSpec   *ct.TestSpecOpts
+		currentSpec    *ct.TestSpecOpts
+		changeset      ct.TestChangesetOpts
+		wantOperations Operations
+	}{
+		{
+			name:        "publish as draft instead",
+			currentSpec: &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+		},
+		{
+			name:        "drafts unsupported",
+			currentSpec: &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "no undraft",
+			previousSpec: &ct.TestSpecOpts{Published: "draft", Title: "Before"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Title: "After"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateDraft,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var previousSpec *btypes.ChangesetSpec
+			if tc.previousSpec != nil {
+				previousSpec = ct.BuildChangesetSpec(t, *tc.previousSpec)
+			}
+			currentSpec := ct.BuildChangesetSpec(t, *tc.currentSpec)
+			cs := ct.BuildChangeset(tc.changeset)
+
+			plan, err := DeterminePlan(previousSpec, currentSpec, cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			plan.HoldForDependencies()
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan determined, want=%v have=%v", want, have)
+			}
+			if !plan.BlockedByDependencies {
+				t.Fatal("plan not marked as blocked by dependencies")
+			}
+
+			plan, err = DeterminePlan(previousSpec, currentSpec, ct.BuildChangeset(tc.changeset))
+			if err != nil {
+				t.Fatal(err)
+			}
+			plan.HoldForConflicts()
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan determined when held for conflicts, want=%v have=%v", want, have)
+			}
+			if !plan.BlockedByConflicts || plan.BlockedByDependencies {
+				t.Fatal("plan not marked as blocked by conflicts")
+			}
+		})
+	}
+}
+
 func uiPublicationStatePtr(state btypes.ChangesetUiPublicationState) *btypes.ChangesetUiPublicationState {
 	return &state
 }
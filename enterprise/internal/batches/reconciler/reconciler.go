@@ -3,6 +3,7 @@ package reconciler
 import (
 	"context"
 
+	"github.com/cockroachdb/errors"
 	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
 
@@ -88,6 +89,22 @@ func (r *Reconciler) process(ctx context.Context, tx *store.Store, ch *btypes.Ch
 		return err
 	}
 
+	blocked, err := blockedByDependencies(ctx, tx, ch, curr)
+	if err != nil {
+		return errors.Wrap(err, "resolving changeset dependencies")
+	}
+	if blocked {
+		plan.HoldForDependencies()
+	} else if plan.Ops.Contains(btypes.ReconcilerOperationPublish) || plan.Ops.Contains(btypes.ReconcilerOperationUndraft) {
+		blocked, err = BlockedByConflicts(ctx, tx, ch, curr)
+		if err != nil {
+			return errors.Wrap(err, "detecting conflicting changesets")
+		}
+		if blocked {
+			plan.HoldForConflicts()
+		}
+	}
+
 	log15.Info("Reconciler processing changeset", "changeset", ch.ID, "operations", plan.Ops)
 
 	return executePlan(
@@ -90,6 +90,9 @@ type BatchChange struct {
 	ChangesetCountsOverTime []ChangesetCounts
 	DiffStat                DiffStat
 	BulkOperations          BulkOperationConnection
+	AutoMergeStrategy       *string
+	ContinuousIntervalHours *int32
+	ContinuousApplyPolicy   *string
 }
 
 type BatchChangeConnection struct {
@@ -381,3 +384,35 @@ type BatchSpecExecution struct {
 	Initiator    User
 	Namespace    UserOrg
 }
+
+type BatchSpecTemplate struct {
+	ID                  string
+	Name                string
+	Description         string
+	Namespace           UserOrg
+	Creator             User
+	LatestVersion       BatchSpecTemplateVersion
+	Versions            []BatchSpecTemplateVersion
+	ViewerCanAdminister bool
+}
+
+type BatchSpecTemplateVersion struct {
+	Version    int32
+	Spec       string
+	Parameters []BatchSpecTemplateParameter
+	RenderSpec string
+}
+
+type BatchSpecTemplateParameter struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	Default     interface{}
+}
+
+type BatchSpecTemplateConnection struct {
+	Nodes      []BatchSpecTemplate
+	TotalCount int
+	PageInfo   PageInfo
+}
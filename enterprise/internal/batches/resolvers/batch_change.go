@@ -2,10 +2,9 @@ package resolvers
 
 import (
 	"context"
-	"sort"
 	"strconv"
+	"strings"
 	"sync"
-	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
@@ -153,6 +152,50 @@ func (r *batchChangeResolver) ClosedAt() *graphqlbackend.DateTime {
 	return &graphqlbackend.DateTime{Time: r.batchChange.ClosedAt}
 }
 
+func (r *batchChangeResolver) AutoMergeStrategy() *string {
+	if !r.batchChange.AutoMergeEnabled() {
+		return nil
+	}
+	strategy := strings.ToUpper(string(r.batchChange.AutoMergeStrategy))
+	return &strategy
+}
+
+func (r *batchChangeResolver) ContinuousIntervalHours() *int32 {
+	if !r.batchChange.ContinuousEnabled() {
+		return nil
+	}
+	hours := r.batchChange.ContinuousIntervalHours
+	return &hours
+}
+
+func (r *batchChangeResolver) ContinuousApplyPolicy() *string {
+	if !r.batchChange.ContinuousEnabled() {
+		return nil
+	}
+	policy := strings.ToUpper(string(r.batchChange.ContinuousApplyPolicy))
+	return &policy
+}
+
+func (r *batchChangeResolver) ContinuousRuns(ctx context.Context, args *graphqlbackend.ListBatchChangeContinuousRunsArgs) ([]graphqlbackend.BatchChangeContinuousRunResolver, error) {
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+
+	runs, _, err := r.store.ListBatchChangeContinuousRuns(ctx, store.ListBatchChangeContinuousRunsOpts{
+		LimitOpts:     store.LimitOpts{Limit: int(args.First)},
+		BatchChangeID: r.batchChange.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeContinuousRunResolver, 0, len(runs))
+	for _, run := range runs {
+		resolvers = append(resolvers, &batchChangeContinuousRunResolver{store: r.store, run: run})
+	}
+	return resolvers, nil
+}
+
 func (r *batchChangeResolver) ChangesetsStats(ctx context.Context) (graphqlbackend.ChangesetsStatsResolver, error) {
 	stats, err := r.store.GetChangesetsStats(ctx, r.batchChange.ID)
 	if err != nil {
@@ -188,44 +231,14 @@ func (r *batchChangeResolver) ChangesetCountsOverTime(
 		// Only load fully-synced changesets, so that the data we use for computing the changeset counts is complete.
 		PublicationState: &publishedState,
 	}
-	cs, _, err := r.store.ListChangesets(ctx, opts)
+	cs, events, err := listChangesetsWithEvents(ctx, r.store, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var es []*btypes.ChangesetEvent
-	changesetIDs := cs.IDs()
-	if len(changesetIDs) > 0 {
-		eventsOpts := store.ListChangesetEventsOpts{ChangesetIDs: changesetIDs, Kinds: state.RequiredEventTypesForHistory}
-		es, _, err = r.store.ListChangesetEvents(ctx, eventsOpts)
-		if err != nil {
-			return nil, err
-		}
-	}
-	// Sort all events once by their timestamps, CalcCounts depends on it.
-	events := state.ChangesetEvents(es)
-	sort.Sort(events)
-
-	// Determine timeframe.
-	now := r.store.Clock()()
-	weekAgo := now.Add(-7 * 24 * time.Hour)
-	start := r.batchChange.CreatedAt.UTC()
-	if len(events) > 0 {
-		start = events[0].Timestamp().UTC()
-	}
-	// At least a week lookback, more if the batch change was created earlier.
-	if start.After(weekAgo) {
-		start = weekAgo
-	}
-	if args.From != nil {
-		start = args.From.Time.UTC()
-	}
-	end := now.UTC()
-	if args.To != nil && args.To.Time.Before(end) {
-		end = args.To.Time.UTC()
-	}
+	start, end := countsTimeframe(r.store.Clock()(), r.batchChange.CreatedAt, events, args.From, args.To)
 
-	counts, err := state.CalcCounts(start, end, cs, es...)
+	counts, err := state.CalcCounts(start, end, cs, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -238,6 +251,24 @@ func (r *batchChangeResolver) ChangesetCountsOverTime(
 	return resolvers, nil
 }
 
+func (r *batchChangeResolver) Report(ctx context.Context, args *graphqlbackend.BatchChangeReportArgs) (graphqlbackend.BatchChangesReportResolver, error) {
+	publishedState := btypes.ChangesetPublicationStatePublished
+	return &batchChangesReportResolver{
+		store: r.store,
+		opts: store.ListChangesetsOpts{
+			BatchChangeID:    r.batchChange.ID,
+			IncludeArchived:  args.IncludeArchived,
+			PublicationState: &publishedState,
+			EnforceAuthz:     true,
+		},
+		batchChange:    r.batchChange,
+		from:           args.From,
+		to:             args.To,
+		staleAfterDays: args.StaleAfterDays,
+		mergeSLADays:   args.MergeSLADays,
+	}, nil
+}
+
 func (r *batchChangeResolver) DiffStat(ctx context.Context) (*graphqlbackend.DiffStat, error) {
 	diffStat, err := r.store.GetBatchChangeDiffStat(ctx, store.GetBatchChangeDiffStatOpts{BatchChangeID: r.batchChange.ID})
 	if err != nil {
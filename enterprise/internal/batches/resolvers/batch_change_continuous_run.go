@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+type batchChangeContinuousRunResolver struct {
+	store *store.Store
+	run   *btypes.BatchChangeContinuousRun
+}
+
+// Type guard.
+var _ graphqlbackend.BatchChangeContinuousRunResolver = &batchChangeContinuousRunResolver{}
+
+func (r *batchChangeContinuousRunResolver) State() string {
+	return strings.ToUpper(string(r.run.State))
+}
+
+func (r *batchChangeContinuousRunResolver) Message() *string {
+	if r.run.Message == "" {
+		return nil
+	}
+	return &r.run.Message
+}
+
+func (r *batchChangeContinuousRunResolver) BatchSpecExecution(ctx context.Context) (graphqlbackend.BatchSpecExecutionResolver, error) {
+	exec, err := r.store.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: r.run.BatchSpecExecutionID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchSpecExecutionResolver{store: r.store, exec: exec}, nil
+}
+
+func (r *batchChangeContinuousRunResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.run.CreatedAt}
+}
+
+func (r *batchChangeContinuousRunResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.run.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.run.FinishedAt}
+}
@@ -0,0 +1,315 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// listChangesetsWithEvents lists the changesets matching the given options,
+// together with the events required to compute their history, sorted by
+// their timestamps.
+func listChangesetsWithEvents(ctx context.Context, s *store.Store, opts store.ListChangesetsOpts) (btypes.Changesets, state.ChangesetEvents, error) {
+	cs, _, err := s.ListChangesets(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var es []*btypes.ChangesetEvent
+	changesetIDs := cs.IDs()
+	if len(changesetIDs) > 0 {
+		kinds := append(append([]btypes.ChangesetEventKind{}, state.RequiredEventTypesForHistory...), state.RequiredEventTypesForCheckHistory...)
+		eventsOpts := store.ListChangesetEventsOpts{ChangesetIDs: changesetIDs, Kinds: kinds}
+		es, _, err = s.ListChangesetEvents(ctx, eventsOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	// Sort all events once by their timestamps, CalcCounts depends on it.
+	events := state.ChangesetEvents(es)
+	sort.Sort(events)
+
+	return cs, events, nil
+}
+
+// countsTimeframe determines the timeframe of changeset counts over time.
+// Unless given, it starts at the first event, or when the batch change was
+// created if there are no events, but at least a week ago. It ends now,
+// unless an earlier end is given.
+func countsTimeframe(now, createdAt time.Time, events state.ChangesetEvents, from, to *graphqlbackend.DateTime) (start, end time.Time) {
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	start = createdAt.UTC()
+	if len(events) > 0 {
+		start = events[0].Timestamp().UTC()
+	}
+	// At least a week lookback, more if the batch change was created earlier.
+	if start.After(weekAgo) {
+		start = weekAgo
+	}
+	if from != nil {
+		start = from.Time.UTC()
+	}
+	end = now.UTC()
+	if to != nil && to.Time.Before(end) {
+		end = to.Time.UTC()
+	}
+	return start, end
+}
+
+var _ graphqlbackend.BatchChangesReportResolver = &batchChangesReportResolver{}
+
+type batchChangesReportResolver struct {
+	store *store.Store
+	opts  store.ListChangesetsOpts
+
+	// batchChange is nil if the report covers all batch changes.
+	batchChange *btypes.BatchChange
+
+	from, to       *graphqlbackend.DateTime
+	staleAfterDays int32
+	mergeSLADays   int32
+
+	once             sync.Once
+	report           *state.ChangesetReport
+	repos            map[api.RepoID]*types.Repo
+	batchChangeNames map[int64]string
+	err              error
+}
+
+func (r *batchChangesReportResolver) compute(ctx context.Context) (*state.ChangesetReport, error) {
+	r.once.Do(func() {
+		cs, events, err := listChangesetsWithEvents(ctx, r.store, r.opts)
+		if err != nil {
+			r.err = err
+			return
+		}
+
+		var createdAt time.Time
+		if r.batchChange != nil {
+			createdAt = r.batchChange.CreatedAt
+			r.batchChangeNames = map[int64]string{r.batchChange.ID: r.batchChange.Name}
+		} else {
+			// Across all batch changes, only changesets that are part of at
+			// least one batch change are reported on.
+			filtered := cs[:0]
+			for _, c := range cs {
+				if len(c.BatchChanges) > 0 {
+					filtered = append(filtered, c)
+				}
+			}
+			cs = filtered
+
+			batchChanges, _, err := r.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{})
+			if err != nil {
+				r.err = err
+				return
+			}
+			r.batchChangeNames = make(map[int64]string, len(batchChanges))
+			for _, b := range batchChanges {
+				r.batchChangeNames[b.ID] = b.Name
+				if createdAt.IsZero() || b.CreatedAt.Before(createdAt) {
+					createdAt = b.CreatedAt
+				}
+			}
+		}
+
+		repoIDs := make([]api.RepoID, 0, len(cs))
+		for _, c := range cs {
+			repoIDs = append(repoIDs, c.RepoID)
+		}
+		// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter
+		// under the hood and filters out repositories that the user doesn't
+		// have access to.
+		r.repos, err = r.store.Repos().GetReposSetByIDs(ctx, repoIDs...)
+		if err != nil {
+			r.err = err
+			return
+		}
+		accessible := cs[:0]
+		repoNames := make(map[api.RepoID]api.RepoName, len(r.repos))
+		for _, c := range cs {
+			if repo, ok := r.repos[c.RepoID]; ok {
+				accessible = append(accessible, c)
+				repoNames[c.RepoID] = repo.Name
+			}
+		}
+		cs = accessible
+
+		now := r.store.Clock()()
+		start, end := countsTimeframe(now, createdAt, events, r.from, r.to)
+
+		r.report, r.err = state.CalcReport(state.ReportOpts{
+			Start:      start,
+			End:        end,
+			Now:        now,
+			StaleAfter: time.Duration(r.staleAfterDays) * 24 * time.Hour,
+			MergeSLA:   time.Duration(r.mergeSLADays) * 24 * time.Hour,
+		}, cs, repoNames, events...)
+	})
+	return r.report, r.err
+}
+
+func (r *batchChangesReportResolver) CountsOverTime(ctx context.Context) ([]graphqlbackend.ChangesetCountsResolver, error) {
+	report, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetCountsResolver, 0, len(report.Counts))
+	for _, c := range report.Counts {
+		resolvers = append(resolvers, &changesetCountsResolver{counts: c})
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangesReportResolver) Totals(ctx context.Context) (graphqlbackend.ChangesetsBreakdownResolver, error) {
+	report, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &changesetsBreakdownResolver{breakdown: report.Totals}, nil
+}
+
+func (r *batchChangesReportResolver) ByCodeHost(ctx context.Context) ([]graphqlbackend.ChangesetsBreakdownResolver, error) {
+	report, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return breakdownResolvers(report.ByCodeHost), nil
+}
+
+func (r *batchChangesReportResolver) ByOrganization(ctx context.Context) ([]graphqlbackend.ChangesetsBreakdownResolver, error) {
+	report, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return breakdownResolvers(report.ByOrg), nil
+}
+
+func (r *batchChangesReportResolver) StaleChangesets(ctx context.Context) ([]graphqlbackend.ChangesetResolver, error) {
+	report, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := report.Stale()
+	resolvers := make([]graphqlbackend.ChangesetResolver, 0, len(stale))
+	for _, e := range stale {
+		resolvers = append(resolvers, NewChangesetResolver(r.store, e.Changeset, r.repos[e.Changeset.RepoID]))
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangesReportResolver) ChangesetsCSV(ctx context.Context) (string, error) {
+	report, err := r.compute(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := state.WriteChangesetsCSV(&buf, report, r.batchChangeNames); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (r *batchChangesReportResolver) CountsOverTimeCSV(ctx context.Context) (string, error) {
+	report, err := r.compute(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := state.WriteCountsCSV(&buf, report); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func breakdownResolvers(bs []*state.ChangesetBreakdown) []graphqlbackend.ChangesetsBreakdownResolver {
+	resolvers := make([]graphqlbackend.ChangesetsBreakdownResolver, 0, len(bs))
+	for _, b := range bs {
+		resolvers = append(resolvers, &changesetsBreakdownResolver{breakdown: b})
+	}
+	return resolvers
+}
+
+type changesetsBreakdownResolver struct {
+	breakdown *state.ChangesetBreakdown
+}
+
+func (r *changesetsBreakdownResolver) Key() string         { return r.breakdown.Key }
+func (r *changesetsBreakdownResolver) Total() int32        { return r.breakdown.Total }
+func (r *changesetsBreakdownResolver) Open() int32         { return r.breakdown.Open }
+func (r *changesetsBreakdownResolver) Draft() int32        { return r.breakdown.Draft }
+func (r *changesetsBreakdownResolver) Merged() int32       { return r.breakdown.Merged }
+func (r *changesetsBreakdownResolver) Closed() int32       { return r.breakdown.Closed }
+func (r *changesetsBreakdownResolver) OpenApproved() int32 { return r.breakdown.OpenApproved }
+func (r *changesetsBreakdownResolver) OpenChangesRequested() int32 {
+	return r.breakdown.OpenChangesRequested
+}
+func (r *changesetsBreakdownResolver) OpenPending() int32       { return r.breakdown.OpenPending }
+func (r *changesetsBreakdownResolver) OpenChecksPending() int32 { return r.breakdown.OpenChecksPending }
+func (r *changesetsBreakdownResolver) OpenChecksPassed() int32  { return r.breakdown.OpenChecksPassed }
+func (r *changesetsBreakdownResolver) OpenChecksFailed() int32  { return r.breakdown.OpenChecksFailed }
+func (r *changesetsBreakdownResolver) Stale() int32             { return r.breakdown.Stale }
+func (r *changesetsBreakdownResolver) SLABreaches() int32       { return r.breakdown.SLABreaches }
+
+func (r *changesetsBreakdownResolver) TimeToMerge() graphqlbackend.TimeToMergeDistributionResolver {
+	return &timeToMergeDistributionResolver{dist: r.breakdown.TimeToMerge}
+}
+
+type timeToMergeDistributionResolver struct {
+	dist state.TimeToMergeDistribution
+}
+
+func (r *timeToMergeDistributionResolver) Count() int32 { return r.dist.Count }
+
+func (r *timeToMergeDistributionResolver) MeanSeconds() *int32   { return r.seconds(r.dist.Mean) }
+func (r *timeToMergeDistributionResolver) MedianSeconds() *int32 { return r.seconds(r.dist.Median) }
+func (r *timeToMergeDistributionResolver) P90Seconds() *int32    { return r.seconds(r.dist.P90) }
+func (r *timeToMergeDistributionResolver) MaxSeconds() *int32    { return r.seconds(r.dist.Max) }
+
+// seconds returns nil if no changesets have been merged, since the
+// statistics are undefined then.
+func (r *timeToMergeDistributionResolver) seconds(d time.Duration) *int32 {
+	if r.dist.Count == 0 {
+		return nil
+	}
+	return durationSeconds(d)
+}
+
+func (r *timeToMergeDistributionResolver) Buckets() []graphqlbackend.TimeToMergeBucketResolver {
+	resolvers := make([]graphqlbackend.TimeToMergeBucketResolver, 0, len(r.dist.Buckets))
+	for _, b := range r.dist.Buckets {
+		resolvers = append(resolvers, &timeToMergeBucketResolver{bucket: b})
+	}
+	return resolvers
+}
+
+type timeToMergeBucketResolver struct {
+	bucket state.TimeToMergeBucket
+}
+
+func (r *timeToMergeBucketResolver) MaxSeconds() *int32 {
+	if r.bucket.UpperBound == 0 {
+		return nil
+	}
+	return durationSeconds(r.bucket.UpperBound)
+}
+
+func (r *timeToMergeBucketResolver) Count() int32 { return r.bucket.Count }
+
+func durationSeconds(d time.Duration) *int32 {
+	s := int32(d / time.Second)
+	return &s
+}
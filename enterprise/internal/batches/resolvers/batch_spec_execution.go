@@ -41,6 +41,12 @@ func (r *batchSpecExecutionResolver) InputSpec() string {
 }
 
 func (r *batchSpecExecutionResolver) State() string {
+	// Once the workspaces are resolved, the execution is queued for assembly
+	// while its workspaces are being executed. From the user's point of view
+	// it's still being processed.
+	if r.exec.WorkspacesResolvedAt != nil && r.exec.State == btypes.BatchSpecExecutionStateQueued {
+		return strings.ToUpper(string(btypes.BatchSpecExecutionStateProcessing))
+	}
 	return strings.ToUpper(string(r.exec.State))
 }
 
@@ -114,6 +120,107 @@ func (r *batchSpecExecutionResolver) Namespace(ctx context.Context) (*graphqlbac
 	return &namespace, err
 }
 
+func (r *batchSpecExecutionResolver) Workspaces(ctx context.Context) ([]graphqlbackend.BatchSpecWorkspaceExecutionResolver, error) {
+	jobs, err := r.store.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+		BatchSpecExecutionID: r.exec.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecWorkspaceExecutionResolver, 0, len(jobs))
+	for _, job := range jobs {
+		resolvers = append(resolvers, &batchSpecWorkspaceExecutionResolver{store: r.store, job: job})
+	}
+	return resolvers, nil
+}
+
+type batchSpecWorkspaceExecutionResolver struct {
+	store *store.Store
+	job   *btypes.BatchSpecWorkspaceExecutionJob
+}
+
+var _ graphqlbackend.BatchSpecWorkspaceExecutionResolver = &batchSpecWorkspaceExecutionResolver{}
+
+func (r *batchSpecWorkspaceExecutionResolver) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	// 🚨 SECURITY: database.Repos.GetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	rs, err := r.store.Repos().GetByIDs(ctx, r.job.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) != 1 {
+		return nil, nil
+	}
+	return graphqlbackend.NewRepositoryResolver(r.store.DB(), rs[0]), nil
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) Branch() string {
+	return r.job.Branch
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) Commit() string {
+	return r.job.Commit
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) Path() string {
+	return r.job.Path
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) State() string {
+	return strings.ToUpper(string(r.job.State))
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) StartedAt() *graphqlbackend.DateTime {
+	if r.job.StartedAt == nil {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: *r.job.StartedAt}
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.job.FinishedAt == nil {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: *r.job.FinishedAt}
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) Failure() *string {
+	return r.job.FailureMessage
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) ExecutionLogs() []graphqlbackend.ExecutionLogEntryResolver {
+	resolvers := make([]graphqlbackend.ExecutionLogEntryResolver, 0, len(r.job.ExecutionLogs))
+	for _, entry := range r.job.ExecutionLogs {
+		resolvers = append(resolvers, graphqlbackend.NewExecutionLogEntryResolver(r.store.DB(), entry))
+	}
+	return resolvers
+}
+
+func (r *batchSpecWorkspaceExecutionResolver) ChangesetSpecs(ctx context.Context) ([]graphqlbackend.ChangesetSpecResolver, error) {
+	// An empty list of rand IDs would not filter the changeset specs at all.
+	if len(r.job.ChangesetSpecRandIDs) == 0 {
+		return []graphqlbackend.ChangesetSpecResolver{}, nil
+	}
+
+	specs, _, err := r.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{
+		RandIDs: r.job.ChangesetSpecRandIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetSpecResolver, 0, len(specs))
+	for _, spec := range specs {
+		resolver, err := NewChangesetSpecResolver(ctx, r.store, spec)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, resolver)
+	}
+	return resolvers, nil
+}
+
 type batchSpecExecutionStepsResolver struct {
 	store *store.Store
 	exec  *btypes.BatchSpecExecution
@@ -0,0 +1,240 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const batchSpecTemplateIDKind = "BatchSpecTemplate"
+
+func marshalBatchSpecTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(batchSpecTemplateIDKind, id)
+}
+
+func unmarshalBatchSpecTemplateID(id graphql.ID) (batchSpecTemplateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &batchSpecTemplateID)
+	return
+}
+
+type batchSpecTemplateResolver struct {
+	store    *store.Store
+	template *btypes.BatchSpecTemplate
+}
+
+var _ graphqlbackend.BatchSpecTemplateResolver = &batchSpecTemplateResolver{}
+
+func (r *batchSpecTemplateResolver) ID() graphql.ID {
+	return marshalBatchSpecTemplateID(r.template.ID)
+}
+
+func (r *batchSpecTemplateResolver) Namespace(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	var (
+		namespace graphqlbackend.NamespaceResolver
+		err       error
+	)
+	if r.template.NamespaceUserID != 0 {
+		namespace.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.store.DB(), r.template.NamespaceUserID)
+		return &namespace, err
+	}
+	namespace.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.store.DB(), r.template.NamespaceOrgID)
+	return &namespace, err
+}
+
+func (r *batchSpecTemplateResolver) Name() string {
+	return r.template.Name
+}
+
+func (r *batchSpecTemplateResolver) Description() string {
+	return r.template.Description
+}
+
+func (r *batchSpecTemplateResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DB(), r.template.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchSpecTemplateResolver) LatestVersion(ctx context.Context) (graphqlbackend.BatchSpecTemplateVersionResolver, error) {
+	v, err := r.store.GetBatchSpecTemplateVersion(ctx, r.template.ID, r.template.LatestVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &batchSpecTemplateVersionResolver{store: r.store, version: v}, nil
+}
+
+func (r *batchSpecTemplateResolver) Versions(ctx context.Context) ([]graphqlbackend.BatchSpecTemplateVersionResolver, error) {
+	vs, err := r.store.ListBatchSpecTemplateVersions(ctx, r.template.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecTemplateVersionResolver, 0, len(vs))
+	for _, v := range vs {
+		resolvers = append(resolvers, &batchSpecTemplateVersionResolver{store: r.store, version: v})
+	}
+	return resolvers, nil
+}
+
+func (r *batchSpecTemplateResolver) Version(ctx context.Context, args *struct{ Version int32 }) (graphqlbackend.BatchSpecTemplateVersionResolver, error) {
+	v, err := r.store.GetBatchSpecTemplateVersion(ctx, r.template.ID, args.Version)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchSpecTemplateVersionResolver{store: r.store, version: v}, nil
+}
+
+func (r *batchSpecTemplateResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
+	svc := service.New(r.store)
+	// 🚨 SECURITY: Only users with access to the namespace of the template
+	// can update or delete it.
+	err := svc.CheckNamespaceAccess(ctx, r.template.NamespaceUserID, r.template.NamespaceOrgID)
+	if err != nil {
+		if err == backend.ErrNotAnOrgMember || errors.HasType(err, &backend.InsufficientAuthorizationError{}) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *batchSpecTemplateResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.CreatedAt}
+}
+
+func (r *batchSpecTemplateResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.UpdatedAt}
+}
+
+type batchSpecTemplateVersionResolver struct {
+	store   *store.Store
+	version *btypes.BatchSpecTemplateVersion
+}
+
+var _ graphqlbackend.BatchSpecTemplateVersionResolver = &batchSpecTemplateVersionResolver{}
+
+func (r *batchSpecTemplateVersionResolver) Version() int32 {
+	return r.version.Version
+}
+
+func (r *batchSpecTemplateVersionResolver) Spec() string {
+	return r.version.Spec
+}
+
+func (r *batchSpecTemplateVersionResolver) Parameters() []graphqlbackend.BatchSpecTemplateParameterResolver {
+	resolvers := make([]graphqlbackend.BatchSpecTemplateParameterResolver, 0, len(r.version.Parameters))
+	for _, p := range r.version.Parameters {
+		resolvers = append(resolvers, &batchSpecTemplateParameterResolver{parameter: p})
+	}
+	return resolvers
+}
+
+func (r *batchSpecTemplateVersionResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DB(), r.version.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchSpecTemplateVersionResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.version.CreatedAt}
+}
+
+func (r *batchSpecTemplateVersionResolver) RenderSpec(args *struct{ Parameters *graphqlbackend.JSONValue }) (string, error) {
+	values, err := batchSpecTemplateParameterValues(args.Parameters)
+	if err != nil {
+		return "", err
+	}
+	return r.version.Render(values)
+}
+
+type batchSpecTemplateParameterResolver struct {
+	parameter btypes.BatchSpecTemplateParameter
+}
+
+var _ graphqlbackend.BatchSpecTemplateParameterResolver = &batchSpecTemplateParameterResolver{}
+
+func (r *batchSpecTemplateParameterResolver) Name() string {
+	return r.parameter.Name
+}
+
+func (r *batchSpecTemplateParameterResolver) Type() string {
+	switch r.parameter.Type {
+	case btypes.BatchSpecTemplateParameterTypeRepoQuery:
+		return "REPO_QUERY"
+	default:
+		return strings.ToUpper(string(r.parameter.Type))
+	}
+}
+
+func (r *batchSpecTemplateParameterResolver) Description() string {
+	return r.parameter.Description
+}
+
+func (r *batchSpecTemplateParameterResolver) Required() bool {
+	return r.parameter.Required
+}
+
+func (r *batchSpecTemplateParameterResolver) Default() *graphqlbackend.JSONValue {
+	if r.parameter.Default == nil {
+		return nil
+	}
+	return &graphqlbackend.JSONValue{Value: r.parameter.Default}
+}
+
+// batchSpecTemplateParametersFromInput converts the GraphQL input parameters
+// of a batch spec template.
+func batchSpecTemplateParametersFromInput(in []graphqlbackend.BatchSpecTemplateParameterInput) ([]btypes.BatchSpecTemplateParameter, error) {
+	params := make([]btypes.BatchSpecTemplateParameter, 0, len(in))
+	for _, p := range in {
+		param := btypes.BatchSpecTemplateParameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Required:    p.Required,
+		}
+		switch p.Type {
+		case "STRING":
+			param.Type = btypes.BatchSpecTemplateParameterTypeString
+		case "REPO_QUERY":
+			param.Type = btypes.BatchSpecTemplateParameterTypeRepoQuery
+		case "BOOLEAN":
+			param.Type = btypes.BatchSpecTemplateParameterTypeBoolean
+		default:
+			return nil, errors.Errorf("unknown parameter type %q", p.Type)
+		}
+		if p.Default != nil {
+			param.Default = p.Default.Value
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// batchSpecTemplateParameterValues converts the JSON object of parameter
+// values passed when rendering a batch spec template.
+func batchSpecTemplateParameterValues(v *graphqlbackend.JSONValue) (map[string]interface{}, error) {
+	if v == nil || v.Value == nil {
+		return map[string]interface{}{}, nil
+	}
+	values, ok := v.Value.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("parameters must be an object, got %T", v.Value)
+	}
+	return values, nil
+}
@@ -0,0 +1,71 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+type batchSpecTemplateConnectionResolver struct {
+	store *store.Store
+	opts  store.ListBatchSpecTemplatesOpts
+
+	// Cache results because they are used by multiple fields
+	once      sync.Once
+	templates []*btypes.BatchSpecTemplate
+	next      int64
+	err       error
+}
+
+var _ graphqlbackend.BatchSpecTemplateConnectionResolver = &batchSpecTemplateConnectionResolver{}
+
+func (r *batchSpecTemplateConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountBatchSpecTemplates(ctx, store.CountBatchSpecTemplatesOpts{
+		NamespaceUserID: r.opts.NamespaceUserID,
+		NamespaceOrgID:  r.opts.NamespaceOrgID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *batchSpecTemplateConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *batchSpecTemplateConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchSpecTemplateResolver, error) {
+	templates, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecTemplateResolver, 0, len(templates))
+	for _, t := range templates {
+		resolvers = append(resolvers, &batchSpecTemplateResolver{store: r.store, template: t})
+	}
+
+	return resolvers, nil
+}
+
+func (r *batchSpecTemplateConnectionResolver) compute(ctx context.Context) ([]*btypes.BatchSpecTemplate, int64, error) {
+	r.once.Do(func() {
+		r.templates, r.next, r.err = r.store.ListBatchSpecTemplates(ctx, r.opts)
+	})
+
+	return r.templates, r.next, r.err
+}
@@ -0,0 +1,204 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestBatchSpecTemplateResolver(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	user := ct.CreateTestUser(t, db, false)
+	userCtx := actor.WithActor(ctx, actor.FromUser(user.ID))
+	otherUser := ct.CreateTestUser(t, db, false)
+	otherCtx := actor.WithActor(ctx, actor.FromUser(otherUser.ID))
+
+	cstore := store.New(db, nil)
+
+	r := &Resolver{store: cstore}
+	s, err := graphqlbackend.NewSchema(db, r, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	namespaceID := string(graphqlbackend.MarshalUserID(user.ID))
+	spec := "name: upgrade-${{ params.package }}\non:\n  - repositoriesMatchingQuery: ${{ params.repos }}\n"
+
+	input := map[string]interface{}{
+		"namespace":   namespaceID,
+		"name":        "upgrade-dependency",
+		"description": "Upgrades a dependency",
+		"spec":        spec,
+		"parameters": []interface{}{
+			map[string]interface{}{"name": "package", "type": "STRING", "required": true},
+			map[string]interface{}{"name": "repos", "type": "REPO_QUERY", "default": "file:package.json"},
+		},
+	}
+	var createResponse struct{ CreateBatchSpecTemplate apitest.BatchSpecTemplate }
+	apitest.MustExec(userCtx, t, s, input, &createResponse, mutationCreateBatchSpecTemplate)
+
+	template := createResponse.CreateBatchSpecTemplate
+	wantVersion := apitest.BatchSpecTemplateVersion{
+		Version: 1,
+		Spec:    spec,
+		Parameters: []apitest.BatchSpecTemplateParameter{
+			{Name: "package", Type: "STRING", Required: true},
+			{Name: "repos", Type: "REPO_QUERY", Default: "file:package.json"},
+		},
+	}
+	want := apitest.BatchSpecTemplate{
+		ID:                  template.ID,
+		Name:                "upgrade-dependency",
+		Description:         "Upgrades a dependency",
+		Namespace:           apitest.UserOrg{ID: namespaceID},
+		Creator:             apitest.User{ID: namespaceID},
+		LatestVersion:       wantVersion,
+		Versions:            []apitest.BatchSpecTemplateVersion{wantVersion},
+		ViewerCanAdminister: true,
+	}
+	if diff := cmp.Diff(want, template); diff != "" {
+		t.Fatalf("unexpected template (-want +got):\n%s", diff)
+	}
+
+	t.Run("render", func(t *testing.T) {
+		input := map[string]interface{}{
+			"template":   template.ID,
+			"parameters": map[string]interface{}{"package": "lodash"},
+		}
+		var response struct{ Node apitest.BatchSpecTemplate }
+		apitest.MustExec(otherCtx, t, s, input, &response, queryBatchSpecTemplateRender)
+
+		if response.Node.ViewerCanAdminister {
+			t.Fatal("other user can administer template")
+		}
+		wantSpec := "name: upgrade-lodash\non:\n  - repositoriesMatchingQuery: file:package.json\n"
+		if diff := cmp.Diff(wantSpec, response.Node.LatestVersion.RenderSpec); diff != "" {
+			t.Fatalf("unexpected rendered spec (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		input := map[string]interface{}{
+			"template": template.ID,
+			"spec":     spec,
+			"parameters": []interface{}{
+				map[string]interface{}{"name": "package", "type": "STRING", "required": true},
+				map[string]interface{}{"name": "repos", "type": "REPO_QUERY", "required": true},
+			},
+		}
+
+		var response struct{ UpdateBatchSpecTemplate apitest.BatchSpecTemplate }
+		errs := apitest.Exec(otherCtx, t, s, input, &response, mutationUpdateBatchSpecTemplate)
+		if len(errs) == 0 {
+			t.Fatal("other user could update template")
+		}
+
+		apitest.MustExec(userCtx, t, s, input, &response, mutationUpdateBatchSpecTemplate)
+		if have, want := response.UpdateBatchSpecTemplate.LatestVersion.Version, int32(2); have != want {
+			t.Fatalf("wrong latest version. want=%d, have=%d", want, have)
+		}
+		if have, want := len(response.UpdateBatchSpecTemplate.Versions), 2; have != want {
+			t.Fatalf("wrong number of versions. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		input := map[string]interface{}{"namespace": namespaceID}
+		var response struct {
+			BatchSpecTemplates apitest.BatchSpecTemplateConnection
+		}
+		apitest.MustExec(otherCtx, t, s, input, &response, queryBatchSpecTemplates)
+
+		if have, want := response.BatchSpecTemplates.TotalCount, 1; have != want {
+			t.Fatalf("wrong total count. want=%d, have=%d", want, have)
+		}
+		if have, want := response.BatchSpecTemplates.Nodes[0].ID, template.ID; have != want {
+			t.Fatalf("wrong template. want=%s, have=%s", want, have)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		input := map[string]interface{}{"template": template.ID}
+		var response struct{}
+		apitest.MustExec(userCtx, t, s, input, &response, mutationDeleteBatchSpecTemplate)
+
+		count, err := cstore.CountBatchSpecTemplates(ctx, store.CountBatchSpecTemplatesOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Fatalf("template not deleted")
+		}
+	})
+}
+
+const fragmentBatchSpecTemplate = `
+fragment t on BatchSpecTemplate {
+	id
+	name
+	description
+	namespace { ... on User { id } }
+	creator { id }
+	latestVersion { ...v }
+	versions { ...v }
+	viewerCanAdminister
+}
+
+fragment v on BatchSpecTemplateVersion {
+	version
+	spec
+	parameters { name, type, description, required, default }
+}
+`
+
+const mutationCreateBatchSpecTemplate = fragmentBatchSpecTemplate + `
+mutation($namespace: ID!, $name: String!, $description: String, $spec: String!, $parameters: [BatchSpecTemplateParameterInput!]) {
+	createBatchSpecTemplate(namespace: $namespace, name: $name, description: $description, spec: $spec, parameters: $parameters) { ...t }
+}
+`
+
+const mutationUpdateBatchSpecTemplate = fragmentBatchSpecTemplate + `
+mutation($template: ID!, $spec: String!, $parameters: [BatchSpecTemplateParameterInput!]) {
+	updateBatchSpecTemplate(batchSpecTemplate: $template, spec: $spec, parameters: $parameters) { ...t }
+}
+`
+
+const mutationDeleteBatchSpecTemplate = `
+mutation($template: ID!) {
+	deleteBatchSpecTemplate(batchSpecTemplate: $template) { alwaysNil }
+}
+`
+
+const queryBatchSpecTemplateRender = `
+query($template: ID!, $parameters: JSONValue) {
+	node(id: $template) {
+		... on BatchSpecTemplate {
+			viewerCanAdminister
+			latestVersion { renderSpec(parameters: $parameters) }
+		}
+	}
+}
+`
+
+const queryBatchSpecTemplates = `
+query($namespace: ID) {
+	batchSpecTemplates(namespace: $namespace) {
+		totalCount
+		nodes { id }
+	}
+}
+`
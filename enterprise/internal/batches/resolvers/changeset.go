@@ -387,6 +387,8 @@ func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
 
+func (r *changesetResolver) NeedsReexecution() bool { return r.changeset.NeedsReexecution }
+
 func (r *changesetResolver) ScheduleEstimateAt(ctx context.Context) (*graphqlbackend.DateTime, error) {
 	// We need to find out how deep in the queue this changeset is.
 	place, err := r.store.GetChangesetPlaceInSchedulerQueue(ctx, r.changeset.ID)
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -23,6 +24,7 @@ type changesetApplyPreviewResolver struct {
 	preloadedNextSync    time.Time
 	preloadedBatchChange *btypes.BatchChange
 	batchSpecID          int64
+	rewirerMappings      *rewirerMappingsFacade
 }
 
 var _ graphqlbackend.ChangesetApplyPreviewResolver = &changesetApplyPreviewResolver{}
@@ -40,6 +42,7 @@ func (r *changesetApplyPreviewResolver) ToVisibleChangesetApplyPreview() (graphq
 			preloadedNextSync:    r.preloadedNextSync,
 			preloadedBatchChange: r.preloadedBatchChange,
 			batchSpecID:          r.batchSpecID,
+			rewirerMappings:      r.rewirerMappings,
 		}, true
 	}
 	return nil, false
@@ -135,6 +138,7 @@ type visibleChangesetApplyPreviewResolver struct {
 	preloadedNextSync    time.Time
 	preloadedBatchChange *btypes.BatchChange
 	batchSpecID          int64
+	rewirerMappings      *rewirerMappingsFacade
 
 	planOnce sync.Once
 	plan     *reconciler.Plan
@@ -179,6 +183,86 @@ func (r *visibleChangesetApplyPreviewResolver) Targets() graphqlbackend.VisibleA
 	}
 }
 
+func (r *visibleChangesetApplyPreviewResolver) Dependencies(ctx context.Context) ([]graphqlbackend.ChangesetApplyPreviewDependencyResolver, error) {
+	g, n, err := r.dependencyNode(ctx)
+	if err != nil || n == nil {
+		return []graphqlbackend.ChangesetApplyPreviewDependencyResolver{}, err
+	}
+
+	deps := g.Dependencies(n)
+	resolvers := make([]graphqlbackend.ChangesetApplyPreviewDependencyResolver, 0, len(deps))
+	for _, d := range deps {
+		resolvers = append(resolvers, &changesetApplyPreviewDependencyResolver{
+			store:      r.store,
+			dependency: d,
+			repos:      r.rewirerMappings.dependencyGraphRepos,
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *visibleChangesetApplyPreviewResolver) BlockedByDependencies(ctx context.Context) (bool, error) {
+	plan, err := r.computePlan(ctx)
+	if err != nil {
+		return false, err
+	}
+	return plan.BlockedByDependencies, nil
+}
+
+func (r *visibleChangesetApplyPreviewResolver) Conflicts(ctx context.Context) ([]graphqlbackend.ChangesetApplyPreviewConflictResolver, error) {
+	conflicts, err := r.changesetConflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetApplyPreviewConflictResolver, 0, len(conflicts))
+	for _, c := range conflicts {
+		resolvers = append(resolvers, &changesetApplyPreviewConflictResolver{
+			store:    r.store,
+			conflict: c,
+			repo:     r.mapping.Repo,
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *visibleChangesetApplyPreviewResolver) BlockedByConflicts(ctx context.Context) (bool, error) {
+	plan, err := r.computePlan(ctx)
+	if err != nil {
+		return false, err
+	}
+	return plan.BlockedByConflicts, nil
+}
+
+// changesetConflicts returns the open changesets of other batch changes that
+// modify the same files as the changeset spec.
+func (r *visibleChangesetApplyPreviewResolver) changesetConflicts(ctx context.Context) ([]*btypes.ChangesetConflict, error) {
+	if r.mapping.ChangesetSpec == nil || r.rewirerMappings == nil {
+		return nil, nil
+	}
+
+	conflicts, err := r.rewirerMappings.Conflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conflicts[r.mapping.ChangesetSpec.ID], nil
+}
+
+// dependencyNode returns the dependency graph of the batch spec and the node
+// of the changeset spec in it. The node is nil if the changeset spec doesn't
+// declare any dependencies.
+func (r *visibleChangesetApplyPreviewResolver) dependencyNode(ctx context.Context) (*btypes.ChangesetDependencyGraph, *btypes.ChangesetDependencyNode, error) {
+	if r.mapping.ChangesetSpec == nil || len(r.mapping.ChangesetSpec.Spec.DependsOn) == 0 || r.rewirerMappings == nil {
+		return nil, nil, nil
+	}
+
+	g, err := r.rewirerMappings.DependencyGraph(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, g.Node(r.mapping.ChangesetSpec.ID), nil
+}
+
 func (r *visibleChangesetApplyPreviewResolver) computePlan(ctx context.Context) (*reconciler.Plan, error) {
 	r.planOnce.Do(func() {
 		batchChange, err := r.computeBatchChange(ctx)
@@ -256,6 +340,38 @@ func (r *visibleChangesetApplyPreviewResolver) computePlan(ctx context.Context)
 			}
 		}
 		r.plan, r.planErr = reconciler.DeterminePlan(previousSpec, currentSpec, changeset)
+		if r.planErr != nil || currentSpec != r.mapping.ChangesetSpec {
+			return
+		}
+
+		// Changesets are held back by the reconciler until the changesets
+		// they depend on have been merged.
+		g, n, err := r.dependencyNode(ctx)
+		if err != nil {
+			r.planErr = err
+			return
+		}
+		if n != nil && g.Blocked(n) {
+			r.plan.HoldForDependencies()
+			return
+		}
+
+		// And, if the batch spec asks for it, until conflicting changesets of
+		// other batch changes have been merged or closed.
+		if r.rewirerMappings == nil || r.rewirerMappings.batchSpec == nil || !r.rewirerMappings.batchSpec.Spec.ChangesetTemplate.WaitForConflictingChangesets {
+			return
+		}
+		if !r.plan.Ops.Contains(btypes.ReconcilerOperationPublish) && !r.plan.Ops.Contains(btypes.ReconcilerOperationUndraft) {
+			return
+		}
+		conflicts, err := r.changesetConflicts(ctx)
+		if err != nil {
+			r.planErr = err
+			return
+		}
+		if len(conflicts) > 0 {
+			r.plan.HoldForConflicts()
+		}
 	})
 	return r.plan, r.planErr
 }
@@ -323,6 +439,70 @@ func (r *visibleApplyPreviewTargetsResolver) Changeset(ctx context.Context) (gra
 	return NewChangesetResolverWithNextSync(r.store, r.mapping.Changeset, r.mapping.Repo, r.preloadedNextSync), nil
 }
 
+type changesetApplyPreviewDependencyResolver struct {
+	store *store.Store
+
+	dependency btypes.ResolvedChangesetDependency
+	repos      map[api.RepoID]*types.Repo
+}
+
+var _ graphqlbackend.ChangesetApplyPreviewDependencyResolver = &changesetApplyPreviewDependencyResolver{}
+
+func (r *changesetApplyPreviewDependencyResolver) Repository() *string {
+	if r.dependency.Dependency.Repository == "" {
+		return nil
+	}
+	return &r.dependency.Dependency.Repository
+}
+
+func (r *changesetApplyPreviewDependencyResolver) Branch() *string {
+	if r.dependency.Dependency.Branch == "" {
+		return nil
+	}
+	return &r.dependency.Dependency.Branch
+}
+
+func (r *changesetApplyPreviewDependencyResolver) ChangesetSpecs() []graphqlbackend.VisibleChangesetSpecResolver {
+	resolvers := make([]graphqlbackend.VisibleChangesetSpecResolver, 0, len(r.dependency.Nodes))
+	for _, n := range r.dependency.Nodes {
+		resolvers = append(resolvers, NewChangesetSpecResolverWithRepo(r.store, r.repos[n.ChangesetSpec.RepoID], n.ChangesetSpec))
+	}
+	return resolvers
+}
+
+func (r *changesetApplyPreviewDependencyResolver) Merged() bool {
+	return r.dependency.Merged()
+}
+
+type changesetApplyPreviewConflictResolver struct {
+	store *store.Store
+
+	conflict *btypes.ChangesetConflict
+	repo     *types.Repo
+}
+
+var _ graphqlbackend.ChangesetApplyPreviewConflictResolver = &changesetApplyPreviewConflictResolver{}
+
+func (r *changesetApplyPreviewConflictResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return NewChangesetResolver(r.store, r.conflict.Changeset, r.repo)
+}
+
+func (r *changesetApplyPreviewConflictResolver) BatchChange(ctx context.Context) (graphqlbackend.BatchChangeResolver, error) {
+	batchChange, err := r.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: r.conflict.Changeset.OwnedByBatchChangeID})
+	if err != nil {
+		return nil, err
+	}
+	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
+}
+
+func (r *changesetApplyPreviewConflictResolver) Paths() []string {
+	return r.conflict.Paths
+}
+
+func (r *changesetApplyPreviewConflictResolver) HunksOverlap() bool {
+	return r.conflict.HunksOverlap
+}
+
 type changesetSpecDeltaResolver struct {
 	delta reconciler.ChangesetSpecDelta
 }
@@ -353,3 +533,6 @@ func (c *changesetSpecDeltaResolver) AuthorNameChanged() bool {
 func (c *changesetSpecDeltaResolver) AuthorEmailChanged() bool {
 	return c.delta.AuthorEmailChanged
 }
+func (c *changesetSpecDeltaResolver) MetadataChanged() bool {
+	return c.delta.MetadataChanged
+}
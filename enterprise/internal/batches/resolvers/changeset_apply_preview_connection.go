@@ -10,11 +10,14 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/syncer"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 var _ graphqlbackend.ChangesetApplyPreviewConnectionResolver = &changesetApplyPreviewConnectionResolver{}
@@ -249,9 +252,26 @@ type rewirerMappingsFacade struct {
 	batchSpecID int64
 	store       *store.Store
 
-	// This field is set when ReconcileBatchChange is called.
+	// These fields are set when ReconcileBatchChange is called.
+	batchSpec   *btypes.BatchSpec
 	batchChange *btypes.BatchChange
 
+	// Whether All has been filtered by the options given to compute.
+	filtered bool
+
+	// The dependency graph of the changeset specs in the batch spec, computed
+	// on demand.
+	dependencyGraphOnce  sync.Once
+	dependencyGraph      *btypes.ChangesetDependencyGraph
+	dependencyGraphRepos map[api.RepoID]*types.Repo
+	dependencyGraphErr   error
+
+	// The open changesets of other batch changes that conflict with the
+	// changeset specs in All, keyed by changeset spec ID, computed on demand.
+	conflictsOnce sync.Once
+	conflicts     map[int64][]*btypes.ChangesetConflict
+	conflictsErr  error
+
 	// Cache of filtered pages.
 	pagesMu sync.Mutex
 	pages   map[rewirerMappingPageOpts]*rewirerMappingPage
@@ -278,6 +298,7 @@ func (rmf *rewirerMappingsFacade) compute(ctx context.Context, opts store.GetRew
 	if err != nil {
 		return err
 	}
+	rmf.batchSpec = batchSpec
 	// Dry-run reconcile the batch change with the new batch spec.
 	if rmf.batchChange, _, err = svc.ReconcileBatchChange(ctx, batchSpec); err != nil {
 		return err
@@ -289,10 +310,62 @@ func (rmf *rewirerMappingsFacade) compute(ctx context.Context, opts store.GetRew
 		TextSearch:    opts.TextSearch,
 		CurrentState:  opts.CurrentState,
 	}
+	rmf.filtered = len(opts.TextSearch) > 0 || opts.CurrentState != nil
 	rmf.All, err = rmf.store.GetRewirerMappings(ctx, opts)
 	return err
 }
 
+// DependencyGraph returns the dependency graph of the changeset specs in the
+// batch spec, based on the current state of the changesets they would be
+// applied to.
+func (rmf *rewirerMappingsFacade) DependencyGraph(ctx context.Context) (*btypes.ChangesetDependencyGraph, error) {
+	rmf.dependencyGraphOnce.Do(func() {
+		mappings := rmf.All
+		if rmf.filtered {
+			// Dependencies can reference changesets that have been filtered
+			// out, so we need all of them.
+			mappings, rmf.dependencyGraphErr = rmf.store.GetRewirerMappings(ctx, store.GetRewirerMappingsOpts{
+				BatchSpecID:   rmf.batchSpecID,
+				BatchChangeID: rmf.batchChange.ID,
+			})
+			if rmf.dependencyGraphErr != nil {
+				return
+			}
+		}
+
+		rmf.dependencyGraph = &btypes.ChangesetDependencyGraph{}
+		rmf.dependencyGraphRepos = make(map[api.RepoID]*types.Repo)
+		for _, mapping := range mappings {
+			if mapping.ChangesetSpec == nil || mapping.Repo == nil {
+				continue
+			}
+			rmf.dependencyGraphRepos[mapping.Repo.ID] = mapping.Repo
+			rmf.dependencyGraph.Nodes = append(rmf.dependencyGraph.Nodes, &btypes.ChangesetDependencyNode{
+				ChangesetSpec: mapping.ChangesetSpec,
+				Changeset:     mapping.Changeset,
+				RepoName:      mapping.Repo.Name,
+			})
+		}
+	})
+	return rmf.dependencyGraph, rmf.dependencyGraphErr
+}
+
+// Conflicts returns the open changesets of other batch changes that modify
+// the same files as the changeset specs of the mappings, keyed by changeset
+// spec ID.
+func (rmf *rewirerMappingsFacade) Conflicts(ctx context.Context) (map[int64][]*btypes.ChangesetConflict, error) {
+	rmf.conflictsOnce.Do(func() {
+		specs := make([]*btypes.ChangesetSpec, 0, len(rmf.All))
+		for _, mapping := range rmf.All {
+			if mapping.ChangesetSpec != nil {
+				specs = append(specs, mapping.ChangesetSpec)
+			}
+		}
+		rmf.conflicts, rmf.conflictsErr = reconciler.FindChangesetConflicts(ctx, rmf.store, rmf.batchChange.ID, specs)
+	})
+	return rmf.conflicts, rmf.conflictsErr
+}
+
 type rewirerMappingPageOpts struct {
 	*database.LimitOffset
 	Op *btypes.ReconcilerOperation
@@ -381,6 +454,7 @@ func (rmf *rewirerMappingsFacade) Resolver(mapping *btypes.RewirerMapping) graph
 		mapping:              mapping,
 		preloadedBatchChange: rmf.batchChange,
 		batchSpecID:          rmf.batchSpecID,
+		rewirerMappings:      rmf,
 	}
 	return rmf.resolvers[mapping]
 }
@@ -471,5 +471,17 @@ func (r *mockVisibleChangesetApplyPreviewResolver) Delta(context.Context) (graph
 func (r *mockVisibleChangesetApplyPreviewResolver) Targets() graphqlbackend.VisibleApplyPreviewTargetsResolver {
 	return r.targets
 }
+func (r *mockVisibleChangesetApplyPreviewResolver) Dependencies(context.Context) ([]graphqlbackend.ChangesetApplyPreviewDependencyResolver, error) {
+	return []graphqlbackend.ChangesetApplyPreviewDependencyResolver{}, nil
+}
+func (r *mockVisibleChangesetApplyPreviewResolver) BlockedByDependencies(context.Context) (bool, error) {
+	return false, nil
+}
+func (r *mockVisibleChangesetApplyPreviewResolver) Conflicts(context.Context) ([]graphqlbackend.ChangesetApplyPreviewConflictResolver, error) {
+	return []graphqlbackend.ChangesetApplyPreviewConflictResolver{}, nil
+}
+func (r *mockVisibleChangesetApplyPreviewResolver) BlockedByConflicts(context.Context) (bool, error) {
+	return false, nil
+}
 
 var _ graphqlbackend.VisibleChangesetApplyPreviewResolver = &mockVisibleChangesetApplyPreviewResolver{}
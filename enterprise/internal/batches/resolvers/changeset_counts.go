@@ -20,3 +20,6 @@ func (r *changesetCountsResolver) Open() int32                 { return r.counts
 func (r *changesetCountsResolver) OpenApproved() int32         { return r.counts.OpenApproved }
 func (r *changesetCountsResolver) OpenChangesRequested() int32 { return r.counts.OpenChangesRequested }
 func (r *changesetCountsResolver) OpenPending() int32          { return r.counts.OpenPending }
+func (r *changesetCountsResolver) OpenChecksPending() int32    { return r.counts.OpenChecksPending }
+func (r *changesetCountsResolver) OpenChecksPassed() int32     { return r.counts.OpenChecksPassed }
+func (r *changesetCountsResolver) OpenChecksFailed() int32     { return r.counts.OpenChecksFailed }
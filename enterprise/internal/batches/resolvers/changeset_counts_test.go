@@ -40,6 +40,9 @@ func TestChangesetCountsOverTimeResolver(t *testing.T) {
 		OpenApproved:         6,
 		OpenChangesRequested: 5,
 		OpenPending:          4,
+		OpenChecksPending:    3,
+		OpenChecksPassed:     2,
+		OpenChecksFailed:     1,
 	}
 
 	resolver := changesetCountsResolver{counts: counts}
@@ -56,6 +59,9 @@ func TestChangesetCountsOverTimeResolver(t *testing.T) {
 		{name: "OpenApproved", method: resolver.OpenApproved, want: counts.OpenApproved},
 		{name: "OpenChangesRequested", method: resolver.OpenChangesRequested, want: counts.OpenChangesRequested},
 		{name: "OpenPending", method: resolver.OpenPending, want: counts.OpenPending},
+		{name: "OpenChecksPending", method: resolver.OpenChecksPending, want: counts.OpenChecksPending},
+		{name: "OpenChecksPassed", method: resolver.OpenChecksPassed, want: counts.OpenChecksPassed},
+		{name: "OpenChecksFailed", method: resolver.OpenChecksFailed, want: counts.OpenChecksFailed},
 	}
 
 	for _, tc := range tests {
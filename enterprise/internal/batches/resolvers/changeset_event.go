@@ -1,6 +1,8 @@
 package resolvers
 
 import (
+	"strings"
+
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 
@@ -32,3 +34,44 @@ func (r *changesetEventResolver) CreatedAt() graphqlbackend.DateTime {
 func (r *changesetEventResolver) Changeset() graphqlbackend.ExternalChangesetResolver {
 	return r.changesetResolver
 }
+
+func (r *changesetEventResolver) AutoMergeDecision() graphqlbackend.ChangesetAutoMergeDecisionResolver {
+	decision, ok := r.ChangesetEvent.Metadata.(*btypes.ChangesetAutoMergeDecision)
+	if !ok {
+		return nil
+	}
+	return &changesetAutoMergeDecisionResolver{decision: decision}
+}
+
+type changesetAutoMergeDecisionResolver struct {
+	decision *btypes.ChangesetAutoMergeDecision
+}
+
+var _ graphqlbackend.ChangesetAutoMergeDecisionResolver = &changesetAutoMergeDecisionResolver{}
+
+func (r *changesetAutoMergeDecisionResolver) Merged() bool {
+	return r.decision.Merged
+}
+
+func (r *changesetAutoMergeDecisionResolver) Strategy() string {
+	return strings.ToUpper(string(r.decision.Strategy))
+}
+
+func (r *changesetAutoMergeDecisionResolver) SkipReason() *string {
+	if r.decision.Merged {
+		return nil
+	}
+	reason := strings.ToUpper(string(r.decision.SkipReason))
+	return &reason
+}
+
+func (r *changesetAutoMergeDecisionResolver) Message() *string {
+	if r.decision.Message == "" {
+		return nil
+	}
+	return &r.decision.Message
+}
+
+func (r *changesetAutoMergeDecisionResolver) DecidedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.decision.DecidedAt}
+}
@@ -158,6 +158,38 @@ func (r *changesetDescriptionResolver) Published() *batches.PublishedValue {
 	return nil
 }
 
+func (r *changesetDescriptionResolver) Reviewers() []string {
+	return nonNilStrings(r.desc.Reviewers)
+}
+
+func (r *changesetDescriptionResolver) TeamReviewers() []string {
+	return nonNilStrings(r.desc.TeamReviewers)
+}
+
+func (r *changesetDescriptionResolver) Labels() []string {
+	return nonNilStrings(r.desc.Labels)
+}
+
+func (r *changesetDescriptionResolver) Assignees() []string {
+	return nonNilStrings(r.desc.Assignees)
+}
+
+func (r *changesetDescriptionResolver) Milestone() *string {
+	if r.desc.Milestone == "" {
+		return nil
+	}
+	return &r.desc.Milestone
+}
+
+// nonNilStrings returns an empty slice instead of nil, for non-null GraphQL
+// lists.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
 func (r *changesetDescriptionResolver) DiffStat() *graphqlbackend.DiffStat {
 	return graphqlbackend.NewDiffStat(r.diffStat)
 }
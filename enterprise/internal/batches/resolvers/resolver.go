@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
@@ -130,6 +131,9 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		batchSpecExecutionIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecExecutionByID(ctx, id)
 		},
+		batchSpecTemplateIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.batchSpecTemplateByID(ctx, id)
+		},
 	}
 }
 
@@ -371,6 +375,30 @@ func (r *Resolver) batchSpecExecutionByID(ctx context.Context, id graphql.ID) (g
 	return &batchSpecExecutionResolver{store: r.store, exec: spec}, nil
 }
 
+func (r *Resolver) batchSpecTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.BatchSpecTemplateResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, nil
+	}
+
+	template, err := r.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: templateID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchSpecTemplateResolver{store: r.store, template: template}, nil
+}
+
 func (r *Resolver) CreateBatchChange(ctx context.Context, args *graphqlbackend.CreateBatchChangeArgs) (graphqlbackend.BatchChangeResolver, error) {
 	var err error
 	tr, _ := trace.New(ctx, "Resolver.CreateBatchChange", fmt.Sprintf("BatchSpec %s", args.BatchSpec))
@@ -617,6 +645,81 @@ func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.Mov
 	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
 }
 
+func (r *Resolver) SetBatchChangeAutoMergeStrategy(ctx context.Context, args *graphqlbackend.SetBatchChangeAutoMergeStrategyArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeAutoMergeStrategy", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling batch change id")
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var strategy btypes.BatchChangeAutoMergeStrategy
+	if args.Strategy != nil {
+		strategy = btypes.BatchChangeAutoMergeStrategy(strings.ToLower(*args.Strategy))
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeAutoMergeStrategy checks whether the current user is authorized.
+	batchChange, err := svc.SetBatchChangeAutoMergeStrategy(ctx, batchChangeID, strategy)
+	if err != nil {
+		return nil, errors.Wrap(err, "setting auto-merge strategy")
+	}
+
+	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
+}
+
+func (r *Resolver) SetBatchChangeContinuousExecution(ctx context.Context, args *graphqlbackend.SetBatchChangeContinuousExecutionArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeContinuousExecution", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling batch change id")
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var intervalHours int32
+	if args.IntervalHours != nil {
+		intervalHours = *args.IntervalHours
+	}
+
+	var policy btypes.BatchChangeContinuousApplyPolicy
+	if args.ApplyPolicy != nil {
+		policy = btypes.BatchChangeContinuousApplyPolicy(strings.ToLower(*args.ApplyPolicy))
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeContinuousExecution checks whether the current user is authorized.
+	batchChange, err := svc.SetBatchChangeContinuousExecution(ctx, batchChangeID, intervalHours, policy)
+	if err != nil {
+		return nil, errors.Wrap(err, "setting continuous execution")
+	}
+
+	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
+}
+
 func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.DeleteBatchChangeArgs) (_ *graphqlbackend.EmptyResponse, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChange", fmt.Sprintf("BatchChange: %q", args.BatchChange))
 	defer func() {
@@ -1022,14 +1125,22 @@ func (r *Resolver) CreateBatchChangesCredential(ctx context.Context, args *graph
 		return nil, errors.New("empty credential not allowed")
 	}
 
+	var username string
+	if args.Username != nil {
+		username = *args.Username
+	}
+	if kind == extsvc.KindBitbucketCloud && username == "" {
+		return nil, errors.New("username required for Bitbucket Cloud credentials")
+	}
+
 	if userID != 0 {
-		return r.createBatchChangesUserCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), userID, args.Credential)
+		return r.createBatchChangesUserCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), userID, username, args.Credential)
 	}
 
-	return r.createBatchChangesSiteCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), args.Credential)
+	return r.createBatchChangesSiteCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), username, args.Credential)
 }
 
-func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externalServiceURL, externalServiceType string, userID int32, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
+func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externalServiceURL, externalServiceType string, userID int32, username, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
 	// 🚨 SECURITY: Check that the requesting user can create the credential.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.store.DB(), userID); err != nil {
 		return nil, err
@@ -1050,7 +1161,7 @@ func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externa
 		return nil, ErrDuplicateCredential{}
 	}
 
-	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, credential)
+	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, username, credential)
 	if err != nil {
 		return nil, err
 	}
@@ -1062,7 +1173,7 @@ func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externa
 	return &batchChangesUserCredentialResolver{credential: cred}, nil
 }
 
-func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externalServiceURL, externalServiceType string, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
+func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externalServiceURL, externalServiceType, username, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
 	// 🚨 SECURITY: Check that a site credential can only be created
 	// by a site-admin.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DB()); err != nil {
@@ -1081,7 +1192,7 @@ func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externa
 		return nil, ErrDuplicateCredential{}
 	}
 
-	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, credential)
+	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, username, credential)
 	if err != nil {
 		return nil, err
 	}
@@ -1096,7 +1207,7 @@ func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externa
 	return &batchChangesSiteCredentialResolver{credential: cred}, nil
 }
 
-func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, externalServiceType, externalServiceURL, credential string) (auth.Authenticator, error) {
+func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, externalServiceType, externalServiceURL, username, credential string) (auth.Authenticator, error) {
 	svc := service.New(r.store)
 
 	var a auth.Authenticator
@@ -1119,6 +1230,15 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
+	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+		// App passwords can only be used together with the username of the
+		// account they belong to.
+		a = &auth.BasicAuthWithSSH{
+			BasicAuth:  auth.BasicAuth{Username: username, Password: credential},
+			PrivateKey: keypair.PrivateKey,
+			PublicKey:  keypair.PublicKey,
+			Passphrase: keypair.Passphrase,
+		}
 	} else {
 		a = &auth.OAuthBearerTokenWithSSH{
 			OAuthBearerToken: auth.OAuthBearerToken{Token: credential},
@@ -1433,15 +1553,21 @@ func (r *Resolver) CreateBatchSpecExecution(ctx context.Context, args *graphqlba
 		return nil, err
 	}
 
+	return r.createBatchSpecExecution(ctx, args.Spec, args.Namespace)
+}
+
+// createBatchSpecExecution creates a BatchSpecExecution for the given batch
+// spec in the given namespace or, if nil, the current user's namespace.
+func (r *Resolver) createBatchSpecExecution(ctx context.Context, spec string, namespace *graphql.ID) (graphqlbackend.BatchSpecExecutionResolver, error) {
 	actor := actor.FromContext(ctx)
 
 	exec := &btypes.BatchSpecExecution{
-		BatchSpec: args.Spec,
+		BatchSpec: spec,
 		UserID:    actor.UID,
 	}
 
-	if args.Namespace != nil {
-		err = graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &exec.NamespaceUserID, &exec.NamespaceOrgID)
+	if namespace != nil {
+		err := graphqlbackend.UnmarshalNamespaceID(*namespace, &exec.NamespaceUserID, &exec.NamespaceOrgID)
 		if err != nil {
 			return nil, err
 		}
@@ -1462,6 +1588,203 @@ func (r *Resolver) CreateBatchSpecExecution(ctx context.Context, args *graphqlba
 	return r.batchSpecExecutionByID(ctx, marshalBatchSpecExecutionRandID(exec.RandID))
 }
 
+func (r *Resolver) CreateBatchSpecTemplate(ctx context.Context, args *graphqlbackend.CreateBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecTemplate", fmt.Sprintf("Namespace %s, Name %q", args.Namespace, args.Name))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	opts := service.CreateBatchSpecTemplateOpts{
+		Name:        args.Name,
+		Description: args.Description,
+		Spec:        args.Spec,
+	}
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+	if opts.Parameters, err = batchSpecTemplateParametersFromInput(args.Parameters); err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: CreateBatchSpecTemplate checks whether the current user
+	// has access to the namespace.
+	template, err := svc.CreateBatchSpecTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) UpdateBatchSpecTemplate(ctx context.Context, args *graphqlbackend.UpdateBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.UpdateBatchSpecTemplate", fmt.Sprintf("BatchSpecTemplate %s", args.BatchSpecTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	opts := service.UpdateBatchSpecTemplateOpts{
+		ID:          templateID,
+		Description: args.Description,
+		Spec:        args.Spec,
+	}
+	if opts.Parameters, err = batchSpecTemplateParametersFromInput(args.Parameters); err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: UpdateBatchSpecTemplate checks whether the current user
+	// has access to the namespace of the template.
+	template, err := svc.UpdateBatchSpecTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) DeleteBatchSpecTemplate(ctx context.Context, args *graphqlbackend.DeleteBatchSpecTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchSpecTemplate", fmt.Sprintf("BatchSpecTemplate %s", args.BatchSpecTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: DeleteBatchSpecTemplate checks whether the current user
+	// has access to the namespace of the template.
+	if err := svc.DeleteBatchSpecTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) ExecuteBatchSpecTemplate(ctx context.Context, args *graphqlbackend.ExecuteBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecExecutionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ExecuteBatchSpecTemplate", fmt.Sprintf("BatchSpecTemplate %s", args.BatchSpecTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check that the requesting user is admin, just like for
+	// createBatchSpecExecution.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var version int32
+	if args.Version != nil {
+		version = *args.Version
+	}
+
+	values, err := batchSpecTemplateParameterValues(args.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	spec, err := svc.RenderBatchSpecTemplate(ctx, templateID, version, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.createBatchSpecExecution(ctx, spec, args.Namespace)
+}
+
+func (r *Resolver) BatchSpecTemplates(ctx context.Context, args *graphqlbackend.ListBatchSpecTemplatesArgs) (graphqlbackend.BatchSpecTemplateConnectionResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts := store.ListBatchSpecTemplatesOpts{LimitOpts: store.LimitOpts{Limit: int(args.First)}}
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
+
+	if args.Namespace != nil {
+		err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &batchSpecTemplateConnectionResolver{store: r.store, opts: opts}, nil
+}
+
+func (r *Resolver) BatchChangesReport(ctx context.Context, args *graphqlbackend.BatchChangesReportArgs) (graphqlbackend.BatchChangesReportResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	publishedState := btypes.ChangesetPublicationStatePublished
+	return &batchChangesReportResolver{
+		store: r.store,
+		opts: store.ListChangesetsOpts{
+			PublicationState: &publishedState,
+			// 🚨 SECURITY: Only report on changesets in repositories the
+			// user has access to.
+			EnforceAuthz: true,
+		},
+		from:           args.From,
+		to:             args.To,
+		staleAfterDays: args.StaleAfterDays,
+		mergeSLADays:   args.MergeSLADays,
+	}, nil
+}
+
 func parseBatchChangeState(s *string) (btypes.BatchChangeState, error) {
 	if s == nil {
 		return btypes.BatchChangeStateAny, nil
@@ -54,6 +54,7 @@ func TestNullIDResilience(t *testing.T) {
 		marshalBatchChangesCredentialID(0, false),
 		marshalBatchChangesCredentialID(0, true),
 		marshalBulkOperationID(""),
+		marshalBatchSpecTemplateID(0),
 	}
 
 	for _, id := range ids {
@@ -90,6 +91,8 @@ func TestNullIDResilience(t *testing.T) {
 		fmt.Sprintf(`mutation { closeChangesets(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
 		fmt.Sprintf(`mutation { publishChangesets(batchChange: %q, changesets: []) { id } }`, marshalBatchChangeID(0)),
 		fmt.Sprintf(`mutation { publishChangesets(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
+		fmt.Sprintf(`mutation { updateBatchSpecTemplate(batchSpecTemplate: %q, spec: "name: foo") { id } }`, marshalBatchSpecTemplateID(0)),
+		fmt.Sprintf(`mutation { deleteBatchSpecTemplate(batchSpecTemplate: %q) { alwaysNil } }`, marshalBatchSpecTemplateID(0)),
 	}
 
 	for _, m := range mutations {
@@ -876,6 +879,165 @@ mutation($batchChange: ID!, $newName: String, $newNamespace: ID){
 }
 `
 
+func TestSetBatchChangeAutoMergeStrategy(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	userID := ct.CreateTestUser(t, db, true).ID
+
+	cstore := store.New(db, nil)
+
+	batchSpec := &btypes.BatchSpec{
+		RawSpec:         ct.TestRawBatchSpec,
+		UserID:          userID,
+		NamespaceUserID: userID,
+	}
+	if err := cstore.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	batchChange := &btypes.BatchChange{
+		BatchSpecID:      batchSpec.ID,
+		Name:             "auto-merge",
+		InitialApplierID: userID,
+		LastApplierID:    userID,
+		LastAppliedAt:    time.Now(),
+		NamespaceUserID:  batchSpec.UserID,
+	}
+	if err := cstore.CreateBatchChange(ctx, batchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Resolver{store: cstore}
+	s, err := graphqlbackend.NewSchema(db, r, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actorCtx := actor.WithActor(ctx, actor.FromUser(userID))
+	batchChangeAPIID := string(marshalBatchChangeID(batchChange.ID))
+
+	for _, strategy := range []interface{}{"SQUASH", "MERGE", nil} {
+		input := map[string]interface{}{
+			"batchChange": batchChangeAPIID,
+			"strategy":    strategy,
+		}
+
+		var response struct{ SetBatchChangeAutoMergeStrategy apitest.BatchChange }
+		apitest.MustExec(actorCtx, t, s, input, &response, mutationSetBatchChangeAutoMergeStrategy)
+
+		var want *string
+		if strategy != nil {
+			s := strategy.(string)
+			want = &s
+		}
+		if diff := cmp.Diff(want, response.SetBatchChangeAutoMergeStrategy.AutoMergeStrategy); diff != "" {
+			t.Fatalf("unexpected strategy (-want +got):\n%s", diff)
+		}
+	}
+}
+
+const mutationSetBatchChangeAutoMergeStrategy = `
+mutation($batchChange: ID!, $strategy: BatchChangeAutoMergeStrategy){
+  setBatchChangeAutoMergeStrategy(batchChange: $batchChange, strategy: $strategy) {
+	id
+	autoMergeStrategy
+  }
+}
+`
+
+func TestSetBatchChangeContinuousExecution(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	userID := ct.CreateTestUser(t, db, true).ID
+
+	cstore := store.New(db, nil)
+
+	batchSpec := &btypes.BatchSpec{
+		RawSpec:         ct.TestRawBatchSpec,
+		UserID:          userID,
+		NamespaceUserID: userID,
+	}
+	if err := cstore.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	batchChange := &btypes.BatchChange{
+		BatchSpecID:      batchSpec.ID,
+		Name:             "continuous",
+		InitialApplierID: userID,
+		LastApplierID:    userID,
+		LastAppliedAt:    time.Now(),
+		NamespaceUserID:  batchSpec.UserID,
+	}
+	if err := cstore.CreateBatchChange(ctx, batchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Resolver{store: cstore}
+	s, err := graphqlbackend.NewSchema(db, r, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actorCtx := actor.WithActor(ctx, actor.FromUser(userID))
+	batchChangeAPIID := string(marshalBatchChangeID(batchChange.ID))
+
+	for _, tc := range []struct {
+		intervalHours interface{}
+		applyPolicy   interface{}
+	}{
+		{intervalHours: 24, applyPolicy: "CREATE"},
+		{intervalHours: 168, applyPolicy: "CREATE_AND_UPDATE"},
+		{intervalHours: nil, applyPolicy: nil},
+	} {
+		input := map[string]interface{}{
+			"batchChange":   batchChangeAPIID,
+			"intervalHours": tc.intervalHours,
+			"applyPolicy":   tc.applyPolicy,
+		}
+
+		var response struct{ SetBatchChangeContinuousExecution apitest.BatchChange }
+		apitest.MustExec(actorCtx, t, s, input, &response, mutationSetBatchChangeContinuousExecution)
+
+		var (
+			wantHours  *int32
+			wantPolicy *string
+		)
+		if tc.intervalHours != nil {
+			h := int32(tc.intervalHours.(int))
+			p := tc.applyPolicy.(string)
+			wantHours, wantPolicy = &h, &p
+		}
+		have := response.SetBatchChangeContinuousExecution
+		if diff := cmp.Diff(wantHours, have.ContinuousIntervalHours); diff != "" {
+			t.Fatalf("unexpected interval (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(wantPolicy, have.ContinuousApplyPolicy); diff != "" {
+			t.Fatalf("unexpected apply policy (-want +got):\n%s", diff)
+		}
+	}
+}
+
+const mutationSetBatchChangeContinuousExecution = `
+mutation($batchChange: ID!, $intervalHours: Int, $applyPolicy: BatchChangeContinuousApplyPolicy){
+  setBatchChangeContinuousExecution(batchChange: $batchChange, intervalHours: $intervalHours, applyPolicy: $applyPolicy) {
+	id
+	continuousIntervalHours
+	continuousApplyPolicy
+  }
+}
+`
+
 func TestListChangesetOptsFromArgs(t *testing.T) {
 	var wantFirst int32 = 10
 	wantPublicationStates := []btypes.ChangesetPublicationState{
@@ -115,6 +115,19 @@ func (s *Service) CreateBatchSpec(ctx context.Context, opts CreateBatchSpecOpts)
 		}
 	}
 
+	// Changesets that depend on each other in a cycle would never be
+	// published, so we reject the batch spec right away.
+	g := &btypes.ChangesetDependencyGraph{}
+	for _, changesetSpec := range cs {
+		g.Nodes = append(g.Nodes, &btypes.ChangesetDependencyNode{
+			ChangesetSpec: changesetSpec,
+			RepoName:      accessibleReposByID[changesetSpec.RepoID].Name,
+		})
+	}
+	if cycle := g.Cycle(); cycle != nil {
+		return nil, &btypes.ChangesetDependencyCycleError{Cycle: cycle}
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -287,6 +300,93 @@ func (s *Service) MoveBatchChange(ctx context.Context, opts MoveBatchChangeOpts)
 	return batchChange, tx.UpdateBatchChange(ctx, batchChange)
 }
 
+// SetBatchChangeAutoMergeStrategy sets the strategy used to automatically
+// merge the changesets of the batch change. An empty strategy disables
+// auto-merge.
+func (s *Service) SetBatchChangeAutoMergeStrategy(ctx context.Context, id int64, strategy btypes.BatchChangeAutoMergeStrategy) (batchChange *btypes.BatchChange, err error) {
+	traceTitle := fmt.Sprintf("batchChange: %d, strategy: %q", id, strategy)
+	tr, ctx := trace.New(ctx, "service.SetBatchChangeAutoMergeStrategy", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if strategy != "" && !strategy.Valid() {
+		return nil, errors.Errorf("invalid auto-merge strategy %q", strategy)
+	}
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can change its
+	// auto-merge strategy, since changesets are merged on behalf of the last
+	// applier.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.InitialApplierID); err != nil {
+		return nil, err
+	}
+
+	if strategy != "" && batchChange.Closed() {
+		return nil, errors.New("cannot enable auto-merge on a closed batch change")
+	}
+
+	batchChange.AutoMergeStrategy = strategy
+	return batchChange, s.store.UpdateBatchChange(ctx, batchChange)
+}
+
+// SetBatchChangeContinuousExecution configures the batch change to execute
+// its current batch spec again every intervalHours hours and to apply the
+// results according to policy. An intervalHours of zero disables continuous
+// execution.
+func (s *Service) SetBatchChangeContinuousExecution(ctx context.Context, id int64, intervalHours int32, policy btypes.BatchChangeContinuousApplyPolicy) (batchChange *btypes.BatchChange, err error) {
+	traceTitle := fmt.Sprintf("batchChange: %d, intervalHours: %d, policy: %q", id, intervalHours, policy)
+	tr, ctx := trace.New(ctx, "service.SetBatchChangeContinuousExecution", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if intervalHours < 0 {
+		return nil, errors.New("interval must not be negative")
+	}
+	if intervalHours > 0 && !policy.Valid() {
+		return nil, errors.Errorf("invalid continuous apply policy %q", policy)
+	}
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	if intervalHours > 0 {
+		// 🚨 SECURITY: Continuous runs execute the batch spec server-side,
+		// which only site admins can do, and apply the results on behalf of
+		// the last applier.
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx, s.store.DB()); err != nil {
+			return nil, err
+		}
+
+		if batchChange.Closed() {
+			return nil, errors.New("cannot enable continuous execution on a closed batch change")
+		}
+
+		batchChange.ContinuousIntervalHours = intervalHours
+		batchChange.ContinuousApplyPolicy = policy
+	} else {
+		// 🚨 SECURITY: Only the author of the batch change or a site admin
+		// can disable continuous execution.
+		if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.InitialApplierID); err != nil {
+			return nil, err
+		}
+
+		batchChange.ContinuousIntervalHours = 0
+		batchChange.ContinuousApplyPolicy = ""
+	}
+
+	return batchChange, s.store.UpdateBatchChange(ctx, batchChange)
+}
+
 // CloseBatchChange closes the BatchChange with the given ID if it has not been closed yet.
 func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangesets bool) (batchChange *btypes.BatchChange, err error) {
 	traceTitle := fmt.Sprintf("batchChange: %d, closeChangesets: %t", id, closeChangesets)
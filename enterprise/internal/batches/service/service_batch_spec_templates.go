@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+type CreateBatchSpecTemplateOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	Name        string
+	Description string
+
+	Spec       string
+	Parameters []btypes.BatchSpecTemplateParameter
+}
+
+// CreateBatchSpecTemplate creates a BatchSpecTemplate in the given namespace,
+// together with its first version.
+func (s *Service) CreateBatchSpecTemplate(ctx context.Context, opts CreateBatchSpecTemplateOpts) (template *btypes.BatchSpecTemplate, err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.CreateBatchSpecTemplate", fmt.Sprintf("Actor %s", actor))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if strings.TrimSpace(opts.Name) == "" {
+		return nil, errors.New("batch spec template name cannot be blank")
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace can publish
+	// templates in it.
+	if err := s.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	version := &btypes.BatchSpecTemplateVersion{
+		Spec:       opts.Spec,
+		Parameters: opts.Parameters,
+		CreatorID:  actor.UID,
+	}
+	if err := version.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	template = &btypes.BatchSpecTemplate{
+		Name:            opts.Name,
+		Description:     opts.Description,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+		CreatorID:       actor.UID,
+	}
+	if err := tx.CreateBatchSpecTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	version.TemplateID = template.ID
+	if err := tx.CreateBatchSpecTemplateVersion(ctx, version); err != nil {
+		return nil, err
+	}
+
+	return tx.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: template.ID})
+}
+
+type UpdateBatchSpecTemplateOpts struct {
+	ID int64
+
+	// Description replaces the description of the template if not nil.
+	Description *string
+
+	Spec       string
+	Parameters []btypes.BatchSpecTemplateParameter
+}
+
+// UpdateBatchSpecTemplate publishes a new version of the BatchSpecTemplate.
+// Earlier versions are kept, so that they can still be applied.
+func (s *Service) UpdateBatchSpecTemplate(ctx context.Context, opts UpdateBatchSpecTemplateOpts) (template *btypes.BatchSpecTemplate, err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.UpdateBatchSpecTemplate", fmt.Sprintf("Actor %s, template %d", actor, opts.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	version := &btypes.BatchSpecTemplateVersion{
+		TemplateID: opts.ID,
+		Spec:       opts.Spec,
+		Parameters: opts.Parameters,
+		CreatorID:  actor.UID,
+	}
+	if err := version.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	template, err = tx.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: opts.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch spec template")
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace of the template
+	// can update it.
+	if err := s.CheckNamespaceAccess(ctx, template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	if opts.Description != nil && *opts.Description != template.Description {
+		template.Description = *opts.Description
+		if err := tx.UpdateBatchSpecTemplate(ctx, template); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.CreateBatchSpecTemplateVersion(ctx, version); err != nil {
+		return nil, err
+	}
+
+	return tx.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: template.ID})
+}
+
+// DeleteBatchSpecTemplate deletes the BatchSpecTemplate with the given ID
+// and all of its versions.
+func (s *Service) DeleteBatchSpecTemplate(ctx context.Context, id int64) (err error) {
+	tr, ctx := trace.New(ctx, "Service.DeleteBatchSpecTemplate", fmt.Sprintf("template %d", id))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	template, err := s.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: id})
+	if err != nil {
+		return errors.Wrap(err, "getting batch spec template")
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace of the template
+	// can delete it.
+	if err := s.CheckNamespaceAccess(ctx, template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchSpecTemplate(ctx, id)
+}
+
+// RenderBatchSpecTemplate substitutes the given values for the parameters of
+// the given version of the BatchSpecTemplate and returns the resulting batch
+// spec. If version is 0, the latest version is used.
+func (s *Service) RenderBatchSpecTemplate(ctx context.Context, id int64, version int32, values map[string]interface{}) (spec string, err error) {
+	tr, ctx := trace.New(ctx, "Service.RenderBatchSpecTemplate", fmt.Sprintf("template %d, version %d", id, version))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if version == 0 {
+		template, err := s.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: id})
+		if err != nil {
+			return "", errors.Wrap(err, "getting batch spec template")
+		}
+		version = template.LatestVersion
+	}
+
+	v, err := s.store.GetBatchSpecTemplateVersion(ctx, id, version)
+	if err != nil {
+		return "", errors.Wrap(err, "getting batch spec template version")
+	}
+
+	return v.Render(values)
+}
@@ -155,6 +155,16 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("SetBatchChangeAutoMergeStrategy", func(t *testing.T) {
+				_, err := svc.SetBatchChangeAutoMergeStrategy(currentUserCtx, batchChange.ID, "")
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("SetBatchChangeContinuousExecution", func(t *testing.T) {
+				_, err := svc.SetBatchChangeContinuousExecution(currentUserCtx, batchChange.ID, 0, "")
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("ApplyBatchChange", func(t *testing.T) {
 				_, err := svc.ApplyBatchChange(currentUserCtx, ApplyBatchChangeOpts{
 					BatchSpecRandID: batchSpec.RandID,
@@ -287,6 +297,93 @@ func TestService(t *testing.T) {
 		})
 	})
 
+	t.Run("SetBatchChangeAutoMergeStrategy", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := svc.SetBatchChangeAutoMergeStrategy(adminCtx, batchChange.ID, btypes.BatchChangeAutoMergeStrategySquash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := updated.AutoMergeStrategy, btypes.BatchChangeAutoMergeStrategySquash; have != want {
+			t.Fatalf("wrong strategy. want=%q, have=%q", want, have)
+		}
+
+		reloaded, err := s.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.AutoMergeStrategy, btypes.BatchChangeAutoMergeStrategySquash; have != want {
+			t.Fatalf("wrong strategy in database. want=%q, have=%q", want, have)
+		}
+
+		if _, err := svc.SetBatchChangeAutoMergeStrategy(adminCtx, batchChange.ID, "rebase"); err == nil {
+			t.Fatal("expected error for invalid strategy, but got none")
+		}
+
+		disabled, err := svc.SetBatchChangeAutoMergeStrategy(adminCtx, batchChange.ID, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if disabled.AutoMergeEnabled() {
+			t.Fatal("expected auto-merge to be disabled")
+		}
+	})
+
+	t.Run("SetBatchChangeContinuousExecution", func(t *testing.T) {
+		spec := testBatchSpec(user.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(user.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		// Enabling executes batch specs server-side, which only site admins
+		// can do, even if they didn't author the batch change.
+		if _, err := svc.SetBatchChangeContinuousExecution(userCtx, batchChange.ID, 24, btypes.BatchChangeContinuousApplyPolicyCreate); err != backend.ErrMustBeSiteAdmin {
+			t.Fatalf("unexpected error. want=%v, have=%v", backend.ErrMustBeSiteAdmin, err)
+		}
+
+		if _, err := svc.SetBatchChangeContinuousExecution(adminCtx, batchChange.ID, 24, "always"); err == nil {
+			t.Fatal("expected error for invalid policy, but got none")
+		}
+
+		updated, err := svc.SetBatchChangeContinuousExecution(adminCtx, batchChange.ID, 24, btypes.BatchChangeContinuousApplyPolicyCreate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := updated.ContinuousInterval(), 24*time.Hour; have != want {
+			t.Fatalf("wrong interval. want=%s, have=%s", want, have)
+		}
+
+		reloaded, err := s.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.ContinuousApplyPolicy, btypes.BatchChangeContinuousApplyPolicyCreate; have != want {
+			t.Fatalf("wrong policy in database. want=%q, have=%q", want, have)
+		}
+
+		// The author can always disable continuous execution again.
+		disabled, err := svc.SetBatchChangeContinuousExecution(userCtx, batchChange.ID, 0, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if disabled.ContinuousEnabled() || disabled.ContinuousApplyPolicy != "" {
+			t.Fatal("expected continuous execution to be disabled")
+		}
+	})
+
 	t.Run("EnqueueChangesetSync", func(t *testing.T) {
 		spec := testBatchSpec(admin.ID)
 		if err := s.CreateBatchSpec(ctx, spec); err != nil {
@@ -1099,6 +1196,99 @@ func TestService(t *testing.T) {
 
 		})
 	})
+
+	t.Run("BatchSpecTemplates", func(t *testing.T) {
+		spec := "name: ${{ params.name }}\non:\n  - repositoriesMatchingQuery: ${{ params.query }}\n"
+		parameters := []btypes.BatchSpecTemplateParameter{
+			{Name: "name", Type: btypes.BatchSpecTemplateParameterTypeString, Default: "my-batch-change"},
+			{Name: "query", Type: btypes.BatchSpecTemplateParameterTypeRepoQuery, Required: true},
+		}
+
+		template, err := svc.CreateBatchSpecTemplate(userCtx, CreateBatchSpecTemplateOpts{
+			NamespaceUserID: user.ID,
+			Name:            "my-template",
+			Spec:            spec,
+			Parameters:      parameters,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := template.LatestVersion, int32(1); have != want {
+			t.Fatalf("wrong latest version. want=%d, have=%d", want, have)
+		}
+
+		t.Run("invalid spec", func(t *testing.T) {
+			_, err := svc.CreateBatchSpecTemplate(userCtx, CreateBatchSpecTemplateOpts{
+				NamespaceUserID: user.ID,
+				Name:            "invalid",
+				Spec:            spec,
+				Parameters:      parameters[:1],
+			})
+			if err == nil {
+				t.Fatal("expected error for undeclared parameter, but got none")
+			}
+		})
+
+		t.Run("other namespace", func(t *testing.T) {
+			_, err := svc.CreateBatchSpecTemplate(userCtx, CreateBatchSpecTemplateOpts{
+				NamespaceUserID: admin.ID,
+				Name:            "my-template",
+				Spec:            spec,
+				Parameters:      parameters,
+			})
+			if !errors.HasType(err, &backend.InsufficientAuthorizationError{}) {
+				t.Fatalf("expected auth error, got %v", err)
+			}
+		})
+
+		t.Run("update", func(t *testing.T) {
+			description := "Now with a description"
+			updated, err := svc.UpdateBatchSpecTemplate(userCtx, UpdateBatchSpecTemplateOpts{
+				ID:          template.ID,
+				Description: &description,
+				Spec:        spec + "description: ${{ params.name }}\n",
+				Parameters:  parameters,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := updated.LatestVersion, int32(2); have != want {
+				t.Fatalf("wrong latest version. want=%d, have=%d", want, have)
+			}
+			if updated.Description != description {
+				t.Fatalf("description not updated: %q", updated.Description)
+			}
+		})
+
+		t.Run("render", func(t *testing.T) {
+			values := map[string]interface{}{"query": "repo:foo"}
+
+			latest, err := svc.RenderBatchSpecTemplate(userCtx, template.ID, 0, values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "name: my-batch-change\non:\n  - repositoriesMatchingQuery: repo:foo\ndescription: my-batch-change\n"; latest != want {
+				t.Fatalf("wrong rendered spec. want=%q, have=%q", want, latest)
+			}
+
+			first, err := svc.RenderBatchSpecTemplate(userCtx, template.ID, 1, values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "name: my-batch-change\non:\n  - repositoriesMatchingQuery: repo:foo\n"; first != want {
+				t.Fatalf("wrong rendered spec. want=%q, have=%q", want, first)
+			}
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			if err := svc.DeleteBatchSpecTemplate(userCtx, template.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: template.ID}); err != store.ErrNoResults {
+				t.Fatalf("want template to be deleted, got err %v", err)
+			}
+		})
+	})
 }
 
 func testBatchChange(user int32, spec *btypes.BatchSpec) *btypes.BatchChange {
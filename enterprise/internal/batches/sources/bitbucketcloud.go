@@ -0,0 +1,295 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type BitbucketCloudSource struct {
+	client *bitbucketcloud.Client
+	au     auth.Authenticator
+}
+
+// NewBitbucketCloudSource returns a new BitbucketCloudSource from the given external service.
+func NewBitbucketCloudSource(svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketCloudSource, error) {
+	var c schema.BitbucketCloudConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newBitbucketCloudSource(&c, cf)
+}
+
+func newBitbucketCloudSource(c *schema.BitbucketCloudConnection, cf *httpcli.Factory) (*BitbucketCloudSource, error) {
+	if c.ApiURL == "" {
+		c.ApiURL = "https://api.bitbucket.org"
+	}
+	apiURL, err := url.Parse(c.ApiURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Bitbucket Cloud API URL")
+	}
+	apiURL = extsvc.NormalizeBaseURL(apiURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := bitbucketcloud.NewClient(apiURL, cli)
+	client.Username = c.Username
+	client.AppPassword = c.AppPassword
+
+	return &BitbucketCloudSource{
+		au:     &auth.BasicAuth{Username: c.Username, Password: c.AppPassword},
+		client: client,
+	}, nil
+}
+
+func (s BitbucketCloudSource) GitserverPushConfig(ctx context.Context, store *database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return gitserverPushConfig(ctx, store, repo, s.au)
+}
+
+func (s BitbucketCloudSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	var client *bitbucketcloud.Client
+	switch av := a.(type) {
+	case *auth.BasicAuth:
+		client = s.client.WithBasicAuth(av.Username, av.Password)
+	case *auth.BasicAuthWithSSH:
+		client = s.client.WithBasicAuth(av.Username, av.Password)
+	case *auth.OAuthBearerToken:
+		client = s.client.WithToken(av.Token)
+	case *auth.OAuthBearerTokenWithSSH:
+		client = s.client.WithToken(av.Token)
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("BitbucketCloudSource", a)
+	}
+
+	return &BitbucketCloudSource{
+		client: client,
+		au:     a,
+	}, nil
+}
+
+func (s BitbucketCloudSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.CurrentUser(ctx)
+	return err
+}
+
+// CreateChangeset creates the given *Changeset in the code host. Since
+// Bitbucket Cloud allows multiple pull requests for the same branches, an
+// existing open pull request is looked up first and reused.
+func (s BitbucketCloudSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	repo := c.Repo.Metadata.(*bitbucketcloud.Repo)
+
+	in := &bitbucketcloud.PullRequestInput{
+		Title:             c.Title,
+		Description:       c.Body,
+		SourceBranch:      git.AbbreviateRef(c.HeadRef),
+		DestinationBranch: git.AbbreviateRef(c.BaseRef),
+	}
+
+	existing, err := s.findOpenPullRequest(ctx, repo.FullName, in.SourceBranch, in.DestinationBranch)
+	if err != nil {
+		return false, errors.Wrap(err, "looking for existing pull request")
+	}
+	if existing != nil {
+		return true, s.setChangesetMetadata(ctx, c, repo.FullName, existing)
+	}
+
+	pr, err := s.client.CreatePullRequest(ctx, repo.FullName, in)
+	if err != nil {
+		return false, err
+	}
+
+	return false, s.setChangesetMetadata(ctx, c, repo.FullName, pr)
+}
+
+func (s BitbucketCloudSource) findOpenPullRequest(ctx context.Context, repo, source, destination string) (*bitbucketcloud.PullRequest, error) {
+	query := fmt.Sprintf(
+		"source.branch.name = %s AND destination.branch.name = %s AND state = %q",
+		strconv.Quote(source), strconv.Quote(destination), bitbucketcloud.PullRequestStateOpen,
+	)
+	prs, _, err := s.client.PullRequests(ctx, &bitbucketcloud.PageToken{Pagelen: 1}, repo, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0], nil
+}
+
+// CloseChangeset declines the given *Changeset on the code host and updates
+// the Metadata column in the *batches.Changeset to the newly declined pull
+// request.
+func (s BitbucketCloudSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	pr, repo, err := bitbucketCloudPullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	declined, err := s.client.DeclinePullRequest(ctx, repo, pr.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.setChangesetMetadata(ctx, c, repo, declined)
+}
+
+// LoadChangeset loads the latest state of the given Changeset from the codehost.
+func (s BitbucketCloudSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.Repo.Metadata.(*bitbucketcloud.Repo)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, repo.FullName, number)
+	if err != nil {
+		if err == bitbucketcloud.ErrPullRequestNotFound {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return err
+	}
+
+	return s.setChangesetMetadata(ctx, cs, repo.FullName, pr)
+}
+
+func (s BitbucketCloudSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	pr, repo, err := bitbucketCloudPullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, &bitbucketcloud.PullRequestInput{
+		Title:             c.Title,
+		Description:       c.Body,
+		DestinationBranch: git.AbbreviateRef(c.BaseRef),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.setChangesetMetadata(ctx, c, repo, updated)
+}
+
+// ReopenChangeset reopens the *Changeset on the code host. Bitbucket Cloud
+// can't reopen declined pull requests, so a new pull request for the same
+// branches is opened instead and the Changeset tracks that from now on.
+func (s BitbucketCloudSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	pr, repo, err := bitbucketCloudPullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	if pr.State == bitbucketcloud.PullRequestStateOpen {
+		return nil
+	}
+
+	title, description := pr.Title, pr.Description
+	if c.Title != "" {
+		title, description = c.Title, c.Body
+	}
+
+	reopened, err := s.client.CreatePullRequest(ctx, repo, &bitbucketcloud.PullRequestInput{
+		Title:             title,
+		Description:       description,
+		SourceBranch:      pr.Source.Branch.Name,
+		DestinationBranch: pr.Destination.Branch.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.setChangesetMetadata(ctx, c, repo, reopened)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s BitbucketCloudSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	pr, repo, err := bitbucketCloudPullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	return s.client.CreatePullRequestComment(ctx, repo, pr.ID, text)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, the commits of the pull request are squashed.
+func (s BitbucketCloudSource) MergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	pr, repo, err := bitbucketCloudPullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	merged, err := s.client.MergePullRequest(ctx, repo, pr.ID, squash)
+	if err != nil {
+		if errors.Is(err, bitbucketcloud.ErrNotMergeable) {
+			return &ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return err
+	}
+
+	return s.setChangesetMetadata(ctx, c, repo, merged)
+}
+
+// setChangesetMetadata loads the commit statuses of the pull request, which
+// aren't part of the pull request itself, and sets it as the metadata of the
+// Changeset.
+func (s BitbucketCloudSource) setChangesetMetadata(ctx context.Context, c *Changeset, repo string, pr *bitbucketcloud.PullRequest) error {
+	var statuses []*bitbucketcloud.CommitStatus
+	page := &bitbucketcloud.PageToken{Pagelen: 100}
+	for {
+		var (
+			batch []*bitbucketcloud.CommitStatus
+			err   error
+		)
+		batch, page, err = s.client.PullRequestStatuses(ctx, page, repo, pr.ID)
+		if err != nil {
+			return errors.Wrap(err, "loading pull request statuses")
+		}
+		statuses = append(statuses, batch...)
+		if !page.HasMore() {
+			break
+		}
+	}
+	pr.Statuses = statuses
+
+	if err := c.Changeset.SetMetadata(pr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// bitbucketCloudPullRequest returns the pull request of the Changeset and the
+// full name of its repository.
+func bitbucketCloudPullRequest(c *Changeset) (*bitbucketcloud.PullRequest, string, error) {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return nil, "", errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+	repo, ok := c.Repo.Metadata.(*bitbucketcloud.Repo)
+	if !ok {
+		return nil, "", errors.New("Changeset repo is not a Bitbucket Cloud repository")
+	}
+	return pr, repo.FullName, nil
+}
@@ -0,0 +1,271 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBitbucketCloudSource_ChangesetSource(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repo{
+		Metadata: &bitbucketcloud.Repo{FullName: "sglocal/mux"},
+	}
+
+	const prPath = "/2.0/repositories/sglocal/mux/pullrequests"
+
+	// newSource returns a BitbucketCloudSource talking to a fake API that
+	// responds to the given paths and records the requests it received.
+	newSource := func(t *testing.T, routes map[string]func(w http.ResponseWriter, body string)) (*BitbucketCloudSource, *[]string) {
+		var requests []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Method + " " + r.URL.Path
+			requests = append(requests, key)
+
+			if r.URL.Path == prPath+"/7/statuses" || r.URL.Path == prPath+"/8/statuses" {
+				_, _ = io.WriteString(w, `{"values": [{"key": "ci", "state": "SUCCESSFUL", "commit": {"hash": "abc"}}]}`)
+				return
+			}
+
+			route, ok := routes[key]
+			if !ok {
+				t.Errorf("unexpected request %q", key)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			route(w, string(body))
+		}))
+		t.Cleanup(srv.Close)
+
+		svc := &types.ExternalService{
+			Kind: extsvc.KindBitbucketCloud,
+			Config: marshalJSON(t, &schema.BitbucketCloudConnection{
+				Url:         "https://bitbucket.org",
+				ApiURL:      srv.URL,
+				Username:    "user",
+				AppPassword: "app-password",
+			}),
+		}
+		src, err := NewBitbucketCloudSource(svc, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return src, &requests
+	}
+
+	respond := func(status int, response string) func(http.ResponseWriter, string) {
+		return func(w http.ResponseWriter, _ string) {
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, response)
+		}
+	}
+
+	t.Run("CreateChangeset", func(t *testing.T) {
+		src, requests := newSource(t, map[string]func(http.ResponseWriter, string){
+			"GET " + prPath:  respond(http.StatusOK, `{"values": []}`),
+			"POST " + prPath: respond(http.StatusCreated, `{"id": 7, "state": "OPEN", "source": {"branch": {"name": "my-branch"}}}`),
+		})
+
+		cs := &Changeset{
+			Title:     "Title",
+			Body:      "Body",
+			HeadRef:   "refs/heads/my-branch",
+			BaseRef:   "refs/heads/master",
+			Repo:      repo,
+			Changeset: &btypes.Changeset{},
+		}
+		exists, err := src.CreateChangeset(ctx, cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists {
+			t.Fatal("unexpectedly existing changeset")
+		}
+
+		assertBitbucketCloudChangeset(t, cs.Changeset, "7")
+		if len(*requests) != 3 {
+			t.Fatalf("unexpected requests: %v", *requests)
+		}
+	})
+
+	t.Run("CreateChangeset existing", func(t *testing.T) {
+		src, requests := newSource(t, map[string]func(http.ResponseWriter, string){
+			"GET " + prPath: respond(http.StatusOK, `{"values": [{"id": 7, "state": "OPEN", "source": {"branch": {"name": "my-branch"}}}]}`),
+		})
+
+		cs := &Changeset{
+			HeadRef:   "refs/heads/my-branch",
+			BaseRef:   "refs/heads/master",
+			Repo:      repo,
+			Changeset: &btypes.Changeset{},
+		}
+		exists, err := src.CreateChangeset(ctx, cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Fatal("changeset unexpectedly not existing")
+		}
+
+		assertBitbucketCloudChangeset(t, cs.Changeset, "7")
+		if len(*requests) != 2 {
+			t.Fatalf("unexpected requests: %v", *requests)
+		}
+	})
+
+	t.Run("LoadChangeset not found", func(t *testing.T) {
+		src, _ := newSource(t, map[string]func(http.ResponseWriter, string){
+			"GET " + prPath + "/9": respond(http.StatusNotFound, `{"type": "error"}`),
+		})
+
+		cs := &Changeset{Repo: repo, Changeset: &btypes.Changeset{ExternalID: "9"}}
+		err := src.LoadChangeset(ctx, cs)
+		if !errors.As(err, &ChangesetNotFoundError{}) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ReopenChangeset opens a new pull request", func(t *testing.T) {
+		var payload bitbucketCloudPullRequestPayload
+		src, _ := newSource(t, map[string]func(http.ResponseWriter, string){
+			"POST " + prPath: func(w http.ResponseWriter, body string) {
+				if err := json.Unmarshal([]byte(body), &payload); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, _ = io.WriteString(w, `{"id": 8, "state": "OPEN", "source": {"branch": {"name": "my-branch"}}}`)
+			},
+		})
+
+		declined := &bitbucketcloud.PullRequest{ID: 7, State: bitbucketcloud.PullRequestStateDeclined, Title: "Title"}
+		declined.Source.Branch.Name = "my-branch"
+		declined.Destination.Branch.Name = "master"
+		cs := &Changeset{Repo: repo, Changeset: &btypes.Changeset{Metadata: declined}}
+
+		if err := src.ReopenChangeset(ctx, cs); err != nil {
+			t.Fatal(err)
+		}
+
+		assertBitbucketCloudChangeset(t, cs.Changeset, "8")
+		if payload.Title != "Title" || payload.Source.Branch.Name != "my-branch" || payload.Destination.Branch.Name != "master" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+	})
+
+	t.Run("MergeChangeset not mergeable", func(t *testing.T) {
+		src, _ := newSource(t, map[string]func(http.ResponseWriter, string){
+			"POST " + prPath + "/7/merge": respond(http.StatusBadRequest, `{"type": "error"}`),
+		})
+
+		cs := &Changeset{Repo: repo, Changeset: &btypes.Changeset{Metadata: &bitbucketcloud.PullRequest{ID: 7}}}
+		err := src.MergeChangeset(ctx, cs, false)
+		var e *ChangesetNotMergeableError
+		if !errors.As(err, &e) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+type bitbucketCloudPullRequestPayload struct {
+	Title  string `json:"title"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"source"`
+	Destination struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"destination"`
+}
+
+func assertBitbucketCloudChangeset(t *testing.T, c *btypes.Changeset, externalID string) {
+	t.Helper()
+
+	if c.ExternalID != externalID {
+		t.Fatalf("wrong external ID: have %q, want %q", c.ExternalID, externalID)
+	}
+	if c.ExternalServiceType != extsvc.TypeBitbucketCloud {
+		t.Fatalf("wrong external service type %q", c.ExternalServiceType)
+	}
+	if c.ExternalBranch != "refs/heads/my-branch" {
+		t.Fatalf("wrong external branch %q", c.ExternalBranch)
+	}
+	pr := c.Metadata.(*bitbucketcloud.PullRequest)
+	if len(pr.Statuses) != 1 || pr.Statuses[0].Key() != "abc:ci" {
+		t.Fatalf("statuses not loaded: %+v", pr.Statuses)
+	}
+}
+
+func TestBitbucketCloudSource_WithAuthenticator(t *testing.T) {
+	svc := &types.ExternalService{
+		Kind: extsvc.KindBitbucketCloud,
+		Config: marshalJSON(t, &schema.BitbucketCloudConnection{
+			Url:         "https://bitbucket.org",
+			Username:    "user",
+			AppPassword: "app-password",
+		}),
+	}
+
+	src, err := NewBitbucketCloudSource(svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":               &auth.BasicAuth{},
+			"BasicAuthWithSSH":        &auth.BasicAuthWithSSH{},
+			"OAuthBearerToken":        &auth.OAuthBearerToken{},
+			"OAuthBearerTokenWithSSH": &auth.OAuthBearerTokenWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				newSrc, err := src.WithAuthenticator(tc)
+				if err != nil {
+					t.Errorf("unexpected non-nil error: %v", err)
+				}
+
+				if bcs, ok := newSrc.(*BitbucketCloudSource); !ok {
+					t.Error("cannot coerce Source into BitbucketCloudSource")
+				} else if bcs == nil {
+					t.Error("unexpected nil Source")
+				} else if bcs.au != tc {
+					t.Errorf("incorrect authenticator: have=%v want=%v", bcs.au, tc)
+				}
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"nil":         nil,
+			"OAuthClient": &auth.OAuthClient{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				newSrc, err := src.WithAuthenticator(tc)
+				if err == nil {
+					t.Error("unexpected nil error")
+				} else if !errors.HasType(err, UnsupportedAuthenticatorError{}) {
+					t.Errorf("unexpected error of type %T: %v", err, err)
+				}
+				if newSrc != nil {
+					t.Errorf("expected non-nil Source: %v", newSrc)
+				}
+			})
+		}
+	})
+}
@@ -271,3 +271,32 @@ func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset,
 
 	return c.Changeset.SetMetadata(pr)
 }
+
+// UpdateChangesetMetadata adds and removes the reviewers of the pull request.
+// Bitbucket Server has no team reviewers, labels, assignees or milestones, so
+// they are ignored.
+func (s BitbucketServerSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset, update ChangesetMetadataUpdate) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	for _, username := range update.AddReviewers {
+		if err := s.client.AddPullRequestReviewer(ctx, pr, username); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", username)
+		}
+	}
+
+	for _, username := range update.RemoveReviewers {
+		for _, reviewer := range pr.Reviewers {
+			if reviewer.User == nil || reviewer.User.Name != username {
+				continue
+			}
+			if err := s.client.RemovePullRequestReviewer(ctx, pr, reviewer.User.Slug); err != nil {
+				return errors.Wrapf(err, "removing reviewer %q", username)
+			}
+		}
+	}
+
+	return s.LoadChangeset(ctx, c)
+}
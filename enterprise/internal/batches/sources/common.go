@@ -37,6 +37,15 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A MetadataChangesetSource can update the reviewers, labels, assignees and
+// milestone of changesets.
+type MetadataChangesetSource interface {
+	// UpdateChangesetMetadata applies the given update to the Changeset on
+	// the source and reloads it. Parts of the update that the code host does
+	// not support are ignored.
+	UpdateChangesetMetadata(context.Context, *Changeset, ChangesetMetadataUpdate) error
+}
+
 // A ChangesetSource can load the latest state of a list of Changesets.
 type ChangesetSource interface {
 	// GitserverPushConfig returns an authenticated push config used for pushing
@@ -90,6 +99,76 @@ func (e ChangesetNotMergeableError) Error() string {
 
 func (e ChangesetNotMergeableError) NonRetryable() bool { return true }
 
+// ChangesetMetadataUpdate describes how to bring the reviewers, labels,
+// assignees and milestone of a changeset in line with its changeset spec.
+// Only values that were previously set by a changeset spec are removed, so
+// that values added on the code host by other means are left alone.
+type ChangesetMetadataUpdate struct {
+	AddReviewers        []string
+	RemoveReviewers     []string
+	AddTeamReviewers    []string
+	RemoveTeamReviewers []string
+	AddLabels           []string
+	RemoveLabels        []string
+	AddAssignees        []string
+	RemoveAssignees     []string
+
+	// Milestone is the title of the milestone to set. If it is empty and
+	// RemoveMilestone is true, the milestone of the changeset is cleared.
+	Milestone       string
+	RemoveMilestone bool
+}
+
+// NewChangesetMetadataUpdate returns the update required to go from the
+// metadata of the previous changeset spec, which may be nil, to the metadata
+// of the current one. Values of the current spec are always added, so that
+// applying the update is idempotent.
+func NewChangesetMetadataUpdate(previous, current *btypes.ChangesetSpec) ChangesetMetadataUpdate {
+	var prev btypes.ChangesetSpecDescription
+	if previous != nil && previous.Spec != nil {
+		prev = *previous.Spec
+	}
+	curr := current.Spec
+
+	return ChangesetMetadataUpdate{
+		AddReviewers:        curr.Reviewers,
+		RemoveReviewers:     difference(prev.Reviewers, curr.Reviewers),
+		AddTeamReviewers:    curr.TeamReviewers,
+		RemoveTeamReviewers: difference(prev.TeamReviewers, curr.TeamReviewers),
+		AddLabels:           curr.Labels,
+		RemoveLabels:        difference(prev.Labels, curr.Labels),
+		AddAssignees:        curr.Assignees,
+		RemoveAssignees:     difference(prev.Assignees, curr.Assignees),
+		Milestone:           curr.Milestone,
+		RemoveMilestone:     curr.Milestone == "" && prev.Milestone != "",
+	}
+}
+
+// IsEmpty returns true if applying the update would not change anything.
+func (u ChangesetMetadataUpdate) IsEmpty() bool {
+	return len(u.AddReviewers) == 0 && len(u.RemoveReviewers) == 0 &&
+		len(u.AddTeamReviewers) == 0 && len(u.RemoveTeamReviewers) == 0 &&
+		len(u.AddLabels) == 0 && len(u.RemoveLabels) == 0 &&
+		len(u.AddAssignees) == 0 && len(u.RemoveAssignees) == 0 &&
+		u.Milestone == "" && !u.RemoveMilestone
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	set := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		set[v] = struct{}{}
+	}
+
+	var diff []string
+	for _, v := range a {
+		if _, ok := set[v]; !ok {
+			diff = append(diff, v)
+		}
+	}
+	return diff
+}
+
 // A Changeset of an existing Repo.
 type Changeset struct {
 	Title   string
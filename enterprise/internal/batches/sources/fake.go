@@ -38,19 +38,20 @@ type FakeChangesetSource struct {
 
 	CurrentAuthenticator auth.Authenticator
 
-	CreateDraftChangesetCalled  bool
-	UndraftedChangesetsCalled   bool
-	CreateChangesetCalled       bool
-	UpdateChangesetCalled       bool
-	ListReposCalled             bool
-	ExternalServicesCalled      bool
-	LoadChangesetCalled         bool
-	CloseChangesetCalled        bool
-	ReopenChangesetCalled       bool
-	CreateCommentCalled         bool
-	AuthenticatedUsernameCalled bool
-	ValidateAuthenticatorCalled bool
-	MergeChangesetCalled        bool
+	CreateDraftChangesetCalled    bool
+	UndraftedChangesetsCalled     bool
+	CreateChangesetCalled         bool
+	UpdateChangesetCalled         bool
+	ListReposCalled               bool
+	ExternalServicesCalled        bool
+	LoadChangesetCalled           bool
+	CloseChangesetCalled          bool
+	ReopenChangesetCalled         bool
+	CreateCommentCalled           bool
+	AuthenticatedUsernameCalled   bool
+	ValidateAuthenticatorCalled   bool
+	MergeChangesetCalled          bool
+	UpdateChangesetMetadataCalled bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -91,12 +92,17 @@ type FakeChangesetSource struct {
 	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
 	UndraftedChangesets []*Changeset
 
+	// MetadataUpdates contains the updates that were passed to
+	// UpdateChangesetMetadata
+	MetadataUpdates []ChangesetMetadataUpdate
+
 	// Username is the username returned by AuthenticatedUsername
 	Username string
 }
 
 var _ ChangesetSource = &FakeChangesetSource{}
 var _ DraftChangesetSource = &FakeChangesetSource{}
+var _ MetadataChangesetSource = &FakeChangesetSource{}
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
 	s.CreateDraftChangesetCalled = true
@@ -141,6 +147,22 @@ func (s *FakeChangesetSource) UndraftChangeset(ctx context.Context, c *Changeset
 	return c.SetMetadata(s.FakeMetadata)
 }
 
+func (s *FakeChangesetSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset, update ChangesetMetadataUpdate) error {
+	s.UpdateChangesetMetadataCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.Repo == nil {
+		return NoReposErr
+	}
+
+	s.MetadataUpdates = append(s.MetadataUpdates, update)
+
+	return c.SetMetadata(s.FakeMetadata)
+}
+
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
 	s.CreateChangesetCalled = true
 
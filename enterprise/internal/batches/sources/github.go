@@ -268,3 +268,30 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 
 	return c.Changeset.SetMetadata(pr)
 }
+
+// UpdateChangesetMetadata updates the reviewers, team reviewers, labels,
+// assignees and milestone of the pull request and reloads it.
+func (s GithubSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset, update ChangesetMetadataUpdate) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+	pr.RepoWithOwner = c.Repo.Metadata.(*github.Repository).NameWithOwner
+
+	if err := s.client.UpdatePullRequestMetadata(ctx, pr, &github.UpdatePullRequestMetadataInput{
+		AddReviewers:        update.AddReviewers,
+		RemoveReviewers:     update.RemoveReviewers,
+		AddTeamReviewers:    update.AddTeamReviewers,
+		RemoveTeamReviewers: update.RemoveTeamReviewers,
+		AddLabels:           update.AddLabels,
+		RemoveLabels:        update.RemoveLabels,
+		AddAssignees:        update.AddAssignees,
+		RemoveAssignees:     update.RemoveAssignees,
+		Milestone:           update.Milestone,
+		RemoveMilestone:     update.RemoveMilestone,
+	}); err != nil {
+		return errors.Wrap(err, "updating pull request metadata")
+	}
+
+	return s.LoadChangeset(ctx, c)
+}
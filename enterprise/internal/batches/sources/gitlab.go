@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 
@@ -447,3 +448,95 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 
 	return c.Changeset.SetMetadata(updated)
 }
+
+// UpdateChangesetMetadata updates the labels, assignees, reviewers and
+// milestone of the merge request. GitLab has no team reviewers, so they are
+// ignored.
+func (s *GitLabSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset, update ChangesetMetadataUpdate) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.Repo.Metadata.(*gitlab.Project)
+
+	opts := gitlab.UpdateMergeRequestMetadataOpts{
+		AddLabels:    strings.Join(update.AddLabels, ","),
+		RemoveLabels: strings.Join(update.RemoveLabels, ","),
+	}
+
+	if len(update.AddAssignees) > 0 || len(update.RemoveAssignees) > 0 {
+		ids, err := s.replaceUserIDs(ctx, mr.Assignees, update.AddAssignees, update.RemoveAssignees)
+		if err != nil {
+			return errors.Wrap(err, "resolving assignees")
+		}
+		opts.AssigneeIDs = &ids
+	}
+
+	if len(update.AddReviewers) > 0 || len(update.RemoveReviewers) > 0 {
+		ids, err := s.replaceUserIDs(ctx, mr.Reviewers, update.AddReviewers, update.RemoveReviewers)
+		if err != nil {
+			return errors.Wrap(err, "resolving reviewers")
+		}
+		opts.ReviewerIDs = &ids
+	}
+
+	if update.Milestone != "" {
+		milestones, err := s.client.ListProjectMilestones(ctx, project, update.Milestone)
+		if err != nil {
+			return errors.Wrap(err, "listing milestones")
+		}
+		if len(milestones) == 0 {
+			return errors.Errorf("active milestone %q not found", update.Milestone)
+		}
+		opts.MilestoneID = &milestones[0].ID
+	} else if update.RemoveMilestone {
+		var none gitlab.ID
+		opts.MilestoneID = &none
+	}
+
+	updated, err := s.client.UpdateMergeRequestMetadata(ctx, project, mr, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request metadata")
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// replaceUserIDs returns the IDs of the given current users, without the
+// removed usernames and with the added usernames.
+func (s *GitLabSource) replaceUserIDs(ctx context.Context, current []gitlab.User, add, remove []string) ([]int32, error) {
+	removed := make(map[string]struct{}, len(remove))
+	for _, username := range remove {
+		removed[username] = struct{}{}
+	}
+
+	ids := []int32{}
+	seen := map[int32]struct{}{}
+	for _, user := range current {
+		if _, ok := removed[user.Username]; !ok {
+			ids = append(ids, user.ID)
+			seen[user.ID] = struct{}{}
+		}
+	}
+
+	for _, username := range add {
+		users, _, err := s.client.ListUsers(ctx, "users?username="+url.QueryEscape(username))
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, errors.Errorf("user %q not found", username)
+		}
+		if _, ok := seen[users[0].ID]; !ok {
+			ids = append(ids, users[0].ID)
+			seen[users[0].ID] = struct{}{}
+		}
+	}
+
+	return ids, nil
+}
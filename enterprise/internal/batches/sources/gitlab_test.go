@@ -610,6 +610,66 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("UpdateChangesetMetadata", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			in := &gitlab.MergeRequest{
+				IID:       2,
+				Assignees: []gitlab.User{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}},
+			}
+			out := &gitlab.MergeRequest{IID: 2}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = in
+			p.mockListUsers(map[string]int32{"carol": 3})
+			gitlab.MockListProjectMilestones = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, title string) ([]*gitlab.Milestone, error) {
+				p.testCommonParams(ctx, client, project)
+				return []*gitlab.Milestone{{ID: 42, Title: title}}, nil
+			}
+			gitlab.MockUpdateMergeRequestMetadata = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestMetadataOpts) (*gitlab.MergeRequest, error) {
+				p.testCommonParams(ctx, client, project)
+				milestoneID := gitlab.ID(42)
+				want := gitlab.UpdateMergeRequestMetadataOpts{
+					AddLabels:    "a,b",
+					RemoveLabels: "c",
+					AssigneeIDs:  &[]int32{1, 3},
+					MilestoneID:  &milestoneID,
+				}
+				if diff := cmp.Diff(want, opts); diff != "" {
+					t.Errorf("unexpected options (-want +got):\n%s", diff)
+				}
+				return out, nil
+			}
+			p.mockGetMergeRequestNotes(out.IID, nil, 20, nil)
+			p.mockGetMergeRequestResourceStateEvents(out.IID, nil, 20, nil)
+			p.mockGetMergeRequestPipelines(out.IID, nil, 20, nil)
+
+			if err := p.source.UpdateChangesetMetadata(p.ctx, p.changeset, ChangesetMetadataUpdate{
+				AddLabels:       []string{"a", "b"},
+				RemoveLabels:    []string{"c"},
+				AddAssignees:    []string{"carol"},
+				RemoveAssignees: []string{"bob"},
+				Milestone:       "v1",
+			}); err != nil {
+				t.Errorf("unexpected non-nil error: %+v", err)
+			}
+			if p.changeset.Changeset.Metadata != out {
+				t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
+			}
+		})
+
+		t.Run("unknown user", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = &gitlab.MergeRequest{IID: 2}
+			p.mockListUsers(map[string]int32{})
+
+			if err := p.source.UpdateChangesetMetadata(p.ctx, p.changeset, ChangesetMetadataUpdate{
+				AddReviewers: []string{"nobody"},
+			}); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+	})
+
 	t.Run("CreateComment", func(t *testing.T) {
 		commentBody := "test-comment"
 		t.Run("invalid metadata", func(t *testing.T) {
@@ -948,6 +1008,22 @@ func (p *gitLabChangesetSourceTestProvider) mockCreateComment(expected string, m
 	}
 }
 
+// mockListUsers mocks gitlab.ListUsers lookups by username with the given
+// usernames and user IDs.
+func (p *gitLabChangesetSourceTestProvider) mockListUsers(ids map[string]int32) {
+	gitlab.MockListUsers = func(client *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.User, *string, error) {
+		u, err := url.Parse(urlStr)
+		if err != nil {
+			p.t.Fatal(err)
+		}
+		username := u.Query().Get("username")
+		if id, ok := ids[username]; ok {
+			return []*gitlab.User{{ID: id, Username: username}}, nil, nil
+		}
+		return nil, nil, nil
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockCreateMergeRequest = nil
 	gitlab.MockGetMergeRequest = nil
@@ -957,6 +1033,9 @@ func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockGetOpenMergeRequestByRefs = nil
 	gitlab.MockUpdateMergeRequest = nil
 	gitlab.MockCreateMergeRequestNote = nil
+	gitlab.MockUpdateMergeRequestMetadata = nil
+	gitlab.MockListProjectMilestones = nil
+	gitlab.MockListUsers = nil
 }
 
 // panicDoer provides a httpcli.Doer implementation that panics if any attempt
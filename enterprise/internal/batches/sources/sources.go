@@ -191,6 +191,13 @@ func ToDraftChangesetSource(css ChangesetSource) (DraftChangesetSource, error) {
 	return draftCss, nil
 }
 
+// ToMetadataChangesetSource returns the given ChangesetSource as a
+// MetadataChangesetSource, if it implements it.
+func ToMetadataChangesetSource(css ChangesetSource) (MetadataChangesetSource, bool) {
+	metadataCss, ok := css.(MetadataChangesetSource)
+	return metadataCss, ok
+}
+
 // WithAuthenticatorForUser authenticates the given ChangesetSource with a credential
 // usable by the given user with userID. User credentials are preferred, with a
 // fallback to site credentials. If none of these exist, ErrMissingCredentials
@@ -265,6 +272,10 @@ func loadExternalService(ctx context.Context, s *database.ExternalServiceStore,
 			if cfg.Token != "" {
 				return e, nil
 			}
+		case *schema.BitbucketCloudConnection:
+			if cfg.AppPassword != "" {
+				return e, nil
+			}
 		}
 	}
 
@@ -282,6 +293,8 @@ func buildChangesetSource(store SourcerStore, cf *httpcli.Factory, externalServi
 		return NewGitLabSource(externalService, cf)
 	case extsvc.KindBitbucketServer:
 		return NewBitbucketServerSource(externalService, cf)
+	case extsvc.KindBitbucketCloud:
+		return NewBitbucketCloudSource(externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -334,6 +347,9 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	case extsvc.TypeBitbucketServer:
 		return errors.New("require username/token to push commits to BitbucketServer")
 
+	case extsvc.TypeBitbucketCloud:
+		u.User = url.UserPassword("x-token-auth", token)
+
 	default:
 		panic(fmt.Sprintf("setOAuthTokenAuth: invalid external service type %q", extSvcType))
 	}
@@ -347,7 +363,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
 		u.User = url.UserPassword(username, password)
 
 	default:
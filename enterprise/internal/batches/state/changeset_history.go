@@ -64,9 +64,12 @@ var RequiredEventTypesForHistory = []btypes.ChangesetEventKind{
 	btypes.ChangesetEventKindBitbucketServerApproved,
 	btypes.ChangesetEventKindBitbucketServerReviewed,
 	btypes.ChangesetEventKindGitLabApproved,
+	btypes.ChangesetEventKindBitbucketCloudApproved,
+	btypes.ChangesetEventKindBitbucketCloudChangesRequested,
 	btypes.ChangesetEventKindBitbucketServerUnapproved,
 	btypes.ChangesetEventKindBitbucketServerDismissed,
 	btypes.ChangesetEventKindGitLabUnapproved,
+	btypes.ChangesetEventKindBitbucketCloudReviewDismissed,
 }
 
 type changesetStatesAtTime struct {
@@ -175,7 +178,9 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		case btypes.ChangesetEventKindGitHubReviewed,
 			btypes.ChangesetEventKindBitbucketServerApproved,
 			btypes.ChangesetEventKindBitbucketServerReviewed,
-			btypes.ChangesetEventKindGitLabApproved:
+			btypes.ChangesetEventKindGitLabApproved,
+			btypes.ChangesetEventKindBitbucketCloudApproved,
+			btypes.ChangesetEventKindBitbucketCloudChangesRequested:
 
 			s, err := e.ReviewState()
 			if err != nil {
@@ -217,7 +222,8 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 
 		case btypes.ChangesetEventKindBitbucketServerUnapproved,
 			btypes.ChangesetEventKindBitbucketServerDismissed,
-			btypes.ChangesetEventKindGitLabUnapproved:
+			btypes.ChangesetEventKindGitLabUnapproved,
+			btypes.ChangesetEventKindBitbucketCloudReviewDismissed:
 			author := e.ReviewAuthor()
 			// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
 			if author == "" {
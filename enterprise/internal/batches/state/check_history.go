@@ -0,0 +1,119 @@
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// RequiredEventTypesForCheckHistory keeps track of all event kinds required
+// for calculating the check state history of a changeset.
+var RequiredEventTypesForCheckHistory = []btypes.ChangesetEventKind{
+	btypes.ChangesetEventKindCommitStatus,
+	btypes.ChangesetEventKindCheckSuite,
+	btypes.ChangesetEventKindCheckRun,
+	btypes.ChangesetEventKindBitbucketServerCommitStatus,
+	btypes.ChangesetEventKindBitbucketCloudCommitStatus,
+	btypes.ChangesetEventKindGitLabPipeline,
+}
+
+// checkHistory is the overall check state of a changeset over time.
+type checkHistory []checkStateAtTime
+
+type checkStateAtTime struct {
+	t     time.Time
+	state btypes.ChangesetCheckState
+}
+
+// StateAtTime returns the check state valid at the given time. Before the
+// first check reported its state, the state is unknown.
+func (h checkHistory) StateAtTime(t time.Time) btypes.ChangesetCheckState {
+	state := btypes.ChangesetCheckStateUnknown
+	for _, s := range h {
+		if s.t.After(t) {
+			break
+		}
+		state = s.state
+	}
+	return state
+}
+
+// computeCheckHistory calculates the checkHistory for the given
+// ChangesetEvents of a changeset. Only the events are taken into account,
+// because the code hosts don't keep the history of the checks of a changeset.
+// The ChangesetEvents MUST be sorted by their Timestamp.
+func computeCheckHistory(ce ChangesetEvents) (checkHistory, error) {
+	if !sort.IsSorted(ce) {
+		return nil, errors.New("changeset events not sorted")
+	}
+
+	var (
+		history = checkHistory{}
+		current = btypes.ChangesetCheckStateUnknown
+
+		// The check states are tracked per status context, check suite,
+		// check run or pipeline and combined into the overall check state.
+		statesByKey = map[string]btypes.ChangesetCheckState{}
+	)
+
+	for _, e := range ce {
+		et := e.Timestamp()
+		if et.IsZero() {
+			continue
+		}
+
+		key, s, ok := checkEventState(e)
+		if !ok {
+			continue
+		}
+		statesByKey[key] = s
+
+		states := make([]btypes.ChangesetCheckState, 0, len(statesByKey))
+		for _, s := range statesByKey {
+			states = append(states, s)
+		}
+		if next := combineCheckStates(states); next != current {
+			current = next
+			history = append(history, checkStateAtTime{t: et, state: current})
+		}
+	}
+
+	return history, nil
+}
+
+// checkEventState returns the check state the given event reports and the key
+// of the check it belongs to. The second parameter is false if the event
+// doesn't report a check state.
+//
+// NOTE: If you add any event types here, make sure their kinds also appear in
+// `RequiredEventTypesForCheckHistory`.
+func checkEventState(e *btypes.ChangesetEvent) (string, btypes.ChangesetCheckState, bool) {
+	switch m := e.Metadata.(type) {
+	case *github.CommitStatus:
+		return "status:" + m.Context, parseGithubCheckState(m.State), true
+	case *github.CheckSuite:
+		// Suites without runs are ignored, like when computing the current
+		// check state.
+		if (m.Status == "QUEUED" || m.Status == "COMPLETED") && len(m.CheckRuns.Nodes) == 0 {
+			return "", "", false
+		}
+		return "suite:" + m.ID, parseGithubCheckSuiteState(m.Status, m.Conclusion), true
+	case *github.CheckRun:
+		return "run:" + m.ID, parseGithubCheckSuiteState(m.Status, m.Conclusion), true
+	case *bitbucketserver.CommitStatus:
+		return m.Key(), parseBitbucketBuildState(m.Status.State), true
+	case *bitbucketcloud.CommitStatus:
+		return m.Key(), parseBitbucketCloudBuildState(m.State), true
+	case *gitlab.Pipeline:
+		// Only the latest pipeline counts on GitLab.
+		return "pipeline", parseGitLabPipelineStatus(m.Status), true
+	}
+	return "", "", false
+}
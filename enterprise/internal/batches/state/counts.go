@@ -11,7 +11,8 @@ import (
 const timestampCount = 150
 
 // ChangesetCounts represents the states in which a given set of Changesets was
-// at a given point in time
+// at a given point in time. The check state counts are only known for the
+// checks Sourcegraph received events for.
 type ChangesetCounts struct {
 	Time                 time.Time
 	Total                int32
@@ -22,10 +23,13 @@ type ChangesetCounts struct {
 	OpenApproved         int32
 	OpenChangesRequested int32
 	OpenPending          int32
+	OpenChecksPending    int32
+	OpenChecksPassed     int32
+	OpenChecksFailed     int32
 }
 
 func (cc *ChangesetCounts) String() string {
-	return fmt.Sprintf("%s (Total: %d, Merged: %d, Closed: %d, Draft: %d, Open: %d, OpenApproved: %d, OpenChangesRequested: %d, OpenPending: %d)",
+	return fmt.Sprintf("%s (Total: %d, Merged: %d, Closed: %d, Draft: %d, Open: %d, OpenApproved: %d, OpenChangesRequested: %d, OpenPending: %d, OpenChecksPending: %d, OpenChecksPassed: %d, OpenChecksFailed: %d)",
 		cc.Time.String(),
 		cc.Total,
 		cc.Merged,
@@ -35,6 +39,9 @@ func (cc *ChangesetCounts) String() string {
 		cc.OpenApproved,
 		cc.OpenChangesRequested,
 		cc.OpenPending,
+		cc.OpenChecksPending,
+		cc.OpenChecksPassed,
+		cc.OpenChecksFailed,
 	)
 }
 
@@ -69,6 +76,10 @@ func CalcCounts(start, end time.Time, cs []*btypes.Changeset, es ...*btypes.Chan
 		if err != nil {
 			return counts, err
 		}
+		checks, err := computeCheckHistory(csEvents)
+		if err != nil {
+			return counts, err
+		}
 
 		// Go through every point in time we want to record and check the
 		// states of the changeset at that point in time
@@ -93,6 +104,14 @@ func CalcCounts(start, end time.Time, cs []*btypes.Changeset, es ...*btypes.Chan
 				case btypes.ChangesetReviewStateChangesRequested:
 					c.OpenChangesRequested++
 				}
+				switch checks.StateAtTime(c.Time) {
+				case btypes.ChangesetCheckStatePending:
+					c.OpenChecksPending++
+				case btypes.ChangesetCheckStatePassed:
+					c.OpenChecksPassed++
+				case btypes.ChangesetCheckStateFailed:
+					c.OpenChecksFailed++
+				}
 
 			case btypes.ChangesetExternalStateMerged:
 				c.Merged++
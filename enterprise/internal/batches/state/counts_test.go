@@ -1425,6 +1425,45 @@ func TestCalcCounts(t *testing.T) {
 				{Time: daysAgo(0), Total: 1, Draft: 1},
 			},
 		},
+		{
+			codehosts: extsvc.TypeGitHub,
+			name:      "GitHub check states",
+			changesets: []*btypes.Changeset{
+				ghChangeset(1, daysAgo(3)),
+			},
+			start: daysAgo(3),
+			events: []*btypes.ChangesetEvent{
+				ghCommitStatus(1, daysAgo(2), "ci/build", "PENDING"),
+				ghCommitStatus(1, daysAgo(2).Add(time.Hour), "ci/lint", "SUCCESS"),
+				ghCommitStatus(1, daysAgo(1), "ci/build", "FAILURE"),
+				ghCommitStatus(1, daysAgo(0), "ci/build", "SUCCESS"),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenPending: 1, OpenChecksPending: 1},
+				{Time: daysAgo(1), Total: 1, Open: 1, OpenPending: 1, OpenChecksFailed: 1},
+				{Time: daysAgo(0), Total: 1, Open: 1, OpenPending: 1, OpenChecksPassed: 1},
+			},
+		},
+		{
+			codehosts: extsvc.TypeGitLab,
+			name:      "GitLab check states ignored once closed",
+			changesets: []*btypes.Changeset{
+				glChangeset(1, daysAgo(3)),
+			},
+			start: daysAgo(3),
+			events: []*btypes.ChangesetEvent{
+				glPipeline(1, daysAgo(2), gitlab.PipelineStatusRunning),
+				glPipeline(1, daysAgo(1), gitlab.PipelineStatusSuccess),
+				glClosed(1, daysAgo(0), "user1"),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenPending: 1, OpenChecksPending: 1},
+				{Time: daysAgo(1), Total: 1, Open: 1, OpenPending: 1, OpenChecksPassed: 1},
+				{Time: daysAgo(0), Total: 1, Closed: 1},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1519,6 +1558,18 @@ func event(t *testing.T, ti time.Time, kind btypes.ChangesetEventKind, id int64)
 	return ch
 }
 
+func ghCommitStatus(id int64, t time.Time, context, state string) *btypes.ChangesetEvent {
+	return &btypes.ChangesetEvent{
+		ChangesetID: id,
+		Kind:        btypes.ChangesetEventKindCommitStatus,
+		Metadata: &github.CommitStatus{
+			Context:    context,
+			State:      state,
+			ReceivedAt: t,
+		},
+	}
+}
+
 func ghReview(id int64, t time.Time, login, state string) *btypes.ChangesetEvent {
 	return &btypes.ChangesetEvent{
 		ChangesetID: id,
@@ -1624,6 +1675,18 @@ func glClosed(id int64, t time.Time, login string) *btypes.ChangesetEvent {
 	}
 }
 
+func glPipeline(id int64, t time.Time, status gitlab.PipelineStatus) *btypes.ChangesetEvent {
+	return &btypes.ChangesetEvent{
+		ChangesetID: id,
+		Kind:        btypes.ChangesetEventKindGitLabPipeline,
+		Metadata: &gitlab.Pipeline{
+			Status:    status,
+			CreatedAt: gitlab.Time{Time: t},
+		},
+		CreatedAt: t,
+	}
+}
+
 func glReopen(id int64, t time.Time, login string) *btypes.ChangesetEvent {
 	return &btypes.ChangesetEvent{
 		ChangesetID: id,
@@ -0,0 +1,357 @@
+package state
+
+import (
+	"math"
+	"path"
+	"sort"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// ReportOpts configures the ChangesetReport calculated by CalcReport.
+type ReportOpts struct {
+	// Start and End are the timeframe of the changeset counts over time.
+	Start, End time.Time
+	// Now is the point in time the report is calculated for.
+	Now time.Time
+	// StaleAfter is the duration without any activity after which an open
+	// or draft changeset is considered stale. If zero, no changeset is stale.
+	StaleAfter time.Duration
+	// MergeSLA is the duration in which changesets are expected to be merged
+	// after they have been opened. If zero, no SLA is checked.
+	MergeSLA time.Duration
+}
+
+// ChangesetReport summarizes the progress of a set of changesets, for
+// reporting on one or more batch changes.
+type ChangesetReport struct {
+	// Counts are the changeset counts over time, as calculated by
+	// CalcCounts.
+	Counts []*ChangesetCounts
+	// Totals break down all changesets of the report.
+	Totals *ChangesetBreakdown
+	// ByCodeHost break down the changesets by the type of their code host,
+	// sorted by key.
+	ByCodeHost []*ChangesetBreakdown
+	// ByOrg break down the changesets by the organization owning their
+	// repository on the code host, sorted by key.
+	ByOrg []*ChangesetBreakdown
+	// Changesets are the entries of all changesets of the report, sorted by
+	// changeset ID.
+	Changesets []*ChangesetReportEntry
+}
+
+// Stale returns the entries of the stale changesets of the report.
+func (r *ChangesetReport) Stale() []*ChangesetReportEntry {
+	var stale []*ChangesetReportEntry
+	for _, e := range r.Changesets {
+		if e.Stale {
+			stale = append(stale, e)
+		}
+	}
+	return stale
+}
+
+// ChangesetReportEntry is a changeset of a ChangesetReport, together with the
+// data the report derived for it.
+type ChangesetReportEntry struct {
+	Changeset *btypes.Changeset
+	RepoName  api.RepoName
+
+	// CodeHost is the external service type of the changeset.
+	CodeHost string
+	// Org is the organization owning the repository on the code host. It's
+	// the repository name without its last path element, for example
+	// github.com/sourcegraph for github.com/sourcegraph/sourcegraph.
+	Org string
+
+	OpenedAt time.Time
+	// MergedAt is zero if the changeset hasn't been merged.
+	MergedAt time.Time
+	// LastActivityAt is the time of the last update of the changeset on the
+	// code host, or of its last event.
+	LastActivityAt time.Time
+
+	// Stale is true if the changeset is open or a draft and had no activity
+	// for the configured duration.
+	Stale bool
+	// BreachesSLA is true if the changeset was merged later than the
+	// configured SLA, or is still open or a draft after it.
+	BreachesSLA bool
+}
+
+// TimeToMerge returns the duration between opening and merging the
+// changeset, or zero if it hasn't been merged.
+func (e *ChangesetReportEntry) TimeToMerge() time.Duration {
+	if e.MergedAt.IsZero() {
+		return 0
+	}
+	return e.MergedAt.Sub(e.OpenedAt)
+}
+
+// ChangesetBreakdown counts the changesets of a report that share the same
+// key, for example the same code host, by their current states.
+type ChangesetBreakdown struct {
+	Key string
+
+	Total  int32
+	Open   int32
+	Draft  int32
+	Merged int32
+	Closed int32
+
+	OpenApproved         int32
+	OpenChangesRequested int32
+	OpenPending          int32
+
+	OpenChecksPending int32
+	OpenChecksPassed  int32
+	OpenChecksFailed  int32
+
+	Stale       int32
+	SLABreaches int32
+
+	TimeToMerge TimeToMergeDistribution
+
+	timesToMerge []time.Duration
+}
+
+func (b *ChangesetBreakdown) add(e *ChangesetReportEntry) {
+	c := e.Changeset
+
+	b.Total++
+	switch c.ExternalState {
+	case btypes.ChangesetExternalStateDraft:
+		b.Draft++
+	case btypes.ChangesetExternalStateOpen:
+		b.Open++
+		switch c.ExternalReviewState {
+		case btypes.ChangesetReviewStatePending:
+			b.OpenPending++
+		case btypes.ChangesetReviewStateApproved:
+			b.OpenApproved++
+		case btypes.ChangesetReviewStateChangesRequested:
+			b.OpenChangesRequested++
+		}
+		switch c.ExternalCheckState {
+		case btypes.ChangesetCheckStatePending:
+			b.OpenChecksPending++
+		case btypes.ChangesetCheckStatePassed:
+			b.OpenChecksPassed++
+		case btypes.ChangesetCheckStateFailed:
+			b.OpenChecksFailed++
+		}
+	case btypes.ChangesetExternalStateMerged:
+		b.Merged++
+	case btypes.ChangesetExternalStateClosed:
+		b.Closed++
+	}
+
+	if e.Stale {
+		b.Stale++
+	}
+	if e.BreachesSLA {
+		b.SLABreaches++
+	}
+	if !e.MergedAt.IsZero() {
+		b.timesToMerge = append(b.timesToMerge, e.TimeToMerge())
+	}
+}
+
+// timeToMergeBucketBounds are the upper bounds of the buckets of a
+// TimeToMergeDistribution. The last bucket is unbounded.
+var timeToMergeBucketBounds = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	0,
+}
+
+// TimeToMergeDistribution describes how long it took to merge changesets
+// after they have been opened.
+type TimeToMergeDistribution struct {
+	Count  int32
+	Mean   time.Duration
+	Median time.Duration
+	P90    time.Duration
+	Max    time.Duration
+
+	Buckets []TimeToMergeBucket
+}
+
+// TimeToMergeBucket counts the changesets that were merged within
+// UpperBound, but not within the upper bound of the previous bucket. An
+// UpperBound of zero means that the bucket is unbounded.
+type TimeToMergeBucket struct {
+	UpperBound time.Duration
+	Count      int32
+}
+
+func calcTimeToMergeDistribution(ds []time.Duration) TimeToMergeDistribution {
+	dist := TimeToMergeDistribution{
+		Count:   int32(len(ds)),
+		Buckets: make([]TimeToMergeBucket, len(timeToMergeBucketBounds)),
+	}
+	for i, bound := range timeToMergeBucketBounds {
+		dist.Buckets[i].UpperBound = bound
+	}
+	if len(ds) == 0 {
+		return dist
+	}
+
+	sorted := make([]time.Duration, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+		for i, bound := range timeToMergeBucketBounds {
+			if bound == 0 || d <= bound {
+				dist.Buckets[i].Count++
+				break
+			}
+		}
+	}
+
+	dist.Mean = sum / time.Duration(len(sorted))
+	dist.Median = percentile(sorted, 0.5)
+	dist.P90 = percentile(sorted, 0.9)
+	dist.Max = sorted[len(sorted)-1]
+	return dist
+}
+
+// percentile returns the p-th percentile of the sorted durations, using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// CalcReport calculates the ChangesetReport for the given published
+// Changesets and their ChangesetEvents. The repository names are used to
+// break down the changesets by organization. `es` are expected to be
+// pre-sorted.
+func CalcReport(opts ReportOpts, cs []*btypes.Changeset, repoNames map[api.RepoID]api.RepoName, es ...*btypes.ChangesetEvent) (*ChangesetReport, error) {
+	counts, err := CalcCounts(opts.Start, opts.End, cs, es...)
+	if err != nil {
+		return nil, err
+	}
+
+	byChangesetID := make(map[int64]ChangesetEvents)
+	for _, e := range es {
+		id := e.Changeset()
+		byChangesetID[id] = append(byChangesetID[id], e)
+	}
+
+	report := &ChangesetReport{
+		Counts:     counts,
+		Totals:     &ChangesetBreakdown{},
+		Changesets: make([]*ChangesetReportEntry, 0, len(cs)),
+	}
+	byCodeHost := make(map[string]*ChangesetBreakdown)
+	byOrg := make(map[string]*ChangesetBreakdown)
+
+	for _, c := range cs {
+		events := byChangesetID[c.ID]
+		history, err := computeHistory(c, events)
+		if err != nil {
+			return nil, err
+		}
+
+		e := &ChangesetReportEntry{
+			Changeset: c,
+			RepoName:  repoNames[c.RepoID],
+			CodeHost:  c.ExternalServiceType,
+			OpenedAt:  c.ExternalCreatedAt(),
+			MergedAt:  mergedAt(c, history),
+		}
+		e.Org = repoOrg(e.RepoName)
+
+		e.LastActivityAt = e.OpenedAt
+		if c.ExternalUpdatedAt.After(e.LastActivityAt) {
+			e.LastActivityAt = c.ExternalUpdatedAt
+		}
+		for _, ev := range events {
+			if t := ev.Timestamp(); t.After(e.LastActivityAt) {
+				e.LastActivityAt = t
+			}
+		}
+
+		open := c.ExternalState == btypes.ChangesetExternalStateOpen || c.ExternalState == btypes.ChangesetExternalStateDraft
+		if open && opts.StaleAfter > 0 {
+			e.Stale = opts.Now.Sub(e.LastActivityAt) > opts.StaleAfter
+		}
+		if opts.MergeSLA > 0 {
+			if !e.MergedAt.IsZero() {
+				e.BreachesSLA = e.TimeToMerge() > opts.MergeSLA
+			} else if open {
+				e.BreachesSLA = opts.Now.Sub(e.OpenedAt) > opts.MergeSLA
+			}
+		}
+
+		report.Changesets = append(report.Changesets, e)
+		report.Totals.add(e)
+		breakdown(byCodeHost, e.CodeHost).add(e)
+		breakdown(byOrg, e.Org).add(e)
+	}
+
+	sort.Slice(report.Changesets, func(i, j int) bool {
+		return report.Changesets[i].Changeset.ID < report.Changesets[j].Changeset.ID
+	})
+	report.Totals.TimeToMerge = calcTimeToMergeDistribution(report.Totals.timesToMerge)
+	report.ByCodeHost = sortedBreakdowns(byCodeHost)
+	report.ByOrg = sortedBreakdowns(byOrg)
+
+	return report, nil
+}
+
+// mergedAt returns when the changeset was merged according to its history.
+// Some code hosts don't have events for merges, so the last update of merged
+// changesets is used if the history doesn't contain the merge.
+func mergedAt(c *btypes.Changeset, history changesetHistory) time.Time {
+	for _, s := range history {
+		if s.externalState == btypes.ChangesetExternalStateMerged {
+			return s.t
+		}
+	}
+	if c.ExternalState == btypes.ChangesetExternalStateMerged {
+		return c.ExternalUpdatedAt
+	}
+	return time.Time{}
+}
+
+func repoOrg(name api.RepoName) string {
+	if dir := path.Dir(string(name)); dir != "." {
+		return dir
+	}
+	return string(name)
+}
+
+func breakdown(m map[string]*ChangesetBreakdown, key string) *ChangesetBreakdown {
+	b, ok := m[key]
+	if !ok {
+		b = &ChangesetBreakdown{Key: key}
+		m[key] = b
+	}
+	return b
+}
+
+// sortedBreakdowns returns the breakdowns sorted by key, with their time to
+// merge distributions calculated.
+func sortedBreakdowns(m map[string]*ChangesetBreakdown) []*ChangesetBreakdown {
+	bs := make([]*ChangesetBreakdown, 0, len(m))
+	for _, b := range m {
+		b.TimeToMerge = calcTimeToMergeDistribution(b.timesToMerge)
+		bs = append(bs, b)
+	}
+	sort.Slice(bs, func(i, j int) bool { return bs[i].Key < bs[j].Key })
+	return bs
+}
@@ -0,0 +1,149 @@
+package state
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteChangesetsCSV writes one row per changeset of the report to w, with a
+// header row. The names of the batch changes a changeset belongs to are
+// looked up in batchChangeNames.
+func WriteChangesetsCSV(w io.Writer, r *ChangesetReport, batchChangeNames map[int64]string) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{
+		"changeset_id",
+		"batch_changes",
+		"repository",
+		"code_host",
+		"organization",
+		"title",
+		"url",
+		"state",
+		"review_state",
+		"check_state",
+		"opened_at",
+		"merged_at",
+		"time_to_merge_hours",
+		"last_activity_at",
+		"stale",
+		"breaches_sla",
+	}); err != nil {
+		return err
+	}
+
+	for _, e := range r.Changesets {
+		c := e.Changeset
+
+		names := make([]string, 0, len(c.BatchChanges))
+		for _, assoc := range c.BatchChanges {
+			if name, ok := batchChangeNames[assoc.BatchChangeID]; ok {
+				names = append(names, name)
+			}
+		}
+
+		title, _ := c.Title()
+		url, _ := c.URL()
+
+		var timeToMerge string
+		if !e.MergedAt.IsZero() {
+			timeToMerge = strconv.FormatFloat(e.TimeToMerge().Hours(), 'f', 1, 64)
+		}
+
+		if err := cw.Write([]string{
+			strconv.FormatInt(c.ID, 10),
+			escapeCSVFormula(strings.Join(names, ";")),
+			escapeCSVFormula(string(e.RepoName)),
+			e.CodeHost,
+			escapeCSVFormula(e.Org),
+			escapeCSVFormula(title),
+			escapeCSVFormula(url),
+			string(c.ExternalState),
+			string(c.ExternalReviewState),
+			string(c.ExternalCheckState),
+			formatCSVTime(e.OpenedAt),
+			formatCSVTime(e.MergedAt),
+			timeToMerge,
+			formatCSVTime(e.LastActivityAt),
+			strconv.FormatBool(e.Stale),
+			strconv.FormatBool(e.BreachesSLA),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCountsCSV writes one row per point in time of the changeset counts
+// over time of the report to w, with a header row.
+func WriteCountsCSV(w io.Writer, r *ChangesetReport) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{
+		"date",
+		"total",
+		"merged",
+		"closed",
+		"draft",
+		"open",
+		"open_approved",
+		"open_changes_requested",
+		"open_pending",
+		"open_checks_pending",
+		"open_checks_passed",
+		"open_checks_failed",
+	}); err != nil {
+		return err
+	}
+
+	for _, c := range r.Counts {
+		row := []string{formatCSVTime(c.Time)}
+		for _, n := range []int32{
+			c.Total,
+			c.Merged,
+			c.Closed,
+			c.Draft,
+			c.Open,
+			c.OpenApproved,
+			c.OpenChangesRequested,
+			c.OpenPending,
+			c.OpenChecksPending,
+			c.OpenChecksPassed,
+			c.OpenChecksFailed,
+		} {
+			row = append(row, strconv.Itoa(int(n)))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeCSVFormula prefixes values that spreadsheet applications would
+// interpret as a formula with a single quote. Titles come from the code host
+// and batch change names from users, so they must not be trusted.
+func escapeCSVFormula(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
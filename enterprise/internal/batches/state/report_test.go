@@ -0,0 +1,249 @@
+package state
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestCalcReport(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	reportChangeset := func(c *btypes.Changeset, repo api.RepoID, state btypes.ChangesetExternalState, updatedAt time.Time) *btypes.Changeset {
+		c.RepoID = repo
+		c.ExternalServiceType = extsvc.TypeGitHub
+		c.ExternalState = state
+		c.ExternalReviewState = btypes.ChangesetReviewStatePending
+		c.ExternalCheckState = btypes.ChangesetCheckStateUnknown
+		c.ExternalUpdatedAt = updatedAt
+		c.PublicationState = btypes.ChangesetPublicationStatePublished
+		return c
+	}
+
+	// Merged after 1 day.
+	merged := reportChangeset(ghChangeset(1, daysAgo(20)), 1, btypes.ChangesetExternalStateMerged, daysAgo(19))
+	// Merged after 10 days.
+	mergedSlowly := reportChangeset(ghChangeset(2, daysAgo(20)), 2, btypes.ChangesetExternalStateMerged, daysAgo(1))
+	// Open, without activity for 15 days.
+	stale := reportChangeset(ghChangeset(3, daysAgo(20)), 2, btypes.ChangesetExternalStateOpen, daysAgo(15))
+	// Open, with recent activity and failed checks.
+	active := reportChangeset(ghChangeset(4, daysAgo(20)), 3, btypes.ChangesetExternalStateOpen, daysAgo(20))
+	active.ExternalCheckState = btypes.ChangesetCheckStateFailed
+	// Open on a different code host.
+	gitlab := reportChangeset(glChangeset(5, daysAgo(3)), 3, btypes.ChangesetExternalStateOpen, daysAgo(3))
+	gitlab.ExternalServiceType = extsvc.TypeGitLab
+
+	events := []*btypes.ChangesetEvent{
+		event(t, daysAgo(19), btypes.ChangesetEventKindGitHubMerged, 1),
+		event(t, daysAgo(10), btypes.ChangesetEventKindGitHubMerged, 2),
+		ghCommitStatus(4, daysAgo(2), "ci", "FAILURE"),
+	}
+
+	repoNames := map[api.RepoID]api.RepoName{
+		1: "github.com/sourcegraph/a",
+		2: "github.com/sourcegraph/b",
+		3: "gitlab.com/other/c",
+	}
+
+	report, err := CalcReport(ReportOpts{
+		Start:      daysAgo(2),
+		End:        now,
+		Now:        now,
+		StaleAfter: 14 * 24 * time.Hour,
+		MergeSLA:   7 * 24 * time.Hour,
+	}, []*btypes.Changeset{gitlab, active, stale, mergedSlowly, merged}, repoNames, events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("entries", func(t *testing.T) {
+		type entry struct {
+			ID          int64
+			Org         string
+			TimeToMerge time.Duration
+			Stale       bool
+			BreachesSLA bool
+		}
+		var have []entry
+		for _, e := range report.Changesets {
+			have = append(have, entry{
+				ID:          e.Changeset.ID,
+				Org:         e.Org,
+				TimeToMerge: e.TimeToMerge(),
+				Stale:       e.Stale,
+				BreachesSLA: e.BreachesSLA,
+			})
+		}
+		want := []entry{
+			{ID: 1, Org: "github.com/sourcegraph", TimeToMerge: 24 * time.Hour},
+			{ID: 2, Org: "github.com/sourcegraph", TimeToMerge: 10 * 24 * time.Hour, BreachesSLA: true},
+			{ID: 3, Org: "github.com/sourcegraph", Stale: true, BreachesSLA: true},
+			{ID: 4, Org: "gitlab.com/other", BreachesSLA: true},
+			{ID: 5, Org: "gitlab.com/other"},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if stale := report.Stale(); len(stale) != 1 || stale[0].Changeset.ID != 3 {
+			t.Fatalf("wrong stale changesets: %+v", stale)
+		}
+	})
+
+	t.Run("breakdowns", func(t *testing.T) {
+		type counts struct {
+			Key              string
+			Total, Open      int32
+			Merged           int32
+			OpenChecksFailed int32
+			Stale            int32
+			SLABreaches      int32
+			MergedCount      int32
+		}
+		toCounts := func(bs ...*ChangesetBreakdown) []counts {
+			var cs []counts
+			for _, b := range bs {
+				cs = append(cs, counts{
+					Key:              b.Key,
+					Total:            b.Total,
+					Open:             b.Open,
+					Merged:           b.Merged,
+					OpenChecksFailed: b.OpenChecksFailed,
+					Stale:            b.Stale,
+					SLABreaches:      b.SLABreaches,
+					MergedCount:      b.TimeToMerge.Count,
+				})
+			}
+			return cs
+		}
+
+		if diff := cmp.Diff([]counts{
+			{Total: 5, Open: 3, Merged: 2, OpenChecksFailed: 1, Stale: 1, SLABreaches: 3, MergedCount: 2},
+		}, toCounts(report.Totals)); diff != "" {
+			t.Fatalf("wrong totals: %s", diff)
+		}
+
+		if diff := cmp.Diff([]counts{
+			{Key: extsvc.TypeGitHub, Total: 4, Open: 2, Merged: 2, OpenChecksFailed: 1, Stale: 1, SLABreaches: 3, MergedCount: 2},
+			{Key: extsvc.TypeGitLab, Total: 1, Open: 1},
+		}, toCounts(report.ByCodeHost...)); diff != "" {
+			t.Fatalf("wrong code host breakdown: %s", diff)
+		}
+
+		if diff := cmp.Diff([]counts{
+			{Key: "github.com/sourcegraph", Total: 3, Open: 1, Merged: 2, Stale: 1, SLABreaches: 2, MergedCount: 2},
+			{Key: "gitlab.com/other", Total: 2, Open: 2, OpenChecksFailed: 1, SLABreaches: 1},
+		}, toCounts(report.ByOrg...)); diff != "" {
+			t.Fatalf("wrong org breakdown: %s", diff)
+		}
+	})
+
+	t.Run("counts", func(t *testing.T) {
+		last := report.Counts[len(report.Counts)-1]
+		if last.Total != 5 || last.Merged != 2 || last.OpenChecksFailed != 1 {
+			t.Fatalf("wrong counts: %s", last)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteChangesetsCSV(&buf, report, nil); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(rows), len(report.Changesets)+1; have != want {
+			t.Fatalf("wrong number of rows. want=%d, have=%d", want, have)
+		}
+		if have, want := rows[2][12], "240.0"; have != want {
+			t.Fatalf("wrong time to merge. want=%q, have=%q", want, have)
+		}
+
+		buf.Reset()
+		if err := WriteCountsCSV(&buf, report); err != nil {
+			t.Fatal(err)
+		}
+		rows, err = csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(rows), len(report.Counts)+1; have != want {
+			t.Fatalf("wrong number of rows. want=%d, have=%d", want, have)
+		}
+	})
+}
+
+func TestWriteChangesetsCSVEscapesFormulas(t *testing.T) {
+	c := &btypes.Changeset{ID: 1, Metadata: &github.PullRequest{Title: `=HYPERLINK("https://example.com")`}}
+
+	report := &ChangesetReport{Changesets: []*ChangesetReportEntry{{
+		Changeset: c,
+		RepoName:  "github.com/sourcegraph/a",
+	}}}
+
+	var buf bytes.Buffer
+	if err := WriteChangesetsCSV(&buf, report, map[int64]string{}); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := rows[1][5], `'=HYPERLINK("https://example.com")`; have != want {
+		t.Fatalf("wrong title. want=%q, have=%q", want, have)
+	}
+
+	for in, want := range map[string]string{
+		"":           "",
+		"fix things": "fix things",
+		"+1":         "'+1",
+		"-1":         "'-1",
+		"@SUM(A1)":   "'@SUM(A1)",
+		"\tfoo":      "'\tfoo",
+		"\rfoo":      "'\rfoo",
+	} {
+		if have := escapeCSVFormula(in); have != want {
+			t.Errorf("wrong escaping of %q. want=%q, have=%q", in, want, have)
+		}
+	}
+}
+
+func TestCalcTimeToMergeDistribution(t *testing.T) {
+	day := 24 * time.Hour
+
+	have := calcTimeToMergeDistribution([]time.Duration{
+		40 * day, 2 * day, 1 * day, 10 * day, 2 * day,
+	})
+	want := TimeToMergeDistribution{
+		Count:  5,
+		Mean:   11 * day,
+		Median: 2 * day,
+		P90:    40 * day,
+		Max:    40 * day,
+		Buckets: []TimeToMergeBucket{
+			{UpperBound: day, Count: 1},
+			{UpperBound: 3 * day, Count: 2},
+			{UpperBound: 7 * day},
+			{UpperBound: 14 * day, Count: 1},
+			{UpperBound: 30 * day},
+			{Count: 1},
+		},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
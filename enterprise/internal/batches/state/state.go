@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -103,6 +104,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 	case *bitbucketserver.PullRequest:
 		return computeBitbucketBuildStatus(c.UpdatedAt, m, events)
 
+	case *bitbucketcloud.PullRequest:
+		return computeBitbucketCloudBuildStatus(c.UpdatedAt, m, events)
+
 	case *gitlab.MergeRequest:
 		return computeGitLabCheckState(c.UpdatedAt, m, events)
 	}
@@ -116,6 +120,13 @@ func computeExternalState(c *btypes.Changeset, history []changesetStatesAtTime)
 	if len(history) == 0 {
 		return computeSingleChangesetExternalState(c)
 	}
+
+	// Bitbucket Cloud has no events for state changes, so the history never
+	// reflects them and we always need to look at the Changeset.
+	if c.ExternalServiceType == extsvc.TypeBitbucketCloud {
+		return computeSingleChangesetExternalState(c)
+	}
+
 	newestDataPoint := history[len(history)-1]
 	if c.UpdatedAt.After(newestDataPoint.t) {
 		return computeSingleChangesetExternalState(c)
@@ -197,6 +208,49 @@ func parseBitbucketBuildState(s string) btypes.ChangesetCheckState {
 	}
 }
 
+func computeBitbucketCloudBuildStatus(lastSynced time.Time, pr *bitbucketcloud.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
+	stateMap := make(map[string]btypes.ChangesetCheckState)
+
+	// States from last sync
+	for _, status := range pr.Statuses {
+		stateMap[status.Key()] = parseBitbucketCloudBuildState(status.State)
+	}
+
+	// Add any events we've received since our last sync
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *bitbucketcloud.CommitStatus:
+			if m.Commit.Hash != pr.Source.Commit.Hash {
+				continue
+			}
+			if m.UpdatedOn.Before(lastSynced) {
+				continue
+			}
+			stateMap[m.Key()] = parseBitbucketCloudBuildState(m.State)
+		}
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(stateMap))
+	for _, v := range stateMap {
+		states = append(states, v)
+	}
+
+	return combineCheckStates(states)
+}
+
+func parseBitbucketCloudBuildState(s string) btypes.ChangesetCheckState {
+	switch s {
+	case bitbucketcloud.CommitStatusStateFailed, bitbucketcloud.CommitStatusStateStopped:
+		return btypes.ChangesetCheckStateFailed
+	case bitbucketcloud.CommitStatusStateInProgress:
+		return btypes.ChangesetCheckStatePending
+	case bitbucketcloud.CommitStatusStateSuccessful:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -435,6 +489,17 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		} else {
 			s = btypes.ChangesetExternalState(m.State)
 		}
+	case *bitbucketcloud.PullRequest:
+		switch m.State {
+		case bitbucketcloud.PullRequestStateOpen:
+			s = btypes.ChangesetExternalStateOpen
+		case bitbucketcloud.PullRequestStateMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case bitbucketcloud.PullRequestStateDeclined, bitbucketcloud.PullRequestStateSuperseded:
+			s = btypes.ChangesetExternalStateClosed
+		default:
+			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
+		}
 	case *gitlab.MergeRequest:
 		switch m.State {
 		case gitlab.MergeRequestStateClosed, gitlab.MergeRequestStateLocked:
@@ -486,6 +551,16 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *bitbucketcloud.PullRequest:
+		for _, p := range m.Participants {
+			switch p.State {
+			case bitbucketcloud.ParticipantStateApproved:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case bitbucketcloud.ParticipantStateChangesRequested:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			}
+		}
+
 	case *gitlab.MergeRequest:
 		// GitLab has an elaborate approvers workflow, but this doesn't map
 		// terribly closely to the GitHub/Bitbucket workflow: most notably,
@@ -9,6 +9,7 @@ import (
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -276,6 +277,80 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeBitbucketCloudBuildStatus(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	sha := "abcdef"
+	lastSynced := now.Add(-1 * time.Minute)
+
+	status := func(commit, key, state string, updatedOn time.Time) *bitbucketcloud.CommitStatus {
+		return &bitbucketcloud.CommitStatus{
+			StatusKey: key,
+			State:     state,
+			Commit:    bitbucketcloud.PullRequestCommit{Hash: commit},
+			UpdatedOn: updatedOn,
+		}
+	}
+	statusEvent := func(commit, key, state string, updatedOn time.Time) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind:     btypes.ChangesetEventKindBitbucketCloudCommitStatus,
+			Metadata: status(commit, key, state, updatedOn),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		statuses []*bitbucketcloud.CommitStatus
+		events   []*btypes.ChangesetEvent
+		want     btypes.ChangesetCheckState
+	}{
+		{
+			name: "no statuses",
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:     "synced success",
+			statuses: []*bitbucketcloud.CommitStatus{status(sha, "ctx1", "SUCCESSFUL", lastSynced)},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:     "synced stopped",
+			statuses: []*bitbucketcloud.CommitStatus{status(sha, "ctx1", "STOPPED", lastSynced)},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:     "newer event has precedence",
+			statuses: []*bitbucketcloud.CommitStatus{status(sha, "ctx1", "INPROGRESS", lastSynced)},
+			events:   []*btypes.ChangesetEvent{statusEvent(sha, "ctx1", "FAILED", now)},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:     "older event is ignored",
+			statuses: []*bitbucketcloud.CommitStatus{status(sha, "ctx1", "INPROGRESS", lastSynced)},
+			events:   []*btypes.ChangesetEvent{statusEvent(sha, "ctx1", "FAILED", lastSynced.Add(-time.Minute))},
+			want:     btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:   "event for other commit is ignored",
+			events: []*btypes.ChangesetEvent{statusEvent("other", "ctx1", "FAILED", now)},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &bitbucketcloud.PullRequest{Statuses: tc.statuses}
+			pr.Source.Commit.Hash = sha
+
+			have := computeBitbucketCloudBuildStatus(lastSynced, pr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -464,6 +539,26 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "bitbucketcloud - no events",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, bitbucketcloud.ParticipantStateChangesRequested),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name:      "bitbucketcloud - changeset newer than events",
+			changeset: bitbucketCloudChangeset(daysAgo(0), bitbucketcloud.PullRequestStateOpen, bitbucketcloud.ParticipantStateApproved),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(10), reviewState: btypes.ChangesetReviewStateChangesRequested},
+			},
+			want: btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "bitbucketcloud - participant without review",
+			changeset: bitbucketCloudChangeset(daysAgo(0), bitbucketcloud.PullRequestStateOpen, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
 		{
 			name:      "gitlab - no events, no approvals",
 			changeset: gitLabChangeset(daysAgo(0), gitlab.MergeRequestStateOpened, []*gitlab.Note{}),
@@ -653,6 +748,26 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateDeleted,
 		},
+		{
+			name:      "bitbucketcloud - declined",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateDeclined, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "bitbucketcloud - superseded",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateSuperseded, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "bitbucketcloud - changeset older than events",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateMerged, ""),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), externalState: btypes.ChangesetExternalStateOpen},
+			},
+			want: btypes.ChangesetExternalStateMerged,
+		},
 		{
 			name:      "gitlab - no events, opened",
 			changeset: gitLabChangeset(daysAgo(0), gitlab.MergeRequestStateOpened, nil),
@@ -846,6 +961,19 @@ func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *btypes
 	}
 }
 
+func bitbucketCloudChangeset(updatedAt time.Time, state, participantState string) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeBitbucketCloud,
+		UpdatedAt:           updatedAt,
+		Metadata: &bitbucketcloud.PullRequest{
+			State: state,
+			Participants: []bitbucketcloud.Participant{
+				{Role: "REVIEWER", State: participantState},
+			},
+		},
+	}
+}
+
 func githubChangeset(updatedAt time.Time, state string) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypeGitHub,
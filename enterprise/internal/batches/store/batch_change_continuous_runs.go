@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// batchChangeContinuousRunColumns are used by the continuous run related
+// Store methods to insert, update and query continuous runs.
+var batchChangeContinuousRunColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_continuous_runs.id"),
+	sqlf.Sprintf("batch_change_continuous_runs.batch_change_id"),
+	sqlf.Sprintf("batch_change_continuous_runs.batch_spec_execution_id"),
+	sqlf.Sprintf("batch_change_continuous_runs.state"),
+	sqlf.Sprintf("batch_change_continuous_runs.message"),
+	sqlf.Sprintf("batch_change_continuous_runs.created_at"),
+	sqlf.Sprintf("batch_change_continuous_runs.updated_at"),
+	sqlf.Sprintf("batch_change_continuous_runs.finished_at"),
+}
+
+// CreateBatchChangeContinuousRun creates the given BatchChangeContinuousRun.
+func (s *Store) CreateBatchChangeContinuousRun(ctx context.Context, r *btypes.BatchChangeContinuousRun) error {
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = s.now()
+	}
+
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = r.CreatedAt
+	}
+
+	if r.State == "" {
+		r.State = btypes.BatchChangeContinuousRunStateExecuting
+	}
+
+	q := sqlf.Sprintf(
+		createBatchChangeContinuousRunQueryFmtstr,
+		r.BatchChangeID,
+		r.BatchSpecExecutionID,
+		r.State,
+		nullStringColumn(r.Message),
+		r.CreatedAt,
+		r.UpdatedAt,
+		nullTimeColumn(r.FinishedAt),
+		sqlf.Join(batchChangeContinuousRunColumns, ", "),
+	)
+	return s.query(ctx, q, func(sc scanner) error { return scanBatchChangeContinuousRun(r, sc) })
+}
+
+var createBatchChangeContinuousRunQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_continuous_runs.go:CreateBatchChangeContinuousRun
+INSERT INTO batch_change_continuous_runs (
+	batch_change_id,
+	batch_spec_execution_id,
+	state,
+	message,
+	created_at,
+	updated_at,
+	finished_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateBatchChangeContinuousRun updates the state, message and finished_at
+// timestamp of the given BatchChangeContinuousRun.
+func (s *Store) UpdateBatchChangeContinuousRun(ctx context.Context, r *btypes.BatchChangeContinuousRun) error {
+	r.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateBatchChangeContinuousRunQueryFmtstr,
+		r.State,
+		nullStringColumn(r.Message),
+		r.UpdatedAt,
+		nullTimeColumn(r.FinishedAt),
+		r.ID,
+		sqlf.Join(batchChangeContinuousRunColumns, ", "),
+	)
+
+	updated := &btypes.BatchChangeContinuousRun{}
+	if err := s.query(ctx, q, func(sc scanner) error { return scanBatchChangeContinuousRun(updated, sc) }); err != nil {
+		return err
+	}
+	if updated.ID == 0 {
+		return ErrNoResults
+	}
+	*r = *updated
+	return nil
+}
+
+var updateBatchChangeContinuousRunQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_continuous_runs.go:UpdateBatchChangeContinuousRun
+UPDATE batch_change_continuous_runs
+SET
+	state = %s,
+	message = %s,
+	updated_at = %s,
+	finished_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// GetLatestBatchChangeContinuousRun gets the most recently created
+// BatchChangeContinuousRun of the batch change with the given ID. It returns
+// ErrNoResults if the batch change has no runs yet.
+func (s *Store) GetLatestBatchChangeContinuousRun(ctx context.Context, batchChangeID int64) (*btypes.BatchChangeContinuousRun, error) {
+	runs, _, err := s.ListBatchChangeContinuousRuns(ctx, ListBatchChangeContinuousRunsOpts{
+		LimitOpts:     LimitOpts{Limit: 1},
+		BatchChangeID: batchChangeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runs) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return runs[0], nil
+}
+
+// ListBatchChangeContinuousRunsOpts captures the query options needed for
+// listing continuous runs.
+type ListBatchChangeContinuousRunsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	BatchChangeID int64
+}
+
+// ListBatchChangeContinuousRuns lists continuous runs with the given filters,
+// newest first.
+func (s *Store) ListBatchChangeContinuousRuns(ctx context.Context, opts ListBatchChangeContinuousRunsOpts) (rs []*btypes.BatchChangeContinuousRun, next int64, err error) {
+	q := listBatchChangeContinuousRunsQuery(&opts)
+
+	rs = make([]*btypes.BatchChangeContinuousRun, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var r btypes.BatchChangeContinuousRun
+		if err := scanBatchChangeContinuousRun(&r, sc); err != nil {
+			return err
+		}
+		rs = append(rs, &r)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(rs) == opts.DBLimit() {
+		next = rs[len(rs)-1].ID
+		rs = rs[:len(rs)-1]
+	}
+
+	return rs, next, err
+}
+
+var listBatchChangeContinuousRunsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_continuous_runs.go:ListBatchChangeContinuousRuns
+SELECT %s FROM batch_change_continuous_runs
+WHERE %s
+ORDER BY id DESC
+`
+
+func listBatchChangeContinuousRunsQuery(opts *ListBatchChangeContinuousRunsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{}
+
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_continuous_runs.id <= %s", opts.Cursor))
+	}
+
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_continuous_runs.batch_change_id = %s", opts.BatchChangeID))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		listBatchChangeContinuousRunsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchChangeContinuousRunColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanBatchChangeContinuousRun(r *btypes.BatchChangeContinuousRun, s scanner) error {
+	return s.Scan(
+		&r.ID,
+		&r.BatchChangeID,
+		&r.BatchSpecExecutionID,
+		&r.State,
+		&dbutil.NullString{S: &r.Message},
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&dbutil.NullTime{Time: &r.FinishedAt},
+	)
+}
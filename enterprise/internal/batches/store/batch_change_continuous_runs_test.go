@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchChangeContinuousRuns(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	runs := make([]*btypes.BatchChangeContinuousRun, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(runs); i++ {
+			r := &btypes.BatchChangeContinuousRun{
+				BatchChangeID:        1234,
+				BatchSpecExecutionID: int64(i) + 5678,
+			}
+			if i == cap(runs)-1 {
+				r.BatchChangeID = 4321
+			}
+
+			want := r.Clone()
+			if err := s.CreateBatchChangeContinuousRun(ctx, r); err != nil {
+				t.Fatal(err)
+			}
+			if r.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = r.ID
+			want.State = btypes.BatchChangeContinuousRunStateExecuting
+			want.CreatedAt = clock.Now()
+			want.UpdatedAt = clock.Now()
+			if diff := cmp.Diff(r, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			runs = append(runs, r)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Add(1 * time.Second)
+
+		r := runs[1]
+		r.State = btypes.BatchChangeContinuousRunStateSkipped
+		r.Message = "changesets would be updated"
+		r.FinishedAt = clock.Now()
+
+		want := r.Clone()
+		want.UpdatedAt = clock.Now()
+		if err := s.UpdateBatchChangeContinuousRun(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(r, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			err := s.UpdateBatchChangeContinuousRun(ctx, &btypes.BatchChangeContinuousRun{ID: 0xdeadbeef})
+			if err != ErrNoResults {
+				t.Fatalf("unexpected error: want=%v, have=%v", ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("GetLatest", func(t *testing.T) {
+		have, err := s.GetLatestBatchChangeContinuousRun(ctx, 1234)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, runs[1]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetLatestBatchChangeContinuousRun(ctx, 0xdeadbeef)
+			if err != ErrNoResults {
+				t.Fatalf("unexpected error: want=%v, have=%v", ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, next, err := s.ListBatchChangeContinuousRuns(ctx, ListBatchChangeContinuousRunsOpts{BatchChangeID: 1234})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != 0 {
+			t.Fatalf("unexpected next: %d", next)
+		}
+		if diff := cmp.Diff(have, []*btypes.BatchChangeContinuousRun{runs[1], runs[0]}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("With Limit", func(t *testing.T) {
+			have, next, err := s.ListBatchChangeContinuousRuns(ctx, ListBatchChangeContinuousRunsOpts{
+				LimitOpts:     LimitOpts{Limit: 1},
+				BatchChangeID: 1234,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := runs[0].ID; next != want {
+				t.Fatalf("wrong next. want=%d, have=%d", want, next)
+			}
+			if diff := cmp.Diff(have, []*btypes.BatchChangeContinuousRun{runs[1]}); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+}
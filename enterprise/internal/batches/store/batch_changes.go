@@ -29,6 +29,9 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.auto_merge_strategy"),
+	sqlf.Sprintf("batch_changes.continuous_interval_hours"),
+	sqlf.Sprintf("batch_changes.continuous_apply_policy"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -46,6 +49,9 @@ var batchChangeInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("updated_at"),
 	sqlf.Sprintf("closed_at"),
 	sqlf.Sprintf("batch_spec_id"),
+	sqlf.Sprintf("auto_merge_strategy"),
+	sqlf.Sprintf("continuous_interval_hours"),
+	sqlf.Sprintf("continuous_apply_policy"),
 }
 
 // CreateBatchChange creates the given batch change.
@@ -60,7 +66,7 @@ func (s *Store) CreateBatchChange(ctx context.Context, c *btypes.BatchChange) er
 var createBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateBatchChange
 INSERT INTO batch_changes (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -87,6 +93,9 @@ func (s *Store) createBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		nullStringColumn(string(c.AutoMergeStrategy)),
+		nullInt32Column(c.ContinuousIntervalHours),
+		nullStringColumn(string(c.ContinuousApplyPolicy)),
 		sqlf.Join(batchChangeColumns, ", "),
 	)
 }
@@ -101,7 +110,7 @@ func (s *Store) UpdateBatchChange(ctx context.Context, c *btypes.BatchChange) er
 var updateBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:UpdateBatchChange
 UPDATE batch_changes
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING %s
 `
@@ -123,6 +132,9 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		nullStringColumn(string(c.AutoMergeStrategy)),
+		nullInt32Column(c.ContinuousIntervalHours),
+		nullStringColumn(string(c.ContinuousApplyPolicy)),
 		c.ID,
 		sqlf.Join(batchChangeColumns, ", "),
 	)
@@ -372,6 +384,9 @@ type ListBatchChangesOpts struct {
 	NamespaceOrgID  int32
 
 	RepoID api.RepoID
+
+	OnlyAutoMergeEnabled  bool
+	OnlyContinuousEnabled bool
 }
 
 // ListBatchChanges lists batch changes with the given filters.
@@ -459,6 +474,14 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 		)`, opts.RepoID, repoAuthzConds))
 	}
 
+	if opts.OnlyAutoMergeEnabled {
+		preds = append(preds, sqlf.Sprintf("batch_changes.auto_merge_strategy IS NOT NULL"))
+	}
+
+	if opts.OnlyContinuousEnabled {
+		preds = append(preds, sqlf.Sprintf("batch_changes.continuous_interval_hours IS NOT NULL"))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -485,5 +508,8 @@ func scanBatchChange(c *btypes.BatchChange, s scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&c.BatchSpecID,
+		&dbutil.NullString{S: (*string)(&c.AutoMergeStrategy)},
+		&dbutil.NullInt32{N: &c.ContinuousIntervalHours},
+		&dbutil.NullString{S: (*string)(&c.ContinuousApplyPolicy)},
 	)
 }
@@ -41,6 +41,11 @@ func testStoreBatchChanges(t *testing.T, ctx context.Context, s *Store, clock ct
 				c.ClosedAt = time.Time{}
 			}
 
+			if i == 1 {
+				c.ContinuousIntervalHours = 24
+				c.ContinuousApplyPolicy = btypes.BatchChangeContinuousApplyPolicyCreate
+			}
+
 			if i%2 == 0 {
 				c.NamespaceOrgID = int32(i) + 23
 			} else {
@@ -420,6 +425,16 @@ func testStoreBatchChanges(t *testing.T, ctx context.Context, s *Store, clock ct
 				}
 			}
 		})
+
+		t.Run("ListBatchChanges OnlyContinuousEnabled", func(t *testing.T) {
+			have, _, err := s.ListBatchChanges(ctx, ListBatchChangesOpts{OnlyContinuousEnabled: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, []*btypes.BatchChange{cs[1]}); diff != "" {
+				t.Fatal(diff)
+			}
+		})
 	})
 
 	t.Run("Update", func(t *testing.T) {
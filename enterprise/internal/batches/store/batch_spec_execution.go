@@ -34,6 +34,7 @@ var BatchSpecExecutionColumns = []*sqlf.Query{
 	sqlf.Sprintf(`batch_spec_executions.user_id`),
 	sqlf.Sprintf(`batch_spec_executions.namespace_user_id`),
 	sqlf.Sprintf(`batch_spec_executions.namespace_org_id`),
+	sqlf.Sprintf(`batch_spec_executions.workspaces_resolved_at`),
 }
 
 var batchSpecExecutionInsertColumns = []*sqlf.Query{
@@ -170,6 +171,7 @@ func scanBatchSpecExecution(b *btypes.BatchSpecExecution, sc scanner) error {
 		&b.UserID,
 		&dbutil.NullInt32{N: &b.NamespaceUserID},
 		&dbutil.NullInt32{N: &b.NamespaceOrgID},
+		&b.WorkspacesResolvedAt,
 	); err != nil {
 		return err
 	}
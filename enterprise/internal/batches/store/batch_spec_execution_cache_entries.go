@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+var batchSpecExecutionCacheEntryColumns = SQLColumns{
+	"batch_spec_execution_cache_entries.id",
+	"batch_spec_execution_cache_entries.user_id",
+	"batch_spec_execution_cache_entries.key",
+	"batch_spec_execution_cache_entries.value",
+	"batch_spec_execution_cache_entries.created_at",
+}
+
+// UpsertBatchSpecExecutionCacheEntry creates the given cache entry or, if
+// the user already has an entry with the same key, replaces its value.
+func (s *Store) UpsertBatchSpecExecutionCacheEntry(ctx context.Context, e *btypes.BatchSpecExecutionCacheEntry) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		upsertBatchSpecExecutionCacheEntryQueryFmtstr,
+		e.UserID,
+		e.Key,
+		e.Value,
+		e.CreatedAt,
+		sqlf.Join(batchSpecExecutionCacheEntryColumns.ToSqlf(), ", "),
+	)
+	return s.query(ctx, q, func(sc scanner) error { return scanBatchSpecExecutionCacheEntry(e, sc) })
+}
+
+var upsertBatchSpecExecutionCacheEntryQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_execution_cache_entries.go:UpsertBatchSpecExecutionCacheEntry
+INSERT INTO batch_spec_execution_cache_entries (user_id, key, value, created_at)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT (user_id, key) DO UPDATE SET
+	value = EXCLUDED.value,
+	created_at = EXCLUDED.created_at
+RETURNING %s
+`
+
+// GetBatchSpecExecutionCacheEntryOpts captures the query options needed for
+// getting a BatchSpecExecutionCacheEntry.
+type GetBatchSpecExecutionCacheEntryOpts struct {
+	UserID int32
+	Key    string
+}
+
+// GetBatchSpecExecutionCacheEntry gets the cache entry of the given user with
+// the given key.
+func (s *Store) GetBatchSpecExecutionCacheEntry(ctx context.Context, opts GetBatchSpecExecutionCacheEntryOpts) (*btypes.BatchSpecExecutionCacheEntry, error) {
+	q := sqlf.Sprintf(
+		getBatchSpecExecutionCacheEntryQueryFmtstr,
+		sqlf.Join(batchSpecExecutionCacheEntryColumns.ToSqlf(), ", "),
+		opts.UserID,
+		opts.Key,
+	)
+
+	var e btypes.BatchSpecExecutionCacheEntry
+	err := s.query(ctx, q, func(sc scanner) error { return scanBatchSpecExecutionCacheEntry(&e, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if e.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &e, nil
+}
+
+var getBatchSpecExecutionCacheEntryQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_execution_cache_entries.go:GetBatchSpecExecutionCacheEntry
+SELECT %s FROM batch_spec_execution_cache_entries
+WHERE user_id = %s AND key = %s
+`
+
+func scanBatchSpecExecutionCacheEntry(e *btypes.BatchSpecExecutionCacheEntry, sc scanner) error {
+	return sc.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Key,
+		&e.Value,
+		&e.CreatedAt,
+	)
+}
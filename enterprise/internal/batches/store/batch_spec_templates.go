@@ -0,0 +1,444 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// batchSpecTemplateColumns are used by the batch spec template related Store
+// methods to insert, update and query batch spec templates.
+var batchSpecTemplateColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_templates.id"),
+	sqlf.Sprintf("batch_spec_templates.name"),
+	sqlf.Sprintf("batch_spec_templates.description"),
+	sqlf.Sprintf("batch_spec_templates.namespace_user_id"),
+	sqlf.Sprintf("batch_spec_templates.namespace_org_id"),
+	sqlf.Sprintf("batch_spec_templates.creator_id"),
+	sqlf.Sprintf("batch_spec_templates.latest_version"),
+	sqlf.Sprintf("batch_spec_templates.created_at"),
+	sqlf.Sprintf("batch_spec_templates.updated_at"),
+}
+
+// batchSpecTemplateVersionColumns are used by the batch spec template version
+// related Store methods to insert and query batch spec template versions.
+var batchSpecTemplateVersionColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_template_versions.id"),
+	sqlf.Sprintf("batch_spec_template_versions.batch_spec_template_id"),
+	sqlf.Sprintf("batch_spec_template_versions.version"),
+	sqlf.Sprintf("batch_spec_template_versions.spec"),
+	sqlf.Sprintf("batch_spec_template_versions.parameters"),
+	sqlf.Sprintf("batch_spec_template_versions.creator_id"),
+	sqlf.Sprintf("batch_spec_template_versions.created_at"),
+}
+
+// CreateBatchSpecTemplate creates the given BatchSpecTemplate. The template
+// has no versions until CreateBatchSpecTemplateVersion is called.
+func (s *Store) CreateBatchSpecTemplate(ctx context.Context, t *btypes.BatchSpecTemplate) error {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecTemplateQueryFmtstr,
+		t.Name,
+		t.Description,
+		nullInt32Column(t.NamespaceUserID),
+		nullInt32Column(t.NamespaceOrgID),
+		nullInt32Column(t.CreatorID),
+		t.LatestVersion,
+		t.CreatedAt,
+		t.UpdatedAt,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+	)
+	return s.query(ctx, q, func(sc scanner) error { return scanBatchSpecTemplate(t, sc) })
+}
+
+var createBatchSpecTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:CreateBatchSpecTemplate
+INSERT INTO batch_spec_templates (
+	name,
+	description,
+	namespace_user_id,
+	namespace_org_id,
+	creator_id,
+	latest_version,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateBatchSpecTemplate updates the name and description of the given
+// BatchSpecTemplate.
+func (s *Store) UpdateBatchSpecTemplate(ctx context.Context, t *btypes.BatchSpecTemplate) error {
+	t.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateBatchSpecTemplateQueryFmtstr,
+		t.Name,
+		t.Description,
+		t.UpdatedAt,
+		t.ID,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+	)
+
+	updated := &btypes.BatchSpecTemplate{}
+	if err := s.query(ctx, q, func(sc scanner) error { return scanBatchSpecTemplate(updated, sc) }); err != nil {
+		return err
+	}
+	if updated.ID == 0 {
+		return ErrNoResults
+	}
+	*t = *updated
+	return nil
+}
+
+var updateBatchSpecTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:UpdateBatchSpecTemplate
+UPDATE batch_spec_templates
+SET
+	name = %s,
+	description = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// DeleteBatchSpecTemplate deletes the BatchSpecTemplate with the given ID,
+// together with all of its versions.
+func (s *Store) DeleteBatchSpecTemplate(ctx context.Context, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchSpecTemplateQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchSpecTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:DeleteBatchSpecTemplate
+DELETE FROM batch_spec_templates WHERE id = %s
+`
+
+// GetBatchSpecTemplateOpts captures the query options needed for getting a
+// BatchSpecTemplate. Either the ID or the Name and one of the namespace
+// fields must be set.
+type GetBatchSpecTemplateOpts struct {
+	ID int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	Name            string
+}
+
+// GetBatchSpecTemplate gets a BatchSpecTemplate matching the given options.
+func (s *Store) GetBatchSpecTemplate(ctx context.Context, opts GetBatchSpecTemplateOpts) (*btypes.BatchSpecTemplate, error) {
+	q := getBatchSpecTemplateQuery(&opts)
+
+	var t btypes.BatchSpecTemplate
+	err := s.query(ctx, q, func(sc scanner) error { return scanBatchSpecTemplate(&t, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &t, nil
+}
+
+var getBatchSpecTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:GetBatchSpecTemplate
+SELECT %s FROM batch_spec_templates
+WHERE %s
+LIMIT 1
+`
+
+func getBatchSpecTemplateQuery(opts *GetBatchSpecTemplateOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
+	}
+
+	if opts.NamespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", opts.NamespaceUserID))
+	}
+
+	if opts.NamespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", opts.NamespaceOrgID))
+	}
+
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("name = %s", opts.Name))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		getBatchSpecTemplateQueryFmtstr,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListBatchSpecTemplatesOpts captures the query options needed for listing
+// batch spec templates.
+type ListBatchSpecTemplatesOpts struct {
+	LimitOpts
+	Cursor int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ListBatchSpecTemplates lists BatchSpecTemplates with the given filters.
+func (s *Store) ListBatchSpecTemplates(ctx context.Context, opts ListBatchSpecTemplatesOpts) (ts []*btypes.BatchSpecTemplate, next int64, err error) {
+	q := listBatchSpecTemplatesQuery(&opts)
+
+	ts = make([]*btypes.BatchSpecTemplate, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var t btypes.BatchSpecTemplate
+		if err := scanBatchSpecTemplate(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.DBLimit() {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listBatchSpecTemplatesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:ListBatchSpecTemplates
+SELECT %s FROM batch_spec_templates
+WHERE %s
+ORDER BY id ASC
+`
+
+func listBatchSpecTemplatesQuery(opts *ListBatchSpecTemplatesOpts) *sqlf.Query {
+	preds := batchSpecTemplatesNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	preds = append(preds, sqlf.Sprintf("id >= %s", opts.Cursor))
+
+	return sqlf.Sprintf(
+		listBatchSpecTemplatesQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CountBatchSpecTemplatesOpts captures the query options needed for counting
+// batch spec templates.
+type CountBatchSpecTemplatesOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CountBatchSpecTemplates returns the number of batch spec templates matching
+// the given options.
+func (s *Store) CountBatchSpecTemplates(ctx context.Context, opts CountBatchSpecTemplatesOpts) (int, error) {
+	preds := batchSpecTemplatesNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+	return s.queryCount(ctx, sqlf.Sprintf(countBatchSpecTemplatesQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+}
+
+var countBatchSpecTemplatesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:CountBatchSpecTemplates
+SELECT COUNT(id)
+FROM batch_spec_templates
+WHERE %s
+`
+
+func batchSpecTemplatesNamespacePreds(namespaceUserID, namespaceOrgID int32) []*sqlf.Query {
+	var preds []*sqlf.Query
+	if namespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", namespaceUserID))
+	}
+	if namespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", namespaceOrgID))
+	}
+	return preds
+}
+
+// CreateBatchSpecTemplateVersion creates the given BatchSpecTemplateVersion
+// as the next version of its template and sets the LatestVersion of the
+// template accordingly. The Version of v is set by the database.
+func (s *Store) CreateBatchSpecTemplateVersion(ctx context.Context, v *btypes.BatchSpecTemplateVersion) error {
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = s.now()
+	}
+
+	parameters := v.Parameters
+	if parameters == nil {
+		parameters = []btypes.BatchSpecTemplateParameter{}
+	}
+	params, err := jsonbColumn(parameters)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecTemplateVersionQueryFmtstr,
+		v.CreatedAt,
+		v.TemplateID,
+		v.Spec,
+		params,
+		nullInt32Column(v.CreatorID),
+		v.CreatedAt,
+		sqlf.Join(batchSpecTemplateVersionColumns, ", "),
+	)
+
+	created := &btypes.BatchSpecTemplateVersion{}
+	if err := s.query(ctx, q, func(sc scanner) error { return scanBatchSpecTemplateVersion(created, sc) }); err != nil {
+		return err
+	}
+	if created.ID == 0 {
+		return ErrNoResults
+	}
+	*v = *created
+	return nil
+}
+
+var createBatchSpecTemplateVersionQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:CreateBatchSpecTemplateVersion
+WITH template AS (
+	UPDATE batch_spec_templates
+	SET
+		latest_version = latest_version + 1,
+		updated_at = %s
+	WHERE id = %s
+	RETURNING id, latest_version
+)
+INSERT INTO batch_spec_template_versions (
+	batch_spec_template_id,
+	version,
+	spec,
+	parameters,
+	creator_id,
+	created_at
+)
+SELECT template.id, template.latest_version, %s, %s, %s, %s
+FROM template
+RETURNING %s
+`
+
+// GetBatchSpecTemplateVersion gets the given version of the
+// BatchSpecTemplate with the given ID.
+func (s *Store) GetBatchSpecTemplateVersion(ctx context.Context, templateID int64, version int32) (*btypes.BatchSpecTemplateVersion, error) {
+	q := sqlf.Sprintf(
+		getBatchSpecTemplateVersionQueryFmtstr,
+		sqlf.Join(batchSpecTemplateVersionColumns, ", "),
+		templateID,
+		version,
+	)
+
+	var v btypes.BatchSpecTemplateVersion
+	err := s.query(ctx, q, func(sc scanner) error { return scanBatchSpecTemplateVersion(&v, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if v.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &v, nil
+}
+
+var getBatchSpecTemplateVersionQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:GetBatchSpecTemplateVersion
+SELECT %s FROM batch_spec_template_versions
+WHERE batch_spec_template_id = %s AND version = %s
+LIMIT 1
+`
+
+// ListBatchSpecTemplateVersions lists all versions of the BatchSpecTemplate
+// with the given ID, newest first.
+func (s *Store) ListBatchSpecTemplateVersions(ctx context.Context, templateID int64) (vs []*btypes.BatchSpecTemplateVersion, err error) {
+	q := sqlf.Sprintf(
+		listBatchSpecTemplateVersionsQueryFmtstr,
+		sqlf.Join(batchSpecTemplateVersionColumns, ", "),
+		templateID,
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var v btypes.BatchSpecTemplateVersion
+		if err := scanBatchSpecTemplateVersion(&v, sc); err != nil {
+			return err
+		}
+		vs = append(vs, &v)
+		return nil
+	})
+
+	return vs, err
+}
+
+var listBatchSpecTemplateVersionsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:ListBatchSpecTemplateVersions
+SELECT %s FROM batch_spec_template_versions
+WHERE batch_spec_template_id = %s
+ORDER BY version DESC
+`
+
+func scanBatchSpecTemplate(t *btypes.BatchSpecTemplate, s scanner) error {
+	err := s.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&dbutil.NullInt32{N: &t.NamespaceUserID},
+		&dbutil.NullInt32{N: &t.NamespaceOrgID},
+		&dbutil.NullInt32{N: &t.CreatorID},
+		&t.LatestVersion,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	return errors.Wrap(err, "scanning batch spec template")
+}
+
+func scanBatchSpecTemplateVersion(v *btypes.BatchSpecTemplateVersion, s scanner) error {
+	var parameters json.RawMessage
+
+	err := s.Scan(
+		&v.ID,
+		&v.TemplateID,
+		&v.Version,
+		&v.Spec,
+		&parameters,
+		&dbutil.NullInt32{N: &v.CreatorID},
+		&v.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning batch spec template version")
+	}
+
+	if err := json.Unmarshal(parameters, &v.Parameters); err != nil {
+		return errors.Wrap(err, "scanBatchSpecTemplateVersion: failed to unmarshal parameters")
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchSpecTemplates(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	user := ct.CreateTestUser(t, s.DB(), false)
+	otherUser := ct.CreateTestUser(t, s.DB(), false)
+
+	templates := make([]*btypes.BatchSpecTemplate, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(templates); i++ {
+			tmpl := &btypes.BatchSpecTemplate{
+				Name:            "template-" + string(rune('a'+i)),
+				Description:     "My description",
+				NamespaceUserID: user.ID,
+				CreatorID:       user.ID,
+			}
+			if i == cap(templates)-1 {
+				tmpl.NamespaceUserID = otherUser.ID
+			}
+
+			want := tmpl.Clone()
+			if err := s.CreateBatchSpecTemplate(ctx, tmpl); err != nil {
+				t.Fatal(err)
+			}
+			if tmpl.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = tmpl.ID
+			want.CreatedAt = clock.Now()
+			want.UpdatedAt = clock.Now()
+			if diff := cmp.Diff(tmpl, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			templates = append(templates, tmpl)
+		}
+
+		t.Run("duplicate name in namespace", func(t *testing.T) {
+			tmpl := &btypes.BatchSpecTemplate{Name: templates[0].Name, NamespaceUserID: user.ID}
+			if err := s.CreateBatchSpecTemplate(ctx, tmpl); err == nil {
+				t.Fatal("expected error for duplicate name, got none")
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ByID", func(t *testing.T) {
+			for _, want := range templates {
+				have, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: want.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+
+		t.Run("ByNamespaceAndName", func(t *testing.T) {
+			want := templates[1]
+			have, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{
+				NamespaceUserID: user.ID,
+				Name:            want.Name,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: 0xdeadbeef})
+			if have, want := err, ErrNoResults; have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, next, err := s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{NamespaceUserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != 0 {
+			t.Fatalf("have next %d, want 0", next)
+		}
+		if diff := cmp.Diff(have, templates[:2]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		count, err := s.CountBatchSpecTemplates(ctx, CountBatchSpecTemplatesOpts{NamespaceUserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, 2; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		t.Run("WithLimit", func(t *testing.T) {
+			have, next, err := s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{LimitOpts: LimitOpts{Limit: 1}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, templates[:1]); diff != "" {
+				t.Fatal(diff)
+			}
+			if have, want := next, templates[1].ID; have != want {
+				t.Fatalf("have next %d, want %d", have, want)
+			}
+		})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		tmpl := templates[0]
+		tmpl.Description = "Updated description"
+		clock.Add(1 * time.Second)
+		if err := s.UpdateBatchSpecTemplate(ctx, tmpl); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := tmpl.UpdatedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("have updated at %s, want %s", have, want)
+		}
+
+		have, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: tmpl.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, tmpl); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		tmpl := templates[0]
+
+		versions := make([]*btypes.BatchSpecTemplateVersion, 0, 2)
+		for i := 0; i < cap(versions); i++ {
+			v := &btypes.BatchSpecTemplateVersion{
+				TemplateID: tmpl.ID,
+				Spec:       "name: ${{ params.name }}",
+				Parameters: []btypes.BatchSpecTemplateParameter{
+					{Name: "name", Type: btypes.BatchSpecTemplateParameterTypeString, Required: true},
+				},
+				CreatorID: user.ID,
+			}
+			if err := s.CreateBatchSpecTemplateVersion(ctx, v); err != nil {
+				t.Fatal(err)
+			}
+			if have, want := v.Version, int32(i+1); have != want {
+				t.Fatalf("have version %d, want %d", have, want)
+			}
+			versions = append(versions, v)
+		}
+
+		reloaded, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: tmpl.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.LatestVersion, int32(2); have != want {
+			t.Fatalf("have latest version %d, want %d", have, want)
+		}
+
+		have, err := s.GetBatchSpecTemplateVersion(ctx, tmpl.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, versions[0]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		list, err := s.ListBatchSpecTemplateVersions(ctx, tmpl.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(list, []*btypes.BatchSpecTemplateVersion{versions[1], versions[0]}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if _, err := s.GetBatchSpecTemplateVersion(ctx, tmpl.ID, 3); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+
+		t.Run("missing template", func(t *testing.T) {
+			err := s.CreateBatchSpecTemplateVersion(ctx, &btypes.BatchSpecTemplateVersion{TemplateID: 0xdeadbeef})
+			if err != ErrNoResults {
+				t.Fatalf("have err %v, want %v", err, ErrNoResults)
+			}
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, tmpl := range templates {
+			if err := s.DeleteBatchSpecTemplate(ctx, tmpl.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: tmpl.ID}); err != ErrNoResults {
+				t.Fatalf("have err %v, want %v", err, ErrNoResults)
+			}
+		}
+
+		versions, err := s.ListBatchSpecTemplateVersions(ctx, templates[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(versions) != 0 {
+			t.Fatalf("versions were not deleted: %+v", versions)
+		}
+
+		if err := s.DeleteBatchSpecTemplate(ctx, templates[0].ID); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+}
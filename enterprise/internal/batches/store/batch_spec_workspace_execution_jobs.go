@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// batchSpecWorkspaceExecutionJobInsertColumns is the list of
+// batch_spec_workspace_execution_jobs columns that are set in
+// CreateBatchSpecWorkspaceExecutionJobs.
+var batchSpecWorkspaceExecutionJobInsertColumns = []string{
+	"batch_spec_execution_id",
+	"repository_id",
+	"branch",
+	"commit",
+	"path",
+	"only_fetch_workspace",
+	"file_matches",
+	"cache_key",
+	"created_at",
+	"updated_at",
+}
+
+// BatchSpecWorkspaceExecutionJobColumns are used by the batch spec workspace
+// execution job related Store methods and by the executor queue to query
+// batch spec workspace execution jobs.
+var BatchSpecWorkspaceExecutionJobColumns = SQLColumns{
+	"batch_spec_workspace_execution_jobs.id",
+	"batch_spec_workspace_execution_jobs.batch_spec_execution_id",
+	"batch_spec_workspace_execution_jobs.repository_id",
+	"batch_spec_workspace_execution_jobs.branch",
+	"batch_spec_workspace_execution_jobs.commit",
+	"batch_spec_workspace_execution_jobs.path",
+	"batch_spec_workspace_execution_jobs.only_fetch_workspace",
+	"batch_spec_workspace_execution_jobs.file_matches",
+	"batch_spec_workspace_execution_jobs.cache_key",
+	"batch_spec_workspace_execution_jobs.changeset_spec_rand_ids",
+	"batch_spec_workspace_execution_jobs.state",
+	"batch_spec_workspace_execution_jobs.failure_message",
+	"batch_spec_workspace_execution_jobs.started_at",
+	"batch_spec_workspace_execution_jobs.finished_at",
+	"batch_spec_workspace_execution_jobs.process_after",
+	"batch_spec_workspace_execution_jobs.num_resets",
+	"batch_spec_workspace_execution_jobs.num_failures",
+	"batch_spec_workspace_execution_jobs.execution_logs",
+	"batch_spec_workspace_execution_jobs.worker_hostname",
+	"batch_spec_workspace_execution_jobs.created_at",
+	"batch_spec_workspace_execution_jobs.updated_at",
+}
+
+// CreateBatchSpecWorkspaceExecutionJobs creates the given batch spec
+// workspace execution jobs in the queued state.
+func (s *Store) CreateBatchSpecWorkspaceExecutionJobs(ctx context.Context, jobs ...*btypes.BatchSpecWorkspaceExecutionJob) error {
+	inserter := func(inserter *batch.Inserter) error {
+		for _, j := range jobs {
+			if j.CreatedAt.IsZero() {
+				j.CreatedAt = s.now()
+			}
+
+			if j.UpdatedAt.IsZero() {
+				j.UpdatedAt = j.CreatedAt
+			}
+
+			fileMatches := j.FileMatches
+			if fileMatches == nil {
+				fileMatches = []string{}
+			}
+
+			if err := inserter.Insert(
+				ctx,
+				j.BatchSpecExecutionID,
+				j.RepoID,
+				j.Branch,
+				j.Commit,
+				j.Path,
+				j.OnlyFetchWorkspace,
+				pq.Array(fileMatches),
+				j.CacheKey,
+				j.CreatedAt,
+				j.UpdatedAt,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+	i := -1
+	return batch.WithInserterWithReturn(
+		ctx,
+		s.Handle().DB(),
+		"batch_spec_workspace_execution_jobs",
+		batchSpecWorkspaceExecutionJobInsertColumns,
+		BatchSpecWorkspaceExecutionJobColumns,
+		func(rows *sql.Rows) error {
+			i++
+			return scanBatchSpecWorkspaceExecutionJob(jobs[i], rows)
+		},
+		inserter,
+	)
+}
+
+// GetBatchSpecWorkspaceExecutionJobOpts captures the query options needed for
+// getting a BatchSpecWorkspaceExecutionJob.
+type GetBatchSpecWorkspaceExecutionJobOpts struct {
+	ID int64
+}
+
+// GetBatchSpecWorkspaceExecutionJob gets a BatchSpecWorkspaceExecutionJob
+// matching the given options.
+func (s *Store) GetBatchSpecWorkspaceExecutionJob(ctx context.Context, opts GetBatchSpecWorkspaceExecutionJobOpts) (*btypes.BatchSpecWorkspaceExecutionJob, error) {
+	q, err := getBatchSpecWorkspaceExecutionJobQuery(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var j btypes.BatchSpecWorkspaceExecutionJob
+	err = s.query(ctx, q, func(sc scanner) (err error) {
+		return scanBatchSpecWorkspaceExecutionJob(&j, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if j.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &j, nil
+}
+
+var getBatchSpecWorkspaceExecutionJobQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_execution_jobs.go:GetBatchSpecWorkspaceExecutionJob
+SELECT %s FROM batch_spec_workspace_execution_jobs
+WHERE %s
+LIMIT 1
+`
+
+func getBatchSpecWorkspaceExecutionJobQuery(opts *GetBatchSpecWorkspaceExecutionJobOpts) (*sqlf.Query, error) {
+	if opts.ID == 0 {
+		return nil, errors.New("no predicates given")
+	}
+
+	return sqlf.Sprintf(
+		getBatchSpecWorkspaceExecutionJobQueryFmtstr,
+		sqlf.Join(BatchSpecWorkspaceExecutionJobColumns.ToSqlf(), ", "),
+		sqlf.Sprintf("batch_spec_workspace_execution_jobs.id = %s", opts.ID),
+	), nil
+}
+
+// ListBatchSpecWorkspaceExecutionJobsOpts captures the query options needed
+// for listing batch spec workspace execution jobs.
+type ListBatchSpecWorkspaceExecutionJobsOpts struct {
+	BatchSpecExecutionID int64
+}
+
+// ListBatchSpecWorkspaceExecutionJobs lists the batch spec workspace
+// execution jobs matching the given options, ordered by ID.
+func (s *Store) ListBatchSpecWorkspaceExecutionJobs(ctx context.Context, opts ListBatchSpecWorkspaceExecutionJobsOpts) (jobs []*btypes.BatchSpecWorkspaceExecutionJob, err error) {
+	q := listBatchSpecWorkspaceExecutionJobsQuery(&opts)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var j btypes.BatchSpecWorkspaceExecutionJob
+		if err := scanBatchSpecWorkspaceExecutionJob(&j, sc); err != nil {
+			return err
+		}
+		jobs = append(jobs, &j)
+		return nil
+	})
+
+	return jobs, err
+}
+
+var listBatchSpecWorkspaceExecutionJobsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_execution_jobs.go:ListBatchSpecWorkspaceExecutionJobs
+SELECT %s FROM batch_spec_workspace_execution_jobs
+WHERE %s
+ORDER BY batch_spec_workspace_execution_jobs.id ASC
+`
+
+func listBatchSpecWorkspaceExecutionJobsQuery(opts *ListBatchSpecWorkspaceExecutionJobsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+
+	if opts.BatchSpecExecutionID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspace_execution_jobs.batch_spec_execution_id = %s", opts.BatchSpecExecutionID))
+	}
+
+	return sqlf.Sprintf(
+		listBatchSpecWorkspaceExecutionJobsQueryFmtstr,
+		sqlf.Join(BatchSpecWorkspaceExecutionJobColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanBatchSpecWorkspaceExecutionJob(j *btypes.BatchSpecWorkspaceExecutionJob, sc scanner) error {
+	var executionLogs []dbworkerstore.ExecutionLogEntry
+
+	if err := sc.Scan(
+		&j.ID,
+		&j.BatchSpecExecutionID,
+		&j.RepoID,
+		&j.Branch,
+		&j.Commit,
+		&j.Path,
+		&j.OnlyFetchWorkspace,
+		pq.Array(&j.FileMatches),
+		&j.CacheKey,
+		pq.Array(&j.ChangesetSpecRandIDs),
+		&j.State,
+		&j.FailureMessage,
+		&j.StartedAt,
+		&j.FinishedAt,
+		&j.ProcessAfter,
+		&j.NumResets,
+		&j.NumFailures,
+		pq.Array(&executionLogs),
+		&j.WorkerHostname,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	for _, entry := range executionLogs {
+		j.ExecutionLogs = append(j.ExecutionLogs, workerutil.ExecutionLogEntry(entry))
+	}
+
+	return nil
+}
+
+// ScanFirstBatchSpecWorkspaceExecutionJob scans a slice of batch spec
+// workspace execution jobs from the rows and returns the first.
+func ScanFirstBatchSpecWorkspaceExecutionJob(rows *sql.Rows, err error) (*btypes.BatchSpecWorkspaceExecutionJob, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var jobs []*btypes.BatchSpecWorkspaceExecutionJob
+	err = scanAll(rows, func(sc scanner) error {
+		var j btypes.BatchSpecWorkspaceExecutionJob
+		if err := scanBatchSpecWorkspaceExecutionJob(&j, sc); err != nil {
+			return err
+		}
+		jobs = append(jobs, &j)
+		return nil
+	})
+	if err != nil || len(jobs) == 0 {
+		return nil, false, err
+	}
+	return jobs[0], true, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -60,6 +61,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
+	sqlf.Sprintf("changesets.needs_reexecution"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -96,6 +98,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("needs_reexecution"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -185,6 +188,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		c.NeedsReexecution,
 		nullStringColumn(title),
 	}
 
@@ -226,7 +230,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) error
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -254,6 +258,11 @@ type CountChangesetsOpts struct {
 	TextSearch           []search.TextSearchTerm
 	EnforceAuthz         bool
 	RepoID               api.RepoID
+	// OnlyWithoutAutoMergeDecision filters out changesets for which an
+	// auto-merge decision has been recorded since they were last updated.
+	// Skips because of a closed rollout window don't count as a decision,
+	// because the window can open without the changeset being updated.
+	OnlyWithoutAutoMergeDecision bool
 }
 
 // CountChangesets returns the number of changesets in the database.
@@ -319,6 +328,13 @@ func countChangesetsQuery(opts *CountChangesetsOpts, authzConds *sqlf.Query) *sq
 	if opts.RepoID != 0 {
 		preds = append(preds, sqlf.Sprintf("repo.id = %s", opts.RepoID))
 	}
+	if opts.OnlyWithoutAutoMergeDecision {
+		preds = append(preds, sqlf.Sprintf(
+			withoutAutoMergeDecisionFmtstr,
+			btypes.ChangesetEventKindAutoMerge,
+			"skip:"+string(btypes.ChangesetAutoMergeSkipReasonOutsideRolloutWindow),
+		))
+	}
 
 	join := sqlf.Sprintf("")
 	if len(opts.TextSearch) != 0 {
@@ -513,6 +529,21 @@ type ListChangesetsOpts struct {
 	TextSearch           []search.TextSearchTerm
 	EnforceAuthz         bool
 	RepoID               api.RepoID
+	// OnlyWithoutAutoMergeDecision filters out changesets for which an
+	// auto-merge decision has been recorded since they were last updated.
+	// Skips because of a closed rollout window don't count as a decision,
+	// because the window can open without the changeset being updated.
+	OnlyWithoutAutoMergeDecision bool
+	// OnlyWithDependencies filters out changesets whose current changeset
+	// spec doesn't depend on other changesets.
+	OnlyWithDependencies bool
+	// RepoIDs filters out changesets that aren't in one of the given
+	// repositories.
+	RepoIDs []api.RepoID
+	// OnlyWaitingForConflicts filters out changesets whose current changeset
+	// spec belongs to a batch spec that doesn't wait for conflicting
+	// changesets of other batch changes.
+	OnlyWaitingForConflicts bool
 }
 
 // ListChangesets lists Changesets with the given filters.
@@ -609,13 +640,35 @@ func listChangesetsQuery(opts *ListChangesetsOpts, authzConds *sqlf.Query) *sqlf
 	if opts.RepoID != 0 {
 		preds = append(preds, sqlf.Sprintf("repo.id = %s", opts.RepoID))
 	}
+	if len(opts.RepoIDs) > 0 {
+		ids := make([]*sqlf.Query, 0, len(opts.RepoIDs))
+		for _, id := range opts.RepoIDs {
+			ids = append(ids, sqlf.Sprintf("%d", id))
+		}
+		preds = append(preds, sqlf.Sprintf("repo.id IN (%s)", sqlf.Join(ids, ",")))
+	}
+	if opts.OnlyWithoutAutoMergeDecision {
+		preds = append(preds, sqlf.Sprintf(
+			withoutAutoMergeDecisionFmtstr,
+			btypes.ChangesetEventKindAutoMerge,
+			"skip:"+string(btypes.ChangesetAutoMergeSkipReasonOutsideRolloutWindow),
+		))
+	}
 
 	join := sqlf.Sprintf("")
-	if len(opts.TextSearch) != 0 {
-		// TextSearch predicates require changeset_specs to be joined into the
-		// query as well.
+	if len(opts.TextSearch) != 0 || opts.OnlyWithDependencies || opts.OnlyWaitingForConflicts {
+		// TextSearch, OnlyWithDependencies and OnlyWaitingForConflicts
+		// predicates require changeset_specs to be joined into the query as
+		// well.
 		join = sqlf.Sprintf("LEFT JOIN changeset_specs ON changesets.current_spec_id = changeset_specs.id")
-
+	}
+	if opts.OnlyWithDependencies {
+		preds = append(preds, sqlf.Sprintf("jsonb_array_length(COALESCE(changeset_specs.spec->'dependsOn', '[]'::jsonb)) > 0"))
+	}
+	if opts.OnlyWaitingForConflicts {
+		preds = append(preds, sqlf.Sprintf(waitingForConflictsFmtstr))
+	}
+	if len(opts.TextSearch) != 0 {
 		for _, term := range opts.TextSearch {
 			preds = append(preds, textSearchTermToClause(
 				term,
@@ -635,6 +688,25 @@ func listChangesetsQuery(opts *ListChangesetsOpts, authzConds *sqlf.Query) *sqlf
 	)
 }
 
+const waitingForConflictsFmtstr = `
+EXISTS (
+	SELECT 1 FROM batch_specs
+	WHERE
+		batch_specs.id = changeset_specs.batch_spec_id AND
+		batch_specs.spec->'changesetTemplate'->>'waitForConflictingChangesets' = 'true'
+)
+`
+
+const withoutAutoMergeDecisionFmtstr = `
+NOT EXISTS (
+	SELECT 1 FROM changeset_events
+	WHERE
+		changeset_events.changeset_id = changesets.id AND
+		changeset_events.kind = %s AND
+		changeset_events.key != %s AND
+		changeset_events.updated_at >= changesets.updated_at
+)`
+
 // EnqueueChangeset enqueues the given changeset by resetting all
 // worker-related columns and setting its reconciler_state column to the
 // `resetState` argument but *only if* the `currentState` matches its current
@@ -704,7 +776,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) error
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1055,6 +1127,7 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		&t.NumFailures,
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
+		&t.NeedsReexecution,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1076,6 +1149,8 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		t.Metadata = new(github.PullRequest)
 	case extsvc.TypeBitbucketServer:
 		t.Metadata = new(bitbucketserver.PullRequest)
+	case extsvc.TypeBitbucketCloud:
+		t.Metadata = new(bitbucketcloud.PullRequest)
 	case extsvc.TypeGitLab:
 		t.Metadata = new(gitlab.MergeRequest)
 	default:
@@ -645,6 +645,24 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock ct.C
 			}
 		})
 
+		t.Run("RepoIDs", func(t *testing.T) {
+			have, _, err := s.ListChangesets(ctx, ListChangesetsOpts{RepoIDs: []api.RepoID{repo.ID, gitlabRepo.ID}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, changesets); diff != "" {
+				t.Fatal(diff)
+			}
+
+			have, _, err = s.ListChangesets(ctx, ListChangesetsOpts{RepoIDs: []api.RepoID{gitlabRepo.ID}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 0 {
+				t.Fatalf("have %d changesets. want 0", len(have))
+			}
+		})
+
 		t.Run("Cursor pagination", func(t *testing.T) {
 			var cursor int64
 			for i := 1; i <= len(changesets); i++ {
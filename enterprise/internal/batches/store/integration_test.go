@@ -25,6 +25,8 @@ func TestIntegration(t *testing.T) {
 		t.Run("ListChangesetSyncData", storeTest(db, nil, testStoreListChangesetSyncData))
 		t.Run("ListChangesetsTextSearch", storeTest(db, nil, testStoreListChangesetsTextSearch))
 		t.Run("BatchSpecs", storeTest(db, nil, testStoreBatchSpecs))
+		t.Run("BatchSpecTemplates", storeTest(db, nil, testStoreBatchSpecTemplates))
+		t.Run("BatchChangeContinuousRuns", storeTest(db, nil, testStoreBatchChangeContinuousRuns))
 		t.Run("ChangesetSpecs", storeTest(db, nil, testStoreChangesetSpecs))
 		t.Run("GetRewirerMappingWithArchivedChangesets", storeTest(db, nil, testStoreGetRewirerMappingWithArchivedChangesets))
 		t.Run("ChangesetSpecsCurrentState", storeTest(db, nil, testStoreChangesetSpecsCurrentState))
@@ -438,6 +438,8 @@ func (s *changesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 // SyncChangeset refreshes the metadata of the given changeset and
 // updates them in the database.
 func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset) (err error) {
+	wasConflicting := c.Conflicting()
+
 	repoChangeset := &sources.Changeset{Repo: repo, Changeset: c}
 	if err := source.LoadChangeset(ctx, repoChangeset); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
@@ -475,9 +477,29 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 		return err
 	}
 
+	if !wasConflicting && needsRebase(c) {
+		// The base branch moved and the changeset now conflicts with it. We
+		// let the reconciler try to rebase it.
+		if err := tx.EnqueueChangeset(ctx, c, btypes.ReconcilerStateQueued, btypes.ReconcilerStateCompleted); err != nil {
+			return err
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
 
+// needsRebase returns whether the given changeset is owned by a batch change,
+// conflicts with its base branch and hasn't been flagged as needing
+// re-execution yet.
+func needsRebase(c *btypes.Changeset) bool {
+	return c.OwnedByBatchChangeID != 0 &&
+		c.CurrentSpecID != 0 &&
+		c.HasDiff() &&
+		c.ReconcilerState == btypes.ReconcilerStateCompleted &&
+		!c.NeedsReexecution &&
+		c.Conflicting()
+}
+
 func loadChangesetSource(ctx context.Context, cf *httpcli.Factory, syncStore SyncStore, repo *types.Repo) (sources.ChangesetSource, error) {
 	srcer := sources.NewSourcer(cf)
 	// This is a ChangesetSource authenticated with the external service
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -254,6 +255,40 @@ func TestSyncRegistry(t *testing.T) {
 	}
 }
 
+func TestNeedsRebase(t *testing.T) {
+	conflicting := func() *btypes.Changeset {
+		return &btypes.Changeset{
+			OwnedByBatchChangeID: 1,
+			CurrentSpecID:        2,
+			ExternalState:        btypes.ChangesetExternalStateOpen,
+			ReconcilerState:      btypes.ReconcilerStateCompleted,
+			Metadata:             &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		mutate func(c *btypes.Changeset)
+		want   bool
+	}{
+		"conflicting": {mutate: func(c *btypes.Changeset) {}, want: true},
+		"mergeable": {mutate: func(c *btypes.Changeset) {
+			c.Metadata = &github.PullRequest{Mergeable: github.PullRequestMergeableStateMergeable}
+		}, want: false},
+		"imported":           {mutate: func(c *btypes.Changeset) { c.OwnedByBatchChangeID = 0; c.CurrentSpecID = 0 }, want: false},
+		"closed":             {mutate: func(c *btypes.Changeset) { c.ExternalState = btypes.ChangesetExternalStateClosed }, want: false},
+		"being processed":    {mutate: func(c *btypes.Changeset) { c.ReconcilerState = btypes.ReconcilerStateProcessing }, want: false},
+		"needs re-execution": {mutate: func(c *btypes.Changeset) { c.NeedsReexecution = true }, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := conflicting()
+			tc.mutate(c)
+			if have := needsRebase(c); have != tc.want {
+				t.Fatalf("wrong result. want=%t, have=%t", tc.want, have)
+			}
+		})
+	}
+}
+
 func TestLoadChangesetSource(t *testing.T) {
 	ctx := context.Background()
 	cf := httpcli.NewFactory(
@@ -48,6 +48,8 @@ type TestChangesetOpts struct {
 	IsArchived bool
 	Archive    bool
 
+	NeedsReexecution bool
+
 	Metadata interface{}
 }
 
@@ -96,6 +98,8 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 
 		Closing: opts.Closing,
 
+		NeedsReexecution: opts.NeedsReexecution,
+
 		ReconcilerState: opts.ReconcilerState,
 		NumFailures:     opts.NumFailures,
 		NumResets:       opts.NumResets,
@@ -143,6 +147,7 @@ type ChangesetAssertions struct {
 	ExternalBranch     string
 	DiffStat           *diff.Stat
 	Closing            bool
+	NeedsReexecution   bool
 
 	Title string
 	Body  string
@@ -218,6 +223,10 @@ func AssertChangeset(t *testing.T, c *btypes.Changeset, a ChangesetAssertions) {
 		t.Fatalf("changeset Closing wrong. (-want +got):\n%s", diff)
 	}
 
+	if have, want := c.NeedsReexecution, a.NeedsReexecution; have != want {
+		t.Fatalf("changeset NeedsReexecution wrong. want=%t, have=%t", want, have)
+	}
+
 	toDetach := []int64{}
 	for _, assoc := range c.BatchChanges {
 		if assoc.Detach {
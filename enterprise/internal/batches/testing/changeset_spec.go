@@ -39,6 +39,10 @@ type TestSpecOpts struct {
 
 	BaseRev string
 	BaseRef string
+
+	Reviewers []string
+	Labels    []string
+	Milestone string
 }
 
 var TestChangsetSpecDiffStat = &diff.Stat{Added: 10, Changed: 5, Deleted: 2}
@@ -69,6 +73,10 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 			Title: opts.Title,
 			Body:  opts.Body,
 
+			Reviewers: opts.Reviewers,
+			Labels:    opts.Labels,
+			Milestone: opts.Milestone,
+
 			Commits: []btypes.GitCommitDescription{
 				{
 					Message:     opts.CommitMessage,
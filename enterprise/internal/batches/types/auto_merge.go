@@ -0,0 +1,58 @@
+package types
+
+import "time"
+
+// BatchChangeAutoMergeStrategy defines how the changesets of a batch change
+// are merged when auto-merge is enabled.
+type BatchChangeAutoMergeStrategy string
+
+// BatchChangeAutoMergeStrategy constants.
+const (
+	BatchChangeAutoMergeStrategyMerge  BatchChangeAutoMergeStrategy = "merge"
+	BatchChangeAutoMergeStrategySquash BatchChangeAutoMergeStrategy = "squash"
+)
+
+// Valid returns true if the given BatchChangeAutoMergeStrategy is valid.
+func (s BatchChangeAutoMergeStrategy) Valid() bool {
+	switch s {
+	case BatchChangeAutoMergeStrategyMerge,
+		BatchChangeAutoMergeStrategySquash:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetAutoMergeSkipReason defines why a changeset was not automatically
+// merged.
+type ChangesetAutoMergeSkipReason string
+
+// ChangesetAutoMergeSkipReason constants.
+const (
+	ChangesetAutoMergeSkipReasonChecksNotPassed      ChangesetAutoMergeSkipReason = "checks_not_passed"
+	ChangesetAutoMergeSkipReasonReviewNotApproved    ChangesetAutoMergeSkipReason = "review_not_approved"
+	ChangesetAutoMergeSkipReasonOutsideRolloutWindow ChangesetAutoMergeSkipReason = "outside_rollout_window"
+	ChangesetAutoMergeSkipReasonMergeFailed          ChangesetAutoMergeSkipReason = "merge_failed"
+)
+
+// ChangesetAutoMergeDecision is the metadata of a ChangesetEvent of kind
+// ChangesetEventKindAutoMerge. It records whether a changeset has been merged
+// automatically and, if not, why it was skipped.
+type ChangesetAutoMergeDecision struct {
+	Merged     bool                         `json:"merged"`
+	Strategy   BatchChangeAutoMergeStrategy `json:"strategy"`
+	SkipReason ChangesetAutoMergeSkipReason `json:"skipReason,omitempty"`
+	// Message contains the error returned by the code host if the merge
+	// failed.
+	Message   string    `json:"message,omitempty"`
+	DecidedAt time.Time `json:"decidedAt"`
+}
+
+// Key returns the deduplication key of the ChangesetEvent recording the
+// decision. Repeated decisions for the same reason update the same event.
+func (d *ChangesetAutoMergeDecision) Key() string {
+	if d.Merged {
+		return "merge"
+	}
+	return "skip:" + string(d.SkipReason)
+}
@@ -28,6 +28,20 @@ type BatchChange struct {
 
 	ClosedAt time.Time
 
+	// AutoMergeStrategy is the strategy used to automatically merge the
+	// changesets owned by the batch change once their checks passed and they
+	// have been approved. Empty if auto-merge is disabled.
+	AutoMergeStrategy BatchChangeAutoMergeStrategy
+
+	// ContinuousIntervalHours is the number of hours after which the current
+	// batch spec of the batch change is executed again, to pick up newly
+	// matching repositories and changed workspaces. Zero if continuous
+	// execution is disabled.
+	ContinuousIntervalHours int32
+	// ContinuousApplyPolicy defines which results of continuous executions
+	// are applied automatically. Empty if continuous execution is disabled.
+	ContinuousApplyPolicy BatchChangeContinuousApplyPolicy
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -40,3 +54,14 @@ func (c *BatchChange) Clone() *BatchChange {
 
 // Closed returns true when the ClosedAt timestamp has been set.
 func (c *BatchChange) Closed() bool { return !c.ClosedAt.IsZero() }
+
+// AutoMergeEnabled returns true when an auto-merge strategy has been set.
+func (c *BatchChange) AutoMergeEnabled() bool { return c.AutoMergeStrategy != "" }
+
+// ContinuousEnabled returns true when continuous execution has been enabled.
+func (c *BatchChange) ContinuousEnabled() bool { return c.ContinuousIntervalHours > 0 }
+
+// ContinuousInterval returns the interval between continuous executions.
+func (c *BatchChange) ContinuousInterval() time.Duration {
+	return time.Duration(c.ContinuousIntervalHours) * time.Hour
+}
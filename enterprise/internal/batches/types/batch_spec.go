@@ -85,6 +85,16 @@ type ChangesetTemplate struct {
 	Branch    string                   `json:"branch,omitempty" yaml:"branch,omitempty"`
 	Commit    CommitTemplate           `json:"commit,omitempty" yaml:"commit,omitempty"`
 	Published overridable.BoolOrString `json:"published,omitempty" yaml:"published,omitempty"`
+
+	Reviewers     OverridableStringList `json:"reviewers,omitempty" yaml:"reviewers,omitempty"`
+	TeamReviewers OverridableStringList `json:"teamReviewers,omitempty" yaml:"teamReviewers,omitempty"`
+	Labels        OverridableStringList `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Assignees     OverridableStringList `json:"assignees,omitempty" yaml:"assignees,omitempty"`
+	Milestone     OverridableString     `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+
+	DependsOn OverridableStringList `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+
+	WaitForConflictingChangesets bool `json:"waitForConflictingChangesets,omitempty" yaml:"waitForConflictingChangesets,omitempty"`
 }
 
 type CommitTemplate struct {
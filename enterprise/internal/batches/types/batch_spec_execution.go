@@ -35,6 +35,13 @@ type BatchSpecExecution struct {
 	UserID          int32
 	NamespaceUserID int32
 	NamespaceOrgID  int32
+
+	// WorkspacesResolvedAt is set once the workspaces of the batch spec have
+	// been resolved and a BatchSpecWorkspaceExecutionJob was created for each
+	// of them. From then on the record is processed as the final job that
+	// assembles the batch spec out of the changeset specs created by the
+	// workspace jobs.
+	WorkspacesResolvedAt *time.Time
 }
 
 func (i BatchSpecExecution) RecordID() int {
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// BatchSpecExecutionCacheEntry is the cached result of executing the steps of
+// a batch spec in a workspace. The value is opaque to Sourcegraph: it is
+// produced and consumed by src-cli.
+type BatchSpecExecutionCacheEntry struct {
+	ID     int64
+	UserID int32
+
+	Key   string
+	Value string
+
+	CreatedAt time.Time
+}
+
+// BatchSpecExecutionCacheKey returns the key under which the result of
+// executing the given steps in a workspace is cached. Changing the commit, the
+// workspace, or any of the steps results in a different key.
+func BatchSpecExecutionCacheKey(repoID api.RepoID, commit, path string, onlyFetchWorkspace bool, steps json.RawMessage) (string, error) {
+	raw, err := json.Marshal(struct {
+		RepoID             api.RepoID      `json:"repoID"`
+		Commit             string          `json:"commit"`
+		Path               string          `json:"path"`
+		OnlyFetchWorkspace bool            `json:"onlyFetchWorkspace"`
+		Steps              json.RawMessage `json:"steps"`
+	}{repoID, commit, path, onlyFetchWorkspace, steps})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
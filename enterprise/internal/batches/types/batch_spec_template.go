@@ -0,0 +1,312 @@
+package types
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+)
+
+// BatchSpecTemplate is a reusable batch spec owned by a namespace. Its
+// content is stored in immutable BatchSpecTemplateVersions, a new one of
+// which is created every time the template is updated.
+type BatchSpecTemplate struct {
+	ID int64
+
+	Name        string
+	Description string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	CreatorID int32
+
+	LatestVersion int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a BatchSpecTemplate.
+func (t *BatchSpecTemplate) Clone() *BatchSpecTemplate {
+	tt := *t
+	return &tt
+}
+
+// BatchSpecTemplateVersion is a version of a BatchSpecTemplate. Spec is a
+// batch spec in which the Parameters are referenced as ${{ params.<name> }}.
+type BatchSpecTemplateVersion struct {
+	ID         int64
+	TemplateID int64
+	Version    int32
+
+	Spec       string
+	Parameters []BatchSpecTemplateParameter
+
+	CreatorID int32
+
+	CreatedAt time.Time
+}
+
+// BatchSpecTemplateParameterType is the type of the value of a
+// BatchSpecTemplateParameter.
+type BatchSpecTemplateParameterType string
+
+const (
+	BatchSpecTemplateParameterTypeString    BatchSpecTemplateParameterType = "string"
+	BatchSpecTemplateParameterTypeRepoQuery BatchSpecTemplateParameterType = "repoQuery"
+	BatchSpecTemplateParameterTypeBoolean   BatchSpecTemplateParameterType = "boolean"
+)
+
+// Valid returns true if the given BatchSpecTemplateParameterType is valid.
+func (t BatchSpecTemplateParameterType) Valid() bool {
+	switch t {
+	case BatchSpecTemplateParameterTypeString,
+		BatchSpecTemplateParameterTypeRepoQuery,
+		BatchSpecTemplateParameterTypeBoolean:
+		return true
+	default:
+		return false
+	}
+}
+
+// BatchSpecTemplateParameter is a typed input parameter of a batch spec
+// template.
+type BatchSpecTemplateParameter struct {
+	Name        string                         `json:"name"`
+	Type        BatchSpecTemplateParameterType `json:"type"`
+	Description string                         `json:"description,omitempty"`
+	Required    bool                           `json:"required,omitempty"`
+	// Default is the value used when none is given when the template is
+	// applied. It is a string or a bool, depending on Type.
+	Default interface{} `json:"default,omitempty"`
+}
+
+var (
+	batchSpecTemplateParameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// batchSpecTemplatePlaceholderPattern matches the references to the
+	// parameters in the spec of a template. Other ${{ }} expressions are
+	// left untouched, since they are evaluated by src-cli when the steps
+	// are executed.
+	batchSpecTemplatePlaceholderPattern = regexp.MustCompile(`\$\{\{\s*params\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+// Validate checks that the parameters of the version are well-formed, that
+// the spec only references declared parameters, and that the spec renders to
+// a valid batch spec.
+func (v *BatchSpecTemplateVersion) Validate() error {
+	var errs *multierror.Error
+
+	declared := make(map[string]struct{}, len(v.Parameters))
+	sample := make(map[string]interface{}, len(v.Parameters))
+	for _, p := range v.Parameters {
+		if !batchSpecTemplateParameterNamePattern.MatchString(p.Name) {
+			errs = multierror.Append(errs, errors.Errorf("invalid parameter name %q", p.Name))
+			continue
+		}
+		if _, ok := declared[p.Name]; ok {
+			errs = multierror.Append(errs, errors.Errorf("parameter %q is declared more than once", p.Name))
+			continue
+		}
+		declared[p.Name] = struct{}{}
+
+		if !p.Type.Valid() {
+			errs = multierror.Append(errs, errors.Errorf("parameter %q has invalid type %q", p.Name, p.Type))
+			continue
+		}
+		if p.Default != nil {
+			if _, err := p.normalize(p.Default); err != nil {
+				errs = multierror.Append(errs, errors.Wrap(err, "invalid default"))
+				continue
+			}
+		}
+		sample[p.Name] = p.sampleValue()
+	}
+
+	undeclared := make(map[string]struct{})
+	for _, m := range batchSpecTemplatePlaceholderPattern.FindAllStringSubmatch(v.Spec, -1) {
+		_, ok := declared[m[1]]
+		_, reported := undeclared[m[1]]
+		if !ok && !reported {
+			errs = multierror.Append(errs, errors.Errorf("spec references undeclared parameter %q", m[1]))
+			undeclared[m[1]] = struct{}{}
+		}
+	}
+
+	if errs.ErrorOrNil() != nil {
+		return errs
+	}
+
+	if _, err := v.Render(sample); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Render substitutes the given values for the parameters in the spec of the
+// version and returns the resulting batch spec, after validating it.
+//
+// The values are substituted into the parsed spec rather than into its text,
+// and the spec is encoded again, so that a value can't change the structure of
+// the spec. A string that only consists of a reference to a parameter is
+// replaced with the value itself, e.g. a boolean, while references within a
+// longer string are replaced with the string representation of the value.
+//
+// Parameters for which no value is given use their default value. It's an
+// error to omit a required parameter without a default, and to pass values
+// for parameters that aren't declared.
+func (v *BatchSpecTemplateVersion) Render(values map[string]interface{}) (string, error) {
+	var errs *multierror.Error
+
+	params := make(map[string]BatchSpecTemplateParameter, len(v.Parameters))
+	for _, p := range v.Parameters {
+		params[p.Name] = p
+	}
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			errs = multierror.Append(errs, errors.Errorf("unknown parameter %q", name))
+		}
+	}
+
+	normalized := make(map[string]interface{}, len(v.Parameters))
+	for _, p := range v.Parameters {
+		value, ok := values[p.Name]
+		if !ok || value == nil {
+			value = p.Default
+		}
+		if value == nil {
+			if p.Required {
+				errs = multierror.Append(errs, errors.Errorf("missing value for required parameter %q", p.Name))
+				continue
+			}
+			value = p.zeroValue()
+		}
+
+		normalizedValue, err := p.normalize(value)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		normalized[p.Name] = normalizedValue
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return "", err
+	}
+
+	var spec yaml.Node
+	if err := yaml.Unmarshal([]byte(v.Spec), &spec); err != nil {
+		return "", errors.Wrap(err, "parsing spec")
+	}
+	substituteBatchSpecTemplateParameters(&spec, normalized)
+	out, err := yaml.Marshal(&spec)
+	if err != nil {
+		return "", errors.Wrap(err, "encoding rendered batch spec")
+	}
+	rendered := string(out)
+
+	if _, err := NewBatchSpecFromRaw(rendered); err != nil {
+		return "", errors.Wrap(err, "rendered batch spec is invalid")
+	}
+	return rendered, nil
+}
+
+// substituteBatchSpecTemplateParameters replaces the references to the
+// parameters in the string values of the given node of a parsed spec with the
+// given values. The encoder quotes the resulting strings as needed.
+func substituteBatchSpecTemplateParameters(node *yaml.Node, values map[string]interface{}) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			substituteBatchSpecTemplateParameters(n, values)
+		}
+
+	case yaml.MappingNode:
+		// Only the values are substituted, the keys are the fields of the spec.
+		for i := 1; i < len(node.Content); i += 2 {
+			substituteBatchSpecTemplateParameters(node.Content[i], values)
+		}
+
+	case yaml.ScalarNode:
+		if node.ShortTag() != "!!str" {
+			return
+		}
+
+		if m := batchSpecTemplatePlaceholderPattern.FindStringSubmatchIndex(node.Value); m != nil && m[0] == 0 && m[1] == len(node.Value) {
+			if b, ok := values[node.Value[m[2]:m[3]]].(bool); ok {
+				node.SetString(strconv.FormatBool(b))
+				node.Tag = "!!bool"
+				return
+			}
+		}
+
+		node.Value = batchSpecTemplatePlaceholderPattern.ReplaceAllStringFunc(node.Value, func(m string) string {
+			switch value := values[batchSpecTemplatePlaceholderPattern.FindStringSubmatch(m)[1]].(type) {
+			case string:
+				return value
+			case bool:
+				return strconv.FormatBool(value)
+			default:
+				return m
+			}
+		})
+	}
+}
+
+// normalize checks that the value has the type of the parameter and returns it
+// as a string or a bool, depending on the type.
+func (p BatchSpecTemplateParameter) normalize(value interface{}) (interface{}, error) {
+	switch p.Type {
+	case BatchSpecTemplateParameterTypeString, BatchSpecTemplateParameterTypeRepoQuery:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("parameter %q must be a string, got %T", p.Name, value)
+		}
+		if strings.ContainsAny(s, "\r\n") {
+			return nil, errors.Errorf("parameter %q must not contain line breaks", p.Name)
+		}
+		return s, nil
+
+	case BatchSpecTemplateParameterTypeBoolean:
+		switch b := value.(type) {
+		case bool:
+			return b, nil
+		case string:
+			if b == "true" || b == "false" {
+				return b == "true", nil
+			}
+		}
+		return nil, errors.Errorf("parameter %q must be a boolean, got %v", p.Name, value)
+
+	default:
+		return nil, errors.Errorf("parameter %q has invalid type %q", p.Name, p.Type)
+	}
+}
+
+func (p BatchSpecTemplateParameter) zeroValue() interface{} {
+	if p.Type == BatchSpecTemplateParameterTypeBoolean {
+		return false
+	}
+	return ""
+}
+
+// sampleValue returns the value used to check that the spec of a template
+// renders to a valid batch spec.
+func (p BatchSpecTemplateParameter) sampleValue() interface{} {
+	if p.Default != nil {
+		return p.Default
+	}
+	switch p.Type {
+	case BatchSpecTemplateParameterTypeBoolean:
+		return false
+	case BatchSpecTemplateParameterTypeRepoQuery:
+		return "repo:sample"
+	default:
+		return "sample"
+	}
+}
@@ -0,0 +1,191 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+const testTemplateSpec = `name: upgrade-${{ params.package }}
+description: Upgrade ${{ params.package }} to ${{ params.version }}
+on:
+  - repositoriesMatchingQuery: ${{ params.repos }}
+steps:
+  - run: npm install ${{ params.package }}@${{ params.version }} && echo ${{ repository.name }}
+    container: node:14
+changesetTemplate:
+  title: Upgrade ${{ params.package }}
+  body: Upgrades ${{ params.package }} to ${{ params.version }}
+  branch: upgrade-${{ params.package }}
+  commit:
+    message: Upgrade ${{ params.package }}
+  published: ${{ params.publish }}
+`
+
+var testTemplateParameters = []BatchSpecTemplateParameter{
+	{Name: "package", Type: BatchSpecTemplateParameterTypeString, Required: true},
+	{Name: "version", Type: BatchSpecTemplateParameterTypeString, Default: "latest"},
+	{Name: "repos", Type: BatchSpecTemplateParameterTypeRepoQuery, Required: true},
+	{Name: "publish", Type: BatchSpecTemplateParameterTypeBoolean},
+}
+
+func TestBatchSpecTemplateVersionValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []BatchSpecTemplateParameter
+		spec   string
+		err    string
+	}{
+		{
+			name:   "valid",
+			params: testTemplateParameters,
+			spec:   testTemplateSpec,
+		},
+		{
+			name:   "undeclared parameter",
+			params: testTemplateParameters[:3],
+			spec:   testTemplateSpec,
+			err:    `spec references undeclared parameter "publish"`,
+		},
+		{
+			name: "invalid parameters",
+			params: append([]BatchSpecTemplateParameter{
+				{Name: "not-valid", Type: BatchSpecTemplateParameterTypeString},
+				{Name: "package", Type: BatchSpecTemplateParameterTypeString},
+				{Name: "other", Type: "number"},
+				{Name: "flag", Type: BatchSpecTemplateParameterTypeBoolean, Default: "yes"},
+			}, testTemplateParameters...),
+			spec: testTemplateSpec,
+			err: `invalid parameter name "not-valid"` +
+				`|parameter "other" has invalid type "number"` +
+				`|invalid default: parameter "flag" must be a boolean, got yes` +
+				`|parameter "package" is declared more than once`,
+		},
+		{
+			name:   "invalid batch spec",
+			params: testTemplateParameters,
+			spec:   strings.Replace(testTemplateSpec, "name: ", "title: ", 1),
+			err:    "rendered batch spec is invalid",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &BatchSpecTemplateVersion{Spec: tc.spec, Parameters: tc.params}
+			err := v.Validate()
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+			for _, want := range strings.Split(tc.err, "|") {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchSpecTemplateVersionRender(t *testing.T) {
+	v := &BatchSpecTemplateVersion{Spec: testTemplateSpec, Parameters: testTemplateParameters}
+
+	t.Run("substitutes values and defaults", func(t *testing.T) {
+		rendered, err := v.Render(map[string]interface{}{
+			"package": "lodash",
+			"repos":   "repo:github.com/sourcegraph/ file:package.json",
+			"publish": true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec, err := NewBatchSpecFromRaw(rendered)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := spec.Spec.Name, "upgrade-lodash"; have != want {
+			t.Errorf("wrong name. want=%q, have=%q", want, have)
+		}
+		if have, want := spec.Spec.Description, "Upgrade lodash to latest"; have != want {
+			t.Errorf("wrong description. want=%q, have=%q", want, have)
+		}
+		if have, want := spec.Spec.On[0].RepositoriesMatchingQuery, "repo:github.com/sourcegraph/ file:package.json"; have != want {
+			t.Errorf("wrong query. want=%q, have=%q", want, have)
+		}
+		// Step templates are evaluated by src-cli and must be left untouched.
+		if !strings.Contains(rendered, "${{ repository.name }}") {
+			t.Errorf("step template variable was replaced:\n%s", rendered)
+		}
+		if !strings.Contains(rendered, "published: true") {
+			t.Errorf("boolean parameter not substituted:\n%s", rendered)
+		}
+	})
+
+	t.Run("encodes values as scalars", func(t *testing.T) {
+		rendered, err := v.Render(map[string]interface{}{
+			"package": "lodash",
+			"version": `1.0.0 # "x": [steps]`,
+			"repos":   `"repo:a" file:b`,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec, err := NewBatchSpecFromRaw(rendered)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := spec.Spec.Description, `Upgrade lodash to 1.0.0 # "x": [steps]`; have != want {
+			t.Errorf("wrong description. want=%q, have=%q", want, have)
+		}
+		if have, want := spec.Spec.On[0].RepositoriesMatchingQuery, `"repo:a" file:b`; have != want {
+			t.Errorf("wrong query. want=%q, have=%q", want, have)
+		}
+		if have, want := len(spec.Spec.Steps), 1; have != want {
+			t.Errorf("wrong number of steps. want=%d, have=%d", want, have)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		err    string
+	}{
+		{
+			name:   "missing required parameter",
+			values: map[string]interface{}{"package": "lodash"},
+			err:    `missing value for required parameter "repos"`,
+		},
+		{
+			name:   "unknown parameter",
+			values: map[string]interface{}{"package": "lodash", "repos": "r", "foo": "bar"},
+			err:    `unknown parameter "foo"`,
+		},
+		{
+			name:   "wrong type",
+			values: map[string]interface{}{"package": true, "repos": "r", "publish": "maybe"},
+			err:    `parameter "package" must be a string, got bool`,
+		},
+		{
+			name:   "line breaks",
+			values: map[string]interface{}{"package": "lodash\nsteps: []", "repos": "r"},
+			err:    `parameter "package" must not contain line breaks`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.Render(tc.values)
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("error %q does not contain %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+type BatchSpecWorkspaceExecutionJobState string
+
+const (
+	BatchSpecWorkspaceExecutionJobStateQueued     BatchSpecWorkspaceExecutionJobState = "queued"
+	BatchSpecWorkspaceExecutionJobStateErrored    BatchSpecWorkspaceExecutionJobState = "errored"
+	BatchSpecWorkspaceExecutionJobStateFailed     BatchSpecWorkspaceExecutionJobState = "failed"
+	BatchSpecWorkspaceExecutionJobStateCompleted  BatchSpecWorkspaceExecutionJobState = "completed"
+	BatchSpecWorkspaceExecutionJobStateProcessing BatchSpecWorkspaceExecutionJobState = "processing"
+)
+
+// BatchSpecWorkspaceExecutionJob is the executor job that runs the steps of a
+// BatchSpecExecution in a single workspace of a repository.
+type BatchSpecWorkspaceExecutionJob struct {
+	ID                   int64
+	BatchSpecExecutionID int64
+
+	RepoID             api.RepoID
+	Branch             string
+	Commit             string
+	Path               string
+	OnlyFetchWorkspace bool
+	FileMatches        []string
+
+	// CacheKey is the key under which the result of executing the steps in
+	// the workspace is cached. See BatchSpecExecutionCacheKey.
+	CacheKey string
+
+	// ChangesetSpecRandIDs are the random IDs of the changeset specs that
+	// were created by the job.
+	ChangesetSpecRandIDs []string
+
+	State          BatchSpecWorkspaceExecutionJobState
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int64
+	NumFailures    int64
+	ExecutionLogs  []workerutil.ExecutionLogEntry
+	WorkerHostname string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (j *BatchSpecWorkspaceExecutionJob) RecordID() int {
+	return int(j.ID)
+}
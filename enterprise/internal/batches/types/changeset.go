@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -284,6 +285,11 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// NeedsReexecution is set to true by the reconciler when the changeset
+	// conflicts with its base branch and the diff of its current spec no
+	// longer applies on top of it. It's reset once a new commit is pushed.
+	NeedsReexecution bool
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -373,6 +379,12 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeBitbucketServer
 		c.ExternalBranch = git.EnsureRefPrefix(pr.FromRef.ID)
 		c.ExternalUpdatedAt = unixMilliToTime(int64(pr.UpdatedDate))
+	case *bitbucketcloud.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.ID, 10)
+		c.ExternalServiceType = extsvc.TypeBitbucketCloud
+		c.ExternalBranch = git.EnsureRefPrefix(pr.Source.Branch.Name)
+		c.ExternalUpdatedAt = pr.UpdatedOn
 	case *gitlab.MergeRequest:
 		c.Metadata = pr
 		c.ExternalID = strconv.FormatInt(int64(pr.IID), 10)
@@ -402,6 +414,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bitbucketserver.PullRequest:
 		return m.Title, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
 	default:
@@ -419,6 +433,8 @@ func (c *Changeset) AuthorName() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.Name, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Author.Nickname, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Username, nil
 	default:
@@ -442,6 +458,9 @@ func (c *Changeset) AuthorEmail() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.EmailAddress, nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud doesn't expose the email addresses of other users.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.Author.Email, nil
 	default:
@@ -458,6 +477,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt
 	case *bitbucketserver.PullRequest:
 		return unixMilliToTime(int64(m.CreatedDate))
+	case *bitbucketcloud.PullRequest:
+		return m.CreatedOn
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
 	default:
@@ -472,6 +493,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Body, nil
 	case *bitbucketserver.PullRequest:
 		return m.Description, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
 	default:
@@ -509,6 +532,8 @@ func (c *Changeset) URL() (s string, err error) {
 		}
 		selfLink := m.Links.Self[0]
 		return selfLink.Href, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Links.HTML.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
 	default:
@@ -600,6 +625,39 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 			}
 		}
 
+	case *bitbucketcloud.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Participants)+len(m.Statuses))
+
+		addEvent := func(e Keyer) error {
+			kind, err := ChangesetEventKindFor(e)
+			if err != nil {
+				return err
+			}
+
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        kind,
+				Metadata:    e,
+			})
+			return nil
+		}
+		for i := range m.Participants {
+			// Participants that haven't reviewed the pull request don't
+			// result in an event.
+			if m.Participants[i].State == "" {
+				continue
+			}
+			if err = addEvent(&m.Participants[i]); err != nil {
+				return
+			}
+		}
+		for _, s := range m.Statuses {
+			if err = addEvent(s); err != nil {
+				return
+			}
+		}
+
 	case *gitlab.MergeRequest:
 		events = make([]*ChangesetEvent, 0, len(m.Notes)+len(m.ResourceStateEvents)+len(m.Pipelines))
 		var kind ChangesetEventKind
@@ -656,6 +714,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.HeadRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		return m.Source.Commit.Hash, nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
 	default:
@@ -671,6 +731,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.HeadRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.FromRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Source.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
 	default:
@@ -687,6 +749,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.BaseRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		return m.Destination.Commit.Hash, nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
 	default:
@@ -702,6 +766,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.BaseRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.ToRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Destination.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
 	default:
@@ -709,6 +775,20 @@ func (c *Changeset) BaseRef() (string, error) {
 	}
 }
 
+// Conflicting returns true if the code host reports that the changeset can't
+// be merged into its base branch because of merge conflicts. Code hosts that
+// don't report this are never considered conflicting.
+func (c *Changeset) Conflicting() bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.Mergeable == github.PullRequestMergeableStateConflicting
+	case *gitlab.MergeRequest:
+		return m.HasConflicts
+	default:
+		return false
+	}
+}
+
 // AttachedTo returns true if the changeset is currently attached to the batch
 // change with the given batchChangeID.
 func (c *Changeset) AttachedTo(batchChangeID int64) bool {
@@ -964,6 +1044,17 @@ func ChangesetEventKindFor(e interface{}) (ChangesetEventKind, error) {
 		return ChangesetEventKind("bitbucketserver:participant_status:" + strings.ToLower(string(e.Action))), nil
 	case *bitbucketserver.CommitStatus:
 		return ChangesetEventKindBitbucketServerCommitStatus, nil
+	case *bitbucketcloud.Participant:
+		switch e.State {
+		case bitbucketcloud.ParticipantStateApproved:
+			return ChangesetEventKindBitbucketCloudApproved, nil
+		case bitbucketcloud.ParticipantStateChangesRequested:
+			return ChangesetEventKindBitbucketCloudChangesRequested, nil
+		default:
+			return ChangesetEventKindBitbucketCloudReviewDismissed, nil
+		}
+	case *bitbucketcloud.CommitStatus:
+		return ChangesetEventKindBitbucketCloudCommitStatus, nil
 	case *gitlab.Pipeline:
 		return ChangesetEventKindGitLabPipeline, nil
 	case *gitlab.ReviewApprovedEvent:
@@ -981,6 +1072,9 @@ func ChangesetEventKindFor(e interface{}) (ChangesetEventKind, error) {
 		return ChangesetEventKindGitLabReopened, nil
 	case *gitlab.MergeRequestMergedEvent:
 		return ChangesetEventKindGitLabMerged, nil
+
+	case *ChangesetAutoMergeDecision:
+		return ChangesetEventKindAutoMerge, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -999,6 +1093,13 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		default:
 			return new(bitbucketserver.Activity), nil
 		}
+	case strings.HasPrefix(string(k), "bitbucketcloud"):
+		switch k {
+		case ChangesetEventKindBitbucketCloudCommitStatus:
+			return new(bitbucketcloud.CommitStatus), nil
+		default:
+			return new(bitbucketcloud.Participant), nil
+		}
 	case strings.HasPrefix(string(k), "github"):
 		switch k {
 		case ChangesetEventKindGitHubAssigned:
@@ -1061,6 +1162,8 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		case ChangesetEventKindGitLabReopened:
 			return new(gitlab.MergeRequestReopenedEvent), nil
 		}
+	case k == ChangesetEventKindAutoMerge:
+		return new(ChangesetAutoMergeDecision), nil
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
@@ -0,0 +1,135 @@
+package types
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sourcegraph/go-diff/diff"
+)
+
+// ChangesetConflict is an open changeset of another batch change in the same
+// repository that modifies files a changeset spec modifies as well.
+type ChangesetConflict struct {
+	// Changeset is the changeset of the other batch change.
+	Changeset *Changeset
+	// Paths are the paths of the files both diffs modify, sorted.
+	Paths []string
+	// HunksOverlap is true if both diffs modify the same lines of at least
+	// one of the files, which means that merging one of the changesets will
+	// make the other one conflict with its base branch.
+	HunksOverlap bool
+}
+
+// ChangesetSpecsConflict returns the conflict between the diffs of the two
+// changeset specs, or nil if they don't modify the same files. The specs are
+// expected to target the same repository and base branch.
+func ChangesetSpecsConflict(a, b *ChangesetSpec) (*ChangesetConflict, error) {
+	if a.Spec.IsImportingExisting() || b.Spec.IsImportingExisting() {
+		return nil, nil
+	}
+
+	diffA, err := a.Spec.Diff()
+	if err != nil {
+		return nil, err
+	}
+	diffB, err := b.Spec.Diff()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, hunksOverlap, err := diffOverlap(diffA, diffB)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return &ChangesetConflict{Paths: paths, HunksOverlap: hunksOverlap}, nil
+}
+
+// diffOverlap returns the paths of the files both unified diffs modify, and
+// whether any of their hunks modify the same lines of the original file.
+func diffOverlap(a, b string) (paths []string, hunksOverlap bool, err error) {
+	filesA, err := diff.ParseMultiFileDiff([]byte(a))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "parsing diff")
+	}
+	filesB, err := diff.ParseMultiFileDiff([]byte(b))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "parsing diff")
+	}
+
+	byPath := make(map[string]*diff.FileDiff, len(filesA))
+	for _, f := range filesA {
+		for _, p := range fileDiffPaths(f) {
+			byPath[p] = f
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, fb := range filesB {
+		for _, p := range fileDiffPaths(fb) {
+			fa, ok := byPath[p]
+			if !ok {
+				continue
+			}
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				paths = append(paths, p)
+			}
+			if !hunksOverlap && hunksOverlapIn(fa, fb) {
+				hunksOverlap = true
+			}
+		}
+	}
+
+	sort.Strings(paths)
+	return paths, hunksOverlap, nil
+}
+
+// fileDiffPaths returns the paths of the file before and after the change,
+// without the a/ and b/ prefixes of git diffs. Added and deleted files only
+// have one path.
+func fileDiffPaths(f *diff.FileDiff) []string {
+	var paths []string
+	for _, name := range []string{f.OrigName, f.NewName} {
+		if name == "" || name == "/dev/null" {
+			continue
+		}
+		if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+			name = name[2:]
+		}
+		if len(paths) == 1 && paths[0] == name {
+			continue
+		}
+		paths = append(paths, name)
+	}
+	return paths
+}
+
+// hunksOverlapIn returns whether any hunk of a modifies lines of the
+// original file that a hunk of b modifies, too. Hunks only adding lines
+// cover the line they're inserted at.
+func hunksOverlapIn(a, b *diff.FileDiff) bool {
+	// Diffs without hunks, such as mode changes and binary files, change
+	// the whole file.
+	if len(a.Hunks) == 0 || len(b.Hunks) == 0 {
+		return true
+	}
+
+	for _, ha := range a.Hunks {
+		startA, endA := hunkOrigRange(ha)
+		for _, hb := range b.Hunks {
+			startB, endB := hunkOrigRange(hb)
+			if startA <= endB && startB <= endA {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hunkOrigRange(h *diff.Hunk) (start, end int32) {
+	if h.OrigLines == 0 {
+		return h.OrigStartLine, h.OrigStartLine
+	}
+	return h.OrigStartLine, h.OrigStartLine + h.OrigLines - 1
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChangesetSpecsConflict(t *testing.T) {
+	spec := func(diff string) *ChangesetSpec {
+		return &ChangesetSpec{Spec: &ChangesetSpecDescription{
+			Commits: []GitCommitDescription{{Diff: diff}},
+		}}
+	}
+
+	const readmeTop = `diff --git a/README.md b/README.md
+index 671e50a..851b23a 100644
+--- a/README.md
++++ b/README.md
+@@ -1,3 +1,3 @@
+-# Title
++# New title
+ line 2
+ line 3
+`
+	const readmeBottom = `diff --git a/README.md b/README.md
+index 671e50a..851b23a 100644
+--- a/README.md
++++ b/README.md
+@@ -40,3 +40,4 @@
+ line 40
+ line 41
+ line 42
++line 43
+`
+	const readmeTopAndMain = `diff --git a/README.md b/README.md
+index 671e50a..851b23a 100644
+--- a/README.md
++++ b/README.md
+@@ -2,2 +2,2 @@
+-line 2
++new line 2
+ line 3
+diff --git a/main.go b/main.go
+index 671e50a..851b23a 100644
+--- a/main.go
++++ b/main.go
+@@ -1,1 +1,1 @@
+-package main
++package main // changed
+`
+	const mainRenamed = `diff --git a/main.go b/cmd/main.go
+similarity index 100%
+rename from main.go
+rename to cmd/main.go
+`
+	const newFile = `diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000..851b23a
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,1 @@
++package new
+`
+
+	tests := map[string]struct {
+		a, b *ChangesetSpec
+		want *ChangesetConflict
+	}{
+		"different files": {
+			a:    spec(readmeTop),
+			b:    spec(newFile),
+			want: nil,
+		},
+		"same file, different lines": {
+			a:    spec(readmeTop),
+			b:    spec(readmeBottom),
+			want: &ChangesetConflict{Paths: []string{"README.md"}},
+		},
+		"same lines": {
+			a:    spec(readmeTop),
+			b:    spec(readmeTopAndMain),
+			want: &ChangesetConflict{Paths: []string{"README.md"}, HunksOverlap: true},
+		},
+		"renamed file": {
+			a:    spec(readmeTopAndMain),
+			b:    spec(mainRenamed),
+			want: &ChangesetConflict{Paths: []string{"main.go"}, HunksOverlap: true},
+		},
+		"importing": {
+			a:    spec(readmeTop),
+			b:    &ChangesetSpec{Spec: &ChangesetSpecDescription{ExternalID: "123"}},
+			want: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := ChangesetSpecsConflict(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, tc.want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// ChangesetSpecDependency references the changesets of a batch change that a
+// changeset depends on. At least one of the fields is set.
+type ChangesetSpecDependency struct {
+	// Repository is the name of the repository of the prerequisite
+	// changesets. If empty, it is the repository of the dependent changeset.
+	Repository string `json:"repository,omitempty"`
+	// Branch is the head branch of the prerequisite changeset. If empty, all
+	// changesets in the repository are prerequisites.
+	Branch string `json:"branch,omitempty"`
+}
+
+func (d ChangesetSpecDependency) String() string {
+	if d.Branch == "" {
+		return d.Repository
+	}
+	return d.Repository + "@" + d.Branch
+}
+
+// ChangesetDependencyNode is a changeset spec of a batch change, together
+// with the changeset it is applied to, if that exists already.
+type ChangesetDependencyNode struct {
+	ChangesetSpec *ChangesetSpec
+	Changeset     *Changeset
+	RepoName      api.RepoName
+}
+
+// Merged returns whether the changeset of the node has been merged.
+func (n *ChangesetDependencyNode) Merged() bool {
+	return n.Changeset != nil && n.Changeset.ExternalState == ChangesetExternalStateMerged
+}
+
+// satisfies returns whether the node is one of the changesets the given
+// dependency of the dependent node references.
+func (n *ChangesetDependencyNode) satisfies(dependent *ChangesetDependencyNode, d ChangesetSpecDependency) bool {
+	if n == dependent {
+		return false
+	}
+
+	repo := dependent.RepoName
+	if d.Repository != "" {
+		repo = api.RepoName(d.Repository)
+	}
+	if n.RepoName != repo {
+		return false
+	}
+
+	return d.Branch == "" || git.EnsureRefPrefix(d.Branch) == git.EnsureRefPrefix(n.ChangesetSpec.Spec.HeadRef)
+}
+
+// ResolvedChangesetDependency is a dependency of a changeset spec together
+// with the nodes it references.
+type ResolvedChangesetDependency struct {
+	Dependency ChangesetSpecDependency
+	Nodes      []*ChangesetDependencyNode
+}
+
+// Merged returns whether all changesets referenced by the dependency have
+// been merged. Dependencies that don't reference any changeset are considered
+// merged, so that a typo in a batch spec doesn't hold back a changeset
+// forever.
+func (d ResolvedChangesetDependency) Merged() bool {
+	for _, n := range d.Nodes {
+		if !n.Merged() {
+			return false
+		}
+	}
+	return true
+}
+
+// ChangesetDependencyGraph resolves the dependencies declared by the
+// changeset specs of a batch change.
+type ChangesetDependencyGraph struct {
+	Nodes []*ChangesetDependencyNode
+}
+
+// Node returns the node of the changeset spec with the given ID, or nil.
+func (g *ChangesetDependencyGraph) Node(changesetSpecID int64) *ChangesetDependencyNode {
+	for _, n := range g.Nodes {
+		if n.ChangesetSpec.ID == changesetSpecID {
+			return n
+		}
+	}
+	return nil
+}
+
+// Dependencies resolves the dependencies declared by the changeset spec of
+// the given node.
+func (g *ChangesetDependencyGraph) Dependencies(n *ChangesetDependencyNode) []ResolvedChangesetDependency {
+	deps := make([]ResolvedChangesetDependency, 0, len(n.ChangesetSpec.Spec.DependsOn))
+	for _, d := range n.ChangesetSpec.Spec.DependsOn {
+		resolved := ResolvedChangesetDependency{Dependency: d}
+		for _, other := range g.Nodes {
+			if other.satisfies(n, d) {
+				resolved.Nodes = append(resolved.Nodes, other)
+			}
+		}
+		deps = append(deps, resolved)
+	}
+	return deps
+}
+
+// Blocked returns whether the changeset of the given node has to be held
+// back because not all of its prerequisites have been merged.
+func (g *ChangesetDependencyGraph) Blocked(n *ChangesetDependencyNode) bool {
+	for _, d := range g.Dependencies(n) {
+		if !d.Merged() {
+			return true
+		}
+	}
+	return false
+}
+
+// Cycle returns the nodes of a dependency cycle, with the first node
+// repeated at the end, or nil if the graph has no cycles.
+func (g *ChangesetDependencyGraph) Cycle() []*ChangesetDependencyNode {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*ChangesetDependencyNode]int, len(g.Nodes))
+	var path []*ChangesetDependencyNode
+
+	var visit func(n *ChangesetDependencyNode) []*ChangesetDependencyNode
+	visit = func(n *ChangesetDependencyNode) []*ChangesetDependencyNode {
+		state[n] = visiting
+		path = append(path, n)
+		for _, d := range g.Dependencies(n) {
+			for _, next := range d.Nodes {
+				switch state[next] {
+				case visiting:
+					for i, p := range path {
+						if p == next {
+							cycle := append([]*ChangesetDependencyNode{}, path[i:]...)
+							return append(cycle, next)
+						}
+					}
+				case unvisited:
+					if cycle := visit(next); cycle != nil {
+						return cycle
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[n] = visited
+		return nil
+	}
+
+	for _, n := range g.Nodes {
+		if state[n] == unvisited {
+			if cycle := visit(n); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// ChangesetDependencyCycleError is returned when the changeset specs of a
+// batch spec depend on each other in a cycle, which would hold back all of
+// them forever.
+type ChangesetDependencyCycleError struct {
+	Cycle []*ChangesetDependencyNode
+}
+
+func (e *ChangesetDependencyCycleError) Error() string {
+	names := make([]string, 0, len(e.Cycle))
+	for _, n := range e.Cycle {
+		names = append(names, fmt.Sprintf("%s@%s", n.RepoName, git.AbbreviateRef(n.ChangesetSpec.Spec.HeadRef)))
+	}
+	return "changeset dependencies form a cycle: " + strings.Join(names, " -> ")
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestChangesetDependencyGraph(t *testing.T) {
+	node := func(id int64, repo, branch string, state ChangesetExternalState, deps ...ChangesetSpecDependency) *ChangesetDependencyNode {
+		n := &ChangesetDependencyNode{
+			ChangesetSpec: &ChangesetSpec{
+				ID: id,
+				Spec: &ChangesetSpecDescription{
+					HeadRef:   "refs/heads/" + branch,
+					DependsOn: deps,
+				},
+			},
+			RepoName: api.RepoName(repo),
+		}
+		if state != "" {
+			n.Changeset = &Changeset{ExternalState: state}
+		}
+		return n
+	}
+
+	t.Run("blocked", func(t *testing.T) {
+		lib := node(1, "github.com/sourcegraph/lib", "bump", ChangesetExternalStateOpen)
+		merged := node(2, "github.com/sourcegraph/other", "bump", ChangesetExternalStateMerged)
+		unpublished := node(3, "github.com/sourcegraph/third", "bump", "")
+
+		tests := map[string]struct {
+			node *ChangesetDependencyNode
+			want bool
+		}{
+			"no dependencies": {
+				node: node(10, "github.com/sourcegraph/app", "bump", ""),
+				want: false,
+			},
+			"open repository": {
+				node: node(10, "github.com/sourcegraph/app", "bump", "", ChangesetSpecDependency{Repository: "github.com/sourcegraph/lib"}),
+				want: true,
+			},
+			"open branch": {
+				node: node(10, "github.com/sourcegraph/app", "bump", "", ChangesetSpecDependency{Repository: "github.com/sourcegraph/lib", Branch: "bump"}),
+				want: true,
+			},
+			"other branch": {
+				node: node(10, "github.com/sourcegraph/app", "bump", "", ChangesetSpecDependency{Repository: "github.com/sourcegraph/lib", Branch: "refs/heads/other"}),
+				want: false,
+			},
+			"merged": {
+				node: node(10, "github.com/sourcegraph/app", "bump", "", ChangesetSpecDependency{Repository: "github.com/sourcegraph/other"}),
+				want: false,
+			},
+			"unpublished": {
+				node: node(10, "github.com/sourcegraph/app", "bump", "", ChangesetSpecDependency{Repository: "github.com/sourcegraph/third"}),
+				want: true,
+			},
+			"unknown repository": {
+				node: node(10, "github.com/sourcegraph/app", "bump", "", ChangesetSpecDependency{Repository: "github.com/sourcegraph/unknown"}),
+				want: false,
+			},
+			"same repository": {
+				node: node(10, "github.com/sourcegraph/lib", "next", "", ChangesetSpecDependency{Branch: "bump"}),
+				want: true,
+			},
+			"itself": {
+				node: node(10, "github.com/sourcegraph/app", "bump", "", ChangesetSpecDependency{Branch: "bump"}),
+				want: false,
+			},
+		}
+
+		for name, tc := range tests {
+			t.Run(name, func(t *testing.T) {
+				g := &ChangesetDependencyGraph{Nodes: []*ChangesetDependencyNode{lib, merged, unpublished, tc.node}}
+				if have := g.Blocked(tc.node); have != tc.want {
+					t.Fatalf("wrong blocked: have %t, want %t", have, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		a := node(1, "a", "a", "", ChangesetSpecDependency{Repository: "b"})
+		b := node(2, "b", "b", "", ChangesetSpecDependency{Repository: "c"})
+		c := node(3, "c", "c", "")
+
+		g := &ChangesetDependencyGraph{Nodes: []*ChangesetDependencyNode{a, b, c}}
+		if cycle := g.Cycle(); cycle != nil {
+			t.Fatalf("unexpected cycle: %v", (&ChangesetDependencyCycleError{Cycle: cycle}).Error())
+		}
+
+		c.ChangesetSpec.Spec.DependsOn = []ChangesetSpecDependency{{Repository: "a"}}
+		cycle := g.Cycle()
+		if cycle == nil {
+			t.Fatal("no cycle found")
+		}
+		want := "changeset dependencies form a cycle: a@a -> b@b -> c@c -> a@a"
+		if have := (&ChangesetDependencyCycleError{Cycle: cycle}).Error(); have != want {
+			t.Fatalf("wrong error:\nhave %q\nwant %q", have, want)
+		}
+	})
+}
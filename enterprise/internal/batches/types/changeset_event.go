@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -69,6 +70,15 @@ const (
 	// clearly convey that it only occurs when a request for changes has been dismissed.
 	ChangesetEventKindBitbucketServerDismissed ChangesetEventKind = "bitbucketserver:participant_status:unapproved"
 
+	ChangesetEventKindBitbucketCloudApproved         ChangesetEventKind = "bitbucketcloud:approved"
+	ChangesetEventKindBitbucketCloudChangesRequested ChangesetEventKind = "bitbucketcloud:changes_requested"
+	ChangesetEventKindBitbucketCloudCommitStatus     ChangesetEventKind = "bitbucketcloud:commit_status"
+
+	// Bitbucket Cloud only reports the current review state of participants,
+	// so an approval or request for changes being withdrawn results in a
+	// participant without a state, which we record as a dismissal.
+	ChangesetEventKindBitbucketCloudReviewDismissed ChangesetEventKind = "bitbucketcloud:review_dismissed"
+
 	ChangesetEventKindGitLabApproved             ChangesetEventKind = "gitlab:approved"
 	ChangesetEventKindGitLabClosed               ChangesetEventKind = "gitlab:closed"
 	ChangesetEventKindGitLabMerged               ChangesetEventKind = "gitlab:merged"
@@ -78,6 +88,11 @@ const (
 	ChangesetEventKindGitLabMarkWorkInProgress   ChangesetEventKind = "gitlab:mark_wip"
 	ChangesetEventKindGitLabUnmarkWorkInProgress ChangesetEventKind = "gitlab:unmark_wip"
 
+	// ChangesetEventKindAutoMerge records the decisions of the auto-merge
+	// worker. Contrary to the other kinds it doesn't originate from the code
+	// host.
+	ChangesetEventKindAutoMerge ChangesetEventKind = "batches:auto_merge"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -115,6 +130,9 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 	case *bitbucketserver.ParticipantStatusEvent:
 		return meta.User.Name
 
+	case *bitbucketcloud.Participant:
+		return meta.User.UUID
+
 	case *gitlab.ReviewApprovedEvent:
 		return meta.Author.Username
 
@@ -130,9 +148,13 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	switch e.Kind {
 	case ChangesetEventKindBitbucketServerApproved,
+		ChangesetEventKindBitbucketCloudApproved,
 		ChangesetEventKindGitLabApproved:
 		return ChangesetReviewStateApproved, nil
 
+	case ChangesetEventKindBitbucketCloudChangesRequested:
+		return ChangesetReviewStateChangesRequested, nil
+
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
 	case ChangesetEventKindBitbucketServerReviewed:
@@ -155,6 +177,7 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	case ChangesetEventKindGitHubReviewDismissed,
 		ChangesetEventKindBitbucketServerUnapproved,
 		ChangesetEventKindBitbucketServerDismissed,
+		ChangesetEventKindBitbucketCloudReviewDismissed,
 		ChangesetEventKindGitLabUnapproved:
 		return ChangesetReviewStateDismissed, nil
 
@@ -221,6 +244,10 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = unixMilliToTime(int64(ev.CreatedDate))
 	case *bitbucketserver.CommitStatus:
 		t = unixMilliToTime(ev.Status.DateAdded)
+	case *bitbucketcloud.Participant:
+		t = ev.ParticipatedOn
+	case *bitbucketcloud.CommitStatus:
+		t = ev.UpdatedOn
 	case *gitlab.ReviewApprovedEvent:
 		t = ev.CreatedAt.Time
 	case *gitlab.ReviewUnapprovedEvent:
@@ -235,11 +262,15 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedAt.Time
 	case *gitlab.MergeRequestMergedEvent:
 		t = ev.CreatedAt.Time
+	case *gitlab.Pipeline:
+		t = ev.CreatedAt.Time
 	case *gitlabwebhooks.PipelineEvent:
 		// These events do not inherently have timestamps from GitLab, so we
 		// fall back to the event record we created when we received the
 		// webhook.
 		t = e.CreatedAt
+	case *ChangesetAutoMergeDecision:
+		t = ev.DecidedAt
 	}
 
 	return t
@@ -602,6 +633,23 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *bitbucketcloud.Participant:
+		o := o.Metadata.(*bitbucketcloud.Participant)
+		if e.ParticipatedOn.IsZero() {
+			e.ParticipatedOn = o.ParticipatedOn
+		}
+		if e.User.UUID == "" {
+			e.User = o.User
+		}
+		if e.Role == "" {
+			e.Role = o.Role
+		}
+
+	case *bitbucketcloud.CommitStatus:
+		o := o.Metadata.(*bitbucketcloud.CommitStatus)
+		// We always get the full status, so safe to replace it
+		*e = *o
+
 	case *github.CheckRun:
 		o := o.Metadata.(*github.CheckRun)
 		if e.Status == "" {
@@ -722,6 +770,11 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *ChangesetAutoMergeDecision:
+		o := o.Metadata.(*ChangesetAutoMergeDecision)
+		// The latest decision always wins.
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
@@ -26,6 +26,19 @@ type ChangesetSpecDescription struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published batches.PublishedValue `json:"published,omitempty"`
+
+	// Reviewers, TeamReviewers, Labels, Assignees and Milestone are applied
+	// to the changeset on code hosts that support them and ignored
+	// otherwise.
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
+	Labels        []string `json:"labels,omitempty"`
+	Assignees     []string `json:"assignees,omitempty"`
+	Milestone     string   `json:"milestone,omitempty"`
+
+	// DependsOn lists the changesets of the same batch change that have to
+	// be merged before this changeset is published or undrafted.
+	DependsOn []ChangesetSpecDependency `json:"dependsOn,omitempty"`
 }
 
 // Type returns the ChangesetSpecDescriptionType of the ChangesetSpecDescription.
@@ -48,6 +61,12 @@ func (d *ChangesetSpecDescription) IsBranch() bool {
 	return d.Type() == ChangesetSpecDescriptionTypeBranch
 }
 
+// HasMetadata returns whether the description sets any reviewers, team
+// reviewers, labels, assignees or a milestone.
+func (d *ChangesetSpecDescription) HasMetadata() bool {
+	return len(d.Reviewers) > 0 || len(d.TeamReviewers) > 0 || len(d.Labels) > 0 || len(d.Assignees) > 0 || d.Milestone != ""
+}
+
 // ChangesetSpecDescriptionType tells the consumer what the type of a
 // ChangesetSpecDescription is without having to look into the description.
 // Useful in the GraphQL when a HiddenChangesetSpec is returned.
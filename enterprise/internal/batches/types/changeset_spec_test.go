@@ -56,6 +56,29 @@ func TestChangesetSpecUnmarshalValidate(t *testing.T) {
 			}`,
 			err: "4 errors occurred:\n\t* Must validate one and only one schema (oneOf)\n\t* baseRev is required\n\t* body is required\n\t* commits.0: message is required\n\n",
 		},
+		{
+			name: "valid GitBranchChangesetDescription with dependencies",
+			rawSpec: `{
+				"baseRepository": "graphql-id",
+				"baseRef": "refs/heads/master",
+				"baseRev": "d34db33f",
+				"headRef": "refs/heads/my-branch",
+				"headRepository": "graphql-id",
+				"title": "my title",
+				"body": "my body",
+				"published": true,
+				"commits": [{
+				  "message": "commit message",
+				  "diff": "the diff",
+				  "authorName": "Mary McButtons",
+				  "authorEmail": "mary@example.com"
+				}],
+				"dependsOn": [
+				  {"repository": "github.com/sourcegraph/lib"},
+				  {"branch": "refs/heads/other-branch"}
+				]
+			}`,
+		},
 		{
 			name: "missing fields in ExistingChangesetReference",
 			rawSpec: `{
@@ -452,6 +452,49 @@ func TestChangeset_BaseRef(t *testing.T) {
 	})
 }
 
+func TestChangeset_Conflicting(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meta interface{}
+		want bool
+	}{
+		"bitbucketserver": {
+			meta: &bitbucketserver.PullRequest{},
+			want: false,
+		},
+		"GitHub mergeable": {
+			meta: &github.PullRequest{Mergeable: github.PullRequestMergeableStateMergeable},
+			want: false,
+		},
+		"GitHub unknown": {
+			meta: &github.PullRequest{Mergeable: github.PullRequestMergeableStateUnknown},
+			want: false,
+		},
+		"GitHub conflicting": {
+			meta: &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+			want: true,
+		},
+		"GitLab": {
+			meta: &gitlab.MergeRequest{},
+			want: false,
+		},
+		"GitLab conflicting": {
+			meta: &gitlab.MergeRequest{HasConflicts: true},
+			want: true,
+		},
+		"unknown changeset type": {
+			meta: nil,
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			if have := c.Conflicting(); have != tc.want {
+				t.Errorf("unexpected conflicting state: have %t; want %t", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_Labels(t *testing.T) {
 	for name, tc := range map[string]struct {
 		meta interface{}
@@ -0,0 +1,72 @@
+package types
+
+import "time"
+
+// BatchChangeContinuousApplyPolicy defines which results of a continuous
+// execution of a batch change are applied automatically.
+type BatchChangeContinuousApplyPolicy string
+
+// BatchChangeContinuousApplyPolicy constants.
+const (
+	// BatchChangeContinuousApplyPolicyNone never applies automatically. New
+	// batch specs are only created, to be previewed and applied manually.
+	BatchChangeContinuousApplyPolicyNone BatchChangeContinuousApplyPolicy = "none"
+	// BatchChangeContinuousApplyPolicyCreate applies automatically only if no
+	// published changeset would be updated.
+	BatchChangeContinuousApplyPolicyCreate BatchChangeContinuousApplyPolicy = "create"
+	// BatchChangeContinuousApplyPolicyCreateAndUpdate applies automatically if
+	// changesets would be created or updated.
+	BatchChangeContinuousApplyPolicyCreateAndUpdate BatchChangeContinuousApplyPolicy = "create_and_update"
+)
+
+// Valid returns true if the given BatchChangeContinuousApplyPolicy is valid.
+func (p BatchChangeContinuousApplyPolicy) Valid() bool {
+	switch p {
+	case BatchChangeContinuousApplyPolicyNone,
+		BatchChangeContinuousApplyPolicyCreate,
+		BatchChangeContinuousApplyPolicyCreateAndUpdate:
+		return true
+	default:
+		return false
+	}
+}
+
+// BatchChangeContinuousRunState defines the possible states of a
+// BatchChangeContinuousRun.
+type BatchChangeContinuousRunState string
+
+// BatchChangeContinuousRunState constants.
+const (
+	BatchChangeContinuousRunStateExecuting BatchChangeContinuousRunState = "executing"
+	BatchChangeContinuousRunStateApplied   BatchChangeContinuousRunState = "applied"
+	BatchChangeContinuousRunStateSkipped   BatchChangeContinuousRunState = "skipped"
+	BatchChangeContinuousRunStateFailed    BatchChangeContinuousRunState = "failed"
+)
+
+// Finished returns true if the run is no longer executing.
+func (s BatchChangeContinuousRunState) Finished() bool {
+	return s != BatchChangeContinuousRunStateExecuting
+}
+
+// BatchChangeContinuousRun is a single scheduled re-execution of the batch
+// spec of a batch change that has continuous execution enabled.
+type BatchChangeContinuousRun struct {
+	ID int64
+
+	BatchChangeID        int64
+	BatchSpecExecutionID int64
+
+	State BatchChangeContinuousRunState
+	// Message explains why a run was skipped or failed.
+	Message string
+
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Clone returns a clone of a BatchChangeContinuousRun.
+func (r *BatchChangeContinuousRun) Clone() *BatchChangeContinuousRun {
+	rr := *r
+	return &rr
+}
@@ -0,0 +1,162 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+)
+
+// overridableRule is a single `pattern: value` rule of a changeset template
+// field that can be overridden for repositories matching a glob pattern.
+type overridableRule struct {
+	pattern  string
+	compiled glob.Glob
+	value    interface{}
+}
+
+// overridableRules is an ordered list of rules. The last matching rule wins.
+type overridableRules []overridableRule
+
+// match returns the value of the last rule matching the given repository
+// name, or nil if no rule matches.
+func (r overridableRules) match(name string) interface{} {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i].compiled.Match(name) {
+			return r[i].value
+		}
+	}
+	return nil
+}
+
+// unmarshalOverridableRules builds rules from either a scalar value, which
+// applies to all repositories, or a list of single-entry objects mapping a
+// glob pattern to a value. decode converts a raw rule value into its typed
+// representation and fails if the value is not of the expected type.
+func unmarshalOverridableRules(data []byte, decode func(json.RawMessage) (interface{}, error)) (overridableRules, error) {
+	if v, err := decode(data); err == nil {
+		return overridableRules{{pattern: "*", compiled: glob.MustCompile("*"), value: v}}, nil
+	}
+
+	var complex []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &complex); err != nil {
+		return nil, err
+	}
+
+	rules := make(overridableRules, 0, len(complex))
+	for i, entry := range complex {
+		if len(entry) != 1 {
+			return nil, errors.Errorf("unexpected number of elements in the array at entry %d: %d (must be 1)", i, len(entry))
+		}
+
+		for pattern, raw := range entry {
+			compiled, err := glob.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "building rule for array entry %d", i)
+			}
+			v, err := decode(raw)
+			if err != nil {
+				return nil, errors.Wrapf(err, "decoding value of array entry %d", i)
+			}
+			rules = append(rules, overridableRule{pattern: pattern, compiled: compiled, value: v})
+		}
+	}
+
+	return rules, nil
+}
+
+// equal tests two rule lists for equality.
+func (r overridableRules) equal(other overridableRules) bool {
+	if len(r) != len(other) {
+		return false
+	}
+	for i := range r {
+		if r[i].pattern != other[i].pattern || !reflect.DeepEqual(r[i].value, other[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r overridableRules) marshalJSON() ([]byte, error) {
+	if len(r) == 1 && r[0].pattern == "*" {
+		return json.Marshal(r[0].value)
+	}
+
+	rules := make([]map[string]interface{}, 0, len(r))
+	for _, rule := range r {
+		rules = append(rules, map[string]interface{}{rule.pattern: rule.value})
+	}
+	return json.Marshal(rules)
+}
+
+// OverridableStringList is a list of strings, such as the reviewers of a
+// changeset template, that can be overridden for specific repositories.
+type OverridableStringList struct {
+	rules overridableRules
+}
+
+// Value returns the list for the given repository.
+func (l *OverridableStringList) Value(name string) []string {
+	if v, ok := l.rules.match(name).([]string); ok {
+		return v
+	}
+	return nil
+}
+
+// Equal tests two OverridableStringLists for equality, used in cmp.
+func (l OverridableStringList) Equal(other OverridableStringList) bool {
+	return l.rules.equal(other.rules)
+}
+
+func (l OverridableStringList) MarshalJSON() ([]byte, error) {
+	if len(l.rules) == 0 {
+		return []byte("[]"), nil
+	}
+	return l.rules.marshalJSON()
+}
+
+func (l *OverridableStringList) UnmarshalJSON(data []byte) (err error) {
+	l.rules, err = unmarshalOverridableRules(data, func(raw json.RawMessage) (interface{}, error) {
+		var v []string
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	})
+	return err
+}
+
+// OverridableString is a string, such as the milestone of a changeset
+// template, that can be overridden for specific repositories.
+type OverridableString struct {
+	rules overridableRules
+}
+
+// Value returns the string for the given repository.
+func (s *OverridableString) Value(name string) string {
+	if v, ok := s.rules.match(name).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// Equal tests two OverridableStrings for equality, used in cmp.
+func (s OverridableString) Equal(other OverridableString) bool {
+	return s.rules.equal(other.rules)
+}
+
+func (s OverridableString) MarshalJSON() ([]byte, error) {
+	if len(s.rules) == 0 {
+		return []byte(`""`), nil
+	}
+	return s.rules.marshalJSON()
+}
+
+func (s *OverridableString) UnmarshalJSON(data []byte) (err error) {
+	s.rules, err = unmarshalOverridableRules(data, func(raw json.RawMessage) (interface{}, error) {
+		var v string
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	})
+	return err
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChangesetTemplateMetadata(t *testing.T) {
+	spec, err := NewBatchSpecFromRaw(`
+name: my-unique-name
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  reviewers: [alice]
+  labels:
+    - "*": [batch-change]
+    - github.com/sourcegraph/*: [batch-change, team/search]
+  assignees: []
+  milestone:
+    - github.com/sourcegraph/sourcegraph: "3.31"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := spec.Spec.ChangesetTemplate
+
+	for _, tc := range []struct {
+		repo          string
+		wantReviewers []string
+		wantLabels    []string
+		wantMilestone string
+	}{
+		{
+			repo:          "github.com/sourcegraph/sourcegraph",
+			wantReviewers: []string{"alice"},
+			wantLabels:    []string{"batch-change", "team/search"},
+			wantMilestone: "3.31",
+		},
+		{
+			repo:          "gitlab.com/sourcegraph/other",
+			wantReviewers: []string{"alice"},
+			wantLabels:    []string{"batch-change"},
+		},
+	} {
+		if diff := cmp.Diff(tc.wantReviewers, tmpl.Reviewers.Value(tc.repo)); diff != "" {
+			t.Errorf("unexpected reviewers for %s (-want +got):\n%s", tc.repo, diff)
+		}
+		if diff := cmp.Diff(tc.wantLabels, tmpl.Labels.Value(tc.repo)); diff != "" {
+			t.Errorf("unexpected labels for %s (-want +got):\n%s", tc.repo, diff)
+		}
+		if have := tmpl.Milestone.Value(tc.repo); have != tc.wantMilestone {
+			t.Errorf("unexpected milestone for %s. want=%q have=%q", tc.repo, tc.wantMilestone, have)
+		}
+		if have := tmpl.Assignees.Value(tc.repo); len(have) != 0 {
+			t.Errorf("unexpected assignees for %s: %v", tc.repo, have)
+		}
+		if have := tmpl.TeamReviewers.Value(tc.repo); have != nil {
+			t.Errorf("unexpected team reviewers for %s: %v", tc.repo, have)
+		}
+	}
+
+	if _, err := NewBatchSpecFromRaw(`
+name: my-unique-name
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  labels: batch-change
+`); err == nil {
+		t.Error("expected error for labels that are not a list")
+	}
+}
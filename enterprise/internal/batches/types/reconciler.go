@@ -4,18 +4,20 @@ package types
 type ReconcilerOperation string
 
 const (
-	ReconcilerOperationPush         ReconcilerOperation = "PUSH"
-	ReconcilerOperationUpdate       ReconcilerOperation = "UPDATE"
-	ReconcilerOperationUndraft      ReconcilerOperation = "UNDRAFT"
-	ReconcilerOperationPublish      ReconcilerOperation = "PUBLISH"
-	ReconcilerOperationPublishDraft ReconcilerOperation = "PUBLISH_DRAFT"
-	ReconcilerOperationSync         ReconcilerOperation = "SYNC"
-	ReconcilerOperationImport       ReconcilerOperation = "IMPORT"
-	ReconcilerOperationClose        ReconcilerOperation = "CLOSE"
-	ReconcilerOperationReopen       ReconcilerOperation = "REOPEN"
-	ReconcilerOperationSleep        ReconcilerOperation = "SLEEP"
-	ReconcilerOperationDetach       ReconcilerOperation = "DETACH"
-	ReconcilerOperationArchive      ReconcilerOperation = "ARCHIVE"
+	ReconcilerOperationPush           ReconcilerOperation = "PUSH"
+	ReconcilerOperationUpdate         ReconcilerOperation = "UPDATE"
+	ReconcilerOperationUndraft        ReconcilerOperation = "UNDRAFT"
+	ReconcilerOperationPublish        ReconcilerOperation = "PUBLISH"
+	ReconcilerOperationPublishDraft   ReconcilerOperation = "PUBLISH_DRAFT"
+	ReconcilerOperationSync           ReconcilerOperation = "SYNC"
+	ReconcilerOperationImport         ReconcilerOperation = "IMPORT"
+	ReconcilerOperationClose          ReconcilerOperation = "CLOSE"
+	ReconcilerOperationReopen         ReconcilerOperation = "REOPEN"
+	ReconcilerOperationSleep          ReconcilerOperation = "SLEEP"
+	ReconcilerOperationDetach         ReconcilerOperation = "DETACH"
+	ReconcilerOperationArchive        ReconcilerOperation = "ARCHIVE"
+	ReconcilerOperationUpdateMetadata ReconcilerOperation = "UPDATE_METADATA"
+	ReconcilerOperationRebase         ReconcilerOperation = "REBASE"
 )
 
 // Valid returns true if the given ReconcilerOperation is valid.
@@ -32,7 +34,9 @@ func (r ReconcilerOperation) Valid() bool {
 		ReconcilerOperationReopen,
 		ReconcilerOperationSleep,
 		ReconcilerOperationDetach,
-		ReconcilerOperationArchive:
+		ReconcilerOperationArchive,
+		ReconcilerOperationUpdateMetadata,
+		ReconcilerOperationRebase:
 		return true
 	default:
 		return false
@@ -84,6 +84,17 @@ func (cfg *Configuration) HasRolloutWindows() bool {
 	return len(cfg.windows) != 0
 }
 
+// IsOpen returns true if changesets may be processed at the given time: either
+// no rollout windows are defined, or the window in effect has a non-zero rate.
+func (cfg *Configuration) IsOpen(at time.Time) bool {
+	if !cfg.HasRolloutWindows() {
+		return true
+	}
+
+	window, _ := cfg.windowFor(at)
+	return window != nil && window.rate.n != 0
+}
+
 // Schedule returns the currently active schedule.
 func (cfg *Configuration) Schedule() *Schedule {
 	// If there are no rollout windows, then we return an unlimited schedule and
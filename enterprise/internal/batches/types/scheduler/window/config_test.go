@@ -157,6 +157,59 @@ func TestConfiguration_Estimate(t *testing.T) {
 	})
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// Sunday, 12:00 UTC.
+	at := time.Date(2021, 4, 4, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		want bool
+	}{
+		"no rollout windows": {
+			cfg:  &Configuration{windows: []Window{}},
+			want: true,
+		},
+		"open window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(), rate: rate{n: 10, unit: ratePerHour}},
+				},
+			},
+			want: true,
+		},
+		"unlimited window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(), rate: makeUnlimitedRate()},
+				},
+			},
+			want: true,
+		},
+		"zero rate window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(), rate: rate{n: 0}},
+				},
+			},
+			want: false,
+		},
+		"no window in effect": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(time.Monday), rate: makeUnlimitedRate()},
+				},
+			},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_Schedule(t *testing.T) {
 	// We have other tests to test the actual implementation of scheduleAt();
 	// this is purely to ensure that we do the special case handling of not
@@ -0,0 +1,259 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	gh "github.com/google/go-github/v28/github"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type BitbucketCloudWebhook struct {
+	*Webhook
+}
+
+func NewBitbucketCloudWebhook(store *store.Store) *BitbucketCloudWebhook {
+	return &BitbucketCloudWebhook{&Webhook{store, extsvc.TypeBitbucketCloud}}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil {
+		respond(w, http.StatusBadRequest, "missing request body")
+		return
+	}
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "reading payload"))
+		return
+	}
+
+	extSvc, err := h.getExternalServiceFromRawID(r.Context(), r.FormValue(extsvc.IDParam))
+	if err == errExternalServiceNotFound {
+		respond(w, http.StatusUnauthorized, err)
+		return
+	} else if err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "getting external service"))
+		return
+	}
+
+	// 🚨 SECURITY: Verify the signature of the payload against the secrets of
+	// the webhooks in the external service configuration. If none of them
+	// matches, or the header is empty, then we return a 401 to the client.
+	if ok, err := validateBitbucketCloudSignature(extSvc, r.Header.Get("X-Hub-Signature"), payload); err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "validating the signature"))
+		return
+	} else if !ok {
+		respond(w, http.StatusUnauthorized, "signature is incorrect")
+		return
+	}
+
+	event, err := bitbucketcloud.ParseWebhookEvent(bitbucketcloud.WebhookEventType(r), payload)
+	if err != nil {
+		if errors.Is(err, bitbucketcloud.ErrUnknownWebhookEventType) {
+			// We don't want to return a non-2XX status code and have Bitbucket
+			// Cloud retry the webhook, so we'll log that we don't know what to
+			// do and return 204.
+			log15.Debug("unknown Bitbucket Cloud webhook event type", "err", err)
+
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusNoContent)
+			fmt.Fprintf(w, "%v", err)
+		} else {
+			respond(w, http.StatusBadRequest, errors.Wrap(err, "parsing webhook"))
+		}
+		return
+	}
+
+	if err := h.handleEvent(r.Context(), extSvc, event); err != nil {
+		respond(w, err.code, err)
+	} else {
+		respond(w, http.StatusNoContent, nil)
+	}
+}
+
+// getExternalServiceFromRawID retrieves the Bitbucket Cloud external service
+// matching the given raw ID.
+//
+// On failure, errExternalServiceNotFound is returned if the ID doesn't match
+// any Bitbucket Cloud service.
+func (h *BitbucketCloudWebhook) getExternalServiceFromRawID(ctx context.Context, raw string) (*types.ExternalService, error) {
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing the raw external service ID")
+	}
+
+	es, err := h.Store.ExternalServices().List(ctx, database.ExternalServicesListOptions{
+		IDs:   []int64{id},
+		Kinds: []string{extsvc.KindBitbucketCloud},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external services")
+	}
+
+	if len(es) == 0 {
+		return nil, errExternalServiceNotFound
+	} else if len(es) > 1 {
+		return nil, errors.New("too many external services found")
+	}
+
+	return es[0], nil
+}
+
+// handleEvent dispatches based on the event type to perform whatever
+// changeset action is appropriate for that event.
+func (h *BitbucketCloudWebhook) handleEvent(ctx context.Context, extSvc *types.ExternalService, event interface{}) *httpError {
+	log15.Debug("Bitbucket Cloud webhook received", "type", fmt.Sprintf("%T", event))
+
+	esID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		return &httpError{http.StatusInternalServerError, err}
+	}
+
+	switch e := event.(type) {
+	case *bitbucketcloud.PullRequestApprovedEvent:
+		err = h.upsertReview(ctx, esID, &e.PullRequestEvent, e.Approval.ToParticipant(bitbucketcloud.ParticipantStateApproved))
+	case *bitbucketcloud.PullRequestUnapprovedEvent:
+		err = h.upsertReview(ctx, esID, &e.PullRequestEvent, e.Approval.ToParticipant(""))
+	case *bitbucketcloud.PullRequestChangesRequestCreatedEvent:
+		err = h.upsertReview(ctx, esID, &e.PullRequestEvent, e.ChangesRequest.ToParticipant(bitbucketcloud.ParticipantStateChangesRequested))
+	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
+		err = h.upsertReview(ctx, esID, &e.PullRequestEvent, e.ChangesRequest.ToParticipant(""))
+
+	// Bitbucket Cloud has no events for state changes of pull requests: the
+	// state is derived from the pull request itself. The payload contains the
+	// pull request, but not its commit statuses, so rather than updating the
+	// changeset here we ask repo-updater to prioritize syncing it.
+	case *bitbucketcloud.PullRequestUpdatedEvent:
+		err = h.enqueueChangesetSync(ctx, esID, &e.PullRequestEvent)
+
+	case *bitbucketcloud.RepoCommitStatusEvent:
+		err = h.handleCommitStatusEvent(ctx, esID, e)
+
+	default:
+		log15.Debug("cannot handle Bitbucket Cloud webhook event of unknown type", "type", fmt.Sprintf("%T", event))
+	}
+
+	if err != nil {
+		return &httpError{http.StatusInternalServerError, err}
+	}
+	return nil
+}
+
+func (h *BitbucketCloudWebhook) upsertReview(ctx context.Context, esID string, e *bitbucketcloud.PullRequestEvent, p *bitbucketcloud.Participant) error {
+	if err := h.upsertChangesetEvent(ctx, esID, bitbucketCloudToPR(e), p); err != nil {
+		return errors.Wrap(err, "upserting changeset event")
+	}
+	return nil
+}
+
+func (h *BitbucketCloudWebhook) enqueueChangesetSync(ctx context.Context, esID string, e *bitbucketcloud.PullRequestEvent) error {
+	pr := bitbucketCloudToPR(e)
+	repo, err := h.getRepoForPR(ctx, h.Store, pr, esID)
+	if err != nil {
+		return errors.Wrap(err, "getting repo")
+	}
+
+	c, err := h.Store.GetChangeset(ctx, store.GetChangesetOpts{
+		RepoID:              repo.ID,
+		ExternalID:          strconv.FormatInt(pr.ID, 10),
+		ExternalServiceType: h.ServiceType,
+	})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return errors.Wrap(err, "getting changeset")
+	}
+
+	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{c.ID}); err != nil {
+		return errors.Wrap(err, "enqueuing changeset sync")
+	}
+	return nil
+}
+
+// handleCommitStatusEvent records the commit status on every open changeset
+// whose head is the commit the status was reported against, since the
+// payload doesn't reference the pull request.
+func (h *BitbucketCloudWebhook) handleCommitStatusEvent(ctx context.Context, esID string, e *bitbucketcloud.RepoCommitStatusEvent) error {
+	repo, err := h.getRepoForPR(ctx, h.Store, PR{RepoExternalID: e.Repository.UUID}, esID)
+	if err != nil {
+		log15.Debug("Webhook event could not be matched to repo", "err", err)
+		return nil
+	}
+
+	cs, _, err := h.Store.ListChangesets(ctx, store.ListChangesetsOpts{
+		RepoID: repo.ID,
+		ExternalStates: []btypes.ChangesetExternalState{
+			btypes.ChangesetExternalStateOpen,
+			btypes.ChangesetExternalStateDraft,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	m := new(multierror.Error)
+	for _, c := range cs {
+		pr, ok := c.Metadata.(*bitbucketcloud.PullRequest)
+		if !ok || pr.Source.Commit.Hash != e.CommitStatus.Commit.Hash {
+			continue
+		}
+
+		status := e.CommitStatus
+		if err := h.upsertChangesetEvent(ctx, esID, PR{ID: pr.ID, RepoExternalID: e.Repository.UUID}, &status); err != nil {
+			m = multierror.Append(m, errors.Wrap(err, "upserting changeset event"))
+		}
+	}
+	return m.ErrorOrNil()
+}
+
+// bitbucketCloudToPR instantiates a new PR instance given the fields common
+// to Bitbucket Cloud pull request webhook payloads.
+func bitbucketCloudToPR(e *bitbucketcloud.PullRequestEvent) PR {
+	return PR{
+		ID:             e.PullRequest.ID,
+		RepoExternalID: e.Repository.UUID,
+	}
+}
+
+// validateBitbucketCloudSignature validates that the given signature of the
+// payload was created with the secret of one of the webhooks in the external
+// service.
+func validateBitbucketCloudSignature(extSvc *types.ExternalService, signature string, payload []byte) (bool, error) {
+	// An empty signature never succeeds.
+	if signature == "" {
+		return false, nil
+	}
+
+	c, err := extSvc.Configuration()
+	if err != nil {
+		return false, errors.Wrap(err, "getting external service configuration")
+	}
+
+	config, ok := c.(*schema.BitbucketCloudConnection)
+	if !ok {
+		return false, errExternalServiceWrongKind
+	}
+
+	for _, webhook := range config.Webhooks {
+		if err := gh.ValidateSignature(signature, payload, []byte(webhook.Secret)); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"testing"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateBitbucketCloudSignature(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"pullrequest": {"id": 1}}`)
+
+	t.Run("empty signature", func(t *testing.T) {
+		ok, err := validateBitbucketCloudSignature(nil, "", payload)
+		if ok {
+			t.Errorf("unexpected ok: %v", ok)
+		}
+		if err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+
+	t.Run("not a Bitbucket Cloud connection", func(t *testing.T) {
+		es := &types.ExternalService{Kind: extsvc.KindGitHub}
+		ok, err := validateBitbucketCloudSignature(es, sign(t, payload, []byte("secret")), payload)
+		if ok {
+			t.Errorf("unexpected ok: %v", ok)
+		}
+		if err != errExternalServiceWrongKind {
+			t.Errorf("unexpected error: have %+v; want %+v", err, errExternalServiceWrongKind)
+		}
+	})
+
+	t.Run("valid webhooks", func(t *testing.T) {
+		es := &types.ExternalService{
+			Kind: extsvc.KindBitbucketCloud,
+			Config: ct.MarshalJSON(t, &schema.BitbucketCloudConnection{
+				Url: "https://bitbucket.org",
+				Webhooks: []*schema.BitbucketCloudWebhook{
+					{Secret: "super"},
+					{Secret: "secret"},
+				},
+			}),
+		}
+
+		for secret, want := range map[string]bool{
+			"not secret": false,
+			"secret":     true,
+			"super":      true,
+		} {
+			t.Run(secret, func(t *testing.T) {
+				ok, err := validateBitbucketCloudSignature(es, sign(t, payload, []byte(secret)), payload)
+				if ok != want {
+					t.Errorf("unexpected ok: have %v; want %v", ok, want)
+				}
+				if err != nil {
+					t.Errorf("unexpected non-nil error: %+v", err)
+				}
+			})
+		}
+	})
+}
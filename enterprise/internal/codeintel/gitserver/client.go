@@ -227,16 +227,59 @@ func (c *Client) RefDescriptions(ctx context.Context, repositoryID int) (_ map[s
 	return parseRefDescriptions(strings.Split(out, "\n"))
 }
 
+// Refs returns a description of the tip of each branch and tag of the given repository. Unlike
+// RefDescriptions, multiple branches and tags pointing to the same commit are all returned.
+func (c *Client) Refs(ctx context.Context, repositoryID int) (_ []Ref, err error) {
+	ctx, endObservation := c.operations.refs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	args := []string{"for-each-ref", "--format=%(objectname):%(refname):%(HEAD):%(creatordate:iso8601-strict)"}
+	for prefix := range refPrefixes {
+		args = append(args, prefix)
+	}
+
+	out, err := c.execGitCommand(ctx, repositoryID, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRefs(strings.Split(out, "\n"))
+}
+
+// Ref describes the tip of a branch or tag along with the commit at the tip.
+type Ref struct {
+	Commit string
+	RefDescription
+}
+
 // parseRefDescriptions converts the output of the for-each-ref command in the RefDescriptions
-// method to a map from commits to RefDescription objects. Each line should conform to the format
-// string `%(objectname):%(refname):%(HEAD):%(creatordate)`, where
+// method to a map from commits to RefDescription objects. See parseRefs for the expected format.
+func parseRefDescriptions(lines []string) (map[string]RefDescription, error) {
+	refs, err := parseRefs(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	refDescriptions := make(map[string]RefDescription, len(refs))
+	for _, ref := range refs {
+		refDescriptions[ref.Commit] = ref.RefDescription
+	}
+
+	return refDescriptions, nil
+}
+
+// parseRefs converts the output of the for-each-ref command in the RefDescriptions and Refs
+// methods to a slice of Ref objects. Each line should conform to the format string
+// `%(objectname):%(refname):%(HEAD):%(creatordate)`, where
 //
 // - %(objectname) is the 40-character revhash
 // - %(refname) is the name of the tag or branch (prefixed with refs/heads/ or ref/tags/)
 // - %(HEAD) is `*` if the branch is the default branch (and whitesace otherwise)
 // - %(creatordate) is the ISO-formatted date the object was created
-func parseRefDescriptions(lines []string) (map[string]RefDescription, error) {
-	refDescriptions := make(map[string]RefDescription, len(lines))
+func parseRefs(lines []string) ([]Ref, error) {
+	refs := make([]Ref, 0, len(lines))
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		if line == "" {
@@ -269,15 +312,80 @@ func parseRefDescriptions(lines []string) (map[string]RefDescription, error) {
 			return nil, errors.Errorf(`unexpected output from git for-each-ref (bad date format) "%s"`, line)
 		}
 
-		refDescriptions[commit] = RefDescription{
-			Name:            name,
-			Type:            refType,
-			IsDefaultBranch: isDefaultBranch,
-			CreatedDate:     createdDate,
+		refs = append(refs, Ref{
+			Commit: commit,
+			RefDescription: RefDescription{
+				Name:            name,
+				Type:            refType,
+				IsDefaultBranch: isDefaultBranch,
+				CreatedDate:     createdDate,
+			},
+		})
+	}
+
+	return refs, nil
+}
+
+// CommitsOnBranch returns the commits reachable from the tip of the given branch of the given
+// repository along with their commit dates, most recent first. If maxCount is positive, at most
+// that many commits are returned. If since is non-zero, commits committed before that time are
+// omitted.
+func (c *Client) CommitsOnBranch(ctx context.Context, repositoryID int, branchName string, maxCount int, since time.Time) (_ []BranchCommit, err error) {
+	ctx, endObservation := c.operations.commitsOnBranch.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("branchName", branchName),
+		log.Int("maxCount", maxCount),
+		log.String("since", since.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	args := []string{"log", "--format=%H:%cI"}
+	if maxCount > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", maxCount))
+	}
+	if !since.IsZero() {
+		args = append(args, fmt.Sprintf("--since=%s", since.Format(time.RFC3339)))
+	}
+	args = append(args, "refs/heads/"+branchName, "--")
+
+	out, err := c.execGitCommand(ctx, repositoryID, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBranchCommits(strings.Split(out, "\n"))
+}
+
+// BranchCommit pairs a commit on a branch with its commit date.
+type BranchCommit struct {
+	Commit     string
+	CommitDate time.Time
+}
+
+// parseBranchCommits converts the output of the log command in the CommitsOnBranch method to a
+// slice of BranchCommit objects. Each line should conform to the format string `%H:%cI`.
+func parseBranchCommits(lines []string) ([]BranchCommit, error) {
+	commitDates := make([]BranchCommit, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf(`unexpected output from git log "%s"`, line)
 		}
+
+		commitDate, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			return nil, errors.Errorf(`unexpected output from git log (bad date format) "%s"`, line)
+		}
+
+		commitDates = append(commitDates, BranchCommit{Commit: parts[0], CommitDate: commitDate})
 	}
 
-	return refDescriptions, nil
+	return commitDates, nil
 }
 
 // RawContents returns the contents of a file in a particular commit of a repository.
@@ -207,3 +207,59 @@ func TestParseRefDescriptions(t *testing.T) {
 		t.Errorf("unexpected ref descriptions (-want +got):\n%s", diff)
 	}
 }
+
+func TestParseRefs(t *testing.T) {
+	refs, err := parseRefs([]string{
+		"ce30aee6cc56f39d0ac6fee03c4c151c08a8cd2e:refs/heads/master:*:2021-06-16T11:51:09-07:00",
+		"ce30aee6cc56f39d0ac6fee03c4c151c08a8cd2e:refs/tags/v1.5.0: :2021-05-20T18:41:41-05:00",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error parsing refs: %s", err)
+	}
+
+	mustParseDate := func(s string) time.Time {
+		date, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("unexpected error parsing date string: %s", err)
+		}
+
+		return date
+	}
+
+	expectedRefs := []Ref{
+		{
+			Commit:         "ce30aee6cc56f39d0ac6fee03c4c151c08a8cd2e",
+			RefDescription: RefDescription{Name: "master", Type: RefTypeBranch, IsDefaultBranch: true, CreatedDate: mustParseDate("2021-06-16T11:51:09-07:00")},
+		},
+		{
+			Commit:         "ce30aee6cc56f39d0ac6fee03c4c151c08a8cd2e",
+			RefDescription: RefDescription{Name: "v1.5.0", Type: RefTypeTag, IsDefaultBranch: false, CreatedDate: mustParseDate("2021-05-20T18:41:41-05:00")},
+		},
+	}
+	if diff := cmp.Diff(expectedRefs, refs); diff != "" {
+		t.Errorf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseBranchCommits(t *testing.T) {
+	branchCommits, err := parseBranchCommits([]string{
+		"ce30aee6cc56f39d0ac6fee03c4c151c08a8cd2e:2021-06-16T11:51:09-07:00",
+		"340b84452286c18000afad9b140a32212a82840a:2021-05-20T18:41:41-05:00",
+		"",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error parsing branch commits: %s", err)
+	}
+
+	expectedBranchCommits := []BranchCommit{
+		{Commit: "ce30aee6cc56f39d0ac6fee03c4c151c08a8cd2e", CommitDate: time.Date(2021, 6, 16, 18, 51, 9, 0, time.UTC)},
+		{Commit: "340b84452286c18000afad9b140a32212a82840a", CommitDate: time.Date(2021, 5, 20, 23, 41, 41, 0, time.UTC)},
+	}
+	if diff := cmp.Diff(expectedBranchCommits, branchCommits, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("unexpected branch commits (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseBranchCommits([]string{"ce30aee6cc56f39d0ac6fee03c4c151c08a8cd2e"}); err == nil {
+		t.Errorf("expected error parsing malformed line")
+	}
+}
@@ -13,6 +13,7 @@ import (
 type operations struct {
 	commitDate        *observation.Operation
 	commitExists      *observation.Operation
+	commitsOnBranch   *observation.Operation
 	commitGraph       *observation.Operation
 	directoryChildren *observation.Operation
 	fileExists        *observation.Operation
@@ -20,6 +21,7 @@ type operations struct {
 	listFiles         *observation.Operation
 	rawContents       *observation.Operation
 	refDescriptions   *observation.Operation
+	refs              *observation.Operation
 	resolveRevision   *observation.Operation
 }
 
@@ -45,6 +47,7 @@ func newOperations(observationContext *observation.Context) *operations {
 	return &operations{
 		commitDate:        op("CommitDate"),
 		commitExists:      op("CommitExists"),
+		commitsOnBranch:   op("CommitsOnBranch"),
 		commitGraph:       op("CommitGraph"),
 		directoryChildren: op("DirectoryChildren"),
 		fileExists:        op("FileExists"),
@@ -52,6 +55,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		listFiles:         op("ListFiles"),
 		rawContents:       op("RawContents"),
 		refDescriptions:   op("RefDescriptions"),
+		refs:              op("Refs"),
 		resolveRevision:   op("ResolveRevision"),
 	}
 }
@@ -0,0 +1,203 @@
+package policies
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+// Reason describes why a retention policy retains an upload.
+type Reason struct {
+	Policy store.RetentionPolicy
+	// RefName is the name of the branch or tag matched by the policy.
+	RefName string
+	// ExpiresAt is the time after which the policy no longer retains the upload for this
+	// branch or tag. The upload is retained forever if nil.
+	ExpiresAt *time.Time
+}
+
+// Retention is the result of evaluating the retention policies of a repository against one
+// of its uploads.
+type Retention struct {
+	Retained bool
+	Reasons  []Reason
+}
+
+// ExpiresAt returns the time after which no policy retains the upload any longer. A nil time
+// is returned if the upload is not retained or if some policy retains it forever.
+func (r Retention) ExpiresAt() *time.Time {
+	var expiresAt *time.Time
+	for _, reason := range r.Reasons {
+		if reason.ExpiresAt == nil {
+			return nil
+		}
+		if expiresAt == nil || reason.ExpiresAt.After(*expiresAt) {
+			expiresAt = reason.ExpiresAt
+		}
+	}
+
+	return expiresAt
+}
+
+// MaxRetainCommitCount is the maximum number of commits of a branch that a retention policy
+// considers. It bounds the history walked for each matching branch, even for policies created
+// before branch policies required a bound.
+const MaxRetainCommitCount = 5000
+
+// ValidatePolicy returns an error if the given retention policy cannot be evaluated.
+func ValidatePolicy(policy store.RetentionPolicy) error {
+	if policy.Type != store.RetentionPolicyTypeBranch && policy.Type != store.RetentionPolicyTypeTag {
+		return errors.Errorf("illegal retention policy type %q", policy.Type)
+	}
+	if policy.RepositoryPattern != nil {
+		if _, err := glob.Compile(*policy.RepositoryPattern); err != nil {
+			return errors.Wrap(err, "illegal repository pattern")
+		}
+	}
+	if _, err := glob.Compile(policy.Pattern); err != nil {
+		return errors.Wrap(err, "illegal pattern")
+	}
+	if policy.RetentionDuration != nil && *policy.RetentionDuration < 0 {
+		return errors.New("illegal negative retention duration")
+	}
+	if policy.RetainCommitCount != nil && *policy.RetainCommitCount <= 0 {
+		return errors.New("illegal non-positive retain commit count")
+	}
+	if policy.RetainCommitCount != nil && *policy.RetainCommitCount > MaxRetainCommitCount {
+		return errors.Errorf("illegal retain commit count greater than %d", MaxRetainCommitCount)
+	}
+	if policy.Type == store.RetentionPolicyTypeBranch && policy.RetainCommitCount == nil && policy.RetentionDuration == nil {
+		return errors.New("branch retention policies require a retention duration or a retain commit count")
+	}
+
+	return nil
+}
+
+// MatchingPolicies returns the subset of the given policies that apply to the given repository.
+func MatchingPolicies(policies []store.RetentionPolicy, repositoryName string) ([]store.RetentionPolicy, error) {
+	var matching []store.RetentionPolicy
+	for _, policy := range policies {
+		if policy.RepositoryPattern != nil {
+			g, err := glob.Compile(*policy.RepositoryPattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "retention policy %d", policy.ID)
+			}
+			if !g.Match(repositoryName) {
+				continue
+			}
+		}
+
+		matching = append(matching, policy)
+	}
+
+	return matching, nil
+}
+
+// Evaluate determines which of the given uploads of a repository are retained by the given
+// retention policies at the given time. The returned map is keyed by upload identifier.
+//
+// A repository is governed by its retention policies if at least one of them applies to it. In
+// that case every upload is either retained by at least one policy or has expired. If no policy
+// applies to the repository, a false-valued flag is returned and the default retention period
+// applies to all of its uploads.
+func Evaluate(
+	ctx context.Context,
+	gitserverClient GitserverClient,
+	policies []store.RetentionPolicy,
+	repositoryID int,
+	repositoryName string,
+	uploads []store.Upload,
+	now time.Time,
+) (_ map[int]Retention, governed bool, err error) {
+	policies, err = MatchingPolicies(policies, repositoryName)
+	if err != nil || len(policies) == 0 {
+		return nil, false, err
+	}
+
+	refs, err := gitserverClient.Refs(ctx, repositoryID)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "gitserver.Refs")
+	}
+
+	reasonsByCommit := map[string][]Reason{}
+	for _, policy := range policies {
+		g, err := glob.Compile(policy.Pattern)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "retention policy %d", policy.ID)
+		}
+
+		for _, ref := range refs {
+			if !matchesRef(policy, g, ref) {
+				continue
+			}
+
+			if ref.Type == gitserver.RefTypeTag {
+				if expiresAt, ok := expiration(policy, ref.CreatedDate, now); ok {
+					reasonsByCommit[ref.Commit] = append(reasonsByCommit[ref.Commit], Reason{Policy: policy, RefName: ref.Name, ExpiresAt: expiresAt})
+				}
+
+				continue
+			}
+
+			maxCount := MaxRetainCommitCount
+			if policy.RetainCommitCount != nil && *policy.RetainCommitCount < maxCount {
+				maxCount = *policy.RetainCommitCount
+			}
+			var since time.Time
+			if policy.RetentionDuration != nil {
+				since = now.Add(-*policy.RetentionDuration)
+			}
+
+			commits, err := gitserverClient.CommitsOnBranch(ctx, repositoryID, ref.Name, maxCount, since)
+			if err != nil {
+				return nil, false, errors.Wrap(err, "gitserver.CommitsOnBranch")
+			}
+
+			for _, commit := range commits {
+				if expiresAt, ok := expiration(policy, commit.CommitDate, now); ok {
+					reasonsByCommit[commit.Commit] = append(reasonsByCommit[commit.Commit], Reason{Policy: policy, RefName: ref.Name, ExpiresAt: expiresAt})
+				}
+			}
+		}
+	}
+
+	retention := make(map[int]Retention, len(uploads))
+	for _, upload := range uploads {
+		reasons := reasonsByCommit[upload.Commit]
+		retention[upload.ID] = Retention{Retained: len(reasons) > 0, Reasons: reasons}
+	}
+
+	return retention, true, nil
+}
+
+// matchesRef returns true if the given policy applies to the given branch or tag.
+func matchesRef(policy store.RetentionPolicy, g glob.Glob, ref gitserver.Ref) bool {
+	switch ref.Type {
+	case gitserver.RefTypeBranch:
+		return policy.Type == store.RetentionPolicyTypeBranch && g.Match(ref.Name)
+	case gitserver.RefTypeTag:
+		return policy.Type == store.RetentionPolicyTypeTag && g.Match(ref.Name)
+	}
+
+	return false
+}
+
+// expiration returns the time after which the given policy no longer retains a commit with the
+// given date. A false-valued flag is returned if that time has already passed.
+func expiration(policy store.RetentionPolicy, date, now time.Time) (*time.Time, bool) {
+	if policy.RetentionDuration == nil {
+		return nil, true
+	}
+
+	expiresAt := date.Add(*policy.RetentionDuration)
+	if !expiresAt.After(now) {
+		return nil, false
+	}
+
+	return &expiresAt, true
+}
@@ -0,0 +1,180 @@
+package policies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+type testGitserverClient struct {
+	refs     []gitserver.Ref
+	branches map[string][]gitserver.BranchCommit
+}
+
+func (c *testGitserverClient) Refs(ctx context.Context, repositoryID int) ([]gitserver.Ref, error) {
+	return c.refs, nil
+}
+
+func (c *testGitserverClient) CommitsOnBranch(ctx context.Context, repositoryID int, branchName string, maxCount int, since time.Time) ([]gitserver.BranchCommit, error) {
+	var commits []gitserver.BranchCommit
+	for _, commit := range c.branches[branchName] {
+		if maxCount > 0 && len(commits) >= maxCount {
+			break
+		}
+		if !since.IsZero() && commit.CommitDate.Before(since) {
+			continue
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+	day := 24 * time.Hour
+	ninetyDays := 90 * day
+	sevenDays := 7 * day
+	two := 2
+	repositoryPattern := "github.com/sourcegraph/*"
+
+	releases := store.RetentionPolicy{ID: 1, Name: "releases", Type: store.RetentionPolicyTypeTag, Pattern: "v*"}
+	main := store.RetentionPolicy{ID: 2, Name: "main", RepositoryPattern: &repositoryPattern, Type: store.RetentionPolicyTypeBranch, Pattern: "main", RetentionDuration: &ninetyDays}
+	features := store.RetentionPolicy{ID: 3, Name: "features", Type: store.RetentionPolicyTypeBranch, Pattern: "feature/*", RetentionDuration: &sevenDays, RetainCommitCount: &two}
+
+	gitserverClient := &testGitserverClient{
+		refs: []gitserver.Ref{
+			{Commit: "c1", RefDescription: gitserver.RefDescription{Name: "v1.0.0", Type: gitserver.RefTypeTag, CreatedDate: now.Add(-365 * day)}},
+			{Commit: "c2", RefDescription: gitserver.RefDescription{Name: "main", Type: gitserver.RefTypeBranch, IsDefaultBranch: true}},
+			{Commit: "c5", RefDescription: gitserver.RefDescription{Name: "feature/foo", Type: gitserver.RefTypeBranch}},
+		},
+		branches: map[string][]gitserver.BranchCommit{
+			"main": {
+				{Commit: "c2", CommitDate: now.Add(-10 * day)},
+				{Commit: "c3", CommitDate: now.Add(-100 * day)},
+			},
+			"feature/foo": {
+				{Commit: "c5", CommitDate: now.Add(-1 * day)},
+				{Commit: "c6", CommitDate: now.Add(-2 * day)},
+				{Commit: "c7", CommitDate: now.Add(-3 * day)},
+			},
+		},
+	}
+
+	uploads := []store.Upload{
+		{ID: 11, Commit: "c1"},
+		{ID: 12, Commit: "c2"},
+		{ID: 13, Commit: "c3"},
+		{ID: 14, Commit: "c4"},
+		{ID: 15, Commit: "c5"},
+		{ID: 16, Commit: "c7"},
+	}
+
+	retention, governed, err := Evaluate(context.Background(), gitserverClient, []store.RetentionPolicy{releases, main, features}, 50, "github.com/sourcegraph/sourcegraph", uploads, now)
+	if err != nil {
+		t.Fatalf("unexpected error evaluating policies: %s", err)
+	}
+	if !governed {
+		t.Fatalf("expected repository to be governed by retention policies")
+	}
+
+	expiresAt := func(date time.Time) *time.Time { return &date }
+	expectedRetention := map[int]Retention{
+		11: {Retained: true, Reasons: []Reason{{Policy: releases, RefName: "v1.0.0"}}},
+		12: {Retained: true, Reasons: []Reason{{Policy: main, RefName: "main", ExpiresAt: expiresAt(now.Add(80 * day))}}},
+		13: {Retained: false},
+		14: {Retained: false},
+		15: {Retained: true, Reasons: []Reason{{Policy: features, RefName: "feature/foo", ExpiresAt: expiresAt(now.Add(6 * day))}}},
+		16: {Retained: false},
+	}
+	if diff := cmp.Diff(expectedRetention, retention); diff != "" {
+		t.Errorf("unexpected retention (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvaluateUngoverned(t *testing.T) {
+	repositoryPattern := "github.com/sourcegraph/*"
+	policies := []store.RetentionPolicy{
+		{ID: 1, Name: "main", RepositoryPattern: &repositoryPattern, Type: store.RetentionPolicyTypeBranch, Pattern: "main"},
+	}
+
+	_, governed, err := Evaluate(context.Background(), &testGitserverClient{}, policies, 50, "github.com/golang/go", []store.Upload{{ID: 1}}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error evaluating policies: %s", err)
+	}
+	if governed {
+		t.Errorf("expected repository not to be governed by retention policies")
+	}
+}
+
+func TestRetentionExpiresAt(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+	t1 := now.Add(time.Hour)
+	t2 := now.Add(time.Hour * 2)
+
+	if expiresAt := (Retention{Retained: true, Reasons: []Reason{{ExpiresAt: &t1}, {ExpiresAt: &t2}}}).ExpiresAt(); expiresAt == nil || !expiresAt.Equal(t2) {
+		t.Errorf("unexpected expiration: want=%s have=%v", t2, expiresAt)
+	}
+	if expiresAt := (Retention{Retained: true, Reasons: []Reason{{ExpiresAt: &t1}, {}}}).ExpiresAt(); expiresAt != nil {
+		t.Errorf("unexpected expiration: want=nil have=%s", expiresAt)
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	zero := 0
+	tooMany := MaxRetainCommitCount + 1
+	badPattern := "github.com/[sourcegraph"
+
+	for _, policy := range []store.RetentionPolicy{
+		{Type: "GIT_COMMIT", Pattern: "*"},
+		{Type: store.RetentionPolicyTypeBranch, Pattern: "[main"},
+		{Type: store.RetentionPolicyTypeBranch, Pattern: "main", RepositoryPattern: &badPattern},
+		{Type: store.RetentionPolicyTypeBranch, Pattern: "main", RetainCommitCount: &zero},
+		{Type: store.RetentionPolicyTypeBranch, Pattern: "main", RetainCommitCount: &tooMany},
+		{Type: store.RetentionPolicyTypeBranch, Pattern: "main"},
+	} {
+		if err := ValidatePolicy(policy); err == nil {
+			t.Errorf("expected policy %+v to be invalid", policy)
+		}
+	}
+
+	if err := ValidatePolicy(store.RetentionPolicy{Type: store.RetentionPolicyTypeTag, Pattern: "v*"}); err != nil {
+		t.Errorf("unexpected error validating policy: %s", err)
+	}
+}
+
+func TestEvaluateCapsBranchHistory(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+	// Policies created before branch policies required a bound.
+	unbounded := store.RetentionPolicy{ID: 1, Name: "main", Type: store.RetentionPolicyTypeBranch, Pattern: "main"}
+
+	var maxCounts []int
+	gitserverClient := &capturingGitserverClient{
+		testGitserverClient: testGitserverClient{
+			refs: []gitserver.Ref{{Commit: "c1", RefDescription: gitserver.RefDescription{Name: "main", Type: gitserver.RefTypeBranch}}},
+		},
+		maxCounts: &maxCounts,
+	}
+
+	if _, _, err := Evaluate(context.Background(), gitserverClient, []store.RetentionPolicy{unbounded}, 50, "github.com/sourcegraph/sourcegraph", nil, now); err != nil {
+		t.Fatalf("unexpected error evaluating policies: %s", err)
+	}
+	if diff := cmp.Diff([]int{MaxRetainCommitCount}, maxCounts); diff != "" {
+		t.Errorf("unexpected max counts (-want +got):\n%s", diff)
+	}
+}
+
+type capturingGitserverClient struct {
+	testGitserverClient
+	maxCounts *[]int
+}
+
+func (c *capturingGitserverClient) CommitsOnBranch(ctx context.Context, repositoryID int, branchName string, maxCount int, since time.Time) ([]gitserver.BranchCommit, error) {
+	*c.maxCounts = append(*c.maxCounts, maxCount)
+	return c.testGitserverClient.CommitsOnBranch(ctx, repositoryID, branchName, maxCount, since)
+}
@@ -0,0 +1,13 @@
+package policies
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+)
+
+type GitserverClient interface {
+	Refs(ctx context.Context, repositoryID int) ([]gitserver.Ref, error)
+	CommitsOnBranch(ctx context.Context, repositoryID int, branchName string, maxCount int, since time.Time) ([]gitserver.BranchCommit, error)
+}
@@ -266,6 +266,11 @@ func (s *Store) CalculateVisibleUploads(
 		return err
 	}
 
+	// Persist data to permanent table: lsif_uploads_visible_at_tip -> lsif_repository_dependencies
+	if err := tx.persistRepositoryDependencies(ctx, repositoryID); err != nil {
+		return err
+	}
+
 	if dirtyToken != 0 {
 		// If the user requests us to clear a dirty token, set the updated_token value to
 		// the dirty token if it wouldn't decrease the value. Dirty repositories are determined
@@ -0,0 +1,307 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// Diagnostic is a diagnostic of a completed upload that has been copied into the lsif_diagnostics
+// table. Unlike the diagnostics stored in the codeintel database, the path is relative to the root
+// of the repository (not the root of the upload).
+type Diagnostic struct {
+	ID             int
+	UploadID       int
+	RepositoryID   int
+	RepositoryName string
+	Commit         string
+	Path           string
+	semantic.DiagnosticData
+}
+
+// scanDiagnostics scans a slice of diagnostics from the return value of `*Store.query`.
+func scanDiagnostics(rows *sql.Rows, queryErr error) (_ []Diagnostic, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var diagnostics []Diagnostic
+	for rows.Next() {
+		var diagnostic Diagnostic
+		if err := rows.Scan(
+			&diagnostic.ID,
+			&diagnostic.UploadID,
+			&diagnostic.RepositoryID,
+			&diagnostic.RepositoryName,
+			&diagnostic.Commit,
+			&diagnostic.Path,
+			&diagnostic.Severity,
+			&diagnostic.Code,
+			&diagnostic.Source,
+			&diagnostic.Message,
+			&diagnostic.StartLine,
+			&diagnostic.StartCharacter,
+			&diagnostic.EndLine,
+			&diagnostic.EndCharacter,
+		); err != nil {
+			return nil, err
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	return diagnostics, nil
+}
+
+type GetDiagnosticsOptions struct {
+	// RepositoryIDs restricts the diagnostics to the given repositories, if non-empty.
+	RepositoryIDs []int
+	// Severity restricts the diagnostics to the given LSP severity, if non-zero.
+	Severity int
+	Source   string
+	Code     string
+	// PathPatterns are POSIX regular expressions that must all match the repository-relative
+	// path of the diagnostic's document.
+	PathPatterns []string
+	// ExcludePathPattern is a POSIX regular expression that must not match the repository-relative
+	// path of the diagnostic's document, if non-empty.
+	ExcludePathPattern string
+	// PathPatternsAreCaseSensitive controls whether path patterns are matched case-sensitively.
+	PathPatternsAreCaseSensitive bool
+	// MessagePattern is a POSIX regular expression matched against the diagnostic's message,
+	// if non-empty.
+	MessagePattern string
+	// MessagePatternIsCaseSensitive controls whether the message pattern is matched case-sensitively.
+	MessagePatternIsCaseSensitive bool
+	Limit                         int
+	Offset                        int
+}
+
+// GetDiagnostics returns a page of indexed diagnostics matching the given conditions, and the total
+// count of such diagnostics. Only the diagnostics of completed uploads visible from the tip of the
+// default branch of their repository are considered, so that the result reflects the current state
+// of each repository.
+func (s *Store) GetDiagnostics(ctx context.Context, opts GetDiagnosticsOptions) (_ []Diagnostic, _ int, err error) {
+	ctx, traceLog, endObservation := s.operations.getDiagnostics.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numRepositoryIDs", len(opts.RepositoryIDs)),
+		log.Int("severity", opts.Severity),
+		log.String("source", opts.Source),
+		log.String("code", opts.Code),
+		log.String("pathPatterns", strings.Join(opts.PathPatterns, ", ")),
+		log.String("excludePathPattern", opts.ExcludePathPattern),
+		log.String("messagePattern", opts.MessagePattern),
+		log.Int("limit", opts.Limit),
+		log.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var conds []*sqlf.Query
+	if len(opts.RepositoryIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("u.repository_id = ANY(%s)", pq.Array(opts.RepositoryIDs)))
+	}
+	if opts.Severity != 0 {
+		conds = append(conds, sqlf.Sprintf("d.severity = %s", opts.Severity))
+	}
+	if opts.Source != "" {
+		conds = append(conds, sqlf.Sprintf("d.source = %s", opts.Source))
+	}
+	if opts.Code != "" {
+		conds = append(conds, sqlf.Sprintf("d.code = %s", opts.Code))
+	}
+	for _, pattern := range opts.PathPatterns {
+		conds = append(conds, sqlf.Sprintf("d.path "+regexpOperator(opts.PathPatternsAreCaseSensitive)+" %s", pattern))
+	}
+	if opts.ExcludePathPattern != "" {
+		conds = append(conds, sqlf.Sprintf("d.path !"+regexpOperator(opts.PathPatternsAreCaseSensitive)+" %s", opts.ExcludePathPattern))
+	}
+	if opts.MessagePattern != "" {
+		conds = append(conds, sqlf.Sprintf("d.message "+regexpOperator(opts.MessagePatternIsCaseSensitive)+" %s", opts.MessagePattern))
+	}
+
+	authzConds, err := database.AuthzQueryConds(ctx, tx.Store.Handle().DB())
+	if err != nil {
+		return nil, 0, err
+	}
+	conds = append(conds, authzConds)
+
+	totalCount, _, err := basestore.ScanFirstInt(tx.Store.Query(ctx, sqlf.Sprintf(getDiagnosticsCountQuery, sqlf.Join(conds, " AND "))))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	diagnostics, err := scanDiagnostics(tx.Store.Query(ctx, sqlf.Sprintf(getDiagnosticsQuery, sqlf.Join(conds, " AND "), opts.Limit, opts.Offset)))
+	if err != nil {
+		return nil, 0, err
+	}
+	traceLog(
+		log.Int("totalCount", totalCount),
+		log.Int("numDiagnostics", len(diagnostics)),
+	)
+
+	return diagnostics, totalCount, nil
+}
+
+// regexpOperator returns the Postgres regular expression match operator with the given case
+// sensitivity.
+func regexpOperator(caseSensitive bool) string {
+	if caseSensitive {
+		return "~"
+	}
+
+	return "~*"
+}
+
+const diagnosticsVisibleAtTipFragment = `
+lsif_diagnostics d
+JOIN lsif_uploads u ON u.id = d.upload_id
+JOIN repo ON repo.id = u.repository_id
+WHERE
+	u.state = 'completed' AND
+	repo.deleted_at IS NULL AND
+	EXISTS (
+		SELECT 1
+		FROM lsif_uploads_visible_at_tip uvt
+		WHERE uvt.repository_id = u.repository_id AND uvt.upload_id = u.id AND uvt.is_default_branch
+	) AND
+`
+
+const getDiagnosticsCountQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/diagnostics.go:GetDiagnostics
+SELECT COUNT(*)
+FROM ` + diagnosticsVisibleAtTipFragment + ` %s
+`
+
+const getDiagnosticsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/diagnostics.go:GetDiagnostics
+SELECT
+	d.id,
+	d.upload_id,
+	u.repository_id,
+	repo.name,
+	u.commit,
+	d.path,
+	d.severity,
+	d.code,
+	d.source,
+	d.message,
+	d.start_line,
+	d.start_character,
+	d.end_line,
+	d.end_character
+FROM ` + diagnosticsVisibleAtTipFragment + ` %s
+ORDER BY repo.name, d.path, d.start_line, d.start_character, d.id
+LIMIT %s OFFSET %s
+`
+
+// UploadIDsWithUnindexedDiagnostics returns the identifiers of completed uploads whose diagnostics have
+// not yet been copied into the lsif_diagnostics table, oldest first.
+func (s *Store) UploadIDsWithUnindexedDiagnostics(ctx context.Context, limit int) (_ []int, err error) {
+	ctx, traceLog, endObservation := s.operations.uploadIDsWithUnindexedDiagnostics.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	ids, err := basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(uploadIDsWithUnindexedDiagnosticsQuery, limit)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numUploads", len(ids)))
+
+	return ids, nil
+}
+
+const uploadIDsWithUnindexedDiagnosticsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/diagnostics.go:UploadIDsWithUnindexedDiagnostics
+SELECT u.id
+FROM lsif_uploads u
+WHERE u.state = 'completed' AND u.diagnostics_indexed_at IS NULL
+ORDER BY u.id
+LIMIT %s
+`
+
+// UpdateDiagnostics replaces the indexed diagnostics of the given upload and marks the upload's
+// diagnostics as indexed. The paths of the given diagnostics must be relative to the repository root.
+func (s *Store) UpdateDiagnostics(ctx context.Context, uploadID int, diagnostics []Diagnostic, now time.Time) (err error) {
+	ctx, endObservation := s.operations.updateDiagnostics.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+		log.Int("numDiagnostics", len(diagnostics)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteDiagnosticsQuery, uploadID)); err != nil {
+		return err
+	}
+
+	if len(diagnostics) > 0 {
+		if err := batch.InsertValues(
+			ctx,
+			tx.Handle().DB(),
+			"lsif_diagnostics",
+			[]string{"upload_id", "path", "severity", "code", "source", "message", "start_line", "start_character", "end_line", "end_character"},
+			loadDiagnosticsChannel(uploadID, diagnostics),
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(markDiagnosticsIndexedQuery, now, uploadID))
+}
+
+const deleteDiagnosticsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/diagnostics.go:UpdateDiagnostics
+DELETE FROM lsif_diagnostics WHERE upload_id = %s
+`
+
+const markDiagnosticsIndexedQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/diagnostics.go:UpdateDiagnostics
+UPDATE lsif_uploads SET diagnostics_indexed_at = %s WHERE id = %s
+`
+
+func loadDiagnosticsChannel(uploadID int, diagnostics []Diagnostic) <-chan []interface{} {
+	ch := make(chan []interface{}, len(diagnostics))
+
+	go func() {
+		defer close(ch)
+
+		for _, d := range diagnostics {
+			ch <- []interface{}{
+				uploadID,
+				d.Path,
+				d.Severity,
+				d.Code,
+				d.Source,
+				d.Message,
+				d.StartLine,
+				d.StartCharacter,
+				d.EndLine,
+				d.EndCharacter,
+			}
+		}
+	}()
+
+	return ch
+}
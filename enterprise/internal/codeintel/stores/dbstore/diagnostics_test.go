@@ -0,0 +1,120 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestDiagnostics(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50, RepositoryName: "n-50"},
+		Upload{ID: 2, RepositoryID: 51, RepositoryName: "n-51"},
+		Upload{ID: 3, RepositoryID: 51, RepositoryName: "n-51", Root: "sub/"},
+		Upload{ID: 4, RepositoryID: 52, RepositoryName: "n-52", State: "errored"},
+	)
+	insertVisibleAtTip(t, db, 50, 1)
+	insertVisibleAtTip(t, db, 51, 2)
+	insertVisibleAtTipNonDefaultBranch(t, db, 51, 3)
+
+	if ids, err := store.UploadIDsWithUnindexedDiagnostics(context.Background(), 10); err != nil {
+		t.Fatalf("unexpected error getting uploads with unindexed diagnostics: %s", err)
+	} else if diff := cmp.Diff([]int{1, 2, 3}, ids); diff != "" {
+		t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+
+	diagnostic := func(path string, severity int, source, code, message string) Diagnostic {
+		return Diagnostic{Path: path, DiagnosticData: semantic.DiagnosticData{
+			Severity: severity,
+			Source:   source,
+			Code:     code,
+			Message:  message,
+		}}
+	}
+
+	for uploadID, diagnostics := range map[int][]Diagnostic{
+		1: {diagnostic("a.go", 1, "go", "E1", "undefined: foo"), diagnostic("b.go", 2, "staticcheck", "SA1019", "deprecated")},
+		2: {diagnostic("c.go", 2, "staticcheck", "SA1019", "Deprecated API")},
+		3: {diagnostic("sub/d.go", 2, "staticcheck", "SA1019", "deprecated")},
+	} {
+		if err := store.UpdateDiagnostics(context.Background(), uploadID, diagnostics, time.Now()); err != nil {
+			t.Fatalf("unexpected error updating diagnostics: %s", err)
+		}
+	}
+
+	if ids, err := store.UploadIDsWithUnindexedDiagnostics(context.Background(), 10); err != nil {
+		t.Fatalf("unexpected error getting uploads with unindexed diagnostics: %s", err)
+	} else if len(ids) != 0 {
+		t.Errorf("unexpected upload ids. want=%v have=%v", nil, ids)
+	}
+
+	testCases := []struct {
+		opts          GetDiagnosticsOptions
+		expectedPaths []string
+	}{
+		// upload 3 is not visible at the tip of the default branch
+		{GetDiagnosticsOptions{}, []string{"a.go", "b.go", "c.go"}},
+		{GetDiagnosticsOptions{RepositoryIDs: []int{51}}, []string{"c.go"}},
+		{GetDiagnosticsOptions{Severity: 2}, []string{"b.go", "c.go"}},
+		{GetDiagnosticsOptions{Source: "go"}, []string{"a.go"}},
+		{GetDiagnosticsOptions{Code: "SA1019"}, []string{"b.go", "c.go"}},
+		{GetDiagnosticsOptions{PathPatterns: []string{`^[ab]\.go$`}}, []string{"a.go", "b.go"}},
+		{GetDiagnosticsOptions{PathPatterns: []string{`\.go$`, `^[ab]`}, ExcludePathPattern: `^b`}, []string{"a.go"}},
+		{GetDiagnosticsOptions{PathPatterns: []string{`^A\.GO$`}}, []string{"a.go"}},
+		{GetDiagnosticsOptions{PathPatterns: []string{`^A\.GO$`}, PathPatternsAreCaseSensitive: true}, nil},
+		{GetDiagnosticsOptions{MessagePattern: "deprecated"}, []string{"b.go", "c.go"}},
+		{GetDiagnosticsOptions{MessagePattern: "deprecated", MessagePatternIsCaseSensitive: true}, []string{"b.go"}},
+	}
+
+	for _, testCase := range testCases {
+		opts := testCase.opts
+		opts.Limit = 10
+
+		diagnostics, totalCount, err := store.GetDiagnostics(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("unexpected error getting diagnostics: %s", err)
+		}
+		if totalCount != len(testCase.expectedPaths) {
+			t.Errorf("unexpected total count for %+v. want=%d have=%d", testCase.opts, len(testCase.expectedPaths), totalCount)
+		}
+
+		var paths []string
+		for _, diagnostic := range diagnostics {
+			paths = append(paths, diagnostic.Path)
+		}
+		if diff := cmp.Diff(testCase.expectedPaths, paths); diff != "" {
+			t.Errorf("unexpected paths for %+v (-want +got):\n%s", testCase.opts, diff)
+		}
+	}
+
+	// Re-indexing replaces the previous diagnostics
+	if err := store.UpdateDiagnostics(context.Background(), 1, nil, time.Now()); err != nil {
+		t.Fatalf("unexpected error updating diagnostics: %s", err)
+	}
+
+	diagnostics, _, err := store.GetDiagnostics(context.Background(), GetDiagnosticsOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error getting diagnostics: %s", err)
+	}
+
+	expected := []Diagnostic{diagnostic("c.go", 2, "staticcheck", "SA1019", "Deprecated API")}
+	expected[0].UploadID = 2
+	expected[0].RepositoryID = 51
+	expected[0].RepositoryName = "n-51"
+	expected[0].Commit = makeCommit(2)
+	if diff := cmp.Diff(expected, diagnostics, cmpopts.IgnoreFields(Diagnostic{}, "ID")); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+}
@@ -254,6 +254,25 @@ func insertVisibleAtTipInternal(t testing.TB, db *sql.DB, repositoryID int, isDe
 	}
 }
 
+// insertRepositoryDependencies populates the lsif_repository_dependencies table with the given edges,
+// which map a repository identifier to the identifiers of the repositories it depends on.
+func insertRepositoryDependencies(t testing.TB, db *sql.DB, dependencies map[int][]int) {
+	var rows []*sqlf.Query
+	for repositoryID, dependencyRepositoryIDs := range dependencies {
+		for _, dependencyRepositoryID := range dependencyRepositoryIDs {
+			rows = append(rows, sqlf.Sprintf("(%s, %s)", repositoryID, dependencyRepositoryID))
+		}
+	}
+
+	query := sqlf.Sprintf(
+		`INSERT INTO lsif_repository_dependencies (repository_id, dependency_repository_id) VALUES %s`,
+		sqlf.Join(rows, ","),
+	)
+	if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+		t.Fatalf("unexpected error while inserting repository dependencies: %s", err)
+	}
+}
+
 // insertNearestUploads populates the lsif_nearest_uploads table with the given upload metadata.
 func insertNearestUploads(t testing.TB, db *sql.DB, repositoryID int, uploads map[string][]commitgraph.UploadMeta) {
 	var rows []*sqlf.Query
@@ -11,11 +11,14 @@ type operations struct {
 	addUploadPart                          *observation.Operation
 	calculateVisibleUploads                *observation.Operation
 	commitGraphMetadata                    *observation.Operation
+	copyPackageData                        *observation.Operation
+	createRetentionPolicy                  *observation.Operation
 	definitionDumps                        *observation.Operation
 	deleteIndexByID                        *observation.Operation
 	deleteIndexesWithoutRepository         *observation.Operation
 	deleteOldIndexes                       *observation.Operation
 	deleteOverlappingDumps                 *observation.Operation
+	deleteRetentionPolicyByID              *observation.Operation
 	deleteUploadByID                       *observation.Operation
 	deleteUploadsStuckUploading            *observation.Operation
 	deleteUploadsWithoutRepository         *observation.Operation
@@ -25,13 +28,18 @@ type operations struct {
 	findClosestDumps                       *observation.Operation
 	findClosestDumpsFromGraphFragment      *observation.Operation
 	getAutoindexDisabledRepositories       *observation.Operation
+	getDiagnostics                         *observation.Operation
 	getDumpsByIDs                          *observation.Operation
 	getIndexByID                           *observation.Operation
 	getIndexConfigurationByRepositoryID    *observation.Operation
 	getIndexes                             *observation.Operation
 	getIndexesByIDs                        *observation.Operation
 	getOldestCommitDate                    *observation.Operation
+	getPackageDependencies                 *observation.Operation
+	getPackageDependents                   *observation.Operation
 	getRepositoriesWithIndexConfiguration  *observation.Operation
+	getRetentionPolicies                   *observation.Operation
+	getRetentionPolicyByID                 *observation.Operation
 	getUploadByID                          *observation.Operation
 	getUploads                             *observation.Operation
 	getUploadsByIDs                        *observation.Operation
@@ -50,24 +58,31 @@ type operations struct {
 	markIndexErrored                       *observation.Operation
 	markQueued                             *observation.Operation
 	markRepositoryAsDirty                  *observation.Operation
+	packageReferenceVersions               *observation.Operation
 	queueSize                              *observation.Operation
 	referenceIDsAndFilters                 *observation.Operation
 	referencesForUpload                    *observation.Operation
 	refreshCommitResolvability             *observation.Operation
 	repoName                               *observation.Operation
+	repositoryIDsForRetentionEvaluation    *observation.Operation
 	requeue                                *observation.Operation
 	requeueIndex                           *observation.Operation
 	softDeleteOldUploads                   *observation.Operation
 	staleSourcedCommits                    *observation.Operation
 	updateCommitedAt                       *observation.Operation
+	updateDiagnostics                      *observation.Operation
 	updateIndexConfigurationByRepositoryID *observation.Operation
 	updatePackageReferences                *observation.Operation
 	updatePackages                         *observation.Operation
+	updateRetentionPolicy                  *observation.Operation
+	updateUploadRetention                  *observation.Operation
+	uploadIDsWithUnindexedDiagnostics      *observation.Operation
 
-	writeVisibleUploads        *observation.Operation
-	persistNearestUploads      *observation.Operation
-	persistNearestUploadsLinks *observation.Operation
-	persistUploadsVisibleAtTip *observation.Operation
+	writeVisibleUploads           *observation.Operation
+	persistNearestUploads         *observation.Operation
+	persistNearestUploadsLinks    *observation.Operation
+	persistUploadsVisibleAtTip    *observation.Operation
+	persistRepositoryDependencies *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -99,11 +114,14 @@ func newOperations(observationContext *observation.Context) *operations {
 		addUploadPart:                          op("AddUploadPart"),
 		calculateVisibleUploads:                op("CalculateVisibleUploads"),
 		commitGraphMetadata:                    op("CommitGraphMetadata"),
+		copyPackageData:                        op("CopyPackageData"),
+		createRetentionPolicy:                  op("CreateRetentionPolicy"),
 		definitionDumps:                        op("DefinitionDumps"),
 		deleteIndexByID:                        op("DeleteIndexByID"),
 		deleteIndexesWithoutRepository:         op("DeleteIndexesWithoutRepository"),
 		deleteOldIndexes:                       op("DeleteOldIndexes"),
 		deleteOverlappingDumps:                 op("DeleteOverlappingDumps"),
+		deleteRetentionPolicyByID:              op("DeleteRetentionPolicyByID"),
 		deleteUploadByID:                       op("DeleteUploadByID"),
 		deleteUploadsStuckUploading:            op("DeleteUploadsStuckUploading"),
 		deleteUploadsWithoutRepository:         op("DeleteUploadsWithoutRepository"),
@@ -113,13 +131,18 @@ func newOperations(observationContext *observation.Context) *operations {
 		findClosestDumps:                       op("FindClosestDumps"),
 		findClosestDumpsFromGraphFragment:      op("FindClosestDumpsFromGraphFragment"),
 		getAutoindexDisabledRepositories:       op("getAutoindexDisabledRepositories"),
+		getDiagnostics:                         op("GetDiagnostics"),
 		getDumpsByIDs:                          op("GetDumpsByIDs"),
 		getIndexByID:                           op("GetIndexByID"),
 		getIndexConfigurationByRepositoryID:    op("GetIndexConfigurationByRepositoryID"),
 		getIndexes:                             op("GetIndexes"),
 		getIndexesByIDs:                        op("GetIndexesByIDs"),
 		getOldestCommitDate:                    op("GetOldestCommitDate"),
+		getPackageDependencies:                 op("GetPackageDependencies"),
+		getPackageDependents:                   op("GetPackageDependents"),
 		getRepositoriesWithIndexConfiguration:  op("GetRepositoriesWithIndexConfiguration"),
+		getRetentionPolicies:                   op("GetRetentionPolicies"),
+		getRetentionPolicyByID:                 op("GetRetentionPolicyByID"),
 		getUploadByID:                          op("GetUploadByID"),
 		getUploads:                             op("GetUploads"),
 		getUploadsByIDs:                        op("GetUploadsByIDs"),
@@ -138,23 +161,30 @@ func newOperations(observationContext *observation.Context) *operations {
 		markIndexErrored:                       op("MarkIndexErrored"),
 		markQueued:                             op("MarkQueued"),
 		markRepositoryAsDirty:                  op("MarkRepositoryAsDirty"),
+		packageReferenceVersions:               op("PackageReferenceVersions"),
 		queueSize:                              op("QueueSize"),
 		referenceIDsAndFilters:                 op("ReferenceIDsAndFilters"),
 		referencesForUpload:                    op("ReferencesForUpload"),
 		refreshCommitResolvability:             op("RefreshCommitResolvability"),
 		repoName:                               op("RepoName"),
+		repositoryIDsForRetentionEvaluation:    op("RepositoryIDsForRetentionEvaluation"),
 		requeue:                                op("Requeue"),
 		requeueIndex:                           op("RequeueIndex"),
 		softDeleteOldUploads:                   op("SoftDeleteOldUploads"),
 		staleSourcedCommits:                    op("StaleSourcedCommits"),
 		updateCommitedAt:                       op("UpdateCommitedAt"),
+		updateDiagnostics:                      op("UpdateDiagnostics"),
 		updateIndexConfigurationByRepositoryID: op("UpdateIndexConfigurationByRepositoryID"),
 		updatePackageReferences:                op("UpdatePackageReferences"),
 		updatePackages:                         op("UpdatePackages"),
+		updateRetentionPolicy:                  op("UpdateRetentionPolicy"),
+		updateUploadRetention:                  op("UpdateUploadRetention"),
+		uploadIDsWithUnindexedDiagnostics:      op("UploadIDsWithUnindexedDiagnostics"),
 
-		writeVisibleUploads:        subOp("writeVisibleUploads"),
-		persistNearestUploads:      subOp("persistNearestUploads"),
-		persistNearestUploadsLinks: subOp("persistNearestUploadsLinks"),
-		persistUploadsVisibleAtTip: subOp("persistUploadsVisibleAtTip"),
+		writeVisibleUploads:           subOp("writeVisibleUploads"),
+		persistNearestUploads:         subOp("persistNearestUploads"),
+		persistNearestUploadsLinks:    subOp("persistNearestUploadsLinks"),
+		persistUploadsVisibleAtTip:    subOp("persistUploadsVisibleAtTip"),
+		persistRepositoryDependencies: subOp("persistRepositoryDependencies"),
 	}
 }
@@ -0,0 +1,316 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// MaximumDependencyGraphDepth is the maximum number of package references followed when
+// traversing the dependency graph in GetPackageDependencies and GetPackageDependents.
+const MaximumDependencyGraphDepth = 10
+
+// PackageDependency is a package referenced, directly or transitively, by the uploads visible at
+// the tip of the default branch of a repository.
+type PackageDependency struct {
+	Scheme  string
+	Name    string
+	Version string
+	// Depth is the length of the shortest chain of package references from the repository to this
+	// package. Direct dependencies have a depth of one.
+	Depth int
+	// ProviderRepositoryIDs are the identifiers of the repositories whose uploads visible at the
+	// tip of their default branch provide this package.
+	ProviderRepositoryIDs []int
+}
+
+// scanPackageDependencies scans a slice of package dependencies from the return value of `*Store.query`.
+func scanPackageDependencies(rows *sql.Rows, queryErr error) (_ []PackageDependency, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var dependencies []PackageDependency
+	for rows.Next() {
+		var dependency PackageDependency
+		var providerRepositoryIDs pq.Int64Array
+		if err := rows.Scan(
+			&dependency.Scheme,
+			&dependency.Name,
+			&dependency.Version,
+			&dependency.Depth,
+			&providerRepositoryIDs,
+		); err != nil {
+			return nil, err
+		}
+
+		for _, id := range providerRepositoryIDs {
+			dependency.ProviderRepositoryIDs = append(dependency.ProviderRepositoryIDs, int(id))
+		}
+
+		dependencies = append(dependencies, dependency)
+	}
+
+	return dependencies, nil
+}
+
+// PackageDependent is a repository whose uploads visible at the tip of its default branch reference,
+// directly or transitively, a target package.
+type PackageDependent struct {
+	RepositoryID   int
+	RepositoryName string
+	// Scheme, Name, and Version identify the package referenced by the repository. For direct
+	// dependents, this is a version of the target package. For transitive dependents, this is a
+	// package that (transitively) depends on the target package.
+	Scheme  string
+	Name    string
+	Version string
+	// Depth is the length of the shortest chain of package references from the repository to the
+	// target package. Direct dependents have a depth of one.
+	Depth int
+}
+
+// scanPackageDependents scans a slice of package dependents from the return value of `*Store.query`.
+func scanPackageDependents(rows *sql.Rows, queryErr error) (_ []PackageDependent, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var dependents []PackageDependent
+	for rows.Next() {
+		var dependent PackageDependent
+		if err := rows.Scan(
+			&dependent.RepositoryID,
+			&dependent.RepositoryName,
+			&dependent.Scheme,
+			&dependent.Name,
+			&dependent.Version,
+			&dependent.Depth,
+		); err != nil {
+			return nil, err
+		}
+
+		dependents = append(dependents, dependent)
+	}
+
+	return dependents, nil
+}
+
+type GetPackageDependenciesOptions struct {
+	RepositoryID int
+	// MaxDepth is the maximum length of a chain of package references followed from the repository.
+	// A value of one returns only direct dependencies.
+	MaxDepth int
+	// Scheme and Name restrict the returned packages, if non-empty. Packages not matching these
+	// conditions are still traversed.
+	Scheme string
+	Name   string
+}
+
+// GetPackageDependencies returns the packages that the given repository depends on, ordered by depth.
+// Direct dependencies are the packages referenced by the uploads visible at the tip of the default
+// branch of the repository. Transitive dependencies are found by following the references of the
+// uploads that provide each dependency. Only uploads of repositories visible to the current user are
+// traversed.
+func (s *Store) GetPackageDependencies(ctx context.Context, opts GetPackageDependenciesOptions) (_ []PackageDependency, err error) {
+	ctx, traceLog, endObservation := s.operations.getPackageDependencies.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", opts.RepositoryID),
+		log.Int("maxDepth", opts.MaxDepth),
+		log.String("scheme", opts.Scheme),
+		log.String("name", opts.Name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, s.Store.Handle().DB())
+	if err != nil {
+		return nil, err
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.Scheme != "" {
+		conds = append(conds, sqlf.Sprintf("d.scheme = %s", opts.Scheme))
+	}
+	if opts.Name != "" {
+		conds = append(conds, sqlf.Sprintf("d.name = %s", opts.Name))
+	}
+
+	dependencies, err := scanPackageDependencies(s.Store.Query(ctx, sqlf.Sprintf(
+		getPackageDependenciesQuery,
+		authzConds,
+		opts.RepositoryID,
+		clampDependencyGraphDepth(opts.MaxDepth),
+		sqlf.Join(conds, " AND "),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numDependencies", len(dependencies)))
+
+	return dependencies, nil
+}
+
+const visibleDependencyGraphUploadsFragment = `
+visible_uploads AS (
+	SELECT uvt.upload_id, uvt.repository_id
+	FROM lsif_uploads_visible_at_tip uvt
+	JOIN repo ON repo.id = uvt.repository_id
+	WHERE uvt.is_default_branch AND repo.deleted_at IS NULL AND %s
+)
+`
+
+const getPackageDependenciesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/package_dependencies.go:GetPackageDependencies
+WITH RECURSIVE
+` + visibleDependencyGraphUploadsFragment + `,
+dependencies AS (
+	SELECT r.scheme, r.name, r.version, 1 AS depth
+	FROM lsif_references r
+	JOIN visible_uploads vu ON vu.upload_id = r.dump_id
+	WHERE vu.repository_id = %s
+
+	UNION
+
+	SELECT r.scheme, r.name, r.version, d.depth + 1
+	FROM dependencies d
+	JOIN lsif_packages p ON p.scheme = d.scheme AND p.name = d.name AND p.version = d.version
+	JOIN visible_uploads vu ON vu.upload_id = p.dump_id
+	JOIN lsif_references r ON r.dump_id = p.dump_id
+	WHERE d.depth < %s
+)
+SELECT
+	d.scheme,
+	d.name,
+	d.version,
+	MIN(d.depth),
+	ARRAY(
+		SELECT DISTINCT vu.repository_id
+		FROM lsif_packages p
+		JOIN visible_uploads vu ON vu.upload_id = p.dump_id
+		WHERE p.scheme = d.scheme AND p.name = d.name AND p.version = d.version
+		ORDER BY vu.repository_id
+	)
+FROM dependencies d
+WHERE %s
+GROUP BY d.scheme, d.name, d.version
+ORDER BY MIN(d.depth), d.scheme, d.name, d.version
+`
+
+type GetPackageDependentsOptions struct {
+	Scheme string
+	Name   string
+	// Versions restricts the target package to the given versions, if non-empty.
+	Versions []string
+	// MaxDepth is the maximum length of a chain of package references followed to the target
+	// package. A value of one returns only direct dependents.
+	MaxDepth int
+}
+
+// GetPackageDependents returns the repositories that depend on the given package, ordered by depth.
+// Direct dependents are the repositories whose uploads visible at the tip of their default branch
+// reference the package. Transitive dependents are found by following references to the packages
+// provided by the uploads of each dependent. Only uploads of repositories visible to the current
+// user are traversed.
+func (s *Store) GetPackageDependents(ctx context.Context, opts GetPackageDependentsOptions) (_ []PackageDependent, err error) {
+	ctx, traceLog, endObservation := s.operations.getPackageDependents.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("scheme", opts.Scheme),
+		log.String("name", opts.Name),
+		log.Int("numVersions", len(opts.Versions)),
+		log.Int("maxDepth", opts.MaxDepth),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, s.Store.Handle().DB())
+	if err != nil {
+		return nil, err
+	}
+
+	versionCond := sqlf.Sprintf("TRUE")
+	if len(opts.Versions) > 0 {
+		versionCond = sqlf.Sprintf("r.version = ANY(%s)", pq.Array(opts.Versions))
+	}
+
+	dependents, err := scanPackageDependents(s.Store.Query(ctx, sqlf.Sprintf(
+		getPackageDependentsQuery,
+		authzConds,
+		opts.Scheme,
+		opts.Name,
+		versionCond,
+		clampDependencyGraphDepth(opts.MaxDepth),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numDependents", len(dependents)))
+
+	return dependents, nil
+}
+
+const getPackageDependentsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/package_dependencies.go:GetPackageDependents
+WITH RECURSIVE
+` + visibleDependencyGraphUploadsFragment + `,
+dependents AS (
+	SELECT vu.repository_id, r.dump_id AS upload_id, r.scheme, r.name, r.version, 1 AS depth
+	FROM lsif_references r
+	JOIN visible_uploads vu ON vu.upload_id = r.dump_id
+	WHERE r.scheme = %s AND r.name = %s AND %s
+
+	UNION
+
+	SELECT vu.repository_id, r.dump_id, r.scheme, r.name, r.version, d.depth + 1
+	FROM dependents d
+	JOIN lsif_packages p ON p.dump_id = d.upload_id
+	JOIN lsif_references r ON r.scheme = p.scheme AND r.name = p.name AND r.version = p.version
+	JOIN visible_uploads vu ON vu.upload_id = r.dump_id
+	WHERE d.depth < %s
+)
+SELECT d.repository_id, repo.name, d.scheme, d.name, d.version, MIN(d.depth)
+FROM dependents d
+JOIN repo ON repo.id = d.repository_id
+GROUP BY d.repository_id, repo.name, d.scheme, d.name, d.version
+ORDER BY MIN(d.depth), repo.name, d.scheme, d.name, d.version
+`
+
+// PackageReferenceVersions returns the distinct versions of the given package referenced by any
+// upload, in lexicographic order.
+func (s *Store) PackageReferenceVersions(ctx context.Context, scheme, name string) (_ []string, err error) {
+	ctx, traceLog, endObservation := s.operations.packageReferenceVersions.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("scheme", scheme),
+		log.String("name", name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	versions, err := basestore.ScanStrings(s.Store.Query(ctx, sqlf.Sprintf(packageReferenceVersionsQuery, scheme, name)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numVersions", len(versions)))
+
+	return versions, nil
+}
+
+const packageReferenceVersionsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/package_dependencies.go:PackageReferenceVersions
+SELECT DISTINCT r.version FROM lsif_references r WHERE r.scheme = %s AND r.name = %s ORDER BY r.version
+`
+
+// clampDependencyGraphDepth returns the given depth within the range [1, MaximumDependencyGraphDepth].
+func clampDependencyGraphDepth(depth int) int {
+	if depth < 1 {
+		return 1
+	}
+	if depth > MaximumDependencyGraphDepth {
+		return MaximumDependencyGraphDepth
+	}
+
+	return depth
+}
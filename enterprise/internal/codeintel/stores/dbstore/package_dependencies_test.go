@@ -0,0 +1,141 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// insertDependencyGraph creates the following graph of repositories (with one upload each, visible
+// at the tip of the default branch) and packages:
+//
+//	n-50 -> lib-a@1.0.0 (n-51) -> lib-b@2.0.0 (n-52)
+//	n-53 -> lib-b@1.0.0 (n-54)
+//	n-55 -> lib-b@2.0.0 (not visible from the default branch)
+func insertDependencyGraph(t *testing.T, db *sql.DB, store *Store) {
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50},
+		Upload{ID: 2, RepositoryID: 51},
+		Upload{ID: 3, RepositoryID: 52},
+		Upload{ID: 4, RepositoryID: 53},
+		Upload{ID: 5, RepositoryID: 54},
+		Upload{ID: 6, RepositoryID: 55},
+	)
+	for i := 1; i <= 5; i++ {
+		insertVisibleAtTip(t, db, 49+i, i)
+	}
+	insertVisibleAtTipNonDefaultBranch(t, db, 55, 6)
+
+	for uploadID, pkg := range map[int]semantic.Package{
+		2: {Scheme: "npm", Name: "lib-a", Version: "1.0.0"},
+		3: {Scheme: "npm", Name: "lib-b", Version: "2.0.0"},
+		5: {Scheme: "npm", Name: "lib-b", Version: "1.0.0"},
+	} {
+		if err := store.UpdatePackages(context.Background(), uploadID, []semantic.Package{pkg}); err != nil {
+			t.Fatalf("unexpected error updating packages: %s", err)
+		}
+	}
+
+	insertPackageReferences(t, store, []lsifstore.PackageReference{
+		{Package: lsifstore.Package{DumpID: 1, Scheme: "npm", Name: "lib-a", Version: "1.0.0"}, Filter: []byte("f")},
+		{Package: lsifstore.Package{DumpID: 2, Scheme: "npm", Name: "lib-b", Version: "2.0.0"}, Filter: []byte("f")},
+		{Package: lsifstore.Package{DumpID: 4, Scheme: "npm", Name: "lib-b", Version: "1.0.0"}, Filter: []byte("f")},
+		{Package: lsifstore.Package{DumpID: 6, Scheme: "npm", Name: "lib-b", Version: "2.0.0"}, Filter: []byte("f")},
+	})
+}
+
+func TestGetPackageDependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	insertDependencyGraph(t, db, store)
+
+	libA := PackageDependency{Scheme: "npm", Name: "lib-a", Version: "1.0.0", Depth: 1, ProviderRepositoryIDs: []int{51}}
+	libB := PackageDependency{Scheme: "npm", Name: "lib-b", Version: "2.0.0", Depth: 2, ProviderRepositoryIDs: []int{52}}
+
+	testCases := []struct {
+		opts     GetPackageDependenciesOptions
+		expected []PackageDependency
+	}{
+		{GetPackageDependenciesOptions{RepositoryID: 50, MaxDepth: 1}, []PackageDependency{libA}},
+		{GetPackageDependenciesOptions{RepositoryID: 50, MaxDepth: 5}, []PackageDependency{libA, libB}},
+		{GetPackageDependenciesOptions{RepositoryID: 50, MaxDepth: 5, Name: "lib-b"}, []PackageDependency{libB}},
+		{GetPackageDependenciesOptions{RepositoryID: 52, MaxDepth: 5}, nil},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			dependencies, err := store.GetPackageDependencies(context.Background(), testCase.opts)
+			if err != nil {
+				t.Fatalf("unexpected error getting package dependencies: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, dependencies); diff != "" {
+				t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetPackageDependents(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	insertDependencyGraph(t, db, store)
+
+	n50 := PackageDependent{RepositoryID: 50, RepositoryName: "n-50", Scheme: "npm", Name: "lib-a", Version: "1.0.0", Depth: 2}
+	n51 := PackageDependent{RepositoryID: 51, RepositoryName: "n-51", Scheme: "npm", Name: "lib-b", Version: "2.0.0", Depth: 1}
+	n53 := PackageDependent{RepositoryID: 53, RepositoryName: "n-53", Scheme: "npm", Name: "lib-b", Version: "1.0.0", Depth: 1}
+
+	testCases := []struct {
+		opts     GetPackageDependentsOptions
+		expected []PackageDependent
+	}{
+		{GetPackageDependentsOptions{Scheme: "npm", Name: "lib-b", MaxDepth: 1}, []PackageDependent{n51, n53}},
+		{GetPackageDependentsOptions{Scheme: "npm", Name: "lib-b", MaxDepth: 5}, []PackageDependent{n51, n53, n50}},
+		{GetPackageDependentsOptions{Scheme: "npm", Name: "lib-b", Versions: []string{"1.0.0"}, MaxDepth: 5}, []PackageDependent{n53}},
+		{GetPackageDependentsOptions{Scheme: "npm", Name: "lib-c", MaxDepth: 5}, nil},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			dependents, err := store.GetPackageDependents(context.Background(), testCase.opts)
+			if err != nil {
+				t.Fatalf("unexpected error getting package dependents: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, dependents); diff != "" {
+				t.Errorf("unexpected dependents (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPackageReferenceVersions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	insertDependencyGraph(t, db, store)
+
+	versions, err := store.PackageReferenceVersions(context.Background(), "npm", "lib-b")
+	if err != nil {
+		t.Fatalf("unexpected error getting package reference versions: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"1.0.0", "2.0.0"}, versions); diff != "" {
+		t.Errorf("unexpected versions (-want +got):\n%s", diff)
+	}
+}
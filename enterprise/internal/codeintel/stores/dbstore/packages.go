@@ -65,6 +65,57 @@ SELECT %s, source.scheme, source.name, source.version
 FROM t_lsif_packages source
 `
 
+// CopyPackageData copies the package and package reference data of the source upload to the target
+// upload. This is used to carry the data of a base upload over to an incremental upload, whose own
+// package data covers only its changed documents. Packages already provided by the target upload are
+// not duplicated. Package references are copied unconditionally, as the identifiers recorded for the
+// same package by each upload may differ.
+func (s *Store) CopyPackageData(ctx context.Context, sourceDumpID, targetDumpID int) (err error) {
+	ctx, endObservation := s.operations.copyPackageData.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceDumpID", sourceDumpID),
+		log.Int("targetDumpID", targetDumpID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(copyPackagesQuery, targetDumpID, sourceDumpID, targetDumpID)); err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(copyPackageReferencesQuery, targetDumpID, sourceDumpID))
+}
+
+const copyPackagesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/packages.go:CopyPackageData
+INSERT INTO lsif_packages (dump_id, scheme, name, version)
+SELECT %s, p.scheme, p.name, p.version
+FROM lsif_packages p
+WHERE
+	p.dump_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_packages t
+		WHERE
+			t.dump_id = %s AND
+			t.scheme = p.scheme AND
+			t.name = p.name AND
+			t.version IS NOT DISTINCT FROM p.version
+	)
+`
+
+const copyPackageReferencesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/packages.go:CopyPackageData
+INSERT INTO lsif_references (dump_id, scheme, name, version, filter)
+SELECT %s, r.scheme, r.name, r.version, r.filter
+FROM lsif_references r
+WHERE r.dump_id = %s
+`
+
 func loadPackagesChannel(packages []semantic.Package) <-chan []interface{} {
 	ch := make(chan []interface{}, len(packages))
 
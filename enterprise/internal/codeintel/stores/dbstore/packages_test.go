@@ -62,3 +62,51 @@ func TestUpdatePackagesEmpty(t *testing.T) {
 		t.Errorf("unexpected package count. want=%d have=%d", 0, count)
 	}
 }
+
+func TestCopyPackageData(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	// for foreign key relation
+	insertUploads(t, db, Upload{ID: 42}, Upload{ID: 43})
+
+	if err := store.UpdatePackages(context.Background(), 42, []semantic.Package{
+		{Scheme: "s0", Name: "n0", Version: "v0"},
+		{Scheme: "s1", Name: "n1", Version: "v1"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	if err := store.UpdatePackageReferences(context.Background(), 42, []semantic.PackageReference{
+		{Package: semantic.Package{Scheme: "s2", Name: "n2", Version: "v2"}, Filter: []byte("f0")},
+	}); err != nil {
+		t.Fatalf("unexpected error updating package references: %s", err)
+	}
+	if err := store.UpdatePackages(context.Background(), 43, []semantic.Package{
+		{Scheme: "s1", Name: "n1", Version: "v1"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+
+	if err := store.CopyPackageData(context.Background(), 42, 43); err != nil {
+		t.Fatalf("unexpected error copying package data: %s", err)
+	}
+
+	packageCount, _, err := basestore.ScanFirstInt(db.Query("SELECT COUNT(*) FROM lsif_packages WHERE dump_id = 43"))
+	if err != nil {
+		t.Fatalf("unexpected error checking package count: %s", err)
+	}
+	if packageCount != 2 {
+		t.Errorf("unexpected package count. want=%d have=%d", 2, packageCount)
+	}
+
+	referenceCount, _, err := basestore.ScanFirstInt(db.Query("SELECT COUNT(*) FROM lsif_references WHERE dump_id = 43"))
+	if err != nil {
+		t.Fatalf("unexpected error checking reference count: %s", err)
+	}
+	if referenceCount != 1 {
+		t.Errorf("unexpected reference count. want=%d have=%d", 1, referenceCount)
+	}
+}
@@ -0,0 +1,66 @@
+package dbstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// persistRepositoryDependencies modifies the lsif_repository_dependencies table so that the edges
+// incident to the given repository reflect the packages provided and referenced by the uploads
+// currently visible at the tip of the default branch of each repository. This method must be called
+// after the lsif_uploads_visible_at_tip table has been updated for the given repository.
+func (s *Store) persistRepositoryDependencies(ctx context.Context, repositoryID int) (err error) {
+	ctx, traceLog, endObservation := s.operations.persistRepositoryDependencies.WithAndLogger(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	edgesQuery := sqlf.Sprintf(repositoryDependencyEdgesQuery, repositoryID, repositoryID)
+
+	rowsInserted, _, rowsDeleted, err := s.bulkTransfer(
+		ctx,
+		sqlf.Sprintf(repositoryDependenciesInsertQuery, edgesQuery),
+		nil,
+		sqlf.Sprintf(repositoryDependenciesDeleteQuery, repositoryID, repositoryID, edgesQuery),
+	)
+	if err != nil {
+		return err
+	}
+	traceLog(
+		log.Int("lsif_repository_dependencies.ins", rowsInserted),
+		log.Int("lsif_repository_dependencies.del", rowsDeleted),
+	)
+
+	return nil
+}
+
+const repositoryDependencyEdgesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/repository_dependencies.go:persistRepositoryDependencies
+SELECT DISTINCT rv.repository_id, pv.repository_id
+FROM lsif_uploads_visible_at_tip rv
+JOIN lsif_references r ON r.dump_id = rv.upload_id
+JOIN lsif_packages p ON p.scheme = r.scheme AND p.name = r.name AND p.version = r.version
+JOIN lsif_uploads_visible_at_tip pv ON pv.upload_id = p.dump_id
+WHERE
+	rv.is_default_branch AND
+	pv.is_default_branch AND
+	rv.repository_id != pv.repository_id AND
+	(rv.repository_id = %s OR pv.repository_id = %s)
+`
+
+const repositoryDependenciesInsertQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/repository_dependencies.go:persistRepositoryDependencies
+INSERT INTO lsif_repository_dependencies (repository_id, dependency_repository_id)
+%s
+ON CONFLICT DO NOTHING
+`
+
+const repositoryDependenciesDeleteQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/repository_dependencies.go:persistRepositoryDependencies
+DELETE FROM lsif_repository_dependencies d
+WHERE
+	(d.repository_id = %s OR d.dependency_repository_id = %s) AND
+	(d.repository_id, d.dependency_repository_id) NOT IN (%s)
+`
@@ -0,0 +1,92 @@
+package dbstore
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestPersistRepositoryDependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50}, // provides leftpad
+		Upload{ID: 2, RepositoryID: 51}, // references leftpad
+		Upload{ID: 3, RepositoryID: 52}, // references leftpad, not visible from the default branch
+		Upload{ID: 4, RepositoryID: 53}, // references rightpad
+		Upload{ID: 5, RepositoryID: 54}, // provides rightpad
+	)
+	insertVisibleAtTip(t, db, 50, 1)
+	insertVisibleAtTip(t, db, 51, 2)
+	insertVisibleAtTipNonDefaultBranch(t, db, 52, 3)
+	insertVisibleAtTip(t, db, 53, 4)
+	insertVisibleAtTip(t, db, 54, 5)
+
+	if err := store.UpdatePackages(context.Background(), 1, []semantic.Package{{Scheme: "gomod", Name: "leftpad", Version: "0.1.0"}}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	if err := store.UpdatePackages(context.Background(), 5, []semantic.Package{{Scheme: "gomod", Name: "rightpad", Version: "0.1.0"}}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	insertPackageReferences(t, store, []lsifstore.PackageReference{
+		{Package: lsifstore.Package{DumpID: 2, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"}, Filter: []byte("f2")},
+		{Package: lsifstore.Package{DumpID: 3, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"}, Filter: []byte("f3")},
+		{Package: lsifstore.Package{DumpID: 4, Scheme: "gomod", Name: "rightpad", Version: "0.1.0"}, Filter: []byte("f4")},
+	})
+
+	// Stale edge incident to the updated repository
+	insertRepositoryDependencies(t, db, map[int][]int{55: {50}})
+
+	if err := store.persistRepositoryDependencies(context.Background(), 50); err != nil {
+		t.Fatalf("unexpected error persisting repository dependencies: %s", err)
+	}
+
+	// Edges not incident to the updated repository are not yet inserted
+	expected := [][2]int{{51, 50}}
+	if diff := cmp.Diff(expected, getRepositoryDependencies(t, store)); diff != "" {
+		t.Errorf("unexpected repository dependencies (-want +got):\n%s", diff)
+	}
+
+	if err := store.persistRepositoryDependencies(context.Background(), 54); err != nil {
+		t.Fatalf("unexpected error persisting repository dependencies: %s", err)
+	}
+
+	expected = [][2]int{{51, 50}, {53, 54}}
+	if diff := cmp.Diff(expected, getRepositoryDependencies(t, store)); diff != "" {
+		t.Errorf("unexpected repository dependencies (-want +got):\n%s", diff)
+	}
+}
+
+func getRepositoryDependencies(t testing.TB, store *Store) (edges [][2]int) {
+	rows, err := store.Query(context.Background(), sqlf.Sprintf(`SELECT repository_id, dependency_repository_id FROM lsif_repository_dependencies`))
+	if err != nil {
+		t.Fatalf("unexpected error querying repository dependencies: %s", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var edge [2]int
+		if err := rows.Scan(&edge[0], &edge[1]); err != nil {
+			t.Fatalf("unexpected error scanning repository dependencies: %s", err)
+		}
+
+		edges = append(edges, edge)
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i][0] < edges[j][0] || (edges[i][0] == edges[j][0] && edges[i][1] < edges[j][1])
+	})
+
+	return edges
+}
@@ -0,0 +1,297 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const (
+	RetentionPolicyTypeBranch = "GIT_BRANCH"
+	RetentionPolicyTypeTag    = "GIT_TAG"
+)
+
+// RetentionPolicy determines how long uploads for the commits of matching branches or tags are retained.
+type RetentionPolicy struct {
+	ID   int
+	Name string
+	// RepositoryPattern is a glob matched against repository names. The policy applies to
+	// all repositories if nil.
+	RepositoryPattern *string
+	// Type is one of RetentionPolicyTypeBranch or RetentionPolicyTypeTag.
+	Type string
+	// Pattern is a glob matched against branch or tag names.
+	Pattern string
+	// RetentionDuration is how long uploads are retained, measured from the commit date (or the
+	// tag creation date). Matching uploads are retained forever if nil.
+	RetentionDuration *time.Duration
+	// RetainCommitCount is the number of most recent commits of each matching branch that are
+	// retained. All commits of the branch are considered if nil.
+	RetainCommitCount *int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// scanRetentionPolicies scans a slice of retention policies from the return value of `*Store.query`.
+func scanRetentionPolicies(rows *sql.Rows, queryErr error) (_ []RetentionPolicy, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var policies []RetentionPolicy
+	for rows.Next() {
+		var policy RetentionPolicy
+		var retentionDurationHours *int
+		if err := rows.Scan(
+			&policy.ID,
+			&policy.Name,
+			&policy.RepositoryPattern,
+			&policy.Type,
+			&policy.Pattern,
+			&retentionDurationHours,
+			&policy.RetainCommitCount,
+			&policy.CreatedAt,
+			&policy.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if retentionDurationHours != nil {
+			duration := time.Duration(*retentionDurationHours) * time.Hour
+			policy.RetentionDuration = &duration
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// scanFirstRetentionPolicy scans a slice of retention policies from the return value of `*Store.query`
+// and returns the first.
+func scanFirstRetentionPolicy(rows *sql.Rows, err error) (RetentionPolicy, bool, error) {
+	policies, err := scanRetentionPolicies(rows, err)
+	if err != nil || len(policies) == 0 {
+		return RetentionPolicy{}, false, err
+	}
+	return policies[0], true, nil
+}
+
+// GetRetentionPolicies returns all retention policies ordered by identifier.
+func (s *Store) GetRetentionPolicies(ctx context.Context) (_ []RetentionPolicy, err error) {
+	ctx, traceLog, endObservation := s.operations.getRetentionPolicies.WithAndLogger(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	policies, err := scanRetentionPolicies(s.Store.Query(ctx, sqlf.Sprintf(getRetentionPoliciesQuery)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numPolicies", len(policies)))
+
+	return policies, nil
+}
+
+const retentionPolicyColumns = `
+	p.id,
+	p.name,
+	p.repository_pattern,
+	p.type,
+	p.pattern,
+	p.retention_duration_hours,
+	p.retain_commit_count,
+	p.created_at,
+	p.updated_at
+`
+
+var getRetentionPoliciesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/retention_policies.go:GetRetentionPolicies
+SELECT ` + retentionPolicyColumns + `
+FROM lsif_retention_policies p
+ORDER BY p.id
+`
+
+// GetRetentionPolicyByID returns the retention policy with the given identifier.
+func (s *Store) GetRetentionPolicyByID(ctx context.Context, id int) (_ RetentionPolicy, _ bool, err error) {
+	ctx, endObservation := s.operations.getRetentionPolicyByID.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstRetentionPolicy(s.Store.Query(ctx, sqlf.Sprintf(getRetentionPolicyByIDQuery, id)))
+}
+
+var getRetentionPolicyByIDQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/retention_policies.go:GetRetentionPolicyByID
+SELECT ` + retentionPolicyColumns + `
+FROM lsif_retention_policies p
+WHERE p.id = %s
+`
+
+// CreateRetentionPolicy inserts a new retention policy and returns it with its identifier and
+// timestamps populated.
+func (s *Store) CreateRetentionPolicy(ctx context.Context, policy RetentionPolicy) (_ RetentionPolicy, err error) {
+	ctx, endObservation := s.operations.createRetentionPolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("name", policy.Name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	policy, _, err = scanFirstRetentionPolicy(s.Store.Query(ctx, sqlf.Sprintf(
+		createRetentionPolicyQuery,
+		policy.Name,
+		policy.RepositoryPattern,
+		policy.Type,
+		policy.Pattern,
+		retentionDurationHours(policy.RetentionDuration),
+		policy.RetainCommitCount,
+	)))
+	return policy, err
+}
+
+var createRetentionPolicyQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/retention_policies.go:CreateRetentionPolicy
+INSERT INTO lsif_retention_policies AS p (name, repository_pattern, type, pattern, retention_duration_hours, retain_commit_count)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING ` + retentionPolicyColumns
+
+// UpdateRetentionPolicy updates the mutable fields of the given retention policy. A false-valued flag
+// is returned if no such policy exists.
+func (s *Store) UpdateRetentionPolicy(ctx context.Context, policy RetentionPolicy, now time.Time) (_ RetentionPolicy, _ bool, err error) {
+	ctx, endObservation := s.operations.updateRetentionPolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("id", policy.ID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstRetentionPolicy(s.Store.Query(ctx, sqlf.Sprintf(
+		updateRetentionPolicyQuery,
+		policy.Name,
+		policy.RepositoryPattern,
+		policy.Type,
+		policy.Pattern,
+		retentionDurationHours(policy.RetentionDuration),
+		policy.RetainCommitCount,
+		now,
+		policy.ID,
+	)))
+}
+
+var updateRetentionPolicyQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/retention_policies.go:UpdateRetentionPolicy
+UPDATE lsif_retention_policies p SET
+	name = %s,
+	repository_pattern = %s,
+	type = %s,
+	pattern = %s,
+	retention_duration_hours = %s,
+	retain_commit_count = %s,
+	updated_at = %s
+WHERE p.id = %s
+RETURNING ` + retentionPolicyColumns
+
+// DeleteRetentionPolicyByID deletes the retention policy with the given identifier. A false-valued
+// flag is returned if no such policy exists.
+func (s *Store) DeleteRetentionPolicyByID(ctx context.Context, id int) (_ bool, err error) {
+	ctx, endObservation := s.operations.deleteRetentionPolicyByID.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, exists, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(deleteRetentionPolicyByIDQuery, id)))
+	return exists, err
+}
+
+const deleteRetentionPolicyByIDQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/retention_policies.go:DeleteRetentionPolicyByID
+DELETE FROM lsif_retention_policies WHERE id = %s RETURNING id
+`
+
+// RepositoryIDsForRetentionEvaluation returns a set of identifiers of repositories with completed
+// uploads whose retention has not been evaluated within the given duration. Repositories whose uploads
+// have never been evaluated are returned first, followed by the repositories evaluated least recently.
+func (s *Store) RepositoryIDsForRetentionEvaluation(ctx context.Context, minimumTimeSinceLastEvaluation time.Duration, limit int, now time.Time) (_ []int, err error) {
+	ctx, traceLog, endObservation := s.operations.repositoryIDsForRetentionEvaluation.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("minimumTimeSinceLastEvaluation", minimumTimeSinceLastEvaluation.String()),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	repositoryIDs, err := basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(
+		repositoryIDsForRetentionEvaluationQuery,
+		now,
+		int(minimumTimeSinceLastEvaluation/time.Second),
+		limit,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numRepositories", len(repositoryIDs)))
+
+	return repositoryIDs, nil
+}
+
+const repositoryIDsForRetentionEvaluationQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/retention_policies.go:RepositoryIDsForRetentionEvaluation
+SELECT u.repository_id
+FROM lsif_uploads u
+WHERE
+	u.state = 'completed' AND
+	(u.retention_evaluated_at IS NULL OR %s - u.retention_evaluated_at > (%s * '1 second'::interval))
+GROUP BY u.repository_id
+ORDER BY bool_or(u.retention_evaluated_at IS NULL) DESC, MIN(u.retention_evaluated_at), u.repository_id
+LIMIT %s
+`
+
+// UpdateUploadRetention records the result of a retention policy evaluation for the given uploads.
+// Uploads in retainedIDs are marked as retained and uploads in expiredIDs are marked as expired.
+// Every other upload in uploadIDs is marked as not governed by any retention policy, in which case
+// the default retention period applies.
+func (s *Store) UpdateUploadRetention(ctx context.Context, uploadIDs, retainedIDs, expiredIDs []int, now time.Time) (err error) {
+	ctx, endObservation := s.operations.updateUploadRetention.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numUploads", len(uploadIDs)),
+		log.Int("numRetained", len(retainedIDs)),
+		log.Int("numExpired", len(expiredIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 {
+		return nil
+	}
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(
+		updateUploadRetentionQuery,
+		pq.Array(retainedIDs),
+		pq.Array(expiredIDs),
+		now,
+		pq.Array(uploadIDs),
+	))
+}
+
+const updateUploadRetentionQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/retention_policies.go:UpdateUploadRetention
+UPDATE lsif_uploads SET
+	retained = CASE
+		WHEN id = ANY(%s) THEN true
+		WHEN id = ANY(%s) THEN false
+		ELSE NULL
+	END,
+	retention_evaluated_at = %s
+WHERE id = ANY(%s)
+`
+
+// retentionDurationHours converts the given duration into the number of hours stored in the
+// retention_duration_hours column.
+func retentionDurationHours(duration *time.Duration) *int {
+	if duration == nil {
+		return nil
+	}
+
+	hours := int(*duration / time.Hour)
+	return &hours
+}
@@ -0,0 +1,161 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestRetentionPolicies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	repositoryPattern := "github.com/sourcegraph/*"
+	ninetyDays := 90 * 24 * time.Hour
+	ten := 10
+
+	release, err := store.CreateRetentionPolicy(context.Background(), RetentionPolicy{
+		Name:    "release tags",
+		Type:    RetentionPolicyTypeTag,
+		Pattern: "v*",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating policy: %s", err)
+	}
+	mainPolicy, err := store.CreateRetentionPolicy(context.Background(), RetentionPolicy{
+		Name:              "main",
+		RepositoryPattern: &repositoryPattern,
+		Type:              RetentionPolicyTypeBranch,
+		Pattern:           "main",
+		RetentionDuration: &ninetyDays,
+		RetainCommitCount: &ten,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating policy: %s", err)
+	}
+
+	expectedPolicies := []RetentionPolicy{
+		{ID: release.ID, Name: "release tags", Type: RetentionPolicyTypeTag, Pattern: "v*"},
+		{ID: mainPolicy.ID, Name: "main", RepositoryPattern: &repositoryPattern, Type: RetentionPolicyTypeBranch, Pattern: "main", RetentionDuration: &ninetyDays, RetainCommitCount: &ten},
+	}
+	ignoreTimestamps := cmpopts.IgnoreFields(RetentionPolicy{}, "CreatedAt", "UpdatedAt")
+
+	policies, err := store.GetRetentionPolicies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting policies: %s", err)
+	}
+	if diff := cmp.Diff(expectedPolicies, policies, ignoreTimestamps); diff != "" {
+		t.Errorf("unexpected policies (-want +got):\n%s", diff)
+	}
+
+	mainPolicy.RetentionDuration = nil
+	mainPolicy.RetainCommitCount = nil
+	if _, ok, err := store.UpdateRetentionPolicy(context.Background(), mainPolicy, time.Now()); err != nil {
+		t.Fatalf("unexpected error updating policy: %s", err)
+	} else if !ok {
+		t.Fatalf("expected policy to exist")
+	}
+
+	if policy, ok, err := store.GetRetentionPolicyByID(context.Background(), mainPolicy.ID); err != nil {
+		t.Fatalf("unexpected error getting policy: %s", err)
+	} else if !ok {
+		t.Fatalf("expected policy to exist")
+	} else if policy.RetentionDuration != nil || policy.RetainCommitCount != nil {
+		t.Errorf("expected policy to retain all commits forever")
+	}
+
+	if ok, err := store.DeleteRetentionPolicyByID(context.Background(), release.ID); err != nil {
+		t.Fatalf("unexpected error deleting policy: %s", err)
+	} else if !ok {
+		t.Fatalf("expected policy to exist")
+	}
+	if _, ok, err := store.GetRetentionPolicyByID(context.Background(), release.ID); err != nil {
+		t.Fatalf("unexpected error getting policy: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected policy")
+	}
+}
+
+func TestRepositoryIDsForRetentionEvaluation(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	now := time.Unix(1587396557, 0).UTC()
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50},
+		Upload{ID: 2, RepositoryID: 51},
+		Upload{ID: 3, RepositoryID: 52},
+		Upload{ID: 4, RepositoryID: 53, State: "errored"},
+	)
+
+	if err := store.UpdateUploadRetention(context.Background(), []int{1}, nil, nil, now.Add(-time.Hour*3)); err != nil {
+		t.Fatalf("unexpected error updating retention: %s", err)
+	}
+	if err := store.UpdateUploadRetention(context.Background(), []int{2}, nil, nil, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error updating retention: %s", err)
+	}
+
+	repositoryIDs, err := store.RepositoryIDsForRetentionEvaluation(context.Background(), time.Hour, 10, now)
+	if err != nil {
+		t.Fatalf("unexpected error getting repositories: %s", err)
+	}
+	if diff := cmp.Diff([]int{52, 50}, repositoryIDs); diff != "" {
+		t.Errorf("unexpected repository ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestSoftDeleteOldUploadsRetentionPolicies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Minute * 6)
+
+	insertUploads(t, db,
+		Upload{ID: 1, FinishedAt: &t1}, // old, retained by policy
+		Upload{ID: 2, FinishedAt: &t2}, // new, expired by policy
+		Upload{ID: 3, FinishedAt: &t2}, // new, not governed
+		Upload{ID: 4, FinishedAt: &t1}, // old, visible from a non-default branch but expired by policy
+		Upload{ID: 5, FinishedAt: &t1}, // old, visible from the default branch but expired by policy
+	)
+	insertVisibleAtTip(t, db, 50, 5)
+	insertVisibleAtTipNonDefaultBranch(t, db, 50, 4)
+
+	if err := store.UpdateUploadRetention(context.Background(), []int{1, 2, 3, 4, 5}, []int{1}, []int{2, 4, 5}, t2); err != nil {
+		t.Fatalf("unexpected error updating retention: %s", err)
+	}
+
+	if count, err := store.SoftDeleteOldUploads(context.Background(), time.Minute, t2); err != nil {
+		t.Fatalf("unexpected error soft deleting uploads: %s", err)
+	} else if count != 2 {
+		t.Fatalf("unexpected number of uploads deleted: want=%d have=%d", 2, count)
+	}
+
+	expectedStates := map[int]string{
+		1: "completed",
+		2: "deleting",
+		3: "completed",
+		4: "deleting",
+		5: "completed",
+	}
+	if states, err := getUploadStates(db, 1, 2, 3, 4, 5); err != nil {
+		t.Fatalf("unexpected error getting states: %s", err)
+	} else if diff := cmp.Diff(expectedStates, states); diff != "" {
+		t.Errorf("unexpected upload states (-want +got):\n%s", diff)
+	}
+}
@@ -41,6 +41,8 @@ type Upload struct {
 	UploadSize        *int64     `json:"uploadSize"`
 	Rank              *int       `json:"placeInQueue"`
 	AssociatedIndexID *int       `json:"associatedIndex"`
+	BaseUploadID      *int       `json:"baseUpload"`
+	ChangedPaths      []string   `json:"changedPaths"`
 }
 
 func (u Upload) RecordID() int {
@@ -78,6 +80,8 @@ func scanUploads(rows *sql.Rows, queryErr error) (_ []Upload, err error) {
 			pq.Array(&rawUploadedParts),
 			&upload.UploadSize,
 			&upload.AssociatedIndexID,
+			&upload.BaseUploadID,
+			pq.Array(&upload.ChangedPaths),
 			&upload.Rank,
 		); err != nil {
 			return nil, err
@@ -175,6 +179,8 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.base_upload_id,
+	u.changed_paths,
 	s.rank
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -232,6 +238,8 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.base_upload_id,
+	u.changed_paths,
 	s.rank
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -287,6 +295,10 @@ type GetUploadsOptions struct {
 	OldestFirst    bool
 	Limit          int
 	Offset         int
+
+	// OmitBaseUploads excludes uploads that are the base upload of another upload. The data of
+	// an incremental upload is partially stored by its base upload.
+	OmitBaseUploads bool
 }
 
 // GetUploads returns a list of uploads and the total count of records matching the given conditions.
@@ -301,6 +313,7 @@ func (s *Store) GetUploads(ctx context.Context, opts GetUploadsOptions) (_ []Upl
 		log.Bool("oldestFirst", opts.OldestFirst),
 		log.Int("limit", opts.Limit),
 		log.Int("offset", opts.Offset),
+		log.Bool("omitBaseUploads", opts.OmitBaseUploads),
 	}})
 	defer endObservation(1, observation.Args{})
 
@@ -331,6 +344,9 @@ func (s *Store) GetUploads(ctx context.Context, opts GetUploadsOptions) (_ []Upl
 	if opts.UploadedAfter != nil {
 		conds = append(conds, sqlf.Sprintf("u.uploaded_at > %s", *opts.UploadedAfter))
 	}
+	if opts.OmitBaseUploads {
+		conds = append(conds, sqlf.Sprintf("NOT EXISTS (SELECT 1 FROM lsif_uploads dependent WHERE dependent.base_upload_id = u.id)"))
+	}
 
 	authzConds, err := database.AuthzQueryConds(ctx, tx.Store.Handle().DB())
 	if err != nil {
@@ -394,6 +410,8 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.base_upload_id,
+	u.changed_paths,
 	s.rank
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -447,6 +465,8 @@ func (s *Store) InsertUpload(ctx context.Context, upload Upload) (id int, err er
 			pq.Array(upload.UploadedParts),
 			upload.UploadSize,
 			upload.AssociatedIndexID,
+			upload.BaseUploadID,
+			pq.Array(upload.ChangedPaths),
 		),
 	))
 
@@ -464,8 +484,10 @@ INSERT INTO lsif_uploads (
 	num_parts,
 	uploaded_parts,
 	upload_size,
-	associated_index_id
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+	associated_index_id,
+	base_upload_id,
+	changed_paths
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -544,6 +566,8 @@ var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.uploaded_parts"),
 	sqlf.Sprintf("u.upload_size"),
 	sqlf.Sprintf("u.associated_index_id"),
+	sqlf.Sprintf("u.base_upload_id"),
+	sqlf.Sprintf("u.changed_paths"),
 	sqlf.Sprintf("NULL"),
 }
 
@@ -670,7 +694,7 @@ DELETE FROM lsif_uploads WHERE id IN (%s)
 `
 
 // SoftDeleteOldUploads marks uploads older than the given age that are not visible at the tip of the default branch
-// as deleted. The associated repositories will be marked as dirty so that their commit graphs are updated in the
+// as deleted. Uploads governed by a retention policy are instead deleted once the policy no longer retains them. The associated repositories will be marked as dirty so that their commit graphs are updated in the
 // background.
 func (s *Store) SoftDeleteOldUploads(ctx context.Context, maxAge time.Duration, now time.Time) (count int, err error) {
 	ctx, traceLog, endObservation := s.operations.softDeleteOldUploads.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -712,14 +736,20 @@ const softDeleteOldUploadsQuery = `
 WITH RECURSIVE
 protected_uploads AS (
 	(
-		-- Base case: select all upload records that are yonger than the configured
-		-- retention age, as well as all upload records visible from a non-stale
-		-- branch or tag. These form the roots of our dependency graph traversal.
+		-- Base case: select all upload records retained by a retention policy, all
+		-- upload records not governed by a retention policy that are younger than the
+		-- configured retention age, as well as all upload records visible from a
+		-- non-stale branch or tag. Retention policies take precedence over visibility
+		-- from branches and tags other than the default branch. These form the roots
+		-- of our dependency graph traversal.
 
 		SELECT u.id FROM lsif_uploads u
-		WHERE %s - COALESCE(u.finished_at, u.uploaded_at) <= (%s || ' second')::interval
+		WHERE u.retained OR (u.retained IS NULL AND %s - COALESCE(u.finished_at, u.uploaded_at) <= (%s || ' second')::interval)
 		UNION
-		SELECT upload_id as id FROM lsif_uploads_visible_at_tip
+		SELECT t.upload_id as id
+		FROM lsif_uploads_visible_at_tip t
+		JOIN lsif_uploads u ON u.id = t.upload_id
+		WHERE u.retained IS NULL OR t.is_default_branch
 	) UNION (
 		-- Iterative case: expand the working set of protected uploads by traversing
 		-- the dependency graph: select all upload records that define an LSIF package
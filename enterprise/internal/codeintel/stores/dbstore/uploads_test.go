@@ -322,15 +322,24 @@ func TestGetUploads(t *testing.T) {
 	)
 	insertVisibleAtTip(t, db, 50, 2, 5, 7, 8)
 
+	// Incremental uploads, including a deleted one, keep their base uploads
+	if _, err := db.ExecContext(ctx, `UPDATE lsif_uploads SET base_upload_id = 7 WHERE id = 8`); err != nil {
+		t.Fatalf("unexpected error setting base upload: %s", err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE lsif_uploads SET base_upload_id = 10 WHERE id = 13`); err != nil {
+		t.Fatalf("unexpected error setting base upload: %s", err)
+	}
+
 	testCases := []struct {
-		repositoryID   int
-		state          string
-		term           string
-		visibleAtTip   bool
-		uploadedBefore *time.Time
-		uploadedAfter  *time.Time
-		oldestFirst    bool
-		expectedIDs    []int
+		repositoryID    int
+		state           string
+		term            string
+		visibleAtTip    bool
+		uploadedBefore  *time.Time
+		uploadedAfter   *time.Time
+		oldestFirst     bool
+		omitBaseUploads bool
+		expectedIDs     []int
 	}{
 		{expectedIDs: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
 		{oldestFirst: true, expectedIDs: []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}},
@@ -345,6 +354,7 @@ func TestGetUploads(t *testing.T) {
 		{visibleAtTip: true, expectedIDs: []int{2, 5, 7, 8}},
 		{uploadedBefore: &t5, expectedIDs: []int{6, 7, 8, 9, 10}},
 		{uploadedAfter: &t4, expectedIDs: []int{1, 2, 3}},
+		{omitBaseUploads: true, expectedIDs: []int{1, 2, 3, 4, 5, 6, 8, 9}},
 	}
 
 	for _, testCase := range testCases {
@@ -365,15 +375,16 @@ func TestGetUploads(t *testing.T) {
 
 			t.Run(name, func(t *testing.T) {
 				uploads, totalCount, err := store.GetUploads(ctx, GetUploadsOptions{
-					RepositoryID:   testCase.repositoryID,
-					State:          testCase.state,
-					Term:           testCase.term,
-					VisibleAtTip:   testCase.visibleAtTip,
-					UploadedBefore: testCase.uploadedBefore,
-					UploadedAfter:  testCase.uploadedAfter,
-					OldestFirst:    testCase.oldestFirst,
-					Limit:          3,
-					Offset:         lo,
+					RepositoryID:    testCase.repositoryID,
+					State:           testCase.state,
+					Term:            testCase.term,
+					VisibleAtTip:    testCase.visibleAtTip,
+					UploadedBefore:  testCase.uploadedBefore,
+					UploadedAfter:   testCase.uploadedAfter,
+					OldestFirst:     testCase.oldestFirst,
+					OmitBaseUploads: testCase.omitBaseUploads,
+					Limit:           3,
+					Offset:          lo,
 				})
 				if err != nil {
 					t.Fatalf("unexpected error getting uploads for repo: %s", err)
@@ -73,6 +73,11 @@ FROM lsif_dumps_with_repository_name u WHERE u.id IN (
 // Visibility is determined in two parts: if the index belongs to the given repository, it is visible if
 // it can be seen from the given index; otherwise, an index is visible if it can be seen from the tip of
 // the default branch of its own repository.
+//
+// Uploads are ranked so that the first pages of the result set are the most relevant: uploads of
+// repositories closer to the given repository in the repository dependency graph come first, followed
+// by uploads of repositories with more dependents, followed by more recent uploads. All records of a
+// single upload are adjacent in the result set.
 func (s *Store) ReferenceIDsAndFilters(ctx context.Context, repositoryID int, commit string, monikers []semantic.QualifiedMonikerData, limit, offset int) (_ PackageReferenceScanner, _ int, err error) {
 	ctx, traceLog, endObservation := s.operations.referenceIDsAndFilters.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("repositoryID", repositoryID),
@@ -110,7 +115,10 @@ func (s *Store) ReferenceIDsAndFilters(ctx context.Context, repositoryID int, co
 		referenceIDsAndFiltersQuery,
 		visibleUploadsQuery,
 		repositoryID,
+		repositoryID,
+		MaximumRankedDependencyGraphDistance,
 		sqlf.Join(qs, ", "),
+		MaximumRankedDependencyGraphDistance+1,
 		limit,
 		offset,
 	))
@@ -121,9 +129,14 @@ func (s *Store) ReferenceIDsAndFilters(ctx context.Context, repositoryID int, co
 	return packageReferenceScannerFromRows(rows), totalCount, nil
 }
 
+// MaximumRankedDependencyGraphDistance is the maximum number of edges of the repository dependency
+// graph traversed when ranking the results of ReferenceIDsAndFilters. Uploads of repositories further
+// away from the source repository are ranked as if they were one edge further away.
+const MaximumRankedDependencyGraphDistance = 3
+
 const referenceIDsAndFiltersCTEDefinitions = `
 -- source: enterprise/internal/codeintel/stores/dbstore/xrepo.go:ReferenceIDsAndFilters
-WITH
+WITH RECURSIVE
 visible_uploads AS (
 	(%s)
 	UNION
@@ -134,19 +147,45 @@ visible_uploads AS (
 const referenceIDsAndFiltersBaseQuery = `
 FROM lsif_references r
 LEFT JOIN lsif_dumps u ON u.id = r.dump_id
+`
+
+const referenceIDsAndFiltersConditions = `
 WHERE (r.scheme, r.name, r.version) IN (%s) AND r.dump_id IN (SELECT * FROM visible_uploads)
 `
 
-const referenceIDsAndFiltersQuery = referenceIDsAndFiltersCTEDefinitions + `
+const referenceIDsAndFiltersQuery = referenceIDsAndFiltersCTEDefinitions + `,
+-- Distances of repositories from the source repository in the (undirected) repository dependency
+-- graph. A repository may be reachable by paths of different lengths, so we take the minimum below.
+dependency_graph_distances AS (
+	SELECT %s::integer AS repository_id, 0 AS distance
+	UNION
+	SELECT
+		CASE WHEN d.repository_id = g.repository_id THEN d.dependency_repository_id ELSE d.repository_id END,
+		g.distance + 1
+	FROM dependency_graph_distances g
+	JOIN lsif_repository_dependencies d ON g.repository_id IN (d.repository_id, d.dependency_repository_id)
+	WHERE g.distance < %s
+)
 SELECT r.dump_id, r.scheme, r.name, r.version, r.filter
 ` + referenceIDsAndFiltersBaseQuery + `
-ORDER BY dump_id
+LEFT JOIN (
+	SELECT g.repository_id, MIN(g.distance) AS distance
+	FROM dependency_graph_distances g
+	GROUP BY g.repository_id
+) rd ON rd.repository_id = u.repository_id
+` + referenceIDsAndFiltersConditions + `
+ORDER BY
+	COALESCE(rd.distance, %s),
+	(SELECT COUNT(*) FROM lsif_repository_dependencies d WHERE d.dependency_repository_id = u.repository_id) DESC,
+	u.finished_at DESC,
+	r.dump_id,
+	r.id
 LIMIT %s OFFSET %s
 `
 
 const referenceIDsAndFiltersCountQuery = referenceIDsAndFiltersCTEDefinitions + `
 SELECT COUNT(distinct r.dump_id)
-` + referenceIDsAndFiltersBaseQuery
+` + referenceIDsAndFiltersBaseQuery + referenceIDsAndFiltersConditions
 
 func monikersToString(vs []semantic.QualifiedMonikerData) string {
 	strs := make([]string, 0, len(vs))
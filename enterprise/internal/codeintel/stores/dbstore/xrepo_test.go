@@ -404,6 +404,91 @@ func TestReferencesForUpload(t *testing.T) {
 }
 
 // consumeScanner reads all values from the scanner into memory.
+func TestReferenceIDsAndFiltersRanking(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+
+	insertUploads(t, db,
+		Upload{ID: 1, Commit: makeCommit(1), RepositoryID: 56, FinishedAt: &t2}, // unconnected, newer
+		Upload{ID: 2, Commit: makeCommit(2), RepositoryID: 55, FinishedAt: &t1}, // unconnected, older
+		Upload{ID: 3, Commit: makeCommit(3), RepositoryID: 54, FinishedAt: &t1}, // unconnected, two dependents
+		Upload{ID: 4, Commit: makeCommit(4), RepositoryID: 52, FinishedAt: &t1}, // distance 2
+		Upload{ID: 5, Commit: makeCommit(5), RepositoryID: 51, FinishedAt: &t1}, // distance 1
+	)
+	insertVisibleAtTip(t, db, 51, 5)
+	insertVisibleAtTip(t, db, 52, 4)
+	insertVisibleAtTip(t, db, 54, 3)
+	insertVisibleAtTip(t, db, 55, 2)
+	insertVisibleAtTip(t, db, 56, 1)
+
+	insertRepositoryDependencies(t, db, map[int][]int{
+		51: {50},
+		52: {51},
+		57: {54},
+		58: {54},
+	})
+
+	var refs []lsifstore.PackageReference
+	for i := 1; i <= 5; i++ {
+		refs = append(refs, lsifstore.PackageReference{
+			Package: lsifstore.Package{DumpID: i, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"},
+			Filter:  []byte(fmt.Sprintf("f%d", i)),
+		})
+	}
+	insertPackageReferences(t, store, refs)
+
+	moniker := semantic.QualifiedMonikerData{
+		MonikerData: semantic.MonikerData{
+			Scheme: "gomod",
+		},
+		PackageInformationData: semantic.PackageInformationData{
+			Name:    "leftpad",
+			Version: "0.1.0",
+		},
+	}
+
+	expected := []lsifstore.PackageReference{refs[4], refs[3], refs[2], refs[0], refs[1]}
+
+	testCases := []struct {
+		limit    int
+		offset   int
+		expected []lsifstore.PackageReference
+	}{
+		{5, 0, expected},
+		{2, 0, expected[:2]},
+		{2, 2, expected[2:4]},
+		{2, 4, expected[4:]},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			scanner, totalCount, err := store.ReferenceIDsAndFilters(context.Background(), 50, makeCommit(1), []semantic.QualifiedMonikerData{moniker}, testCase.limit, testCase.offset)
+			if err != nil {
+				t.Fatalf("unexpected error getting filters: %s", err)
+			}
+
+			if totalCount != 5 {
+				t.Errorf("unexpected count. want=%d have=%d", 5, totalCount)
+			}
+
+			filters, err := consumeScanner(scanner)
+			if err != nil {
+				t.Fatalf("unexpected error from scanner: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, filters); diff != "" {
+				t.Errorf("unexpected filters (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func consumeScanner(scanner PackageReferenceScanner) (references []lsifstore.PackageReference, _ error) {
 	for {
 		reference, exists, err := scanner.Next()
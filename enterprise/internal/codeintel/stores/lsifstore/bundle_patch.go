@@ -0,0 +1,381 @@
+package lsifstore
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// bundlePatch holds the data of an incremental bundle rewritten so that it can be layered over the
+// data of its base bundle. The documents of the patch replace the documents of the base bundle with
+// the same path, and the changed paths that have no document in the patch are deleted.
+//
+// The result sets of the incremental bundle are linked to the result sets of the base bundle through
+// the anchors of the bundle: ranges of unchanged documents that occur within a result set of the patch.
+// An anchor with the same position as a range of the base bundle's copy of its document identifies
+// the result sets of both ranges. Linked result sets take the identifier used by the base bundle, so
+// that the ranges of unchanged documents (which are not rewritten) and the ranges of changed documents
+// resolve to the same set of locations. All other result sets are given an identifier unique to the
+// incremental bundle.
+type bundlePatch struct {
+	bundleID     int
+	changedPaths map[string]struct{}
+
+	// linkedResultIDs maps result identifiers of the incremental bundle to their counterpart in the
+	// base bundle.
+	linkedResultIDs map[semantic.ID]semantic.ID
+
+	// anchorRangeIDs maps the range identifiers of anchors to the identifier of the range with the
+	// same position in the base bundle's copy of the same document, keyed by document path.
+	anchorRangeIDs map[string]map[semantic.ID]semantic.ID
+
+	// documents holds the changed documents of the incremental bundle with rewritten result identifiers.
+	documents map[string]semantic.DocumentData
+
+	// results maps rewritten result identifiers to the locations within changed documents and the
+	// (rewritten) anchors that compose the result set.
+	results map[semantic.ID][]semantic.DocumentPathRangeID
+}
+
+// newBundlePatch creates a patch from the given incremental bundle data and the base bundle's copy of
+// the documents containing an anchor of the incremental bundle.
+func newBundlePatch(bundleID int, changedPaths []string, data *semantic.GroupedBundleDataMaps, anchorDocuments map[string]semantic.DocumentData) *bundlePatch {
+	p := &bundlePatch{
+		bundleID:        bundleID,
+		changedPaths:    make(map[string]struct{}, len(changedPaths)),
+		linkedResultIDs: map[semantic.ID]semantic.ID{},
+		anchorRangeIDs:  make(map[string]map[semantic.ID]semantic.ID, len(data.Anchors)),
+		documents:       make(map[string]semantic.DocumentData, len(data.Documents)),
+		results:         map[semantic.ID][]semantic.DocumentPathRangeID{},
+	}
+	for _, path := range changedPaths {
+		p.changedPaths[path] = struct{}{}
+	}
+
+	p.linkAnchors(data.Anchors, anchorDocuments)
+
+	for path, document := range data.Documents {
+		if !p.isChanged(path) {
+			continue
+		}
+
+		ranges := make(map[semantic.ID]semantic.RangeData, len(document.Ranges))
+		for rangeID, r := range document.Ranges {
+			r.DefinitionResultID = p.resultID(r.DefinitionResultID)
+			r.ReferenceResultID = p.resultID(r.ReferenceResultID)
+			r.ImplementationResultID = p.resultID(r.ImplementationResultID)
+			r.TypeDefinitionResultID = p.resultID(r.TypeDefinitionResultID)
+			ranges[rangeID] = r
+		}
+		document.Ranges = ranges
+
+		p.documents[path] = document
+	}
+
+	for _, resultChunk := range data.ResultChunks {
+		for resultID, documentIDRangeIDs := range resultChunk.DocumentIDRangeIDs {
+			targetID := p.resultID(resultID)
+
+			for _, documentIDRangeID := range documentIDRangeIDs {
+				path, ok := resultChunk.DocumentPaths[documentIDRangeID.DocumentID]
+				if !ok {
+					continue
+				}
+
+				rangeID := documentIDRangeID.RangeID
+				if !p.isChanged(path) {
+					// Locations within unchanged documents must refer to the base bundle's copy
+					// of the document. Anchors without a counterpart are dropped.
+					if rangeID, ok = p.anchorRangeIDs[path][rangeID]; !ok {
+						continue
+					}
+				}
+
+				p.results[targetID] = append(p.results[targetID], semantic.DocumentPathRangeID{
+					Path:    path,
+					RangeID: rangeID,
+				})
+			}
+		}
+	}
+
+	for resultID, locations := range p.results {
+		p.results[resultID] = sortDocumentPathRangeIDs(dedupeDocumentPathRangeIDs(locations), func(location semantic.DocumentPathRangeID) semantic.RangeData {
+			if document, ok := p.documents[location.Path]; ok {
+				return document.Ranges[location.RangeID]
+			}
+
+			return anchorDocuments[location.Path].Ranges[location.RangeID]
+		})
+	}
+
+	return p
+}
+
+// linkAnchors populates the anchor range and linked result identifier maps of the patch.
+func (p *bundlePatch) linkAnchors(anchors map[string]map[semantic.ID]semantic.RangeData, anchorDocuments map[string]semantic.DocumentData) {
+	for path, anchorRanges := range anchors {
+		baseRangeIDsByPosition := map[[4]int]semantic.ID{}
+		for rangeID, r := range anchorDocuments[path].Ranges {
+			baseRangeIDsByPosition[rangePosition(r)] = rangeID
+		}
+
+		// Link anchors in a deterministic order in case an incremental result set corresponds to
+		// multiple result sets of the base bundle.
+		rangeIDs := make([]semantic.ID, 0, len(anchorRanges))
+		for rangeID := range anchorRanges {
+			rangeIDs = append(rangeIDs, rangeID)
+		}
+		sort.Slice(rangeIDs, func(i, j int) bool { return rangeIDs[i] < rangeIDs[j] })
+
+		rangeIDMap := map[semantic.ID]semantic.ID{}
+		for _, rangeID := range rangeIDs {
+			anchor := anchorRanges[rangeID]
+
+			baseRangeID, ok := baseRangeIDsByPosition[rangePosition(anchor)]
+			if !ok {
+				continue
+			}
+			rangeIDMap[rangeID] = baseRangeID
+
+			baseRange := anchorDocuments[path].Ranges[baseRangeID]
+			p.link(anchor.DefinitionResultID, baseRange.DefinitionResultID)
+			p.link(anchor.ReferenceResultID, baseRange.ReferenceResultID)
+			p.link(anchor.ImplementationResultID, baseRange.ImplementationResultID)
+			p.link(anchor.TypeDefinitionResultID, baseRange.TypeDefinitionResultID)
+		}
+
+		p.anchorRangeIDs[path] = rangeIDMap
+	}
+}
+
+func (p *bundlePatch) link(resultID, baseResultID semantic.ID) {
+	if resultID == "" || baseResultID == "" {
+		return
+	}
+	if _, ok := p.linkedResultIDs[resultID]; !ok {
+		p.linkedResultIDs[resultID] = baseResultID
+	}
+}
+
+// resultID returns the identifier of the given incremental result set within the patched bundle.
+func (p *bundlePatch) resultID(resultID semantic.ID) semantic.ID {
+	if resultID == "" {
+		return ""
+	}
+	if baseResultID, ok := p.linkedResultIDs[resultID]; ok {
+		return baseResultID
+	}
+
+	return semantic.ID(fmt.Sprintf("%d:%s", p.bundleID, resultID))
+}
+
+func (p *bundlePatch) isChanged(path string) bool {
+	_, ok := p.changedPaths[path]
+	return ok
+}
+
+// resultIDsByResultChunkIndex returns the identifiers of the patch's result sets grouped by the index
+// of the result chunk they hash to in a bundle with the given number of result chunks.
+func (p *bundlePatch) resultIDsByResultChunkIndex(numResultChunks int) map[int][]semantic.ID {
+	resultIDsByIndex := map[int][]semantic.ID{}
+	for resultID := range p.results {
+		index := semantic.HashKey(resultID, numResultChunks)
+		resultIDsByIndex[index] = append(resultIDsByIndex[index], resultID)
+	}
+
+	return resultIDsByIndex
+}
+
+// referencesChangedPath determines if the given result chunk of the base bundle contains a location
+// within a changed document.
+func (p *bundlePatch) referencesChangedPath(resultChunk semantic.ResultChunkData) bool {
+	for _, path := range resultChunk.DocumentPaths {
+		if p.isChanged(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// patchResultChunk removes the locations within changed documents from the given result chunk of the
+// base bundle, then adds the locations of the given result sets of the patch to the chunk.
+func (p *bundlePatch) patchResultChunk(resultChunk semantic.ResultChunkData, resultIDs []semantic.ID) semantic.ResultChunkData {
+	documentIDsByPath := make(map[string]semantic.ID, len(resultChunk.DocumentPaths))
+	documentPaths := make(map[semantic.ID]string, len(resultChunk.DocumentPaths))
+	for documentID, path := range resultChunk.DocumentPaths {
+		if !p.isChanged(path) {
+			documentIDsByPath[path] = documentID
+			documentPaths[documentID] = path
+		}
+	}
+
+	documentIDRangeIDs := make(map[semantic.ID][]semantic.DocumentIDRangeID, len(resultChunk.DocumentIDRangeIDs)+len(resultIDs))
+	for resultID, locations := range resultChunk.DocumentIDRangeIDs {
+		filtered := make([]semantic.DocumentIDRangeID, 0, len(locations))
+		for _, location := range locations {
+			if _, ok := documentPaths[location.DocumentID]; ok {
+				filtered = append(filtered, location)
+			}
+		}
+
+		if len(filtered) > 0 {
+			documentIDRangeIDs[resultID] = filtered
+		}
+	}
+
+	nextDocumentID := len(resultChunk.DocumentPaths)
+	documentIDForPath := func(path string) semantic.ID {
+		if documentID, ok := documentIDsByPath[path]; ok {
+			return documentID
+		}
+
+		documentID := semantic.ID(strconv.Itoa(nextDocumentID))
+		for _, ok := resultChunk.DocumentPaths[documentID]; ok; _, ok = resultChunk.DocumentPaths[documentID] {
+			nextDocumentID++
+			documentID = semantic.ID(strconv.Itoa(nextDocumentID))
+		}
+		nextDocumentID++
+
+		documentIDsByPath[path] = documentID
+		documentPaths[documentID] = path
+		return documentID
+	}
+
+	for _, resultID := range resultIDs {
+		existing := map[semantic.DocumentPathRangeID]struct{}{}
+		for _, location := range documentIDRangeIDs[resultID] {
+			existing[semantic.DocumentPathRangeID{Path: documentPaths[location.DocumentID], RangeID: location.RangeID}] = struct{}{}
+		}
+
+		locations := documentIDRangeIDs[resultID]
+		for _, location := range p.results[resultID] {
+			if _, ok := existing[location]; ok {
+				continue
+			}
+
+			locations = append(locations, semantic.DocumentIDRangeID{
+				DocumentID: documentIDForPath(location.Path),
+				RangeID:    location.RangeID,
+			})
+		}
+
+		// Keep the locations of the result set grouped by document path. Locations of the base
+		// bundle precede new locations within the same document.
+		sort.SliceStable(locations, func(i, j int) bool {
+			return documentPaths[locations[i].DocumentID] < documentPaths[locations[j].DocumentID]
+		})
+
+		if len(locations) > 0 {
+			documentIDRangeIDs[resultID] = locations
+		}
+	}
+
+	return semantic.ResultChunkData{
+		DocumentPaths:      documentPaths,
+		DocumentIDRangeIDs: documentIDRangeIDs,
+	}
+}
+
+// patchMonikerLocations merges the given moniker locations of the base bundle with the moniker
+// locations of the incremental bundle. Locations of the base bundle within changed documents are
+// replaced by the locations of the incremental bundle, which only occur within changed documents.
+func (p *bundlePatch) patchMonikerLocations(baseMonikerLocations []QualifiedMonikerLocations, monikerLocations map[string]map[string][]semantic.LocationData) []semantic.MonikerLocations {
+	type monikerKey struct{ scheme, identifier string }
+	locationsByMoniker := map[monikerKey][]semantic.LocationData{}
+
+	for _, baseLocations := range baseMonikerLocations {
+		key := monikerKey{baseLocations.Scheme, baseLocations.Identifier}
+		for _, location := range baseLocations.Locations {
+			if !p.isChanged(location.URI) {
+				locationsByMoniker[key] = append(locationsByMoniker[key], location)
+			}
+		}
+	}
+	for scheme, locationsByIdentifier := range monikerLocations {
+		for identifier, locations := range locationsByIdentifier {
+			key := monikerKey{scheme, identifier}
+			for _, location := range locations {
+				if p.isChanged(location.URI) {
+					locationsByMoniker[key] = append(locationsByMoniker[key], location)
+				}
+			}
+		}
+	}
+
+	patched := make([]semantic.MonikerLocations, 0, len(locationsByMoniker))
+	for key, locations := range locationsByMoniker {
+		if len(locations) == 0 {
+			continue
+		}
+
+		sort.Slice(locations, func(i, j int) bool {
+			if locations[i].URI != locations[j].URI {
+				return locations[i].URI < locations[j].URI
+			}
+			if locations[i].StartLine != locations[j].StartLine {
+				return locations[i].StartLine < locations[j].StartLine
+			}
+
+			return locations[i].StartCharacter < locations[j].StartCharacter
+		})
+
+		patched = append(patched, semantic.MonikerLocations{
+			Scheme:     key.scheme,
+			Identifier: key.identifier,
+			Locations:  locations,
+		})
+	}
+
+	sort.Slice(patched, func(i, j int) bool {
+		if patched[i].Scheme != patched[j].Scheme {
+			return patched[i].Scheme < patched[j].Scheme
+		}
+
+		return patched[i].Identifier < patched[j].Identifier
+	})
+
+	return patched
+}
+
+// rangePosition returns the start and end positions of the given range.
+func rangePosition(r semantic.RangeData) [4]int {
+	return [4]int{r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter}
+}
+
+// dedupeDocumentPathRangeIDs returns the given locations without duplicates.
+func dedupeDocumentPathRangeIDs(locations []semantic.DocumentPathRangeID) []semantic.DocumentPathRangeID {
+	seen := make(map[semantic.DocumentPathRangeID]struct{}, len(locations))
+
+	filtered := locations[:0]
+	for _, location := range locations {
+		if _, ok := seen[location]; !ok {
+			seen[location] = struct{}{}
+			filtered = append(filtered, location)
+		}
+	}
+
+	return filtered
+}
+
+// sortDocumentPathRangeIDs sorts the given locations by document path, then by the position of the
+// range returned by the given function.
+func sortDocumentPathRangeIDs(locations []semantic.DocumentPathRangeID, rangeOf func(semantic.DocumentPathRangeID) semantic.RangeData) []semantic.DocumentPathRangeID {
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Path != locations[j].Path {
+			return locations[i].Path < locations[j].Path
+		}
+
+		ri, rj := rangeOf(locations[i]), rangeOf(locations[j])
+		if ri.StartLine != rj.StartLine {
+			return ri.StartLine < rj.StartLine
+		}
+
+		return ri.StartCharacter < rj.StartCharacter
+	})
+
+	return locations
+}
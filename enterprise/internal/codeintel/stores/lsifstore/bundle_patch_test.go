@@ -0,0 +1,122 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestBundlePatch(t *testing.T) {
+	// The base bundle's copy of the unchanged document a.go
+	anchorDocuments := map[string]semantic.DocumentData{
+		"a.go": {
+			Ranges: map[semantic.ID]semantic.RangeData{
+				"r1": {StartLine: 1, StartCharacter: 0, EndLine: 1, EndCharacter: 3, DefinitionResultID: "d1", ReferenceResultID: "x1"},
+				"r2": {StartLine: 4, StartCharacter: 0, EndLine: 4, EndCharacter: 3, DefinitionResultID: "d2"},
+			},
+		},
+	}
+
+	data := &semantic.GroupedBundleDataMaps{
+		Documents: map[string]semantic.DocumentData{
+			"b.go": {
+				Ranges: map[semantic.ID]semantic.RangeData{
+					"n5": {StartLine: 2, StartCharacter: 0, EndLine: 2, EndCharacter: 3, DefinitionResultID: "7", ReferenceResultID: "8"},
+					"n6": {StartLine: 3, StartCharacter: 0, EndLine: 3, EndCharacter: 3, DefinitionResultID: "11"},
+				},
+			},
+		},
+		ResultChunks: map[int]semantic.ResultChunkData{
+			0: {
+				DocumentPaths: map[semantic.ID]string{"1": "a.go", "2": "b.go"},
+				DocumentIDRangeIDs: map[semantic.ID][]semantic.DocumentIDRangeID{
+					"7":  {{DocumentID: "1", RangeID: "9"}},
+					"8":  {{DocumentID: "2", RangeID: "n5"}, {DocumentID: "1", RangeID: "9"}},
+					"11": {{DocumentID: "2", RangeID: "n6"}},
+				},
+			},
+		},
+		Anchors: map[string]map[semantic.ID]semantic.RangeData{
+			"a.go": {
+				"9": {StartLine: 1, StartCharacter: 0, EndLine: 1, EndCharacter: 3, DefinitionResultID: "7", ReferenceResultID: "8"},
+			},
+		},
+	}
+
+	patch := newBundlePatch(42, []string{"b.go", "c.go"}, data, anchorDocuments)
+
+	expectedDocument := semantic.DocumentData{
+		Ranges: map[semantic.ID]semantic.RangeData{
+			"n5": {StartLine: 2, StartCharacter: 0, EndLine: 2, EndCharacter: 3, DefinitionResultID: "d1", ReferenceResultID: "x1"},
+			"n6": {StartLine: 3, StartCharacter: 0, EndLine: 3, EndCharacter: 3, DefinitionResultID: "42:11"},
+		},
+	}
+	if diff := cmp.Diff(expectedDocument, patch.documents["b.go"]); diff != "" {
+		t.Errorf("unexpected document (-want +got):\n%s", diff)
+	}
+
+	expectedResults := map[semantic.ID][]semantic.DocumentPathRangeID{
+		"d1":    {{Path: "a.go", RangeID: "r1"}},
+		"x1":    {{Path: "a.go", RangeID: "r1"}, {Path: "b.go", RangeID: "n5"}},
+		"42:11": {{Path: "b.go", RangeID: "n6"}},
+	}
+	if diff := cmp.Diff(expectedResults, patch.results); diff != "" {
+		t.Errorf("unexpected results (-want +got):\n%s", diff)
+	}
+
+	baseResultChunk := semantic.ResultChunkData{
+		DocumentPaths: map[semantic.ID]string{"0": "a.go", "1": "b.go", "3": "c.go"},
+		DocumentIDRangeIDs: map[semantic.ID][]semantic.DocumentIDRangeID{
+			"d1": {{DocumentID: "0", RangeID: "r1"}},
+			"d2": {{DocumentID: "0", RangeID: "r2"}},
+			"x1": {{DocumentID: "0", RangeID: "r1"}, {DocumentID: "1", RangeID: "old"}},
+			"x2": {{DocumentID: "3", RangeID: "gone"}},
+		},
+	}
+	if !patch.referencesChangedPath(baseResultChunk) {
+		t.Errorf("expected base result chunk to reference a changed path")
+	}
+
+	expectedResultChunk := semantic.ResultChunkData{
+		DocumentPaths: map[semantic.ID]string{"0": "a.go", "4": "b.go"},
+		DocumentIDRangeIDs: map[semantic.ID][]semantic.DocumentIDRangeID{
+			"d1":    {{DocumentID: "0", RangeID: "r1"}},
+			"d2":    {{DocumentID: "0", RangeID: "r2"}},
+			"x1":    {{DocumentID: "0", RangeID: "r1"}, {DocumentID: "4", RangeID: "n5"}},
+			"42:11": {{DocumentID: "4", RangeID: "n6"}},
+		},
+	}
+	if diff := cmp.Diff(expectedResultChunk, patch.patchResultChunk(baseResultChunk, []semantic.ID{"d1", "x1", "42:11"})); diff != "" {
+		t.Errorf("unexpected result chunk (-want +got):\n%s", diff)
+	}
+}
+
+func TestBundlePatchMonikerLocations(t *testing.T) {
+	patch := newBundlePatch(42, []string{"b.go"}, &semantic.GroupedBundleDataMaps{}, nil)
+
+	baseMonikerLocations := []QualifiedMonikerLocations{
+		{DumpID: 41, MonikerLocations: semantic.MonikerLocations{Scheme: "gomod", Identifier: "foo", Locations: []semantic.LocationData{
+			{URI: "a.go", StartLine: 1},
+			{URI: "b.go", StartLine: 5},
+		}}},
+		{DumpID: 41, MonikerLocations: semantic.MonikerLocations{Scheme: "gomod", Identifier: "bar", Locations: []semantic.LocationData{
+			{URI: "b.go", StartLine: 6},
+		}}},
+	}
+	monikerLocations := map[string]map[string][]semantic.LocationData{
+		"gomod": {
+			"foo": {{URI: "b.go", StartLine: 2}},
+			"baz": {{URI: "b.go", StartLine: 3}},
+		},
+	}
+
+	expected := []semantic.MonikerLocations{
+		{Scheme: "gomod", Identifier: "baz", Locations: []semantic.LocationData{{URI: "b.go", StartLine: 3}}},
+		{Scheme: "gomod", Identifier: "foo", Locations: []semantic.LocationData{{URI: "a.go", StartLine: 1}, {URI: "b.go", StartLine: 2}}},
+	}
+	if diff := cmp.Diff(expected, patch.patchMonikerLocations(baseMonikerLocations, monikerLocations)); diff != "" {
+		t.Errorf("unexpected moniker locations (-want +got):\n%s", diff)
+	}
+}
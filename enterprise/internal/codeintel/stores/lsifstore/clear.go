@@ -15,12 +15,15 @@ import (
 var tableNames = []string{
 	"lsif_data_metadata",
 	"lsif_data_documents",
+	"lsif_data_document_overlays",
 	"lsif_data_documents_schema_versions",
 	"lsif_data_result_chunks",
+	"lsif_data_result_chunk_overlays",
 	"lsif_data_definitions",
 	"lsif_data_definitions_schema_versions",
 	"lsif_data_references",
 	"lsif_data_references_schema_versions",
+	"lsif_data_implementations",
 }
 
 func (s *Store) Clear(ctx context.Context, bundleIDs ...int) (err error) {
@@ -0,0 +1,343 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// WriteIncrementalBundle is called (transactionally) from the precise-code-intel-worker. It writes the
+// data of an incremental bundle, which contains only the given changed paths, as a new bundle layered
+// over the data of the given base bundle. Documents and result chunks of the base bundle that are
+// unaffected by the changed paths are not copied: the new bundle resolves them through overlay rows to
+// the bundle that stores them. The remaining data is patched with the data of the incremental bundle.
+func (s *Store) WriteIncrementalBundle(ctx context.Context, bundleID, baseBundleID int, changedPaths []string, groupedBundleData *semantic.GroupedBundleDataChans) (err error) {
+	ctx, traceLog, endObservation := s.operations.writeIncrementalBundle.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("baseBundleID", baseBundleID),
+		log.Int("numChangedPaths", len(changedPaths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentation := collectDocumentation(groupedBundleData)
+	data := semantic.GroupedBundleDataChansToMaps(groupedBundleData)
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Result identifiers are hashed into the same number of result chunks as the base bundle so that
+	// unaffected result chunks can be shared.
+	numResultChunks, exists, err := basestore.ScanFirstInt(tx.Store.Query(ctx, sqlf.Sprintf(translateIDsToResultChunkIndexesQuery, baseBundleID)))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Wrapf(ErrNoMetadata, "base bundle %d", baseBundleID)
+	}
+	if err := tx.WriteMeta(ctx, bundleID, semantic.MetaData{NumResultChunks: numResultChunks}); err != nil {
+		return err
+	}
+
+	baseMappings, err := tx.scanDocumentationMappings(tx.Store.Query(ctx, sqlf.Sprintf(writeIncrementalBundleDocumentationMappingsQuery, baseBundleID)))
+	if err != nil {
+		return err
+	}
+	documentationPatch := newDocumentationPatch(changedPaths, baseMappings, documentation.mappings)
+	documentationPatch.patchDocuments(data.Documents)
+
+	anchorDocuments, err := tx.readAnchorDocuments(ctx, baseBundleID, data.Anchors)
+	if err != nil {
+		return err
+	}
+	patch := newBundlePatch(bundleID, changedPaths, data, anchorDocuments)
+	traceLog(
+		log.Int("numDocuments", len(patch.documents)),
+		log.Int("numResults", len(patch.results)),
+		log.Int("numLinkedResults", len(patch.linkedResultIDs)),
+	)
+
+	if err := tx.writeIncrementalDocuments(ctx, bundleID, baseBundleID, changedPaths, patch); err != nil {
+		return err
+	}
+	if err := tx.writeIncrementalResultChunks(ctx, bundleID, baseBundleID, numResultChunks, patch, traceLog); err != nil {
+		return err
+	}
+
+	for _, table := range []struct {
+		name             string
+		version          int
+		monikerLocations map[string]map[string][]semantic.LocationData
+	}{
+		{"lsif_data_definitions", CurrentDefinitionsSchemaVersion, data.Definitions},
+		{"lsif_data_references", CurrentReferencesSchemaVersion, data.References},
+		{"lsif_data_implementations", CurrentImplementationsSchemaVersion, data.Implementations},
+	} {
+		baseMonikerLocations, err := tx.scanQualifiedMonikerLocations(tx.Store.Query(ctx, sqlf.Sprintf(
+			writeIncrementalBundleMonikerLocationsQuery,
+			sqlf.Sprintf(table.name),
+			baseBundleID,
+		)))
+		if err != nil {
+			return err
+		}
+
+		monikerLocations := patch.patchMonikerLocations(baseMonikerLocations, table.monikerLocations)
+		if err := tx.writeDefinitionReferences(ctx, bundleID, table.name, table.version, monikerLocationsChan(monikerLocations), traceLog); err != nil {
+			return err
+		}
+	}
+
+	return tx.writeIncrementalDocumentation(ctx, bundleID, baseBundleID, documentationPatch, baseMappings, documentation, traceLog)
+}
+
+const writeIncrementalBundleMonikerLocationsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:WriteIncrementalBundle
+SELECT dump_id, scheme, identifier, data FROM %s WHERE dump_id = %s
+`
+
+const writeIncrementalBundleDocumentationMappingsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:WriteIncrementalBundle
+SELECT path_id, result_id, file_path FROM lsif_data_documentation_mappings WHERE dump_id = %s
+`
+
+// readAnchorDocuments reads the base bundle's copy of each document containing an anchor.
+func (s *Store) readAnchorDocuments(ctx context.Context, baseBundleID int, anchors map[string]map[semantic.ID]semantic.RangeData) (map[string]semantic.DocumentData, error) {
+	paths := make([]string, 0, len(anchors))
+	for path := range anchors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	documents := make(map[string]semantic.DocumentData, len(paths))
+	visitDocuments := s.makeDocumentVisitor(func(path string, document semantic.DocumentData) {
+		documents[path] = document
+	})
+
+	for len(paths) > 0 {
+		var batch []string
+		if len(paths) <= documentBatchSize {
+			batch, paths = paths, nil
+		} else {
+			batch, paths = paths[:documentBatchSize], paths[documentBatchSize:]
+		}
+
+		pathQueries := make([]*sqlf.Query, 0, len(batch))
+		for _, path := range batch {
+			pathQueries = append(pathQueries, sqlf.Sprintf("%s", path))
+		}
+		if err := visitDocuments(s.Store.Query(ctx, sqlf.Sprintf(readRangesFromDocumentsQuery, baseBundleID, sqlf.Join(pathQueries, ",")))); err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+}
+
+// writeIncrementalDocuments writes the changed documents of the patch and resolves every other document
+// of the base bundle to the bundle that stores it.
+func (s *Store) writeIncrementalDocuments(ctx context.Context, bundleID, baseBundleID int, changedPaths []string, patch *bundlePatch) error {
+	documents := make(chan semantic.KeyedDocumentData, len(patch.documents))
+	for path, document := range patch.documents {
+		documents <- semantic.KeyedDocumentData{Path: path, Document: document}
+	}
+	close(documents)
+
+	if err := s.WriteDocuments(ctx, bundleID, documents); err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(writeIncrementalDocumentOverlaysQuery, bundleID, baseBundleID, baseBundleID, pq.Array(changedPaths)))
+}
+
+// The base bundle may itself be an incremental bundle, in which case its overlay rows are followed so
+// that every overlay row refers to the bundle that stores the document.
+const writeIncrementalDocumentOverlaysQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:writeIncrementalDocuments
+INSERT INTO lsif_data_document_overlays (dump_id, path, source_dump_id)
+SELECT %s, base.path, base.source_dump_id
+FROM (
+	SELECT path, dump_id AS source_dump_id FROM lsif_data_documents WHERE dump_id = %s
+	UNION ALL
+	SELECT path, source_dump_id FROM lsif_data_document_overlays WHERE dump_id = %s
+) base
+WHERE NOT (base.path = ANY(%s))
+`
+
+// writeIncrementalResultChunks writes the result chunks of the base bundle affected by the patch and
+// resolves every other result chunk of the base bundle to the bundle that stores it.
+func (s *Store) writeIncrementalResultChunks(ctx context.Context, bundleID, baseBundleID, numResultChunks int, patch *bundlePatch, traceLog observation.TraceLogger) error {
+	resultIDsByIndex := patch.resultIDsByResultChunkIndex(numResultChunks)
+
+	patchedResultChunks := map[int]semantic.ResultChunkData{}
+	visitResultChunks := s.makeResultChunkVisitor(s.Store.Query(ctx, sqlf.Sprintf(writeIncrementalResultChunksQuery, baseBundleID)))
+	if err := visitResultChunks(func(index int, resultChunk semantic.ResultChunkData) {
+		if resultIDs, ok := resultIDsByIndex[index]; ok || patch.referencesChangedPath(resultChunk) {
+			patchedResultChunks[index] = patch.patchResultChunk(resultChunk, resultIDs)
+		}
+	}); err != nil {
+		return err
+	}
+
+	// The base bundle may not have a result chunk at every index
+	for index, resultIDs := range resultIDsByIndex {
+		if _, ok := patchedResultChunks[index]; !ok {
+			patchedResultChunks[index] = patch.patchResultChunk(semantic.ResultChunkData{}, resultIDs)
+		}
+	}
+	traceLog(log.Int("numPatchedResultChunks", len(patchedResultChunks)))
+
+	indexes := make([]int, 0, len(patchedResultChunks))
+	resultChunks := make(chan semantic.IndexedResultChunkData, len(patchedResultChunks))
+	for index, resultChunk := range patchedResultChunks {
+		indexes = append(indexes, index)
+		resultChunks <- semantic.IndexedResultChunkData{Index: index, ResultChunk: resultChunk}
+	}
+	close(resultChunks)
+
+	if err := s.WriteResultChunks(ctx, bundleID, resultChunks); err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(writeIncrementalResultChunkOverlaysQuery, bundleID, baseBundleID, baseBundleID, pq.Array(indexes)))
+}
+
+const writeIncrementalResultChunksQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:writeIncrementalResultChunks
+SELECT idx, data FROM lsif_data_result_chunks_with_overlays WHERE dump_id = %s
+`
+
+const writeIncrementalResultChunkOverlaysQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:writeIncrementalResultChunks
+INSERT INTO lsif_data_result_chunk_overlays (dump_id, idx, source_dump_id)
+SELECT %s, base.idx, base.source_dump_id
+FROM (
+	SELECT idx, dump_id AS source_dump_id FROM lsif_data_result_chunks WHERE dump_id = %s
+	UNION ALL
+	SELECT idx, source_dump_id FROM lsif_data_result_chunk_overlays WHERE dump_id = %s
+) base
+WHERE NOT (base.idx = ANY(%s))
+`
+
+// writeIncrementalDocumentation writes the API documentation of the base bundle patched with the API
+// documentation of the incremental bundle. Pages of the base bundle that are not affected by the patch
+// are copied as-is.
+func (s *Store) writeIncrementalDocumentation(ctx context.Context, bundleID, baseBundleID int, patch *documentationPatch, baseMappings []semantic.DocumentationMapping, documentation incrementalDocumentation, traceLog observation.TraceLogger) error {
+	basePages, err := s.scanDocumentationPages(s.Store.Query(ctx, sqlf.Sprintf(writeIncrementalDocumentationPagesQuery, baseBundleID)))
+	if err != nil {
+		return err
+	}
+	basePathInfo, err := s.scanDocumentationPathInfo(s.Store.Query(ctx, sqlf.Sprintf(writeIncrementalDocumentationPathInfoQuery, baseBundleID)))
+	if err != nil {
+		return err
+	}
+
+	patchedPages, deletedPages := patch.patchPages(basePages, documentation.pages)
+	pathInfo := patch.patchPathInfo(basePathInfo, documentation.pathInfo, patchedPages, deletedPages)
+	mappings := patch.patchMappings(baseMappings, documentation.mappings)
+	traceLog(
+		log.Int("numPatchedDocumentationPages", len(patchedPages)),
+		log.Int("numDeletedDocumentationPages", len(deletedPages)),
+	)
+
+	pathIDs := make([]string, 0, len(patchedPages)+len(deletedPages))
+	pages := make(chan *semantic.DocumentationPageData, len(patchedPages))
+	for pathID, page := range patchedPages {
+		pathIDs = append(pathIDs, pathID)
+		pages <- page
+	}
+	close(pages)
+	pathIDs = append(pathIDs, deletedPages...)
+
+	if err := s.WriteDocumentationPages(ctx, bundleID, pages); err != nil {
+		return err
+	}
+	if err := s.Exec(ctx, sqlf.Sprintf(writeIncrementalDocumentationCopyPagesQuery, bundleID, baseBundleID, pq.Array(pathIDs))); err != nil {
+		return err
+	}
+
+	pathInfoCh := make(chan *semantic.DocumentationPathInfoData, len(pathInfo))
+	for _, info := range pathInfo {
+		pathInfoCh <- info
+	}
+	close(pathInfoCh)
+
+	if err := s.WriteDocumentationPathInfo(ctx, bundleID, pathInfoCh); err != nil {
+		return err
+	}
+
+	mappingsCh := make(chan semantic.DocumentationMapping, len(mappings))
+	for _, mapping := range mappings {
+		mappingsCh <- mapping
+	}
+	close(mappingsCh)
+
+	return s.WriteDocumentationMappings(ctx, bundleID, mappingsCh)
+}
+
+const writeIncrementalDocumentationPagesQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:writeIncrementalDocumentation
+SELECT path_id, data FROM lsif_data_documentation_pages WHERE dump_id = %s
+`
+
+const writeIncrementalDocumentationPathInfoQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:writeIncrementalDocumentation
+SELECT path_id, data FROM lsif_data_documentation_path_info WHERE dump_id = %s
+`
+
+const writeIncrementalDocumentationCopyPagesQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/data_write_incremental.go:writeIncrementalDocumentation
+INSERT INTO lsif_data_documentation_pages (dump_id, path_id, data)
+SELECT %s, path_id, data
+FROM lsif_data_documentation_pages
+WHERE dump_id = %s AND NOT (path_id = ANY(%s))
+`
+
+// incrementalDocumentation holds the API documentation of an incremental bundle.
+type incrementalDocumentation struct {
+	pages    []*semantic.DocumentationPageData
+	pathInfo []*semantic.DocumentationPathInfoData
+	mappings []semantic.DocumentationMapping
+}
+
+// collectDocumentation reads the values of the documentation channels of the given bundle data.
+func collectDocumentation(groupedBundleData *semantic.GroupedBundleDataChans) (documentation incrementalDocumentation) {
+	if groupedBundleData.DocumentationPages != nil {
+		for page := range groupedBundleData.DocumentationPages {
+			documentation.pages = append(documentation.pages, page)
+		}
+	}
+	if groupedBundleData.DocumentationPathInfo != nil {
+		for info := range groupedBundleData.DocumentationPathInfo {
+			documentation.pathInfo = append(documentation.pathInfo, info)
+		}
+	}
+	if groupedBundleData.DocumentationMappings != nil {
+		for mapping := range groupedBundleData.DocumentationMappings {
+			documentation.mappings = append(documentation.mappings, mapping)
+		}
+	}
+
+	return documentation
+}
+
+// monikerLocationsChan returns a closed channel containing the given moniker locations.
+func monikerLocationsChan(monikerLocations []semantic.MonikerLocations) chan semantic.MonikerLocations {
+	ch := make(chan semantic.MonikerLocations, len(monikerLocations))
+	for _, v := range monikerLocations {
+		ch <- v
+	}
+	close(ch)
+
+	return ch
+}
@@ -62,7 +62,7 @@ SELECT
 	NULL AS packages,
 	diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_documents_with_overlays
 WHERE
 	dump_id = %s AND
 	path LIKE %s
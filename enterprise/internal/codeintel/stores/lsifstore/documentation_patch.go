@@ -0,0 +1,349 @@
+package lsifstore
+
+import (
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// documentationPatch merges the API documentation of an incremental bundle into the API documentation
+// of its base bundle. Documentation nodes are attributed to a document through the file path of their
+// documentation mapping. The nodes of the base bundle within a changed document are removed, and the
+// nodes of the incremental bundle within a changed document are added. All other nodes of the base
+// bundle are retained, and all other nodes of the incremental bundle are ignored.
+//
+// The documentationResult identifiers of the incremental bundle are offset so that they do not collide
+// with the identifiers of the base bundle, which are still referenced by the unchanged documents.
+type documentationPatch struct {
+	changedPaths   map[string]struct{}
+	resultIDOffset uint64
+
+	// staleIDs holds the path IDs of the base bundle's nodes within a changed document.
+	staleIDs map[string]struct{}
+
+	// newIDs holds the path IDs of the incremental bundle's nodes within a changed document.
+	newIDs map[string]struct{}
+}
+
+// newDocumentationPatch creates a documentation patch from the documentation mappings of the base
+// bundle and of the incremental bundle.
+func newDocumentationPatch(changedPaths []string, baseMappings, mappings []semantic.DocumentationMapping) *documentationPatch {
+	p := &documentationPatch{
+		changedPaths: make(map[string]struct{}, len(changedPaths)),
+		staleIDs:     map[string]struct{}{},
+		newIDs:       map[string]struct{}{},
+	}
+	for _, path := range changedPaths {
+		p.changedPaths[path] = struct{}{}
+	}
+
+	for _, mapping := range baseMappings {
+		if mapping.ResultID >= p.resultIDOffset {
+			p.resultIDOffset = mapping.ResultID + 1
+		}
+		if p.isChanged(mapping.FilePath) {
+			p.staleIDs[mapping.PathID] = struct{}{}
+		}
+	}
+	for _, mapping := range mappings {
+		if p.isChanged(mapping.FilePath) {
+			p.newIDs[mapping.PathID] = struct{}{}
+		}
+	}
+
+	return p
+}
+
+func (p *documentationPatch) isChanged(path *string) bool {
+	if path == nil {
+		return false
+	}
+
+	_, ok := p.changedPaths[*path]
+	return ok
+}
+
+// documentationResultID returns the identifier of the given documentationResult of the incremental
+// bundle within the patched bundle.
+func (p *documentationPatch) documentationResultID(resultID semantic.ID) semantic.ID {
+	if resultID == "" {
+		return ""
+	}
+
+	id, err := strconv.ParseUint(string(resultID), 10, 64)
+	if err != nil {
+		return resultID
+	}
+
+	return semantic.ID(strconv.FormatUint(id+p.resultIDOffset, 10))
+}
+
+// patchDocuments rewrites the documentationResult identifiers of the given documents of the
+// incremental bundle in place.
+func (p *documentationPatch) patchDocuments(documents map[string]semantic.DocumentData) {
+	for _, document := range documents {
+		for rangeID, r := range document.Ranges {
+			if r.DocumentationResultID != "" {
+				r.DocumentationResultID = p.documentationResultID(r.DocumentationResultID)
+				document.Ranges[rangeID] = r
+			}
+		}
+	}
+}
+
+// patchMappings returns the documentation mappings of the patched bundle.
+func (p *documentationPatch) patchMappings(baseMappings, mappings []semantic.DocumentationMapping) []semantic.DocumentationMapping {
+	patched := make([]semantic.DocumentationMapping, 0, len(baseMappings)+len(p.newIDs))
+	for _, mapping := range baseMappings {
+		if _, ok := p.staleIDs[mapping.PathID]; ok {
+			continue
+		}
+		if _, ok := p.newIDs[mapping.PathID]; ok {
+			continue
+		}
+
+		patched = append(patched, mapping)
+	}
+	for _, mapping := range mappings {
+		if _, ok := p.newIDs[mapping.PathID]; ok {
+			mapping.ResultID += p.resultIDOffset
+			patched = append(patched, mapping)
+		}
+	}
+
+	return patched
+}
+
+// patchPages merges the given documentation pages of the incremental bundle into the given pages of
+// the base bundle, which are modified in place. This method returns the pages that differ from the
+// base bundle, and the path IDs of the pages of the base bundle that no longer document anything.
+func (p *documentationPatch) patchPages(basePages map[string]*semantic.DocumentationPageData, pages []*semantic.DocumentationPageData) (map[string]*semantic.DocumentationPageData, []string) {
+	patched := map[string]*semantic.DocumentationPageData{}
+	for pathID, page := range basePages {
+		if p.prune(page.Tree) {
+			patched[pathID] = page
+		}
+	}
+
+	// Pages that are new to the incremental bundle, e.g. for a new package
+	pageIDs := make(map[string]struct{}, len(basePages))
+	for pathID := range basePages {
+		pageIDs[pathID] = struct{}{}
+	}
+	var addedPages []*semantic.DocumentationPageData
+	for _, page := range pages {
+		if _, ok := basePages[page.Tree.PathID]; !ok && p.contains(page.Tree) {
+			pageIDs[page.Tree.PathID] = struct{}{}
+			addedPages = append(addedPages, page)
+		}
+	}
+	isMissingPage := func(pathID string) bool {
+		_, ok := pageIDs[pathID]
+		return !ok
+	}
+	for _, page := range addedPages {
+		removePageChildren(page.Tree, isMissingPage)
+		patched[page.Tree.PathID] = page
+	}
+
+	for _, page := range pages {
+		if basePage, ok := basePages[page.Tree.PathID]; ok && p.graft(basePage.Tree, page.Tree, pageIDs) {
+			patched[page.Tree.PathID] = basePage
+		}
+	}
+
+	var deleted []string
+	for pathID, page := range patched {
+		if _, ok := basePages[pathID]; ok && len(page.Tree.Children) == 0 {
+			deleted = append(deleted, pathID)
+			delete(patched, pathID)
+			delete(pageIDs, pathID)
+		}
+	}
+	if len(deleted) > 0 {
+		for pathID, page := range basePages {
+			if !isMissingPage(pathID) && removePageChildren(page.Tree, isMissingPage) {
+				patched[pathID] = page
+			}
+		}
+	}
+
+	return patched, deleted
+}
+
+// prune removes the nodes within a changed document from the given tree. This method returns true if
+// any node was removed.
+func (p *documentationPatch) prune(node *semantic.DocumentationNode) (pruned bool) {
+	children := node.Children[:0]
+	for _, child := range node.Children {
+		if child.Node != nil {
+			if _, ok := p.staleIDs[child.Node.PathID]; ok {
+				pruned = true
+				continue
+			}
+			if p.prune(child.Node) {
+				pruned = true
+			}
+		}
+
+		children = append(children, child)
+	}
+	node.Children = children
+
+	return pruned
+}
+
+// graft adds the nodes of the given tree of the incremental bundle that lie within a changed document,
+// as well as links to the given pages that the base tree does not link to, to the given tree of the
+// base bundle. Each added node is attached to the base node with the same path ID as its parent, or to
+// the root of the base tree if there is no such node. This method returns true if the base tree was
+// modified.
+func (p *documentationPatch) graft(baseTree, tree *semantic.DocumentationNode, pageIDs map[string]struct{}) bool {
+	baseNodes := map[string]*semantic.DocumentationNode{}
+	basePageIDs := map[string]struct{}{}
+	indexNodes(baseTree, baseNodes, basePageIDs)
+
+	var grafted bool
+	var visit func(node *semantic.DocumentationNode)
+	visit = func(node *semantic.DocumentationNode) {
+		target, ok := baseNodes[node.PathID]
+		if !ok {
+			target = baseTree
+		}
+
+		for _, child := range node.Children {
+			if child.Node == nil {
+				_, linked := basePageIDs[child.PathID]
+				if _, exists := pageIDs[child.PathID]; exists && !linked {
+					basePageIDs[child.PathID] = struct{}{}
+					target.Children = append(target.Children, child)
+					grafted = true
+				}
+				continue
+			}
+
+			_, isNew := p.newIDs[child.Node.PathID]
+			if _, exists := baseNodes[child.Node.PathID]; isNew && !exists {
+				target.Children = append(target.Children, child)
+				indexNodes(child.Node, baseNodes, basePageIDs)
+				grafted = true
+				continue
+			}
+
+			visit(child.Node)
+		}
+	}
+	visit(tree)
+
+	return grafted
+}
+
+// contains determines if the given tree of the incremental bundle contains a node within a changed
+// document.
+func (p *documentationPatch) contains(node *semantic.DocumentationNode) bool {
+	if _, ok := p.newIDs[node.PathID]; ok {
+		return true
+	}
+	for _, child := range node.Children {
+		if child.Node != nil && p.contains(child.Node) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// patchPathInfo merges the given documentation path info of the incremental bundle into the given
+// path info of the base bundle, which is modified in place. The path info of pages that are neither
+// in the base bundle nor in the given patched pages is ignored, as are links to the given deleted
+// pages. This method returns the path info of the patched bundle.
+func (p *documentationPatch) patchPathInfo(basePathInfo, pathInfo []*semantic.DocumentationPathInfoData, patchedPages map[string]*semantic.DocumentationPageData, deleted []string) []*semantic.DocumentationPathInfoData {
+	deletedIDs := make(map[string]struct{}, len(deleted))
+	for _, pathID := range deleted {
+		deletedIDs[pathID] = struct{}{}
+	}
+
+	byPathID := make(map[string]*semantic.DocumentationPathInfoData, len(basePathInfo))
+	patched := make([]*semantic.DocumentationPathInfoData, 0, len(basePathInfo))
+	for _, info := range basePathInfo {
+		if _, ok := deletedIDs[info.PathID]; !ok {
+			byPathID[info.PathID] = info
+			patched = append(patched, info)
+		}
+	}
+	for _, info := range pathInfo {
+		if _, ok := byPathID[info.PathID]; ok {
+			continue
+		}
+		if _, ok := patchedPages[info.PathID]; ok {
+			byPathID[info.PathID] = &semantic.DocumentationPathInfoData{PathID: info.PathID, IsIndex: info.IsIndex}
+			patched = append(patched, byPathID[info.PathID])
+		}
+	}
+
+	// Link the children of the incremental bundle that are part of the patched bundle
+	for _, info := range pathInfo {
+		target := byPathID[info.PathID]
+		if target == nil {
+			continue
+		}
+
+		for _, child := range info.Children {
+			if _, ok := byPathID[child]; ok {
+				target.Children = append(target.Children, child)
+			}
+		}
+	}
+
+	for _, info := range patched {
+		seen := make(map[string]struct{}, len(info.Children))
+		children := info.Children[:0]
+		for _, child := range info.Children {
+			_, isDeleted := deletedIDs[child]
+			if _, ok := seen[child]; ok || isDeleted {
+				continue
+			}
+
+			seen[child] = struct{}{}
+			children = append(children, child)
+		}
+		info.Children = children
+	}
+
+	return patched
+}
+
+// indexNodes adds the given node and its descendants to the given map keyed by path ID, and adds the
+// path IDs of the pages linked from the tree to the given set.
+func indexNodes(node *semantic.DocumentationNode, nodes map[string]*semantic.DocumentationNode, pageIDs map[string]struct{}) {
+	nodes[node.PathID] = node
+
+	for _, child := range node.Children {
+		if child.Node != nil {
+			indexNodes(child.Node, nodes, pageIDs)
+		} else {
+			pageIDs[child.PathID] = struct{}{}
+		}
+	}
+}
+
+// removePageChildren removes the links to pages matching the given predicate from the given tree.
+// This method returns true if any link was removed.
+func removePageChildren(node *semantic.DocumentationNode, remove func(pathID string) bool) (removed bool) {
+	children := node.Children[:0]
+	for _, child := range node.Children {
+		if child.Node == nil {
+			if remove(child.PathID) {
+				removed = true
+				continue
+			}
+		} else if removePageChildren(child.Node, remove) {
+			removed = true
+		}
+
+		children = append(children, child)
+	}
+	node.Children = children
+
+	return removed
+}
@@ -0,0 +1,128 @@
+package lsifstore
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestDocumentationPatch(t *testing.T) {
+	aGo, bGo, cGo := "pkg/a.go", "pkg/b.go", "other/c.go"
+
+	baseMappings := []semantic.DocumentationMapping{
+		{ResultID: 1, PathID: "/"},
+		{ResultID: 2, PathID: "/pkg"},
+		{ResultID: 3, PathID: "/pkg#A", FilePath: &aGo},
+		{ResultID: 4, PathID: "/pkg#B", FilePath: &bGo},
+		{ResultID: 5, PathID: "/other"},
+		{ResultID: 6, PathID: "/other#C", FilePath: &cGo},
+	}
+	basePages := map[string]*semantic.DocumentationPageData{
+		"/": {Tree: &semantic.DocumentationNode{PathID: "/", Children: []semantic.DocumentationNodeChild{
+			{PathID: "/pkg"},
+			{PathID: "/other"},
+		}}},
+		"/pkg": {Tree: &semantic.DocumentationNode{PathID: "/pkg", Children: []semantic.DocumentationNodeChild{
+			{Node: &semantic.DocumentationNode{PathID: "/pkg#A"}},
+			{Node: &semantic.DocumentationNode{PathID: "/pkg#B"}},
+		}}},
+		"/other": {Tree: &semantic.DocumentationNode{PathID: "/other", Children: []semantic.DocumentationNodeChild{
+			{Node: &semantic.DocumentationNode{PathID: "/other#C"}},
+		}}},
+	}
+	basePathInfo := []*semantic.DocumentationPathInfoData{
+		{PathID: "/", IsIndex: true, Children: []string{"/pkg", "/other"}},
+		{PathID: "/pkg"},
+		{PathID: "/other"},
+	}
+
+	// b.go was modified, c.go was deleted, and d.go was added in a new package. The incremental
+	// bundle documents the whole project, including the unchanged a.go.
+	dGo := "new/d.go"
+	mappings := []semantic.DocumentationMapping{
+		{ResultID: 1, PathID: "/"},
+		{ResultID: 2, PathID: "/pkg"},
+		{ResultID: 3, PathID: "/pkg#A", FilePath: &aGo},
+		{ResultID: 4, PathID: "/pkg#B2", FilePath: &bGo},
+		{ResultID: 5, PathID: "/new"},
+		{ResultID: 6, PathID: "/new#D", FilePath: &dGo},
+	}
+	pages := []*semantic.DocumentationPageData{
+		{Tree: &semantic.DocumentationNode{PathID: "/", Children: []semantic.DocumentationNodeChild{
+			{PathID: "/pkg"},
+			{PathID: "/new"},
+		}}},
+		{Tree: &semantic.DocumentationNode{PathID: "/pkg", Children: []semantic.DocumentationNodeChild{
+			{Node: &semantic.DocumentationNode{PathID: "/pkg#A"}},
+			{Node: &semantic.DocumentationNode{PathID: "/pkg#B2"}},
+		}}},
+		{Tree: &semantic.DocumentationNode{PathID: "/new", Children: []semantic.DocumentationNodeChild{
+			{Node: &semantic.DocumentationNode{PathID: "/new#D"}},
+		}}},
+	}
+	pathInfo := []*semantic.DocumentationPathInfoData{
+		{PathID: "/", IsIndex: true, Children: []string{"/pkg", "/new"}},
+		{PathID: "/pkg"},
+		{PathID: "/new"},
+	}
+
+	patch := newDocumentationPatch([]string{bGo, cGo, dGo}, baseMappings, mappings)
+
+	documents := map[string]semantic.DocumentData{
+		bGo: {Ranges: map[semantic.ID]semantic.RangeData{"r1": {DocumentationResultID: "4"}, "r2": {}}},
+	}
+	patch.patchDocuments(documents)
+	expectedDocuments := map[string]semantic.DocumentData{
+		bGo: {Ranges: map[semantic.ID]semantic.RangeData{"r1": {DocumentationResultID: "11"}, "r2": {}}},
+	}
+	if diff := cmp.Diff(expectedDocuments, documents); diff != "" {
+		t.Errorf("unexpected documents (-want +got):\n%s", diff)
+	}
+
+	expectedMappings := []semantic.DocumentationMapping{
+		{ResultID: 1, PathID: "/"},
+		{ResultID: 2, PathID: "/pkg"},
+		{ResultID: 3, PathID: "/pkg#A", FilePath: &aGo},
+		{ResultID: 5, PathID: "/other"},
+		{ResultID: 11, PathID: "/pkg#B2", FilePath: &bGo},
+		{ResultID: 13, PathID: "/new#D", FilePath: &dGo},
+	}
+	if diff := cmp.Diff(expectedMappings, patch.patchMappings(baseMappings, mappings)); diff != "" {
+		t.Errorf("unexpected mappings (-want +got):\n%s", diff)
+	}
+
+	patchedPages, deleted := patch.patchPages(basePages, pages)
+	expectedPages := map[string]*semantic.DocumentationPageData{
+		"/": {Tree: &semantic.DocumentationNode{PathID: "/", Children: []semantic.DocumentationNodeChild{
+			{PathID: "/pkg"},
+			{PathID: "/new"},
+		}}},
+		"/pkg": {Tree: &semantic.DocumentationNode{PathID: "/pkg", Children: []semantic.DocumentationNodeChild{
+			{Node: &semantic.DocumentationNode{PathID: "/pkg#A"}},
+			{Node: &semantic.DocumentationNode{PathID: "/pkg#B2"}},
+		}}},
+		"/new": {Tree: &semantic.DocumentationNode{PathID: "/new", Children: []semantic.DocumentationNodeChild{
+			{Node: &semantic.DocumentationNode{PathID: "/new#D"}},
+		}}},
+	}
+	if diff := cmp.Diff(expectedPages, patchedPages); diff != "" {
+		t.Errorf("unexpected pages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/other"}, deleted); diff != "" {
+		t.Errorf("unexpected deleted pages (-want +got):\n%s", diff)
+	}
+
+	patchedPathInfo := patch.patchPathInfo(basePathInfo, pathInfo, patchedPages, deleted)
+	sort.Slice(patchedPathInfo, func(i, j int) bool { return patchedPathInfo[i].PathID < patchedPathInfo[j].PathID })
+	expectedPathInfo := []*semantic.DocumentationPathInfoData{
+		{PathID: "/", IsIndex: true, Children: []string{"/pkg", "/new"}},
+		{PathID: "/new"},
+		{PathID: "/pkg"},
+	}
+	if diff := cmp.Diff(expectedPathInfo, patchedPathInfo); diff != "" {
+		t.Errorf("unexpected path info (-want +got):\n%s", diff)
+	}
+}
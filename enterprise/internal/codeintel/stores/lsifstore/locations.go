@@ -28,6 +28,20 @@ func (s *Store) References(ctx context.Context, bundleID int, path string, line,
 	return s.definitionsReferences(ctx, extractor, operation, bundleID, path, line, character, limit, offset)
 }
 
+// Implementations returns the set of locations implementing the symbol at the given position.
+func (s *Store) Implementations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []Location, _ int, err error) {
+	extractor := func(r semantic.RangeData) semantic.ID { return r.ImplementationResultID }
+	operation := s.operations.implementations
+	return s.definitionsReferences(ctx, extractor, operation, bundleID, path, line, character, limit, offset)
+}
+
+// TypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+func (s *Store) TypeDefinitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []Location, _ int, err error) {
+	extractor := func(r semantic.RangeData) semantic.ID { return r.TypeDefinitionResultID }
+	operation := s.operations.typeDefinitions
+	return s.definitionsReferences(ctx, extractor, operation, bundleID, path, line, character, limit, offset)
+}
+
 func (s *Store) definitionsReferences(ctx context.Context, extractor func(r semantic.RangeData) semantic.ID, operation *observation.Operation, bundleID int, path string, line, character, limit, offset int) (_ []Location, _ int, err error) {
 	ctx, traceLog, endObservation := operation.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("bundleID", bundleID),
@@ -300,7 +314,7 @@ func (s *Store) readLocationsFromResultChunks(ctx context.Context, bundleID int,
 
 const readLocationsFromResultChunksQuery = `
 -- source: enterprise/internal/codeintel/stores/lsifstore/locations.go:readLocationsFromResultChunks
-SELECT idx, data FROM lsif_data_result_chunks WHERE dump_id = %s AND idx IN (%s)
+SELECT idx, data FROM lsif_data_result_chunks_with_overlays WHERE dump_id = %s AND idx IN (%s)
 `
 
 // documentBatchSize is the maximum number of documents we will query at once to resolve a single locations request.
@@ -354,7 +368,7 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_documents_with_overlays
 WHERE
 	dump_id = %s AND
 	path IN (%s)
@@ -457,7 +471,7 @@ func extractResultIDs(ranges []semantic.RangeData, fn func(r semantic.RangeData)
 }
 
 const locationsDocumentQuery = `
--- source: enterprise/internal/codeintel/stores/lsifstore/locations.go:{Definitions,References}
+-- source: enterprise/internal/codeintel/stores/lsifstore/locations.go:{Definitions,References,Implementations,TypeDefinitions}
 SELECT
 	dump_id,
 	path,
@@ -468,7 +482,7 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_documents_with_overlays
 WHERE
 	dump_id = %s AND
 	path = %s
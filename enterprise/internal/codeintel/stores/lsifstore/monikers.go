@@ -3,6 +3,7 @@ package lsifstore
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/keegancsmith/sqlf"
@@ -63,7 +64,7 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_documents_with_overlays
 WHERE
 	dump_id = %s AND
 	path = %s
@@ -72,7 +73,8 @@ LIMIT 1
 
 // BulkMonikerResults returns the locations within one of the given bundles that define or reference
 // one of the given monikers. This method also returns the size of the complete result set to aid in
-// pagination.
+// pagination. Locations are ordered by the position of their bundle in the given identifier slice, so
+// callers can rank the bundles they search over.
 func (s *Store) BulkMonikerResults(ctx context.Context, tableName string, uploadIDs []int, monikers []semantic.MonikerData, limit, offset int) (_ []Location, _ int, err error) {
 	ctx, traceLog, endObservation := s.operations.bulkMonikerResults.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("tableName", tableName),
@@ -109,6 +111,18 @@ func (s *Store) BulkMonikerResults(ctx context.Context, tableName string, upload
 		return nil, 0, err
 	}
 
+	// Order the result set by the given upload identifiers. The sort is stable so that the locations
+	// of a single upload remain ordered by scheme and identifier.
+	uploadRanks := make(map[int]int, len(uploadIDs))
+	for i, id := range uploadIDs {
+		if _, ok := uploadRanks[id]; !ok {
+			uploadRanks[id] = i
+		}
+	}
+	sort.SliceStable(locationData, func(i, j int) bool {
+		return uploadRanks[locationData[i].DumpID] < uploadRanks[locationData[j].DumpID]
+	})
+
 	totalCount := 0
 	for _, monikerLocations := range locationData {
 		totalCount += len(monikerLocations.Locations)
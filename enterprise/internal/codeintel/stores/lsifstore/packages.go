@@ -40,7 +40,7 @@ SELECT
 	packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_documents_with_overlays
 WHERE
 	dump_id = %s AND
 	path = %s
@@ -113,7 +113,7 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_documents_with_overlays
 WHERE
 	dump_id = %s AND
 	path = %s
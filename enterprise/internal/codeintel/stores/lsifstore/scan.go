@@ -181,3 +181,75 @@ func (s *Store) scanSingleQualifiedMonikerLocationsObject(rows *sql.Rows) (Quali
 
 	return record, nil
 }
+
+// scanDocumentationPages reads documentation pages from the given row object, keyed by path ID.
+func (s *Store) scanDocumentationPages(rows *sql.Rows, queryErr error) (_ map[string]*semantic.DocumentationPageData, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	values := map[string]*semantic.DocumentationPageData{}
+	for rows.Next() {
+		var pathID string
+		var rawData []byte
+		if err := rows.Scan(&pathID, &rawData); err != nil {
+			return nil, err
+		}
+
+		page, err := s.serializer.UnmarshalDocumentationPageData(rawData)
+		if err != nil {
+			return nil, err
+		}
+
+		values[pathID] = page
+	}
+
+	return values, nil
+}
+
+// scanDocumentationPathInfo reads documentation path info values from the given row object.
+func (s *Store) scanDocumentationPathInfo(rows *sql.Rows, queryErr error) (_ []*semantic.DocumentationPathInfoData, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var values []*semantic.DocumentationPathInfoData
+	for rows.Next() {
+		var pathID string
+		var rawData []byte
+		if err := rows.Scan(&pathID, &rawData); err != nil {
+			return nil, err
+		}
+
+		info, err := s.serializer.UnmarshalDocumentationPathInfoData(rawData)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, info)
+	}
+
+	return values, nil
+}
+
+// scanDocumentationMappings reads documentation mappings from the given row object.
+func (s *Store) scanDocumentationMappings(rows *sql.Rows, queryErr error) (_ []semantic.DocumentationMapping, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var values []semantic.DocumentationMapping
+	for rows.Next() {
+		var mapping semantic.DocumentationMapping
+		if err := rows.Scan(&mapping.PathID, &mapping.ResultID, &mapping.FilePath); err != nil {
+			return nil, err
+		}
+
+		values = append(values, mapping)
+	}
+
+	return values, nil
+}
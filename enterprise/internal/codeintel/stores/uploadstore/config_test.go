@@ -189,6 +189,36 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigFilesystem(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":        "Filesystem",
+		"PRECISE_CODE_INTEL_UPLOAD_BUCKET":         "lsif-uploads",
+		"PRECISE_CODE_INTEL_UPLOAD_TTL":            "8h",
+		"PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_DIR": "/data",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.Backend != "filesystem" {
+		t.Errorf("unexpected value for Backend. want=%s have=%s", "filesystem", config.Backend)
+	}
+	if !config.ManageBucket {
+		t.Errorf("expected filesystem bucket to be managed")
+	}
+	if config.TTL != 8*time.Hour {
+		t.Errorf("unexpected value for Filesystem.TTL. want=%v have=%v", 8*time.Hour, config.TTL)
+	}
+	if config.Filesystem.Dir != "/data" {
+		t.Errorf("unexpected value for Filesystem.Dir. want=%s have=%s", "/data", config.Filesystem.Dir)
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
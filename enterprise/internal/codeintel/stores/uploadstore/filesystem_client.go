@@ -0,0 +1,300 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type filesystemStore struct {
+	root         string
+	ttl          time.Duration
+	manageBucket bool
+	operations   *operations
+
+	expiryMutex    sync.Mutex
+	lastExpiryTime time.Time
+}
+
+var _ Store = &filesystemStore{}
+
+type FilesystemConfig struct {
+	Dir string
+}
+
+func (c *FilesystemConfig) load(parent *env.BaseConfig) {
+	c.Dir = parent.Get("PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_DIR", "/lsif-storage", "The directory in which the bucket directory is created. Must be shared by all services reading or writing uploads.")
+}
+
+// tempDirName is the name of the directory (relative to the bucket root) into which objects
+// are written before being atomically moved into place. Keys may not refer to this directory.
+const tempDirName = ".tmp"
+
+// expiryInterval is the minimum time between two scans of the bucket directory for expired
+// objects.
+const expiryInterval = time.Hour
+
+// newFilesystemFromConfig creates a new store backed by a directory on the local filesystem.
+func newFilesystemFromConfig(ctx context.Context, config *Config, operations *operations) (Store, error) {
+	return newFilesystemWithRoot(filepath.Join(config.Filesystem.Dir, config.Bucket), config.TTL, config.ManageBucket, operations), nil
+}
+
+func newFilesystemWithRoot(root string, ttl time.Duration, manageBucket bool, operations *operations) *filesystemStore {
+	return &filesystemStore{
+		root:         root,
+		ttl:          ttl,
+		manageBucket: manageBucket,
+		operations:   operations,
+	}
+}
+
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Join(s.root, tempDirName), os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create bucket directory")
+	}
+
+	s.expireIfDue(time.Now())
+	return nil
+}
+
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, endObservation := s.operations.get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, endObservation := s.operations.upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := s.writeAtomically(path, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	s.expireIfDue(time.Now())
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, endObservation := s.operations.compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(destination)
+	if err != nil {
+		return 0, err
+	}
+
+	sourcePaths := make([]string, 0, len(sources))
+	for _, source := range sources {
+		sourcePath, err := s.path(source)
+		if err != nil {
+			return 0, err
+		}
+
+		sourcePaths = append(sourcePaths, sourcePath)
+	}
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(sourcePaths); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	n, err := s.writeAtomically(path, func(w io.Writer) (int64, error) {
+		var total int64
+		for _, sourcePath := range sourcePaths {
+			n, err := copyFile(w, sourcePath)
+			total += n
+			if err != nil {
+				return total, err
+			}
+		}
+
+		return total, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	s.expireIfDue(time.Now())
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, endObservation := s.operations.delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete object")
+	}
+
+	return nil
+}
+
+// path returns the path of the file storing the object with the given key. An error is
+// returned if the key would refer to a file outside of the bucket directory.
+func (s *filesystemStore) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("illegal key %q", key)
+	}
+	if cleaned == tempDirName || strings.HasPrefix(cleaned, tempDirName+string(filepath.Separator)) {
+		return "", errors.Errorf("illegal key %q", key)
+	}
+
+	return filepath.Join(s.root, cleaned), nil
+}
+
+// writeAtomically invokes the given function with a temporary file and moves the file to the
+// given path once the function returns successfully. Readers of the target path observe either
+// the previous content or the complete new content, never a partial write.
+func (s *filesystemStore) writeAtomically(path string, fn func(w io.Writer) (int64, error)) (_ int64, err error) {
+	tempFile, err := os.CreateTemp(filepath.Join(s.root, tempDirName), "object-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tempFile.Close()
+			_ = os.Remove(tempFile.Name())
+		}
+	}()
+
+	n, err := fn(tempFile)
+	if err != nil {
+		return 0, err
+	}
+	if err := tempFile.Sync(); err != nil {
+		return 0, err
+	}
+	if err := tempFile.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) deleteSources(sourcePaths []string) error {
+	for _, sourcePath := range sourcePaths {
+		if err := os.Remove(sourcePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+	}
+
+	return nil
+}
+
+// expireIfDue removes expired objects from the bucket directory if it has not been scanned
+// within the last expiry interval. This is the analog of the lifecycle rules that the S3 and
+// GCS stores configure on managed buckets and is skipped for unmanaged buckets.
+func (s *filesystemStore) expireIfDue(now time.Time) {
+	if !s.manageBucket {
+		return
+	}
+
+	s.expiryMutex.Lock()
+	defer s.expiryMutex.Unlock()
+
+	if now.Sub(s.lastExpiryTime) < expiryInterval {
+		return
+	}
+	s.lastExpiryTime = now
+
+	if err := s.expire(now); err != nil {
+		log15.Error("Failed to remove expired objects", "error", err)
+	}
+}
+
+// expire removes all objects (including abandoned temporary files) that were last written
+// before the configured TTL. The TTL is truncated to whole days to match the granularity of
+// the S3 and GCS lifecycle rules.
+func (s *filesystemStore) expire(now time.Time) error {
+	days := s.ttl / (time.Hour * 24)
+	if days == 0 {
+		return nil
+	}
+	cutoff := now.Add(-days * time.Hour * 24)
+
+	return filepath.Walk(s.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if info.Mode().IsRegular() && info.ModTime().Before(cutoff) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// copyFile writes the content of the file at the given path to the given writer.
+func copyFile(w io.Writer, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
@@ -0,0 +1,216 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestFilesystemInit(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "test-bucket")
+
+	client := testFilesystemClient(root, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if info, err := os.Stat(root); err != nil {
+		t.Fatalf("unexpected error statting bucket directory: %s", err)
+	} else if !info.IsDir() {
+		t.Fatalf("expected bucket directory to be a directory")
+	}
+}
+
+func TestFilesystemUploadAndGet(t *testing.T) {
+	client := testFilesystemClient(filepath.Join(t.TempDir(), "test-bucket"), false)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	size, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("TEST PAYLOAD")))
+	if err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	} else if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	if contents := readKey(t, client, "test-key"); contents != "TEST PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+
+	if entries, err := os.ReadDir(filepath.Join(client.root, tempDirName)); err != nil {
+		t.Fatalf("unexpected error reading temp directory: %s", err)
+	} else if len(entries) != 0 {
+		t.Errorf("unexpected temporary files. want=%d have=%d", 0, len(entries))
+	}
+}
+
+func TestFilesystemUploadFailure(t *testing.T) {
+	client := testFilesystemClient(filepath.Join(t.TempDir(), "test-bucket"), false)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if _, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("OLD PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	}
+
+	r := io.MultiReader(strings.NewReader("NEW"), &errReader{err: io.ErrUnexpectedEOF})
+	if _, err := client.Upload(context.Background(), "test-key", r); err == nil {
+		t.Fatalf("expected error uploading key")
+	}
+
+	// Previous content must not be replaced by a partial write
+	if contents := readKey(t, client, "test-key"); contents != "OLD PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "OLD PAYLOAD", contents)
+	}
+
+	if entries, err := os.ReadDir(filepath.Join(client.root, tempDirName)); err != nil {
+		t.Fatalf("unexpected error reading temp directory: %s", err)
+	} else if len(entries) != 0 {
+		t.Errorf("unexpected temporary files. want=%d have=%d", 0, len(entries))
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	client := testFilesystemClient(filepath.Join(t.TempDir(), "test-bucket"), false)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for key, payload := range map[string]string{"test-src1": "A", "test-src2": "BB", "test-src3": "CCC"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader(payload)); err != nil {
+			t.Fatalf("unexpected error uploading key: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing keys: %s", err)
+	} else if size != 6 {
+		t.Errorf("unexpected size. want=%d have=%d", 6, size)
+	}
+
+	if contents := readKey(t, client, "test-key"); contents != "ABBCCC" {
+		t.Errorf("unexpected contents. want=%s have=%s", "ABBCCC", contents)
+	}
+
+	for _, key := range []string{"test-src1", "test-src2", "test-src3"} {
+		if _, err := os.Stat(filepath.Join(client.root, key)); !os.IsNotExist(err) {
+			t.Errorf("expected source object %s to be deleted", key)
+		}
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	client := testFilesystemClient(filepath.Join(t.TempDir(), "test-bucket"), false)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if _, err := client.Upload(context.Background(), "test-key", strings.NewReader("TEST PAYLOAD")); err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	}
+
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting key: %s", err)
+	}
+	if _, err := client.Get(context.Background(), "test-key"); err == nil {
+		t.Fatalf("expected error getting deleted key")
+	}
+
+	// Deleting a missing key is not an error
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting key: %s", err)
+	}
+}
+
+func TestFilesystemIllegalKeys(t *testing.T) {
+	client := testFilesystemClient(filepath.Join(t.TempDir(), "test-bucket"), false)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for _, key := range []string{"", "..", "../test-key", "/etc/passwd", ".tmp/test-key"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("TEST PAYLOAD")); err == nil {
+			t.Errorf("expected error uploading key %q", key)
+		}
+	}
+}
+
+func TestFilesystemExpire(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "test-bucket")
+	client := testFilesystemClient(root, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for _, key := range []string{"test-old", "test-new"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("TEST PAYLOAD")); err != nil {
+			t.Fatalf("unexpected error uploading key: %s", err)
+		}
+	}
+
+	abandoned := filepath.Join(root, tempDirName, "object-abandoned")
+	if err := os.WriteFile(abandoned, []byte("PARTIAL"), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error writing file: %s", err)
+	}
+
+	now := time.Now()
+	old := now.Add(-8 * 24 * time.Hour)
+	for _, path := range []string{filepath.Join(root, "test-old"), abandoned} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("unexpected error changing file times: %s", err)
+		}
+	}
+
+	// Expiry ran during Init; the next scan is not yet due
+	client.expireIfDue(now)
+	if _, err := os.Stat(filepath.Join(root, "test-old")); err != nil {
+		t.Fatalf("expected object to remain until next expiry scan: %s", err)
+	}
+
+	client.expireIfDue(now.Add(expiryInterval))
+	for _, path := range []string{filepath.Join(root, "test-old"), abandoned} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be expired", path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "test-new")); err != nil {
+		t.Errorf("unexpected error statting unexpired object: %s", err)
+	}
+}
+
+func testFilesystemClient(root string, manageBucket bool) *filesystemStore {
+	return newFilesystemWithRoot(root, 24*7*time.Hour, manageBucket, newOperations(&observation.TestContext))
+}
+
+func readKey(t *testing.T, client Store, key string) string {
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting key: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+
+	return string(contents)
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
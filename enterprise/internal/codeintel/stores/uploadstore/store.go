@@ -9,7 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
-// Store is an expiring key/value store backed by a managed blob store.
+// Store is an expiring key/value store backed by a managed blob store or a local directory.
 type Store interface {
 	// Init ensures that the underlying target bucket exists and has the expected ACL
 	// and lifecycle configuration.
@@ -31,9 +31,10 @@ type Store interface {
 }
 
 var storeConstructors = map[string]func(ctx context.Context, config *Config, operations *operations) (Store, error){
-	"s3":    newS3FromConfig,
-	"minio": newS3FromConfig,
-	"gcs":   newGCSFromConfig,
+	"s3":         newS3FromConfig,
+	"minio":      newS3FromConfig,
+	"gcs":        newGCSFromConfig,
+	"filesystem": newFilesystemFromConfig,
 }
 
 // CreateLazy initialize a new store from the given configuration that is initialized
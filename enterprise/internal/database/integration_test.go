@@ -44,6 +44,10 @@ func TestIntegration_PermsStore(t *testing.T) {
 		{"UserIDsWithOldestPerms", testPermsStore_UserIDsWithOldestPerms(db)},
 		{"ReposIDsWithOldestPerms", testPermsStore_ReposIDsWithOldestPerms(db)},
 		{"Metrics", testPermsStore_Metrics(db)},
+
+		{"PermsSyncJobs", testPermsStore_PermsSyncJobs(db)},
+		{"ListRepoAccessExternalServices", testPermsStore_ListRepoAccessExternalServices(db)},
+		{"PermsSyncRequests", testPermsStore_PermsSyncRequests(db)},
 	} {
 		t.Run(tc.name, tc.test)
 	}
@@ -19,4 +19,10 @@ type MockPerms struct {
 	ListPendingUsers             func(ctx context.Context) ([]string, error)
 	ListExternalAccounts         func(ctx context.Context, userID int32) ([]*extsvc.Account, error)
 	GetUserIDsByExternalAccounts func(ctx context.Context, accounts *extsvc.Accounts) (map[string]int32, error)
+
+	InsertPermsSyncJob             func(ctx context.Context, job *PermsSyncJob) error
+	ListPermsSyncJobs              func(ctx context.Context, opts ListPermsSyncJobsOpts) ([]*PermsSyncJob, error)
+	ListRepoAccessExternalServices func(ctx context.Context, userID, repoID int32) (*RepoAccessExternalServices, error)
+
+	EnqueuePermsSyncRequests func(ctx context.Context, opts EnqueuePermsSyncRequestsOpts) error
 }
@@ -0,0 +1,294 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	otlog "github.com/opentracing/opentracing-go/log"
+)
+
+// PermsSyncJobType is the type of a permissions sync, i.e. whether permissions
+// were synced from the point of view of a user or of a repository.
+type PermsSyncJobType string
+
+const (
+	PermsSyncJobTypeUser PermsSyncJobType = "user"
+	PermsSyncJobTypeRepo PermsSyncJobType = "repo"
+)
+
+// PermsSyncJob is a record of a single permissions sync performed by the
+// permissions syncer, stored in the 'perms_sync_jobs' table for auditing.
+type PermsSyncJob struct {
+	ID          int32
+	RequestType PermsSyncJobType
+	// UserID is set when RequestType is PermsSyncJobTypeUser.
+	UserID int32
+	// RepoID is set when RequestType is PermsSyncJobTypeRepo.
+	RepoID int32
+	// Providers is the list of service IDs of the authz providers that have been
+	// consulted during the sync.
+	Providers  []string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// AddedIDs and RemovedIDs are the repository IDs (for user syncs) or the user
+	// IDs (for repository syncs) that have been granted or revoked access.
+	AddedIDs       []int32
+	RemovedIDs     []int32
+	FailureMessage *string
+}
+
+// Duration returns the time it took to perform the sync.
+func (j *PermsSyncJob) Duration() time.Duration {
+	return j.FinishedAt.Sub(j.StartedAt)
+}
+
+// InsertPermsSyncJob inserts the given permissions sync job and sets its ID.
+func (s *PermsStore) InsertPermsSyncJob(ctx context.Context, job *PermsSyncJob) (err error) {
+	if Mocks.Perms.InsertPermsSyncJob != nil {
+		return Mocks.Perms.InsertPermsSyncJob(ctx, job)
+	}
+
+	ctx, save := s.observe(ctx, "InsertPermsSyncJob", "")
+	defer func() {
+		save(&err,
+			otlog.String("requestType", string(job.RequestType)),
+			otlog.Int32("userID", job.UserID),
+			otlog.Int32("repoID", job.RepoID),
+		)
+	}()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_sync_jobs.go:PermsStore.InsertPermsSyncJob
+INSERT INTO perms_sync_jobs
+  (request_type, user_id, repo_id, providers, started_at, finished_at, added_ids, removed_ids, failure_message)
+VALUES
+  (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING id
+`,
+		job.RequestType,
+		nullInt32Column(job.UserID),
+		nullInt32Column(job.RepoID),
+		pq.Array(nonNilStrings(job.Providers)),
+		job.StartedAt.UTC(),
+		job.FinishedAt.UTC(),
+		pq.Array(int32sToInt64s(job.AddedIDs)),
+		pq.Array(int32sToInt64s(job.RemovedIDs)),
+		job.FailureMessage,
+	)
+	return s.execute(ctx, q, &job.ID)
+}
+
+// ListPermsSyncJobsOpts contains options for listing permissions sync jobs.
+type ListPermsSyncJobsOpts struct {
+	// UserID restricts the results to user-centric syncs of the given user.
+	UserID int32
+	// RepoID restricts the results to repository-centric syncs of the given repository.
+	RepoID int32
+	// GrantedUserID and GrantedRepoID restrict the results to syncs that granted
+	// the given user access to the given repository, regardless of the type of the
+	// sync. Both must be set for the condition to apply.
+	GrantedUserID int32
+	GrantedRepoID int32
+	// Limit is the maximum number of jobs to return, no limit is applied when it
+	// is zero.
+	Limit int
+}
+
+// ListPermsSyncJobs returns the permissions sync jobs matching the given options,
+// the most recently finished first.
+func (s *PermsStore) ListPermsSyncJobs(ctx context.Context, opts ListPermsSyncJobsOpts) (jobs []*PermsSyncJob, err error) {
+	if Mocks.Perms.ListPermsSyncJobs != nil {
+		return Mocks.Perms.ListPermsSyncJobs(ctx, opts)
+	}
+
+	ctx, save := s.observe(ctx, "ListPermsSyncJobs", "")
+	defer func() {
+		save(&err,
+			otlog.Int32("userID", opts.UserID),
+			otlog.Int32("repoID", opts.RepoID),
+			otlog.Int("count", len(jobs)),
+		)
+	}()
+
+	rows, err := s.Query(ctx, listPermsSyncJobsQuery(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			job            PermsSyncJob
+			userID, repoID sql.NullInt32
+			added, removed []int64
+		)
+		if err = rows.Scan(
+			&job.ID,
+			&job.RequestType,
+			&userID,
+			&repoID,
+			pq.Array(&job.Providers),
+			&job.StartedAt,
+			&job.FinishedAt,
+			pq.Array(&added),
+			pq.Array(&removed),
+			&job.FailureMessage,
+		); err != nil {
+			return nil, err
+		}
+
+		job.UserID = userID.Int32
+		job.RepoID = repoID.Int32
+		job.AddedIDs = int64sToInt32s(added)
+		job.RemovedIDs = int64sToInt32s(removed)
+		jobs = append(jobs, &job)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func listPermsSyncJobsQuery(opts ListPermsSyncJobsOpts) *sqlf.Query {
+	const format = `
+-- source: enterprise/internal/database/perms_sync_jobs.go:listPermsSyncJobsQuery
+SELECT
+  id, request_type, user_id, repo_id, providers,
+  started_at, finished_at, added_ids, removed_ids, failure_message
+FROM perms_sync_jobs
+WHERE %s
+ORDER BY finished_at DESC, id DESC
+%s
+`
+
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("request_type = %s AND user_id = %s", PermsSyncJobTypeUser, opts.UserID))
+	}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("request_type = %s AND repo_id = %s", PermsSyncJobTypeRepo, opts.RepoID))
+	}
+	if opts.GrantedUserID != 0 && opts.GrantedRepoID != 0 {
+		conds = append(conds, sqlf.Sprintf(`(
+    (request_type = %s AND user_id = %s AND %s = ANY(added_ids))
+OR  (request_type = %s AND repo_id = %s AND %s = ANY(added_ids))
+)`,
+			PermsSyncJobTypeUser, opts.GrantedUserID, opts.GrantedRepoID,
+			PermsSyncJobTypeRepo, opts.GrantedRepoID, opts.GrantedUserID,
+		))
+	}
+
+	limit := sqlf.Sprintf("")
+	if opts.Limit > 0 {
+		limit = sqlf.Sprintf("LIMIT %s", opts.Limit)
+	}
+
+	return sqlf.Sprintf(format, sqlf.Join(conds, "AND"), limit)
+}
+
+// DeletePermsSyncJobsBefore deletes all permissions sync jobs that finished
+// before the given time.
+func (s *PermsStore) DeletePermsSyncJobsBefore(ctx context.Context, before time.Time) (err error) {
+	ctx, save := s.observe(ctx, "DeletePermsSyncJobsBefore", "")
+	defer func() { save(&err, otlog.String("before", before.String())) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_sync_jobs.go:PermsStore.DeletePermsSyncJobsBefore
+DELETE FROM perms_sync_jobs WHERE finished_at < %s
+`, before.UTC())
+	return s.execute(ctx, q)
+}
+
+// RepoAccessExternalServices contains the IDs of the external services that grant
+// a user access to a repository without consulting any permissions.
+type RepoAccessExternalServices struct {
+	// Unrestricted is the list of unrestricted external services that sync the repository.
+	Unrestricted []int64
+	// UserAdded is the list of external services owned by the user that sync the repository.
+	UserAdded []int64
+}
+
+// ListRepoAccessExternalServices returns the external services that grant the
+// given user access to the given repository regardless of any permissions.
+func (s *PermsStore) ListRepoAccessExternalServices(ctx context.Context, userID, repoID int32) (_ *RepoAccessExternalServices, err error) {
+	if Mocks.Perms.ListRepoAccessExternalServices != nil {
+		return Mocks.Perms.ListRepoAccessExternalServices(ctx, userID, repoID)
+	}
+
+	ctx, save := s.observe(ctx, "ListRepoAccessExternalServices", "")
+	defer func() { save(&err, otlog.Int32("userID", userID), otlog.Int32("repoID", repoID)) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_sync_jobs.go:PermsStore.ListRepoAccessExternalServices
+SELECT es.id, es.unrestricted, esr.user_id IS NOT NULL AND esr.user_id = %s
+FROM external_services AS es
+JOIN external_service_repos AS esr ON esr.external_service_id = es.id
+WHERE
+    esr.repo_id = %s
+AND es.deleted_at IS NULL
+AND (es.unrestricted OR esr.user_id = %s)
+ORDER BY es.id ASC
+`, userID, repoID, userID)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var svcs RepoAccessExternalServices
+	for rows.Next() {
+		var (
+			id                      int64
+			unrestricted, userAdded bool
+		)
+		if err = rows.Scan(&id, &unrestricted, &userAdded); err != nil {
+			return nil, err
+		}
+
+		if unrestricted {
+			svcs.Unrestricted = append(svcs.Unrestricted, id)
+		}
+		if userAdded {
+			svcs.UserAdded = append(svcs.UserAdded, id)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &svcs, nil
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func int32sToInt64s(ids []int32) []int64 {
+	out := make([]int64, len(ids))
+	for i := range ids {
+		out[i] = int64(ids[i])
+	}
+	return out
+}
+
+func int64sToInt32s(ids []int64) []int32 {
+	out := make([]int32, len(ids))
+	for i := range ids {
+		out[i] = int32(ids[i])
+	}
+	return out
+}
+
+func nullInt32Column(n int32) *int32 {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
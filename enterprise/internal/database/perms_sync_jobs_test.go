@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+)
+
+func testPermsStore_PermsSyncJobs(db *sql.DB) func(*testing.T) {
+	return func(t *testing.T) {
+		s := Perms(db, clock)
+		t.Cleanup(func() {
+			cleanupUsersTable(t, s)
+			cleanupReposTable(t, s)
+		})
+
+		ctx := context.Background()
+
+		qs := []*sqlf.Query{
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('alice')`),        // ID=1
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('bob')`),          // ID=2
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('r1', TRUE)`), // ID=1
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('r2', TRUE)`), // ID=2
+		}
+		for _, q := range qs {
+			if err := s.execute(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		failure := "rate limit exceeded"
+		jobs := []*PermsSyncJob{
+			{
+				RequestType: PermsSyncJobTypeUser,
+				UserID:      1,
+				Providers:   []string{"https://github.com/"},
+				StartedAt:   clock().Add(-3 * time.Hour),
+				FinishedAt:  clock().Add(-3*time.Hour + time.Second),
+				AddedIDs:    []int32{1, 2},
+				RemovedIDs:  []int32{},
+			},
+			{
+				RequestType: PermsSyncJobTypeRepo,
+				RepoID:      2,
+				Providers:   []string{"https://github.com/"},
+				StartedAt:   clock().Add(-2 * time.Hour),
+				FinishedAt:  clock().Add(-2*time.Hour + time.Second),
+				AddedIDs:    []int32{2},
+				RemovedIDs:  []int32{1},
+			},
+			{
+				RequestType:    PermsSyncJobTypeUser,
+				UserID:         2,
+				Providers:      []string{},
+				StartedAt:      clock().Add(-time.Hour),
+				FinishedAt:     clock().Add(-time.Hour + time.Second),
+				AddedIDs:       []int32{},
+				RemovedIDs:     []int32{},
+				FailureMessage: &failure,
+			},
+		}
+		for _, job := range jobs {
+			if err := s.InsertPermsSyncJob(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, tc := range []struct {
+			name string
+			opts ListPermsSyncJobsOpts
+			want []*PermsSyncJob
+		}{
+			{
+				name: "all",
+				want: []*PermsSyncJob{jobs[2], jobs[1], jobs[0]},
+			},
+			{
+				name: "limit",
+				opts: ListPermsSyncJobsOpts{Limit: 1},
+				want: []*PermsSyncJob{jobs[2]},
+			},
+			{
+				name: "by user",
+				opts: ListPermsSyncJobsOpts{UserID: 1},
+				want: []*PermsSyncJob{jobs[0]},
+			},
+			{
+				name: "by repo",
+				opts: ListPermsSyncJobsOpts{RepoID: 2},
+				want: []*PermsSyncJob{jobs[1]},
+			},
+			{
+				name: "granted to user 1 on repo 2",
+				opts: ListPermsSyncJobsOpts{GrantedUserID: 1, GrantedRepoID: 2},
+				want: []*PermsSyncJob{jobs[0]},
+			},
+			{
+				name: "granted to user 2 on repo 2",
+				opts: ListPermsSyncJobsOpts{GrantedUserID: 2, GrantedRepoID: 2},
+				want: []*PermsSyncJob{jobs[1]},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				have, err := s.ListPermsSyncJobs(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatalf("jobs mismatch (-want +got):\n%s", diff)
+				}
+			})
+		}
+
+		if err := s.DeletePermsSyncJobsBefore(ctx, clock().Add(-90*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+		have, err := s.ListPermsSyncJobs(ctx, ListPermsSyncJobsOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*PermsSyncJob{jobs[2]}, have); diff != "" {
+			t.Fatalf("jobs mismatch after deletion (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func testPermsStore_ListRepoAccessExternalServices(db *sql.DB) func(*testing.T) {
+	return func(t *testing.T) {
+		s := Perms(db, clock)
+		t.Cleanup(func() {
+			cleanupUsersTable(t, s)
+			cleanupReposTable(t, s)
+
+			q := `TRUNCATE TABLE external_services RESTART IDENTITY CASCADE;`
+			if err := s.execute(context.Background(), sqlf.Sprintf(q)); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		ctx := context.Background()
+
+		esSQL := `INSERT INTO external_services(kind, display_name, config, unrestricted, namespace_user_id) VALUES('GITHUB', %s, '{}', %s, %s)`
+		qs := []*sqlf.Query{
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('alice')`),        // ID=1
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('r1', TRUE)`), // ID=1
+			sqlf.Sprintf(esSQL, "restricted", false, nil),                      // ID=1
+			sqlf.Sprintf(esSQL, "unrestricted", true, nil),                     // ID=2
+			sqlf.Sprintf(esSQL, "alice", false, 1),                             // ID=3
+			sqlf.Sprintf(`INSERT INTO external_service_repos(external_service_id, repo_id, clone_url) VALUES(1, 1, '')`),
+			sqlf.Sprintf(`INSERT INTO external_service_repos(external_service_id, repo_id, clone_url) VALUES(2, 1, '')`),
+			sqlf.Sprintf(`INSERT INTO external_service_repos(external_service_id, repo_id, clone_url, user_id) VALUES(3, 1, '', 1)`),
+		}
+		for _, q := range qs {
+			if err := s.execute(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListRepoAccessExternalServices(ctx, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &RepoAccessExternalServices{
+			Unrestricted: []int64{2},
+			UserAdded:    []int64{3},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("external services mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
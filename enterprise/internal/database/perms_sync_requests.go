@@ -0,0 +1,272 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// PermsSyncPriority defines how urgent a permissions sync request is. Requests
+// with a higher priority are processed first.
+type PermsSyncPriority int
+
+const (
+	// PermsSyncPriorityLow is used by requests scheduled in the background, e.g.
+	// to refresh the oldest permissions.
+	PermsSyncPriorityLow PermsSyncPriority = 0
+	// PermsSyncPriorityMedium is used by requests triggered by code host webhooks.
+	PermsSyncPriorityMedium PermsSyncPriority = 5
+	// PermsSyncPriorityHigh is used by requests triggered by user actions (e.g.
+	// sign up, log in, site admin scheduling a sync manually).
+	PermsSyncPriorityHigh PermsSyncPriority = 10
+)
+
+// PermsSyncReason is the reason why a permissions sync request was enqueued.
+type PermsSyncReason string
+
+const (
+	PermsSyncReasonManual      PermsSyncReason = "manual"
+	PermsSyncReasonWebhook     PermsSyncReason = "webhook"
+	PermsSyncReasonNoPerms     PermsSyncReason = "no_perms"
+	PermsSyncReasonOldestPerms PermsSyncReason = "oldest_perms"
+)
+
+// Priority returns the default priority of requests enqueued for the reason.
+func (r PermsSyncReason) Priority() PermsSyncPriority {
+	switch r {
+	case PermsSyncReasonManual:
+		return PermsSyncPriorityHigh
+	case PermsSyncReasonWebhook:
+		return PermsSyncPriorityMedium
+	default:
+		return PermsSyncPriorityLow
+	}
+}
+
+// PermsSyncRequest is a pending or processed permissions sync request, stored in
+// the 'perms_sync_requests' table and processed by a workerutil.Worker.
+type PermsSyncRequest struct {
+	ID             int
+	State          string
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int
+	NumFailures    int
+	RequestType    PermsSyncJobType
+	// UserID is set when RequestType is PermsSyncJobTypeUser.
+	UserID int32
+	// RepoID is set when RequestType is PermsSyncJobTypeRepo.
+	RepoID   int32
+	Priority PermsSyncPriority
+	Reason   PermsSyncReason
+	// NoPerms is true when the user or repository had no permissions when the
+	// request was enqueued.
+	NoPerms  bool
+	QueuedAt time.Time
+}
+
+// RecordID implements workerutil.Record.
+func (r *PermsSyncRequest) RecordID() int {
+	return r.ID
+}
+
+// PermsSyncRequestColumns are the columns selected by the workerutil store of
+// the 'perms_sync_requests' table, in the order expected by ScanPermsSyncRequest.
+var PermsSyncRequestColumns = []*sqlf.Query{
+	sqlf.Sprintf("perms_sync_requests.id"),
+	sqlf.Sprintf("perms_sync_requests.state"),
+	sqlf.Sprintf("perms_sync_requests.failure_message"),
+	sqlf.Sprintf("perms_sync_requests.started_at"),
+	sqlf.Sprintf("perms_sync_requests.finished_at"),
+	sqlf.Sprintf("perms_sync_requests.process_after"),
+	sqlf.Sprintf("perms_sync_requests.num_resets"),
+	sqlf.Sprintf("perms_sync_requests.num_failures"),
+	sqlf.Sprintf("perms_sync_requests.request_type"),
+	sqlf.Sprintf("perms_sync_requests.user_id"),
+	sqlf.Sprintf("perms_sync_requests.repo_id"),
+	sqlf.Sprintf("perms_sync_requests.priority"),
+	sqlf.Sprintf("perms_sync_requests.reason"),
+	sqlf.Sprintf("perms_sync_requests.no_perms"),
+	sqlf.Sprintf("perms_sync_requests.queued_at"),
+}
+
+// ScanPermsSyncRequest scans a single permissions sync request from the return
+// value of `*Store.query`. It implements the dbworker store.RecordScanFn.
+func ScanPermsSyncRequest(rows *sql.Rows, queryErr error) (workerutil.Record, bool, error) {
+	requests, err := scanPermsSyncRequests(rows, queryErr)
+	if err != nil || len(requests) == 0 {
+		return nil, false, err
+	}
+	return requests[0], true, nil
+}
+
+func scanPermsSyncRequests(rows *sql.Rows, queryErr error) (_ []*PermsSyncRequest, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var requests []*PermsSyncRequest
+	for rows.Next() {
+		var (
+			r              PermsSyncRequest
+			userID, repoID sql.NullInt32
+		)
+		if err := rows.Scan(
+			&r.ID,
+			&r.State,
+			&r.FailureMessage,
+			&r.StartedAt,
+			&r.FinishedAt,
+			&r.ProcessAfter,
+			&r.NumResets,
+			&r.NumFailures,
+			&r.RequestType,
+			&userID,
+			&repoID,
+			&r.Priority,
+			&r.Reason,
+			&r.NoPerms,
+			&r.QueuedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		r.UserID = userID.Int32
+		r.RepoID = repoID.Int32
+		requests = append(requests, &r)
+	}
+
+	return requests, nil
+}
+
+// EnqueuePermsSyncRequestsOpts contains options for enqueuing permissions sync
+// requests.
+type EnqueuePermsSyncRequestsOpts struct {
+	RequestType PermsSyncJobType
+	// IDs are the user IDs or repository IDs, depending on RequestType.
+	IDs      []int32
+	Priority PermsSyncPriority
+	Reason   PermsSyncReason
+	NoPerms  bool
+}
+
+// EnqueuePermsSyncRequests enqueues permissions sync requests for the given users
+// or repositories. There is at most one pending request per user or repository:
+// when a request is already queued, its priority (and reason) is raised to the
+// given one if it is higher. When a request is already being processed, the
+// request is recorded as its follow-up instead, which is enqueued once the
+// processing request is finished (see the perms_sync_requests_enqueue_follow_up
+// trigger), because the sync may have read the permissions before they changed.
+func (s *PermsStore) EnqueuePermsSyncRequests(ctx context.Context, opts EnqueuePermsSyncRequestsOpts) (err error) {
+	if Mocks.Perms.EnqueuePermsSyncRequests != nil {
+		return Mocks.Perms.EnqueuePermsSyncRequests(ctx, opts)
+	}
+	if len(opts.IDs) == 0 {
+		return nil
+	}
+
+	ctx, save := s.observe(ctx, "EnqueuePermsSyncRequests", "")
+	defer func() {
+		save(&err,
+			otlog.String("requestType", string(opts.RequestType)),
+			otlog.Int("count", len(opts.IDs)),
+			otlog.String("reason", string(opts.Reason)),
+		)
+	}()
+
+	idColumn := sqlf.Sprintf("user_id")
+	if opts.RequestType == PermsSyncJobTypeRepo {
+		idColumn = sqlf.Sprintf("repo_id")
+	}
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_sync_requests.go:PermsStore.EnqueuePermsSyncRequests
+INSERT INTO perms_sync_requests (request_type, %s, priority, reason, no_perms, queued_at)
+SELECT %s, id, %s, %s, %s, %s
+FROM unnest(%s::integer[]) AS id
+ON CONFLICT (%s) WHERE state IN ('queued', 'processing')
+DO UPDATE SET
+  priority           = CASE WHEN perms_sync_requests.state = 'queued' THEN EXCLUDED.priority ELSE perms_sync_requests.priority END,
+  reason             = CASE WHEN perms_sync_requests.state = 'queued' THEN EXCLUDED.reason ELSE perms_sync_requests.reason END,
+  no_perms           = perms_sync_requests.no_perms OR (perms_sync_requests.state = 'queued' AND EXCLUDED.no_perms),
+  follow_up_priority = CASE WHEN perms_sync_requests.state = 'processing' THEN GREATEST(perms_sync_requests.follow_up_priority, EXCLUDED.priority) ELSE perms_sync_requests.follow_up_priority END,
+  follow_up_reason   = CASE
+    WHEN perms_sync_requests.state = 'processing' AND COALESCE(perms_sync_requests.follow_up_priority < EXCLUDED.priority, TRUE) THEN EXCLUDED.reason
+    ELSE perms_sync_requests.follow_up_reason
+  END,
+  follow_up_no_perms = perms_sync_requests.follow_up_no_perms OR (perms_sync_requests.state = 'processing' AND EXCLUDED.no_perms)
+WHERE
+    (perms_sync_requests.state = 'queued' AND perms_sync_requests.priority < EXCLUDED.priority)
+ OR (perms_sync_requests.state = 'processing' AND (
+        COALESCE(perms_sync_requests.follow_up_priority < EXCLUDED.priority, TRUE)
+     OR (EXCLUDED.no_perms AND NOT perms_sync_requests.follow_up_no_perms)
+    ))
+`,
+		idColumn,
+		opts.RequestType,
+		opts.Priority,
+		opts.Reason,
+		opts.NoPerms,
+		s.clock().UTC(),
+		pq.Array(int32sToInt64s(opts.IDs)),
+		idColumn,
+	)
+	return s.execute(ctx, q)
+}
+
+// ListQueuedPermsSyncRequests returns at most limit queued permissions sync
+// requests, in the order they will be processed.
+func (s *PermsStore) ListQueuedPermsSyncRequests(ctx context.Context, limit int) (requests []*PermsSyncRequest, err error) {
+	ctx, save := s.observe(ctx, "ListQueuedPermsSyncRequests", "")
+	defer func() { save(&err, otlog.Int("count", len(requests))) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_sync_requests.go:PermsStore.ListQueuedPermsSyncRequests
+SELECT %s
+FROM perms_sync_requests
+WHERE state = 'queued'
+ORDER BY priority DESC, queued_at ASC, id ASC
+LIMIT %s
+`, sqlf.Join(PermsSyncRequestColumns, ", "), limit)
+	return scanPermsSyncRequests(s.Query(ctx, q))
+}
+
+// CountQueuedPermsSyncRequests returns the number of queued permissions sync
+// requests.
+func (s *PermsStore) CountQueuedPermsSyncRequests(ctx context.Context) (count int, err error) {
+	ctx, save := s.observe(ctx, "CountQueuedPermsSyncRequests", "")
+	defer func() { save(&err, otlog.Int("count", count)) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_sync_requests.go:PermsStore.CountQueuedPermsSyncRequests
+SELECT COUNT(*) FROM perms_sync_requests WHERE state = 'queued'
+`)
+	count, _, err = basestore.ScanFirstInt(s.Query(ctx, q))
+	return count, err
+}
+
+// DeletePermsSyncRequestsBefore deletes all processed permissions sync requests
+// that finished before the given time.
+func (s *PermsStore) DeletePermsSyncRequestsBefore(ctx context.Context, before time.Time) (err error) {
+	ctx, save := s.observe(ctx, "DeletePermsSyncRequestsBefore", "")
+	defer func() { save(&err, otlog.String("before", before.String())) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_sync_requests.go:PermsStore.DeletePermsSyncRequestsBefore
+DELETE FROM perms_sync_requests
+WHERE
+    state IN ('completed', 'errored', 'failed')
+AND finished_at < %s
+`, before.UTC())
+	return s.execute(ctx, q)
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+func testPermsStore_PermsSyncRequests(db *sql.DB) func(*testing.T) {
+	return func(t *testing.T) {
+		s := Perms(db, clock)
+		t.Cleanup(func() {
+			cleanupUsersTable(t, s)
+			cleanupReposTable(t, s)
+		})
+
+		ctx := context.Background()
+
+		qs := []*sqlf.Query{
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('alice')`),        // ID=1
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('bob')`),          // ID=2
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('r1', TRUE)`), // ID=1
+		}
+		for _, q := range qs {
+			if err := s.execute(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		enqueue := func(typ PermsSyncJobType, reason PermsSyncReason, noPerms bool, ids ...int32) {
+			t.Helper()
+			err := s.EnqueuePermsSyncRequests(ctx, EnqueuePermsSyncRequestsOpts{
+				RequestType: typ,
+				IDs:         ids,
+				Priority:    reason.Priority(),
+				Reason:      reason,
+				NoPerms:     noPerms,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		type request struct {
+			Type     PermsSyncJobType
+			ID       int32
+			Priority PermsSyncPriority
+			Reason   PermsSyncReason
+			NoPerms  bool
+		}
+		listQueued := func() []request {
+			t.Helper()
+			requests, err := s.ListQueuedPermsSyncRequests(ctx, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []request
+			for _, r := range requests {
+				got = append(got, request{
+					Type:     r.RequestType,
+					ID:       r.UserID + r.RepoID,
+					Priority: r.Priority,
+					Reason:   r.Reason,
+					NoPerms:  r.NoPerms,
+				})
+			}
+			return got
+		}
+
+		enqueue(PermsSyncJobTypeUser, PermsSyncReasonOldestPerms, false, 1, 2)
+		enqueue(PermsSyncJobTypeRepo, PermsSyncReasonNoPerms, true, 1)
+		// Raises the priority of the existing request of user 2.
+		enqueue(PermsSyncJobTypeUser, PermsSyncReasonManual, false, 2)
+		// Does not lower the priority of the existing request of user 2.
+		enqueue(PermsSyncJobTypeUser, PermsSyncReasonWebhook, false, 2)
+
+		want := []request{
+			{Type: PermsSyncJobTypeUser, ID: 2, Priority: PermsSyncPriorityHigh, Reason: PermsSyncReasonManual},
+			{Type: PermsSyncJobTypeUser, ID: 1, Priority: PermsSyncPriorityLow, Reason: PermsSyncReasonOldestPerms},
+			{Type: PermsSyncJobTypeRepo, ID: 1, Priority: PermsSyncPriorityLow, Reason: PermsSyncReasonNoPerms, NoPerms: true},
+		}
+		if diff := cmp.Diff(want, listQueued()); diff != "" {
+			t.Fatalf("queued requests mismatch (-want +got):\n%s", diff)
+		}
+
+		count, err := s.CountQueuedPermsSyncRequests(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 3 {
+			t.Fatalf("count: want 3 but got %d", count)
+		}
+
+		// Requests enqueued while one is being processed are recorded as its
+		// follow-up, with the highest priority.
+		err = s.execute(ctx, sqlf.Sprintf(`UPDATE perms_sync_requests SET state = 'processing' WHERE user_id = 2`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		enqueue(PermsSyncJobTypeUser, PermsSyncReasonWebhook, false, 2)
+		enqueue(PermsSyncJobTypeUser, PermsSyncReasonOldestPerms, true, 2)
+		if diff := cmp.Diff(want[1:], listQueued()); diff != "" {
+			t.Fatalf("queued requests mismatch (-want +got):\n%s", diff)
+		}
+
+		// The follow-up is enqueued once the processing request is finished.
+		err = s.execute(ctx, sqlf.Sprintf(`UPDATE perms_sync_requests SET state = 'completed', finished_at = %s WHERE user_id = 2`, clock().AddDate(0, 0, -2)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantFollowUp := append([]request{
+			{Type: PermsSyncJobTypeUser, ID: 2, Priority: PermsSyncPriorityMedium, Reason: PermsSyncReasonWebhook, NoPerms: true},
+		}, want[1:]...)
+		if diff := cmp.Diff(wantFollowUp, listQueued()); diff != "" {
+			t.Fatalf("queued requests mismatch (-want +got):\n%s", diff)
+		}
+
+		// Processed requests are deleted once they are old enough.
+		if err = s.DeletePermsSyncRequestsBefore(ctx, clock().AddDate(0, 0, -1)); err != nil {
+			t.Fatal(err)
+		}
+		total, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(`SELECT COUNT(*) FROM perms_sync_requests`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 {
+			t.Fatalf("total: want 3 but got %d", total)
+		}
+	}
+}
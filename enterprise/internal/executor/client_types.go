@@ -68,6 +68,10 @@ type CliStep struct {
 type DequeueRequest struct {
 	ExecutorName     string `json:"executorName"`
 	ExecutorHostname string `json:"executorHostname"`
+
+	// SrcCLIVersion is the version of src-cli that the executor runs src-cli
+	// steps with. It is empty if the executor couldn't determine it.
+	SrcCLIVersion string `json:"srcCliVersion,omitempty"`
 }
 
 type AddExecutionLogEntryRequest struct {
@@ -0,0 +1,114 @@
+package bitbucketcloud
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Bitbucket Cloud authz providers derived from the connections.
+// It also returns any validation problems with the config, separating these into "serious problems" and
+// "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+func NewAuthzProviders(
+	conns []*types.BitbucketCloudConnection,
+	authProviders []schema.AuthProviders,
+) (ps []authz.Provider, problems []string, warnings []string) {
+	// Authorization (i.e., permissions) providers
+	for _, c := range conns {
+		p, err := newAuthzProvider(c, authProviders)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	for _, p := range ps {
+		for _, problem := range p.Validate() {
+			warnings = append(warnings, fmt.Sprintf("Bitbucket Cloud config for %s was invalid: %s", p.ServiceID(), problem))
+		}
+	}
+
+	return ps, problems, warnings
+}
+
+func newAuthzProvider(c *types.BitbucketCloudConnection, ps []schema.AuthProviders) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	instanceURL := c.Url
+	if instanceURL == "" {
+		instanceURL = "https://bitbucket.org"
+	}
+	bbURL, err := url.Parse(instanceURL)
+	if err != nil {
+		return nil, errors.Errorf("Could not parse URL for Bitbucket Cloud instance %q: %s", instanceURL, err)
+	}
+
+	rawAPIURL := c.ApiURL
+	if rawAPIURL == "" {
+		rawAPIURL = "https://api.bitbucket.org"
+	}
+	apiURL, err := url.Parse(rawAPIURL)
+	if err != nil {
+		return nil, errors.Errorf("Could not parse API URL for Bitbucket Cloud instance %q: %s", rawAPIURL, err)
+	}
+
+	// Check that there is a Bitbucket Cloud authn provider corresponding to this Bitbucket Cloud instance
+	var foundAuthProvider *schema.BitbucketCloudAuthProvider
+	for _, authnProvider := range ps {
+		if authnProvider.Bitbucketcloud == nil {
+			continue
+		}
+		authnURL := authnProvider.Bitbucketcloud.Url
+		if authnURL == "" {
+			authnURL = "https://bitbucket.org"
+		}
+		authProviderURL, err := url.Parse(authnURL)
+		if err != nil {
+			// Ignore the error here, because the authn provider is responsible for its own validation
+			continue
+		}
+		if authProviderURL.Hostname() == bbURL.Hostname() {
+			foundAuthProvider = authnProvider.Bitbucketcloud
+			break
+		}
+	}
+	if foundAuthProvider == nil {
+		return nil, errors.Errorf("Did not find authentication provider matching %q. Check the [**site configuration**](/site-admin/configuration) to verify an entry in [`auth.providers`](https://docs.sourcegraph.com/admin/auth) exists for %s.", instanceURL, instanceURL)
+	}
+
+	cli := bitbucketcloud.NewClient(extsvc.NormalizeBaseURL(apiURL), nil)
+	cli.Username = c.Username
+	cli.AppPassword = c.AppPassword
+
+	// The OAuth tokens of the users are issued by the OAuth consumer of the authn
+	// provider, so they can only be refreshed with its credentials.
+	oauth2Config := &oauth2.Config{
+		ClientID:     foundAuthProvider.ClientKey,
+		ClientSecret: foundAuthProvider.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  bbURL.ResolveReference(&url.URL{Path: "/site/oauth2/authorize"}).String(),
+			TokenURL: bbURL.ResolveReference(&url.URL{Path: "/site/oauth2/access_token"}).String(),
+		},
+	}
+
+	return NewProvider(c.URN, bbURL, cli, oauth2Config), nil
+}
+
+// ValidateAuthz validates the authorization fields of the given Bitbucket Cloud
+// external service config.
+func ValidateAuthz(cfg *schema.BitbucketCloudConnection, ps []schema.AuthProviders) error {
+	_, err := newAuthzProvider(&types.BitbucketCloudConnection{BitbucketCloudConnection: cfg}, ps)
+	return err
+}
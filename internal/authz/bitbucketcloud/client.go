@@ -0,0 +1,67 @@
+package bitbucketcloud
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+// client defines the set of Bitbucket Cloud API client methods used by the authz provider.
+//
+// NOTE: All methods are sorted in alphabetical order.
+type client interface {
+	CurrentUserRepoPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+	CurrentUserWorkspacePermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error)
+	RepoUserPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+	Repos(ctx context.Context, pageToken *bitbucketcloud.PageToken, accountName string) ([]*bitbucketcloud.Repo, *bitbucketcloud.PageToken, error)
+	WithToken(token string) client
+	WorkspacePermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error)
+}
+
+var _ client = (*ClientAdapter)(nil)
+
+// ClientAdapter is an adapter for Bitbucket Cloud API client.
+type ClientAdapter struct {
+	*bitbucketcloud.Client
+}
+
+func (c *ClientAdapter) WithToken(token string) client {
+	return &ClientAdapter{
+		Client: c.Client.WithToken(token),
+	}
+}
+
+var _ client = (*mockClient)(nil)
+
+type mockClient struct {
+	MockCurrentUserRepoPermissions      func(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+	MockCurrentUserWorkspacePermissions func(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error)
+	MockRepoUserPermissions             func(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+	MockRepos                           func(ctx context.Context, pageToken *bitbucketcloud.PageToken, accountName string) ([]*bitbucketcloud.Repo, *bitbucketcloud.PageToken, error)
+	MockWithToken                       func(token string) client
+	MockWorkspacePermissions            func(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error)
+}
+
+func (m *mockClient) CurrentUserRepoPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+	return m.MockCurrentUserRepoPermissions(ctx, pageToken)
+}
+
+func (m *mockClient) CurrentUserWorkspacePermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error) {
+	return m.MockCurrentUserWorkspacePermissions(ctx, pageToken)
+}
+
+func (m *mockClient) RepoUserPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+	return m.MockRepoUserPermissions(ctx, pageToken, workspace, repoSlug)
+}
+
+func (m *mockClient) Repos(ctx context.Context, pageToken *bitbucketcloud.PageToken, accountName string) ([]*bitbucketcloud.Repo, *bitbucketcloud.PageToken, error) {
+	return m.MockRepos(ctx, pageToken, accountName)
+}
+
+func (m *mockClient) WithToken(token string) client {
+	return m.MockWithToken(token)
+}
+
+func (m *mockClient) WorkspacePermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error) {
+	return m.MockWorkspacePermissions(ctx, pageToken, workspace)
+}
@@ -0,0 +1,308 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Provider implements authz.Provider for Bitbucket Cloud repository permissions.
+//
+// User permissions are fetched with the OAuth token of the user's external account,
+// which is created when the user signs in through the "bitbucketcloud" authentication
+// provider. Repository permissions are fetched with the credentials of the external
+// service, which must belong to an owner of the workspace.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+
+	// oauth2Config is the configuration of the OAuth consumer of the authentication
+	// provider, which is used to refresh the expired OAuth tokens of the users.
+	oauth2Config *oauth2.Config
+	accounts     accountsStore
+}
+
+type accountsStore interface {
+	LookupUserAndSave(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider for the
+// Bitbucket Cloud instance at baseURL that uses the given client to talk to the
+// Bitbucket Cloud API. The OAuth tokens of the users are refreshed with the given
+// configuration of the OAuth consumer of the authentication provider.
+func NewProvider(urn string, baseURL *url.URL, cli *bitbucketcloud.Client, oauth2Config *oauth2.Config) *Provider {
+	return &Provider{
+		urn:          urn,
+		client:       &ClientAdapter{Client: cli},
+		codeHost:     extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+		oauth2Config: oauth2Config,
+		accounts:     &database.UserExternalAccountsStore{},
+	}
+}
+
+// FetchAccount implements the authz.Provider interface. It always returns nil, because
+// Bitbucket Cloud accounts can only be linked through the OAuth authentication provider.
+func (p *Provider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (mine *extsvc.Account, err error) {
+	return nil, nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *Provider) Validate() (problems []string) {
+	return nil
+}
+
+// FetchUserPerms returns a list of repository UUIDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/user/permissions/repositories
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) (*authz.ExternalUserPermissions, error) {
+	if account == nil {
+		return nil, errors.New("no account provided")
+	} else if !extsvc.IsHostOfAccount(p.codeHost, account) {
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
+	}
+
+	_, tok, err := bitbucketcloud.GetExternalAccountData(&account.AccountData)
+	if err != nil {
+		return nil, errors.Wrap(err, "get external account data")
+	} else if tok == nil {
+		return nil, errors.New("no token found in the external account data")
+	}
+
+	// Access tokens of Bitbucket Cloud expire after two hours, so the token is
+	// refreshed first if needed.
+	tok, err = p.tokenSource(ctx, account, tok).Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "refresh token")
+	}
+
+	return p.FetchUserPermsByToken(ctx, tok.AccessToken)
+}
+
+// tokenSource returns a token source that returns the given token of the given account
+// until it expires, and then refreshes it and saves the new token to the account.
+func (p *Provider) tokenSource(ctx context.Context, account *extsvc.Account, tok *oauth2.Token) oauth2.TokenSource {
+	if p.oauth2Config == nil {
+		return oauth2.StaticTokenSource(tok)
+	}
+	return &accountTokenSource{
+		account:  account,
+		accounts: p.accounts,
+		current:  tok.AccessToken,
+		source:   p.oauth2Config.TokenSource(ctx, tok),
+		ctx:      ctx,
+	}
+}
+
+// accountTokenSource is an oauth2.TokenSource that saves the tokens returned by the
+// underlying token source to the external account when they change.
+type accountTokenSource struct {
+	account  *extsvc.Account
+	accounts accountsStore
+	current  string
+	source   oauth2.TokenSource
+	ctx      context.Context
+}
+
+func (s *accountTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok.AccessToken == s.current {
+		return tok, nil
+	}
+
+	data := s.account.AccountData
+	data.SetAuthData(tok)
+	if _, err := s.accounts.LookupUserAndSave(s.ctx, s.account.AccountSpec, data); err != nil {
+		return nil, errors.Wrap(err, "save refreshed token")
+	}
+	s.account.AccountData.SetAuthData(tok)
+	s.current = tok.AccessToken
+	return tok, nil
+}
+
+// FetchUserPermsByToken fetches all the repository UUIDs that the token can
+// access. That is the union of the repositories the user has been granted
+// explicit permissions on and all repositories of the workspaces the user owns.
+//
+// The result may include public repositories, which is harmless because
+// they are accessible to everyone anyway.
+func (p *Provider) FetchUserPermsByToken(ctx context.Context, token string) (*authz.ExternalUserPermissions, error) {
+	// 🚨 SECURITY: Use user token is required to only list repositories the user has access to.
+	client := p.client.WithToken(token)
+
+	seen := make(map[extsvc.RepoID]struct{})
+	repoIDs := make([]extsvc.RepoID, 0, 100)
+	addRepo := func(uuid string) {
+		id := extsvc.RepoID(uuid)
+		if _, ok := seen[id]; ok {
+			return
+		}
+		seen[id] = struct{}{}
+		repoIDs = append(repoIDs, id)
+	}
+
+	var next *bitbucketcloud.PageToken
+	for {
+		perms, page, err := client.CurrentUserRepoPermissions(ctx, next)
+		if err != nil {
+			return &authz.ExternalUserPermissions{Exacts: repoIDs}, errors.Wrap(err, "list repository permissions")
+		}
+		for _, perm := range perms {
+			if perm.Repository != nil {
+				addRepo(perm.Repository.UUID)
+			}
+		}
+		if !page.HasMore() {
+			break
+		}
+		next = page
+	}
+
+	// Owners of a workspace can read all of its repositories without being granted
+	// explicit permissions on them.
+	var ownedWorkspaces []string
+	next = nil
+	for {
+		perms, page, err := client.CurrentUserWorkspacePermissions(ctx, next)
+		if err != nil {
+			return &authz.ExternalUserPermissions{Exacts: repoIDs}, errors.Wrap(err, "list workspace permissions")
+		}
+		for _, perm := range perms {
+			if perm.Permission == bitbucketcloud.WorkspacePermissionOwner && perm.Workspace != nil {
+				ownedWorkspaces = append(ownedWorkspaces, perm.Workspace.Slug)
+			}
+		}
+		if !page.HasMore() {
+			break
+		}
+		next = page
+	}
+
+	for _, workspace := range ownedWorkspaces {
+		next = nil
+		for {
+			repos, page, err := client.Repos(ctx, next, workspace)
+			if err != nil {
+				return &authz.ExternalUserPermissions{Exacts: repoIDs}, errors.Wrapf(err, "list repositories of workspace %q", workspace)
+			}
+			for _, r := range repos {
+				addRepo(r.UUID)
+			}
+			if !page.HasMore() {
+				break
+			}
+			next = page
+		}
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: repoIDs,
+	}, nil
+}
+
+// FetchRepoPerms returns a list of user UUIDs (on code host) who have read access to
+// the given repository on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes both users with
+// explicit repository permissions and the owners of the repository's workspace.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories/%7Brepo_slug%7D
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	if repo == nil {
+		return nil, errors.New("no repository provided")
+	} else if !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec) {
+		return nil, errors.Errorf("not a code host of the repository: want %q but have %q",
+			repo.ServiceID, p.codeHost.ServiceID)
+	}
+
+	// NOTE: We do not store port or scheme in our URI, so stripping the hostname alone is enough.
+	fullName := strings.TrimPrefix(repo.URI, p.codeHost.BaseURL.Hostname())
+	fullName = strings.TrimPrefix(fullName, "/")
+	parts := strings.SplitN(fullName, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("invalid repository full name %q", fullName)
+	}
+	workspace, slug := parts[0], parts[1]
+
+	seen := make(map[extsvc.AccountID]struct{})
+	userIDs := make([]extsvc.AccountID, 0, 100)
+	addUser := func(u *bitbucketcloud.User) {
+		if u == nil || u.UUID == "" {
+			return
+		}
+		id := extsvc.AccountID(u.UUID)
+		if _, ok := seen[id]; ok {
+			return
+		}
+		seen[id] = struct{}{}
+		userIDs = append(userIDs, id)
+	}
+
+	var next *bitbucketcloud.PageToken
+	for {
+		perms, page, err := p.client.RepoUserPermissions(ctx, next, workspace, slug)
+		if err != nil {
+			return userIDs, errors.Wrap(err, "list repository user permissions")
+		}
+		for _, perm := range perms {
+			addUser(perm.User)
+		}
+		if !page.HasMore() {
+			break
+		}
+		next = page
+	}
+
+	next = nil
+	for {
+		perms, page, err := p.client.WorkspacePermissions(ctx, next, workspace)
+		if err != nil {
+			return userIDs, errors.Wrap(err, "list workspace permissions")
+		}
+		for _, perm := range perms {
+			if perm.Permission == bitbucketcloud.WorkspacePermissionOwner {
+				addUser(perm.User)
+			}
+		}
+		if !page.HasMore() {
+			break
+		}
+		next = page
+	}
+
+	return userIDs, nil
+}
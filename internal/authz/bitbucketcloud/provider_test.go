@@ -0,0 +1,310 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+func mustURL(t *testing.T, u string) *url.URL {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func newTestProvider(t *testing.T) *Provider {
+	return NewProvider("", mustURL(t, "https://bitbucket.org"), bitbucketcloud.NewClient(mustURL(t, "https://api.bitbucket.org"), nil), nil)
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	t.Run("nil account", func(t *testing.T) {
+		p := newTestProvider(t)
+		_, err := p.FetchUserPerms(context.Background(), nil)
+		want := "no account provided"
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		p := newTestProvider(t)
+		_, err := p.FetchUserPerms(context.Background(),
+			&extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: "gitlab",
+					ServiceID:   "https://gitlab.com/",
+				},
+			},
+		)
+		want := `not a code host of the account: want "https://gitlab.com/" but have "https://bitbucket.org/"`
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("no token found in account data", func(t *testing.T) {
+		p := newTestProvider(t)
+		_, err := p.FetchUserPerms(context.Background(),
+			&extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeBitbucketCloud,
+					ServiceID:   "https://bitbucket.org/",
+				},
+				AccountData: extsvc.AccountData{},
+			},
+		)
+		want := `no token found in the external account data`
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	mockClient := &mockClient{
+		MockCurrentUserRepoPermissions: func(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+			if !pageToken.HasMore() {
+				return []*bitbucketcloud.RepoPermission{
+					{Permission: "read", Repository: &bitbucketcloud.Repo{UUID: "{repo-1}"}},
+					{Permission: "write", Repository: &bitbucketcloud.Repo{UUID: "{repo-2}"}},
+				}, &bitbucketcloud.PageToken{Next: "page-2"}, nil
+			}
+			return []*bitbucketcloud.RepoPermission{
+				{Permission: "admin", Repository: &bitbucketcloud.Repo{UUID: "{repo-3}"}},
+			}, &bitbucketcloud.PageToken{}, nil
+		},
+		MockCurrentUserWorkspacePermissions: func(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error) {
+			return []*bitbucketcloud.WorkspacePermission{
+				{Permission: "owner", Workspace: &bitbucketcloud.Workspace{Slug: "owned"}},
+				{Permission: "member", Workspace: &bitbucketcloud.Workspace{Slug: "joined"}},
+			}, &bitbucketcloud.PageToken{}, nil
+		},
+		MockRepos: func(ctx context.Context, pageToken *bitbucketcloud.PageToken, accountName string) ([]*bitbucketcloud.Repo, *bitbucketcloud.PageToken, error) {
+			if accountName != "owned" {
+				t.Fatalf("unexpected workspace %q", accountName)
+			}
+			return []*bitbucketcloud.Repo{
+				{UUID: "{repo-3}"},
+				{UUID: "{repo-4}"},
+			}, &bitbucketcloud.PageToken{}, nil
+		},
+	}
+	calledWithToken := ""
+	mockClient.MockWithToken = func(token string) client {
+		calledWithToken = token
+		return mockClient
+	}
+
+	p := newTestProvider(t)
+	p.client = mockClient
+
+	authData := json.RawMessage(`{"access_token": "my_access_token"}`)
+	repoIDs, err := p.FetchUserPerms(context.Background(),
+		&extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+			},
+			AccountData: extsvc.AccountData{
+				AuthData: &authData,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calledWithToken != "my_access_token" {
+		t.Fatalf("WithToken: want %q but got %q", "my_access_token", calledWithToken)
+	}
+
+	wantRepoIDs := []extsvc.RepoID{
+		"{repo-1}",
+		"{repo-2}",
+		"{repo-3}",
+		"{repo-4}",
+	}
+	if diff := cmp.Diff(wantRepoIDs, repoIDs.Exacts); diff != "" {
+		t.Fatalf("RepoIDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProvider_FetchUserPerms_ExpiredToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if got := r.Form.Get("grant_type"); got != "refresh_token" {
+			t.Fatalf("grant_type: want %q but got %q", "refresh_token", got)
+		}
+		if got := r.Form.Get("refresh_token"); got != "my_refresh_token" {
+			t.Fatalf("refresh_token: want %q but got %q", "my_refresh_token", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "new_access_token", "refresh_token": "new_refresh_token", "token_type": "bearer", "expires_in": 7200}`))
+	}))
+	defer srv.Close()
+
+	calledWithToken := ""
+	mockClient := &mockClient{
+		MockCurrentUserRepoPermissions: func(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+			return []*bitbucketcloud.RepoPermission{
+				{Permission: "read", Repository: &bitbucketcloud.Repo{UUID: "{repo-1}"}},
+			}, &bitbucketcloud.PageToken{}, nil
+		},
+		MockCurrentUserWorkspacePermissions: func(ctx context.Context, pageToken *bitbucketcloud.PageToken) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error) {
+			return nil, &bitbucketcloud.PageToken{}, nil
+		},
+	}
+	mockClient.MockWithToken = func(token string) client {
+		calledWithToken = token
+		return mockClient
+	}
+
+	var saved *oauth2.Token
+	p := newTestProvider(t)
+	p.client = mockClient
+	p.oauth2Config = &oauth2.Config{
+		ClientID:     "client-key",
+		ClientSecret: "client-secret",
+		Endpoint:     oauth2.Endpoint{TokenURL: srv.URL},
+	}
+	p.accounts = mockAccountsStore(func(spec extsvc.AccountSpec, data extsvc.AccountData) (int32, error) {
+		if spec.AccountID != "{user-1}" {
+			t.Fatalf("unexpected account %q", spec.AccountID)
+		}
+		_, tok, err := bitbucketcloud.GetExternalAccountData(&data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		saved = tok
+		return 1, nil
+	})
+
+	authData := json.RawMessage(`{"access_token": "my_access_token", "refresh_token": "my_refresh_token", "expiry": "2021-01-01T00:00:00Z"}`)
+	repoIDs, err := p.FetchUserPerms(context.Background(),
+		&extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+				AccountID:   "{user-1}",
+			},
+			AccountData: extsvc.AccountData{
+				AuthData: &authData,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calledWithToken != "new_access_token" {
+		t.Fatalf("WithToken: want %q but got %q", "new_access_token", calledWithToken)
+	}
+	if saved == nil {
+		t.Fatal("refreshed token was not saved")
+	}
+	if saved.AccessToken != "new_access_token" || saved.RefreshToken != "new_refresh_token" {
+		t.Fatalf("unexpected saved token %+v", saved)
+	}
+	if diff := cmp.Diff([]extsvc.RepoID{"{repo-1}"}, repoIDs.Exacts); diff != "" {
+		t.Fatalf("RepoIDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type mockAccountsStore func(extsvc.AccountSpec, extsvc.AccountData) (int32, error)
+
+func (m mockAccountsStore) LookupUserAndSave(_ context.Context, spec extsvc.AccountSpec, data extsvc.AccountData) (int32, error) {
+	return m(spec, data)
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	t.Run("nil repository", func(t *testing.T) {
+		p := newTestProvider(t)
+		_, err := p.FetchRepoPerms(context.Background(), nil)
+		want := "no repository provided"
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("not the code host of the repository", func(t *testing.T) {
+		p := newTestProvider(t)
+		_, err := p.FetchRepoPerms(context.Background(),
+			&extsvc.Repository{
+				URI: "gitlab.com/user/repo",
+				ExternalRepoSpec: api.ExternalRepoSpec{
+					ServiceType: "gitlab",
+					ServiceID:   "https://gitlab.com/",
+				},
+			},
+		)
+		want := `not a code host of the repository: want "https://gitlab.com/" but have "https://bitbucket.org/"`
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	p := newTestProvider(t)
+	p.client = &mockClient{
+		MockRepoUserPermissions: func(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+			if workspace != "sglocal" || repoSlug != "mux" {
+				t.Fatalf("unexpected repository %s/%s", workspace, repoSlug)
+			}
+			if !pageToken.HasMore() {
+				return []*bitbucketcloud.RepoPermission{
+					{Permission: "read", User: &bitbucketcloud.User{UUID: "{user-1}"}},
+				}, &bitbucketcloud.PageToken{Next: "page-2"}, nil
+			}
+			return []*bitbucketcloud.RepoPermission{
+				{Permission: "write", User: &bitbucketcloud.User{UUID: "{user-2}"}},
+			}, &bitbucketcloud.PageToken{}, nil
+		},
+		MockWorkspacePermissions: func(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.WorkspacePermission, *bitbucketcloud.PageToken, error) {
+			return []*bitbucketcloud.WorkspacePermission{
+				{Permission: "owner", User: &bitbucketcloud.User{UUID: "{user-2}"}},
+				{Permission: "owner", User: &bitbucketcloud.User{UUID: "{user-3}"}},
+				{Permission: "member", User: &bitbucketcloud.User{UUID: "{user-4}"}},
+			}, &bitbucketcloud.PageToken{}, nil
+		},
+	}
+
+	accountIDs, err := p.FetchRepoPerms(context.Background(),
+		&extsvc.Repository{
+			URI: "bitbucket.org/sglocal/mux",
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "{e1e75436-05e6-4c38-8543-9c36ec26fad1}",
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAccountIDs := []extsvc.AccountID{
+		"{user-1}",
+		"{user-2}",
+		"{user-3}",
+	}
+	if diff := cmp.Diff(wantAccountIDs, accountIDs); diff != "" {
+		t.Fatalf("AccountIDs mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,173 @@
+package idpgroups
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	serviceTypeSAML          = "saml"
+	serviceTypeOpenIDConnect = "openidconnect"
+)
+
+// NewAuthzProviders returns the set of identity provider groups authz providers
+// derived from the SAML and OpenID Connect authentication providers that have
+// group permissions configured. It also returns any validation problems with the
+// config, separating these into "serious problems" and "warnings".
+func NewAuthzProviders(ctx context.Context, authProviders []schema.AuthProviders) (ps []authz.Provider, problems []string, warnings []string) {
+	for _, ap := range authProviders {
+		var (
+			serviceType, serviceID string
+			perms                  *schema.IdentityProviderGroupPermissions
+		)
+		switch {
+		case ap.Saml != nil && ap.Saml.GroupPermissions != nil:
+			entityID, err := samlEntityID(ctx, ap.Saml)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("Could not read the entity ID of the SAML identity provider with group permissions: %v", err))
+				continue
+			}
+			serviceType, serviceID, perms = serviceTypeSAML, entityID, ap.Saml.GroupPermissions
+		case ap.Openidconnect != nil && ap.Openidconnect.GroupPermissions != nil:
+			serviceType, serviceID, perms = serviceTypeOpenIDConnect, ap.Openidconnect.Issuer, ap.Openidconnect.GroupPermissions
+		default:
+			continue
+		}
+
+		groups, err := newGroups(perms)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Group permissions of %s identity provider %s were invalid: %v", serviceType, serviceID, err))
+			continue
+		}
+		ps = append(ps, NewProvider("idpgroups:"+serviceType+":"+serviceID, serviceType, serviceID, groups))
+	}
+	return ps, problems, warnings
+}
+
+func newGroups(perms *schema.IdentityProviderGroupPermissions) ([]*Group, error) {
+	groups := make([]*Group, 0, len(perms.Groups))
+	for _, g := range perms.Groups {
+		for _, pattern := range g.Repos {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, errors.Wrapf(err, "group %q", g.Group)
+			}
+		}
+
+		ids := make([]int64, 0, len(g.ExternalServices))
+		for _, id := range g.ExternalServices {
+			ids = append(ids, int64(id))
+		}
+		groups = append(groups, &Group{
+			Name:               g.Group,
+			Repos:              g.Repos,
+			ExternalServiceIDs: ids,
+		})
+	}
+	return groups, nil
+}
+
+// GroupsAttribute returns the name of the SAML assertion attribute or OpenID
+// Connect claim that lists the groups of the user.
+func GroupsAttribute(perms *schema.IdentityProviderGroupPermissions) string {
+	if perms == nil || perms.GroupsAttribute == "" {
+		return "groups"
+	}
+	return perms.GroupsAttribute
+}
+
+// entityIDsTTL is how long the entity IDs read from SAML identity provider
+// metadata URLs are cached, because providers are derived from the config every
+// few seconds.
+const entityIDsTTL = time.Hour
+
+var entityIDs = struct {
+	sync.Mutex
+	m map[string]cachedEntityID
+}{m: make(map[string]cachedEntityID)}
+
+type cachedEntityID struct {
+	entityID  string
+	fetchedAt time.Time
+}
+
+// samlEntityID returns the entity ID of the SAML identity provider, which is the
+// service ID of the external accounts created at sign in.
+func samlEntityID(ctx context.Context, p *schema.SAMLAuthProvider) (string, error) {
+	if p.IdentityProviderMetadata != "" {
+		return parseEntityID([]byte(p.IdentityProviderMetadata))
+	}
+	if p.IdentityProviderMetadataURL == "" {
+		return "", errors.New("no identity provider metadata")
+	}
+
+	entityIDs.Lock()
+	defer entityIDs.Unlock()
+
+	if c, ok := entityIDs.m[p.IdentityProviderMetadataURL]; ok && time.Since(c.fetchedAt) < entityIDsTTL {
+		return c.entityID, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	resp, err := ctxhttp.Get(ctx, nil, p.IdentityProviderMetadataURL)
+	if err != nil {
+		return "", errors.Wrap(err, "fetch identity provider metadata")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("non-200 HTTP response for identity provider metadata URL: %s", p.IdentityProviderMetadataURL)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "read identity provider metadata")
+	}
+
+	entityID, err := parseEntityID(data)
+	if err != nil {
+		return "", err
+	}
+	entityIDs.m[p.IdentityProviderMetadataURL] = cachedEntityID{entityID: entityID, fetchedAt: time.Now()}
+	return entityID, nil
+}
+
+// parseEntityID returns the entity ID of the identity provider described by the
+// metadata, whose root element is <EntityDescriptor> or <EntitiesDescriptor>.
+func parseEntityID(metadata []byte) (string, error) {
+	var root struct {
+		XMLName  xml.Name
+		EntityID string `xml:"entityID,attr"`
+		Entities []struct {
+			EntityID         string    `xml:"entityID,attr"`
+			IDPSSODescriptor *struct{} `xml:"IDPSSODescriptor"`
+		} `xml:"EntityDescriptor"`
+	}
+	if err := xml.Unmarshal(metadata, &root); err != nil {
+		return "", errors.Wrap(err, "parse identity provider metadata")
+	}
+
+	switch root.XMLName.Local {
+	case "EntityDescriptor":
+		if root.EntityID != "" {
+			return root.EntityID, nil
+		}
+	case "EntitiesDescriptor":
+		for _, e := range root.Entities {
+			if e.IDPSSODescriptor != nil && e.EntityID != "" {
+				return e.EntityID, nil
+			}
+		}
+	}
+	return "", errors.New("no identity provider entity ID found in metadata")
+}
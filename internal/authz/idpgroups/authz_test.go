@@ -0,0 +1,94 @@
+package idpgroups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAuthzProviders(t *testing.T) {
+	groupPerms := &schema.IdentityProviderGroupPermissions{
+		Groups: []*schema.IdentityProviderGroup{
+			{Group: "engineering", Repos: []string{"^github.com/org/"}, ExternalServices: []int{1}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		providers    []schema.AuthProviders
+		wantURNs     []string
+		wantProblems []string
+	}{
+		{
+			name: "no group permissions",
+			providers: []schema.AuthProviders{
+				{Builtin: &schema.BuiltinAuthProvider{Type: "builtin"}},
+				{Openidconnect: &schema.OpenIDConnectAuthProvider{Issuer: "https://oidc.example.com"}},
+			},
+		},
+		{
+			name: "OpenID Connect",
+			providers: []schema.AuthProviders{
+				{Openidconnect: &schema.OpenIDConnectAuthProvider{Issuer: "https://oidc.example.com", GroupPermissions: groupPerms}},
+			},
+			wantURNs: []string{"idpgroups:openidconnect:https://oidc.example.com"},
+		},
+		{
+			name: "SAML with inline metadata",
+			providers: []schema.AuthProviders{
+				{Saml: &schema.SAMLAuthProvider{
+					IdentityProviderMetadata: `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com"><IDPSSODescriptor/></EntityDescriptor>`,
+					GroupPermissions:         groupPerms,
+				}},
+			},
+			wantURNs: []string{"idpgroups:saml:https://idp.example.com"},
+		},
+		{
+			name: "SAML without metadata",
+			providers: []schema.AuthProviders{
+				{Saml: &schema.SAMLAuthProvider{GroupPermissions: groupPerms}},
+			},
+			wantProblems: []string{"Could not read the entity ID of the SAML identity provider with group permissions: no identity provider metadata"},
+		},
+		{
+			name: "invalid repository pattern",
+			providers: []schema.AuthProviders{
+				{Openidconnect: &schema.OpenIDConnectAuthProvider{
+					Issuer: "https://oidc.example.com",
+					GroupPermissions: &schema.IdentityProviderGroupPermissions{
+						Groups: []*schema.IdentityProviderGroup{{Group: "engineering", Repos: []string{"("}}},
+					},
+				}},
+			},
+			wantProblems: []string{"Group permissions of openidconnect identity provider https://oidc.example.com were invalid: group \"engineering\": error parsing regexp: missing closing ): `(`"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ps, problems, _ := NewAuthzProviders(context.Background(), test.providers)
+
+			var urns []string
+			for _, p := range ps {
+				urns = append(urns, p.URN())
+			}
+			if diff := cmp.Diff(test.wantURNs, urns); diff != "" {
+				t.Fatalf("URNs mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantProblems, problems); diff != "" {
+				t.Fatalf("problems mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupsAttribute(t *testing.T) {
+	if got := GroupsAttribute(nil); got != "groups" {
+		t.Fatalf("want %q but got %q", "groups", got)
+	}
+	if got := GroupsAttribute(&schema.IdentityProviderGroupPermissions{GroupsAttribute: "memberOf"}); got != "memberOf" {
+		t.Fatalf("want %q but got %q", "memberOf", got)
+	}
+}
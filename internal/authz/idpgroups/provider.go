@@ -0,0 +1,248 @@
+// Package idpgroups contains an authorization provider that grants access to
+// repositories based on the groups reported by a SAML or OpenID Connect identity
+// provider.
+package idpgroups
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// AccountData is the part of the data of SAML and OpenID Connect external
+// accounts that is read by the provider. The groups are saved when the user
+// signs in.
+type AccountData struct {
+	Groups []string `json:"groups,omitempty"`
+}
+
+// GetAccountData returns the groups saved in the data of the external account.
+func GetAccountData(data *extsvc.AccountData) (*AccountData, error) {
+	var d AccountData
+	if data == nil || data.Data == nil {
+		return &d, nil
+	}
+	if err := json.Unmarshal(*data.Data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Group is the set of repositories granted to the members of a group of the
+// identity provider.
+type Group struct {
+	// Name is the name of the group, as reported by the identity provider.
+	Name string
+	// Repos are regular expressions matching the names of the repositories.
+	Repos []string
+	// ExternalServiceIDs are the IDs of the external services whose repositories
+	// are granted.
+	ExternalServiceIDs []int64
+}
+
+type reposStore interface {
+	ListRepoNames(context.Context, database.ReposListOptions) ([]types.RepoName, error)
+}
+
+type accountsStore interface {
+	List(context.Context, database.ExternalAccountsListOptions) ([]*extsvc.Account, error)
+}
+
+// Provider implements authz.Provider for the groups of a SAML or OpenID Connect
+// identity provider.
+//
+// Unlike code host providers, it is not responsible for the repositories of an
+// external service: the access it grants is added to the access granted by the
+// code host providers, and it only grants access to private repositories.
+type Provider struct {
+	urn         string
+	serviceType string
+	serviceID   string
+	groups      []*Group
+
+	repos    reposStore
+	accounts accountsStore
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new provider for the identity provider identified by the
+// given service type ("saml" or "openidconnect") and service ID, which must be
+// the same as the ones of the external accounts created at sign in.
+func NewProvider(urn, serviceType, serviceID string, groups []*Group) *Provider {
+	return &Provider{
+		urn:         urn,
+		serviceType: serviceType,
+		serviceID:   serviceID,
+		groups:      groups,
+		repos:       database.GlobalRepos,
+		accounts:    &database.UserExternalAccountsStore{},
+	}
+}
+
+// FetchAccount implements the authz.Provider interface. It always returns nil,
+// because the external accounts are created when users sign in through the
+// identity provider.
+func (p *Provider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (*extsvc.Account, error) {
+	return nil, nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+func (p *Provider) ServiceID() string {
+	return p.serviceID
+}
+
+func (p *Provider) ServiceType() string {
+	return p.serviceType
+}
+
+func (p *Provider) Validate() (problems []string) {
+	return nil
+}
+
+// FetchUserPerms returns the internal IDs of the private repositories granted to
+// the groups saved in the data of the given account.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) (*authz.ExternalUserPermissions, error) {
+	if account == nil {
+		return nil, errors.New("no account provided")
+	} else if account.ServiceType != p.serviceType || account.ServiceID != p.serviceID {
+		return nil, errors.Errorf("not the identity provider of the account: want %q but have %q",
+			account.ServiceID, p.serviceID)
+	}
+
+	data, err := GetAccountData(&account.AccountData)
+	if err != nil {
+		return nil, errors.Wrap(err, "get external account data")
+	}
+
+	seen := make(map[api.RepoID]struct{})
+	repoIDs := make([]api.RepoID, 0, 100)
+	for _, g := range p.memberGroups(data.Groups) {
+		ids, err := p.listRepoIDs(ctx, g, nil)
+		if err != nil {
+			return &authz.ExternalUserPermissions{RepoIDs: repoIDs}, errors.Wrapf(err, "list repositories of group %q", g.Name)
+		}
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			repoIDs = append(repoIDs, id)
+		}
+	}
+
+	return &authz.ExternalUserPermissions{RepoIDs: repoIDs}, nil
+}
+
+// FetchUserPermsByToken is not supported, identity providers do not issue tokens
+// that can be used to list groups.
+func (p *Provider) FetchUserPermsByToken(context.Context, string) (*authz.ExternalUserPermissions, error) {
+	return nil, errors.New("fetching user permissions by token is not supported by identity provider groups")
+}
+
+// FetchRepoPerms returns the account IDs of the users who are a member of a group
+// that is granted the given repository. The repository is identified by its
+// external repository spec, i.e. it belongs to a code host.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	if repo == nil {
+		return nil, errors.New("no repository provided")
+	}
+
+	granted := make(map[string]struct{})
+	for _, g := range p.groups {
+		ids, err := p.listRepoIDs(ctx, g, []api.ExternalRepoSpec{repo.ExternalRepoSpec})
+		if err != nil {
+			return nil, errors.Wrapf(err, "list repositories of group %q", g.Name)
+		}
+		if len(ids) > 0 {
+			granted[g.Name] = struct{}{}
+		}
+	}
+	if len(granted) == 0 {
+		return nil, nil
+	}
+
+	accounts, err := p.accounts.List(ctx, database.ExternalAccountsListOptions{
+		ServiceType: p.serviceType,
+		ServiceID:   p.serviceID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list external accounts")
+	}
+
+	var accountIDs []extsvc.AccountID
+	for _, acct := range accounts {
+		data, err := GetAccountData(&acct.AccountData)
+		if err != nil {
+			return accountIDs, errors.Wrapf(err, "get data of external account %d", acct.ID)
+		}
+		for _, name := range data.Groups {
+			if _, ok := granted[name]; ok {
+				accountIDs = append(accountIDs, extsvc.AccountID(acct.AccountID))
+				break
+			}
+		}
+	}
+	return accountIDs, nil
+}
+
+// memberGroups returns the configured groups with one of the given names.
+func (p *Provider) memberGroups(names []string) []*Group {
+	member := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		member[name] = struct{}{}
+	}
+
+	var groups []*Group
+	for _, g := range p.groups {
+		if _, ok := member[g.Name]; ok {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// listRepoIDs returns the IDs of the private repositories granted to the group.
+// When externalRepos is not empty, only the given repositories are considered.
+func (p *Provider) listRepoIDs(ctx context.Context, g *Group, externalRepos []api.ExternalRepoSpec) ([]api.RepoID, error) {
+	// The patterns of a ReposListOptions must all match, so every pattern of the
+	// group is listed separately.
+	opts := make([]database.ReposListOptions, 0, len(g.Repos)+1)
+	for _, pattern := range g.Repos {
+		opts = append(opts, database.ReposListOptions{IncludePatterns: []string{pattern}})
+	}
+	if len(g.ExternalServiceIDs) > 0 {
+		opts = append(opts, database.ReposListOptions{ExternalServiceIDs: g.ExternalServiceIDs})
+	}
+
+	// 🚨 SECURITY: The groups are evaluated against all repositories, regardless
+	// of the permissions of the current actor.
+	ctx = actor.WithInternalActor(ctx)
+
+	var ids []api.RepoID
+	for _, opt := range opts {
+		// Public repositories are accessible to everyone anyway.
+		opt.OnlyPrivate = true
+		opt.ExternalRepos = externalRepos
+
+		repos, err := p.repos.ListRepoNames(ctx, opt)
+		if err != nil {
+			return ids, err
+		}
+		for _, r := range repos {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids, nil
+}
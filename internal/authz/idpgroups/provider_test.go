@@ -0,0 +1,252 @@
+package idpgroups
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeRepo struct {
+	types.RepoName
+	private            bool
+	externalRepo       api.ExternalRepoSpec
+	externalServiceIDs []int64
+}
+
+type fakeReposStore []fakeRepo
+
+func (s fakeReposStore) ListRepoNames(_ context.Context, opt database.ReposListOptions) ([]types.RepoName, error) {
+	var repos []types.RepoName
+	for _, r := range s {
+		if opt.OnlyPrivate && !r.private {
+			continue
+		}
+		if len(opt.ExternalRepos) > 0 && opt.ExternalRepos[0] != r.externalRepo {
+			continue
+		}
+		if len(opt.IncludePatterns) > 0 && !regexp.MustCompile(opt.IncludePatterns[0]).MatchString(string(r.Name)) {
+			continue
+		}
+		if len(opt.ExternalServiceIDs) > 0 && !containsAny(opt.ExternalServiceIDs, r.externalServiceIDs) {
+			continue
+		}
+		repos = append(repos, r.RepoName)
+	}
+	return repos, nil
+}
+
+func containsAny(a, b []int64) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type fakeAccountsStore []*extsvc.Account
+
+func (s fakeAccountsStore) List(_ context.Context, opt database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+	var accounts []*extsvc.Account
+	for _, acct := range s {
+		if acct.ServiceType == opt.ServiceType && acct.ServiceID == opt.ServiceID {
+			accounts = append(accounts, acct)
+		}
+	}
+	return accounts, nil
+}
+
+func newAccount(t *testing.T, serviceID, accountID string, groups ...string) *extsvc.Account {
+	data, err := json.Marshal(AccountData{Groups: groups})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := json.RawMessage(data)
+	return &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: "saml",
+			ServiceID:   serviceID,
+			AccountID:   accountID,
+		},
+		AccountData: extsvc.AccountData{Data: &raw},
+	}
+}
+
+func newTestProvider() *Provider {
+	p := NewProvider("idpgroups:saml:https://idp.example.com", "saml", "https://idp.example.com", []*Group{
+		{Name: "engineering", Repos: []string{"^github.com/org/"}},
+		{Name: "sales", ExternalServiceIDs: []int64{2}},
+		{Name: "admins", Repos: []string{"^github.com/org/", "^gitlab.com/"}},
+	})
+	p.repos = fakeReposStore{
+		{RepoName: types.RepoName{ID: 1, Name: "github.com/org/a"}, private: true, externalRepo: api.ExternalRepoSpec{ID: "a"}, externalServiceIDs: []int64{1}},
+		{RepoName: types.RepoName{ID: 2, Name: "github.com/org/public"}, externalRepo: api.ExternalRepoSpec{ID: "public"}, externalServiceIDs: []int64{1}},
+		{RepoName: types.RepoName{ID: 3, Name: "gitlab.com/org/b"}, private: true, externalRepo: api.ExternalRepoSpec{ID: "b"}, externalServiceIDs: []int64{2}},
+	}
+	return p
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	tests := []struct {
+		name    string
+		account *extsvc.Account
+		want    []api.RepoID
+		wantErr string
+	}{
+		{
+			name:    "nil account",
+			wantErr: "no account provided",
+		},
+		{
+			name:    "account of another identity provider",
+			account: newAccount(t, "https://other.example.com", "alice", "engineering"),
+			wantErr: `not the identity provider of the account: want "https://other.example.com" but have "https://idp.example.com"`,
+		},
+		{
+			name:    "no groups",
+			account: newAccount(t, "https://idp.example.com", "alice"),
+			want:    []api.RepoID{},
+		},
+		{
+			name:    "unknown group",
+			account: newAccount(t, "https://idp.example.com", "alice", "marketing"),
+			want:    []api.RepoID{},
+		},
+		{
+			name:    "repository name patterns",
+			account: newAccount(t, "https://idp.example.com", "alice", "engineering"),
+			want:    []api.RepoID{1},
+		},
+		{
+			name:    "external services",
+			account: newAccount(t, "https://idp.example.com", "alice", "sales"),
+			want:    []api.RepoID{3},
+		},
+		{
+			name:    "multiple groups",
+			account: newAccount(t, "https://idp.example.com", "alice", "engineering", "sales", "admins"),
+			want:    []api.RepoID{1, 3},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			perms, err := newTestProvider().FetchUserPerms(context.Background(), test.account)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Fatalf("err: want %q but got %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			got := perms.RepoIDs
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("RepoIDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider()
+	p.accounts = fakeAccountsStore{
+		newAccount(t, "https://idp.example.com", "alice", "engineering"),
+		newAccount(t, "https://idp.example.com", "bob", "sales"),
+		newAccount(t, "https://idp.example.com", "carol", "admins", "engineering"),
+		newAccount(t, "https://idp.example.com", "dave"),
+		newAccount(t, "https://other.example.com", "erin", "engineering"),
+	}
+
+	tests := []struct {
+		name string
+		repo api.ExternalRepoSpec
+		want []extsvc.AccountID
+	}{
+		{
+			name: "repository name patterns",
+			repo: api.ExternalRepoSpec{ID: "a"},
+			want: []extsvc.AccountID{"alice", "carol"},
+		},
+		{
+			name: "external services and patterns",
+			repo: api.ExternalRepoSpec{ID: "b"},
+			want: []extsvc.AccountID{"bob", "carol"},
+		},
+		{
+			name: "public repository",
+			repo: api.ExternalRepoSpec{ID: "public"},
+		},
+		{
+			name: "unknown repository",
+			repo: api.ExternalRepoSpec{ID: "unknown"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{ExternalRepoSpec: test.repo})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("account IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseEntityID(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "entity descriptor",
+			metadata: `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com"><IDPSSODescriptor/></EntityDescriptor>`,
+			want:     "https://idp.example.com",
+		},
+		{
+			name: "entities descriptor",
+			metadata: `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata">
+  <EntityDescriptor entityID="https://sp.example.com"><SPSSODescriptor/></EntityDescriptor>
+  <EntityDescriptor entityID="https://idp.example.com"><IDPSSODescriptor/></EntityDescriptor>
+</EntitiesDescriptor>`,
+			want: "https://idp.example.com",
+		},
+		{
+			name:     "no entity ID",
+			metadata: `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata"></EntityDescriptor>`,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid XML",
+			metadata: `<EntityDescriptor`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseEntityID([]byte(test.metadata))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("err: want error %v but got %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Fatalf("entity ID: want %q but got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -5,13 +5,15 @@ package authz
 import (
 	"context"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 // ExternalUserPermissions is a collection of accessible repository/project IDs
 // (on code host). It contains exact IDs, as well as prefixes to both include
-// and exclude IDs.
+// and exclude IDs. Providers that are not backed by a code host (e.g. identity
+// provider groups) return the internal IDs of the repositories instead.
 //
 // 🚨 SECURITY: Every call site should evaluate all fields of this struct to
 // have a complete set of IDs.
@@ -19,6 +21,10 @@ type ExternalUserPermissions struct {
 	Exacts          []extsvc.RepoID
 	IncludePrefixes []extsvc.RepoID
 	ExcludePrefixes []extsvc.RepoID
+
+	// RepoIDs are the internal IDs of the accessible repositories, regardless of
+	// the code host they belong to.
+	RepoIDs []api.RepoID
 }
 
 // Provider defines a source of truth of which repositories a user is authorized to view. The
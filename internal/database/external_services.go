@@ -46,6 +46,7 @@ type ExternalServiceStore struct {
 	GitHubValidators          []func(*schema.GitHubConnection) error
 	GitLabValidators          []func(*schema.GitLabConnection, []schema.AuthProviders) error
 	BitbucketServerValidators []func(*schema.BitbucketServerConnection) error
+	BitbucketCloudValidators  []func(*schema.BitbucketCloudConnection, []schema.AuthProviders) error
 	PerforceValidators        []func(*schema.PerforceConnection) error
 
 	key encryption.Key
@@ -60,6 +61,7 @@ func (e *ExternalServiceStore) copy() *ExternalServiceStore {
 		GitHubValidators:          e.GitHubValidators,
 		GitLabValidators:          e.GitLabValidators,
 		BitbucketServerValidators: e.BitbucketServerValidators,
+		BitbucketCloudValidators:  e.BitbucketCloudValidators,
 		PerforceValidators:        e.PerforceValidators,
 	}
 }
@@ -310,7 +312,7 @@ func (e *ExternalServiceStore) ValidateConfig(ctx context.Context, opt ValidateE
 		if err = jsoniter.Unmarshal(normalized, &c); err != nil {
 			return nil, err
 		}
-		err = e.validateBitbucketCloudConnection(ctx, opt.ExternalServiceID, &c)
+		err = e.validateBitbucketCloudConnection(ctx, opt.ExternalServiceID, &c, opt.AuthProviders)
 
 	case extsvc.KindPerforce:
 		var c schema.PerforceConnection
@@ -400,8 +402,15 @@ func (e *ExternalServiceStore) validateBitbucketServerConnection(ctx context.Con
 	return err.ErrorOrNil()
 }
 
-func (e *ExternalServiceStore) validateBitbucketCloudConnection(ctx context.Context, id int64, c *schema.BitbucketCloudConnection) error {
-	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c)
+func (e *ExternalServiceStore) validateBitbucketCloudConnection(ctx context.Context, id int64, c *schema.BitbucketCloudConnection, ps []schema.AuthProviders) error {
+	err := new(multierror.Error)
+	for _, validate := range e.BitbucketCloudValidators {
+		err = multierror.Append(err, validate(c, ps))
+	}
+
+	err = multierror.Append(err, e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c))
+
+	return err.ErrorOrNil()
 }
 
 func (e *ExternalServiceStore) validatePerforceConnection(ctx context.Context, id int64, c *schema.PerforceConnection) error {
@@ -540,6 +540,49 @@ func (u *UserStore) Delete(ctx context.Context, id int32) (err error) {
 	return nil
 }
 
+// Recover undoes the soft-delete of the user. The username is reserved again and
+// the external accounts that were deleted along with the user are restored.
+// Emails and access tokens are not restored, because Delete removes them.
+func (u *UserStore) Recover(ctx context.Context, id int32) (err error) {
+	if Mocks.Users.Recover != nil {
+		return Mocks.Users.Recover(ctx, id)
+	}
+	u.ensureStore()
+
+	tx, err := u.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Delete marks the external accounts with the same timestamp as the user.
+	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE user_external_accounts SET deleted_at=NULL WHERE user_id=%s AND deleted_at=(SELECT deleted_at FROM users WHERE id=%s)", id, id)); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=NULL, updated_at=now() WHERE id=%s AND deleted_at IS NOT NULL", id))
+	if err != nil {
+		var e *pgconn.PgError
+		if errors.As(err, &e) && e.ConstraintName == "users_username" {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return userNotFoundErr{args: []interface{}{id}}
+	}
+
+	// Reserve the username again, it was released by Delete.
+	if err := tx.Exec(ctx, sqlf.Sprintf("INSERT INTO names(name, user_id) SELECT username, id FROM users WHERE id=%s", id)); err != nil {
+		return errCannotCreateUser{errorCodeUsernameExists}
+	}
+	return nil
+}
+
 // HardDelete removes the user and all resources associated with this user.
 func (u *UserStore) HardDelete(ctx context.Context, id int32) (err error) {
 	if Mocks.Users.HardDelete != nil {
@@ -691,6 +734,24 @@ func (u *UserStore) GetByID(ctx context.Context, id int32) (*types.User, error)
 	return u.getOneBySQL(ctx, sqlf.Sprintf("WHERE id=%s AND deleted_at IS NULL LIMIT 1", id))
 }
 
+// GetByIDIncludingDeleted is like GetByID, but also returns users that were
+// soft-deleted. The returned bool reports whether the user was deleted.
+func (u *UserStore) GetByIDIncludingDeleted(ctx context.Context, id int32) (_ *types.User, deleted bool, err error) {
+	if Mocks.Users.GetByIDIncludingDeleted != nil {
+		return Mocks.Users.GetByIDIncludingDeleted(ctx, id)
+	}
+	u.ensureStore()
+
+	user, err := u.getOneBySQL(ctx, sqlf.Sprintf("WHERE id=%s LIMIT 1", id))
+	if err != nil {
+		return nil, false, err
+	}
+	if err := u.QueryRow(ctx, sqlf.Sprintf("SELECT deleted_at IS NOT NULL FROM users WHERE id=%s", id)).Scan(&deleted); err != nil {
+		return nil, false, err
+	}
+	return user, deleted, nil
+}
+
 // GetByVerifiedEmail returns the user (if any) with the specified verified email address. If a user
 // has a matching *unverified* email address, they will not be returned by this method. At most one
 // user may have any given verified email address.
@@ -1181,6 +1242,9 @@ const (
 // error occurs if the user does not exist. Adding a duplicate tag or removing a nonexistent tag is
 // not an error.
 func (u *UserStore) SetTag(ctx context.Context, userID int32, tag string, present bool) error {
+	if Mocks.Users.SetTag != nil {
+		return Mocks.Users.SetTag(ctx, userID, tag, present)
+	}
 	u.ensureStore()
 
 	var q *sqlf.Query
@@ -12,9 +12,11 @@ type MockUsers struct {
 	Update                       func(userID int32, update UserUpdate) error
 	Delete                       func(ctx context.Context, id int32) error
 	HardDelete                   func(ctx context.Context, id int32) error
+	Recover                      func(ctx context.Context, id int32) error
 	SetIsSiteAdmin               func(id int32, isSiteAdmin bool) error
 	CheckAndDecrementInviteQuota func(ctx context.Context, userID int32) (bool, error)
 	GetByID                      func(ctx context.Context, id int32) (*types.User, error)
+	GetByIDIncludingDeleted      func(ctx context.Context, id int32) (*types.User, bool, error)
 	GetByUsername                func(ctx context.Context, username string) (*types.User, error)
 	GetByUsernames               func(ctx context.Context, usernames ...string) ([]*types.User, error)
 	GetByCurrentAuthUser         func(ctx context.Context) (*types.User, error)
@@ -23,6 +25,7 @@ type MockUsers struct {
 	List                         func(ctx context.Context, opt *UsersListOptions) ([]*types.User, error)
 	InvalidateSessionsByID       func(ctx context.Context, id int32) error
 	HasTag                       func(ctx context.Context, userID int32, tag string) (bool, error)
+	SetTag                       func(ctx context.Context, userID int32, tag string, present bool) error
 	Tags                         func(ctx context.Context, userID int32) (map[string]bool, error)
 }
 
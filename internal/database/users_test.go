@@ -685,6 +685,51 @@ func TestUsers_Delete(t *testing.T) {
 	}
 }
 
+func TestUsers_Recover(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	user, err := Users(db).Create(ctx, NewUser{Username: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Users(db).Recover(ctx, user.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got err %v, want errcode.IsNotFound for user that isn't deleted", err)
+	}
+
+	if err := Users(db).Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, deleted, err := Users(db).GetByIDIncludingDeleted(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	} else if !deleted {
+		t.Error("user is not deleted")
+	}
+
+	if err := Users(db).Recover(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Users(db).GetByUsername(ctx, "u"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A deleted user can't be recovered once their username is taken.
+	if err := Users(db).Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Users(db).Create(ctx, NewUser{Username: "u"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Users(db).Recover(ctx, user.ID); !IsUsernameExists(err) {
+		t.Errorf("got err %v, want IsUsernameExists", err)
+	}
+}
+
 func TestUsers_HasTag(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
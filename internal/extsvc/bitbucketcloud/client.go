@@ -50,6 +50,10 @@ type Client struct {
 	// The username and app password credentials for accessing the server.
 	Username, AppPassword string
 
+	// token is an OAuth access token that, if set, is used instead of the
+	// username and app password credentials. See WithToken.
+	token string
+
 	// RateLimit is the self-imposed rate limiter (since Bitbucket does not have a concept
 	// of rate limiting in HTTP response headers).
 	RateLimit *rate.Limiter
@@ -86,6 +90,24 @@ func NewClient(apiURL *url.URL, httpClient httpcli.Doer) *Client {
 	}
 }
 
+// WithToken returns a copy of the Client authenticated as the Bitbucket Cloud user who
+// owns the given OAuth access token.
+func (c *Client) WithToken(token string) *Client {
+	cc := *c
+	cc.token = token
+	return &cc
+}
+
+// WithBasicAuth returns a copy of the Client authenticated with the given
+// username and app password instead of the credentials it was created with.
+func (c *Client) WithBasicAuth(username, appPassword string) *Client {
+	cc := *c
+	cc.Username = username
+	cc.AppPassword = appPassword
+	cc.token = ""
+	return &cc
+}
+
 // Repos returns a list of repositories that are fetched and populated based on given account
 // name and pagination criteria. If the account requested is a team, results will be filtered
 // down to the ones that the app password's user has access to.
@@ -195,6 +217,10 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 }
 
 func (c *Client) authenticate(req *http.Request) error {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		return nil
+	}
 	req.SetBasicAuth(c.Username, c.AppPassword)
 	return nil
 }
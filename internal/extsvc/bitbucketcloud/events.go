@@ -0,0 +1,109 @@
+package bitbucketcloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	eventTypeHeader = "X-Event-Key"
+)
+
+// ErrUnknownWebhookEventType is returned by ParseWebhookEvent for event types
+// that aren't relevant to Sourcegraph.
+var ErrUnknownWebhookEventType = errors.New("unknown webhook event type")
+
+func WebhookEventType(r *http.Request) string {
+	return r.Header.Get(eventTypeHeader)
+}
+
+// ParseWebhookEvent parses the payload of a webhook request with the given
+// event type, as returned by WebhookEventType.
+//
+// Webhook docs: https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/
+func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err error) {
+	switch eventType {
+	case "pullrequest:approved":
+		e = &PullRequestApprovedEvent{}
+	case "pullrequest:unapproved":
+		e = &PullRequestUnapprovedEvent{}
+	case "pullrequest:changes_request_created":
+		e = &PullRequestChangesRequestCreatedEvent{}
+	case "pullrequest:changes_request_removed":
+		e = &PullRequestChangesRequestRemovedEvent{}
+	case "pullrequest:created", "pullrequest:updated", "pullrequest:fulfilled", "pullrequest:rejected":
+		e = &PullRequestUpdatedEvent{}
+	case "repo:commit_status_created", "repo:commit_status_updated":
+		e = &RepoCommitStatusEvent{}
+	default:
+		return nil, errors.Wrapf(ErrUnknownWebhookEventType, "%q", eventType)
+	}
+	return e, json.Unmarshal(payload, e)
+}
+
+// PullRequestEvent contains the fields common to all pull request webhook
+// payloads.
+type PullRequestEvent struct {
+	Actor       User        `json:"actor"`
+	PullRequest PullRequest `json:"pullrequest"`
+	Repository  Repo        `json:"repository"`
+}
+
+// PullRequestUpdatedEvent is sent when a pull request is created, updated,
+// merged or declined.
+type PullRequestUpdatedEvent struct {
+	PullRequestEvent
+}
+
+// PullRequestApprovedEvent is sent when a user approves a pull request.
+type PullRequestApprovedEvent struct {
+	PullRequestEvent
+	Approval Review `json:"approval"`
+}
+
+// PullRequestUnapprovedEvent is sent when a user removes their approval.
+type PullRequestUnapprovedEvent struct {
+	PullRequestEvent
+	Approval Review `json:"approval"`
+}
+
+// PullRequestChangesRequestCreatedEvent is sent when a user requests changes.
+type PullRequestChangesRequestCreatedEvent struct {
+	PullRequestEvent
+	ChangesRequest Review `json:"changes_request"`
+}
+
+// PullRequestChangesRequestRemovedEvent is sent when a user withdraws their
+// request for changes.
+type PullRequestChangesRequestRemovedEvent struct {
+	PullRequestEvent
+	ChangesRequest Review `json:"changes_request"`
+}
+
+// Review is the approval or request for changes included in review webhook
+// payloads.
+type Review struct {
+	Date time.Time `json:"date"`
+	User User      `json:"user"`
+}
+
+// ToParticipant returns the participant state the review results in.
+func (r Review) ToParticipant(state string) *Participant {
+	return &Participant{
+		User:           r.User,
+		Role:           "REVIEWER",
+		Approved:       state == ParticipantStateApproved,
+		State:          state,
+		ParticipatedOn: r.Date,
+	}
+}
+
+// RepoCommitStatusEvent is sent when a commit status is created or updated.
+type RepoCommitStatusEvent struct {
+	Actor        User         `json:"actor"`
+	Repository   Repo         `json:"repository"`
+	CommitStatus CommitStatus `json:"commit_status"`
+}
@@ -0,0 +1,95 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Repository permission levels as returned by the Bitbucket Cloud API.
+const (
+	RepoPermissionRead  = "read"
+	RepoPermissionWrite = "write"
+	RepoPermissionAdmin = "admin"
+)
+
+// Workspace permission levels as returned by the Bitbucket Cloud API.
+const (
+	WorkspacePermissionOwner        = "owner"
+	WorkspacePermissionCollaborator = "collaborator"
+	WorkspacePermissionMember       = "member"
+)
+
+// Workspace is a Bitbucket Cloud workspace, the container of repositories that
+// used to be called a team.
+type Workspace struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	UUID string `json:"uuid"`
+}
+
+// RepoPermission is the permission a user has on a repository.
+type RepoPermission struct {
+	Permission string `json:"permission"`
+	Repository *Repo  `json:"repository"`
+	User       *User  `json:"user"`
+}
+
+// WorkspacePermission is the permission a user has on a workspace.
+type WorkspacePermission struct {
+	Permission string     `json:"permission"`
+	Workspace  *Workspace `json:"workspace"`
+	User       *User      `json:"user"`
+}
+
+// CurrentUserRepoPermissions returns a page of repository permissions of the user the
+// client is authenticated as. Only repositories the user has been explicitly granted
+// access to (directly or through a group) are included.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/user/permissions/repositories
+func (c *Client) CurrentUserRepoPermissions(ctx context.Context, pageToken *PageToken) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	next, err := c.pageOrNext(ctx, "/2.0/user/permissions/repositories", pageToken, &perms)
+	return perms, next, err
+}
+
+// CurrentUserWorkspacePermissions returns a page of workspace memberships of the user
+// the client is authenticated as.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/user/permissions/workspaces
+func (c *Client) CurrentUserWorkspacePermissions(ctx context.Context, pageToken *PageToken) ([]*WorkspacePermission, *PageToken, error) {
+	var perms []*WorkspacePermission
+	next, err := c.pageOrNext(ctx, "/2.0/user/permissions/workspaces", pageToken, &perms)
+	return perms, next, err
+}
+
+// RepoUserPermissions returns a page of the explicit user permissions of the given
+// repository. The client must be authenticated as an owner of the workspace.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories/%7Brepo_slug%7D
+func (c *Client) RepoUserPermissions(ctx context.Context, pageToken *PageToken, workspace, repoSlug string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	path := fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", url.PathEscape(workspace), url.PathEscape(repoSlug))
+	next, err := c.pageOrNext(ctx, path, pageToken, &perms)
+	return perms, next, err
+}
+
+// WorkspacePermissions returns a page of the memberships of the given workspace. The
+// client must be authenticated as a member of the workspace.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions
+func (c *Client) WorkspacePermissions(ctx context.Context, pageToken *PageToken, workspace string) ([]*WorkspacePermission, *PageToken, error) {
+	var perms []*WorkspacePermission
+	path := fmt.Sprintf("/2.0/workspaces/%s/permissions", url.PathEscape(workspace))
+	next, err := c.pageOrNext(ctx, path, pageToken, &perms)
+	return perms, next, err
+}
+
+// pageOrNext requests the page following pageToken if it has more results, or the first
+// page of path otherwise.
+func (c *Client) pageOrNext(ctx context.Context, path string, pageToken *PageToken, results interface{}) (*PageToken, error) {
+	if pageToken.HasMore() {
+		return c.reqPage(ctx, pageToken.Next, results)
+	}
+	return c.page(ctx, path, nil, pageToken, results)
+}
@@ -0,0 +1,309 @@
+package bitbucketcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Pull request states as returned by the Bitbucket Cloud API.
+const (
+	PullRequestStateOpen       = "OPEN"
+	PullRequestStateMerged     = "MERGED"
+	PullRequestStateDeclined   = "DECLINED"
+	PullRequestStateSuperseded = "SUPERSEDED"
+)
+
+// Participant review states as returned by the Bitbucket Cloud API. A
+// participant that hasn't reviewed the pull request has an empty state.
+const (
+	ParticipantStateApproved         = "approved"
+	ParticipantStateChangesRequested = "changes_requested"
+)
+
+// Commit status states as returned by the Bitbucket Cloud API.
+const (
+	CommitStatusStateSuccessful = "SUCCESSFUL"
+	CommitStatusStateFailed     = "FAILED"
+	CommitStatusStateInProgress = "INPROGRESS"
+	CommitStatusStateStopped    = "STOPPED"
+)
+
+// ErrPullRequestNotFound is returned by GetPullRequest if the pull request
+// doesn't exist.
+var ErrPullRequestNotFound = errors.New("pull request not found")
+
+// ErrNotMergeable is returned by MergePullRequest if Bitbucket Cloud refuses
+// to merge the pull request, for example because of merge conflicts or
+// unsatisfied merge checks.
+var ErrNotMergeable = errors.New("pull request cannot be merged")
+
+// PullRequest is a Bitbucket Cloud pull request.
+type PullRequest struct {
+	ID           int64               `json:"id"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	State        string              `json:"state"`
+	Author       User                `json:"author"`
+	Source       PullRequestEndpoint `json:"source"`
+	Destination  PullRequestEndpoint `json:"destination"`
+	Participants []Participant       `json:"participants"`
+	Links        PullRequestLinks    `json:"links"`
+	CreatedOn    time.Time           `json:"created_on"`
+	UpdatedOn    time.Time           `json:"updated_on"`
+
+	// Statuses are the commit statuses of the source commit. They are not
+	// part of the pull request API response and have to be loaded with
+	// PullRequestStatuses.
+	Statuses []*CommitStatus `json:"statuses,omitempty"`
+}
+
+// PullRequestEndpoint is the source or destination of a pull request.
+type PullRequestEndpoint struct {
+	Branch     PullRequestBranch `json:"branch"`
+	Commit     PullRequestCommit `json:"commit"`
+	Repository PullRequestRepo   `json:"repository"`
+}
+
+type PullRequestBranch struct {
+	Name string `json:"name"`
+}
+
+type PullRequestCommit struct {
+	Hash string `json:"hash"`
+}
+
+type PullRequestRepo struct {
+	FullName string `json:"full_name"`
+	UUID     string `json:"uuid"`
+}
+
+type PullRequestLinks struct {
+	HTML Link `json:"html"`
+}
+
+// Participant is a user who has been added as a reviewer to, or has otherwise
+// interacted with, a pull request.
+type Participant struct {
+	User           User      `json:"user"`
+	Role           string    `json:"role"`
+	Approved       bool      `json:"approved"`
+	State          string    `json:"state"`
+	ParticipatedOn time.Time `json:"participated_on"`
+}
+
+// Key is a unique key identifying this participant's review state in the
+// context of its pull request.
+func (p *Participant) Key() string {
+	return fmt.Sprintf("%s:%s", p.User.UUID, p.State)
+}
+
+// CommitStatus is a build status reported against a commit.
+type CommitStatus struct {
+	StatusKey   string            `json:"key"`
+	Name        string            `json:"name"`
+	State       string            `json:"state"`
+	Description string            `json:"description"`
+	URL         string            `json:"url"`
+	Commit      PullRequestCommit `json:"commit"`
+	CreatedOn   time.Time         `json:"created_on"`
+	UpdatedOn   time.Time         `json:"updated_on"`
+}
+
+// Key is a unique key identifying this status in the context of its pull
+// request. StatusKey only identifies the status within its commit.
+func (s *CommitStatus) Key() string {
+	return fmt.Sprintf("%s:%s", s.Commit.Hash, s.StatusKey)
+}
+
+// PullRequestInput is the input used to create and update pull requests.
+type PullRequestInput struct {
+	Title             string
+	Description       string
+	SourceBranch      string
+	DestinationBranch string
+}
+
+func (in *PullRequestInput) MarshalJSON() ([]byte, error) {
+	type branch struct {
+		Name string `json:"name"`
+	}
+	type endpoint struct {
+		Branch branch `json:"branch"`
+	}
+
+	payload := struct {
+		Title       string    `json:"title"`
+		Description string    `json:"description"`
+		Source      *endpoint `json:"source,omitempty"`
+		Destination *endpoint `json:"destination,omitempty"`
+	}{
+		Title:       in.Title,
+		Description: in.Description,
+	}
+	if in.SourceBranch != "" {
+		payload.Source = &endpoint{Branch: branch{Name: in.SourceBranch}}
+	}
+	if in.DestinationBranch != "" {
+		payload.Destination = &endpoint{Branch: branch{Name: in.DestinationBranch}}
+	}
+	return json.Marshal(payload)
+}
+
+// CreatePullRequest opens a new pull request in the repository with the given
+// full name ("workspace/slug").
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/repositories/%7Bworkspace%7D/%7Brepo_slug%7D/pullrequests#post
+func (c *Client) CreatePullRequest(ctx context.Context, repo string, in *PullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.doJSON(ctx, "POST", pullRequestsPath(repo), in, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// PullRequests returns a page of the pull requests of the repository with the
+// given full name. The query uses the Bitbucket Cloud filter syntax, for
+// example `state = "OPEN"`, and may be empty.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/repositories/%7Bworkspace%7D/%7Brepo_slug%7D/pullrequests#get
+func (c *Client) PullRequests(ctx context.Context, pageToken *PageToken, repo, query string) ([]*PullRequest, *PageToken, error) {
+	var prs []*PullRequest
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &prs)
+	} else {
+		qry := make(url.Values)
+		if query != "" {
+			qry.Set("q", query)
+		}
+		next, err = c.page(ctx, pullRequestsPath(repo), qry, pageToken, &prs)
+	}
+	return prs, next, err
+}
+
+// GetPullRequest returns the pull request with the given ID. If it doesn't
+// exist, ErrPullRequestNotFound is returned.
+func (c *Client) GetPullRequest(ctx context.Context, repo string, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.doJSON(ctx, "GET", pullRequestPath(repo, id, ""), nil, &pr); err != nil {
+		if IsNotFound(err) {
+			return nil, ErrPullRequestNotFound
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequest updates the title, description and destination branch of
+// the pull request with the given ID. The source branch can't be changed.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo string, id int64, in *PullRequestInput) (*PullRequest, error) {
+	update := *in
+	update.SourceBranch = ""
+
+	var pr PullRequest
+	if err := c.doJSON(ctx, "PUT", pullRequestPath(repo, id, ""), &update, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// DeclinePullRequest declines the pull request with the given ID. Declined
+// pull requests can't be reopened on Bitbucket Cloud.
+func (c *Client) DeclinePullRequest(ctx context.Context, repo string, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.doJSON(ctx, "POST", pullRequestPath(repo, id, "/decline"), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges the pull request with the given ID, optionally
+// squashing its commits. If Bitbucket Cloud refuses to merge it, an error
+// wrapping ErrNotMergeable is returned.
+func (c *Client) MergePullRequest(ctx context.Context, repo string, id int64, squash bool) (*PullRequest, error) {
+	strategy := "merge_commit"
+	if squash {
+		strategy = "squash"
+	}
+	payload := struct {
+		MergeStrategy string `json:"merge_strategy"`
+	}{strategy}
+
+	var pr PullRequest
+	if err := c.doJSON(ctx, "POST", pullRequestPath(repo, id, "/merge"), &payload, &pr); err != nil {
+		var e *httpError
+		if errors.As(err, &e) && (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusConflict) {
+			return nil, errors.Wrap(ErrNotMergeable, string(e.Body))
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CreatePullRequestComment posts a comment with the given Markdown text on the
+// pull request with the given ID.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repo string, id int64, text string) error {
+	payload := struct {
+		Content struct {
+			Raw string `json:"raw"`
+		} `json:"content"`
+	}{}
+	payload.Content.Raw = text
+
+	return c.doJSON(ctx, "POST", pullRequestPath(repo, id, "/comments"), &payload, nil)
+}
+
+// PullRequestStatuses returns a page of the commit statuses of the source
+// commit of the pull request with the given ID.
+func (c *Client) PullRequestStatuses(ctx context.Context, pageToken *PageToken, repo string, id int64) ([]*CommitStatus, *PageToken, error) {
+	var statuses []*CommitStatus
+	next, err := c.pageOrNext(ctx, pullRequestPath(repo, id, "/statuses"), pageToken, &statuses)
+	return statuses, next, err
+}
+
+// doJSON sends a request with the given JSON-encoded payload, which may be
+// nil, and decodes the response into result.
+func (c *Client) doJSON(ctx context.Context, method, path string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			return errors.Wrap(err, "encoding payload")
+		}
+	}
+
+	req, err := http.NewRequest(method, path, &body)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, result)
+}
+
+func pullRequestsPath(repo string) string {
+	return fmt.Sprintf("/2.0/repositories/%s/pullrequests", repo)
+}
+
+func pullRequestPath(repo string, id int64, suffix string) string {
+	return pullRequestsPath(repo) + "/" + strconv.FormatInt(id, 10) + suffix
+}
+
+// IsNotFound reports whether err is a Bitbucket Cloud API not found error.
+func IsNotFound(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.NotFound()
+}
+
+// IsUnauthorized reports whether err is a Bitbucket Cloud API unauthorized
+// error.
+func IsUnauthorized(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.Unauthorized()
+}
@@ -0,0 +1,161 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_PullRequests(t *testing.T) {
+	ctx := context.Background()
+
+	type request struct {
+		Method string
+		Path   string
+		Query  string
+		Body   string
+	}
+
+	newClient := func(t *testing.T, status int, response string) (*Client, *[]request) {
+		var reqs []request
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			reqs = append(reqs, request{r.Method, r.URL.Path, r.URL.RawQuery, string(body)})
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, response)
+		}))
+		t.Cleanup(srv.Close)
+
+		u, err := url.Parse(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return NewClient(u, srv.Client()), &reqs
+	}
+
+	t.Run("CreatePullRequest", func(t *testing.T) {
+		cli, reqs := newClient(t, http.StatusCreated, `{"id": 7, "state": "OPEN", "source": {"branch": {"name": "my-branch"}}}`)
+
+		pr, err := cli.CreatePullRequest(ctx, "sglocal/mux", &PullRequestInput{
+			Title:             "Title",
+			Description:       "Body",
+			SourceBranch:      "my-branch",
+			DestinationBranch: "master",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.ID != 7 || pr.State != PullRequestStateOpen || pr.Source.Branch.Name != "my-branch" {
+			t.Fatalf("unexpected pull request: %+v", pr)
+		}
+
+		want := []request{{
+			Method: "POST",
+			Path:   "/2.0/repositories/sglocal/mux/pullrequests",
+			Body:   `{"title":"Title","description":"Body","source":{"branch":{"name":"my-branch"}},"destination":{"branch":{"name":"master"}}}` + "\n",
+		}}
+		if diff := cmp.Diff(want, *reqs); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("UpdatePullRequest ignores source branch", func(t *testing.T) {
+		cli, reqs := newClient(t, http.StatusOK, `{"id": 7}`)
+
+		if _, err := cli.UpdatePullRequest(ctx, "sglocal/mux", 7, &PullRequestInput{
+			Title:             "Title",
+			SourceBranch:      "my-branch",
+			DestinationBranch: "main",
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []request{{
+			Method: "PUT",
+			Path:   "/2.0/repositories/sglocal/mux/pullrequests/7",
+			Body:   `{"title":"Title","description":"","destination":{"branch":{"name":"main"}}}` + "\n",
+		}}
+		if diff := cmp.Diff(want, *reqs); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("PullRequests", func(t *testing.T) {
+		cli, reqs := newClient(t, http.StatusOK, `{"values": [{"id": 1}, {"id": 2}], "pagelen": 10}`)
+
+		prs, next, err := cli.PullRequests(ctx, &PageToken{Pagelen: 10}, "sglocal/mux", `state = "OPEN"`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(prs) != 2 || next.HasMore() {
+			t.Fatalf("unexpected result: %d pull requests, next %+v", len(prs), next)
+		}
+
+		want := url.Values{"pagelen": {"10"}, "q": {`state = "OPEN"`}}.Encode()
+		if have := (*reqs)[0].Query; have != want {
+			t.Fatalf("wrong query: have %q, want %q", have, want)
+		}
+	})
+
+	t.Run("GetPullRequest not found", func(t *testing.T) {
+		cli, _ := newClient(t, http.StatusNotFound, `{"type": "error"}`)
+
+		_, err := cli.GetPullRequest(ctx, "sglocal/mux", 7)
+		if err != ErrPullRequestNotFound {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("MergePullRequest not mergeable", func(t *testing.T) {
+		cli, reqs := newClient(t, http.StatusBadRequest, `{"type": "error", "error": {"message": "conflicts"}}`)
+
+		_, err := cli.MergePullRequest(ctx, "sglocal/mux", 7, true)
+		if !errors.Is(err, ErrNotMergeable) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var body struct {
+			MergeStrategy string `json:"merge_strategy"`
+		}
+		if err := json.Unmarshal([]byte((*reqs)[0].Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		if body.MergeStrategy != "squash" {
+			t.Fatalf("wrong merge strategy %q", body.MergeStrategy)
+		}
+	})
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	payload := []byte(`{
+		"pullrequest": {"id": 3},
+		"repository": {"uuid": "{repo}"},
+		"approval": {"date": "2021-06-01T10:00:00Z", "user": {"uuid": "{user}"}}
+	}`)
+
+	e, err := ParseWebhookEvent("pullrequest:approved", payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	approved, ok := e.(*PullRequestApprovedEvent)
+	if !ok {
+		t.Fatalf("unexpected event type %T", e)
+	}
+	if approved.PullRequest.ID != 3 || approved.Repository.UUID != "{repo}" {
+		t.Fatalf("unexpected event: %+v", approved)
+	}
+	if p := approved.Approval.ToParticipant(ParticipantStateApproved); !p.Approved || p.Key() != "{user}:approved" {
+		t.Fatalf("unexpected participant: %+v", p)
+	}
+
+	if _, err := ParseWebhookEvent("issue:created", payload); !errors.Is(err, ErrUnknownWebhookEventType) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
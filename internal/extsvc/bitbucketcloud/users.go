@@ -0,0 +1,89 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// User is a Bitbucket Cloud user account.
+type User struct {
+	// UUID is the stable identifier of the user, including the surrounding braces,
+	// e.g. "{d301aafa-d676-4ee0-88be-962be7417567}".
+	UUID        string    `json:"uuid"`
+	AccountID   string    `json:"account_id"`
+	Username    string    `json:"username"`
+	Nickname    string    `json:"nickname"`
+	DisplayName string    `json:"display_name"`
+	Links       UserLinks `json:"links"`
+}
+
+type UserLinks struct {
+	Avatar Link `json:"avatar"`
+	HTML   Link `json:"html"`
+}
+
+// UserEmail is an email address associated with a Bitbucket Cloud user account.
+type UserEmail struct {
+	Email       string `json:"email"`
+	IsPrimary   bool   `json:"is_primary"`
+	IsConfirmed bool   `json:"is_confirmed"`
+}
+
+// CurrentUser returns the user the client is authenticated as.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/user
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	req, err := http.NewRequest("GET", "/2.0/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CurrentUserEmails returns a page of email addresses of the user the client is
+// authenticated as. It requires the "email" OAuth scope.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/user/emails
+func (c *Client) CurrentUserEmails(ctx context.Context, pageToken *PageToken) ([]*UserEmail, *PageToken, error) {
+	var emails []*UserEmail
+	next, err := c.pageOrNext(ctx, "/2.0/user/emails", pageToken, &emails)
+	return emails, next, err
+}
+
+// GetExternalAccountData returns the deserialized user and token from the external account data
+// JSON blob in a typesafe way.
+func GetExternalAccountData(data *extsvc.AccountData) (usr *User, tok *oauth2.Token, err error) {
+	var (
+		u User
+		t oauth2.Token
+	)
+
+	if data.Data != nil {
+		if err := data.GetAccountData(&u); err != nil {
+			return nil, nil, err
+		}
+		usr = &u
+	}
+	if data.AuthData != nil {
+		if err := data.GetAuthData(&t); err != nil {
+			return nil, nil, err
+		}
+		tok = &t
+	}
+	return usr, tok, nil
+}
+
+// SetExternalAccountData sets the user and token into the external account data blob.
+func SetExternalAccountData(data *extsvc.AccountData, user *User, token *oauth2.Token) {
+	data.SetAccountData(user)
+	data.SetAuthData(token)
+}
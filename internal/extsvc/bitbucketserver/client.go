@@ -719,6 +719,59 @@ func (c *Client) ReopenPullRequest(ctx context.Context, pr *PullRequest) error {
 	return err
 }
 
+// AddPullRequestReviewer adds the user with the given username as a reviewer of
+// the given PullRequest. Adding an existing reviewer is a no-op.
+func (c *Client) AddPullRequestReviewer(ctx context.Context, pr *PullRequest, username string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/participants",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	payload := struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+		Role string `json:"role"`
+	}{Role: "REVIEWER"}
+	payload.User.Name = username
+
+	_, err := c.send(ctx, "POST", path, nil, payload, nil)
+	return err
+}
+
+// RemovePullRequestReviewer removes the user with the given slug from the
+// reviewers of the given PullRequest.
+func (c *Client) RemovePullRequestReviewer(ctx context.Context, pr *PullRequest, userSlug string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/participants/%s",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+		url.PathEscape(userSlug),
+	)
+
+	_, err := c.send(ctx, "DELETE", path, nil, nil, nil)
+	return err
+}
+
 // LoadPullRequestActivities loads the given PullRequest's timeline of activities,
 // returning an error in case of failure.
 func (c *Client) LoadPullRequestActivities(ctx context.Context, pr *PullRequest) (err error) {
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -168,10 +169,21 @@ type PullRequest struct {
 	TimelineItems []TimelineItem
 	Commits       struct{ Nodes []CommitWithChecks }
 	IsDraft       bool
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// Mergeable is one of MERGEABLE, CONFLICTING or UNKNOWN. GitHub computes
+	// it lazily, so it is UNKNOWN until the pull request has been looked at
+	// after its base branch changed.
+	Mergeable string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
+// Values of PullRequest.Mergeable.
+const (
+	PullRequestMergeableStateMergeable   = "MERGEABLE"
+	PullRequestMergeableStateConflicting = "CONFLICTING"
+	PullRequestMergeableStateUnknown     = "UNKNOWN"
+)
+
 // AssignedEvent represents an 'assigned' event on a PullRequest.
 type AssignedEvent struct {
 	Actor     Actor
@@ -1004,6 +1016,285 @@ func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash
 	return nil
 }
 
+// UpdatePullRequestMetadataInput describes the reviewers, labels, assignees
+// and milestone to add to or remove from a pull request. Reviewers and
+// assignees are user logins, team reviewers are the slugs of teams in the
+// organization owning the repository, labels are label names and the
+// milestone is the title of an open milestone.
+type UpdatePullRequestMetadataInput struct {
+	AddReviewers        []string
+	RemoveReviewers     []string
+	AddTeamReviewers    []string
+	RemoveTeamReviewers []string
+	AddLabels           []string
+	RemoveLabels        []string
+	AddAssignees        []string
+	RemoveAssignees     []string
+
+	// Milestone is the title of the milestone to set. If it is empty and
+	// RemoveMilestone is true, the milestone is cleared.
+	Milestone       string
+	RemoveMilestone bool
+}
+
+// pullRequestMetadataIDs are the node IDs required to apply an
+// UpdatePullRequestMetadataInput.
+type pullRequestMetadataIDs struct {
+	// Users and Teams map logins and slugs to node IDs.
+	Users  map[string]string
+	Teams  map[string]string
+	Labels map[string]string
+
+	// RequestedUsers and RequestedTeams are the node IDs of the users and
+	// teams currently requested to review the pull request.
+	RequestedUsers map[string]string
+	RequestedTeams map[string]string
+
+	MilestoneID string
+}
+
+// UpdatePullRequestMetadata adds and removes the reviewers, labels and
+// assignees of the given PullRequest and sets its milestone. The PullRequest
+// must have its RepoWithOwner set.
+func (c *V4Client) UpdatePullRequestMetadata(ctx context.Context, pr *PullRequest, in *UpdatePullRequestMetadataInput) error {
+	ids, err := c.loadPullRequestMetadataIDs(ctx, pr, in)
+	if err != nil {
+		return err
+	}
+
+	type mutation struct {
+		name  string
+		input interface{}
+	}
+	var mutations []mutation
+
+	if labelIDs := lookupIDs(ids.Labels, in.AddLabels); len(labelIDs) > 0 {
+		mutations = append(mutations, mutation{"addLabelsToLabelable", map[string]interface{}{"labelableId": pr.ID, "labelIds": labelIDs}})
+	}
+	if labelIDs := lookupIDs(ids.Labels, in.RemoveLabels); len(labelIDs) > 0 {
+		mutations = append(mutations, mutation{"removeLabelsFromLabelable", map[string]interface{}{"labelableId": pr.ID, "labelIds": labelIDs}})
+	}
+	if userIDs := lookupIDs(ids.Users, in.AddAssignees); len(userIDs) > 0 {
+		mutations = append(mutations, mutation{"addAssigneesToAssignable", map[string]interface{}{"assignableId": pr.ID, "assigneeIds": userIDs}})
+	}
+	if userIDs := lookupIDs(ids.Users, in.RemoveAssignees); len(userIDs) > 0 {
+		mutations = append(mutations, mutation{"removeAssigneesFromAssignable", map[string]interface{}{"assignableId": pr.ID, "assigneeIds": userIDs}})
+	}
+
+	// GitHub has no mutation to remove a review request, so we replace the
+	// requested reviewers with the current ones minus the removed ones plus
+	// the added ones.
+	if len(in.AddReviewers)+len(in.RemoveReviewers)+len(in.AddTeamReviewers)+len(in.RemoveTeamReviewers) > 0 {
+		mutations = append(mutations, mutation{"requestReviews", map[string]interface{}{
+			"pullRequestId": pr.ID,
+			"userIds":       replaceIDs(ids.RequestedUsers, ids.Users, in.AddReviewers, in.RemoveReviewers),
+			"teamIds":       replaceIDs(ids.RequestedTeams, ids.Teams, in.AddTeamReviewers, in.RemoveTeamReviewers),
+			"union":         false,
+		}})
+	}
+
+	if in.Milestone != "" || in.RemoveMilestone {
+		var milestoneID *string
+		if in.Milestone != "" {
+			milestoneID = &ids.MilestoneID
+		}
+		mutations = append(mutations, mutation{"updatePullRequest", map[string]interface{}{"pullRequestId": pr.ID, "milestoneId": milestoneID}})
+	}
+
+	for _, m := range mutations {
+		// The input type of each mutation is named after the mutation.
+		inputType := strings.ToUpper(m.name[:1]) + m.name[1:] + "Input"
+		q := fmt.Sprintf("mutation($input: %s!) {\n  %s(input: $input) { clientMutationId }\n}", inputType, m.name)
+
+		var result json.RawMessage
+		if err := c.requestGraphQL(ctx, q, map[string]interface{}{"input": m.input}, &result); err != nil {
+			return errors.Wrapf(err, "running %s mutation", m.name)
+		}
+	}
+
+	return nil
+}
+
+// loadPullRequestMetadataIDs resolves the node IDs of all users, teams,
+// labels and the milestone referenced by the given input in a single query.
+func (c *V4Client) loadPullRequestMetadataIDs(ctx context.Context, pr *PullRequest, in *UpdatePullRequestMetadataInput) (*pullRequestMetadataIDs, error) {
+	owner, repo, err := SplitRepositoryNameWithOwner(pr.RepoWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	users := dedupeStrings(in.AddReviewers, in.RemoveReviewers, in.AddAssignees, in.RemoveAssignees)
+	teams := dedupeStrings(in.AddTeamReviewers, in.RemoveTeamReviewers)
+	labels := dedupeStrings(in.AddLabels, in.RemoveLabels)
+
+	var q strings.Builder
+	q.WriteString("query($owner: String!, $name: String!, $number: Int!) {\n")
+	q.WriteString("repository(owner: $owner, name: $name) {\n")
+	q.WriteString("pullRequest(number: $number) { reviewRequests(first: 100) { nodes { requestedReviewer { ... on User { id login } ... on Team { id slug } } } } }\n")
+	for i, label := range labels {
+		q.WriteString(fmt.Sprintf("label%d: label(name: %q) { id }\n", i, label))
+	}
+	if in.Milestone != "" {
+		q.WriteString("milestones(first: 100, states: [OPEN]) { nodes { id title } }\n")
+	}
+	q.WriteString("}\n")
+	for i, user := range users {
+		q.WriteString(fmt.Sprintf("user%d: user(login: %q) { id }\n", i, user))
+	}
+	if len(teams) > 0 {
+		q.WriteString("organization(login: $owner) {\n")
+		for i, team := range teams {
+			q.WriteString(fmt.Sprintf("team%d: team(slug: %q) { id }\n", i, team))
+		}
+		q.WriteString("}\n")
+	}
+	q.WriteString("}")
+
+	type node struct{ ID string }
+	var result map[string]json.RawMessage
+	if err := c.requestGraphQL(ctx, q.String(), map[string]interface{}{"owner": owner, "name": repo, "number": pr.Number}, &result); err != nil {
+		return nil, err
+	}
+
+	var repository map[string]json.RawMessage
+	if err := json.Unmarshal(result["repository"], &repository); err != nil {
+		return nil, errors.Wrap(err, "decoding repository")
+	}
+
+	ids := &pullRequestMetadataIDs{
+		Users:          map[string]string{},
+		Teams:          map[string]string{},
+		Labels:         map[string]string{},
+		RequestedUsers: map[string]string{},
+		RequestedTeams: map[string]string{},
+	}
+
+	var pullRequest struct {
+		ReviewRequests struct {
+			Nodes []struct {
+				RequestedReviewer struct {
+					ID    string
+					Login string
+					Slug  string
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(repository["pullRequest"], &pullRequest); err != nil {
+		return nil, errors.Wrap(err, "decoding pull request")
+	}
+	for _, request := range pullRequest.ReviewRequests.Nodes {
+		if reviewer := request.RequestedReviewer; reviewer.Login != "" {
+			ids.RequestedUsers[reviewer.Login] = reviewer.ID
+		} else if reviewer.Slug != "" {
+			ids.RequestedTeams[reviewer.Slug] = reviewer.ID
+		}
+	}
+
+	decodeID := func(raw json.RawMessage, kind, name string) (string, error) {
+		var n *node
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return "", errors.Wrapf(err, "decoding %s %q", kind, name)
+		}
+		if n == nil {
+			return "", errors.Errorf("%s %q not found", kind, name)
+		}
+		return n.ID, nil
+	}
+
+	for i, label := range labels {
+		if ids.Labels[label], err = decodeID(repository[fmt.Sprintf("label%d", i)], "label", label); err != nil {
+			return nil, err
+		}
+	}
+	for i, user := range users {
+		if ids.Users[user], err = decodeID(result[fmt.Sprintf("user%d", i)], "user", user); err != nil {
+			return nil, err
+		}
+	}
+	if len(teams) > 0 {
+		var organization map[string]json.RawMessage
+		if err := json.Unmarshal(result["organization"], &organization); err != nil {
+			return nil, errors.Wrap(err, "decoding organization")
+		}
+		for i, team := range teams {
+			if ids.Teams[team], err = decodeID(organization[fmt.Sprintf("team%d", i)], "team", team); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if in.Milestone != "" {
+		var milestones struct{ Nodes []struct{ ID, Title string } }
+		if err := json.Unmarshal(repository["milestones"], &milestones); err != nil {
+			return nil, errors.Wrap(err, "decoding milestones")
+		}
+		for _, milestone := range milestones.Nodes {
+			if milestone.Title == in.Milestone {
+				ids.MilestoneID = milestone.ID
+			}
+		}
+		if ids.MilestoneID == "" {
+			return nil, errors.Errorf("open milestone %q not found", in.Milestone)
+		}
+	}
+
+	return ids, nil
+}
+
+// lookupIDs returns the IDs of the given names.
+func lookupIDs(ids map[string]string, names []string) []string {
+	var result []string
+	for _, name := range names {
+		if id, ok := ids[name]; ok {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// replaceIDs returns the IDs of the current names, without the removed names
+// and with the added names.
+func replaceIDs(current, ids map[string]string, add, remove []string) []string {
+	removed := make(map[string]struct{}, len(remove))
+	for _, name := range remove {
+		removed[name] = struct{}{}
+	}
+
+	result := []string{}
+	seen := map[string]struct{}{}
+	for name, id := range current {
+		if _, ok := removed[name]; !ok {
+			result = append(result, id)
+			seen[id] = struct{}{}
+		}
+	}
+	for _, id := range lookupIDs(ids, add) {
+		if _, ok := seen[id]; !ok {
+			result = append(result, id)
+			seen[id] = struct{}{}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// dedupeStrings returns the distinct strings of the given lists, in order of
+// first appearance.
+func dedupeStrings(lists ...[]string) []string {
+	var result []string
+	seen := map[string]struct{}{}
+	for _, list := range lists {
+		for _, s := range list {
+			if _, ok := seen[s]; !ok {
+				result = append(result, s)
+				seen[s] = struct{}{}
+			}
+		}
+	}
+	return result
+}
+
 func (c *V4Client) loadRemainingTimelineItems(ctx context.Context, prID string, pageInfo PageInfo) (items []TimelineItem, err error) {
 	version := c.determineGitHubVersion(ctx)
 	timelineItemTypes, err := timelineItemTypes(version)
@@ -1382,6 +1673,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
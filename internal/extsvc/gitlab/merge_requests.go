@@ -42,6 +42,10 @@ type MergeRequest struct {
 	WebURL         string            `json:"web_url"`
 	WorkInProgress bool              `json:"work_in_progress"`
 	Author         User              `json:"author"`
+	Assignees      []User            `json:"assignees"`
+	Reviewers      []User            `json:"reviewers"`
+	Milestone      *Milestone        `json:"milestone"`
+	HasConflicts   bool              `json:"has_conflicts"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -241,6 +245,46 @@ func (c *Client) UpdateMergeRequest(ctx context.Context, project *Project, mr *M
 	return resp, nil
 }
 
+// UpdateMergeRequestMetadataOpts are the options to update the labels,
+// assignees, reviewers and milestone of a merge request. Unset fields are left
+// unchanged.
+type UpdateMergeRequestMetadataOpts struct {
+	// AddLabels and RemoveLabels are comma-separated lists of label names.
+	AddLabels    string `json:"add_labels,omitempty"`
+	RemoveLabels string `json:"remove_labels,omitempty"`
+	// AssigneeIDs and ReviewerIDs replace the assignees and reviewers of the
+	// merge request. An empty, non-nil slice removes all of them.
+	AssigneeIDs *[]int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs *[]int32 `json:"reviewer_ids,omitempty"`
+	// MilestoneID sets the milestone of the merge request. Zero unsets it.
+	MilestoneID *ID `json:"milestone_id,omitempty"`
+}
+
+func (c *Client) UpdateMergeRequestMetadata(ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestMetadataOpts) (*MergeRequest, error) {
+	if MockUpdateMergeRequestMetadata != nil {
+		return MockUpdateMergeRequestMetadata(c, ctx, project, mr, opts)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to update the metadata of a merge request")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to update the metadata of a merge request")
+	}
+
+	return resp, nil
+}
+
 // ErrNotMergeable is returned by MergeMergeRequest when the merge request cannot
 // be merged, because a precondition isn't met.
 var ErrNotMergeable = errors.New("merge request is not in a mergeable state")
@@ -0,0 +1,38 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+)
+
+type Milestone struct {
+	ID    ID     `json:"id"`
+	IID   ID     `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// ListProjectMilestones returns the active milestones of the given project
+// whose title is exactly the given title.
+func (c *Client) ListProjectMilestones(ctx context.Context, project *Project, title string) ([]*Milestone, error) {
+	if MockListProjectMilestones != nil {
+		return MockListProjectMilestones(c, ctx, project, title)
+	}
+
+	q := url.Values{"title": []string{title}, "state": []string{"active"}}
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/milestones?%s", project.ID, q.Encode()), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to list project milestones")
+	}
+
+	var milestones []*Milestone
+	if _, _, err := c.do(ctx, req, &milestones); err != nil {
+		return nil, errors.Wrap(err, "sending request to list project milestones")
+	}
+
+	return milestones, nil
+}
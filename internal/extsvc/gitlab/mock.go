@@ -45,6 +45,10 @@ var MockGetOpenMergeRequestByRefs func(c *Client, ctx context.Context, project *
 // Client.UpdateMergeRequest
 var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestOpts) (*MergeRequest, error)
 
+// MockUpdateMergeRequestMetadata, if non-nil, will be called instead of
+// Client.UpdateMergeRequestMetadata
+var MockUpdateMergeRequestMetadata func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestMetadataOpts) (*MergeRequest, error)
+
 // MockMergeMergeRequest, if non-nil, will be called instead of
 // Client.MergeMergeRequest
 var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error)
@@ -52,3 +56,7 @@ var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project,
 // MockCreateMergeRequestNote, if non-nil, will be called instead of
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
+
+// MockListProjectMilestones, if non-nil, will be called instead of
+// Client.ListProjectMilestones
+var MockListProjectMilestones func(c *Client, ctx context.Context, project *Project, title string) ([]*Milestone, error)
@@ -152,10 +152,25 @@ type ChangesetSyncResponse struct {
 	Error string
 }
 
+// PermsSyncReason is the reason why a permissions sync is requested, it
+// determines the priority of the sync.
+type PermsSyncReason string
+
+const (
+	// PermsSyncReasonManual is used when the sync is requested by a user action,
+	// e.g. a site admin scheduling a sync.
+	PermsSyncReasonManual PermsSyncReason = "manual"
+	// PermsSyncReasonWebhook is used when the sync is requested by a webhook
+	// event received from a code host.
+	PermsSyncReasonWebhook PermsSyncReason = "webhook"
+)
+
 // PermsSyncRequest is a request to sync permissions.
 type PermsSyncRequest struct {
 	UserIDs []int32      `json:"user_ids"`
 	RepoIDs []api.RepoID `json:"repo_ids"`
+	// Reason defaults to PermsSyncReasonManual when empty.
+	Reason PermsSyncReason `json:"reason,omitempty"`
 }
 
 // PermsSyncResponse is a response to sync permissions.
@@ -0,0 +1,156 @@
+package diagnostic
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// SearchOptions restricts the diagnostics returned by SearchFunc. Patterns are POSIX regular
+// expressions.
+type SearchOptions struct {
+	RepositoryIDs []int
+	// Severity is the LSP severity of the returned diagnostics, if non-zero.
+	Severity                      int
+	Source                        string
+	Code                          string
+	PathPatterns                  []string
+	ExcludePathPattern            string
+	PathPatternsAreCaseSensitive  bool
+	MessagePattern                string
+	MessagePatternIsCaseSensitive bool
+	Limit                         int
+}
+
+// FileDiagnostic is a diagnostic of a file at the indexed commit of a repository.
+type FileDiagnostic struct {
+	Repo   types.RepoName
+	Commit api.CommitID
+	Path   string
+	result.Diagnostic
+}
+
+// SearchFunc returns the indexed precise code intelligence diagnostics matching the given
+// options, ordered by repository name and path. It is set by the enterprise frontend; a nil
+// value indicates that diagnostics are not available on this instance.
+var SearchFunc func(ctx context.Context, opts SearchOptions) ([]FileDiagnostic, error)
+
+var errUnavailable = errors.New("type:diagnostic searches require precise code intelligence, which is not available on this instance")
+
+// Search streams the diagnostics of the searched repositories whose message matches the
+// search pattern. The severity:, source: and code: fields of the query restrict the diagnostics
+// in the same way as the corresponding arguments of the codeIntelligenceDiagnostics GraphQL
+// query. Diagnostics are reported at the most recent indexed commit of each
+// repository's default branch; revision specifiers are ignored.
+func Search(ctx context.Context, args *search.TextParameters, limit int, stream streaming.Sender) (err error) {
+	if SearchFunc == nil {
+		return errUnavailable
+	}
+
+	repos, err := args.RepoPromise.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	tr, ctx := trace.New(ctx, "Search diagnostics", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	repositoryIDs := make([]int, 0, len(repos))
+	for _, repo := range repos {
+		repositoryIDs = append(repositoryIDs, int(repo.Repo.ID))
+	}
+	if len(repositoryIDs) == 0 {
+		return nil
+	}
+
+	opts, err := toSearchOptions(args.PatternInfo, args.Query, repositoryIDs, limit)
+	if err != nil {
+		return err
+	}
+	diagnostics, err := SearchFunc(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	limitHit := len(diagnostics) > limit
+	if limitHit {
+		diagnostics = diagnostics[:limit]
+	}
+
+	stream.Send(streaming.SearchEvent{
+		Results: groupByFile(diagnostics),
+		Stats:   streaming.Stats{IsLimitHit: limitHit},
+	})
+	return nil
+}
+
+// toSearchOptions converts the given pattern and diagnostic fields of the given query into search
+// options. One more diagnostic than the limit is requested so that the caller can tell whether the
+// limit was hit.
+func toSearchOptions(p *search.TextPatternInfo, q query.Q, repositoryIDs []int, limit int) (SearchOptions, error) {
+	severityName, _ := q.StringValue(query.FieldSeverity)
+	source, _ := q.StringValue(query.FieldSource)
+	code, _ := q.StringValue(query.FieldCode)
+
+	var severity int
+	if severityName != "" {
+		var ok bool
+		if severity, ok = result.DiagnosticSeverityFromName(severityName); !ok {
+			return SearchOptions{}, errors.Errorf("unknown diagnostic severity %q", severityName)
+		}
+	}
+
+	messagePattern := p.Pattern
+	if !p.IsRegExp {
+		messagePattern = regexp.QuoteMeta(messagePattern)
+	}
+
+	return SearchOptions{
+		RepositoryIDs:                 repositoryIDs,
+		Severity:                      severity,
+		Source:                        source,
+		Code:                          code,
+		PathPatterns:                  p.IncludePatterns,
+		ExcludePathPattern:            p.ExcludePattern,
+		PathPatternsAreCaseSensitive:  p.PathPatternsAreCaseSensitive,
+		MessagePattern:                messagePattern,
+		MessagePatternIsCaseSensitive: p.IsCaseSensitive,
+		Limit:                         limit + 1,
+	}, nil
+}
+
+// groupByFile converts the given diagnostics into one match per file. The given diagnostics
+// must be ordered such that diagnostics of the same file are adjacent.
+func groupByFile(diagnostics []FileDiagnostic) []result.Match {
+	var matches []result.Match
+	var current *result.DiagnosticMatch
+
+	for _, d := range diagnostics {
+		if current == nil || current.Repo.ID != d.Repo.ID || current.CommitID != d.Commit || current.Path != d.Path {
+			current = &result.DiagnosticMatch{
+				File: result.File{
+					Repo:     d.Repo,
+					CommitID: d.Commit,
+					Path:     d.Path,
+				},
+			}
+			matches = append(matches, current)
+		}
+
+		current.Diagnostics = append(current.Diagnostics, d.Diagnostic)
+	}
+
+	return matches
+}
@@ -0,0 +1,94 @@
+package diagnostic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearch(t *testing.T) {
+	repoA := types.RepoName{ID: 1, Name: "a"}
+	repoB := types.RepoName{ID: 2, Name: "b"}
+
+	var opts SearchOptions
+	SearchFunc = func(ctx context.Context, o SearchOptions) ([]FileDiagnostic, error) {
+		opts = o
+		return []FileDiagnostic{
+			{Repo: repoA, Commit: "c1", Path: "x.go", Diagnostic: result.Diagnostic{Message: "m1"}},
+			{Repo: repoA, Commit: "c1", Path: "x.go", Diagnostic: result.Diagnostic{Message: "m2"}},
+			{Repo: repoB, Commit: "c2", Path: "x.go", Diagnostic: result.Diagnostic{Message: "m3"}},
+			{Repo: repoB, Commit: "c2", Path: "y.go", Diagnostic: result.Diagnostic{Message: "m4"}},
+		}, nil
+	}
+	defer func() { SearchFunc = nil }()
+
+	q, err := query.ParseLiteral("type:diagnostic severity:Warning source:staticcheck code:SA1019 foo.bar")
+	if err != nil {
+		t.Fatalf("unexpected error parsing query: %s", err)
+	}
+
+	args := &search.TextParameters{
+		PatternInfo: &search.TextPatternInfo{
+			Pattern:         "foo.bar",
+			IncludePatterns: []string{`\.go$`},
+			ExcludePattern:  `_test\.go$`,
+		},
+		RepoPromise: (&search.RepoPromise{}).Resolve([]*search.RepositoryRevisions{{Repo: repoA}, {Repo: repoB}}),
+		Query:       q,
+	}
+
+	var events []streaming.SearchEvent
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) { events = append(events, event) })
+	if err := Search(context.Background(), args, 3, stream); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedOpts := SearchOptions{
+		RepositoryIDs:      []int{1, 2},
+		Severity:           2,
+		Source:             "staticcheck",
+		Code:               "SA1019",
+		PathPatterns:       []string{`\.go$`},
+		ExcludePathPattern: `_test\.go$`,
+		MessagePattern:     `foo\.bar`,
+		Limit:              4,
+	}
+	if diff := cmp.Diff(expectedOpts, opts); diff != "" {
+		t.Errorf("unexpected options (-want +got):\n%s", diff)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("unexpected number of events. want=%d have=%d", 1, len(events))
+	}
+	if !events[0].Stats.IsLimitHit {
+		t.Errorf("expected limit to be hit")
+	}
+
+	expectedMatches := []result.Match{
+		&result.DiagnosticMatch{
+			File:        result.File{Repo: repoA, CommitID: "c1", Path: "x.go"},
+			Diagnostics: []result.Diagnostic{{Message: "m1"}, {Message: "m2"}},
+		},
+		&result.DiagnosticMatch{
+			File:        result.File{Repo: repoB, CommitID: "c2", Path: "x.go"},
+			Diagnostics: []result.Diagnostic{{Message: "m3"}},
+		},
+	}
+	if diff := cmp.Diff(expectedMatches, events[0].Results); diff != "" {
+		t.Errorf("unexpected matches (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchUnavailable(t *testing.T) {
+	args := &search.TextParameters{PatternInfo: &search.TextPatternInfo{}}
+	if err := Search(context.Background(), args, 10, streaming.StreamFunc(func(streaming.SearchEvent) {})); err != errUnavailable {
+		t.Errorf("unexpected error. want=%v have=%v", errUnavailable, err)
+	}
+}
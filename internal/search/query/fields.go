@@ -28,6 +28,11 @@ const (
 	FieldCommitter = "committer"
 	FieldMessage   = "message"
 
+	// For diagnostic search only:
+	FieldSeverity = "severity"
+	FieldSource   = "source"
+	FieldCode     = "code"
+
 	// Temporary experimental fields:
 	FieldIndex     = "index"
 	FieldCount     = "count" // Searches that specify `count:` will fetch at least that number of results, or the full result set
@@ -65,6 +70,9 @@ var allFields = map[string]struct{}{
 	FieldMessage:            empty,
 	"m":                     empty,
 	"msg":                   empty,
+	FieldSeverity:           empty,
+	FieldSource:             empty,
+	FieldCode:               empty,
 	FieldIndex:              empty,
 	FieldCount:              empty,
 	FieldTimeout:            empty,
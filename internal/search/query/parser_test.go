@@ -664,6 +664,7 @@ func TestParseAndOrLiteral(t *testing.T) {
 	autogold.Want(`\d`, `"\\d" (Literal)`).Equal(t, test(`\d`))
 	autogold.Want(`type:commit message:"a commit message" after:"10 days ago"`, `(and "type:commit" "message:a commit message" "after:10 days ago") (Quoted)`).Equal(t, test(`type:commit message:"a commit message" after:"10 days ago"`))
 	autogold.Want(`type:commit message:"a commit message" after:"10 days ago" test test2`, `(and "type:commit" "message:a commit message" "after:10 days ago" (concat "test" "test2")) (Literal,Quoted)`).Equal(t, test(`type:commit message:"a commit message" after:"10 days ago" test test2`))
+	autogold.Want(`type:diagnostic severity:error source:staticcheck code:SA1019 deprecated`, `(and "type:diagnostic" "severity:error" "source:staticcheck" "code:SA1019" "deprecated") (Literal)`).Equal(t, test(`type:diagnostic severity:error source:staticcheck code:SA1019 deprecated`))
 	autogold.Want(`type:commit message:"a com"mit message" after:"10 days ago"`, `(and "type:commit" "message:a com" "after:10 days ago" (concat "mit" "message\"")) (Literal,Quoted)`).Equal(t, test(`type:commit message:"a com"mit message" after:"10 days ago"`))
 	autogold.Want(`bar and (foo or x\) ()`, `(or (and "bar" "(foo") (concat "x\\)" "()")) (HeuristicDanglingParens,HeuristicHoisted,Literal)`).Equal(t, test(`bar and (foo or x\) ()`))
 
@@ -256,6 +256,13 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return nil
 	}
 
+	isDiagnosticSeverity := func() error {
+		if _, ok := diagnosticSeverities[strings.ToLower(value)]; !ok {
+			return errors.Errorf("invalid value %q for field %q. Valid values are: error, warning, information, hint", value, field)
+		}
+		return nil
+	}
+
 	isUnrecognizedField := func() error {
 		return errors.Errorf("unrecognized field %q", field)
 	}
@@ -317,6 +324,13 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldCommitter,
 		FieldMessage:
 		return satisfies(isValidRegexp)
+	case
+		FieldSeverity:
+		return satisfies(isSingular, isNotNegated, isDiagnosticSeverity)
+	case
+		FieldSource,
+		FieldCode:
+		return satisfies(isSingular, isNotNegated)
 	case
 		FieldIndex,
 		FieldFork,
@@ -397,6 +411,32 @@ func validateCommitParameters(nodes []Node) error {
 	return nil
 }
 
+// diagnosticSeverities are the valid values of the severity: field.
+var diagnosticSeverities = map[string]struct{}{
+	"error":       empty,
+	"warning":     empty,
+	"information": empty,
+	"hint":        empty,
+}
+
+// Queries containing diagnostic parameters without type:diagnostic are not valid.
+func validateDiagnosticParameters(nodes []Node) error {
+	var seenDiagnosticParam string
+	var typeDiagnosticExists bool
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		if field == FieldSeverity || field == FieldSource || field == FieldCode {
+			seenDiagnosticParam = field
+		}
+		if field == FieldType && value == "diagnostic" {
+			typeDiagnosticExists = true
+		}
+	})
+	if seenDiagnosticParam != "" && !typeDiagnosticExists {
+		return errors.Errorf(`your query contains the field '%s', which requires type:diagnostic in the query`, seenDiagnosticParam)
+	}
+	return nil
+}
+
 func validateTypeStructural(nodes []Node) error {
 	seenStructural := false
 	seenType := false
@@ -530,6 +570,7 @@ func validate(nodes []Node) error {
 		validateRepoRevPair,
 		validateRepoHasFile,
 		validateCommitParameters,
+		validateDiagnosticParameters,
 		validatePredicates,
 		validateTypeStructural,
 	)
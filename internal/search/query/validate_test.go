@@ -83,6 +83,22 @@ func TestAndOrQuery_Validation(t *testing.T) {
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "severity:error deprecated",
+			want:  `your query contains the field 'severity', which requires type:diagnostic in the query`,
+		},
+		{
+			input: "type:diagnostic severity:fatal",
+			want:  `invalid value "fatal" for field "severity". Valid values are: error, warning, information, hint`,
+		},
+		{
+			input: "type:diagnostic -source:staticcheck",
+			want:  `field "source" does not support negation`,
+		},
+		{
+			input: "type:diagnostic code:SA1019 code:SA1000",
+			want:  `field "code" may not be used more than once`,
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
@@ -0,0 +1,115 @@
+package result
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Diagnostic is a single compiler or linter diagnostic reported for a file by a precise code
+// intelligence upload. Line and character offsets are zero-based.
+type Diagnostic struct {
+	// Severity is the LSP diagnostic severity (1 = error, 2 = warning, 3 = information, 4 = hint).
+	Severity       int
+	Code           string
+	Source         string
+	Message        string
+	StartLine      int
+	StartCharacter int
+	EndLine        int
+	EndCharacter   int
+}
+
+var diagnosticSeverityNames = map[int]string{
+	1: "ERROR",
+	2: "WARNING",
+	3: "INFORMATION",
+	4: "HINT",
+}
+
+// SeverityName returns the name of the diagnostic's severity as used by the GraphQL API, or
+// false if the severity is unknown.
+func (d Diagnostic) SeverityName() (string, bool) {
+	name, ok := diagnosticSeverityNames[d.Severity]
+	return name, ok
+}
+
+// DiagnosticSeverityFromName returns the LSP severity with the given case-insensitive name, or
+// false if the name is unknown.
+func DiagnosticSeverityFromName(name string) (int, bool) {
+	for severity, severityName := range diagnosticSeverityNames {
+		if strings.EqualFold(severityName, name) {
+			return severity, true
+		}
+	}
+	return 0, false
+}
+
+// DiagnosticMatch is the set of diagnostics of a single file matching a type:diagnostic
+// search. The commit is the indexed commit visible from the tip of the repository's
+// default branch.
+type DiagnosticMatch struct {
+	File
+
+	Diagnostics []Diagnostic
+
+	LimitHit bool
+}
+
+func (dm *DiagnosticMatch) RepoName() types.RepoName {
+	return dm.File.Repo
+}
+
+func (dm *DiagnosticMatch) searchResultMarker() {}
+
+func (dm *DiagnosticMatch) ResultCount() int {
+	return len(dm.Diagnostics)
+}
+
+func (dm *DiagnosticMatch) Select(selectPath filter.SelectPath) Match {
+	switch selectPath.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: dm.Repo.Name,
+			ID:   dm.Repo.ID,
+		}
+	case filter.File:
+		return &FileMatch{File: dm.File}
+	}
+	return nil
+}
+
+// Limit will mutate dm such that it only has limit results. limit is a number
+// greater than 0.
+func (dm *DiagnosticMatch) Limit(limit int) int {
+	if after := limit - dm.ResultCount(); after >= 0 {
+		return after
+	}
+
+	dm.Diagnostics = dm.Diagnostics[:limit]
+	dm.LimitHit = true
+	return 0
+}
+
+// DiagnosticURL returns the URL of the location of the given diagnostic.
+func (dm *DiagnosticMatch) DiagnosticURL(d Diagnostic) *url.URL {
+	base := dm.File.URL()
+	base.Fragment = urlFragmentFromRange(lsp.Range{
+		Start: lsp.Position{Line: d.StartLine, Character: d.StartCharacter},
+		End:   lsp.Position{Line: d.EndLine, Character: d.EndCharacter},
+	})
+	return base
+}
+
+func (dm *DiagnosticMatch) Key() Key {
+	return Key{
+		TypeRank: rankDiagnosticMatch,
+		Repo:     dm.Repo.Name,
+		Commit:   dm.CommitID,
+		Path:     dm.Path,
+	}
+}
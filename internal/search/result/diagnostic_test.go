@@ -0,0 +1,71 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDiagnosticMatch(t *testing.T) {
+	newMatch := func() *DiagnosticMatch {
+		return &DiagnosticMatch{
+			File: File{Repo: types.RepoName{ID: 1, Name: "r"}, CommitID: "c", Path: "a.go"},
+			Diagnostics: []Diagnostic{
+				{Severity: 1, StartLine: 9, StartCharacter: 4, EndLine: 9, EndCharacter: 7},
+				{Severity: 2, StartLine: 20, EndLine: 20},
+				{Severity: 3},
+			},
+		}
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		dm := newMatch()
+		if after := dm.Limit(5); after != 2 || dm.ResultCount() != 3 || dm.LimitHit {
+			t.Errorf("unexpected limit result. after=%d resultCount=%d limitHit=%v", after, dm.ResultCount(), dm.LimitHit)
+		}
+		if after := dm.Limit(2); after != 0 || dm.ResultCount() != 2 || !dm.LimitHit {
+			t.Errorf("unexpected limit result. after=%d resultCount=%d limitHit=%v", after, dm.ResultCount(), dm.LimitHit)
+		}
+	})
+
+	t.Run("url", func(t *testing.T) {
+		dm := newMatch()
+		if url := dm.DiagnosticURL(dm.Diagnostics[0]).String(); url != "/r/-/blob/a.go#L10:5-10:8" {
+			t.Errorf("unexpected url. have=%s", url)
+		}
+		if url := dm.DiagnosticURL(dm.Diagnostics[1]).String(); url != "/r/-/blob/a.go#L21" {
+			t.Errorf("unexpected url. have=%s", url)
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		if _, ok := newMatch().Select(filter.SelectPath{filter.Repository}).(*RepoMatch); !ok {
+			t.Errorf("expected repo match")
+		}
+		if fm, ok := newMatch().Select(filter.SelectPath{filter.File}).(*FileMatch); !ok || fm.Path != "a.go" {
+			t.Errorf("expected file match")
+		}
+		if m := newMatch().Select(filter.SelectPath{filter.Content}); m != nil {
+			t.Errorf("unexpected match for content selection: %v", m)
+		}
+	})
+
+	t.Run("severity name", func(t *testing.T) {
+		if name, ok := (Diagnostic{Severity: 2}).SeverityName(); !ok || name != "WARNING" {
+			t.Errorf("unexpected severity name. want=%s have=%s", "WARNING", name)
+		}
+		if _, ok := (Diagnostic{Severity: 7}).SeverityName(); ok {
+			t.Errorf("expected unknown severity")
+		}
+	})
+
+	t.Run("severity from name", func(t *testing.T) {
+		if severity, ok := DiagnosticSeverityFromName("hint"); !ok || severity != 4 {
+			t.Errorf("unexpected severity. want=%d have=%d", 4, severity)
+		}
+		if _, ok := DiagnosticSeverityFromName("fatal"); ok {
+			t.Errorf("expected unknown severity name")
+		}
+	})
+}
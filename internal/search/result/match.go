@@ -6,7 +6,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-// Match is *FileMatch | *RepoMatch | *CommitMatch. We have a private method
+// Match is *FileMatch | *RepoMatch | *CommitMatch | *DiagnosticMatch. We have a private method
 // to ensure only those types implement Match.
 type Match interface {
 	ResultCount() int
@@ -26,16 +26,18 @@ var (
 	_ Match = (*FileMatch)(nil)
 	_ Match = (*RepoMatch)(nil)
 	_ Match = (*CommitMatch)(nil)
+	_ Match = (*DiagnosticMatch)(nil)
 )
 
 // Match ranks are used for sorting the different match types.
 // Match types with lower ranks will be sorted before match types
 // with higher ranks.
 const (
-	rankFileMatch   = 0
-	rankCommitMatch = 1
-	rankDiffMatch   = 2
-	rankRepoMatch   = 3
+	rankFileMatch       = 0
+	rankCommitMatch     = 1
+	rankDiffMatch       = 2
+	rankRepoMatch       = 3
+	rankDiagnosticMatch = 4
 )
 
 // Key is a sorting or deduplicating key for a Match.
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/diagnostic"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
@@ -184,6 +185,18 @@ func (a *Aggregator) DoCommitSearch(ctx context.Context, tp *search.TextParamete
 	return commit.SearchCommitLogInRepos(ctx, a.db, args, a)
 }
 
+func (a *Aggregator) DoDiagnosticSearch(ctx context.Context, args *search.TextParameters, limit int) (err error) {
+	tr, ctx := trace.New(ctx, "doDiagnosticSearch", "")
+	defer func() {
+		a.Error(err)
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	err = diagnostic.Search(ctx, args, limit, a)
+	return errors.Wrap(err, "diagnostic search failed")
+}
+
 func checkDiffCommitSearchLimits(ctx context.Context, args *search.TextParameters, resultType string) error {
 	repos, err := args.RepoPromise.Get(ctx)
 	if err != nil {
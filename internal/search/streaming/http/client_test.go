@@ -48,6 +48,10 @@ func TestDecoder(t *testing.T) {
 				Type:   CommitMatchType,
 				Detail: "test",
 			},
+			&EventDiagnosticMatch{
+				Type: DiagnosticMatchType,
+				Path: "test",
+			},
 		},
 	}, {
 		Name: "filters",
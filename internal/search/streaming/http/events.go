@@ -111,6 +111,32 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventDiagnosticMatch is the diagnostics of a file matching a type:diagnostic search.
+type EventDiagnosticMatch struct {
+	// Type is always DiagnosticMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	Path       string `json:"name"`
+	Repository string `json:"repository"`
+	RepoStars  int    `json:"repoStars,omitempty"`
+	Version    string `json:"version,omitempty"`
+
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+func (e *EventDiagnosticMatch) eventMatch() {}
+
+type Diagnostic struct {
+	URL      string `json:"url"`
+	Severity string `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Message  string `json:"message"`
+	// Line and Character are zero-based.
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -149,6 +175,7 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	DiagnosticMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -163,6 +190,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case DiagnosticMatchType:
+		return []byte(`"diagnostic"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -179,6 +208,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"diagnostic"`)) {
+		*t = DiagnosticMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
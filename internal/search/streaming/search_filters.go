@@ -150,6 +150,11 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			// can only be used with the 'repo:' scope. In that case,
 			// we shouldn't be getting any repositoy name matches back.
 			addRepoFilter(v.Name, v.ID, "", 1)
+		case *result.DiagnosticMatch:
+			diagnostics := int32(v.ResultCount())
+			addRepoFilter(v.Repo.Name, v.Repo.ID, "", diagnostics)
+			addLangFilter(v.Path, diagnostics, v.LimitHit)
+			addFileFilter(v.Path, diagnostics, v.LimitHit)
 		}
 	}
 }
@@ -0,0 +1,84 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func CSharpPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		extensionPattern(rawPattern("sln")),
+		extensionPattern(rawPattern("csproj")),
+	}
+}
+
+func CanIndexCSharpRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isSolutionPath(path) || isCSharpProjectPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The .NET SDK image has no LSIF indexer installed, so the LsifDotnet tool is
+// installed from NuGet before indexing. The packages are restored in the same
+// container, so that the indexer can resolve them.
+const (
+	dotnetSDKImage = "mcr.microsoft.com/dotnet/sdk:5.0"
+	lsifDotnetPath = "/lsif-dotnet"
+)
+
+func InferCSharpIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	// A solution file references the projects beneath it, so projects sharing a
+	// directory with (or nested under) a solution are indexed through the solution.
+	var solutionDirs []string
+	for _, path := range paths {
+		if !isSolutionPath(path) {
+			continue
+		}
+
+		solutionDirs = append(solutionDirs, dirWithoutDot(path))
+		indexes = append(indexes, makeCSharpIndexJob(path))
+	}
+
+	for _, path := range paths {
+		if !isCSharpProjectPath(path) || hasAncestorIn(path, solutionDirs) {
+			continue
+		}
+
+		indexes = append(indexes, makeCSharpIndexJob(path))
+	}
+
+	return indexes
+}
+
+func makeCSharpIndexJob(path string) config.IndexJob {
+	root := dirWithoutDot(path)
+	file := filepath.Base(path)
+
+	return config.IndexJob{
+		LocalSteps: []string{
+			"dotnet tool install --tool-path " + lsifDotnetPath + " LsifDotnet",
+			"dotnet restore " + file,
+		},
+		Root:        root,
+		Indexer:     dotnetSDKImage,
+		IndexerArgs: []string{lsifDotnetPath + "/lsif-dotnet", file},
+		Outfile:     "dump.lsif",
+	}
+}
+
+var csharpSegmentBlockList = append([]string{"bin", "obj", "packages"}, segmentBlockList...)
+
+func isSolutionPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".sln") && containsNoSegments(path, csharpSegmentBlockList...)
+}
+
+func isCSharpProjectPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csproj") && containsNoSegments(path, csharpSegmentBlockList...)
+}
@@ -0,0 +1,125 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestCSharpPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"App.sln", true},
+		{"src/App.sln", true},
+		{"src/App/App.csproj", true},
+		{"App.csproj/subdir", false},
+		{"Program.cs", false},
+		{"App.fsproj", false},
+	}
+
+	for _, testCase := range testCases {
+		match := false
+		for _, pattern := range CSharpPatterns() {
+			if pattern.MatchString(testCase.path) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			if !testCase.expected {
+				t.Error(fmt.Sprintf("did not expect match: %s", testCase.path))
+			}
+
+		} else if testCase.expected {
+			t.Error(fmt.Sprintf("expected match: %s", testCase.path))
+		}
+	}
+}
+
+func TestCanIndexCSharpRepo(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"App.sln"}, expected: true},
+		{paths: []string{"src/App/App.csproj"}, expected: true},
+		{paths: []string{"package.json"}, expected: false},
+		{paths: []string{"bin/Debug/App.csproj"}, expected: false},
+		{paths: []string{"tests/App.Tests/App.Tests.csproj"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := CanIndexCSharpRepo(NewMockGitClient(), testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestInferCSharpIndexJobsSolution(t *testing.T) {
+	paths := []string{
+		"App.sln",
+		"src/App/App.csproj",
+		"src/Lib/Lib.csproj",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			LocalSteps: []string{
+				"dotnet tool install --tool-path /lsif-dotnet LsifDotnet",
+				"dotnet restore App.sln",
+			},
+			Root:        "",
+			Indexer:     dotnetSDKImage,
+			IndexerArgs: []string{"/lsif-dotnet/lsif-dotnet", "App.sln"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferCSharpIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferCSharpIndexJobsProjects(t *testing.T) {
+	paths := []string{
+		"server/Server.sln",
+		"server/Api/Api.csproj",
+		"tools/Gen/Gen.csproj",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			LocalSteps: []string{
+				"dotnet tool install --tool-path /lsif-dotnet LsifDotnet",
+				"dotnet restore Server.sln",
+			},
+			Root:        "server",
+			Indexer:     dotnetSDKImage,
+			IndexerArgs: []string{"/lsif-dotnet/lsif-dotnet", "Server.sln"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			LocalSteps: []string{
+				"dotnet tool install --tool-path /lsif-dotnet LsifDotnet",
+				"dotnet restore Gen.csproj",
+			},
+			Root:        "tools/Gen",
+			Indexer:     dotnetSDKImage,
+			IndexerArgs: []string{"/lsif-dotnet/lsif-dotnet", "Gen.csproj"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferCSharpIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
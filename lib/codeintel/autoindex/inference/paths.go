@@ -25,6 +25,18 @@ func ancestorDirs(path string) (ancestors []string) {
 	return ancestors
 }
 
+// hasAncestorIn returns true if any ancestor directory of the given path occurs in
+// the given list of directories.
+func hasAncestorIn(path string, dirs []string) bool {
+	for _, dir := range ancestorDirs(path) {
+		if contains(dirs, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // containsSegment returns true if the given path contains the given segment.
 func containsSegment(path, segment string) bool {
 	if path == "" {
@@ -61,3 +61,28 @@ func TestContainsSegment(t *testing.T) {
 		})
 	}
 }
+
+func TestHasAncestorIn(t *testing.T) {
+	testCases := []struct {
+		path     string
+		dirs     []string
+		expected bool
+	}{
+		{path: "foo/bar/baz.txt", dirs: []string{"foo/bar"}, expected: true},
+		{path: "foo/bar/baz.txt", dirs: []string{"foo"}, expected: true},
+		{path: "foo/bar/baz.txt", dirs: []string{""}, expected: true},
+		{path: "foo/bar/baz.txt", dirs: []string{"foo/bar/baz.txt"}, expected: false},
+		{path: "foo/bar/baz.txt", dirs: []string{"bar"}, expected: false},
+		{path: "foo/bar/baz.txt", dirs: nil, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := fmt.Sprintf("%s in %v", testCase.path, testCase.dirs)
+
+		t.Run(name, func(t *testing.T) {
+			if value := hasAncestorIn(testCase.path, testCase.dirs); value != testCase.expected {
+				t.Errorf("unexpected result. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
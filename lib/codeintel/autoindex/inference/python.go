@@ -0,0 +1,98 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func PythonPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("setup.py")),
+		pathPattern(rawPattern("pyproject.toml")),
+		pathPattern(rawPattern("requirements.txt")),
+	}
+}
+
+func CanIndexPythonRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isPythonProjectPath(path) || isPythonRequirementsPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifPyImage = "sourcegraph/lsif-py:latest"
+
+func InferPythonIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	seen := map[string]struct{}{}
+
+	for _, path := range paths {
+		if !isPythonProjectPath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+		if _, ok := seen[root]; ok {
+			// Projects with both a setup.py and a pyproject.toml are indexed once
+			continue
+		}
+		seen[root] = struct{}{}
+
+		var commands []string
+		if contains(paths, filepath.Join(root, "requirements.txt")) {
+			commands = append(commands, "pip install -r requirements.txt")
+		}
+		commands = append(commands, "pip install .")
+
+		indexes = append(indexes, makePythonIndexJob(root, commands))
+	}
+
+	// Applications that aren't packaged are recognized by their list of requirements
+	for _, path := range paths {
+		if !isPythonRequirementsPath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+		if _, ok := seen[root]; ok {
+			// The requirements are installed along with the project in this directory
+			continue
+		}
+		seen[root] = struct{}{}
+
+		indexes = append(indexes, makePythonIndexJob(root, []string{"pip install -r requirements.txt"}))
+	}
+
+	return indexes
+}
+
+func makePythonIndexJob(root string, commands []string) config.IndexJob {
+	return config.IndexJob{
+		Steps: []config.DockerStep{
+			{
+				Root:     root,
+				Image:    lsifPyImage,
+				Commands: commands,
+			},
+		},
+		Root:        root,
+		Indexer:     lsifPyImage,
+		IndexerArgs: []string{"lsif-py", "."},
+		Outfile:     "dump.lsif",
+	}
+}
+
+var pythonSegmentBlockList = append([]string{"site-packages", "venv", ".venv", ".tox"}, segmentBlockList...)
+
+func isPythonProjectPath(path string) bool {
+	base := filepath.Base(path)
+	return (base == "setup.py" || base == "pyproject.toml") && containsNoSegments(path, pythonSegmentBlockList...)
+}
+
+func isPythonRequirementsPath(path string) bool {
+	return filepath.Base(path) == "requirements.txt" && containsNoSegments(path, pythonSegmentBlockList...)
+}
@@ -0,0 +1,84 @@
+package inference
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func RustPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("Cargo.toml")),
+	}
+}
+
+func CanIndexRustRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isCargoManifestPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifRustImage = "sourcegraph/lsif-rust:latest"
+
+func InferRustIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	// Members of a Cargo workspace are indexed along with the workspace root, so
+	// we only emit a job for manifests that do not belong to a workspace.
+	var workspaceRoots []string
+	for _, path := range paths {
+		if isCargoManifestPath(path) && isCargoWorkspace(gitclient, path) {
+			workspaceRoots = append(workspaceRoots, dirWithoutDot(path))
+		}
+	}
+
+	for _, path := range paths {
+		if !isCargoManifestPath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+		if root != "" && hasAncestorIn(root, workspaceRoots) {
+			continue
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        root,
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+var cargoWorkspacePattern = regexp.MustCompile(`(?m)^\s*\[workspace\]`)
+
+// isCargoWorkspace returns true if the Cargo manifest at the given path declares a
+// workspace.
+func isCargoWorkspace(gitclient GitClient, path string) bool {
+	contents, err := gitclient.RawContents(context.TODO(), path)
+	if err != nil {
+		return false
+	}
+
+	return cargoWorkspacePattern.Match(contents)
+}
+
+var rustSegmentBlockList = append([]string{"target"}, segmentBlockList...)
+
+func isCargoManifestPath(path string) bool {
+	return filepath.Base(path) == "Cargo.toml" && containsNoSegments(path, rustSegmentBlockList...)
+}
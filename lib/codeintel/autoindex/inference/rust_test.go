@@ -0,0 +1,143 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRustPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"Cargo.toml", true},
+		{"crates/foo/Cargo.toml", true},
+		{"Cargo.lock", false},
+		{"Cargo.toml/subdir", false},
+		{"main.rs", false},
+	}
+
+	for _, testCase := range testCases {
+		match := false
+		for _, pattern := range RustPatterns() {
+			if pattern.MatchString(testCase.path) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			if !testCase.expected {
+				t.Error(fmt.Sprintf("did not expect match: %s", testCase.path))
+			}
+
+		} else if testCase.expected {
+			t.Error(fmt.Sprintf("expected match: %s", testCase.path))
+		}
+	}
+}
+
+func TestCanIndexRustRepo(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"Cargo.toml"}, expected: true},
+		{paths: []string{"a/Cargo.toml"}, expected: true},
+		{paths: []string{"package.json"}, expected: false},
+		{paths: []string{"target/package/foo/Cargo.toml"}, expected: false},
+		{paths: []string{"foo/bar-Cargo.toml"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := CanIndexRustRepo(NewMockGitClient(), testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestInferRustIndexJobsCrateSubdirs(t *testing.T) {
+	paths := []string{
+		"a/Cargo.toml",
+		"b/Cargo.toml",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "a",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "a",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "b",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "b",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferRustIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferRustIndexJobsWorkspace(t *testing.T) {
+	paths := []string{
+		"Cargo.toml",
+		"crates/foo/Cargo.toml",
+		"crates/bar/Cargo.toml",
+		"tools/xtask/Cargo.toml",
+	}
+
+	mockGit := NewMockGitClient()
+	mockGit.RawContentsFunc.SetDefaultHook(func(ctx context.Context, path string) ([]byte, error) {
+		if path == "Cargo.toml" {
+			return []byte("[workspace]\nmembers = [\"crates/*\", \"tools/xtask\"]\n"), nil
+		}
+
+		return []byte("[package]\nname = \"member\"\n"), nil
+	})
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferRustIndexJobs(mockGit, paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
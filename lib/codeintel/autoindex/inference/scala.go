@@ -0,0 +1,66 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func ScalaPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("build.sbt")),
+	}
+}
+
+func CanIndexScalaRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isSbtBuildPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifScalaImage = "sourcegraph/lsif-java"
+
+func InferScalaIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	var buildDirs []string
+	for _, path := range paths {
+		if isSbtBuildPath(path) {
+			buildDirs = append(buildDirs, dirWithoutDot(path))
+		}
+	}
+
+	for _, path := range paths {
+		if !isSbtBuildPath(path) {
+			continue
+		}
+
+		// Nested build definitions are subprojects of the enclosing sbt build,
+		// which indexes all of its subprojects at once.
+		root := dirWithoutDot(path)
+		if root != "" && hasAncestorIn(root, buildDirs) {
+			continue
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps:   []config.DockerStep{},
+			Root:    root,
+			Indexer: lsifScalaImage,
+			IndexerArgs: []string{
+				"/coursier launch --contrib --ttl 0 lsif-java -- index",
+			},
+			Outfile: "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+var scalaSegmentBlockList = append([]string{"target", "project"}, segmentBlockList...)
+
+func isSbtBuildPath(path string) bool {
+	return filepath.Base(path) == "build.sbt" && containsNoSegments(path, scalaSegmentBlockList...)
+}
@@ -0,0 +1,121 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestScalaPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"build.sbt", true},
+		{"subdir/build.sbt", true},
+		{"project/plugins.sbt", false},
+		{"build.sbt/subdir", false},
+		{"Main.scala", false},
+	}
+
+	for _, testCase := range testCases {
+		match := false
+		for _, pattern := range ScalaPatterns() {
+			if pattern.MatchString(testCase.path) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			if !testCase.expected {
+				t.Error(fmt.Sprintf("did not expect match: %s", testCase.path))
+			}
+
+		} else if testCase.expected {
+			t.Error(fmt.Sprintf("expected match: %s", testCase.path))
+		}
+	}
+}
+
+func TestCanIndexScalaRepo(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"build.sbt"}, expected: true},
+		{paths: []string{"a/build.sbt"}, expected: true},
+		{paths: []string{"pom.xml"}, expected: false},
+		{paths: []string{"project/build.sbt"}, expected: false},
+		{paths: []string{"target/foo/build.sbt"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := CanIndexScalaRepo(NewMockGitClient(), testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestInferScalaIndexJobsMultiProjectBuild(t *testing.T) {
+	paths := []string{
+		"build.sbt",
+		"core/build.sbt",
+		"server/build.sbt",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps:   []config.DockerStep{},
+			Root:    "",
+			Indexer: lsifScalaImage,
+			IndexerArgs: []string{
+				"/coursier launch --contrib --ttl 0 lsif-java -- index",
+			},
+			Outfile: "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferScalaIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferScalaIndexJobsSubdirs(t *testing.T) {
+	paths := []string{
+		"a/build.sbt",
+		"b/build.sbt",
+		"b/sub/build.sbt",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps:   []config.DockerStep{},
+			Root:    "a",
+			Indexer: lsifScalaImage,
+			IndexerArgs: []string{
+				"/coursier launch --contrib --ttl 0 lsif-java -- index",
+			},
+			Outfile: "dump.lsif",
+		},
+		{
+			Steps:   []config.DockerStep{},
+			Root:    "b",
+			Indexer: lsifScalaImage,
+			IndexerArgs: []string{
+				"/coursier launch --contrib --ttl 0 lsif-java -- index",
+			},
+			Outfile: "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferScalaIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
@@ -19,10 +19,28 @@ import (
 )
 
 // Correlate reads LSIF data from the given reader and returns a correlation state object with
-// the same data canonicalized and pruned for storage.
+// the same data canonicalized and pruned for storage. The reader may supply either line-delimited
+// LSIF JSON or a protobuf-encoded index.
 //
 // If getChildren == nil, no pruning of irrelevant data is performed.
 func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*semantic.GroupedBundleDataChans, error) {
+	state, err := correlateAndPrune(ctx, r, root, getChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert data to the format we send to the writer
+	groupedBundleData, err := groupBundleData(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupedBundleData, nil
+}
+
+// correlateAndPrune reads LSIF data from the given reader and returns a canonicalized correlation
+// state. If getChildren != nil, data for documents that do not exist in git is removed.
+func correlateAndPrune(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*State, error) {
 	// Read raw upload stream and return a correlation state
 	state, err := correlateFromReader(ctx, r, root)
 	if err != nil {
@@ -39,13 +57,7 @@ func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathex
 		}
 	}
 
-	// Convert data to the format we send to the writer
-	groupedBundleData, err := groupBundleData(ctx, state)
-	if err != nil {
-		return nil, err
-	}
-
-	return groupedBundleData, nil
+	return state, nil
 }
 
 func CorrelateLocalGitRelative(ctx context.Context, dumpPath, relativeRoot string) (*semantic.GroupedBundleDataChans, error) {
@@ -175,16 +187,18 @@ func correlateElement(state *wrappedState, element Element) error {
 }
 
 var vertexHandlers = map[string]func(state *wrappedState, element Element) error{
-	"metaData":           correlateMetaData,
-	"document":           correlateDocument,
-	"range":              correlateRange,
-	"resultSet":          correlateResultSet,
-	"definitionResult":   correlateDefinitionResult,
-	"referenceResult":    correlateReferenceResult,
-	"hoverResult":        correlateHoverResult,
-	"moniker":            correlateMoniker,
-	"packageInformation": correlatePackageInformation,
-	"diagnosticResult":   correlateDiagnosticResult,
+	"metaData":             correlateMetaData,
+	"document":             correlateDocument,
+	"range":                correlateRange,
+	"resultSet":            correlateResultSet,
+	"definitionResult":     correlateDefinitionResult,
+	"referenceResult":      correlateReferenceResult,
+	"implementationResult": correlateImplementationResult,
+	"typeDefinitionResult": correlateTypeDefinitionResult,
+	"hoverResult":          correlateHoverResult,
+	"moniker":              correlateMoniker,
+	"packageInformation":   correlatePackageInformation,
+	"diagnosticResult":     correlateDiagnosticResult,
 
 	// Sourcegraph extensions
 	string(protocol.VertexSourcegraphDocumentationResult): correlateDocumentationResult,
@@ -208,16 +222,18 @@ func correlateVertex(state *wrappedState, element Element) error {
 }
 
 var edgeHandlers = map[string]func(state *wrappedState, id int, edge Edge) error{
-	"contains":                correlateContainsEdge,
-	"next":                    correlateNextEdge,
-	"item":                    correlateItemEdge,
-	"textDocument/definition": correlateTextDocumentDefinitionEdge,
-	"textDocument/references": correlateTextDocumentReferencesEdge,
-	"textDocument/hover":      correlateTextDocumentHoverEdge,
-	"moniker":                 correlateMonikerEdge,
-	"nextMoniker":             correlateNextMonikerEdge,
-	"packageInformation":      correlatePackageInformationEdge,
-	"textDocument/diagnostic": correlateDiagnosticEdge,
+	"contains":                    correlateContainsEdge,
+	"next":                        correlateNextEdge,
+	"item":                        correlateItemEdge,
+	"textDocument/definition":     correlateTextDocumentDefinitionEdge,
+	"textDocument/references":     correlateTextDocumentReferencesEdge,
+	"textDocument/implementation": correlateTextDocumentImplementationEdge,
+	"textDocument/typeDefinition": correlateTextDocumentTypeDefinitionEdge,
+	"textDocument/hover":          correlateTextDocumentHoverEdge,
+	"moniker":                     correlateMonikerEdge,
+	"nextMoniker":                 correlateNextMonikerEdge,
+	"packageInformation":          correlatePackageInformationEdge,
+	"textDocument/diagnostic":     correlateDiagnosticEdge,
 
 	// Sourcegraph extensions
 	string(protocol.EdgeSourcegraphDocumentationResult):   correlateDocumentationResultEdge,
@@ -313,6 +329,16 @@ func correlateReferenceResult(state *wrappedState, element Element) error {
 	return nil
 }
 
+func correlateImplementationResult(state *wrappedState, element Element) error {
+	state.ImplementationData[element.ID] = datastructures.NewDefaultIDSetMap()
+	return nil
+}
+
+func correlateTypeDefinitionResult(state *wrappedState, element Element) error {
+	state.TypeDefinitionData[element.ID] = datastructures.NewDefaultIDSetMap()
+	return nil
+}
+
 func correlateHoverResult(state *wrappedState, element Element) error {
 	payload, ok := element.Payload.(string)
 	if !ok {
@@ -384,17 +410,19 @@ func correlateNextEdge(state *wrappedState, id int, edge Edge) error {
 }
 
 func correlateItemEdge(state *wrappedState, id int, edge Edge) error {
-	if documentMap, ok := state.DefinitionData[edge.OutV]; ok {
-		for _, inV := range edge.InVs {
-			if _, ok := state.RangeData[inV]; !ok {
-				return malformedDump(id, inV, "range")
+	for _, data := range []map[int]*datastructures.DefaultIDSetMap{state.DefinitionData, state.ImplementationData, state.TypeDefinitionData} {
+		if documentMap, ok := data[edge.OutV]; ok {
+			for _, inV := range edge.InVs {
+				if _, ok := state.RangeData[inV]; !ok {
+					return malformedDump(id, inV, "range")
+				}
+
+				// Link definition, implementation, or type definition data to the target range
+				documentMap.SetAdd(edge.Document, inV)
 			}
 
-			// Link definition data to defining range
-			documentMap.SetAdd(edge.Document, inV)
+			return nil
 		}
-
-		return nil
 	}
 
 	if documentMap, ok := state.ReferenceData[edge.OutV]; ok {
@@ -453,6 +481,36 @@ func correlateTextDocumentReferencesEdge(state *wrappedState, id int, edge Edge)
 	return nil
 }
 
+func correlateTextDocumentImplementationEdge(state *wrappedState, id int, edge Edge) error {
+	if _, ok := state.ImplementationData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "implementationResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetImplementationResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetImplementationResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
+func correlateTextDocumentTypeDefinitionEdge(state *wrappedState, id int, edge Edge) error {
+	if _, ok := state.TypeDefinitionData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "typeDefinitionResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
 func correlateTextDocumentHoverEdge(state *wrappedState, id int, edge Edge) error {
 	if _, ok := state.HoverData[edge.InV]; !ok {
 		return malformedDump(id, edge.InV, "hoverResult")
@@ -104,6 +104,8 @@ func TestCorrelate(t *testing.T) {
 			14: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(4, 5)}),
 			15: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{}),
 		},
+		ImplementationData: map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{},
 		HoverData: map[int]string{
 			16: "```go\ntext A\n```",
 			17: "```go\ntext B\n```",
@@ -196,6 +198,96 @@ func TestCorrelate(t *testing.T) {
 	}
 }
 
+func TestCorrelateImplementationsAndTypeDefinitions(t *testing.T) {
+	input, err := os.ReadFile("../testdata/dump4.lsif")
+	if err != nil {
+		t.Fatalf("unexpected error reading test file: %s", err)
+	}
+
+	state, err := correlateFromReader(context.Background(), bytes.NewReader(input), "root")
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+
+	expectedState := &State{
+		LSIFVersion: "0.4.3",
+		ProjectRoot: "file:///test/root",
+		DocumentData: map[int]string{
+			2: "foo.go",
+			3: "bar.go",
+		},
+		RangeData: map[int]Range{
+			4: {
+				Range: reader.Range{
+					RangeData: protocol.RangeData{
+						Start: protocol.Pos{Line: 1, Character: 2},
+						End:   protocol.Pos{Line: 1, Character: 5},
+					},
+				},
+				TypeDefinitionResultID: 9,
+			},
+			5: {
+				Range: reader.Range{
+					RangeData: protocol.RangeData{
+						Start: protocol.Pos{Line: 3, Character: 1},
+						End:   protocol.Pos{Line: 3, Character: 4},
+					},
+				},
+			},
+			6: {
+				Range: reader.Range{
+					RangeData: protocol.RangeData{
+						Start: protocol.Pos{Line: 5, Character: 1},
+						End:   protocol.Pos{Line: 5, Character: 4},
+					},
+				},
+			},
+		},
+		ResultSetData: map[int]ResultSet{
+			7: {
+				ImplementationResultID: 8,
+			},
+		},
+		DefinitionData: map[int]*datastructures.DefaultIDSetMap{},
+		ReferenceData:  map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData: map[int]*datastructures.DefaultIDSetMap{
+			8: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{3: datastructures.IDSetWith(5)}),
+		},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{
+			9: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{3: datastructures.IDSetWith(6)}),
+		},
+		HoverData:              map[int]string{},
+		MonikerData:            map[int]Moniker{},
+		PackageInformationData: map[int]PackageInformation{},
+		DiagnosticResults:      map[int][]Diagnostic{},
+		NextData: map[int]int{
+			4: 7,
+		},
+		ImportedMonikers:       datastructures.NewIDSet(),
+		ExportedMonikers:       datastructures.NewIDSet(),
+		LinkedMonikers:         datastructures.NewDisjointIDSet(),
+		LinkedReferenceResults: map[int][]int{},
+		Contains: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			2: datastructures.IDSetWith(4),
+			3: datastructures.IDSetWith(5, 6),
+		}),
+		Monikers:    datastructures.NewDefaultIDSetMap(),
+		Diagnostics: datastructures.NewDefaultIDSetMap(),
+
+		// TODO(slimsag): Documentation extension tests
+		DocumentationResultsData:  map[int]protocol.Documentation{},
+		DocumentationStringsData:  map[int]protocol.MarkupContent{},
+		DocumentationResultRoot:   -1,
+		DocumentationChildren:     map[int][]int{},
+		DocumentationStringLabel:  map[int]int{},
+		DocumentationStringDetail: map[int]int{},
+	}
+
+	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
+		t.Errorf("unexpected state (-want +got):\n%s", diff)
+	}
+}
+
 func TestCorrelateMetaDataRoot(t *testing.T) {
 	input, err := os.ReadFile("../testdata/dump2.lsif")
 	if err != nil {
@@ -217,6 +309,8 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		ResultSetData:          map[int]ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
@@ -265,6 +359,8 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		ResultSetData:          map[int]ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
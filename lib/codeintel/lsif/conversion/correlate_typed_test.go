@@ -0,0 +1,177 @@
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsiftyped"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+const (
+	typedFooSymbol  = "gomod . github.com/test/pkg v1.0.0 Foo#"
+	typedImplSymbol = "gomod . github.com/test/pkg v1.0.0 Impl#"
+	typedBarSymbol  = "gomod . github.com/dep/pkg v2.0.0 Bar()."
+)
+
+func TestCorrelateTypedIndex(t *testing.T) {
+	index := &lsiftyped.Index{
+		Metadata: &lsiftyped.Metadata{
+			ToolInfo:    &lsiftyped.ToolInfo{Name: "lsif-test"},
+			ProjectRoot: "file:///test/root",
+		},
+		Documents: []*lsiftyped.Document{
+			{
+				RelativePath: "a.go",
+				Occurrences: []*lsiftyped.Occurrence{
+					{Range: []int32{0, 5, 8}, Symbol: typedFooSymbol, SymbolRoles: int32(lsiftyped.SymbolRole_Definition)},
+					{Range: []int32{2, 1, 2}, Symbol: "local 0", SymbolRoles: int32(lsiftyped.SymbolRole_Definition)},
+					{Range: []int32{3, 1, 2}, Symbol: "local 0"},
+					{Range: []int32{4, 3, 4, 6}, Symbol: typedBarSymbol, SymbolRoles: int32(lsiftyped.SymbolRole_Import)},
+					{
+						Range:       []int32{5, 0, 10},
+						Diagnostics: []*lsiftyped.Diagnostic{{Severity: lsiftyped.Severity_Error, Message: "oops"}},
+					},
+				},
+				Symbols: []*lsiftyped.SymbolInformation{
+					{Symbol: typedFooSymbol, Documentation: []string{"Foo is a type"}},
+					{Symbol: "local 0", Relationships: []*lsiftyped.Relationship{{Symbol: typedFooSymbol, IsTypeDefinition: true}}},
+				},
+			},
+			{
+				RelativePath: "b.go",
+				Occurrences: []*lsiftyped.Occurrence{
+					{Range: []int32{0, 5, 9}, Symbol: typedImplSymbol, SymbolRoles: int32(lsiftyped.SymbolRole_Definition)},
+					{Range: []int32{1, 2, 5}, Symbol: typedFooSymbol},
+				},
+				Symbols: []*lsiftyped.SymbolInformation{
+					{Symbol: typedImplSymbol, Relationships: []*lsiftyped.Relationship{{Symbol: typedFooSymbol, IsImplementation: true}}},
+				},
+			},
+		},
+		ExternalSymbols: []*lsiftyped.SymbolInformation{
+			{Symbol: typedBarSymbol, Documentation: []string{"Bar does things"}},
+		},
+	}
+
+	payload, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	chans, err := Correlate(context.Background(), bytes.NewReader(payload), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating index: %s", err)
+	}
+	bundle := semantic.GroupedBundleDataChansToMaps(chans)
+
+	fooDefinition := semantic.LocationData{URI: "a.go", StartLine: 0, StartCharacter: 5, EndLine: 0, EndCharacter: 8}
+	fooReference := semantic.LocationData{URI: "b.go", StartLine: 1, StartCharacter: 2, EndLine: 1, EndCharacter: 5}
+	implDefinition := semantic.LocationData{URI: "b.go", StartLine: 0, StartCharacter: 5, EndLine: 0, EndCharacter: 9}
+
+	t.Run("global symbol", func(t *testing.T) {
+		result := querySingle(t, bundle, "b.go", 1, 3)
+
+		if diff := cmp.Diff([]semantic.LocationData{fooDefinition}, result.Definitions); diff != "" {
+			t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+		}
+		sortLocations(result.References)
+		if diff := cmp.Diff([]semantic.LocationData{fooDefinition, fooReference}, result.References); diff != "" {
+			t.Errorf("unexpected references (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]semantic.LocationData{implDefinition}, result.Implementations); diff != "" {
+			t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+		}
+		if result.Hover != "Foo is a type" {
+			t.Errorf("unexpected hover. want=%q have=%q", "Foo is a type", result.Hover)
+		}
+		if len(result.Monikers) != 1 {
+			t.Fatalf("unexpected number of monikers. want=%d have=%d", 1, len(result.Monikers))
+		}
+		if moniker := result.Monikers[0]; moniker.Kind != "export" || moniker.Scheme != "gomod" || moniker.Identifier != "Foo#" || moniker.Name != "github.com/test/pkg" || moniker.Version != "v1.0.0" {
+			t.Errorf("unexpected moniker %+v", moniker)
+		}
+	})
+
+	t.Run("local symbol", func(t *testing.T) {
+		result := querySingle(t, bundle, "a.go", 3, 1)
+
+		expectedDefinitions := []semantic.LocationData{{URI: "a.go", StartLine: 2, StartCharacter: 1, EndLine: 2, EndCharacter: 2}}
+		if diff := cmp.Diff(expectedDefinitions, result.Definitions); diff != "" {
+			t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]semantic.LocationData{fooDefinition}, result.TypeDefinitions); diff != "" {
+			t.Errorf("unexpected type definitions (-want +got):\n%s", diff)
+		}
+		if len(result.Monikers) != 0 {
+			t.Errorf("unexpected monikers %+v", result.Monikers)
+		}
+	})
+
+	t.Run("external symbol", func(t *testing.T) {
+		result := querySingle(t, bundle, "a.go", 4, 4)
+
+		if len(result.Definitions) != 0 {
+			t.Errorf("unexpected definitions %+v", result.Definitions)
+		}
+		if result.Hover != "Bar does things" {
+			t.Errorf("unexpected hover. want=%q have=%q", "Bar does things", result.Hover)
+		}
+		if len(result.Monikers) != 1 {
+			t.Fatalf("unexpected number of monikers. want=%d have=%d", 1, len(result.Monikers))
+		}
+		if moniker := result.Monikers[0]; moniker.Kind != "import" || moniker.Identifier != "Bar()." || moniker.Name != "github.com/dep/pkg" || moniker.Version != "v2.0.0" {
+			t.Errorf("unexpected moniker %+v", moniker)
+		}
+	})
+
+	t.Run("packages", func(t *testing.T) {
+		if diff := cmp.Diff([]semantic.Package{{Scheme: "gomod", Name: "github.com/test/pkg", Version: "v1.0.0"}}, bundle.Packages); diff != "" {
+			t.Errorf("unexpected packages (-want +got):\n%s", diff)
+		}
+
+		var packageReferences []semantic.Package
+		for _, packageReference := range bundle.PackageReferences {
+			packageReferences = append(packageReferences, packageReference.Package)
+		}
+		if diff := cmp.Diff([]semantic.Package{{Scheme: "gomod", Name: "github.com/dep/pkg", Version: "v2.0.0"}}, packageReferences); diff != "" {
+			t.Errorf("unexpected package references (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		diagnostics := bundle.Documents["a.go"].Diagnostics
+		if len(diagnostics) != 1 || diagnostics[0].Message != "oops" || diagnostics[0].StartLine != 5 || diagnostics[0].EndCharacter != 10 {
+			t.Errorf("unexpected diagnostics %+v", diagnostics)
+		}
+	})
+}
+
+func TestCorrelateTypedIndexMissingMetadata(t *testing.T) {
+	payload, err := proto.Marshal(&lsiftyped.Index{
+		Documents: []*lsiftyped.Document{{RelativePath: "a.go"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	if _, err := Correlate(context.Background(), bytes.NewReader(payload), "", nil); err == nil {
+		t.Fatalf("expected an error correlating index without metadata")
+	}
+}
+
+func querySingle(t *testing.T, bundle *semantic.GroupedBundleDataMaps, path string, line, character int) semantic.QueryResult {
+	results, err := semantic.Query(bundle, path, line, character)
+	if err != nil {
+		t.Fatalf("unexpected error querying bundle: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("unexpected number of results. want=%d have=%d", 1, len(results))
+	}
+
+	return results[0]
+}
@@ -24,7 +24,7 @@ const resultsPerResultChunk = 512
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
 func groupBundleData(ctx context.Context, state *State) (*semantic.GroupedBundleDataChans, error) {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData) + len(state.TypeDefinitionData)
 	numResultChunks := int(math.Max(1, math.Floor(float64(numResults)/resultsPerResultChunk)))
 
 	meta := semantic.MetaData{NumResultChunks: numResultChunks}
@@ -32,6 +32,7 @@ func groupBundleData(ctx context.Context, state *State) (*semantic.GroupedBundle
 	resultChunks := serializeResultChunks(ctx, state, numResultChunks)
 	definitionRows := gatherMonikersLocations(ctx, state, state.DefinitionData, func(r Range) int { return r.DefinitionResultID })
 	referenceRows := gatherMonikersLocations(ctx, state, state.ReferenceData, func(r Range) int { return r.ReferenceResultID })
+	implementationRows := gatherMonikersLocations(ctx, state, state.ImplementationData, func(r Range) int { return r.ImplementationResultID })
 	documentation := collectDocumentation(ctx, state)
 	packages := gatherPackages(state)
 	packageReferences, err := gatherPackageReferences(state, packages)
@@ -45,6 +46,7 @@ func groupBundleData(ctx context.Context, state *State) (*semantic.GroupedBundle
 		ResultChunks:          resultChunks,
 		Definitions:           definitionRows,
 		References:            referenceRows,
+		Implementations:       implementationRows,
 		DocumentationPages:    documentation.pages,
 		DocumentationPathInfo: documentation.pathInfo,
 		DocumentationMappings: documentation.mappings,
@@ -60,7 +62,7 @@ func serializeBundleDocuments(ctx context.Context, state *State) chan semantic.K
 		defer close(ch)
 
 		for documentID, uri := range state.DocumentData {
-			if strings.HasPrefix(uri, "..") {
+			if strings.HasPrefix(uri, "..") || isUnchangedDocument(state, documentID) {
 				continue
 			}
 
@@ -114,15 +116,17 @@ func serializeDocument(state *State, documentID int) semantic.DocumentData {
 		})
 
 		document.Ranges[toID(rangeID)] = semantic.RangeData{
-			StartLine:             rangeData.Start.Line,
-			StartCharacter:        rangeData.Start.Character,
-			EndLine:               rangeData.End.Line,
-			EndCharacter:          rangeData.End.Character,
-			DefinitionResultID:    toID(rangeData.DefinitionResultID),
-			ReferenceResultID:     toID(rangeData.ReferenceResultID),
-			HoverResultID:         toID(rangeData.HoverResultID),
-			DocumentationResultID: toID(rangeData.DocumentationResultID),
-			MonikerIDs:            monikerIDs,
+			StartLine:              rangeData.Start.Line,
+			StartCharacter:         rangeData.Start.Character,
+			EndLine:                rangeData.End.Line,
+			EndCharacter:           rangeData.End.Character,
+			DefinitionResultID:     toID(rangeData.DefinitionResultID),
+			ReferenceResultID:      toID(rangeData.ReferenceResultID),
+			ImplementationResultID: toID(rangeData.ImplementationResultID),
+			TypeDefinitionResultID: toID(rangeData.TypeDefinitionResultID),
+			HoverResultID:          toID(rangeData.HoverResultID),
+			DocumentationResultID:  toID(rangeData.DocumentationResultID),
+			MonikerIDs:             monikerIDs,
 		}
 
 		if rangeData.HoverResultID != 0 {
@@ -151,13 +155,11 @@ func serializeDocument(state *State, documentID int) semantic.DocumentData {
 
 func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) chan semantic.IndexedResultChunkData {
 	chunkAssignments := make(map[int][]int, numResultChunks)
-	for id := range state.DefinitionData {
-		index := semantic.HashKey(toID(id), numResultChunks)
-		chunkAssignments[index] = append(chunkAssignments[index], id)
-	}
-	for id := range state.ReferenceData {
-		index := semantic.HashKey(toID(id), numResultChunks)
-		chunkAssignments[index] = append(chunkAssignments[index], id)
+	for _, data := range resultData(state) {
+		for id := range data {
+			index := semantic.HashKey(toID(id), numResultChunks)
+			chunkAssignments[index] = append(chunkAssignments[index], id)
+		}
 	}
 
 	ch := make(chan semantic.IndexedResultChunkData)
@@ -174,9 +176,11 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 			rangeIDsByResultID := make(map[semantic.ID][]semantic.DocumentIDRangeID, len(resultIDs))
 
 			for _, resultID := range resultIDs {
-				documentRanges, ok := state.DefinitionData[resultID]
-				if !ok {
-					documentRanges = state.ReferenceData[resultID]
+				var documentRanges *datastructures.DefaultIDSetMap
+				for _, data := range resultData(state) {
+					if documentRanges = data[resultID]; documentRanges != nil {
+						break
+					}
 				}
 
 				rangeIDMap := map[semantic.ID]int{}
@@ -229,6 +233,17 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 	return ch
 }
 
+// resultData returns the definition, reference, implementation, and type definition result
+// data of the given state. Result identifiers are unique over all of the returned maps.
+func resultData(state *State) []map[int]*datastructures.DefaultIDSetMap {
+	return []map[int]*datastructures.DefaultIDSetMap{
+		state.DefinitionData,
+		state.ReferenceData,
+		state.ImplementationData,
+		state.TypeDefinitionData,
+	}
+}
+
 // sortableDocumentIDRangeIDs implements sort.Interface for document/range id pairs.
 type sortableDocumentIDRangeIDs struct {
 	state         *State
@@ -293,7 +308,7 @@ func gatherMonikersLocations(ctx context.Context, state *State, data map[int]*da
 				for _, id := range ids {
 					data[id].Each(func(documentID int, rangeIDs *datastructures.IDSet) {
 						uri := state.DocumentData[documentID]
-						if strings.HasPrefix(uri, "..") {
+						if strings.HasPrefix(uri, "..") || isUnchangedDocument(state, documentID) {
 							return
 						}
 
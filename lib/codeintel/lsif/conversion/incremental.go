@@ -0,0 +1,127 @@
+package conversion
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion/datastructures"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// CorrelateIncremental reads LSIF data from the given reader and returns the data of the documents
+// with one of the given paths, canonicalized and pruned for storage. This is used to process an
+// incremental upload, which is applied on top of the bundle of a base upload.
+//
+// Only the result sets attached to a range of a changed document are retained. Ranges of other
+// documents that occur within these result sets are returned as the anchors of the bundle, which
+// are used to link each result set to its counterpart in the base bundle. Monikers and diagnostics
+// are emitted only for the changed documents. API documentation is emitted as a whole; only the
+// documentation of symbols within changed documents is merged into the base bundle.
+func CorrelateIncremental(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc, changedPaths []string) (*semantic.GroupedBundleDataChans, error) {
+	state, err := correlateAndPrune(ctx, r, root, getChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	anchors := restrictToChangedDocuments(state, changedPaths)
+
+	groupedBundleData, err := groupBundleData(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	groupedBundleData.Anchors = anchors
+
+	return groupedBundleData, nil
+}
+
+// restrictToChangedDocuments marks every document of the given state whose path is not one of
+// the given changed paths as unchanged and removes the result sets that are not reachable from a
+// range of a changed document. This method returns the ranges of unchanged documents that occur
+// in the remaining result sets, keyed by document path and range identifier.
+func restrictToChangedDocuments(state *State, changedPaths []string) map[string]map[semantic.ID]semantic.RangeData {
+	changedPathMap := make(map[string]struct{}, len(changedPaths))
+	for _, path := range changedPaths {
+		changedPathMap[path] = struct{}{}
+	}
+
+	state.UnchangedDocuments = datastructures.NewIDSet()
+	for documentID, uri := range state.DocumentData {
+		if _, ok := changedPathMap[uri]; !ok {
+			state.UnchangedDocuments.Add(documentID)
+		}
+	}
+
+	reachableResultIDs := datastructures.NewIDSet()
+	for documentID := range state.DocumentData {
+		if isUnchangedDocument(state, documentID) {
+			continue
+		}
+
+		state.Contains.SetEach(documentID, func(rangeID int) {
+			for _, resultID := range resultIDs(state.RangeData[rangeID]) {
+				if resultID != 0 {
+					reachableResultIDs.Add(resultID)
+				}
+			}
+		})
+	}
+
+	anchors := map[string]map[semantic.ID]semantic.RangeData{}
+	for _, data := range resultData(state) {
+		for resultID, documentRanges := range data {
+			if !reachableResultIDs.Contains(resultID) {
+				delete(data, resultID)
+				continue
+			}
+
+			documentRanges.Each(func(documentID int, rangeIDs *datastructures.IDSet) {
+				uri := state.DocumentData[documentID]
+				if strings.HasPrefix(uri, "..") || !isUnchangedDocument(state, documentID) {
+					return
+				}
+
+				ranges, ok := anchors[uri]
+				if !ok {
+					ranges = map[semantic.ID]semantic.RangeData{}
+					anchors[uri] = ranges
+				}
+
+				rangeIDs.Each(func(rangeID int) {
+					r := state.RangeData[rangeID]
+
+					ranges[toID(rangeID)] = semantic.RangeData{
+						StartLine:              r.Start.Line,
+						StartCharacter:         r.Start.Character,
+						EndLine:                r.End.Line,
+						EndCharacter:           r.End.Character,
+						DefinitionResultID:     toID(r.DefinitionResultID),
+						ReferenceResultID:      toID(r.ReferenceResultID),
+						ImplementationResultID: toID(r.ImplementationResultID),
+						TypeDefinitionResultID: toID(r.TypeDefinitionResultID),
+					}
+				})
+			})
+		}
+	}
+
+	return anchors
+}
+
+// isUnchangedDocument determines if the given document lies outside of the changed paths of an
+// incremental upload. Documents of a non-incremental upload are never unchanged.
+func isUnchangedDocument(state *State, documentID int) bool {
+	return state.UnchangedDocuments != nil && state.UnchangedDocuments.Contains(documentID)
+}
+
+// resultIDs returns the definition, reference, implementation, and type definition result
+// identifiers of the given range.
+func resultIDs(r Range) []int {
+	return []int{
+		r.DefinitionResultID,
+		r.ReferenceResultID,
+		r.ImplementationResultID,
+		r.TypeDefinitionResultID,
+	}
+}
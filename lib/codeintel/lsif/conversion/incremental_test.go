@@ -0,0 +1,77 @@
+package conversion
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion/datastructures"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestRestrictToChangedDocuments(t *testing.T) {
+	rangeAt := func(line, definitionResultID, referenceResultID int) Range {
+		return Range{
+			Range: reader.Range{
+				RangeData: protocol.RangeData{
+					Start: protocol.Pos{Line: line, Character: 0},
+					End:   protocol.Pos{Line: line, Character: 3},
+				},
+			},
+			DefinitionResultID: definitionResultID,
+			ReferenceResultID:  referenceResultID,
+		}
+	}
+
+	state := &State{
+		DocumentData: map[int]string{
+			1001: "foo.go",
+			1002: "bar.go",
+		},
+		RangeData: map[int]Range{
+			3001: rangeAt(1, 2001, 2002), // foo.go (changed)
+			3002: rangeAt(2, 2001, 2002), // bar.go
+			3003: rangeAt(3, 2003, 0),    // bar.go
+		},
+		Contains: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			1001: datastructures.IDSetWith(3001),
+			1002: datastructures.IDSetWith(3002, 3003),
+		}),
+		DefinitionData: map[int]*datastructures.DefaultIDSetMap{
+			2001: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1002: datastructures.IDSetWith(3002),
+			}),
+			2003: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1002: datastructures.IDSetWith(3003),
+			}),
+		},
+		ReferenceData: map[int]*datastructures.DefaultIDSetMap{
+			2002: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1001: datastructures.IDSetWith(3001),
+				1002: datastructures.IDSetWith(3002),
+			}),
+		},
+		ImplementationData: map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{},
+	}
+
+	anchors := restrictToChangedDocuments(state, []string{"foo.go"})
+
+	expectedAnchors := map[string]map[semantic.ID]semantic.RangeData{
+		"bar.go": {
+			"3002": {StartLine: 2, EndLine: 2, EndCharacter: 3, DefinitionResultID: "2001", ReferenceResultID: "2002"},
+		},
+	}
+	if diff := cmp.Diff(expectedAnchors, anchors); diff != "" {
+		t.Errorf("unexpected anchors (-want +got):\n%s", diff)
+	}
+
+	if _, ok := state.DefinitionData[2003]; ok {
+		t.Errorf("expected unreachable definition result to be removed")
+	}
+	if !isUnchangedDocument(state, 1002) || isUnchangedDocument(state, 1001) {
+		t.Errorf("unexpected unchanged documents")
+	}
+}
@@ -13,7 +13,8 @@ type Pair struct {
 }
 
 // Read reads the given content as line-separated JSON objects and returns a channel of Pair values
-// for each non-empty line.
+// for each non-empty line. Content encoded as a protobuf index is translated into the equivalent
+// LSIF elements.
 func Read(ctx context.Context, r io.Reader) <-chan Pair {
 	elements := make(chan Pair)
 
@@ -14,6 +14,8 @@ type State struct {
 	ResultSetData          map[int]ResultSet
 	DefinitionData         map[int]*datastructures.DefaultIDSetMap
 	ReferenceData          map[int]*datastructures.DefaultIDSetMap
+	ImplementationData     map[int]*datastructures.DefaultIDSetMap
+	TypeDefinitionData     map[int]*datastructures.DefaultIDSetMap
 	HoverData              map[int]string
 	MonikerData            map[int]Moniker
 	PackageInformationData map[int]PackageInformation
@@ -26,6 +28,7 @@ type State struct {
 	Monikers               *datastructures.DefaultIDSetMap // maps items to their monikers
 	Contains               *datastructures.DefaultIDSetMap // maps ranges to containing documents
 	Diagnostics            *datastructures.DefaultIDSetMap // maps diagnostics to their documents
+	UnchangedDocuments     *datastructures.IDSet           // documents outside of the changed paths of an incremental upload (nil if not incremental)
 
 	// Sourcegraph extensions
 	DocumentationResultsData  map[int]protocol.Documentation // maps documentationResult vertices -> their data
@@ -44,6 +47,8 @@ func newState() *State {
 		ResultSetData:          map[int]ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
@@ -7,6 +7,8 @@ import (
 	"io"
 	"runtime"
 	"sync"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsiftyped"
 )
 
 type Pair struct {
@@ -15,11 +17,16 @@ type Pair struct {
 }
 
 // Read reads the given content as line-separated JSON objects and returns a channel of Pair values for each
-// non-empty line.
+// non-empty line. Content encoded as a protobuf index is translated into the equivalent LSIF elements.
 func Read(ctx context.Context, r io.Reader) <-chan Pair {
+	br := bufio.NewReader(r)
+	if lsiftyped.IsIndex(br) {
+		return readTyped(ctx, br)
+	}
+
 	interner := NewInterner()
 
-	return readLines(ctx, r, func(line []byte) (Element, error) {
+	return readLines(ctx, br, func(line []byte) (Element, error) {
 		return unmarshalElement(interner, line)
 	})
 }
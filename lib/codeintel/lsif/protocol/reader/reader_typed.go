@@ -0,0 +1,486 @@
+package reader
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsiftyped"
+)
+
+// readTyped reads the given content as a protobuf-encoded index and returns a channel of Pair
+// values for the vertices and edges of the equivalent LSIF graph. Each document is translated
+// as soon as it is decoded, so consumers of this channel can treat both formats identically.
+func readTyped(ctx context.Context, r io.Reader) <-chan Pair {
+	pairs := make(chan Pair, ChannelBufferSize)
+
+	go func() {
+		defer close(pairs)
+
+		t := newTypedTranslator(ctx, pairs)
+		visitor := &lsiftyped.IndexVisitor{
+			VisitMetadata:       t.translateMetadata,
+			VisitDocument:       t.translateDocument,
+			VisitExternalSymbol: t.stashExternalSymbol,
+		}
+
+		err := visitor.ParseStreaming(r)
+		if err == nil {
+			err = t.finalize()
+		}
+		if err != nil && err != ctx.Err() {
+			select {
+			case pairs <- Pair{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return pairs
+}
+
+// typedTranslator converts the documents of a protobuf-encoded index into LSIF elements.
+//
+// Each symbol is translated into a result set that is attached to the ranges of each of its
+// occurrences. Definition, reference, implementation, type definition, and hover results are
+// attached to result sets on demand. Data that can only be known once the entire index has
+// been read (monikers, whose kind depends on whether or not the symbol is defined within the
+// index, and relationships to symbols of other documents) is emitted after the last document.
+type typedTranslator struct {
+	ctx             context.Context
+	pairs           chan<- Pair
+	id              int
+	projectRoot     string
+	globalSymbols   map[string]*typedSymbol
+	globalOrder     []string
+	packages        map[lsiftyped.Package]int
+	externalSymbols map[string]*lsiftyped.SymbolInformation
+	relationships   []typedRelationship
+}
+
+// typedSymbol tracks the identifiers of the LSIF elements emitted for a single symbol.
+type typedSymbol struct {
+	resultSetID            int
+	definitionResultID     int
+	referenceResultID      int
+	implementationResultID int
+	typeDefinitionResultID int
+	hasHover               bool
+
+	// global is true for symbols that may be referenced from other documents.
+	global bool
+
+	// definitions holds the ranges of each definition of a global symbol.
+	definitions []typedLocations
+}
+
+// typedLocations is a set of ranges within a single document.
+type typedLocations struct {
+	documentID int
+	rangeIDs   []int
+}
+
+// typedRelationship is a relationship to a global symbol, which is resolved once all
+// documents of the index have been read.
+type typedRelationship struct {
+	source       *typedSymbol
+	definitions  typedLocations
+	relationship *lsiftyped.Relationship
+}
+
+func newTypedTranslator(ctx context.Context, pairs chan<- Pair) *typedTranslator {
+	return &typedTranslator{
+		ctx:             ctx,
+		pairs:           pairs,
+		globalSymbols:   map[string]*typedSymbol{},
+		packages:        map[lsiftyped.Package]int{},
+		externalSymbols: map[string]*lsiftyped.SymbolInformation{},
+	}
+}
+
+func (t *typedTranslator) translateMetadata(metadata *lsiftyped.Metadata) error {
+	if t.projectRoot != "" {
+		return errors.New("metadata defined multiple times")
+	}
+
+	t.projectRoot = metadata.ProjectRoot
+	if !strings.HasSuffix(t.projectRoot, "/") {
+		t.projectRoot += "/"
+	}
+
+	_, err := t.emitVertex("metaData", MetaData{
+		Version:     metadata.Version.String(),
+		ProjectRoot: t.projectRoot,
+	})
+	return err
+}
+
+func (t *typedTranslator) translateDocument(document *lsiftyped.Document) error {
+	if t.projectRoot == "" {
+		return lsiftyped.ErrMissingMetadata
+	}
+
+	documentID, err := t.emitVertex("document", t.projectRoot+document.RelativePath)
+	if err != nil {
+		return err
+	}
+
+	localSymbols := map[string]*typedSymbol{}
+	var order []*typedSymbol
+	definitions := map[*typedSymbol][]int{}
+	references := map[*typedSymbol][]int{}
+	var rangeIDs []int
+	var diagnostics []Diagnostic
+
+	for _, occurrence := range document.Occurrences {
+		r, err := lsiftyped.ParseRange(occurrence.Range)
+		if err != nil {
+			return errors.Wrapf(err, "document %q", document.RelativePath)
+		}
+
+		rangeID, err := t.emitVertex("range", Range{
+			RangeData: protocol.RangeData{
+				Start: protocol.Pos{Line: r.Start.Line, Character: r.Start.Character},
+				End:   protocol.Pos{Line: r.End.Line, Character: r.End.Character},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		rangeIDs = append(rangeIDs, rangeID)
+
+		for _, diagnostic := range occurrence.Diagnostics {
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity:       int(diagnostic.Severity),
+				Code:           diagnostic.Code,
+				Message:        diagnostic.Message,
+				Source:         diagnostic.Source,
+				StartLine:      r.Start.Line,
+				StartCharacter: r.Start.Character,
+				EndLine:        r.End.Line,
+				EndCharacter:   r.End.Character,
+			})
+		}
+
+		if len(occurrence.OverrideDocumentation) > 0 {
+			if err := t.emitHover(rangeID, occurrence.OverrideDocumentation); err != nil {
+				return err
+			}
+		}
+
+		if occurrence.Symbol == "" {
+			continue
+		}
+
+		symbol, err := t.symbol(occurrence.Symbol, localSymbols)
+		if err != nil {
+			return err
+		}
+		if _, ok := references[symbol]; !ok {
+			order = append(order, symbol)
+		}
+
+		if err := t.emitEdge("next", Edge{OutV: rangeID, InV: symbol.resultSetID}); err != nil {
+			return err
+		}
+
+		if occurrence.SymbolRoles&int32(lsiftyped.SymbolRole_Definition) != 0 {
+			definitions[symbol] = append(definitions[symbol], rangeID)
+		}
+		references[symbol] = append(references[symbol], rangeID)
+	}
+
+	if len(rangeIDs) > 0 {
+		if err := t.emitEdge("contains", Edge{OutV: documentID, InVs: rangeIDs}); err != nil {
+			return err
+		}
+	}
+
+	for _, symbol := range order {
+		if rangeIDs, ok := definitions[symbol]; ok {
+			if err := t.emitItems(&symbol.definitionResultID, "definitionResult", "textDocument/definition", symbol, documentID, rangeIDs); err != nil {
+				return err
+			}
+
+			if symbol.global {
+				symbol.definitions = append(symbol.definitions, typedLocations{documentID: documentID, rangeIDs: rangeIDs})
+			}
+		}
+
+		if err := t.emitItems(&symbol.referenceResultID, "referenceResult", "textDocument/references", symbol, documentID, references[symbol]); err != nil {
+			return err
+		}
+	}
+
+	for _, info := range document.Symbols {
+		symbol, ok := t.lookup(info.Symbol, localSymbols)
+		if !ok {
+			// Symbols without occurrences cannot be queried
+			continue
+		}
+
+		if len(info.Documentation) > 0 && !symbol.hasHover {
+			if err := t.emitHover(symbol.resultSetID, info.Documentation); err != nil {
+				return err
+			}
+			symbol.hasHover = true
+		}
+
+		sourceDefinitions := typedLocations{documentID: documentID, rangeIDs: definitions[symbol]}
+		for _, relationship := range info.Relationships {
+			if !lsiftyped.IsLocalSymbol(relationship.Symbol) {
+				t.relationships = append(t.relationships, typedRelationship{
+					source:       symbol,
+					definitions:  sourceDefinitions,
+					relationship: relationship,
+				})
+				continue
+			}
+
+			// Local symbols are not visible outside of this document, so the target of the
+			// relationship must have been among the occurrences translated above.
+			target, ok := localSymbols[relationship.Symbol]
+			if !ok {
+				continue
+			}
+			targetDefinitions := []typedLocations{{documentID: documentID, rangeIDs: definitions[target]}}
+			if err := t.emitRelationship(symbol, sourceDefinitions, target, targetDefinitions, relationship); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(diagnostics) > 0 {
+		diagnosticResultID, err := t.emitVertex("diagnosticResult", diagnostics)
+		if err != nil {
+			return err
+		}
+		if err := t.emitEdge("textDocument/diagnostic", Edge{OutV: documentID, InV: diagnosticResultID}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stashExternalSymbol records the given information about a symbol defined outside of the
+// index. This information is applied after all documents have been read, as the symbol may
+// not have been referenced yet.
+func (t *typedTranslator) stashExternalSymbol(info *lsiftyped.SymbolInformation) error {
+	t.externalSymbols[info.Symbol] = info
+	return nil
+}
+
+// finalize emits the elements of the translated index that depend on the content of more
+// than one document.
+func (t *typedTranslator) finalize() error {
+	if t.projectRoot == "" {
+		return lsiftyped.ErrMissingMetadata
+	}
+
+	for _, relationship := range t.relationships {
+		target, ok := t.globalSymbols[relationship.relationship.Symbol]
+		if !ok {
+			// The target symbol never occurs in this index, so its results cannot be queried
+			continue
+		}
+
+		if err := t.emitRelationship(relationship.source, relationship.definitions, target, target.definitions, relationship.relationship); err != nil {
+			return err
+		}
+	}
+
+	externalSymbols := make([]string, 0, len(t.externalSymbols))
+	for name := range t.externalSymbols {
+		externalSymbols = append(externalSymbols, name)
+	}
+	sort.Strings(externalSymbols)
+
+	for _, name := range externalSymbols {
+		symbol, ok := t.globalSymbols[name]
+		if !ok || symbol.hasHover {
+			continue
+		}
+
+		if documentation := t.externalSymbols[name].Documentation; len(documentation) > 0 {
+			if err := t.emitHover(symbol.resultSetID, documentation); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range t.globalOrder {
+		if err := t.emitMoniker(name, t.globalSymbols[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// symbol returns the symbol with the given name, emitting a new result set if the symbol
+// has not been seen before.
+func (t *typedTranslator) symbol(name string, localSymbols map[string]*typedSymbol) (*typedSymbol, error) {
+	if symbol, ok := t.lookup(name, localSymbols); ok {
+		return symbol, nil
+	}
+
+	if !lsiftyped.IsLocalSymbol(name) {
+		if _, err := lsiftyped.ParseSymbol(name); err != nil {
+			return nil, err
+		}
+	}
+
+	resultSetID, err := t.emitVertex("resultSet", nil)
+	if err != nil {
+		return nil, err
+	}
+	symbol := &typedSymbol{resultSetID: resultSetID, global: !lsiftyped.IsLocalSymbol(name)}
+
+	if !symbol.global {
+		localSymbols[name] = symbol
+	} else {
+		t.globalSymbols[name] = symbol
+		t.globalOrder = append(t.globalOrder, name)
+	}
+
+	return symbol, nil
+}
+
+// lookup returns the previously seen symbol with the given name.
+func (t *typedTranslator) lookup(name string, localSymbols map[string]*typedSymbol) (*typedSymbol, bool) {
+	if lsiftyped.IsLocalSymbol(name) {
+		symbol, ok := localSymbols[name]
+		return symbol, ok
+	}
+
+	symbol, ok := t.globalSymbols[name]
+	return symbol, ok
+}
+
+// emitRelationship emits the item edges that encode the given relationship from source to target.
+func (t *typedTranslator) emitRelationship(source *typedSymbol, sourceDefinitions typedLocations, target *typedSymbol, targetDefinitions []typedLocations, relationship *lsiftyped.Relationship) error {
+	if relationship.IsReference && len(sourceDefinitions.rangeIDs) > 0 {
+		if err := t.emitItems(&target.referenceResultID, "referenceResult", "textDocument/references", target, sourceDefinitions.documentID, sourceDefinitions.rangeIDs); err != nil {
+			return err
+		}
+	}
+
+	if relationship.IsImplementation && len(sourceDefinitions.rangeIDs) > 0 {
+		if err := t.emitItems(&target.implementationResultID, "implementationResult", "textDocument/implementation", target, sourceDefinitions.documentID, sourceDefinitions.rangeIDs); err != nil {
+			return err
+		}
+	}
+
+	if relationship.IsTypeDefinition {
+		for _, locations := range targetDefinitions {
+			if len(locations.rangeIDs) == 0 {
+				continue
+			}
+
+			if err := t.emitItems(&source.typeDefinitionResultID, "typeDefinitionResult", "textDocument/typeDefinition", source, locations.documentID, locations.rangeIDs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// emitItems emits an item edge from the result identified by resultID to the given ranges. If
+// resultID is zero, a new result vertex with the given label is first emitted and attached to
+// the result set of the given symbol.
+func (t *typedTranslator) emitItems(resultID *int, vertexLabel, edgeLabel string, symbol *typedSymbol, documentID int, rangeIDs []int) error {
+	if *resultID == 0 {
+		id, err := t.emitVertex(vertexLabel, nil)
+		if err != nil {
+			return err
+		}
+		if err := t.emitEdge(edgeLabel, Edge{OutV: symbol.resultSetID, InV: id}); err != nil {
+			return err
+		}
+
+		*resultID = id
+	}
+
+	return t.emitEdge("item", Edge{OutV: *resultID, InVs: rangeIDs, Document: documentID})
+}
+
+// emitHover emits a hover result with the given documentation attached to the given range or result set.
+func (t *typedTranslator) emitHover(outV int, documentation []string) error {
+	hoverResultID, err := t.emitVertex("hoverResult", strings.Join(documentation, HoverPartSeparator))
+	if err != nil {
+		return err
+	}
+
+	return t.emitEdge("textDocument/hover", Edge{OutV: outV, InV: hoverResultID})
+}
+
+// emitMoniker emits a moniker and package information for the given global symbol. Symbols
+// defined within the index are exported; all others are imported.
+func (t *typedTranslator) emitMoniker(name string, symbol *typedSymbol) error {
+	parsed, err := lsiftyped.ParseSymbol(name)
+	if err != nil {
+		return err
+	}
+
+	kind := "import"
+	if len(symbol.definitions) > 0 {
+		kind = "export"
+	}
+
+	monikerID, err := t.emitVertex("moniker", Moniker{
+		Kind:       kind,
+		Scheme:     parsed.Scheme,
+		Identifier: parsed.Descriptor,
+	})
+	if err != nil {
+		return err
+	}
+	if err := t.emitEdge("moniker", Edge{OutV: symbol.resultSetID, InV: monikerID}); err != nil {
+		return err
+	}
+
+	if parsed.Package.Name == "" {
+		return nil
+	}
+
+	packageInformationID, ok := t.packages[parsed.Package]
+	if !ok {
+		if packageInformationID, err = t.emitVertex("packageInformation", PackageInformation{
+			Name:    parsed.Package.Name,
+			Version: parsed.Package.Version,
+		}); err != nil {
+			return err
+		}
+
+		t.packages[parsed.Package] = packageInformationID
+	}
+
+	return t.emitEdge("packageInformation", Edge{OutV: monikerID, InV: packageInformationID})
+}
+
+func (t *typedTranslator) emitVertex(label string, payload interface{}) (int, error) {
+	return t.emit("vertex", label, payload)
+}
+
+func (t *typedTranslator) emitEdge(label string, edge Edge) error {
+	_, err := t.emit("edge", label, edge)
+	return err
+}
+
+func (t *typedTranslator) emit(typ, label string, payload interface{}) (int, error) {
+	t.id++
+	element := Element{ID: t.id, Type: typ, Label: label, Payload: payload}
+
+	select {
+	case t.pairs <- Pair{Element: element}:
+		return t.id, nil
+	case <-t.ctx.Done():
+		return 0, t.ctx.Err()
+	}
+}
@@ -0,0 +1,3 @@
+package lsiftyped
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative lsif.proto
@@ -0,0 +1,202 @@
+package lsiftyped
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrMissingMetadata occurs when an index does not begin with a metadata field.
+var ErrMissingMetadata = errors.New("index does not begin with metadata")
+
+// IndexVisitor receives the top-level fields of an index as they are decoded by
+// ParseStreaming. Nil functions are skipped.
+type IndexVisitor struct {
+	VisitMetadata       func(metadata *Metadata) error
+	VisitDocument       func(document *Document) error
+	VisitExternalSymbol func(symbol *SymbolInformation) error
+}
+
+// ParseStreaming decodes the protobuf-encoded Index read from r and invokes the visitor
+// functions on each top-level field in the order in which they occur. Only a single
+// document is decoded at a time, so the memory required to read an index is bounded by
+// its largest document rather than by its total size.
+func (v *IndexVisitor) ParseStreaming(r io.Reader) error {
+	br := bufio.NewReader(r)
+
+	for {
+		tag, err := binary.ReadUvarint(br)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return errors.Wrap(err, "reading field tag")
+		}
+
+		number, typ := protowire.DecodeTag(tag)
+		if typ != protowire.BytesType {
+			if err := skipField(br, typ); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		payload, err := readBytes(br)
+		if err != nil {
+			return err
+		}
+
+		if err := v.visit(number, payload); err != nil {
+			return err
+		}
+	}
+}
+
+// visit decodes the given payload of the Index field with the given number and invokes
+// the matching visitor function.
+func (v *IndexVisitor) visit(number protowire.Number, payload []byte) error {
+	switch number {
+	case 1:
+		if v.VisitMetadata == nil {
+			return nil
+		}
+
+		var metadata Metadata
+		if err := proto.Unmarshal(payload, &metadata); err != nil {
+			return errors.Wrap(err, "decoding metadata")
+		}
+
+		return v.VisitMetadata(&metadata)
+
+	case 2:
+		if v.VisitDocument == nil {
+			return nil
+		}
+
+		var document Document
+		if err := proto.Unmarshal(payload, &document); err != nil {
+			return errors.Wrap(err, "decoding document")
+		}
+
+		return v.VisitDocument(&document)
+
+	case 3:
+		if v.VisitExternalSymbol == nil {
+			return nil
+		}
+
+		var symbol SymbolInformation
+		if err := proto.Unmarshal(payload, &symbol); err != nil {
+			return errors.Wrap(err, "decoding external symbol")
+		}
+
+		return v.VisitExternalSymbol(&symbol)
+	}
+
+	// Unknown fields are skipped for forwards compatibility
+	return nil
+}
+
+// readBytes reads a length-prefixed payload from the given reader.
+func readBytes(br *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading field length")
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		return nil, errors.Wrap(err, "reading field payload")
+	}
+
+	return payload, nil
+}
+
+// skipField discards the value of a non-length-prefixed field with the given type.
+func skipField(br *bufio.Reader, typ protowire.Type) (err error) {
+	switch typ {
+	case protowire.VarintType:
+		_, err = binary.ReadUvarint(br)
+	case protowire.Fixed32Type:
+		_, err = br.Discard(4)
+	case protowire.Fixed64Type:
+		_, err = br.Discard(8)
+	default:
+		return errors.Errorf("unsupported wire type %d", typ)
+	}
+
+	return errors.Wrap(err, "skipping field")
+}
+
+// errStopParsing is returned from a visitor function to halt ParseStreaming early.
+var errStopParsing = errors.New("stop parsing")
+
+// ReadMetadata returns the metadata of the protobuf-encoded index read from r. Only the
+// first field of the index is read, which must be the metadata field.
+func ReadMetadata(r io.Reader) (*Metadata, error) {
+	var metadata *Metadata
+	visitor := &IndexVisitor{
+		VisitMetadata: func(m *Metadata) error {
+			metadata = m
+			return errStopParsing
+		},
+		VisitDocument: func(document *Document) error {
+			return ErrMissingMetadata
+		},
+		VisitExternalSymbol: func(symbol *SymbolInformation) error {
+			return ErrMissingMetadata
+		},
+	}
+
+	if err := visitor.ParseStreaming(r); err != nil && err != errStopParsing {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, ErrMissingMetadata
+	}
+
+	return metadata, nil
+}
+
+// maxSniffLength is the number of bytes IsIndex peeks at to determine the format of an index.
+const maxSniffLength = 64
+
+// IsIndex reports whether the content buffered by the given reader is a protobuf-encoded
+// index rather than line-delimited LSIF JSON. No content is consumed from the reader.
+func IsIndex(br *bufio.Reader) bool {
+	prefix, _ := br.Peek(maxSniffLength)
+	if len(prefix) == 0 {
+		return false
+	}
+
+	// An encoded index starts with the tag of a length-prefixed field of the Index message
+	if number, typ := protowire.DecodeTag(uint64(prefix[0])); typ != protowire.BytesType || number < 1 || number > 3 {
+		return false
+	}
+
+	// The first tag byte is also a newline, so a JSON object preceded by blank lines would
+	// pass the check above. A JSON object starts with an opening brace followed by either a
+	// quoted key or a closing brace, which can't occur in the prefix of an encoded index.
+	if i := skipWhitespace(prefix, 0); i < len(prefix) && prefix[i] == '{' {
+		if j := skipWhitespace(prefix, i+1); j < len(prefix) && (prefix[j] == '"' || prefix[j] == '}') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// skipWhitespace returns the index of the first non-whitespace byte of prefix at or after i.
+func skipWhitespace(prefix []byte, i int) int {
+	for i < len(prefix) && (prefix[i] == ' ' || prefix[i] == '\t' || prefix[i] == '\r' || prefix[i] == '\n') {
+		i++
+	}
+
+	return i
+}
@@ -0,0 +1,123 @@
+package lsiftyped
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseStreaming(t *testing.T) {
+	// Encoded indexes may be concatenated; the documents of each are visited in order
+	var buf bytes.Buffer
+	for _, index := range []*Index{
+		{Metadata: &Metadata{ToolInfo: &ToolInfo{Name: "lsif-test"}, ProjectRoot: "file:///root"}},
+		{Documents: []*Document{{RelativePath: "a.go"}, {RelativePath: "b.go"}}},
+		{ExternalSymbols: []*SymbolInformation{{Symbol: "gomod . dep v1 Bar."}}},
+		{Documents: []*Document{{RelativePath: "c.go"}}},
+	} {
+		payload, err := proto.Marshal(index)
+		if err != nil {
+			t.Fatalf("unexpected error marshalling index: %s", err)
+		}
+		buf.Write(payload)
+	}
+
+	var visited []string
+	visitor := &IndexVisitor{
+		VisitMetadata: func(metadata *Metadata) error {
+			visited = append(visited, "metadata:"+metadata.ToolInfo.Name)
+			return nil
+		},
+		VisitDocument: func(document *Document) error {
+			visited = append(visited, "document:"+document.RelativePath)
+			return nil
+		},
+		VisitExternalSymbol: func(symbol *SymbolInformation) error {
+			visited = append(visited, "symbol:"+symbol.Symbol)
+			return nil
+		},
+	}
+	if err := visitor.ParseStreaming(&buf); err != nil {
+		t.Fatalf("unexpected error parsing index: %s", err)
+	}
+
+	expected := []string{
+		"metadata:lsif-test",
+		"document:a.go",
+		"document:b.go",
+		"symbol:gomod . dep v1 Bar.",
+		"document:c.go",
+	}
+	if diff := cmp.Diff(expected, visited); diff != "" {
+		t.Errorf("unexpected visited fields (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseStreamingTruncated(t *testing.T) {
+	payload, err := proto.Marshal(&Index{Documents: []*Document{{RelativePath: "a.go"}}})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	visitor := &IndexVisitor{}
+	if err := visitor.ParseStreaming(bytes.NewReader(payload[:len(payload)-1])); err == nil {
+		t.Fatalf("expected an error parsing truncated index")
+	}
+}
+
+func TestReadMetadata(t *testing.T) {
+	payload, err := proto.Marshal(&Index{
+		Metadata:  &Metadata{ToolInfo: &ToolInfo{Name: "lsif-test"}},
+		Documents: []*Document{{RelativePath: "a.go"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	metadata, err := ReadMetadata(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("unexpected error reading metadata: %s", err)
+	}
+	if name := metadata.ToolInfo.Name; name != "lsif-test" {
+		t.Errorf("unexpected tool name. want=%q have=%q", "lsif-test", name)
+	}
+
+	payload, err = proto.Marshal(&Index{Documents: []*Document{{RelativePath: "a.go"}}})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+	if _, err := ReadMetadata(bytes.NewReader(payload)); err != ErrMissingMetadata {
+		t.Errorf("unexpected error. want=%q have=%q", ErrMissingMetadata, err)
+	}
+}
+
+func TestIsIndex(t *testing.T) {
+	payload, err := proto.Marshal(&Index{Metadata: &Metadata{ToolInfo: &ToolInfo{Name: strings.Repeat("x", 0x7b)}}})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	testCases := []struct {
+		name     string
+		content  []byte
+		expected bool
+	}{
+		{name: "index", content: payload, expected: true},
+		{name: "lsif", content: []byte(`{"id":1,"type":"vertex","label":"metaData"}` + "\n"), expected: false},
+		{name: "lsif with blank lines", content: []byte("\n\n" + `{ "id": 1 }`), expected: false},
+		{name: "empty", content: nil, expected: false},
+		{name: "garbage", content: []byte("garbage"), expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if isIndex := IsIndex(bufio.NewReader(bytes.NewReader(testCase.content))); isIndex != testCase.expected {
+				t.Errorf("unexpected result. want=%v have=%v", testCase.expected, isIndex)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package lsiftyped
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Package identifies the package that defines a global symbol.
+type Package struct {
+	Manager string
+	Name    string
+	Version string
+}
+
+// Symbol is the parsed form of a global symbol name.
+type Symbol struct {
+	Scheme     string
+	Package    Package
+	Descriptor string
+}
+
+// IsLocalSymbol returns true if the given symbol can be referenced only from within the
+// document that defines it.
+func IsLocalSymbol(symbol string) bool {
+	return strings.HasPrefix(symbol, "local ")
+}
+
+// ParseSymbol parses the given global symbol name of the form
+// `<scheme> <manager> <package-name> <version> <descriptor>`.
+func ParseSymbol(symbol string) (Symbol, error) {
+	parts := strings.SplitN(symbol, " ", 5)
+	if len(parts) != 5 {
+		return Symbol{}, errors.Errorf("malformed symbol %q: expected five space-separated fields", symbol)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return Symbol{}, errors.Errorf("malformed symbol %q: empty field", symbol)
+		}
+	}
+
+	return Symbol{
+		Scheme: parts[0],
+		Package: Package{
+			Manager: dotToEmpty(parts[1]),
+			Name:    dotToEmpty(parts[2]),
+			Version: dotToEmpty(parts[3]),
+		},
+		Descriptor: parts[4],
+	}, nil
+}
+
+// String returns the symbol name of the symbol.
+func (s Symbol) String() string {
+	return strings.Join([]string{
+		s.Scheme,
+		emptyToDot(s.Package.Manager),
+		emptyToDot(s.Package.Name),
+		emptyToDot(s.Package.Version),
+		s.Descriptor,
+	}, " ")
+}
+
+func dotToEmpty(s string) string {
+	if s == "." {
+		return ""
+	}
+
+	return s
+}
+
+func emptyToDot(s string) string {
+	if s == "" {
+		return "."
+	}
+
+	return s
+}
+
+// Position is a zero-based line and character offset within a document.
+type Position struct {
+	Line      int
+	Character int
+}
+
+// Range is a half-open range within a document.
+type Range struct {
+	Start Position
+	End   Position
+}
+
+// ParseRange decodes the given range of an occurrence, which has either the form
+// `[startLine, startCharacter, endLine, endCharacter]` or `[line, startCharacter, endCharacter]`.
+func ParseRange(r []int32) (Range, error) {
+	switch len(r) {
+	case 3:
+		return Range{
+			Start: Position{Line: int(r[0]), Character: int(r[1])},
+			End:   Position{Line: int(r[0]), Character: int(r[2])},
+		}, nil
+
+	case 4:
+		return Range{
+			Start: Position{Line: int(r[0]), Character: int(r[1])},
+			End:   Position{Line: int(r[2]), Character: int(r[3])},
+		}, nil
+	}
+
+	return Range{}, errors.Errorf("malformed range %v: expected three or four elements", r)
+}
@@ -0,0 +1,64 @@
+package lsiftyped
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSymbol(t *testing.T) {
+	symbol, err := ParseSymbol("npm npm lodash 4.17.21 lodash/`pad left`().")
+	if err != nil {
+		t.Fatalf("unexpected error parsing symbol: %s", err)
+	}
+
+	expected := Symbol{
+		Scheme:     "npm",
+		Package:    Package{Manager: "npm", Name: "lodash", Version: "4.17.21"},
+		Descriptor: "lodash/`pad left`().",
+	}
+	if diff := cmp.Diff(expected, symbol); diff != "" {
+		t.Errorf("unexpected symbol (-want +got):\n%s", diff)
+	}
+	if s := symbol.String(); s != "npm npm lodash 4.17.21 lodash/`pad left`()." {
+		t.Errorf("unexpected symbol name %q", s)
+	}
+
+	symbol, err = ParseSymbol("gomod . github.com/test/pkg . Foo#")
+	if err != nil {
+		t.Fatalf("unexpected error parsing symbol: %s", err)
+	}
+	if symbol.Package.Manager != "" || symbol.Package.Version != "" {
+		t.Errorf("expected empty manager and version, have %+v", symbol.Package)
+	}
+
+	for _, malformed := range []string{"", "local 0", "gomod pkg Foo#", "gomod  pkg v1 Foo#"} {
+		if _, err := ParseSymbol(malformed); err == nil {
+			t.Errorf("expected an error parsing symbol %q", malformed)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	testCases := []struct {
+		encoded  []int32
+		expected Range
+	}{
+		{encoded: []int32{1, 2, 5}, expected: Range{Start: Position{1, 2}, End: Position{1, 5}}},
+		{encoded: []int32{1, 2, 3, 4}, expected: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+	}
+
+	for _, testCase := range testCases {
+		r, err := ParseRange(testCase.encoded)
+		if err != nil {
+			t.Fatalf("unexpected error parsing range: %s", err)
+		}
+		if diff := cmp.Diff(testCase.expected, r); diff != "" {
+			t.Errorf("unexpected range (-want +got):\n%s", diff)
+		}
+	}
+
+	if _, err := ParseRange([]int32{1, 2}); err == nil {
+		t.Errorf("expected an error parsing malformed range")
+	}
+}
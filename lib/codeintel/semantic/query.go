@@ -3,10 +3,12 @@ package semantic
 import "github.com/cockroachdb/errors"
 
 type QueryResult struct {
-	Definitions []LocationData
-	References  []LocationData
-	Hover       string
-	Monikers    []QualifiedMonikerData
+	Definitions     []LocationData
+	References      []LocationData
+	Implementations []LocationData
+	TypeDefinitions []LocationData
+	Hover           string
+	Monikers        []QualifiedMonikerData
 }
 
 func Query(bundle *GroupedBundleDataMaps, path string, line, character int) ([]QueryResult, error) {
@@ -35,10 +37,12 @@ func Resolve(bundle *GroupedBundleDataMaps, document DocumentData, rng RangeData
 	}
 
 	return QueryResult{
-		Definitions: resolveLocations(bundle, rng.DefinitionResultID),
-		References:  resolveLocations(bundle, rng.ReferenceResultID),
-		Hover:       hover,
-		Monikers:    monikers,
+		Definitions:     resolveLocations(bundle, rng.DefinitionResultID),
+		References:      resolveLocations(bundle, rng.ReferenceResultID),
+		Implementations: resolveLocations(bundle, rng.ImplementationResultID),
+		TypeDefinitions: resolveLocations(bundle, rng.TypeDefinitionResultID),
+		Hover:           hover,
+		Monikers:        monikers,
 	}
 }
 
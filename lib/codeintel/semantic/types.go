@@ -25,15 +25,17 @@ type DocumentData struct {
 // that was reachable via a result set has been collapsed into this object during
 // conversion.
 type RangeData struct {
-	StartLine             int  // 0-indexed, inclusive
-	StartCharacter        int  // 0-indexed, inclusive
-	EndLine               int  // 0-indexed, inclusive
-	EndCharacter          int  // 0-indexed, inclusive
-	DefinitionResultID    ID   // possibly empty
-	ReferenceResultID     ID   // possibly empty
-	HoverResultID         ID   // possibly empty
-	DocumentationResultID ID   // possibly empty
-	MonikerIDs            []ID // possibly empty
+	StartLine              int  // 0-indexed, inclusive
+	StartCharacter         int  // 0-indexed, inclusive
+	EndLine                int  // 0-indexed, inclusive
+	EndCharacter           int  // 0-indexed, inclusive
+	DefinitionResultID     ID   // possibly empty
+	ReferenceResultID      ID   // possibly empty
+	ImplementationResultID ID   // possibly empty
+	TypeDefinitionResultID ID   // possibly empty
+	HoverResultID          ID   // possibly empty
+	DocumentationResultID  ID   // possibly empty
+	MonikerIDs             []ID // possibly empty
 }
 
 // MonikerData represent a unique name (eventually) attached to a range.
@@ -210,11 +212,18 @@ type GroupedBundleDataChans struct {
 	ResultChunks          chan IndexedResultChunkData
 	Definitions           chan MonikerLocations
 	References            chan MonikerLocations
+	Implementations       chan MonikerLocations
 	Packages              []Package
 	PackageReferences     []PackageReference
 	DocumentationPages    chan *DocumentationPageData
 	DocumentationPathInfo chan *DocumentationPathInfoData
 	DocumentationMappings chan DocumentationMapping
+
+	// Anchors is populated only for incremental bundles, which contain the documents changed
+	// since a base bundle. It holds the ranges of unchanged documents that occur in the result
+	// sets of the bundle, keyed by document path and range identifier. Anchors link each result
+	// set to its counterpart in the base bundle.
+	Anchors map[string]map[ID]RangeData
 }
 
 type GroupedBundleDataMaps struct {
@@ -223,6 +232,8 @@ type GroupedBundleDataMaps struct {
 	ResultChunks      map[int]ResultChunkData
 	Definitions       map[string]map[string][]LocationData
 	References        map[string]map[string][]LocationData
+	Implementations   map[string]map[string][]LocationData
 	Packages          []Package
 	PackageReferences []PackageReference
+	Anchors           map[string]map[ID]RangeData
 }
@@ -178,32 +178,28 @@ func GroupedBundleDataMapsToChans(ctx context.Context, maps *GroupedBundleDataMa
 			}
 		}
 	}()
-	monikerDefsChan := make(chan MonikerLocations)
-	go func() {
-		defer close(monikerDefsChan)
+	return &GroupedBundleDataChans{
+		Meta:              maps.Meta,
+		Documents:         documentChan,
+		ResultChunks:      resultChunkChan,
+		Definitions:       monikerLocationsMapToChan(ctx, maps.Definitions),
+		References:        monikerLocationsMapToChan(ctx, maps.References),
+		Implementations:   monikerLocationsMapToChan(ctx, maps.Implementations),
+		Packages:          maps.Packages,
+		PackageReferences: maps.PackageReferences,
+		Anchors:           maps.Anchors,
+	}
+}
 
-		for scheme, identMap := range maps.Definitions {
-			for ident, locations := range identMap {
-				select {
-				case monikerDefsChan <- MonikerLocations{
-					Scheme:     scheme,
-					Identifier: ident,
-					Locations:  locations,
-				}:
-				case <-ctx.Done():
-					return
-				}
-			}
-		}
-	}()
-	monikerRefsChan := make(chan MonikerLocations)
+func monikerLocationsMapToChan(ctx context.Context, m map[string]map[string][]LocationData) chan MonikerLocations {
+	ch := make(chan MonikerLocations)
 	go func() {
-		defer close(monikerRefsChan)
+		defer close(ch)
 
-		for scheme, identMap := range maps.References {
+		for scheme, identMap := range m {
 			for ident, locations := range identMap {
 				select {
-				case monikerRefsChan <- MonikerLocations{
+				case ch <- MonikerLocations{
 					Scheme:     scheme,
 					Identifier: ident,
 					Locations:  locations,
@@ -215,15 +211,7 @@ func GroupedBundleDataMapsToChans(ctx context.Context, maps *GroupedBundleDataMa
 		}
 	}()
 
-	return &GroupedBundleDataChans{
-		Meta:              maps.Meta,
-		Documents:         documentChan,
-		ResultChunks:      resultChunkChan,
-		Definitions:       monikerDefsChan,
-		References:        monikerRefsChan,
-		Packages:          maps.Packages,
-		PackageReferences: maps.PackageReferences,
-	}
+	return ch
 }
 
 // CAUTION: Data is not deep copied.
@@ -236,28 +224,29 @@ func GroupedBundleDataChansToMaps(chans *GroupedBundleDataChans) *GroupedBundleD
 	for indexedResultChunk := range chans.ResultChunks {
 		resultChunkMap[indexedResultChunk.Index] = indexedResultChunk.ResultChunk
 	}
-	monikerDefsMap := make(map[string]map[string][]LocationData)
-	for monikerDefs := range chans.Definitions {
-		if _, exists := monikerDefsMap[monikerDefs.Scheme]; !exists {
-			monikerDefsMap[monikerDefs.Scheme] = make(map[string][]LocationData)
-		}
-		monikerDefsMap[monikerDefs.Scheme][monikerDefs.Identifier] = monikerDefs.Locations
-	}
-	monikerRefsMap := make(map[string]map[string][]LocationData)
-	for monikerRefs := range chans.References {
-		if _, exists := monikerRefsMap[monikerRefs.Scheme]; !exists {
-			monikerRefsMap[monikerRefs.Scheme] = make(map[string][]LocationData)
-		}
-		monikerRefsMap[monikerRefs.Scheme][monikerRefs.Identifier] = monikerRefs.Locations
-	}
-
 	return &GroupedBundleDataMaps{
 		Meta:              chans.Meta,
 		Documents:         documentMap,
 		ResultChunks:      resultChunkMap,
-		Definitions:       monikerDefsMap,
-		References:        monikerRefsMap,
+		Definitions:       monikerLocationsChanToMap(chans.Definitions),
+		References:        monikerLocationsChanToMap(chans.References),
+		Implementations:   monikerLocationsChanToMap(chans.Implementations),
 		Packages:          chans.Packages,
 		PackageReferences: chans.PackageReferences,
+		Anchors:           chans.Anchors,
+	}
+}
+
+func monikerLocationsChanToMap(ch chan MonikerLocations) map[string]map[string][]LocationData {
+	m := make(map[string]map[string][]LocationData)
+	if ch == nil {
+		return m
+	}
+	for monikerLocations := range ch {
+		if _, exists := m[monikerLocations.Scheme]; !exists {
+			m[monikerLocations.Scheme] = make(map[string][]LocationData)
+		}
+		m[monikerLocations.Scheme][monikerLocations.Identifier] = monikerLocations.Locations
 	}
+	return m
 }
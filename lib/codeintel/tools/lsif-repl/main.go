@@ -182,6 +182,22 @@ func queryBundle(bundle *semantic.GroupedBundleDataMaps, path string, line, char
 			fmt.Printf("Reference:    %v:%v:(%v, %v)\n", reference.URI, reference.StartLine, reference.StartCharacter, reference.EndCharacter)
 		}
 
+		for idx, implementation := range result.Implementations {
+			if idx >= 10 {
+				fmt.Printf("Abridging implementations...\n")
+				break
+			}
+			fmt.Printf("Implementation: %v:%v:(%v, %v)\n", implementation.URI, implementation.StartLine, implementation.StartCharacter, implementation.EndCharacter)
+		}
+
+		for idx, typeDefinition := range result.TypeDefinitions {
+			if idx >= 10 {
+				fmt.Printf("Abridging type definitions...\n")
+				break
+			}
+			fmt.Printf("TypeDefinition: %v:%v:(%v, %v)\n", typeDefinition.URI, typeDefinition.StartLine, typeDefinition.StartCharacter, typeDefinition.EndCharacter)
+		}
+
 		for idx, moniker := range result.Monikers {
 			if idx >= 10 {
 				fmt.Printf("Abridging monikers...\n")
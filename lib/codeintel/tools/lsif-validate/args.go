@@ -20,7 +20,7 @@ func init() {
 	app.VersionFlag.Short('v')
 	app.HelpFlag.Hidden()
 
-	app.Arg("index-file", "The LSIF index to validate.").Default("dump.lsif").FileVar(&indexFile)
+	app.Arg("index-file", "The LSIF index to validate, either as LSIF JSON or in the protobuf format.").Default("dump.lsif").FileVar(&indexFile)
 }
 
 func parseArgs(args []string) (err error) {
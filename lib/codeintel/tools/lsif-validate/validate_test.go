@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/validation"
+)
+
+func TestValidateTyped(t *testing.T) {
+	indexFile, err := os.Open("../../lsif/testdata/typed1.lsif")
+	if err != nil {
+		t.Fatalf("unexpected error opening index: %s", err)
+	}
+	defer indexFile.Close()
+
+	if err := validate(indexFile); err != nil {
+		t.Fatalf("unexpected error validating index: %s", err)
+	}
+
+	// Ensure the protobuf index was actually converted rather than read as an
+	// empty JSON lines stream.
+	if _, err := indexFile.Seek(0, 0); err != nil {
+		t.Fatalf("unexpected error rewinding index: %s", err)
+	}
+	ctx := validation.NewValidationContext()
+	if err := (&validation.Validator{Context: ctx}).Validate(indexFile); err != nil {
+		t.Fatalf("unexpected error validating index: %s", err)
+	}
+	if ctx.NumVertices == 0 || ctx.NumEdges == 0 {
+		t.Errorf("expected vertices and edges, got %d vertices and %d edges", ctx.NumVertices, ctx.NumEdges)
+	}
+}
@@ -27,7 +27,7 @@ func init() {
 	app.Flag("depth", "Depth limit of the subgraph to be output").Default("-1").IntVar(&subgraphDepth)
 	app.Flag("exclude", "Vertices to exclude from the visualization").StringsVar(&exclude)
 
-	app.Arg("index-file", "The LSIF index to visualize.").Default("dump.lsif").FileVar(&indexFile)
+	app.Arg("index-file", "The LSIF index to visualize, either as LSIF JSON or in the protobuf format.").Default("dump.lsif").FileVar(&indexFile)
 }
 
 func parseArgs(args []string) (err error) {
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVisualizeTyped(t *testing.T) {
+	indexFile, err := os.Open("../../lsif/testdata/typed1.lsif")
+	if err != nil {
+		t.Fatalf("unexpected error opening index: %s", err)
+	}
+	defer indexFile.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error creating pipe: %s", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		contents, _ := io.ReadAll(r)
+		output <- string(contents)
+	}()
+
+	err = visualize(indexFile, 2, -1, nil)
+	w.Close()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error visualizing index: %s", err)
+	}
+
+	dot := <-output
+	if !strings.HasPrefix(dot, "digraph G {") {
+		t.Errorf("expected DOT output, got %q", dot)
+	}
+	for _, label := range []string{"document", "range", "moniker"} {
+		if !strings.Contains(dot, label) {
+			t.Errorf("expected output to contain %q:\n%s", label, dot)
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsiftyped"
 )
 
 // MaxBufferSize is the maximum size of the metaData line in the dump. This should be large enough
@@ -31,11 +33,20 @@ type toolInfo struct {
 	Name string `json:"name"`
 }
 
+// ErrInvalidMetadata occurs when a protobuf-encoded index does not begin with valid metadata.
+var ErrInvalidMetadata = errors.New("invalid metadata")
+
 // ReadIndexerName returns the name of the tool that generated the given index contents.
 // This function reads only the first line of the file, where the metadata vertex is
-// assumed to be in all valid dumps.
+// assumed to be in all valid dumps. Protobuf-encoded indexes are detected automatically,
+// in which case only the leading metadata field is read.
 func ReadIndexerName(r io.Reader) (string, error) {
-	line, isPrefix, err := bufio.NewReaderSize(r, MaxBufferSize).ReadLine()
+	br := bufio.NewReaderSize(r, MaxBufferSize)
+	if lsiftyped.IsIndex(br) {
+		return readTypedIndexerName(br)
+	}
+
+	line, isPrefix, err := br.ReadLine()
 	if err != nil {
 		return "", err
 	}
@@ -54,3 +65,18 @@ func ReadIndexerName(r io.Reader) (string, error) {
 
 	return meta.ToolInfo.Name, nil
 }
+
+// readTypedIndexerName returns the name of the tool that generated the given protobuf-encoded
+// index contents. The metadata is read from at most the first MaxBufferSize bytes of the index.
+func readTypedIndexerName(r io.Reader) (string, error) {
+	metadata, err := lsiftyped.ReadMetadata(io.LimitReader(r, MaxBufferSize))
+	if err != nil {
+		return "", ErrInvalidMetadata
+	}
+
+	if metadata.ToolInfo.GetName() == "" {
+		return "", ErrInvalidMetadata
+	}
+
+	return metadata.ToolInfo.GetName(), nil
+}
@@ -5,6 +5,10 @@ import (
 	"io"
 	"strings"
 	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsiftyped"
 )
 
 const testMetaDataVertex = `{"label": "metaData", "toolInfo": {"name": "test"}}`
@@ -28,6 +32,37 @@ func TestReadIndexerNameMalformed(t *testing.T) {
 	}
 }
 
+func TestReadIndexerNameTyped(t *testing.T) {
+	payload, err := proto.Marshal(&lsiftyped.Index{
+		Metadata:  &lsiftyped.Metadata{ToolInfo: &lsiftyped.ToolInfo{Name: "test"}},
+		Documents: []*lsiftyped.Document{{RelativePath: "main.go"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	name, err := ReadIndexerName(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("unexpected error reading indexer name: %s", err)
+	}
+	if name != "test" {
+		t.Errorf("unexpected indexer name. want=%s have=%s", "test", name)
+	}
+}
+
+func TestReadIndexerNameTypedMalformed(t *testing.T) {
+	payload, err := proto.Marshal(&lsiftyped.Index{
+		Documents: []*lsiftyped.Document{{RelativePath: "main.go"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	if _, err := ReadIndexerName(bytes.NewReader(payload)); err != ErrInvalidMetadata {
+		t.Fatalf("unexpected error reading indexer name. want=%q have=%q", ErrInvalidMetadata, err)
+	}
+}
+
 func generateTestIndex(metaDataVertex string) io.Reader {
 	lines := []string{metaDataVertex}
 	for i := 0; i < 20000; i++ {
@@ -23,5 +23,6 @@ require (
 	github.com/moby/term v0.0.0-20210619224110-3f7ff695adc6
 	github.com/sourcegraph/jsonx v0.0.0-20200629203448-1a936bd500cf
 	golang.org/x/sys v0.0.0-20210616094352-59db8d763f22
+	google.golang.org/protobuf v1.26.0
 	gopkg.in/yaml.v2 v2.4.0
 )
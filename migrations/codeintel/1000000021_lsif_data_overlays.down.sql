@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP VIEW IF EXISTS lsif_data_result_chunks_with_overlays;
+DROP VIEW IF EXISTS lsif_data_documents_with_overlays;
+DROP TABLE IF EXISTS lsif_data_result_chunk_overlays;
+DROP TABLE IF EXISTS lsif_data_document_overlays;
+
+COMMIT;
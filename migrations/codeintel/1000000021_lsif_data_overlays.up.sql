@@ -0,0 +1,47 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_data_document_overlays (
+    dump_id integer NOT NULL,
+    path text NOT NULL,
+    source_dump_id integer NOT NULL,
+    PRIMARY KEY (dump_id, path)
+);
+
+COMMENT ON TABLE lsif_data_document_overlays IS 'Resolves the unchanged documents of an incremental dump to the dump that stores them.';
+COMMENT ON COLUMN lsif_data_document_overlays.dump_id IS 'The identifier of the incremental dump in the lsif_uploads table.';
+COMMENT ON COLUMN lsif_data_document_overlays.path IS 'The path of the text document relative to the associated dump root.';
+COMMENT ON COLUMN lsif_data_document_overlays.source_dump_id IS 'The identifier of the dump whose lsif_data_documents row holds the document.';
+
+CREATE TABLE IF NOT EXISTS lsif_data_result_chunk_overlays (
+    dump_id integer NOT NULL,
+    idx integer NOT NULL,
+    source_dump_id integer NOT NULL,
+    PRIMARY KEY (dump_id, idx)
+);
+
+COMMENT ON TABLE lsif_data_result_chunk_overlays IS 'Resolves the result chunks of an incremental dump that are not affected by its changed documents to the dump that stores them.';
+COMMENT ON COLUMN lsif_data_result_chunk_overlays.dump_id IS 'The identifier of the incremental dump in the lsif_uploads table.';
+COMMENT ON COLUMN lsif_data_result_chunk_overlays.idx IS 'The result chunk index within the incremental dump.';
+COMMENT ON COLUMN lsif_data_result_chunk_overlays.source_dump_id IS 'The identifier of the dump whose lsif_data_result_chunks row holds the result chunk.';
+
+CREATE VIEW lsif_data_documents_with_overlays AS
+    SELECT d.dump_id, d.path, d.data, d.schema_version, d.num_diagnostics, d.ranges, d.hovers, d.monikers, d.packages, d.diagnostics
+    FROM lsif_data_documents d
+    UNION ALL
+    SELECT o.dump_id, o.path, d.data, d.schema_version, d.num_diagnostics, d.ranges, d.hovers, d.monikers, d.packages, d.diagnostics
+    FROM lsif_data_document_overlays o
+    JOIN lsif_data_documents d ON d.dump_id = o.source_dump_id AND d.path = o.path;
+
+COMMENT ON VIEW lsif_data_documents_with_overlays IS 'The documents of every dump, including the documents an incremental dump shares with its base dump.';
+
+CREATE VIEW lsif_data_result_chunks_with_overlays AS
+    SELECT r.dump_id, r.idx, r.data
+    FROM lsif_data_result_chunks r
+    UNION ALL
+    SELECT o.dump_id, o.idx, r.data
+    FROM lsif_data_result_chunk_overlays o
+    JOIN lsif_data_result_chunks r ON r.dump_id = o.source_dump_id AND r.idx = o.idx;
+
+COMMENT ON VIEW lsif_data_result_chunks_with_overlays IS 'The result chunks of every dump, including the result chunks an incremental dump shares with its base dump.';
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS perms_sync_jobs (
+    id              SERIAL PRIMARY KEY,
+    request_type    TEXT NOT NULL,
+    user_id         INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    repo_id         INTEGER REFERENCES repo(id) ON DELETE CASCADE,
+    providers       TEXT[] NOT NULL DEFAULT '{}'::TEXT[],
+    started_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    added_ids       INTEGER[] NOT NULL DEFAULT '{}'::INTEGER[],
+    removed_ids     INTEGER[] NOT NULL DEFAULT '{}'::INTEGER[],
+    failure_message TEXT,
+
+    CONSTRAINT perms_sync_jobs_request_type_check CHECK (
+        (request_type = 'user' AND user_id IS NOT NULL) OR
+        (request_type = 'repo' AND repo_id IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS perms_sync_jobs_user_id_finished_at ON perms_sync_jobs (user_id, finished_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS perms_sync_jobs_repo_id_finished_at ON perms_sync_jobs (repo_id, finished_at DESC) WHERE repo_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS perms_sync_jobs_finished_at ON perms_sync_jobs (finished_at);
+
+COMMENT ON TABLE perms_sync_jobs IS 'Audit trail of permissions syncs performed by the permissions syncer of repo-updater.';
+COMMENT ON COLUMN perms_sync_jobs.request_type IS 'Whether the sync was user-centric ("user") or repository-centric ("repo").';
+COMMENT ON COLUMN perms_sync_jobs.providers IS 'The service IDs of the authz providers that were consulted during the sync.';
+COMMENT ON COLUMN perms_sync_jobs.added_ids IS 'The repository IDs (for user syncs) or user IDs (for repository syncs) that were granted access by the sync.';
+COMMENT ON COLUMN perms_sync_jobs.removed_ids IS 'The repository IDs (for user syncs) or user IDs (for repository syncs) whose access was revoked by the sync.';
+COMMENT ON COLUMN perms_sync_jobs.failure_message IS 'The error that occurred during the sync, if any.';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_perms_sync_requests_enqueue_follow_up ON perms_sync_requests;
+DROP FUNCTION IF EXISTS perms_sync_requests_enqueue_follow_up();
+DROP TABLE IF EXISTS perms_sync_requests;
+
+COMMIT;
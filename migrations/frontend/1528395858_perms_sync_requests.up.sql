@@ -0,0 +1,70 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS perms_sync_requests (
+    id                 SERIAL PRIMARY KEY,
+    state              TEXT NOT NULL DEFAULT 'queued',
+    failure_message    TEXT,
+    started_at         TIMESTAMP WITH TIME ZONE,
+    finished_at        TIMESTAMP WITH TIME ZONE,
+    process_after      TIMESTAMP WITH TIME ZONE,
+    num_resets         INTEGER NOT NULL DEFAULT 0,
+    num_failures       INTEGER NOT NULL DEFAULT 0,
+    execution_logs     JSON[],
+    worker_hostname    TEXT NOT NULL DEFAULT '',
+    last_heartbeat_at  TIMESTAMP WITH TIME ZONE,
+    request_type       TEXT NOT NULL,
+    user_id            INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    repo_id            INTEGER REFERENCES repo(id) ON DELETE CASCADE,
+    priority           INTEGER NOT NULL DEFAULT 0,
+    reason             TEXT NOT NULL,
+    no_perms           BOOLEAN NOT NULL DEFAULT FALSE,
+    queued_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    follow_up_priority INTEGER,
+    follow_up_reason   TEXT,
+    follow_up_no_perms BOOLEAN NOT NULL DEFAULT FALSE,
+
+    CONSTRAINT perms_sync_requests_request_type_check CHECK (
+        (request_type = 'user' AND user_id IS NOT NULL) OR
+        (request_type = 'repo' AND repo_id IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS perms_sync_requests_state ON perms_sync_requests (state);
+CREATE INDEX IF NOT EXISTS perms_sync_requests_priority_queued_at ON perms_sync_requests (priority DESC, queued_at) WHERE state = 'queued';
+
+-- Only one pending request may exist for a given user or repository at any time,
+-- enqueuing a duplicate request bumps the priority of the existing one instead.
+CREATE UNIQUE INDEX IF NOT EXISTS perms_sync_requests_user_id_pending ON perms_sync_requests (user_id) WHERE state IN ('queued', 'processing');
+CREATE UNIQUE INDEX IF NOT EXISTS perms_sync_requests_repo_id_pending ON perms_sync_requests (repo_id) WHERE state IN ('queued', 'processing');
+
+COMMENT ON TABLE perms_sync_requests IS 'Queue of permissions sync requests processed by the permissions syncer of repo-updater.';
+COMMENT ON COLUMN perms_sync_requests.request_type IS 'Whether the sync is user-centric ("user") or repository-centric ("repo").';
+COMMENT ON COLUMN perms_sync_requests.priority IS 'Requests with a higher priority are processed first.';
+COMMENT ON COLUMN perms_sync_requests.reason IS 'Why the request was enqueued, one of "manual", "webhook", "no_perms" or "oldest_perms".';
+COMMENT ON COLUMN perms_sync_requests.no_perms IS 'Whether the user or repository had no permissions when the request was enqueued, partial results from authz providers are accepted in that case.';
+COMMENT ON COLUMN perms_sync_requests.follow_up_priority IS 'The priority of the request to enqueue once this request is finished, set when a request was enqueued while this one was being processed.';
+
+-- A request that is enqueued while a request for the same user or repository is
+-- being processed is recorded on the processing request, because the sync may
+-- have read the permissions from the code host before they changed. Once the
+-- processing request is finished, a follow-up request is enqueued with the
+-- recorded priority.
+CREATE OR REPLACE FUNCTION perms_sync_requests_enqueue_follow_up() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO perms_sync_requests (request_type, user_id, repo_id, priority, reason, no_perms, queued_at)
+    VALUES (NEW.request_type, NEW.user_id, NEW.repo_id, NEW.follow_up_priority, NEW.follow_up_reason, NEW.follow_up_no_perms, NOW())
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END $$;
+
+CREATE TRIGGER trig_perms_sync_requests_enqueue_follow_up
+AFTER UPDATE OF state ON perms_sync_requests
+FOR EACH ROW
+WHEN (
+    OLD.state = 'processing' AND
+    NEW.state IN ('completed', 'errored', 'failed') AND
+    NEW.follow_up_priority IS NOT NULL
+)
+EXECUTE FUNCTION perms_sync_requests_enqueue_follow_up();
+
+COMMIT;
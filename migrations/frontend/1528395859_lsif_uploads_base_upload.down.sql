@@ -0,0 +1,31 @@
+BEGIN;
+
+DROP VIEW lsif_uploads_with_repository_name;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+ SELECT u.id,
+    u.commit,
+    u.root,
+    u.uploaded_at,
+    u.state,
+    u.failure_message,
+    u.started_at,
+    u.finished_at,
+    u.repository_id,
+    u.indexer,
+    u.num_parts,
+    u.uploaded_parts,
+    u.process_after,
+    u.num_resets,
+    u.upload_size,
+    u.num_failures,
+    u.associated_index_id,
+    r.name AS repository_name
+   FROM (lsif_uploads u
+     JOIN repo r ON ((r.id = u.repository_id)))
+  WHERE (r.deleted_at IS NULL);
+
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS base_upload_id;
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS changed_paths;
+
+COMMIT;
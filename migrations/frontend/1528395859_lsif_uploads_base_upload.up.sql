@@ -0,0 +1,36 @@
+BEGIN;
+
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS base_upload_id integer;
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS changed_paths text[];
+
+COMMENT ON COLUMN lsif_uploads.base_upload_id IS 'The identifier of the completed upload that this incremental upload patches. Null for uploads containing a full index.';
+COMMENT ON COLUMN lsif_uploads.changed_paths IS 'The root-relative paths of the documents that were added, modified, or deleted since the base upload. Only the data of these documents is read from an incremental upload.';
+
+DROP VIEW lsif_uploads_with_repository_name;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+ SELECT u.id,
+    u.commit,
+    u.root,
+    u.uploaded_at,
+    u.state,
+    u.failure_message,
+    u.started_at,
+    u.finished_at,
+    u.repository_id,
+    u.indexer,
+    u.num_parts,
+    u.uploaded_parts,
+    u.process_after,
+    u.num_resets,
+    u.upload_size,
+    u.num_failures,
+    u.associated_index_id,
+    u.base_upload_id,
+    u.changed_paths,
+    r.name AS repository_name
+   FROM (lsif_uploads u
+     JOIN repo r ON ((r.id = u.repository_id)))
+  WHERE (r.deleted_at IS NULL);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS retained;
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS retention_evaluated_at;
+
+DROP TABLE IF EXISTS lsif_retention_policies;
+
+COMMIT;
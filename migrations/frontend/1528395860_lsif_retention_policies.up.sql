@@ -0,0 +1,30 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_retention_policies (
+    id                       SERIAL PRIMARY KEY,
+    name                     TEXT NOT NULL,
+    repository_pattern       TEXT,
+    type                     TEXT NOT NULL,
+    pattern                  TEXT NOT NULL,
+    retention_duration_hours INTEGER,
+    retain_commit_count      INTEGER,
+    created_at               TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at               TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT lsif_retention_policies_type_check CHECK (type IN ('GIT_BRANCH', 'GIT_TAG'))
+);
+
+COMMENT ON TABLE lsif_retention_policies IS 'Rules that determine how long precise code intelligence uploads are retained.';
+COMMENT ON COLUMN lsif_retention_policies.repository_pattern IS 'A glob matched against repository names. The policy applies to all repositories if null.';
+COMMENT ON COLUMN lsif_retention_policies.type IS 'Whether the pattern matches branches (GIT_BRANCH) or tags (GIT_TAG).';
+COMMENT ON COLUMN lsif_retention_policies.pattern IS 'A glob matched against branch or tag names.';
+COMMENT ON COLUMN lsif_retention_policies.retention_duration_hours IS 'How long uploads for matching commits are retained, measured from the commit date (or the tag creation date). Uploads are retained forever if null.';
+COMMENT ON COLUMN lsif_retention_policies.retain_commit_count IS 'The number of most recent commits of each matching branch whose uploads are retained. All commits of the branch are considered if null. Ignored for tags.';
+
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS retained boolean;
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS retention_evaluated_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN lsif_uploads.retained IS 'Whether a retention policy retains this upload. Null if no retention policy applies to the repository of the upload, in which case the default retention period applies.';
+COMMENT ON COLUMN lsif_uploads.retention_evaluated_at IS 'The last time the retention policies were evaluated against this upload.';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS diagnostics_indexed_at;
+
+DROP TABLE IF EXISTS lsif_diagnostics;
+
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_diagnostics (
+    id              BIGSERIAL PRIMARY KEY,
+    upload_id       INTEGER NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    path            TEXT NOT NULL,
+    severity        INTEGER NOT NULL,
+    code            TEXT NOT NULL,
+    source          TEXT NOT NULL,
+    message         TEXT NOT NULL,
+    start_line      INTEGER NOT NULL,
+    start_character INTEGER NOT NULL,
+    end_line        INTEGER NOT NULL,
+    end_character   INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS lsif_diagnostics_upload_id ON lsif_diagnostics(upload_id);
+
+COMMENT ON TABLE lsif_diagnostics IS 'Diagnostics (such as compiler warnings and lint findings) extracted from completed LSIF uploads.';
+COMMENT ON COLUMN lsif_diagnostics.path IS 'The path of the document containing the diagnostic, relative to the repository root.';
+COMMENT ON COLUMN lsif_diagnostics.severity IS 'The LSP severity of the diagnostic: 1 (error), 2 (warning), 3 (information), or 4 (hint).';
+
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS diagnostics_indexed_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN lsif_uploads.diagnostics_indexed_at IS 'The time the diagnostics of this upload were copied into the lsif_diagnostics table. Null if not yet indexed.';
+
+COMMIT;
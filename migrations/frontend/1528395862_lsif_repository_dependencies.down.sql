@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS lsif_repository_dependencies;
+
+COMMIT;
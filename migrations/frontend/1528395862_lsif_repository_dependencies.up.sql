@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_repository_dependencies (
+    repository_id            INTEGER NOT NULL,
+    dependency_repository_id INTEGER NOT NULL,
+    PRIMARY KEY (repository_id, dependency_repository_id)
+);
+
+CREATE INDEX IF NOT EXISTS lsif_repository_dependencies_dependency_repository_id ON lsif_repository_dependencies(dependency_repository_id);
+
+COMMENT ON TABLE lsif_repository_dependencies IS 'The repository dependency graph derived from the packages provided and referenced by the uploads visible at the tip of the default branch of each repository.';
+COMMENT ON COLUMN lsif_repository_dependencies.repository_id IS 'The identifier of the repository whose uploads reference a package.';
+COMMENT ON COLUMN lsif_repository_dependencies.dependency_repository_id IS 'The identifier of the repository whose uploads provide the referenced package.';
+
+INSERT INTO lsif_repository_dependencies (repository_id, dependency_repository_id)
+SELECT DISTINCT rv.repository_id, pv.repository_id
+FROM lsif_uploads_visible_at_tip rv
+JOIN lsif_references r ON r.dump_id = rv.upload_id
+JOIN lsif_packages p ON p.scheme = r.scheme AND p.name = r.name AND p.version = r.version
+JOIN lsif_uploads_visible_at_tip pv ON pv.upload_id = p.dump_id
+WHERE rv.is_default_branch AND pv.is_default_branch AND rv.repository_id != pv.repository_id
+ON CONFLICT DO NOTHING;
+
+COMMIT;
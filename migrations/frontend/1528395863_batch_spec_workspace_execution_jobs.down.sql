@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP VIEW IF EXISTS batch_spec_executions_pending_assembly;
+DROP VIEW IF EXISTS batch_spec_executions_pending_resolution;
+DROP TABLE IF EXISTS batch_spec_execution_cache_entries;
+DROP TABLE IF EXISTS batch_spec_workspace_execution_jobs;
+ALTER TABLE IF EXISTS batch_spec_executions DROP COLUMN IF EXISTS workspaces_resolved_at;
+
+COMMIT;
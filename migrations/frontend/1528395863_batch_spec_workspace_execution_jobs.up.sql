@@ -0,0 +1,73 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS batch_spec_executions ADD COLUMN IF NOT EXISTS workspaces_resolved_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN batch_spec_executions.workspaces_resolved_at IS 'The time at which the workspaces of the batch spec were resolved and a workspace execution job was enqueued for each of them. Until then, the record is a resolution job for executors; afterwards it is the job that assembles the resulting batch spec.';
+
+CREATE TABLE IF NOT EXISTS batch_spec_workspace_execution_jobs (
+  id                      BIGSERIAL PRIMARY KEY,
+  batch_spec_execution_id BIGINT NOT NULL REFERENCES batch_spec_executions(id) ON DELETE CASCADE DEFERRABLE,
+  repository_id           INTEGER NOT NULL REFERENCES repo(id) DEFERRABLE,
+  branch                  TEXT NOT NULL,
+  commit                  TEXT NOT NULL,
+  path                    TEXT NOT NULL DEFAULT '',
+  only_fetch_workspace    BOOLEAN NOT NULL DEFAULT FALSE,
+  file_matches            TEXT[] NOT NULL DEFAULT '{}',
+  cache_key               TEXT NOT NULL,
+  changeset_spec_rand_ids TEXT[] NOT NULL DEFAULT '{}',
+
+  state             TEXT DEFAULT 'queued',
+  failure_message   TEXT,
+  started_at        TIMESTAMP WITH TIME ZONE,
+  finished_at       TIMESTAMP WITH TIME ZONE,
+  process_after     TIMESTAMP WITH TIME ZONE,
+  num_resets        INTEGER NOT NULL DEFAULT 0,
+  num_failures      INTEGER NOT NULL DEFAULT 0,
+  last_heartbeat_at TIMESTAMP WITH TIME ZONE,
+  execution_logs    JSON[],
+  worker_hostname   TEXT NOT NULL DEFAULT '',
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS batch_spec_workspace_execution_jobs_batch_spec_execution_id ON batch_spec_workspace_execution_jobs(batch_spec_execution_id);
+
+COMMENT ON TABLE batch_spec_workspace_execution_jobs IS 'Executor jobs that each run the steps of a batch spec execution in a single workspace of a repository.';
+COMMENT ON COLUMN batch_spec_workspace_execution_jobs.cache_key IS 'The key under which the execution result of this workspace is cached. It is derived from the repository, commit, workspace, and steps.';
+COMMENT ON COLUMN batch_spec_workspace_execution_jobs.changeset_spec_rand_ids IS 'The random IDs of the changeset specs created by the job.';
+
+CREATE TABLE IF NOT EXISTS batch_spec_execution_cache_entries (
+  id         BIGSERIAL PRIMARY KEY,
+  user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  key        TEXT NOT NULL,
+  value      TEXT NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+  UNIQUE (user_id, key)
+);
+
+COMMENT ON TABLE batch_spec_execution_cache_entries IS 'Cached execution results of batch spec workspaces, scoped to the user that executed them.';
+
+-- Batch spec executions whose workspaces still need to be resolved by an
+-- executor.
+CREATE VIEW batch_spec_executions_pending_resolution AS
+SELECT * FROM batch_spec_executions
+WHERE workspaces_resolved_at IS NULL;
+
+-- Batch spec executions whose workspace execution jobs have all finished, so
+-- that the resulting batch spec can be assembled. Errored jobs that will be
+-- retried are not finished; the retry limit must match
+-- batchSpecWorkspaceExecutionMaxNumRetries.
+CREATE VIEW batch_spec_executions_pending_assembly AS
+SELECT e.* FROM batch_spec_executions e
+WHERE
+  e.workspaces_resolved_at IS NOT NULL AND
+  NOT EXISTS (
+    SELECT 1 FROM batch_spec_workspace_execution_jobs j
+    WHERE
+      j.batch_spec_execution_id = e.id AND
+      (j.state IN ('queued', 'processing') OR (j.state = 'errored' AND j.num_failures < 3))
+  );
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS batch_changes DROP CONSTRAINT IF EXISTS batch_changes_auto_merge_strategy_valid;
+ALTER TABLE IF EXISTS batch_changes DROP COLUMN IF EXISTS auto_merge_strategy;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS batch_changes ADD COLUMN IF NOT EXISTS auto_merge_strategy TEXT;
+
+ALTER TABLE IF EXISTS batch_changes DROP CONSTRAINT IF EXISTS batch_changes_auto_merge_strategy_valid;
+ALTER TABLE IF EXISTS batch_changes ADD CONSTRAINT batch_changes_auto_merge_strategy_valid CHECK (auto_merge_strategy IN ('merge', 'squash'));
+
+COMMENT ON COLUMN batch_changes.auto_merge_strategy IS 'The strategy used to automatically merge the changesets of the batch change once their checks passed and they have been approved. NULL if auto-merge is disabled.';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS changesets DROP COLUMN IF EXISTS needs_reexecution;
+
+COMMIT;
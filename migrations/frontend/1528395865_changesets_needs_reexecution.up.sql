@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS changesets ADD COLUMN IF NOT EXISTS needs_reexecution BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN changesets.needs_reexecution IS 'Set when the changeset conflicts with its base branch and the diff of its current spec no longer applies on top of it, so the batch spec needs to be re-executed.';
+
+COMMIT;
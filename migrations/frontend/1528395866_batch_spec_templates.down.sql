@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS batch_spec_template_versions;
+DROP TABLE IF EXISTS batch_spec_templates;
+
+COMMIT;
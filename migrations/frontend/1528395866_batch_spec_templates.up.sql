@@ -0,0 +1,42 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_spec_templates (
+  id                BIGSERIAL PRIMARY KEY,
+  name              TEXT NOT NULL,
+  description       TEXT NOT NULL DEFAULT '',
+  namespace_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  namespace_org_id  INTEGER REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  creator_id        INTEGER REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+  latest_version    INTEGER NOT NULL DEFAULT 0,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT batch_spec_templates_has_1_namespace CHECK ((namespace_user_id IS NULL) <> (namespace_org_id IS NULL)),
+  CONSTRAINT batch_spec_templates_name_not_blank CHECK (name <> '')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_namespace_user_id_name ON batch_spec_templates(namespace_user_id, name) WHERE namespace_user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_namespace_org_id_name ON batch_spec_templates(namespace_org_id, name) WHERE namespace_org_id IS NOT NULL;
+
+COMMENT ON TABLE batch_spec_templates IS 'Reusable batch specs with typed input parameters, owned by a user or an organization.';
+COMMENT ON COLUMN batch_spec_templates.latest_version IS 'The version number of the newest row in batch_spec_template_versions for the template.';
+
+CREATE TABLE IF NOT EXISTS batch_spec_template_versions (
+  id                      BIGSERIAL PRIMARY KEY,
+  batch_spec_template_id  BIGINT NOT NULL REFERENCES batch_spec_templates(id) ON DELETE CASCADE DEFERRABLE,
+  version                 INTEGER NOT NULL,
+  spec                    TEXT NOT NULL,
+  parameters              JSONB NOT NULL DEFAULT '[]'::jsonb,
+  creator_id              INTEGER REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+  UNIQUE (batch_spec_template_id, version)
+);
+
+COMMENT ON TABLE batch_spec_template_versions IS 'The immutable versions of batch spec templates. Every update of a template creates a new version.';
+COMMENT ON COLUMN batch_spec_template_versions.spec IS 'The batch spec YAML, which references the parameters as ${{ params.<name> }}.';
+COMMENT ON COLUMN batch_spec_template_versions.parameters IS 'The typed input parameters of the template.';
+
+COMMIT;
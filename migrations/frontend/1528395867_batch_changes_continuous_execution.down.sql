@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS batch_change_continuous_runs;
+
+ALTER TABLE IF EXISTS batch_changes DROP CONSTRAINT IF EXISTS batch_changes_continuous_execution_valid;
+ALTER TABLE IF EXISTS batch_changes DROP COLUMN IF EXISTS continuous_apply_policy;
+ALTER TABLE IF EXISTS batch_changes DROP COLUMN IF EXISTS continuous_interval_hours;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS batch_changes ADD COLUMN IF NOT EXISTS continuous_interval_hours INTEGER;
+ALTER TABLE IF EXISTS batch_changes ADD COLUMN IF NOT EXISTS continuous_apply_policy TEXT;
+
+ALTER TABLE IF EXISTS batch_changes DROP CONSTRAINT IF EXISTS batch_changes_continuous_execution_valid;
+ALTER TABLE IF EXISTS batch_changes ADD CONSTRAINT batch_changes_continuous_execution_valid CHECK (
+  (continuous_interval_hours IS NULL AND continuous_apply_policy IS NULL) OR
+  (continuous_interval_hours > 0 AND continuous_apply_policy IN ('none', 'create', 'create_and_update'))
+);
+
+COMMENT ON COLUMN batch_changes.continuous_interval_hours IS 'The number of hours after which the current batch spec is executed again to pick up newly matching repositories. NULL if continuous execution is disabled.';
+COMMENT ON COLUMN batch_changes.continuous_apply_policy IS 'Which results of a continuous execution are applied automatically. NULL if continuous execution is disabled.';
+
+CREATE TABLE IF NOT EXISTS batch_change_continuous_runs (
+  id                      BIGSERIAL PRIMARY KEY,
+  batch_change_id         BIGINT NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+  batch_spec_execution_id BIGINT NOT NULL REFERENCES batch_spec_executions(id) ON DELETE CASCADE DEFERRABLE,
+  state                   TEXT NOT NULL DEFAULT 'executing',
+  message                 TEXT,
+
+  created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  finished_at TIMESTAMP WITH TIME ZONE,
+
+  CONSTRAINT batch_change_continuous_runs_state_valid CHECK (state IN ('executing', 'applied', 'skipped', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS batch_change_continuous_runs_batch_change_id_created_at ON batch_change_continuous_runs(batch_change_id, created_at);
+
+COMMENT ON TABLE batch_change_continuous_runs IS 'Scheduled re-executions of the batch spec of batch changes with continuous execution enabled.';
+COMMENT ON COLUMN batch_change_continuous_runs.message IS 'Why the run was skipped or failed.';
+
+COMMIT;
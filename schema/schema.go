@@ -84,12 +84,13 @@ type AuthProviderCommon struct {
 	DisplayName string `json:"displayName,omitempty"`
 }
 type AuthProviders struct {
-	Builtin       *BuiltinAuthProvider
-	Saml          *SAMLAuthProvider
-	Openidconnect *OpenIDConnectAuthProvider
-	HttpHeader    *HTTPHeaderAuthProvider
-	Github        *GitHubAuthProvider
-	Gitlab        *GitLabAuthProvider
+	Builtin        *BuiltinAuthProvider
+	Saml           *SAMLAuthProvider
+	Openidconnect  *OpenIDConnectAuthProvider
+	HttpHeader     *HTTPHeaderAuthProvider
+	Github         *GitHubAuthProvider
+	Gitlab         *GitLabAuthProvider
+	Bitbucketcloud *BitbucketCloudAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -111,6 +112,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Bitbucketcloud != nil {
+		return json.Marshal(v.Bitbucketcloud)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -121,6 +125,8 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch d.DiscriminantProperty {
+	case "bitbucketcloud":
+		return json.Unmarshal(data, &v.Bitbucketcloud)
 	case "builtin":
 		return json.Unmarshal(data, &v.Builtin)
 	case "github":
@@ -134,7 +140,7 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "bitbucketcloud"})
 }
 
 type BatchChangeRolloutWindow struct {
@@ -168,12 +174,32 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BitbucketCloudAuthProvider description: Configures the Bitbucket Cloud OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create an OAuth consumer in your Bitbucket Cloud workspace settings: https://support.atlassian.com/bitbucket-cloud/docs/use-oauth-on-bitbucket-cloud/. The consumer should have the `account`, `email` and `repository` permissions and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/bitbucketcloud/callback".
+type BitbucketCloudAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via Bitbucket Cloud authentication. If false, users signing in via Bitbucket Cloud must have an existing Sourcegraph account, which will be linked to their Bitbucket Cloud identity after sign-in.
+	AllowSignup bool `json:"allowSignup,omitempty"`
+	// ClientKey description: The Key of the Bitbucket OAuth consumer, accessible from the OAuth consumers page of your workspace settings.
+	ClientKey string `json:"clientKey"`
+	// ClientSecret description: The Secret of the Bitbucket OAuth consumer, accessible from the OAuth consumers page of your workspace settings.
+	ClientSecret string `json:"clientSecret"`
+	DisplayName  string `json:"displayName,omitempty"`
+	Type         string `json:"type"`
+	// Url description: URL of the Bitbucket Cloud instance.
+	Url string `json:"url,omitempty"`
+}
+
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. This requires that there is an item in the `auth.providers` field of type "bitbucketcloud" with the same `url` field as specified in this `BitbucketCloudConnection`. The configured "username" must be an owner of every workspace listed in "teams" so that repository permissions can be read from the Bitbucket Cloud API.
+type BitbucketCloudAuthorization struct {
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. This requires that there is an item in the `auth.providers` field of type "bitbucketcloud" with the same `url` field as specified in this `BitbucketCloudConnection`. The configured "username" must be an owner of every workspace listed in "teams" so that repository permissions can be read from the Bitbucket Cloud API.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -200,6 +226,8 @@ type BitbucketCloudConnection struct {
 	Url string `json:"url"`
 	// Username description: The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding "appPassword" field.
 	Username string `json:"username"`
+	// Webhooks description: An array of webhook configurations
+	Webhooks []*BitbucketCloudWebhook `json:"webhooks,omitempty"`
 }
 
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
@@ -209,6 +237,10 @@ type BitbucketCloudRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+type BitbucketCloudWebhook struct {
+	// Secret description: The secret used to authenticate incoming webhook requests
+	Secret string `json:"secret"`
+}
 
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server repository permissions.
 type BitbucketServerAuthorization struct {
@@ -362,16 +394,30 @@ type BuiltinAuthProvider struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users to assign to the changeset. Supported on GitHub and GitLab.
+	Assignees interface{} `json:"assignees,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// DependsOn description: The changesets of this batch change that have to be merged before the changeset is published, or taken out of draft. Each entry is a repository name ("github.com/my-org/lib"), a repository name and branch ("github.com/my-org/lib@my-branch"), or a branch in the same repository ("@my-branch").
+	DependsOn interface{} `json:"dependsOn,omitempty"`
+	// Labels description: The labels to add to the changeset. The labels must exist on the code host. Supported on GitHub and GitLab.
+	Labels interface{} `json:"labels,omitempty"`
+	// Milestone description: The title of the open milestone to set on the changeset. The milestone must exist on the code host. Supported on GitHub and GitLab.
+	Milestone interface{} `json:"milestone,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// Reviewers description: The usernames of the users to request reviews from. Supported on GitHub, GitLab, and Bitbucket Server.
+	Reviewers interface{} `json:"reviewers,omitempty"`
+	// TeamReviewers description: The slugs of the teams in the repository's organization to request reviews from. Only supported on GitHub.
+	TeamReviewers interface{} `json:"teamReviewers,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
+	// WaitForConflictingChangesets description: Whether to hold back changesets that modify the same files as open changesets of other batch changes in the same repository until those have been merged or closed. Held back changesets are published as drafts on code hosts that support them, and left unpublished on the others.
+	WaitForConflictingChangesets bool `json:"waitForConflictingChangesets,omitempty"`
 }
 
 // CloneURLToRepositoryName description: Describes a mapping from clone URL to repository name. The `from` field contains a regular expression with named capturing groups. The `to` field contains a template string that references capturing group names. For instance, if `from` is "^../(?P<name>\w+)$" and `to` is "github.com/user/{name}", the clone URL "../myRepository" would be mapped to the repository name "github.com/user/myRepository".
@@ -841,6 +887,23 @@ func (v *IdentityProvider) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"oauth", "username", "external"})
 }
 
+// IdentityProviderGroup description: The repositories granted to the members of a group of an identity provider.
+type IdentityProviderGroup struct {
+	// ExternalServices description: The IDs of the code host connections whose repositories the members of the group are granted access to.
+	ExternalServices []int `json:"externalServices,omitempty"`
+	// Group description: The name of the group, as reported by the identity provider.
+	Group string `json:"group"`
+	// Repos description: Regular expressions matching the names of the repositories the members of the group are granted access to.
+	Repos []string `json:"repos,omitempty"`
+}
+
+// IdentityProviderGroupPermissions description: Maps groups of an identity provider to the repositories their members are granted access to.
+type IdentityProviderGroupPermissions struct {
+	// Groups description: The repositories granted to the members of each group. Access granted by groups is added to the access granted by code host permissions.
+	Groups []*IdentityProviderGroup `json:"groups"`
+	// GroupsAttribute description: The name of the SAML assertion attribute or OpenID Connect claim that lists the groups the user is a member of.
+	GroupsAttribute string `json:"groupsAttribute,omitempty"`
+}
 type ImportChangesets struct {
 	// ExternalIDs description: The changesets to import from the code host. For GitHub this is the PR number, for GitLab this is the MR number, for Bitbucket Server this is the PR number.
 	ExternalIDs []interface{} `json:"externalIDs"`
@@ -1077,6 +1140,8 @@ type OpenIDConnectAuthProvider struct {
 	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
 	ConfigID    string `json:"configID,omitempty"`
 	DisplayName string `json:"displayName,omitempty"`
+	// GroupPermissions description: Grants users access to private repositories based on the groups they are a member of on the identity provider. The groups are read from the claim of the ID token or user info when the user signs in, and the repositories they grant access to are evaluated at sign-in and periodically by the permissions syncer.
+	GroupPermissions *IdentityProviderGroupPermissions `json:"groupPermissions,omitempty"`
 	// Issuer description: The URL of the OpenID Connect issuer.
 	//
 	// For Google Apps: https://accounts.google.com
@@ -1208,6 +1273,8 @@ type SAMLAuthProvider struct {
 	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
 	ConfigID    string `json:"configID,omitempty"`
 	DisplayName string `json:"displayName,omitempty"`
+	// GroupPermissions description: Grants users access to private repositories based on the groups they are a member of on the identity provider. The groups are read from the attributes of the SAML assertion when the user signs in, and the repositories they grant access to are evaluated at sign-in and periodically by the permissions syncer.
+	GroupPermissions *IdentityProviderGroupPermissions `json:"groupPermissions,omitempty"`
 	// IdentityProviderMetadata description: The SAML Identity Provider metadata XML contents (for static configuration of the SAML Service Provider). The value of this field should be an XML document whose root element is `<EntityDescriptor>` or `<EntityDescriptors>`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	IdentityProviderMetadata string `json:"identityProviderMetadata,omitempty"`
 	// IdentityProviderMetadataURL description: The SAML Identity Provider metadata URL (for dynamic configuration of the SAML Service Provider).
@@ -1227,6 +1294,12 @@ type SAMLAuthProvider struct {
 	Type         string `json:"type"`
 }
 
+// SCIM description: Enables the SCIM 2.0 endpoint at /.api/scim/v2, which identity providers use to provision and deprovision users and to map their groups onto organizations.
+type SCIM struct {
+	// AuthToken description: The bearer token that the identity provider must send in the Authorization header of SCIM requests.
+	AuthToken string `json:"authToken"`
+}
+
 // SMTPServerConfig description: The SMTP server used to send transactional emails (such as email verifications, reset-password emails, and notifications).
 type SMTPServerConfig struct {
 	// Authentication description: The type of authentication to use for the SMTP server.
@@ -1418,6 +1491,8 @@ type SiteConfiguration struct {
 	AuthProviders []AuthProviders `json:"auth.providers,omitempty"`
 	// AuthPublic description: WARNING: This option has been removed as of 3.8.
 	AuthPublic bool `json:"auth.public,omitempty"`
+	// AuthScim description: Enables the SCIM 2.0 endpoint at /.api/scim/v2, which identity providers use to provision and deprovision users and to map their groups onto organizations.
+	AuthScim *SCIM `json:"auth.scim,omitempty"`
 	// AuthSessionExpiry description: The duration of a user session, after which it expires and the user is required to re-authenticate. The default is 90 days. There is typically no need to set this, but some users may have specific internal security requirements.
 	//
 	// The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). E.g., "720h", "43200m", "2592000s" all indicate a timespan of 30 days.